@@ -0,0 +1,153 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+)
+
+// NewBacktestRuleParams creates a new BacktestRuleParams object
+// with the default values initialized.
+func NewBacktestRuleParams() *BacktestRuleParams {
+	var ()
+	return &BacktestRuleParams{
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewBacktestRuleParamsWithTimeout creates a new BacktestRuleParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewBacktestRuleParamsWithTimeout(timeout time.Duration) *BacktestRuleParams {
+	var ()
+	return &BacktestRuleParams{
+
+		timeout: timeout,
+	}
+}
+
+// NewBacktestRuleParamsWithContext creates a new BacktestRuleParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewBacktestRuleParamsWithContext(ctx context.Context) *BacktestRuleParams {
+	var ()
+	return &BacktestRuleParams{
+
+		Context: ctx,
+	}
+}
+
+// NewBacktestRuleParamsWithHTTPClient creates a new BacktestRuleParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewBacktestRuleParamsWithHTTPClient(client *http.Client) *BacktestRuleParams {
+	var ()
+	return &BacktestRuleParams{
+		HTTPClient: client,
+	}
+}
+
+/*BacktestRuleParams contains all the parameters to send to the API endpoint
+for the backtest rule operation typically these are written to a http.Request
+*/
+type BacktestRuleParams struct {
+
+	/*Body*/
+	Body *models.BacktestRule
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the backtest rule params
+func (o *BacktestRuleParams) WithTimeout(timeout time.Duration) *BacktestRuleParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the backtest rule params
+func (o *BacktestRuleParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the backtest rule params
+func (o *BacktestRuleParams) WithContext(ctx context.Context) *BacktestRuleParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the backtest rule params
+func (o *BacktestRuleParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the backtest rule params
+func (o *BacktestRuleParams) WithHTTPClient(client *http.Client) *BacktestRuleParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the backtest rule params
+func (o *BacktestRuleParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithBody adds the body to the backtest rule params
+func (o *BacktestRuleParams) WithBody(body *models.BacktestRule) *BacktestRuleParams {
+	o.SetBody(body)
+	return o
+}
+
+// SetBody adds the body to the backtest rule params
+func (o *BacktestRuleParams) SetBody(body *models.BacktestRule) {
+	o.Body = body
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *BacktestRuleParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	if o.Body != nil {
+		if err := r.SetBodyParam(o.Body); err != nil {
+			return err
+		}
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+)
+
+// CreateCorrelationRuleReader is a Reader for the CreateCorrelationRule structure.
+type CreateCorrelationRuleReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *CreateCorrelationRuleReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 201:
+		result := NewCreateCorrelationRuleCreated()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewCreateCorrelationRuleBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 409:
+		result := NewCreateCorrelationRuleConflict()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewCreateCorrelationRuleInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewCreateCorrelationRuleCreated creates a CreateCorrelationRuleCreated with default headers values
+func NewCreateCorrelationRuleCreated() *CreateCorrelationRuleCreated {
+	return &CreateCorrelationRuleCreated{}
+}
+
+/*CreateCorrelationRuleCreated handles this case with default header values.
+
+Correlation rule created successfully
+*/
+type CreateCorrelationRuleCreated struct {
+	Payload *models.Rule
+}
+
+func (o *CreateCorrelationRuleCreated) Error() string {
+	return fmt.Sprintf("[POST /correlation][%d] createCorrelationRuleCreated  %+v", 201, o.Payload)
+}
+
+func (o *CreateCorrelationRuleCreated) GetPayload() *models.Rule {
+	return o.Payload
+}
+
+func (o *CreateCorrelationRuleCreated) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Rule)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewCreateCorrelationRuleBadRequest creates a CreateCorrelationRuleBadRequest with default headers values
+func NewCreateCorrelationRuleBadRequest() *CreateCorrelationRuleBadRequest {
+	return &CreateCorrelationRuleBadRequest{}
+}
+
+/*CreateCorrelationRuleBadRequest handles this case with default header values.
+
+Bad request
+*/
+type CreateCorrelationRuleBadRequest struct {
+	Payload *models.Error
+}
+
+func (o *CreateCorrelationRuleBadRequest) Error() string {
+	return fmt.Sprintf("[POST /correlation][%d] createCorrelationRuleBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *CreateCorrelationRuleBadRequest) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *CreateCorrelationRuleBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewCreateCorrelationRuleConflict creates a CreateCorrelationRuleConflict with default headers values
+func NewCreateCorrelationRuleConflict() *CreateCorrelationRuleConflict {
+	return &CreateCorrelationRuleConflict{}
+}
+
+/*CreateCorrelationRuleConflict handles this case with default header values.
+
+Analysis with the given ID already exists
+*/
+type CreateCorrelationRuleConflict struct {
+}
+
+func (o *CreateCorrelationRuleConflict) Error() string {
+	return fmt.Sprintf("[POST /correlation][%d] createCorrelationRuleConflict ", 409)
+}
+
+func (o *CreateCorrelationRuleConflict) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// NewCreateCorrelationRuleInternalServerError creates a CreateCorrelationRuleInternalServerError with default headers values
+func NewCreateCorrelationRuleInternalServerError() *CreateCorrelationRuleInternalServerError {
+	return &CreateCorrelationRuleInternalServerError{}
+}
+
+/*CreateCorrelationRuleInternalServerError handles this case with default header values.
+
+Internal server error
+*/
+type CreateCorrelationRuleInternalServerError struct {
+}
+
+func (o *CreateCorrelationRuleInternalServerError) Error() string {
+	return fmt.Sprintf("[POST /correlation][%d] createCorrelationRuleInternalServerError ", 500)
+}
+
+func (o *CreateCorrelationRuleInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
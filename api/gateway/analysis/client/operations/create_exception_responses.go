@@ -0,0 +1,180 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+)
+
+// CreateExceptionReader is a Reader for the CreateException structure.
+type CreateExceptionReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *CreateExceptionReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 201:
+		result := NewCreateExceptionCreated()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewCreateExceptionBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 404:
+		result := NewCreateExceptionNotFound()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewCreateExceptionInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewCreateExceptionCreated creates a CreateExceptionCreated with default headers values
+func NewCreateExceptionCreated() *CreateExceptionCreated {
+	return &CreateExceptionCreated{}
+}
+
+/*CreateExceptionCreated handles this case with default header values.
+
+OK
+*/
+type CreateExceptionCreated struct {
+	Payload *models.PolicyException
+}
+
+func (o *CreateExceptionCreated) Error() string {
+	return fmt.Sprintf("[POST /exception][%d] createExceptionCreated  %+v", 201, o.Payload)
+}
+
+func (o *CreateExceptionCreated) GetPayload() *models.PolicyException {
+	return o.Payload
+}
+
+func (o *CreateExceptionCreated) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.PolicyException)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewCreateExceptionBadRequest creates a CreateExceptionBadRequest with default headers values
+func NewCreateExceptionBadRequest() *CreateExceptionBadRequest {
+	return &CreateExceptionBadRequest{}
+}
+
+/*CreateExceptionBadRequest handles this case with default header values.
+
+Bad request
+*/
+type CreateExceptionBadRequest struct {
+	Payload *models.Error
+}
+
+func (o *CreateExceptionBadRequest) Error() string {
+	return fmt.Sprintf("[POST /exception][%d] createExceptionBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *CreateExceptionBadRequest) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *CreateExceptionBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewCreateExceptionNotFound creates a CreateExceptionNotFound with default headers values
+func NewCreateExceptionNotFound() *CreateExceptionNotFound {
+	return &CreateExceptionNotFound{}
+}
+
+/*CreateExceptionNotFound handles this case with default header values.
+
+Policy not found
+*/
+type CreateExceptionNotFound struct {
+}
+
+func (o *CreateExceptionNotFound) Error() string {
+	return fmt.Sprintf("[POST /exception][%d] createExceptionNotFound ", 404)
+}
+
+func (o *CreateExceptionNotFound) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// NewCreateExceptionInternalServerError creates a CreateExceptionInternalServerError with default headers values
+func NewCreateExceptionInternalServerError() *CreateExceptionInternalServerError {
+	return &CreateExceptionInternalServerError{}
+}
+
+/*CreateExceptionInternalServerError handles this case with default header values.
+
+Internal server error
+*/
+type CreateExceptionInternalServerError struct {
+}
+
+func (o *CreateExceptionInternalServerError) Error() string {
+	return fmt.Sprintf("[POST /exception][%d] createExceptionInternalServerError ", 500)
+}
+
+func (o *CreateExceptionInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
@@ -0,0 +1,180 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+)
+
+// CreateScheduledQueryReader is a Reader for the CreateScheduledQuery structure.
+type CreateScheduledQueryReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *CreateScheduledQueryReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 201:
+		result := NewCreateScheduledQueryCreated()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewCreateScheduledQueryBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 409:
+		result := NewCreateScheduledQueryConflict()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewCreateScheduledQueryInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewCreateScheduledQueryCreated creates a CreateScheduledQueryCreated with default headers values
+func NewCreateScheduledQueryCreated() *CreateScheduledQueryCreated {
+	return &CreateScheduledQueryCreated{}
+}
+
+/*CreateScheduledQueryCreated handles this case with default header values.
+
+Scheduled query created successfully
+*/
+type CreateScheduledQueryCreated struct {
+	Payload *models.Rule
+}
+
+func (o *CreateScheduledQueryCreated) Error() string {
+	return fmt.Sprintf("[POST /query][%d] createScheduledQueryCreated  %+v", 201, o.Payload)
+}
+
+func (o *CreateScheduledQueryCreated) GetPayload() *models.Rule {
+	return o.Payload
+}
+
+func (o *CreateScheduledQueryCreated) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Rule)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewCreateScheduledQueryBadRequest creates a CreateScheduledQueryBadRequest with default headers values
+func NewCreateScheduledQueryBadRequest() *CreateScheduledQueryBadRequest {
+	return &CreateScheduledQueryBadRequest{}
+}
+
+/*CreateScheduledQueryBadRequest handles this case with default header values.
+
+Bad request
+*/
+type CreateScheduledQueryBadRequest struct {
+	Payload *models.Error
+}
+
+func (o *CreateScheduledQueryBadRequest) Error() string {
+	return fmt.Sprintf("[POST /query][%d] createScheduledQueryBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *CreateScheduledQueryBadRequest) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *CreateScheduledQueryBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewCreateScheduledQueryConflict creates a CreateScheduledQueryConflict with default headers values
+func NewCreateScheduledQueryConflict() *CreateScheduledQueryConflict {
+	return &CreateScheduledQueryConflict{}
+}
+
+/*CreateScheduledQueryConflict handles this case with default header values.
+
+Analysis with the given ID already exists
+*/
+type CreateScheduledQueryConflict struct {
+}
+
+func (o *CreateScheduledQueryConflict) Error() string {
+	return fmt.Sprintf("[POST /query][%d] createScheduledQueryConflict ", 409)
+}
+
+func (o *CreateScheduledQueryConflict) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// NewCreateScheduledQueryInternalServerError creates a CreateScheduledQueryInternalServerError with default headers values
+func NewCreateScheduledQueryInternalServerError() *CreateScheduledQueryInternalServerError {
+	return &CreateScheduledQueryInternalServerError{}
+}
+
+/*CreateScheduledQueryInternalServerError handles this case with default header values.
+
+Internal server error
+*/
+type CreateScheduledQueryInternalServerError struct {
+}
+
+func (o *CreateScheduledQueryInternalServerError) Error() string {
+	return fmt.Sprintf("[POST /query][%d] createScheduledQueryInternalServerError ", 500)
+}
+
+func (o *CreateScheduledQueryInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
@@ -0,0 +1,141 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+)
+
+// DeleteExceptionsReader is a Reader for the DeleteExceptions structure.
+type DeleteExceptionsReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *DeleteExceptionsReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewDeleteExceptionsOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewDeleteExceptionsBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewDeleteExceptionsInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewDeleteExceptionsOK creates a DeleteExceptionsOK with default headers values
+func NewDeleteExceptionsOK() *DeleteExceptionsOK {
+	return &DeleteExceptionsOK{}
+}
+
+/*DeleteExceptionsOK handles this case with default header values.
+
+OK
+*/
+type DeleteExceptionsOK struct {
+}
+
+func (o *DeleteExceptionsOK) Error() string {
+	return fmt.Sprintf("[POST /exception/delete][%d] deleteExceptionsOK ", 200)
+}
+
+func (o *DeleteExceptionsOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// NewDeleteExceptionsBadRequest creates a DeleteExceptionsBadRequest with default headers values
+func NewDeleteExceptionsBadRequest() *DeleteExceptionsBadRequest {
+	return &DeleteExceptionsBadRequest{}
+}
+
+/*DeleteExceptionsBadRequest handles this case with default header values.
+
+Bad request
+*/
+type DeleteExceptionsBadRequest struct {
+	Payload *models.Error
+}
+
+func (o *DeleteExceptionsBadRequest) Error() string {
+	return fmt.Sprintf("[POST /exception/delete][%d] deleteExceptionsBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *DeleteExceptionsBadRequest) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *DeleteExceptionsBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewDeleteExceptionsInternalServerError creates a DeleteExceptionsInternalServerError with default headers values
+func NewDeleteExceptionsInternalServerError() *DeleteExceptionsInternalServerError {
+	return &DeleteExceptionsInternalServerError{}
+}
+
+/*DeleteExceptionsInternalServerError handles this case with default header values.
+
+Internal server error
+*/
+type DeleteExceptionsInternalServerError struct {
+}
+
+func (o *DeleteExceptionsInternalServerError) Error() string {
+	return fmt.Sprintf("[POST /exception/delete][%d] deleteExceptionsInternalServerError ", 500)
+}
+
+func (o *DeleteExceptionsInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
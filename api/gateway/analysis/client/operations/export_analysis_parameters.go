@@ -0,0 +1,237 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// NewExportAnalysisParams creates a new ExportAnalysisParams object
+// with the default values initialized.
+func NewExportAnalysisParams() *ExportAnalysisParams {
+	var ()
+	return &ExportAnalysisParams{
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewExportAnalysisParamsWithTimeout creates a new ExportAnalysisParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewExportAnalysisParamsWithTimeout(timeout time.Duration) *ExportAnalysisParams {
+	var ()
+	return &ExportAnalysisParams{
+
+		timeout: timeout,
+	}
+}
+
+// NewExportAnalysisParamsWithContext creates a new ExportAnalysisParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewExportAnalysisParamsWithContext(ctx context.Context) *ExportAnalysisParams {
+	var ()
+	return &ExportAnalysisParams{
+
+		Context: ctx,
+	}
+}
+
+// NewExportAnalysisParamsWithHTTPClient creates a new ExportAnalysisParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewExportAnalysisParamsWithHTTPClient(client *http.Client) *ExportAnalysisParams {
+	var ()
+	return &ExportAnalysisParams{
+		HTTPClient: client,
+	}
+}
+
+/*ExportAnalysisParams contains all the parameters to send to the API endpoint
+for the export analysis operation typically these are written to a http.Request
+*/
+type ExportAnalysisParams struct {
+
+	/*Enabled
+	  Only include policies and rules which are enabled or disabled
+
+	*/
+	Enabled *bool
+	/*ResourceTypes
+	  Only include policies and rules which apply to one of these resource or log types
+
+	*/
+	ResourceTypes []string
+	/*Tags
+	  Only include items with all of these tags (case-insensitive)
+
+	*/
+	Tags []string
+	/*Types
+	  Only include these types of analysis (default all)
+
+	*/
+	Types []string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the export analysis params
+func (o *ExportAnalysisParams) WithTimeout(timeout time.Duration) *ExportAnalysisParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the export analysis params
+func (o *ExportAnalysisParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the export analysis params
+func (o *ExportAnalysisParams) WithContext(ctx context.Context) *ExportAnalysisParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the export analysis params
+func (o *ExportAnalysisParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the export analysis params
+func (o *ExportAnalysisParams) WithHTTPClient(client *http.Client) *ExportAnalysisParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the export analysis params
+func (o *ExportAnalysisParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithEnabled adds the enabled to the export analysis params
+func (o *ExportAnalysisParams) WithEnabled(enabled *bool) *ExportAnalysisParams {
+	o.SetEnabled(enabled)
+	return o
+}
+
+// SetEnabled adds the enabled to the export analysis params
+func (o *ExportAnalysisParams) SetEnabled(enabled *bool) {
+	o.Enabled = enabled
+}
+
+// WithResourceTypes adds the resourceTypes to the export analysis params
+func (o *ExportAnalysisParams) WithResourceTypes(resourceTypes []string) *ExportAnalysisParams {
+	o.SetResourceTypes(resourceTypes)
+	return o
+}
+
+// SetResourceTypes adds the resourceTypes to the export analysis params
+func (o *ExportAnalysisParams) SetResourceTypes(resourceTypes []string) {
+	o.ResourceTypes = resourceTypes
+}
+
+// WithTags adds the tags to the export analysis params
+func (o *ExportAnalysisParams) WithTags(tags []string) *ExportAnalysisParams {
+	o.SetTags(tags)
+	return o
+}
+
+// SetTags adds the tags to the export analysis params
+func (o *ExportAnalysisParams) SetTags(tags []string) {
+	o.Tags = tags
+}
+
+// WithTypes adds the types to the export analysis params
+func (o *ExportAnalysisParams) WithTypes(types []string) *ExportAnalysisParams {
+	o.SetTypes(types)
+	return o
+}
+
+// SetTypes adds the types to the export analysis params
+func (o *ExportAnalysisParams) SetTypes(types []string) {
+	o.Types = types
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *ExportAnalysisParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	if o.Enabled != nil {
+
+		// query param enabled
+		var qrEnabled bool
+		if o.Enabled != nil {
+			qrEnabled = *o.Enabled
+		}
+		qEnabled := swag.FormatBool(qrEnabled)
+		if qEnabled != "" {
+			if err := r.SetQueryParam("enabled", qEnabled); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	valuesResourceTypes := o.ResourceTypes
+
+	joinedResourceTypes := swag.JoinByFormat(valuesResourceTypes, "csv")
+	// query array param resourceTypes
+	if err := r.SetQueryParam("resourceTypes", joinedResourceTypes...); err != nil {
+		return err
+	}
+
+	valuesTags := o.Tags
+
+	joinedTags := swag.JoinByFormat(valuesTags, "csv")
+	// query array param tags
+	if err := r.SetQueryParam("tags", joinedTags...); err != nil {
+		return err
+	}
+
+	valuesTypes := o.Types
+
+	joinedTypes := swag.JoinByFormat(valuesTypes, "csv")
+	// query array param types
+	if err := r.SetQueryParam("types", joinedTypes...); err != nil {
+		return err
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
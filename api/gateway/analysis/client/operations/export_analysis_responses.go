@@ -0,0 +1,153 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+)
+
+// ExportAnalysisReader is a Reader for the ExportAnalysis structure.
+type ExportAnalysisReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *ExportAnalysisReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewExportAnalysisOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewExportAnalysisBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewExportAnalysisInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewExportAnalysisOK creates a ExportAnalysisOK with default headers values
+func NewExportAnalysisOK() *ExportAnalysisOK {
+	return &ExportAnalysisOK{}
+}
+
+/*ExportAnalysisOK handles this case with default header values.
+
+OK
+*/
+type ExportAnalysisOK struct {
+	Payload *models.ExportResult
+}
+
+func (o *ExportAnalysisOK) Error() string {
+	return fmt.Sprintf("[GET /export][%d] exportAnalysisOK  %+v", 200, o.Payload)
+}
+
+func (o *ExportAnalysisOK) GetPayload() *models.ExportResult {
+	return o.Payload
+}
+
+func (o *ExportAnalysisOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.ExportResult)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewExportAnalysisBadRequest creates a ExportAnalysisBadRequest with default headers values
+func NewExportAnalysisBadRequest() *ExportAnalysisBadRequest {
+	return &ExportAnalysisBadRequest{}
+}
+
+/*ExportAnalysisBadRequest handles this case with default header values.
+
+Bad request
+*/
+type ExportAnalysisBadRequest struct {
+	Payload *models.Error
+}
+
+func (o *ExportAnalysisBadRequest) Error() string {
+	return fmt.Sprintf("[GET /export][%d] exportAnalysisBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *ExportAnalysisBadRequest) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *ExportAnalysisBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewExportAnalysisInternalServerError creates a ExportAnalysisInternalServerError with default headers values
+func NewExportAnalysisInternalServerError() *ExportAnalysisInternalServerError {
+	return &ExportAnalysisInternalServerError{}
+}
+
+/*ExportAnalysisInternalServerError handles this case with default header values.
+
+Internal server error
+*/
+type ExportAnalysisInternalServerError struct {
+}
+
+func (o *ExportAnalysisInternalServerError) Error() string {
+	return fmt.Sprintf("[GET /export][%d] exportAnalysisInternalServerError ", 500)
+}
+
+func (o *ExportAnalysisInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
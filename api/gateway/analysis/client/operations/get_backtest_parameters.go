@@ -0,0 +1,157 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// NewGetBacktestParams creates a new GetBacktestParams object
+// with the default values initialized.
+func NewGetBacktestParams() *GetBacktestParams {
+	var ()
+	return &GetBacktestParams{
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewGetBacktestParamsWithTimeout creates a new GetBacktestParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewGetBacktestParamsWithTimeout(timeout time.Duration) *GetBacktestParams {
+	var ()
+	return &GetBacktestParams{
+
+		timeout: timeout,
+	}
+}
+
+// NewGetBacktestParamsWithContext creates a new GetBacktestParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewGetBacktestParamsWithContext(ctx context.Context) *GetBacktestParams {
+	var ()
+	return &GetBacktestParams{
+
+		Context: ctx,
+	}
+}
+
+// NewGetBacktestParamsWithHTTPClient creates a new GetBacktestParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewGetBacktestParamsWithHTTPClient(client *http.Client) *GetBacktestParams {
+	var ()
+	return &GetBacktestParams{
+		HTTPClient: client,
+	}
+}
+
+/*GetBacktestParams contains all the parameters to send to the API endpoint
+for the get backtest operation typically these are written to a http.Request
+*/
+type GetBacktestParams struct {
+
+	/*BacktestID
+	  The backtestId returned when the backtest was started
+
+	*/
+	BacktestID string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the get backtest params
+func (o *GetBacktestParams) WithTimeout(timeout time.Duration) *GetBacktestParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the get backtest params
+func (o *GetBacktestParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the get backtest params
+func (o *GetBacktestParams) WithContext(ctx context.Context) *GetBacktestParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the get backtest params
+func (o *GetBacktestParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the get backtest params
+func (o *GetBacktestParams) WithHTTPClient(client *http.Client) *GetBacktestParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the get backtest params
+func (o *GetBacktestParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithBacktestID adds the backtestID to the get backtest params
+func (o *GetBacktestParams) WithBacktestID(backtestID string) *GetBacktestParams {
+	o.SetBacktestID(backtestID)
+	return o
+}
+
+// SetBacktestID adds the backtestId to the get backtest params
+func (o *GetBacktestParams) SetBacktestID(backtestID string) {
+	o.BacktestID = backtestID
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *GetBacktestParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	// query param backtestId
+	qrBacktestID := o.BacktestID
+	qBacktestID := qrBacktestID
+	if qBacktestID != "" {
+		if err := r.SetQueryParam("backtestId", qBacktestID); err != nil {
+			return err
+		}
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+)
+
+// GetBacktestReader is a Reader for the GetBacktest structure.
+type GetBacktestReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *GetBacktestReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewGetBacktestOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewGetBacktestBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 404:
+		result := NewGetBacktestNotFound()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewGetBacktestInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewGetBacktestOK creates a GetBacktestOK with default headers values
+func NewGetBacktestOK() *GetBacktestOK {
+	return &GetBacktestOK{}
+}
+
+/*GetBacktestOK handles this case with default header values.
+
+OK
+*/
+type GetBacktestOK struct {
+	Payload *models.BacktestResult
+}
+
+func (o *GetBacktestOK) Error() string {
+	return fmt.Sprintf("[GET /rule/backtest][%d] getBacktestOK  %+v", 200, o.Payload)
+}
+
+func (o *GetBacktestOK) GetPayload() *models.BacktestResult {
+	return o.Payload
+}
+
+func (o *GetBacktestOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.BacktestResult)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewGetBacktestBadRequest creates a GetBacktestBadRequest with default headers values
+func NewGetBacktestBadRequest() *GetBacktestBadRequest {
+	return &GetBacktestBadRequest{}
+}
+
+/*GetBacktestBadRequest handles this case with default header values.
+
+Bad request
+*/
+type GetBacktestBadRequest struct {
+	Payload *models.Error
+}
+
+func (o *GetBacktestBadRequest) Error() string {
+	return fmt.Sprintf("[GET /rule/backtest][%d] getBacktestBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *GetBacktestBadRequest) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *GetBacktestBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewGetBacktestNotFound creates a GetBacktestNotFound with default headers values
+func NewGetBacktestNotFound() *GetBacktestNotFound {
+	return &GetBacktestNotFound{}
+}
+
+/*GetBacktestNotFound handles this case with default header values.
+
+Backtest does not exist (or it has expired)
+*/
+type GetBacktestNotFound struct {
+}
+
+func (o *GetBacktestNotFound) Error() string {
+	return fmt.Sprintf("[GET /rule/backtest][%d] getBacktestNotFound ", 404)
+}
+
+func (o *GetBacktestNotFound) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// NewGetBacktestInternalServerError creates a GetBacktestInternalServerError with default headers values
+func NewGetBacktestInternalServerError() *GetBacktestInternalServerError {
+	return &GetBacktestInternalServerError{}
+}
+
+/*GetBacktestInternalServerError handles this case with default header values.
+
+Internal server error
+*/
+type GetBacktestInternalServerError struct {
+}
+
+func (o *GetBacktestInternalServerError) Error() string {
+	return fmt.Sprintf("[GET /rule/backtest][%d] getBacktestInternalServerError ", 500)
+}
+
+func (o *GetBacktestInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
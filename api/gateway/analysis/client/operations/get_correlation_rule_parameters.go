@@ -0,0 +1,189 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// NewGetCorrelationRuleParams creates a new GetCorrelationRuleParams object
+// with the default values initialized.
+func NewGetCorrelationRuleParams() *GetCorrelationRuleParams {
+	var ()
+	return &GetCorrelationRuleParams{
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewGetCorrelationRuleParamsWithTimeout creates a new GetCorrelationRuleParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewGetCorrelationRuleParamsWithTimeout(timeout time.Duration) *GetCorrelationRuleParams {
+	var ()
+	return &GetCorrelationRuleParams{
+
+		timeout: timeout,
+	}
+}
+
+// NewGetCorrelationRuleParamsWithContext creates a new GetCorrelationRuleParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewGetCorrelationRuleParamsWithContext(ctx context.Context) *GetCorrelationRuleParams {
+	var ()
+	return &GetCorrelationRuleParams{
+
+		Context: ctx,
+	}
+}
+
+// NewGetCorrelationRuleParamsWithHTTPClient creates a new GetCorrelationRuleParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewGetCorrelationRuleParamsWithHTTPClient(client *http.Client) *GetCorrelationRuleParams {
+	var ()
+	return &GetCorrelationRuleParams{
+		HTTPClient: client,
+	}
+}
+
+/*GetCorrelationRuleParams contains all the parameters to send to the API endpoint
+for the get correlation rule operation typically these are written to a http.Request
+*/
+type GetCorrelationRuleParams struct {
+
+	/*CorrelationID
+	  Unique ASCII correlation rule identifier
+
+	*/
+	CorrelationID string
+	/*VersionID
+	  The version of the analysis to retrieve
+
+	*/
+	VersionID *string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the get correlation rule params
+func (o *GetCorrelationRuleParams) WithTimeout(timeout time.Duration) *GetCorrelationRuleParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the get correlation rule params
+func (o *GetCorrelationRuleParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the get correlation rule params
+func (o *GetCorrelationRuleParams) WithContext(ctx context.Context) *GetCorrelationRuleParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the get correlation rule params
+func (o *GetCorrelationRuleParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the get correlation rule params
+func (o *GetCorrelationRuleParams) WithHTTPClient(client *http.Client) *GetCorrelationRuleParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the get correlation rule params
+func (o *GetCorrelationRuleParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithCorrelationID adds the correlationID to the get correlation rule params
+func (o *GetCorrelationRuleParams) WithCorrelationID(correlationID string) *GetCorrelationRuleParams {
+	o.SetCorrelationID(correlationID)
+	return o
+}
+
+// SetCorrelationID adds the correlationId to the get correlation rule params
+func (o *GetCorrelationRuleParams) SetCorrelationID(correlationID string) {
+	o.CorrelationID = correlationID
+}
+
+// WithVersionID adds the versionID to the get correlation rule params
+func (o *GetCorrelationRuleParams) WithVersionID(versionID *string) *GetCorrelationRuleParams {
+	o.SetVersionID(versionID)
+	return o
+}
+
+// SetVersionID adds the versionId to the get correlation rule params
+func (o *GetCorrelationRuleParams) SetVersionID(versionID *string) {
+	o.VersionID = versionID
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *GetCorrelationRuleParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	// query param correlationId
+	qrCorrelationID := o.CorrelationID
+	qCorrelationID := qrCorrelationID
+	if qCorrelationID != "" {
+		if err := r.SetQueryParam("correlationId", qCorrelationID); err != nil {
+			return err
+		}
+	}
+
+	if o.VersionID != nil {
+
+		// query param versionId
+		var qrVersionID string
+		if o.VersionID != nil {
+			qrVersionID = *o.VersionID
+		}
+		qVersionID := qrVersionID
+		if qVersionID != "" {
+			if err := r.SetQueryParam("versionId", qVersionID); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
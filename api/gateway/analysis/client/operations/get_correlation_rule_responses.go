@@ -0,0 +1,180 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+)
+
+// GetCorrelationRuleReader is a Reader for the GetCorrelationRule structure.
+type GetCorrelationRuleReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *GetCorrelationRuleReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewGetCorrelationRuleOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewGetCorrelationRuleBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 404:
+		result := NewGetCorrelationRuleNotFound()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewGetCorrelationRuleInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewGetCorrelationRuleOK creates a GetCorrelationRuleOK with default headers values
+func NewGetCorrelationRuleOK() *GetCorrelationRuleOK {
+	return &GetCorrelationRuleOK{}
+}
+
+/*GetCorrelationRuleOK handles this case with default header values.
+
+OK
+*/
+type GetCorrelationRuleOK struct {
+	Payload *models.Rule
+}
+
+func (o *GetCorrelationRuleOK) Error() string {
+	return fmt.Sprintf("[GET /correlation][%d] getCorrelationRuleOK  %+v", 200, o.Payload)
+}
+
+func (o *GetCorrelationRuleOK) GetPayload() *models.Rule {
+	return o.Payload
+}
+
+func (o *GetCorrelationRuleOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Rule)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewGetCorrelationRuleBadRequest creates a GetCorrelationRuleBadRequest with default headers values
+func NewGetCorrelationRuleBadRequest() *GetCorrelationRuleBadRequest {
+	return &GetCorrelationRuleBadRequest{}
+}
+
+/*GetCorrelationRuleBadRequest handles this case with default header values.
+
+Bad request
+*/
+type GetCorrelationRuleBadRequest struct {
+	Payload *models.Error
+}
+
+func (o *GetCorrelationRuleBadRequest) Error() string {
+	return fmt.Sprintf("[GET /correlation][%d] getCorrelationRuleBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *GetCorrelationRuleBadRequest) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *GetCorrelationRuleBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewGetCorrelationRuleNotFound creates a GetCorrelationRuleNotFound with default headers values
+func NewGetCorrelationRuleNotFound() *GetCorrelationRuleNotFound {
+	return &GetCorrelationRuleNotFound{}
+}
+
+/*GetCorrelationRuleNotFound handles this case with default header values.
+
+Correlation rule does not exist
+*/
+type GetCorrelationRuleNotFound struct {
+}
+
+func (o *GetCorrelationRuleNotFound) Error() string {
+	return fmt.Sprintf("[GET /correlation][%d] getCorrelationRuleNotFound ", 404)
+}
+
+func (o *GetCorrelationRuleNotFound) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// NewGetCorrelationRuleInternalServerError creates a GetCorrelationRuleInternalServerError with default headers values
+func NewGetCorrelationRuleInternalServerError() *GetCorrelationRuleInternalServerError {
+	return &GetCorrelationRuleInternalServerError{}
+}
+
+/*GetCorrelationRuleInternalServerError handles this case with default header values.
+
+Internal server error
+*/
+type GetCorrelationRuleInternalServerError struct {
+}
+
+func (o *GetCorrelationRuleInternalServerError) Error() string {
+	return fmt.Sprintf("[GET /correlation][%d] getCorrelationRuleInternalServerError ", 500)
+}
+
+func (o *GetCorrelationRuleInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
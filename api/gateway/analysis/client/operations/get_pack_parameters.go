@@ -0,0 +1,157 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// NewGetPackParams creates a new GetPackParams object
+// with the default values initialized.
+func NewGetPackParams() *GetPackParams {
+	var ()
+	return &GetPackParams{
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewGetPackParamsWithTimeout creates a new GetPackParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewGetPackParamsWithTimeout(timeout time.Duration) *GetPackParams {
+	var ()
+	return &GetPackParams{
+
+		timeout: timeout,
+	}
+}
+
+// NewGetPackParamsWithContext creates a new GetPackParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewGetPackParamsWithContext(ctx context.Context) *GetPackParams {
+	var ()
+	return &GetPackParams{
+
+		Context: ctx,
+	}
+}
+
+// NewGetPackParamsWithHTTPClient creates a new GetPackParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewGetPackParamsWithHTTPClient(client *http.Client) *GetPackParams {
+	var ()
+	return &GetPackParams{
+		HTTPClient: client,
+	}
+}
+
+/*GetPackParams contains all the parameters to send to the API endpoint
+for the get pack operation typically these are written to a http.Request
+*/
+type GetPackParams struct {
+
+	/*PackID
+	  Unique ASCII analysis pack identifier
+
+	*/
+	PackID string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the get pack params
+func (o *GetPackParams) WithTimeout(timeout time.Duration) *GetPackParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the get pack params
+func (o *GetPackParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the get pack params
+func (o *GetPackParams) WithContext(ctx context.Context) *GetPackParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the get pack params
+func (o *GetPackParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the get pack params
+func (o *GetPackParams) WithHTTPClient(client *http.Client) *GetPackParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the get pack params
+func (o *GetPackParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithPackID adds the packID to the get pack params
+func (o *GetPackParams) WithPackID(packID string) *GetPackParams {
+	o.SetPackID(packID)
+	return o
+}
+
+// SetPackID adds the packId to the get pack params
+func (o *GetPackParams) SetPackID(packID string) {
+	o.PackID = packID
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *GetPackParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	// query param packId
+	qrPackID := o.PackID
+	qPackID := qrPackID
+	if qPackID != "" {
+		if err := r.SetQueryParam("packId", qPackID); err != nil {
+			return err
+		}
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+)
+
+// GetPackReader is a Reader for the GetPack structure.
+type GetPackReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *GetPackReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewGetPackOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewGetPackBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 404:
+		result := NewGetPackNotFound()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewGetPackInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewGetPackOK creates a GetPackOK with default headers values
+func NewGetPackOK() *GetPackOK {
+	return &GetPackOK{}
+}
+
+/*GetPackOK handles this case with default header values.
+
+OK
+*/
+type GetPackOK struct {
+	Payload *models.Pack
+}
+
+func (o *GetPackOK) Error() string {
+	return fmt.Sprintf("[GET /pack][%d] getPackOK  %+v", 200, o.Payload)
+}
+
+func (o *GetPackOK) GetPayload() *models.Pack {
+	return o.Payload
+}
+
+func (o *GetPackOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Pack)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewGetPackBadRequest creates a GetPackBadRequest with default headers values
+func NewGetPackBadRequest() *GetPackBadRequest {
+	return &GetPackBadRequest{}
+}
+
+/*GetPackBadRequest handles this case with default header values.
+
+Bad request
+*/
+type GetPackBadRequest struct {
+	Payload *models.Error
+}
+
+func (o *GetPackBadRequest) Error() string {
+	return fmt.Sprintf("[GET /pack][%d] getPackBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *GetPackBadRequest) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *GetPackBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewGetPackNotFound creates a GetPackNotFound with default headers values
+func NewGetPackNotFound() *GetPackNotFound {
+	return &GetPackNotFound{}
+}
+
+/*GetPackNotFound handles this case with default header values.
+
+Pack not found
+*/
+type GetPackNotFound struct {
+}
+
+func (o *GetPackNotFound) Error() string {
+	return fmt.Sprintf("[GET /pack][%d] getPackNotFound ", 404)
+}
+
+func (o *GetPackNotFound) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// NewGetPackInternalServerError creates a GetPackInternalServerError with default headers values
+func NewGetPackInternalServerError() *GetPackInternalServerError {
+	return &GetPackInternalServerError{}
+}
+
+/*GetPackInternalServerError handles this case with default header values.
+
+Internal server error
+*/
+type GetPackInternalServerError struct {
+}
+
+func (o *GetPackInternalServerError) Error() string {
+	return fmt.Sprintf("[GET /pack][%d] getPackInternalServerError ", 500)
+}
+
+func (o *GetPackInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
@@ -0,0 +1,189 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// NewGetScheduledQueryParams creates a new GetScheduledQueryParams object
+// with the default values initialized.
+func NewGetScheduledQueryParams() *GetScheduledQueryParams {
+	var ()
+	return &GetScheduledQueryParams{
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewGetScheduledQueryParamsWithTimeout creates a new GetScheduledQueryParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewGetScheduledQueryParamsWithTimeout(timeout time.Duration) *GetScheduledQueryParams {
+	var ()
+	return &GetScheduledQueryParams{
+
+		timeout: timeout,
+	}
+}
+
+// NewGetScheduledQueryParamsWithContext creates a new GetScheduledQueryParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewGetScheduledQueryParamsWithContext(ctx context.Context) *GetScheduledQueryParams {
+	var ()
+	return &GetScheduledQueryParams{
+
+		Context: ctx,
+	}
+}
+
+// NewGetScheduledQueryParamsWithHTTPClient creates a new GetScheduledQueryParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewGetScheduledQueryParamsWithHTTPClient(client *http.Client) *GetScheduledQueryParams {
+	var ()
+	return &GetScheduledQueryParams{
+		HTTPClient: client,
+	}
+}
+
+/*GetScheduledQueryParams contains all the parameters to send to the API endpoint
+for the get scheduled query operation typically these are written to a http.Request
+*/
+type GetScheduledQueryParams struct {
+
+	/*QueryID
+	  Unique ASCII scheduled query identifier
+
+	*/
+	QueryID string
+	/*VersionID
+	  The version of the analysis to retrieve
+
+	*/
+	VersionID *string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the get scheduled query params
+func (o *GetScheduledQueryParams) WithTimeout(timeout time.Duration) *GetScheduledQueryParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the get scheduled query params
+func (o *GetScheduledQueryParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the get scheduled query params
+func (o *GetScheduledQueryParams) WithContext(ctx context.Context) *GetScheduledQueryParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the get scheduled query params
+func (o *GetScheduledQueryParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the get scheduled query params
+func (o *GetScheduledQueryParams) WithHTTPClient(client *http.Client) *GetScheduledQueryParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the get scheduled query params
+func (o *GetScheduledQueryParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithQueryID adds the queryID to the get scheduled query params
+func (o *GetScheduledQueryParams) WithQueryID(queryID string) *GetScheduledQueryParams {
+	o.SetQueryID(queryID)
+	return o
+}
+
+// SetQueryID adds the queryId to the get scheduled query params
+func (o *GetScheduledQueryParams) SetQueryID(queryID string) {
+	o.QueryID = queryID
+}
+
+// WithVersionID adds the versionID to the get scheduled query params
+func (o *GetScheduledQueryParams) WithVersionID(versionID *string) *GetScheduledQueryParams {
+	o.SetVersionID(versionID)
+	return o
+}
+
+// SetVersionID adds the versionId to the get scheduled query params
+func (o *GetScheduledQueryParams) SetVersionID(versionID *string) {
+	o.VersionID = versionID
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *GetScheduledQueryParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	// query param queryId
+	qrQueryID := o.QueryID
+	qQueryID := qrQueryID
+	if qQueryID != "" {
+		if err := r.SetQueryParam("queryId", qQueryID); err != nil {
+			return err
+		}
+	}
+
+	if o.VersionID != nil {
+
+		// query param versionId
+		var qrVersionID string
+		if o.VersionID != nil {
+			qrVersionID = *o.VersionID
+		}
+		qVersionID := qrVersionID
+		if qVersionID != "" {
+			if err := r.SetQueryParam("versionId", qVersionID); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
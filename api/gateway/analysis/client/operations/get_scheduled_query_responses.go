@@ -0,0 +1,180 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+)
+
+// GetScheduledQueryReader is a Reader for the GetScheduledQuery structure.
+type GetScheduledQueryReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *GetScheduledQueryReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewGetScheduledQueryOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewGetScheduledQueryBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 404:
+		result := NewGetScheduledQueryNotFound()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewGetScheduledQueryInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewGetScheduledQueryOK creates a GetScheduledQueryOK with default headers values
+func NewGetScheduledQueryOK() *GetScheduledQueryOK {
+	return &GetScheduledQueryOK{}
+}
+
+/*GetScheduledQueryOK handles this case with default header values.
+
+OK
+*/
+type GetScheduledQueryOK struct {
+	Payload *models.Rule
+}
+
+func (o *GetScheduledQueryOK) Error() string {
+	return fmt.Sprintf("[GET /query][%d] getScheduledQueryOK  %+v", 200, o.Payload)
+}
+
+func (o *GetScheduledQueryOK) GetPayload() *models.Rule {
+	return o.Payload
+}
+
+func (o *GetScheduledQueryOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Rule)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewGetScheduledQueryBadRequest creates a GetScheduledQueryBadRequest with default headers values
+func NewGetScheduledQueryBadRequest() *GetScheduledQueryBadRequest {
+	return &GetScheduledQueryBadRequest{}
+}
+
+/*GetScheduledQueryBadRequest handles this case with default header values.
+
+Bad request
+*/
+type GetScheduledQueryBadRequest struct {
+	Payload *models.Error
+}
+
+func (o *GetScheduledQueryBadRequest) Error() string {
+	return fmt.Sprintf("[GET /query][%d] getScheduledQueryBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *GetScheduledQueryBadRequest) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *GetScheduledQueryBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewGetScheduledQueryNotFound creates a GetScheduledQueryNotFound with default headers values
+func NewGetScheduledQueryNotFound() *GetScheduledQueryNotFound {
+	return &GetScheduledQueryNotFound{}
+}
+
+/*GetScheduledQueryNotFound handles this case with default header values.
+
+Scheduled query does not exist
+*/
+type GetScheduledQueryNotFound struct {
+}
+
+func (o *GetScheduledQueryNotFound) Error() string {
+	return fmt.Sprintf("[GET /query][%d] getScheduledQueryNotFound ", 404)
+}
+
+func (o *GetScheduledQueryNotFound) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// NewGetScheduledQueryInternalServerError creates a GetScheduledQueryInternalServerError with default headers values
+func NewGetScheduledQueryInternalServerError() *GetScheduledQueryInternalServerError {
+	return &GetScheduledQueryInternalServerError{}
+}
+
+/*GetScheduledQueryInternalServerError handles this case with default header values.
+
+Internal server error
+*/
+type GetScheduledQueryInternalServerError struct {
+}
+
+func (o *GetScheduledQueryInternalServerError) Error() string {
+	return fmt.Sprintf("[GET /query][%d] getScheduledQueryInternalServerError ", 500)
+}
+
+func (o *GetScheduledQueryInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
@@ -0,0 +1,214 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// NewGetVersionDiffParams creates a new GetVersionDiffParams object
+// with the default values initialized.
+func NewGetVersionDiffParams() *GetVersionDiffParams {
+	var ()
+	return &GetVersionDiffParams{
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewGetVersionDiffParamsWithTimeout creates a new GetVersionDiffParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewGetVersionDiffParamsWithTimeout(timeout time.Duration) *GetVersionDiffParams {
+	var ()
+	return &GetVersionDiffParams{
+
+		timeout: timeout,
+	}
+}
+
+// NewGetVersionDiffParamsWithContext creates a new GetVersionDiffParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewGetVersionDiffParamsWithContext(ctx context.Context) *GetVersionDiffParams {
+	var ()
+	return &GetVersionDiffParams{
+
+		Context: ctx,
+	}
+}
+
+// NewGetVersionDiffParamsWithHTTPClient creates a new GetVersionDiffParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewGetVersionDiffParamsWithHTTPClient(client *http.Client) *GetVersionDiffParams {
+	var ()
+	return &GetVersionDiffParams{
+		HTTPClient: client,
+	}
+}
+
+/*GetVersionDiffParams contains all the parameters to send to the API endpoint
+for the get version diff operation typically these are written to a http.Request
+*/
+type GetVersionDiffParams struct {
+
+	/*AnalysisID
+	  Unique ASCII policy, rule or global identifier
+
+	*/
+	AnalysisID string
+	/*FromVersionID
+	  The older version to compare
+
+	*/
+	FromVersionID string
+	/*ToVersionID
+	  The newer version to compare (defaults to the latest version)
+
+	*/
+	ToVersionID *string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the get version diff params
+func (o *GetVersionDiffParams) WithTimeout(timeout time.Duration) *GetVersionDiffParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the get version diff params
+func (o *GetVersionDiffParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the get version diff params
+func (o *GetVersionDiffParams) WithContext(ctx context.Context) *GetVersionDiffParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the get version diff params
+func (o *GetVersionDiffParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the get version diff params
+func (o *GetVersionDiffParams) WithHTTPClient(client *http.Client) *GetVersionDiffParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the get version diff params
+func (o *GetVersionDiffParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithAnalysisID adds the analysisID to the get version diff params
+func (o *GetVersionDiffParams) WithAnalysisID(analysisID string) *GetVersionDiffParams {
+	o.SetAnalysisID(analysisID)
+	return o
+}
+
+// SetAnalysisID adds the analysisId to the get version diff params
+func (o *GetVersionDiffParams) SetAnalysisID(analysisID string) {
+	o.AnalysisID = analysisID
+}
+
+// WithFromVersionID adds the fromVersionID to the get version diff params
+func (o *GetVersionDiffParams) WithFromVersionID(fromVersionID string) *GetVersionDiffParams {
+	o.SetFromVersionID(fromVersionID)
+	return o
+}
+
+// SetFromVersionID adds the fromVersionId to the get version diff params
+func (o *GetVersionDiffParams) SetFromVersionID(fromVersionID string) {
+	o.FromVersionID = fromVersionID
+}
+
+// WithToVersionID adds the toVersionID to the get version diff params
+func (o *GetVersionDiffParams) WithToVersionID(toVersionID *string) *GetVersionDiffParams {
+	o.SetToVersionID(toVersionID)
+	return o
+}
+
+// SetToVersionID adds the toVersionId to the get version diff params
+func (o *GetVersionDiffParams) SetToVersionID(toVersionID *string) {
+	o.ToVersionID = toVersionID
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *GetVersionDiffParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	// query param analysisId
+	qrAnalysisID := o.AnalysisID
+	qAnalysisID := qrAnalysisID
+	if qAnalysisID != "" {
+		if err := r.SetQueryParam("analysisId", qAnalysisID); err != nil {
+			return err
+		}
+	}
+
+	// query param fromVersionId
+	qrFromVersionID := o.FromVersionID
+	qFromVersionID := qrFromVersionID
+	if qFromVersionID != "" {
+		if err := r.SetQueryParam("fromVersionId", qFromVersionID); err != nil {
+			return err
+		}
+	}
+
+	if o.ToVersionID != nil {
+
+		// query param toVersionId
+		var qrToVersionID string
+		if o.ToVersionID != nil {
+			qrToVersionID = *o.ToVersionID
+		}
+		qToVersionID := qrToVersionID
+		if qToVersionID != "" {
+			if err := r.SetQueryParam("toVersionId", qToVersionID); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
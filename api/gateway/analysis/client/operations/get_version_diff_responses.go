@@ -0,0 +1,180 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+)
+
+// GetVersionDiffReader is a Reader for the GetVersionDiff structure.
+type GetVersionDiffReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *GetVersionDiffReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewGetVersionDiffOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewGetVersionDiffBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 404:
+		result := NewGetVersionDiffNotFound()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewGetVersionDiffInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewGetVersionDiffOK creates a GetVersionDiffOK with default headers values
+func NewGetVersionDiffOK() *GetVersionDiffOK {
+	return &GetVersionDiffOK{}
+}
+
+/*GetVersionDiffOK handles this case with default header values.
+
+OK
+*/
+type GetVersionDiffOK struct {
+	Payload *models.VersionDiff
+}
+
+func (o *GetVersionDiffOK) Error() string {
+	return fmt.Sprintf("[GET /version/diff][%d] getVersionDiffOK  %+v", 200, o.Payload)
+}
+
+func (o *GetVersionDiffOK) GetPayload() *models.VersionDiff {
+	return o.Payload
+}
+
+func (o *GetVersionDiffOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.VersionDiff)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewGetVersionDiffBadRequest creates a GetVersionDiffBadRequest with default headers values
+func NewGetVersionDiffBadRequest() *GetVersionDiffBadRequest {
+	return &GetVersionDiffBadRequest{}
+}
+
+/*GetVersionDiffBadRequest handles this case with default header values.
+
+Bad request
+*/
+type GetVersionDiffBadRequest struct {
+	Payload *models.Error
+}
+
+func (o *GetVersionDiffBadRequest) Error() string {
+	return fmt.Sprintf("[GET /version/diff][%d] getVersionDiffBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *GetVersionDiffBadRequest) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *GetVersionDiffBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewGetVersionDiffNotFound creates a GetVersionDiffNotFound with default headers values
+func NewGetVersionDiffNotFound() *GetVersionDiffNotFound {
+	return &GetVersionDiffNotFound{}
+}
+
+/*GetVersionDiffNotFound handles this case with default header values.
+
+Policy, rule, global or version does not exist
+*/
+type GetVersionDiffNotFound struct {
+}
+
+func (o *GetVersionDiffNotFound) Error() string {
+	return fmt.Sprintf("[GET /version/diff][%d] getVersionDiffNotFound ", 404)
+}
+
+func (o *GetVersionDiffNotFound) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// NewGetVersionDiffInternalServerError creates a GetVersionDiffInternalServerError with default headers values
+func NewGetVersionDiffInternalServerError() *GetVersionDiffInternalServerError {
+	return &GetVersionDiffInternalServerError{}
+}
+
+/*GetVersionDiffInternalServerError handles this case with default header values.
+
+Internal server error
+*/
+type GetVersionDiffInternalServerError struct {
+}
+
+func (o *GetVersionDiffInternalServerError) Error() string {
+	return fmt.Sprintf("[GET /version/diff][%d] getVersionDiffInternalServerError ", 500)
+}
+
+func (o *GetVersionDiffInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
@@ -0,0 +1,192 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+)
+
+// InstallPackReader is a Reader for the InstallPack structure.
+type InstallPackReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *InstallPackReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewInstallPackOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewInstallPackBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 409:
+		result := NewInstallPackConflict()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewInstallPackInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewInstallPackOK creates a InstallPackOK with default headers values
+func NewInstallPackOK() *InstallPackOK {
+	return &InstallPackOK{}
+}
+
+/*InstallPackOK handles this case with default header values.
+
+OK
+*/
+type InstallPackOK struct {
+	Payload *models.InstallPackResult
+}
+
+func (o *InstallPackOK) Error() string {
+	return fmt.Sprintf("[POST /pack][%d] installPackOK  %+v", 200, o.Payload)
+}
+
+func (o *InstallPackOK) GetPayload() *models.InstallPackResult {
+	return o.Payload
+}
+
+func (o *InstallPackOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.InstallPackResult)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewInstallPackBadRequest creates a InstallPackBadRequest with default headers values
+func NewInstallPackBadRequest() *InstallPackBadRequest {
+	return &InstallPackBadRequest{}
+}
+
+/*InstallPackBadRequest handles this case with default header values.
+
+Bad request
+*/
+type InstallPackBadRequest struct {
+	Payload *models.Error
+}
+
+func (o *InstallPackBadRequest) Error() string {
+	return fmt.Sprintf("[POST /pack][%d] installPackBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *InstallPackBadRequest) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *InstallPackBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewInstallPackConflict creates a InstallPackConflict with default headers values
+func NewInstallPackConflict() *InstallPackConflict {
+	return &InstallPackConflict{}
+}
+
+/*InstallPackConflict handles this case with default header values.
+
+An item belongs to a different pack or conflicts with an existing item
+*/
+type InstallPackConflict struct {
+	Payload *models.Error
+}
+
+func (o *InstallPackConflict) Error() string {
+	return fmt.Sprintf("[POST /pack][%d] installPackConflict  %+v", 409, o.Payload)
+}
+
+func (o *InstallPackConflict) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *InstallPackConflict) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewInstallPackInternalServerError creates a InstallPackInternalServerError with default headers values
+func NewInstallPackInternalServerError() *InstallPackInternalServerError {
+	return &InstallPackInternalServerError{}
+}
+
+/*InstallPackInternalServerError handles this case with default header values.
+
+Internal server error
+*/
+type InstallPackInternalServerError struct {
+}
+
+func (o *InstallPackInternalServerError) Error() string {
+	return fmt.Sprintf("[POST /pack][%d] installPackInternalServerError ", 500)
+}
+
+func (o *InstallPackInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
@@ -0,0 +1,441 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// NewListCorrelationRulesParams creates a new ListCorrelationRulesParams object
+// with the default values initialized.
+func NewListCorrelationRulesParams() *ListCorrelationRulesParams {
+	var (
+		pageDefault     = int64(1)
+		pageSizeDefault = int64(25)
+		sortByDefault   = string("severity")
+		sortDirDefault  = string("ascending")
+	)
+	return &ListCorrelationRulesParams{
+		Page:     &pageDefault,
+		PageSize: &pageSizeDefault,
+		SortBy:   &sortByDefault,
+		SortDir:  &sortDirDefault,
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewListCorrelationRulesParamsWithTimeout creates a new ListCorrelationRulesParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewListCorrelationRulesParamsWithTimeout(timeout time.Duration) *ListCorrelationRulesParams {
+	var (
+		pageDefault     = int64(1)
+		pageSizeDefault = int64(25)
+		sortByDefault   = string("severity")
+		sortDirDefault  = string("ascending")
+	)
+	return &ListCorrelationRulesParams{
+		Page:     &pageDefault,
+		PageSize: &pageSizeDefault,
+		SortBy:   &sortByDefault,
+		SortDir:  &sortDirDefault,
+
+		timeout: timeout,
+	}
+}
+
+// NewListCorrelationRulesParamsWithContext creates a new ListCorrelationRulesParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewListCorrelationRulesParamsWithContext(ctx context.Context) *ListCorrelationRulesParams {
+	var (
+		pageDefault     = int64(1)
+		pageSizeDefault = int64(25)
+		sortByDefault   = string("severity")
+		sortDirDefault  = string("ascending")
+	)
+	return &ListCorrelationRulesParams{
+		Page:     &pageDefault,
+		PageSize: &pageSizeDefault,
+		SortBy:   &sortByDefault,
+		SortDir:  &sortDirDefault,
+
+		Context: ctx,
+	}
+}
+
+// NewListCorrelationRulesParamsWithHTTPClient creates a new ListCorrelationRulesParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewListCorrelationRulesParamsWithHTTPClient(client *http.Client) *ListCorrelationRulesParams {
+	var (
+		pageDefault     = int64(1)
+		pageSizeDefault = int64(25)
+		sortByDefault   = string("severity")
+		sortDirDefault  = string("ascending")
+	)
+	return &ListCorrelationRulesParams{
+		Page:       &pageDefault,
+		PageSize:   &pageSizeDefault,
+		SortBy:     &sortByDefault,
+		SortDir:    &sortDirDefault,
+		HTTPClient: client,
+	}
+}
+
+/*ListCorrelationRulesParams contains all the parameters to send to the API endpoint
+for the list correlation rules operation typically these are written to a http.Request
+*/
+type ListCorrelationRulesParams struct {
+
+	/*Enabled
+	  Only include correlation rules which are enabled or disabled
+
+	*/
+	Enabled *bool
+	/*LogTypes
+	  Only include correlation rules which read one of these log types
+
+	*/
+	LogTypes []string
+	/*NameContains
+	  Only include correlation rules whose ID or display name contains this substring (case-insensitive)
+
+	*/
+	NameContains *string
+	/*Page
+	  Which page of results to retrieve
+
+	*/
+	Page *int64
+	/*PageSize
+	  Number of items in each page of results
+
+	*/
+	PageSize *int64
+	/*Severity
+	  Only include correlation rules with this severity
+
+	*/
+	Severity *string
+	/*SortBy
+	  Name of the field to sort by
+
+	*/
+	SortBy *string
+	/*SortDir
+	  Sort direction
+
+	*/
+	SortDir *string
+	/*Tags
+	  Only include correlation rules with all of these tags (case-insensitive)
+
+	*/
+	Tags []string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the list correlation rules params
+func (o *ListCorrelationRulesParams) WithTimeout(timeout time.Duration) *ListCorrelationRulesParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the list correlation rules params
+func (o *ListCorrelationRulesParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the list correlation rules params
+func (o *ListCorrelationRulesParams) WithContext(ctx context.Context) *ListCorrelationRulesParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the list correlation rules params
+func (o *ListCorrelationRulesParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the list correlation rules params
+func (o *ListCorrelationRulesParams) WithHTTPClient(client *http.Client) *ListCorrelationRulesParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the list correlation rules params
+func (o *ListCorrelationRulesParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithEnabled adds the enabled to the list correlation rules params
+func (o *ListCorrelationRulesParams) WithEnabled(enabled *bool) *ListCorrelationRulesParams {
+	o.SetEnabled(enabled)
+	return o
+}
+
+// SetEnabled adds the enabled to the list correlation rules params
+func (o *ListCorrelationRulesParams) SetEnabled(enabled *bool) {
+	o.Enabled = enabled
+}
+
+// WithLogTypes adds the logTypes to the list correlation rules params
+func (o *ListCorrelationRulesParams) WithLogTypes(logTypes []string) *ListCorrelationRulesParams {
+	o.SetLogTypes(logTypes)
+	return o
+}
+
+// SetLogTypes adds the logTypes to the list correlation rules params
+func (o *ListCorrelationRulesParams) SetLogTypes(logTypes []string) {
+	o.LogTypes = logTypes
+}
+
+// WithNameContains adds the nameContains to the list correlation rules params
+func (o *ListCorrelationRulesParams) WithNameContains(nameContains *string) *ListCorrelationRulesParams {
+	o.SetNameContains(nameContains)
+	return o
+}
+
+// SetNameContains adds the nameContains to the list correlation rules params
+func (o *ListCorrelationRulesParams) SetNameContains(nameContains *string) {
+	o.NameContains = nameContains
+}
+
+// WithPage adds the page to the list correlation rules params
+func (o *ListCorrelationRulesParams) WithPage(page *int64) *ListCorrelationRulesParams {
+	o.SetPage(page)
+	return o
+}
+
+// SetPage adds the page to the list correlation rules params
+func (o *ListCorrelationRulesParams) SetPage(page *int64) {
+	o.Page = page
+}
+
+// WithPageSize adds the pageSize to the list correlation rules params
+func (o *ListCorrelationRulesParams) WithPageSize(pageSize *int64) *ListCorrelationRulesParams {
+	o.SetPageSize(pageSize)
+	return o
+}
+
+// SetPageSize adds the pageSize to the list correlation rules params
+func (o *ListCorrelationRulesParams) SetPageSize(pageSize *int64) {
+	o.PageSize = pageSize
+}
+
+// WithSeverity adds the severity to the list correlation rules params
+func (o *ListCorrelationRulesParams) WithSeverity(severity *string) *ListCorrelationRulesParams {
+	o.SetSeverity(severity)
+	return o
+}
+
+// SetSeverity adds the severity to the list correlation rules params
+func (o *ListCorrelationRulesParams) SetSeverity(severity *string) {
+	o.Severity = severity
+}
+
+// WithSortBy adds the sortBy to the list correlation rules params
+func (o *ListCorrelationRulesParams) WithSortBy(sortBy *string) *ListCorrelationRulesParams {
+	o.SetSortBy(sortBy)
+	return o
+}
+
+// SetSortBy adds the sortBy to the list correlation rules params
+func (o *ListCorrelationRulesParams) SetSortBy(sortBy *string) {
+	o.SortBy = sortBy
+}
+
+// WithSortDir adds the sortDir to the list correlation rules params
+func (o *ListCorrelationRulesParams) WithSortDir(sortDir *string) *ListCorrelationRulesParams {
+	o.SetSortDir(sortDir)
+	return o
+}
+
+// SetSortDir adds the sortDir to the list correlation rules params
+func (o *ListCorrelationRulesParams) SetSortDir(sortDir *string) {
+	o.SortDir = sortDir
+}
+
+// WithTags adds the tags to the list correlation rules params
+func (o *ListCorrelationRulesParams) WithTags(tags []string) *ListCorrelationRulesParams {
+	o.SetTags(tags)
+	return o
+}
+
+// SetTags adds the tags to the list correlation rules params
+func (o *ListCorrelationRulesParams) SetTags(tags []string) {
+	o.Tags = tags
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *ListCorrelationRulesParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	if o.Enabled != nil {
+
+		// query param enabled
+		var qrEnabled bool
+		if o.Enabled != nil {
+			qrEnabled = *o.Enabled
+		}
+		qEnabled := swag.FormatBool(qrEnabled)
+		if qEnabled != "" {
+			if err := r.SetQueryParam("enabled", qEnabled); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	valuesLogTypes := o.LogTypes
+
+	joinedLogTypes := swag.JoinByFormat(valuesLogTypes, "csv")
+	// query array param logTypes
+	if err := r.SetQueryParam("logTypes", joinedLogTypes...); err != nil {
+		return err
+	}
+
+	if o.NameContains != nil {
+
+		// query param nameContains
+		var qrNameContains string
+		if o.NameContains != nil {
+			qrNameContains = *o.NameContains
+		}
+		qNameContains := qrNameContains
+		if qNameContains != "" {
+			if err := r.SetQueryParam("nameContains", qNameContains); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.Page != nil {
+
+		// query param page
+		var qrPage int64
+		if o.Page != nil {
+			qrPage = *o.Page
+		}
+		qPage := swag.FormatInt64(qrPage)
+		if qPage != "" {
+			if err := r.SetQueryParam("page", qPage); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.PageSize != nil {
+
+		// query param pageSize
+		var qrPageSize int64
+		if o.PageSize != nil {
+			qrPageSize = *o.PageSize
+		}
+		qPageSize := swag.FormatInt64(qrPageSize)
+		if qPageSize != "" {
+			if err := r.SetQueryParam("pageSize", qPageSize); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.Severity != nil {
+
+		// query param severity
+		var qrSeverity string
+		if o.Severity != nil {
+			qrSeverity = *o.Severity
+		}
+		qSeverity := qrSeverity
+		if qSeverity != "" {
+			if err := r.SetQueryParam("severity", qSeverity); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.SortBy != nil {
+
+		// query param sortBy
+		var qrSortBy string
+		if o.SortBy != nil {
+			qrSortBy = *o.SortBy
+		}
+		qSortBy := qrSortBy
+		if qSortBy != "" {
+			if err := r.SetQueryParam("sortBy", qSortBy); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.SortDir != nil {
+
+		// query param sortDir
+		var qrSortDir string
+		if o.SortDir != nil {
+			qrSortDir = *o.SortDir
+		}
+		qSortDir := qrSortDir
+		if qSortDir != "" {
+			if err := r.SetQueryParam("sortDir", qSortDir); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	valuesTags := o.Tags
+
+	joinedTags := swag.JoinByFormat(valuesTags, "csv")
+	// query array param tags
+	if err := r.SetQueryParam("tags", joinedTags...); err != nil {
+		return err
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+)
+
+// ListCorrelationRulesReader is a Reader for the ListCorrelationRules structure.
+type ListCorrelationRulesReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *ListCorrelationRulesReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewListCorrelationRulesOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewListCorrelationRulesBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewListCorrelationRulesInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewListCorrelationRulesOK creates a ListCorrelationRulesOK with default headers values
+func NewListCorrelationRulesOK() *ListCorrelationRulesOK {
+	return &ListCorrelationRulesOK{}
+}
+
+/*ListCorrelationRulesOK handles this case with default header values.
+
+OK
+*/
+type ListCorrelationRulesOK struct {
+	Payload *models.RuleList
+}
+
+func (o *ListCorrelationRulesOK) Error() string {
+	return fmt.Sprintf("[GET /correlation/list][%d] listCorrelationRulesOK  %+v", 200, o.Payload)
+}
+
+func (o *ListCorrelationRulesOK) GetPayload() *models.RuleList {
+	return o.Payload
+}
+
+func (o *ListCorrelationRulesOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.RuleList)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewListCorrelationRulesBadRequest creates a ListCorrelationRulesBadRequest with default headers values
+func NewListCorrelationRulesBadRequest() *ListCorrelationRulesBadRequest {
+	return &ListCorrelationRulesBadRequest{}
+}
+
+/*ListCorrelationRulesBadRequest handles this case with default header values.
+
+Bad request
+*/
+type ListCorrelationRulesBadRequest struct {
+	Payload *models.Error
+}
+
+func (o *ListCorrelationRulesBadRequest) Error() string {
+	return fmt.Sprintf("[GET /correlation/list][%d] listCorrelationRulesBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *ListCorrelationRulesBadRequest) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *ListCorrelationRulesBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewListCorrelationRulesInternalServerError creates a ListCorrelationRulesInternalServerError with default headers values
+func NewListCorrelationRulesInternalServerError() *ListCorrelationRulesInternalServerError {
+	return &ListCorrelationRulesInternalServerError{}
+}
+
+/*ListCorrelationRulesInternalServerError handles this case with default header values.
+
+Internal server error
+*/
+type ListCorrelationRulesInternalServerError struct {
+}
+
+func (o *ListCorrelationRulesInternalServerError) Error() string {
+	return fmt.Sprintf("[GET /correlation/list][%d] listCorrelationRulesInternalServerError ", 500)
+}
+
+func (o *ListCorrelationRulesInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
@@ -0,0 +1,130 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// NewListDataModelsParams creates a new ListDataModelsParams object
+// with the default values initialized.
+func NewListDataModelsParams() *ListDataModelsParams {
+
+	return &ListDataModelsParams{
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewListDataModelsParamsWithTimeout creates a new ListDataModelsParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewListDataModelsParamsWithTimeout(timeout time.Duration) *ListDataModelsParams {
+
+	return &ListDataModelsParams{
+
+		timeout: timeout,
+	}
+}
+
+// NewListDataModelsParamsWithContext creates a new ListDataModelsParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewListDataModelsParamsWithContext(ctx context.Context) *ListDataModelsParams {
+
+	return &ListDataModelsParams{
+
+		Context: ctx,
+	}
+}
+
+// NewListDataModelsParamsWithHTTPClient creates a new ListDataModelsParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewListDataModelsParamsWithHTTPClient(client *http.Client) *ListDataModelsParams {
+
+	return &ListDataModelsParams{
+		HTTPClient: client,
+	}
+}
+
+/*ListDataModelsParams contains all the parameters to send to the API endpoint
+for the list data models operation typically these are written to a http.Request
+*/
+type ListDataModelsParams struct {
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the list data models params
+func (o *ListDataModelsParams) WithTimeout(timeout time.Duration) *ListDataModelsParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the list data models params
+func (o *ListDataModelsParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the list data models params
+func (o *ListDataModelsParams) WithContext(ctx context.Context) *ListDataModelsParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the list data models params
+func (o *ListDataModelsParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the list data models params
+func (o *ListDataModelsParams) WithHTTPClient(client *http.Client) *ListDataModelsParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the list data models params
+func (o *ListDataModelsParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *ListDataModelsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
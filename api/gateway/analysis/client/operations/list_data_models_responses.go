@@ -0,0 +1,114 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+)
+
+// ListDataModelsReader is a Reader for the ListDataModels structure.
+type ListDataModelsReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *ListDataModelsReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewListDataModelsOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 500:
+		result := NewListDataModelsInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewListDataModelsOK creates a ListDataModelsOK with default headers values
+func NewListDataModelsOK() *ListDataModelsOK {
+	return &ListDataModelsOK{}
+}
+
+/*ListDataModelsOK handles this case with default header values.
+
+OK
+*/
+type ListDataModelsOK struct {
+	Payload *models.DataModels
+}
+
+func (o *ListDataModelsOK) Error() string {
+	return fmt.Sprintf("[GET /datamodels][%d] listDataModelsOK  %+v", 200, o.Payload)
+}
+
+func (o *ListDataModelsOK) GetPayload() *models.DataModels {
+	return o.Payload
+}
+
+func (o *ListDataModelsOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.DataModels)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewListDataModelsInternalServerError creates a ListDataModelsInternalServerError with default headers values
+func NewListDataModelsInternalServerError() *ListDataModelsInternalServerError {
+	return &ListDataModelsInternalServerError{}
+}
+
+/*ListDataModelsInternalServerError handles this case with default header values.
+
+Internal server error
+*/
+type ListDataModelsInternalServerError struct {
+}
+
+func (o *ListDataModelsInternalServerError) Error() string {
+	return fmt.Sprintf("[GET /datamodels][%d] listDataModelsInternalServerError ", 500)
+}
+
+func (o *ListDataModelsInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
@@ -0,0 +1,281 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// NewListExceptionsParams creates a new ListExceptionsParams object
+// with the default values initialized.
+func NewListExceptionsParams() *ListExceptionsParams {
+	var (
+		includeExpiredDefault = bool(false)
+		includeRevokedDefault = bool(false)
+	)
+	return &ListExceptionsParams{
+		IncludeExpired: &includeExpiredDefault,
+		IncludeRevoked: &includeRevokedDefault,
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewListExceptionsParamsWithTimeout creates a new ListExceptionsParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewListExceptionsParamsWithTimeout(timeout time.Duration) *ListExceptionsParams {
+	var (
+		includeExpiredDefault = bool(false)
+		includeRevokedDefault = bool(false)
+	)
+	return &ListExceptionsParams{
+		IncludeExpired: &includeExpiredDefault,
+		IncludeRevoked: &includeRevokedDefault,
+
+		timeout: timeout,
+	}
+}
+
+// NewListExceptionsParamsWithContext creates a new ListExceptionsParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewListExceptionsParamsWithContext(ctx context.Context) *ListExceptionsParams {
+	var (
+		includeExpiredDefault = bool(false)
+		includeRevokedDefault = bool(false)
+	)
+	return &ListExceptionsParams{
+		IncludeExpired: &includeExpiredDefault,
+		IncludeRevoked: &includeRevokedDefault,
+
+		Context: ctx,
+	}
+}
+
+// NewListExceptionsParamsWithHTTPClient creates a new ListExceptionsParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewListExceptionsParamsWithHTTPClient(client *http.Client) *ListExceptionsParams {
+	var (
+		includeExpiredDefault = bool(false)
+		includeRevokedDefault = bool(false)
+	)
+	return &ListExceptionsParams{
+		IncludeExpired: &includeExpiredDefault,
+		IncludeRevoked: &includeRevokedDefault,
+		HTTPClient:     client,
+	}
+}
+
+/*ListExceptionsParams contains all the parameters to send to the API endpoint
+for the list exceptions operation typically these are written to a http.Request
+*/
+type ListExceptionsParams struct {
+
+	/*ExpiresWithinDays
+	  Only include exceptions which expire within this many days
+
+	*/
+	ExpiresWithinDays *int64
+	/*IncludeExpired
+	  Include exceptions which have already expired
+
+	*/
+	IncludeExpired *bool
+	/*IncludeRevoked
+	  Include exceptions which have been revoked
+
+	*/
+	IncludeRevoked *bool
+	/*PolicyID
+	  Only include exceptions from this policy
+
+	*/
+	PolicyID *string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the list exceptions params
+func (o *ListExceptionsParams) WithTimeout(timeout time.Duration) *ListExceptionsParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the list exceptions params
+func (o *ListExceptionsParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the list exceptions params
+func (o *ListExceptionsParams) WithContext(ctx context.Context) *ListExceptionsParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the list exceptions params
+func (o *ListExceptionsParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the list exceptions params
+func (o *ListExceptionsParams) WithHTTPClient(client *http.Client) *ListExceptionsParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the list exceptions params
+func (o *ListExceptionsParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithExpiresWithinDays adds the expiresWithinDays to the list exceptions params
+func (o *ListExceptionsParams) WithExpiresWithinDays(expiresWithinDays *int64) *ListExceptionsParams {
+	o.SetExpiresWithinDays(expiresWithinDays)
+	return o
+}
+
+// SetExpiresWithinDays adds the expiresWithinDays to the list exceptions params
+func (o *ListExceptionsParams) SetExpiresWithinDays(expiresWithinDays *int64) {
+	o.ExpiresWithinDays = expiresWithinDays
+}
+
+// WithIncludeExpired adds the includeExpired to the list exceptions params
+func (o *ListExceptionsParams) WithIncludeExpired(includeExpired *bool) *ListExceptionsParams {
+	o.SetIncludeExpired(includeExpired)
+	return o
+}
+
+// SetIncludeExpired adds the includeExpired to the list exceptions params
+func (o *ListExceptionsParams) SetIncludeExpired(includeExpired *bool) {
+	o.IncludeExpired = includeExpired
+}
+
+// WithIncludeRevoked adds the includeRevoked to the list exceptions params
+func (o *ListExceptionsParams) WithIncludeRevoked(includeRevoked *bool) *ListExceptionsParams {
+	o.SetIncludeRevoked(includeRevoked)
+	return o
+}
+
+// SetIncludeRevoked adds the includeRevoked to the list exceptions params
+func (o *ListExceptionsParams) SetIncludeRevoked(includeRevoked *bool) {
+	o.IncludeRevoked = includeRevoked
+}
+
+// WithPolicyID adds the policyID to the list exceptions params
+func (o *ListExceptionsParams) WithPolicyID(policyID *string) *ListExceptionsParams {
+	o.SetPolicyID(policyID)
+	return o
+}
+
+// SetPolicyID adds the policyId to the list exceptions params
+func (o *ListExceptionsParams) SetPolicyID(policyID *string) {
+	o.PolicyID = policyID
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *ListExceptionsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	if o.ExpiresWithinDays != nil {
+
+		// query param expiresWithinDays
+		var qrExpiresWithinDays int64
+		if o.ExpiresWithinDays != nil {
+			qrExpiresWithinDays = *o.ExpiresWithinDays
+		}
+		qExpiresWithinDays := swag.FormatInt64(qrExpiresWithinDays)
+		if qExpiresWithinDays != "" {
+			if err := r.SetQueryParam("expiresWithinDays", qExpiresWithinDays); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.IncludeExpired != nil {
+
+		// query param includeExpired
+		var qrIncludeExpired bool
+		if o.IncludeExpired != nil {
+			qrIncludeExpired = *o.IncludeExpired
+		}
+		qIncludeExpired := swag.FormatBool(qrIncludeExpired)
+		if qIncludeExpired != "" {
+			if err := r.SetQueryParam("includeExpired", qIncludeExpired); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.IncludeRevoked != nil {
+
+		// query param includeRevoked
+		var qrIncludeRevoked bool
+		if o.IncludeRevoked != nil {
+			qrIncludeRevoked = *o.IncludeRevoked
+		}
+		qIncludeRevoked := swag.FormatBool(qrIncludeRevoked)
+		if qIncludeRevoked != "" {
+			if err := r.SetQueryParam("includeRevoked", qIncludeRevoked); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.PolicyID != nil {
+
+		// query param policyId
+		var qrPolicyID string
+		if o.PolicyID != nil {
+			qrPolicyID = *o.PolicyID
+		}
+		qPolicyID := qrPolicyID
+		if qPolicyID != "" {
+			if err := r.SetQueryParam("policyId", qPolicyID); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
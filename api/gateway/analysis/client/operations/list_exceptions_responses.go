@@ -0,0 +1,153 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+)
+
+// ListExceptionsReader is a Reader for the ListExceptions structure.
+type ListExceptionsReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *ListExceptionsReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewListExceptionsOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewListExceptionsBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewListExceptionsInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewListExceptionsOK creates a ListExceptionsOK with default headers values
+func NewListExceptionsOK() *ListExceptionsOK {
+	return &ListExceptionsOK{}
+}
+
+/*ListExceptionsOK handles this case with default header values.
+
+OK
+*/
+type ListExceptionsOK struct {
+	Payload *models.ExceptionList
+}
+
+func (o *ListExceptionsOK) Error() string {
+	return fmt.Sprintf("[GET /exception/list][%d] listExceptionsOK  %+v", 200, o.Payload)
+}
+
+func (o *ListExceptionsOK) GetPayload() *models.ExceptionList {
+	return o.Payload
+}
+
+func (o *ListExceptionsOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.ExceptionList)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewListExceptionsBadRequest creates a ListExceptionsBadRequest with default headers values
+func NewListExceptionsBadRequest() *ListExceptionsBadRequest {
+	return &ListExceptionsBadRequest{}
+}
+
+/*ListExceptionsBadRequest handles this case with default header values.
+
+Bad request
+*/
+type ListExceptionsBadRequest struct {
+	Payload *models.Error
+}
+
+func (o *ListExceptionsBadRequest) Error() string {
+	return fmt.Sprintf("[GET /exception/list][%d] listExceptionsBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *ListExceptionsBadRequest) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *ListExceptionsBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewListExceptionsInternalServerError creates a ListExceptionsInternalServerError with default headers values
+func NewListExceptionsInternalServerError() *ListExceptionsInternalServerError {
+	return &ListExceptionsInternalServerError{}
+}
+
+/*ListExceptionsInternalServerError handles this case with default header values.
+
+Internal server error
+*/
+type ListExceptionsInternalServerError struct {
+}
+
+func (o *ListExceptionsInternalServerError) Error() string {
+	return fmt.Sprintf("[GET /exception/list][%d] listExceptionsInternalServerError ", 500)
+}
+
+func (o *ListExceptionsInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
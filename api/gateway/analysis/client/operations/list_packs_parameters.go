@@ -0,0 +1,130 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// NewListPacksParams creates a new ListPacksParams object
+// with the default values initialized.
+func NewListPacksParams() *ListPacksParams {
+
+	return &ListPacksParams{
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewListPacksParamsWithTimeout creates a new ListPacksParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewListPacksParamsWithTimeout(timeout time.Duration) *ListPacksParams {
+
+	return &ListPacksParams{
+
+		timeout: timeout,
+	}
+}
+
+// NewListPacksParamsWithContext creates a new ListPacksParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewListPacksParamsWithContext(ctx context.Context) *ListPacksParams {
+
+	return &ListPacksParams{
+
+		Context: ctx,
+	}
+}
+
+// NewListPacksParamsWithHTTPClient creates a new ListPacksParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewListPacksParamsWithHTTPClient(client *http.Client) *ListPacksParams {
+
+	return &ListPacksParams{
+		HTTPClient: client,
+	}
+}
+
+/*ListPacksParams contains all the parameters to send to the API endpoint
+for the list packs operation typically these are written to a http.Request
+*/
+type ListPacksParams struct {
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the list packs params
+func (o *ListPacksParams) WithTimeout(timeout time.Duration) *ListPacksParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the list packs params
+func (o *ListPacksParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the list packs params
+func (o *ListPacksParams) WithContext(ctx context.Context) *ListPacksParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the list packs params
+func (o *ListPacksParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the list packs params
+func (o *ListPacksParams) WithHTTPClient(client *http.Client) *ListPacksParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the list packs params
+func (o *ListPacksParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *ListPacksParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,441 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// NewListScheduledQueriesParams creates a new ListScheduledQueriesParams object
+// with the default values initialized.
+func NewListScheduledQueriesParams() *ListScheduledQueriesParams {
+	var (
+		pageDefault     = int64(1)
+		pageSizeDefault = int64(25)
+		sortByDefault   = string("severity")
+		sortDirDefault  = string("ascending")
+	)
+	return &ListScheduledQueriesParams{
+		Page:     &pageDefault,
+		PageSize: &pageSizeDefault,
+		SortBy:   &sortByDefault,
+		SortDir:  &sortDirDefault,
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewListScheduledQueriesParamsWithTimeout creates a new ListScheduledQueriesParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewListScheduledQueriesParamsWithTimeout(timeout time.Duration) *ListScheduledQueriesParams {
+	var (
+		pageDefault     = int64(1)
+		pageSizeDefault = int64(25)
+		sortByDefault   = string("severity")
+		sortDirDefault  = string("ascending")
+	)
+	return &ListScheduledQueriesParams{
+		Page:     &pageDefault,
+		PageSize: &pageSizeDefault,
+		SortBy:   &sortByDefault,
+		SortDir:  &sortDirDefault,
+
+		timeout: timeout,
+	}
+}
+
+// NewListScheduledQueriesParamsWithContext creates a new ListScheduledQueriesParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewListScheduledQueriesParamsWithContext(ctx context.Context) *ListScheduledQueriesParams {
+	var (
+		pageDefault     = int64(1)
+		pageSizeDefault = int64(25)
+		sortByDefault   = string("severity")
+		sortDirDefault  = string("ascending")
+	)
+	return &ListScheduledQueriesParams{
+		Page:     &pageDefault,
+		PageSize: &pageSizeDefault,
+		SortBy:   &sortByDefault,
+		SortDir:  &sortDirDefault,
+
+		Context: ctx,
+	}
+}
+
+// NewListScheduledQueriesParamsWithHTTPClient creates a new ListScheduledQueriesParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewListScheduledQueriesParamsWithHTTPClient(client *http.Client) *ListScheduledQueriesParams {
+	var (
+		pageDefault     = int64(1)
+		pageSizeDefault = int64(25)
+		sortByDefault   = string("severity")
+		sortDirDefault  = string("ascending")
+	)
+	return &ListScheduledQueriesParams{
+		Page:       &pageDefault,
+		PageSize:   &pageSizeDefault,
+		SortBy:     &sortByDefault,
+		SortDir:    &sortDirDefault,
+		HTTPClient: client,
+	}
+}
+
+/*ListScheduledQueriesParams contains all the parameters to send to the API endpoint
+for the list scheduled queries operation typically these are written to a http.Request
+*/
+type ListScheduledQueriesParams struct {
+
+	/*Enabled
+	  Only include queries which are enabled or disabled
+
+	*/
+	Enabled *bool
+	/*LogTypes
+	  Only include queries which read one of these log types
+
+	*/
+	LogTypes []string
+	/*NameContains
+	  Only include queries whose ID or display name contains this substring (case-insensitive)
+
+	*/
+	NameContains *string
+	/*Page
+	  Which page of results to retrieve
+
+	*/
+	Page *int64
+	/*PageSize
+	  Number of items in each page of results
+
+	*/
+	PageSize *int64
+	/*Severity
+	  Only include queries with this severity
+
+	*/
+	Severity *string
+	/*SortBy
+	  Name of the field to sort by
+
+	*/
+	SortBy *string
+	/*SortDir
+	  Sort direction
+
+	*/
+	SortDir *string
+	/*Tags
+	  Only include queries with all of these tags (case-insensitive)
+
+	*/
+	Tags []string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the list scheduled queries params
+func (o *ListScheduledQueriesParams) WithTimeout(timeout time.Duration) *ListScheduledQueriesParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the list scheduled queries params
+func (o *ListScheduledQueriesParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the list scheduled queries params
+func (o *ListScheduledQueriesParams) WithContext(ctx context.Context) *ListScheduledQueriesParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the list scheduled queries params
+func (o *ListScheduledQueriesParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the list scheduled queries params
+func (o *ListScheduledQueriesParams) WithHTTPClient(client *http.Client) *ListScheduledQueriesParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the list scheduled queries params
+func (o *ListScheduledQueriesParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithEnabled adds the enabled to the list scheduled queries params
+func (o *ListScheduledQueriesParams) WithEnabled(enabled *bool) *ListScheduledQueriesParams {
+	o.SetEnabled(enabled)
+	return o
+}
+
+// SetEnabled adds the enabled to the list scheduled queries params
+func (o *ListScheduledQueriesParams) SetEnabled(enabled *bool) {
+	o.Enabled = enabled
+}
+
+// WithLogTypes adds the logTypes to the list scheduled queries params
+func (o *ListScheduledQueriesParams) WithLogTypes(logTypes []string) *ListScheduledQueriesParams {
+	o.SetLogTypes(logTypes)
+	return o
+}
+
+// SetLogTypes adds the logTypes to the list scheduled queries params
+func (o *ListScheduledQueriesParams) SetLogTypes(logTypes []string) {
+	o.LogTypes = logTypes
+}
+
+// WithNameContains adds the nameContains to the list scheduled queries params
+func (o *ListScheduledQueriesParams) WithNameContains(nameContains *string) *ListScheduledQueriesParams {
+	o.SetNameContains(nameContains)
+	return o
+}
+
+// SetNameContains adds the nameContains to the list scheduled queries params
+func (o *ListScheduledQueriesParams) SetNameContains(nameContains *string) {
+	o.NameContains = nameContains
+}
+
+// WithPage adds the page to the list scheduled queries params
+func (o *ListScheduledQueriesParams) WithPage(page *int64) *ListScheduledQueriesParams {
+	o.SetPage(page)
+	return o
+}
+
+// SetPage adds the page to the list scheduled queries params
+func (o *ListScheduledQueriesParams) SetPage(page *int64) {
+	o.Page = page
+}
+
+// WithPageSize adds the pageSize to the list scheduled queries params
+func (o *ListScheduledQueriesParams) WithPageSize(pageSize *int64) *ListScheduledQueriesParams {
+	o.SetPageSize(pageSize)
+	return o
+}
+
+// SetPageSize adds the pageSize to the list scheduled queries params
+func (o *ListScheduledQueriesParams) SetPageSize(pageSize *int64) {
+	o.PageSize = pageSize
+}
+
+// WithSeverity adds the severity to the list scheduled queries params
+func (o *ListScheduledQueriesParams) WithSeverity(severity *string) *ListScheduledQueriesParams {
+	o.SetSeverity(severity)
+	return o
+}
+
+// SetSeverity adds the severity to the list scheduled queries params
+func (o *ListScheduledQueriesParams) SetSeverity(severity *string) {
+	o.Severity = severity
+}
+
+// WithSortBy adds the sortBy to the list scheduled queries params
+func (o *ListScheduledQueriesParams) WithSortBy(sortBy *string) *ListScheduledQueriesParams {
+	o.SetSortBy(sortBy)
+	return o
+}
+
+// SetSortBy adds the sortBy to the list scheduled queries params
+func (o *ListScheduledQueriesParams) SetSortBy(sortBy *string) {
+	o.SortBy = sortBy
+}
+
+// WithSortDir adds the sortDir to the list scheduled queries params
+func (o *ListScheduledQueriesParams) WithSortDir(sortDir *string) *ListScheduledQueriesParams {
+	o.SetSortDir(sortDir)
+	return o
+}
+
+// SetSortDir adds the sortDir to the list scheduled queries params
+func (o *ListScheduledQueriesParams) SetSortDir(sortDir *string) {
+	o.SortDir = sortDir
+}
+
+// WithTags adds the tags to the list scheduled queries params
+func (o *ListScheduledQueriesParams) WithTags(tags []string) *ListScheduledQueriesParams {
+	o.SetTags(tags)
+	return o
+}
+
+// SetTags adds the tags to the list scheduled queries params
+func (o *ListScheduledQueriesParams) SetTags(tags []string) {
+	o.Tags = tags
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *ListScheduledQueriesParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	if o.Enabled != nil {
+
+		// query param enabled
+		var qrEnabled bool
+		if o.Enabled != nil {
+			qrEnabled = *o.Enabled
+		}
+		qEnabled := swag.FormatBool(qrEnabled)
+		if qEnabled != "" {
+			if err := r.SetQueryParam("enabled", qEnabled); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	valuesLogTypes := o.LogTypes
+
+	joinedLogTypes := swag.JoinByFormat(valuesLogTypes, "csv")
+	// query array param logTypes
+	if err := r.SetQueryParam("logTypes", joinedLogTypes...); err != nil {
+		return err
+	}
+
+	if o.NameContains != nil {
+
+		// query param nameContains
+		var qrNameContains string
+		if o.NameContains != nil {
+			qrNameContains = *o.NameContains
+		}
+		qNameContains := qrNameContains
+		if qNameContains != "" {
+			if err := r.SetQueryParam("nameContains", qNameContains); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.Page != nil {
+
+		// query param page
+		var qrPage int64
+		if o.Page != nil {
+			qrPage = *o.Page
+		}
+		qPage := swag.FormatInt64(qrPage)
+		if qPage != "" {
+			if err := r.SetQueryParam("page", qPage); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.PageSize != nil {
+
+		// query param pageSize
+		var qrPageSize int64
+		if o.PageSize != nil {
+			qrPageSize = *o.PageSize
+		}
+		qPageSize := swag.FormatInt64(qrPageSize)
+		if qPageSize != "" {
+			if err := r.SetQueryParam("pageSize", qPageSize); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.Severity != nil {
+
+		// query param severity
+		var qrSeverity string
+		if o.Severity != nil {
+			qrSeverity = *o.Severity
+		}
+		qSeverity := qrSeverity
+		if qSeverity != "" {
+			if err := r.SetQueryParam("severity", qSeverity); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.SortBy != nil {
+
+		// query param sortBy
+		var qrSortBy string
+		if o.SortBy != nil {
+			qrSortBy = *o.SortBy
+		}
+		qSortBy := qrSortBy
+		if qSortBy != "" {
+			if err := r.SetQueryParam("sortBy", qSortBy); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.SortDir != nil {
+
+		// query param sortDir
+		var qrSortDir string
+		if o.SortDir != nil {
+			qrSortDir = *o.SortDir
+		}
+		qSortDir := qrSortDir
+		if qSortDir != "" {
+			if err := r.SetQueryParam("sortDir", qSortDir); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	valuesTags := o.Tags
+
+	joinedTags := swag.JoinByFormat(valuesTags, "csv")
+	// query array param tags
+	if err := r.SetQueryParam("tags", joinedTags...); err != nil {
+		return err
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+)
+
+// ListScheduledQueriesReader is a Reader for the ListScheduledQueries structure.
+type ListScheduledQueriesReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *ListScheduledQueriesReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewListScheduledQueriesOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewListScheduledQueriesBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewListScheduledQueriesInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewListScheduledQueriesOK creates a ListScheduledQueriesOK with default headers values
+func NewListScheduledQueriesOK() *ListScheduledQueriesOK {
+	return &ListScheduledQueriesOK{}
+}
+
+/*ListScheduledQueriesOK handles this case with default header values.
+
+OK
+*/
+type ListScheduledQueriesOK struct {
+	Payload *models.RuleList
+}
+
+func (o *ListScheduledQueriesOK) Error() string {
+	return fmt.Sprintf("[GET /query/list][%d] listScheduledQueriesOK  %+v", 200, o.Payload)
+}
+
+func (o *ListScheduledQueriesOK) GetPayload() *models.RuleList {
+	return o.Payload
+}
+
+func (o *ListScheduledQueriesOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.RuleList)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewListScheduledQueriesBadRequest creates a ListScheduledQueriesBadRequest with default headers values
+func NewListScheduledQueriesBadRequest() *ListScheduledQueriesBadRequest {
+	return &ListScheduledQueriesBadRequest{}
+}
+
+/*ListScheduledQueriesBadRequest handles this case with default header values.
+
+Bad request
+*/
+type ListScheduledQueriesBadRequest struct {
+	Payload *models.Error
+}
+
+func (o *ListScheduledQueriesBadRequest) Error() string {
+	return fmt.Sprintf("[GET /query/list][%d] listScheduledQueriesBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *ListScheduledQueriesBadRequest) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *ListScheduledQueriesBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewListScheduledQueriesInternalServerError creates a ListScheduledQueriesInternalServerError with default headers values
+func NewListScheduledQueriesInternalServerError() *ListScheduledQueriesInternalServerError {
+	return &ListScheduledQueriesInternalServerError{}
+}
+
+/*ListScheduledQueriesInternalServerError handles this case with default header values.
+
+Internal server error
+*/
+type ListScheduledQueriesInternalServerError struct {
+}
+
+func (o *ListScheduledQueriesInternalServerError) Error() string {
+	return fmt.Sprintf("[GET /query/list][%d] listScheduledQueriesInternalServerError ", 500)
+}
+
+func (o *ListScheduledQueriesInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
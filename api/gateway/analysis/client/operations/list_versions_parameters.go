@@ -0,0 +1,234 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// NewListVersionsParams creates a new ListVersionsParams object
+// with the default values initialized.
+func NewListVersionsParams() *ListVersionsParams {
+	var (
+		pageSizeDefault = int64(25)
+	)
+	return &ListVersionsParams{
+		PageSize: &pageSizeDefault,
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewListVersionsParamsWithTimeout creates a new ListVersionsParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewListVersionsParamsWithTimeout(timeout time.Duration) *ListVersionsParams {
+	var (
+		pageSizeDefault = int64(25)
+	)
+	return &ListVersionsParams{
+		PageSize: &pageSizeDefault,
+
+		timeout: timeout,
+	}
+}
+
+// NewListVersionsParamsWithContext creates a new ListVersionsParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewListVersionsParamsWithContext(ctx context.Context) *ListVersionsParams {
+	var (
+		pageSizeDefault = int64(25)
+	)
+	return &ListVersionsParams{
+		PageSize: &pageSizeDefault,
+
+		Context: ctx,
+	}
+}
+
+// NewListVersionsParamsWithHTTPClient creates a new ListVersionsParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewListVersionsParamsWithHTTPClient(client *http.Client) *ListVersionsParams {
+	var (
+		pageSizeDefault = int64(25)
+	)
+	return &ListVersionsParams{
+		PageSize:   &pageSizeDefault,
+		HTTPClient: client,
+	}
+}
+
+/*ListVersionsParams contains all the parameters to send to the API endpoint
+for the list versions operation typically these are written to a http.Request
+*/
+type ListVersionsParams struct {
+
+	/*AnalysisID
+	  Unique ASCII policy, rule or global identifier
+
+	*/
+	AnalysisID string
+	/*PageSize
+	  Number of versions in each page of results
+
+	*/
+	PageSize *int64
+	/*VersionIDMarker
+	  Return versions older than this one (from nextVersionIdMarker)
+
+	*/
+	VersionIDMarker *string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the list versions params
+func (o *ListVersionsParams) WithTimeout(timeout time.Duration) *ListVersionsParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the list versions params
+func (o *ListVersionsParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the list versions params
+func (o *ListVersionsParams) WithContext(ctx context.Context) *ListVersionsParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the list versions params
+func (o *ListVersionsParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the list versions params
+func (o *ListVersionsParams) WithHTTPClient(client *http.Client) *ListVersionsParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the list versions params
+func (o *ListVersionsParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithAnalysisID adds the analysisID to the list versions params
+func (o *ListVersionsParams) WithAnalysisID(analysisID string) *ListVersionsParams {
+	o.SetAnalysisID(analysisID)
+	return o
+}
+
+// SetAnalysisID adds the analysisId to the list versions params
+func (o *ListVersionsParams) SetAnalysisID(analysisID string) {
+	o.AnalysisID = analysisID
+}
+
+// WithPageSize adds the pageSize to the list versions params
+func (o *ListVersionsParams) WithPageSize(pageSize *int64) *ListVersionsParams {
+	o.SetPageSize(pageSize)
+	return o
+}
+
+// SetPageSize adds the pageSize to the list versions params
+func (o *ListVersionsParams) SetPageSize(pageSize *int64) {
+	o.PageSize = pageSize
+}
+
+// WithVersionIDMarker adds the versionIDMarker to the list versions params
+func (o *ListVersionsParams) WithVersionIDMarker(versionIDMarker *string) *ListVersionsParams {
+	o.SetVersionIDMarker(versionIDMarker)
+	return o
+}
+
+// SetVersionIDMarker adds the versionIdMarker to the list versions params
+func (o *ListVersionsParams) SetVersionIDMarker(versionIDMarker *string) {
+	o.VersionIDMarker = versionIDMarker
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *ListVersionsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	// query param analysisId
+	qrAnalysisID := o.AnalysisID
+	qAnalysisID := qrAnalysisID
+	if qAnalysisID != "" {
+		if err := r.SetQueryParam("analysisId", qAnalysisID); err != nil {
+			return err
+		}
+	}
+
+	if o.PageSize != nil {
+
+		// query param pageSize
+		var qrPageSize int64
+		if o.PageSize != nil {
+			qrPageSize = *o.PageSize
+		}
+		qPageSize := swag.FormatInt64(qrPageSize)
+		if qPageSize != "" {
+			if err := r.SetQueryParam("pageSize", qPageSize); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.VersionIDMarker != nil {
+
+		// query param versionIdMarker
+		var qrVersionIDMarker string
+		if o.VersionIDMarker != nil {
+			qrVersionIDMarker = *o.VersionIDMarker
+		}
+		qVersionIDMarker := qrVersionIDMarker
+		if qVersionIDMarker != "" {
+			if err := r.SetQueryParam("versionIdMarker", qVersionIDMarker); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
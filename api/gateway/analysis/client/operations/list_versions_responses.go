@@ -0,0 +1,180 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+)
+
+// ListVersionsReader is a Reader for the ListVersions structure.
+type ListVersionsReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *ListVersionsReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewListVersionsOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewListVersionsBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 404:
+		result := NewListVersionsNotFound()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewListVersionsInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewListVersionsOK creates a ListVersionsOK with default headers values
+func NewListVersionsOK() *ListVersionsOK {
+	return &ListVersionsOK{}
+}
+
+/*ListVersionsOK handles this case with default header values.
+
+OK
+*/
+type ListVersionsOK struct {
+	Payload *models.VersionList
+}
+
+func (o *ListVersionsOK) Error() string {
+	return fmt.Sprintf("[GET /version/list][%d] listVersionsOK  %+v", 200, o.Payload)
+}
+
+func (o *ListVersionsOK) GetPayload() *models.VersionList {
+	return o.Payload
+}
+
+func (o *ListVersionsOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.VersionList)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewListVersionsBadRequest creates a ListVersionsBadRequest with default headers values
+func NewListVersionsBadRequest() *ListVersionsBadRequest {
+	return &ListVersionsBadRequest{}
+}
+
+/*ListVersionsBadRequest handles this case with default header values.
+
+Bad request
+*/
+type ListVersionsBadRequest struct {
+	Payload *models.Error
+}
+
+func (o *ListVersionsBadRequest) Error() string {
+	return fmt.Sprintf("[GET /version/list][%d] listVersionsBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *ListVersionsBadRequest) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *ListVersionsBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewListVersionsNotFound creates a ListVersionsNotFound with default headers values
+func NewListVersionsNotFound() *ListVersionsNotFound {
+	return &ListVersionsNotFound{}
+}
+
+/*ListVersionsNotFound handles this case with default header values.
+
+Policy, rule or global does not exist
+*/
+type ListVersionsNotFound struct {
+}
+
+func (o *ListVersionsNotFound) Error() string {
+	return fmt.Sprintf("[GET /version/list][%d] listVersionsNotFound ", 404)
+}
+
+func (o *ListVersionsNotFound) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// NewListVersionsInternalServerError creates a ListVersionsInternalServerError with default headers values
+func NewListVersionsInternalServerError() *ListVersionsInternalServerError {
+	return &ListVersionsInternalServerError{}
+}
+
+/*ListVersionsInternalServerError handles this case with default header values.
+
+Internal server error
+*/
+type ListVersionsInternalServerError struct {
+}
+
+func (o *ListVersionsInternalServerError) Error() string {
+	return fmt.Sprintf("[GET /version/list][%d] listVersionsInternalServerError ", 500)
+}
+
+func (o *ListVersionsInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
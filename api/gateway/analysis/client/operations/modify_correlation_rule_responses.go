@@ -0,0 +1,180 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+)
+
+// ModifyCorrelationRuleReader is a Reader for the ModifyCorrelationRule structure.
+type ModifyCorrelationRuleReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *ModifyCorrelationRuleReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewModifyCorrelationRuleOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewModifyCorrelationRuleBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 404:
+		result := NewModifyCorrelationRuleNotFound()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewModifyCorrelationRuleInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewModifyCorrelationRuleOK creates a ModifyCorrelationRuleOK with default headers values
+func NewModifyCorrelationRuleOK() *ModifyCorrelationRuleOK {
+	return &ModifyCorrelationRuleOK{}
+}
+
+/*ModifyCorrelationRuleOK handles this case with default header values.
+
+OK
+*/
+type ModifyCorrelationRuleOK struct {
+	Payload *models.Rule
+}
+
+func (o *ModifyCorrelationRuleOK) Error() string {
+	return fmt.Sprintf("[POST /correlation/update][%d] modifyCorrelationRuleOK  %+v", 200, o.Payload)
+}
+
+func (o *ModifyCorrelationRuleOK) GetPayload() *models.Rule {
+	return o.Payload
+}
+
+func (o *ModifyCorrelationRuleOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Rule)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewModifyCorrelationRuleBadRequest creates a ModifyCorrelationRuleBadRequest with default headers values
+func NewModifyCorrelationRuleBadRequest() *ModifyCorrelationRuleBadRequest {
+	return &ModifyCorrelationRuleBadRequest{}
+}
+
+/*ModifyCorrelationRuleBadRequest handles this case with default header values.
+
+Bad request
+*/
+type ModifyCorrelationRuleBadRequest struct {
+	Payload *models.Error
+}
+
+func (o *ModifyCorrelationRuleBadRequest) Error() string {
+	return fmt.Sprintf("[POST /correlation/update][%d] modifyCorrelationRuleBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *ModifyCorrelationRuleBadRequest) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *ModifyCorrelationRuleBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewModifyCorrelationRuleNotFound creates a ModifyCorrelationRuleNotFound with default headers values
+func NewModifyCorrelationRuleNotFound() *ModifyCorrelationRuleNotFound {
+	return &ModifyCorrelationRuleNotFound{}
+}
+
+/*ModifyCorrelationRuleNotFound handles this case with default header values.
+
+Correlation rule not found
+*/
+type ModifyCorrelationRuleNotFound struct {
+}
+
+func (o *ModifyCorrelationRuleNotFound) Error() string {
+	return fmt.Sprintf("[POST /correlation/update][%d] modifyCorrelationRuleNotFound ", 404)
+}
+
+func (o *ModifyCorrelationRuleNotFound) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// NewModifyCorrelationRuleInternalServerError creates a ModifyCorrelationRuleInternalServerError with default headers values
+func NewModifyCorrelationRuleInternalServerError() *ModifyCorrelationRuleInternalServerError {
+	return &ModifyCorrelationRuleInternalServerError{}
+}
+
+/*ModifyCorrelationRuleInternalServerError handles this case with default header values.
+
+Internal server error
+*/
+type ModifyCorrelationRuleInternalServerError struct {
+}
+
+func (o *ModifyCorrelationRuleInternalServerError) Error() string {
+	return fmt.Sprintf("[POST /correlation/update][%d] modifyCorrelationRuleInternalServerError ", 500)
+}
+
+func (o *ModifyCorrelationRuleInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
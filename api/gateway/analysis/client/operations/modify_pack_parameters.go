@@ -0,0 +1,153 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+)
+
+// NewModifyPackParams creates a new ModifyPackParams object
+// with the default values initialized.
+func NewModifyPackParams() *ModifyPackParams {
+	var ()
+	return &ModifyPackParams{
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewModifyPackParamsWithTimeout creates a new ModifyPackParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewModifyPackParamsWithTimeout(timeout time.Duration) *ModifyPackParams {
+	var ()
+	return &ModifyPackParams{
+
+		timeout: timeout,
+	}
+}
+
+// NewModifyPackParamsWithContext creates a new ModifyPackParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewModifyPackParamsWithContext(ctx context.Context) *ModifyPackParams {
+	var ()
+	return &ModifyPackParams{
+
+		Context: ctx,
+	}
+}
+
+// NewModifyPackParamsWithHTTPClient creates a new ModifyPackParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewModifyPackParamsWithHTTPClient(client *http.Client) *ModifyPackParams {
+	var ()
+	return &ModifyPackParams{
+		HTTPClient: client,
+	}
+}
+
+/*ModifyPackParams contains all the parameters to send to the API endpoint
+for the modify pack operation typically these are written to a http.Request
+*/
+type ModifyPackParams struct {
+
+	/*Body*/
+	Body *models.UpdatePack
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the modify pack params
+func (o *ModifyPackParams) WithTimeout(timeout time.Duration) *ModifyPackParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the modify pack params
+func (o *ModifyPackParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the modify pack params
+func (o *ModifyPackParams) WithContext(ctx context.Context) *ModifyPackParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the modify pack params
+func (o *ModifyPackParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the modify pack params
+func (o *ModifyPackParams) WithHTTPClient(client *http.Client) *ModifyPackParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the modify pack params
+func (o *ModifyPackParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithBody adds the body to the modify pack params
+func (o *ModifyPackParams) WithBody(body *models.UpdatePack) *ModifyPackParams {
+	o.SetBody(body)
+	return o
+}
+
+// SetBody adds the body to the modify pack params
+func (o *ModifyPackParams) SetBody(body *models.UpdatePack) {
+	o.Body = body
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *ModifyPackParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	if o.Body != nil {
+		if err := r.SetBodyParam(o.Body); err != nil {
+			return err
+		}
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+)
+
+// ModifyPackReader is a Reader for the ModifyPack structure.
+type ModifyPackReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *ModifyPackReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewModifyPackOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewModifyPackBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 404:
+		result := NewModifyPackNotFound()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 409:
+		result := NewModifyPackConflict()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewModifyPackInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewModifyPackOK creates a ModifyPackOK with default headers values
+func NewModifyPackOK() *ModifyPackOK {
+	return &ModifyPackOK{}
+}
+
+/*ModifyPackOK handles this case with default header values.
+
+OK
+*/
+type ModifyPackOK struct {
+	Payload *models.Pack
+}
+
+func (o *ModifyPackOK) Error() string {
+	return fmt.Sprintf("[POST /pack/update][%d] modifyPackOK  %+v", 200, o.Payload)
+}
+
+func (o *ModifyPackOK) GetPayload() *models.Pack {
+	return o.Payload
+}
+
+func (o *ModifyPackOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Pack)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewModifyPackBadRequest creates a ModifyPackBadRequest with default headers values
+func NewModifyPackBadRequest() *ModifyPackBadRequest {
+	return &ModifyPackBadRequest{}
+}
+
+/*ModifyPackBadRequest handles this case with default header values.
+
+Bad request
+*/
+type ModifyPackBadRequest struct {
+	Payload *models.Error
+}
+
+func (o *ModifyPackBadRequest) Error() string {
+	return fmt.Sprintf("[POST /pack/update][%d] modifyPackBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *ModifyPackBadRequest) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *ModifyPackBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewModifyPackNotFound creates a ModifyPackNotFound with default headers values
+func NewModifyPackNotFound() *ModifyPackNotFound {
+	return &ModifyPackNotFound{}
+}
+
+/*ModifyPackNotFound handles this case with default header values.
+
+Pack not found
+*/
+type ModifyPackNotFound struct {
+}
+
+func (o *ModifyPackNotFound) Error() string {
+	return fmt.Sprintf("[POST /pack/update][%d] modifyPackNotFound ", 404)
+}
+
+func (o *ModifyPackNotFound) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// NewModifyPackConflict creates a ModifyPackConflict with default headers values
+func NewModifyPackConflict() *ModifyPackConflict {
+	return &ModifyPackConflict{}
+}
+
+/*ModifyPackConflict handles this case with default header values.
+
+An item was changed concurrently
+*/
+type ModifyPackConflict struct {
+	Payload *models.Error
+}
+
+func (o *ModifyPackConflict) Error() string {
+	return fmt.Sprintf("[POST /pack/update][%d] modifyPackConflict  %+v", 409, o.Payload)
+}
+
+func (o *ModifyPackConflict) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *ModifyPackConflict) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewModifyPackInternalServerError creates a ModifyPackInternalServerError with default headers values
+func NewModifyPackInternalServerError() *ModifyPackInternalServerError {
+	return &ModifyPackInternalServerError{}
+}
+
+/*ModifyPackInternalServerError handles this case with default header values.
+
+Internal server error
+*/
+type ModifyPackInternalServerError struct {
+}
+
+func (o *ModifyPackInternalServerError) Error() string {
+	return fmt.Sprintf("[POST /pack/update][%d] modifyPackInternalServerError ", 500)
+}
+
+func (o *ModifyPackInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
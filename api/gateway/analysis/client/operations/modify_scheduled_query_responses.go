@@ -0,0 +1,180 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+)
+
+// ModifyScheduledQueryReader is a Reader for the ModifyScheduledQuery structure.
+type ModifyScheduledQueryReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *ModifyScheduledQueryReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewModifyScheduledQueryOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewModifyScheduledQueryBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 404:
+		result := NewModifyScheduledQueryNotFound()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewModifyScheduledQueryInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewModifyScheduledQueryOK creates a ModifyScheduledQueryOK with default headers values
+func NewModifyScheduledQueryOK() *ModifyScheduledQueryOK {
+	return &ModifyScheduledQueryOK{}
+}
+
+/*ModifyScheduledQueryOK handles this case with default header values.
+
+OK
+*/
+type ModifyScheduledQueryOK struct {
+	Payload *models.Rule
+}
+
+func (o *ModifyScheduledQueryOK) Error() string {
+	return fmt.Sprintf("[POST /query/update][%d] modifyScheduledQueryOK  %+v", 200, o.Payload)
+}
+
+func (o *ModifyScheduledQueryOK) GetPayload() *models.Rule {
+	return o.Payload
+}
+
+func (o *ModifyScheduledQueryOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Rule)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewModifyScheduledQueryBadRequest creates a ModifyScheduledQueryBadRequest with default headers values
+func NewModifyScheduledQueryBadRequest() *ModifyScheduledQueryBadRequest {
+	return &ModifyScheduledQueryBadRequest{}
+}
+
+/*ModifyScheduledQueryBadRequest handles this case with default header values.
+
+Bad request
+*/
+type ModifyScheduledQueryBadRequest struct {
+	Payload *models.Error
+}
+
+func (o *ModifyScheduledQueryBadRequest) Error() string {
+	return fmt.Sprintf("[POST /query/update][%d] modifyScheduledQueryBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *ModifyScheduledQueryBadRequest) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *ModifyScheduledQueryBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewModifyScheduledQueryNotFound creates a ModifyScheduledQueryNotFound with default headers values
+func NewModifyScheduledQueryNotFound() *ModifyScheduledQueryNotFound {
+	return &ModifyScheduledQueryNotFound{}
+}
+
+/*ModifyScheduledQueryNotFound handles this case with default header values.
+
+Scheduled query not found
+*/
+type ModifyScheduledQueryNotFound struct {
+}
+
+func (o *ModifyScheduledQueryNotFound) Error() string {
+	return fmt.Sprintf("[POST /query/update][%d] modifyScheduledQueryNotFound ", 404)
+}
+
+func (o *ModifyScheduledQueryNotFound) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// NewModifyScheduledQueryInternalServerError creates a ModifyScheduledQueryInternalServerError with default headers values
+func NewModifyScheduledQueryInternalServerError() *ModifyScheduledQueryInternalServerError {
+	return &ModifyScheduledQueryInternalServerError{}
+}
+
+/*ModifyScheduledQueryInternalServerError handles this case with default header values.
+
+Internal server error
+*/
+type ModifyScheduledQueryInternalServerError struct {
+}
+
+func (o *ModifyScheduledQueryInternalServerError) Error() string {
+	return fmt.Sprintf("[POST /query/update][%d] modifyScheduledQueryInternalServerError ", 500)
+}
+
+func (o *ModifyScheduledQueryInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
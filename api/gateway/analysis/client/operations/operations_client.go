@@ -45,38 +45,82 @@ type Client struct {
 
 // ClientService is the interface for Client methods
 type ClientService interface {
+	BacktestRule(params *BacktestRuleParams) (*BacktestRuleAccepted, error)
+
 	BulkUpload(params *BulkUploadParams) (*BulkUploadOK, error)
 
+	CreateCorrelationRule(params *CreateCorrelationRuleParams) (*CreateCorrelationRuleCreated, error)
+
+	CreateException(params *CreateExceptionParams) (*CreateExceptionCreated, error)
+
 	CreateGlobal(params *CreateGlobalParams) (*CreateGlobalCreated, error)
 
 	CreatePolicy(params *CreatePolicyParams) (*CreatePolicyCreated, error)
 
 	CreateRule(params *CreateRuleParams) (*CreateRuleCreated, error)
 
+	CreateScheduledQuery(params *CreateScheduledQueryParams) (*CreateScheduledQueryCreated, error)
+
+	DeleteExceptions(params *DeleteExceptionsParams) (*DeleteExceptionsOK, error)
+
 	DeleteGlobals(params *DeleteGlobalsParams) (*DeleteGlobalsOK, error)
 
 	DeletePolicies(params *DeletePoliciesParams) (*DeletePoliciesOK, error)
 
+	ExportAnalysis(params *ExportAnalysisParams) (*ExportAnalysisOK, error)
+
+	GetBacktest(params *GetBacktestParams) (*GetBacktestOK, error)
+
+	GetCorrelationRule(params *GetCorrelationRuleParams) (*GetCorrelationRuleOK, error)
+
 	GetEnabledPolicies(params *GetEnabledPoliciesParams) (*GetEnabledPoliciesOK, error)
 
 	GetGlobal(params *GetGlobalParams) (*GetGlobalOK, error)
 
+	GetPack(params *GetPackParams) (*GetPackOK, error)
+
 	GetPolicy(params *GetPolicyParams) (*GetPolicyOK, error)
 
 	GetRule(params *GetRuleParams) (*GetRuleOK, error)
 
+	GetScheduledQuery(params *GetScheduledQueryParams) (*GetScheduledQueryOK, error)
+
+	GetVersionDiff(params *GetVersionDiffParams) (*GetVersionDiffOK, error)
+
+	InstallPack(params *InstallPackParams) (*InstallPackOK, error)
+
+	ListCorrelationRules(params *ListCorrelationRulesParams) (*ListCorrelationRulesOK, error)
+
+	ListDataModels(params *ListDataModelsParams) (*ListDataModelsOK, error)
+
+	ListExceptions(params *ListExceptionsParams) (*ListExceptionsOK, error)
+
 	ListGlobals(params *ListGlobalsParams) (*ListGlobalsOK, error)
 
+	ListPacks(params *ListPacksParams) (*ListPacksOK, error)
+
 	ListPolicies(params *ListPoliciesParams) (*ListPoliciesOK, error)
 
 	ListRules(params *ListRulesParams) (*ListRulesOK, error)
 
+	ListScheduledQueries(params *ListScheduledQueriesParams) (*ListScheduledQueriesOK, error)
+
+	ListVersions(params *ListVersionsParams) (*ListVersionsOK, error)
+
+	ModifyCorrelationRule(params *ModifyCorrelationRuleParams) (*ModifyCorrelationRuleOK, error)
+
 	ModifyGlobal(params *ModifyGlobalParams) (*ModifyGlobalOK, error)
 
+	ModifyPack(params *ModifyPackParams) (*ModifyPackOK, error)
+
 	ModifyPolicy(params *ModifyPolicyParams) (*ModifyPolicyOK, error)
 
 	ModifyRule(params *ModifyRuleParams) (*ModifyRuleOK, error)
 
+	ModifyScheduledQuery(params *ModifyScheduledQueryParams) (*ModifyScheduledQueryOK, error)
+
+	RevertToVersion(params *RevertToVersionParams) (*RevertToVersionOK, error)
+
 	Suppress(params *SuppressParams) (*SuppressOK, error)
 
 	TestPolicy(params *TestPolicyParams) (*TestPolicyOK, error)
@@ -84,6 +128,40 @@ type ClientService interface {
 	SetTransport(transport runtime.ClientTransport)
 }
 
+/*
+  BacktestRule starts running a rule against historical logs
+*/
+func (a *Client) BacktestRule(params *BacktestRuleParams) (*BacktestRuleAccepted, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewBacktestRuleParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "BacktestRule",
+		Method:             "POST",
+		PathPattern:        "/rule/backtest",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &BacktestRuleReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*BacktestRuleAccepted)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for BacktestRule: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
   BulkUpload uploads a zipfile containing a bundle of policies
 */
@@ -118,6 +196,74 @@ func (a *Client) BulkUpload(params *BulkUploadParams) (*BulkUploadOK, error) {
 	panic(msg)
 }
 
+/*
+  CreateCorrelationRule creates a new correlation rule
+*/
+func (a *Client) CreateCorrelationRule(params *CreateCorrelationRuleParams) (*CreateCorrelationRuleCreated, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewCreateCorrelationRuleParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "CreateCorrelationRule",
+		Method:             "POST",
+		PathPattern:        "/correlation",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &CreateCorrelationRuleReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*CreateCorrelationRuleCreated)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for CreateCorrelationRule: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  CreateException grants an exception from a policy for resources matching a pattern
+*/
+func (a *Client) CreateException(params *CreateExceptionParams) (*CreateExceptionCreated, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewCreateExceptionParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "CreateException",
+		Method:             "POST",
+		PathPattern:        "/exception",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &CreateExceptionReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*CreateExceptionCreated)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for CreateException: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
   CreateGlobal creates a new global module
 */
@@ -221,410 +367,1056 @@ func (a *Client) CreateRule(params *CreateRuleParams) (*CreateRuleCreated, error
 }
 
 /*
-  DeleteGlobals deletes one or more globals
+  CreateScheduledQuery creates a new scheduled query
 */
-func (a *Client) DeleteGlobals(params *DeleteGlobalsParams) (*DeleteGlobalsOK, error) {
+func (a *Client) CreateScheduledQuery(params *CreateScheduledQueryParams) (*CreateScheduledQueryCreated, error) {
 	// TODO: Validate the params before sending
 	if params == nil {
-		params = NewDeleteGlobalsParams()
+		params = NewCreateScheduledQueryParams()
 	}
 
 	result, err := a.transport.Submit(&runtime.ClientOperation{
-		ID:                 "DeleteGlobals",
+		ID:                 "CreateScheduledQuery",
 		Method:             "POST",
-		PathPattern:        "/global/delete",
+		PathPattern:        "/query",
 		ProducesMediaTypes: []string{"application/json"},
 		ConsumesMediaTypes: []string{"application/json"},
 		Schemes:            []string{"https"},
 		Params:             params,
-		Reader:             &DeleteGlobalsReader{formats: a.formats},
+		Reader:             &CreateScheduledQueryReader{formats: a.formats},
 		Context:            params.Context,
 		Client:             params.HTTPClient,
 	})
 	if err != nil {
 		return nil, err
 	}
-	success, ok := result.(*DeleteGlobalsOK)
+	success, ok := result.(*CreateScheduledQueryCreated)
 	if ok {
 		return success, nil
 	}
 	// unexpected success response
 	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
-	msg := fmt.Sprintf("unexpected success response for DeleteGlobals: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	msg := fmt.Sprintf("unexpected success response for CreateScheduledQuery: API contract not enforced by server. Client expected to get an error, but got: %T", result)
 	panic(msg)
 }
 
 /*
-  DeletePolicies deletes one or more policies rules
+  DeleteExceptions revokes policy exceptions
 */
-func (a *Client) DeletePolicies(params *DeletePoliciesParams) (*DeletePoliciesOK, error) {
+func (a *Client) DeleteExceptions(params *DeleteExceptionsParams) (*DeleteExceptionsOK, error) {
 	// TODO: Validate the params before sending
 	if params == nil {
-		params = NewDeletePoliciesParams()
+		params = NewDeleteExceptionsParams()
 	}
 
 	result, err := a.transport.Submit(&runtime.ClientOperation{
-		ID:                 "DeletePolicies",
+		ID:                 "DeleteExceptions",
 		Method:             "POST",
-		PathPattern:        "/delete",
+		PathPattern:        "/exception/delete",
 		ProducesMediaTypes: []string{"application/json"},
 		ConsumesMediaTypes: []string{"application/json"},
 		Schemes:            []string{"https"},
 		Params:             params,
-		Reader:             &DeletePoliciesReader{formats: a.formats},
+		Reader:             &DeleteExceptionsReader{formats: a.formats},
 		Context:            params.Context,
 		Client:             params.HTTPClient,
 	})
 	if err != nil {
 		return nil, err
 	}
-	success, ok := result.(*DeletePoliciesOK)
+	success, ok := result.(*DeleteExceptionsOK)
 	if ok {
 		return success, nil
 	}
 	// unexpected success response
 	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
-	msg := fmt.Sprintf("unexpected success response for DeletePolicies: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	msg := fmt.Sprintf("unexpected success response for DeleteExceptions: API contract not enforced by server. Client expected to get an error, but got: %T", result)
 	panic(msg)
 }
 
 /*
-  GetEnabledPolicies lists all enabled rules policies for a customer account for backend processing
+  DeleteGlobals deletes one or more globals
 */
-func (a *Client) GetEnabledPolicies(params *GetEnabledPoliciesParams) (*GetEnabledPoliciesOK, error) {
+func (a *Client) DeleteGlobals(params *DeleteGlobalsParams) (*DeleteGlobalsOK, error) {
 	// TODO: Validate the params before sending
 	if params == nil {
-		params = NewGetEnabledPoliciesParams()
+		params = NewDeleteGlobalsParams()
 	}
 
 	result, err := a.transport.Submit(&runtime.ClientOperation{
-		ID:                 "GetEnabledPolicies",
-		Method:             "GET",
-		PathPattern:        "/enabled",
+		ID:                 "DeleteGlobals",
+		Method:             "POST",
+		PathPattern:        "/global/delete",
 		ProducesMediaTypes: []string{"application/json"},
 		ConsumesMediaTypes: []string{"application/json"},
 		Schemes:            []string{"https"},
 		Params:             params,
-		Reader:             &GetEnabledPoliciesReader{formats: a.formats},
+		Reader:             &DeleteGlobalsReader{formats: a.formats},
 		Context:            params.Context,
 		Client:             params.HTTPClient,
 	})
 	if err != nil {
 		return nil, err
 	}
-	success, ok := result.(*GetEnabledPoliciesOK)
+	success, ok := result.(*DeleteGlobalsOK)
 	if ok {
 		return success, nil
 	}
 	// unexpected success response
 	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
-	msg := fmt.Sprintf("unexpected success response for GetEnabledPolicies: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	msg := fmt.Sprintf("unexpected success response for DeleteGlobals: API contract not enforced by server. Client expected to get an error, but got: %T", result)
 	panic(msg)
 }
 
 /*
-  GetGlobal gets global details
+  DeletePolicies deletes one or more policies rules
 */
-func (a *Client) GetGlobal(params *GetGlobalParams) (*GetGlobalOK, error) {
+func (a *Client) DeletePolicies(params *DeletePoliciesParams) (*DeletePoliciesOK, error) {
 	// TODO: Validate the params before sending
 	if params == nil {
-		params = NewGetGlobalParams()
+		params = NewDeletePoliciesParams()
 	}
 
 	result, err := a.transport.Submit(&runtime.ClientOperation{
-		ID:                 "GetGlobal",
-		Method:             "GET",
-		PathPattern:        "/global",
+		ID:                 "DeletePolicies",
+		Method:             "POST",
+		PathPattern:        "/delete",
 		ProducesMediaTypes: []string{"application/json"},
 		ConsumesMediaTypes: []string{"application/json"},
 		Schemes:            []string{"https"},
 		Params:             params,
-		Reader:             &GetGlobalReader{formats: a.formats},
+		Reader:             &DeletePoliciesReader{formats: a.formats},
 		Context:            params.Context,
 		Client:             params.HTTPClient,
 	})
 	if err != nil {
 		return nil, err
 	}
-	success, ok := result.(*GetGlobalOK)
+	success, ok := result.(*DeletePoliciesOK)
 	if ok {
 		return success, nil
 	}
 	// unexpected success response
 	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
-	msg := fmt.Sprintf("unexpected success response for GetGlobal: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	msg := fmt.Sprintf("unexpected success response for DeletePolicies: API contract not enforced by server. Client expected to get an error, but got: %T", result)
 	panic(msg)
 }
 
 /*
-  GetPolicy gets policy details
+  ExportAnalysis exports a bundle of policies rules and globals
 */
-func (a *Client) GetPolicy(params *GetPolicyParams) (*GetPolicyOK, error) {
+func (a *Client) ExportAnalysis(params *ExportAnalysisParams) (*ExportAnalysisOK, error) {
 	// TODO: Validate the params before sending
 	if params == nil {
-		params = NewGetPolicyParams()
+		params = NewExportAnalysisParams()
 	}
 
 	result, err := a.transport.Submit(&runtime.ClientOperation{
-		ID:                 "GetPolicy",
+		ID:                 "ExportAnalysis",
 		Method:             "GET",
-		PathPattern:        "/policy",
+		PathPattern:        "/export",
 		ProducesMediaTypes: []string{"application/json"},
 		ConsumesMediaTypes: []string{"application/json"},
 		Schemes:            []string{"https"},
 		Params:             params,
-		Reader:             &GetPolicyReader{formats: a.formats},
+		Reader:             &ExportAnalysisReader{formats: a.formats},
 		Context:            params.Context,
 		Client:             params.HTTPClient,
 	})
 	if err != nil {
 		return nil, err
 	}
-	success, ok := result.(*GetPolicyOK)
+	success, ok := result.(*ExportAnalysisOK)
 	if ok {
 		return success, nil
 	}
 	// unexpected success response
 	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
-	msg := fmt.Sprintf("unexpected success response for GetPolicy: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	msg := fmt.Sprintf("unexpected success response for ExportAnalysis: API contract not enforced by server. Client expected to get an error, but got: %T", result)
 	panic(msg)
 }
 
 /*
-  GetRule gets rule details
+  GetBacktest gets the results of a rule backtest
 */
-func (a *Client) GetRule(params *GetRuleParams) (*GetRuleOK, error) {
+func (a *Client) GetBacktest(params *GetBacktestParams) (*GetBacktestOK, error) {
 	// TODO: Validate the params before sending
 	if params == nil {
-		params = NewGetRuleParams()
+		params = NewGetBacktestParams()
 	}
 
 	result, err := a.transport.Submit(&runtime.ClientOperation{
-		ID:                 "GetRule",
+		ID:                 "GetBacktest",
 		Method:             "GET",
-		PathPattern:        "/rule",
+		PathPattern:        "/rule/backtest",
 		ProducesMediaTypes: []string{"application/json"},
 		ConsumesMediaTypes: []string{"application/json"},
 		Schemes:            []string{"https"},
 		Params:             params,
-		Reader:             &GetRuleReader{formats: a.formats},
+		Reader:             &GetBacktestReader{formats: a.formats},
 		Context:            params.Context,
 		Client:             params.HTTPClient,
 	})
 	if err != nil {
 		return nil, err
 	}
-	success, ok := result.(*GetRuleOK)
+	success, ok := result.(*GetBacktestOK)
 	if ok {
 		return success, nil
 	}
 	// unexpected success response
 	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
-	msg := fmt.Sprintf("unexpected success response for GetRule: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	msg := fmt.Sprintf("unexpected success response for GetBacktest: API contract not enforced by server. Client expected to get an error, but got: %T", result)
 	panic(msg)
 }
 
 /*
-  ListGlobals pages through globals in a customer s account
+  GetCorrelationRule gets correlation rule details
 */
-func (a *Client) ListGlobals(params *ListGlobalsParams) (*ListGlobalsOK, error) {
+func (a *Client) GetCorrelationRule(params *GetCorrelationRuleParams) (*GetCorrelationRuleOK, error) {
 	// TODO: Validate the params before sending
 	if params == nil {
-		params = NewListGlobalsParams()
+		params = NewGetCorrelationRuleParams()
 	}
 
 	result, err := a.transport.Submit(&runtime.ClientOperation{
-		ID:                 "ListGlobals",
+		ID:                 "GetCorrelationRule",
 		Method:             "GET",
-		PathPattern:        "/global/list",
+		PathPattern:        "/correlation",
 		ProducesMediaTypes: []string{"application/json"},
 		ConsumesMediaTypes: []string{"application/json"},
 		Schemes:            []string{"https"},
 		Params:             params,
-		Reader:             &ListGlobalsReader{formats: a.formats},
+		Reader:             &GetCorrelationRuleReader{formats: a.formats},
 		Context:            params.Context,
 		Client:             params.HTTPClient,
 	})
 	if err != nil {
 		return nil, err
 	}
-	success, ok := result.(*ListGlobalsOK)
+	success, ok := result.(*GetCorrelationRuleOK)
 	if ok {
 		return success, nil
 	}
 	// unexpected success response
 	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
-	msg := fmt.Sprintf("unexpected success response for ListGlobals: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	msg := fmt.Sprintf("unexpected success response for GetCorrelationRule: API contract not enforced by server. Client expected to get an error, but got: %T", result)
 	panic(msg)
 }
 
 /*
-  ListPolicies pages through policies in a customer s account
+  GetEnabledPolicies lists all enabled rules policies for a customer account for backend processing
 */
-func (a *Client) ListPolicies(params *ListPoliciesParams) (*ListPoliciesOK, error) {
+func (a *Client) GetEnabledPolicies(params *GetEnabledPoliciesParams) (*GetEnabledPoliciesOK, error) {
 	// TODO: Validate the params before sending
 	if params == nil {
-		params = NewListPoliciesParams()
+		params = NewGetEnabledPoliciesParams()
 	}
 
 	result, err := a.transport.Submit(&runtime.ClientOperation{
-		ID:                 "ListPolicies",
+		ID:                 "GetEnabledPolicies",
 		Method:             "GET",
-		PathPattern:        "/list",
+		PathPattern:        "/enabled",
 		ProducesMediaTypes: []string{"application/json"},
 		ConsumesMediaTypes: []string{"application/json"},
 		Schemes:            []string{"https"},
 		Params:             params,
-		Reader:             &ListPoliciesReader{formats: a.formats},
+		Reader:             &GetEnabledPoliciesReader{formats: a.formats},
 		Context:            params.Context,
 		Client:             params.HTTPClient,
 	})
 	if err != nil {
 		return nil, err
 	}
-	success, ok := result.(*ListPoliciesOK)
+	success, ok := result.(*GetEnabledPoliciesOK)
 	if ok {
 		return success, nil
 	}
 	// unexpected success response
 	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
-	msg := fmt.Sprintf("unexpected success response for ListPolicies: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	msg := fmt.Sprintf("unexpected success response for GetEnabledPolicies: API contract not enforced by server. Client expected to get an error, but got: %T", result)
 	panic(msg)
 }
 
 /*
-  ListRules pages through rules in a customer s account
+  GetGlobal gets global details
 */
-func (a *Client) ListRules(params *ListRulesParams) (*ListRulesOK, error) {
+func (a *Client) GetGlobal(params *GetGlobalParams) (*GetGlobalOK, error) {
 	// TODO: Validate the params before sending
 	if params == nil {
-		params = NewListRulesParams()
+		params = NewGetGlobalParams()
 	}
 
 	result, err := a.transport.Submit(&runtime.ClientOperation{
-		ID:                 "ListRules",
+		ID:                 "GetGlobal",
 		Method:             "GET",
-		PathPattern:        "/rule/list",
+		PathPattern:        "/global",
 		ProducesMediaTypes: []string{"application/json"},
 		ConsumesMediaTypes: []string{"application/json"},
 		Schemes:            []string{"https"},
 		Params:             params,
-		Reader:             &ListRulesReader{formats: a.formats},
+		Reader:             &GetGlobalReader{formats: a.formats},
 		Context:            params.Context,
 		Client:             params.HTTPClient,
 	})
 	if err != nil {
 		return nil, err
 	}
-	success, ok := result.(*ListRulesOK)
+	success, ok := result.(*GetGlobalOK)
 	if ok {
 		return success, nil
 	}
 	// unexpected success response
 	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
-	msg := fmt.Sprintf("unexpected success response for ListRules: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	msg := fmt.Sprintf("unexpected success response for GetGlobal: API contract not enforced by server. Client expected to get an error, but got: %T", result)
 	panic(msg)
 }
 
 /*
-  ModifyGlobal modifies an existing global
+  GetPack gets an analysis pack
 */
-func (a *Client) ModifyGlobal(params *ModifyGlobalParams) (*ModifyGlobalOK, error) {
+func (a *Client) GetPack(params *GetPackParams) (*GetPackOK, error) {
 	// TODO: Validate the params before sending
 	if params == nil {
-		params = NewModifyGlobalParams()
+		params = NewGetPackParams()
 	}
 
 	result, err := a.transport.Submit(&runtime.ClientOperation{
-		ID:                 "ModifyGlobal",
-		Method:             "POST",
-		PathPattern:        "/global/update",
+		ID:                 "GetPack",
+		Method:             "GET",
+		PathPattern:        "/pack",
 		ProducesMediaTypes: []string{"application/json"},
 		ConsumesMediaTypes: []string{"application/json"},
 		Schemes:            []string{"https"},
 		Params:             params,
-		Reader:             &ModifyGlobalReader{formats: a.formats},
+		Reader:             &GetPackReader{formats: a.formats},
 		Context:            params.Context,
 		Client:             params.HTTPClient,
 	})
 	if err != nil {
 		return nil, err
 	}
-	success, ok := result.(*ModifyGlobalOK)
+	success, ok := result.(*GetPackOK)
 	if ok {
 		return success, nil
 	}
 	// unexpected success response
 	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
-	msg := fmt.Sprintf("unexpected success response for ModifyGlobal: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	msg := fmt.Sprintf("unexpected success response for GetPack: API contract not enforced by server. Client expected to get an error, but got: %T", result)
 	panic(msg)
 }
 
 /*
-  ModifyPolicy modifies an existing policy
+  GetPolicy gets policy details
 */
-func (a *Client) ModifyPolicy(params *ModifyPolicyParams) (*ModifyPolicyOK, error) {
+func (a *Client) GetPolicy(params *GetPolicyParams) (*GetPolicyOK, error) {
 	// TODO: Validate the params before sending
 	if params == nil {
-		params = NewModifyPolicyParams()
+		params = NewGetPolicyParams()
 	}
 
 	result, err := a.transport.Submit(&runtime.ClientOperation{
-		ID:                 "ModifyPolicy",
-		Method:             "POST",
-		PathPattern:        "/update",
+		ID:                 "GetPolicy",
+		Method:             "GET",
+		PathPattern:        "/policy",
 		ProducesMediaTypes: []string{"application/json"},
 		ConsumesMediaTypes: []string{"application/json"},
 		Schemes:            []string{"https"},
 		Params:             params,
-		Reader:             &ModifyPolicyReader{formats: a.formats},
+		Reader:             &GetPolicyReader{formats: a.formats},
 		Context:            params.Context,
 		Client:             params.HTTPClient,
 	})
 	if err != nil {
 		return nil, err
 	}
-	success, ok := result.(*ModifyPolicyOK)
+	success, ok := result.(*GetPolicyOK)
 	if ok {
 		return success, nil
 	}
 	// unexpected success response
 	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
-	msg := fmt.Sprintf("unexpected success response for ModifyPolicy: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	msg := fmt.Sprintf("unexpected success response for GetPolicy: API contract not enforced by server. Client expected to get an error, but got: %T", result)
 	panic(msg)
 }
 
 /*
-  ModifyRule modifies an existing rule
+  GetRule gets rule details
 */
-func (a *Client) ModifyRule(params *ModifyRuleParams) (*ModifyRuleOK, error) {
+func (a *Client) GetRule(params *GetRuleParams) (*GetRuleOK, error) {
 	// TODO: Validate the params before sending
 	if params == nil {
-		params = NewModifyRuleParams()
+		params = NewGetRuleParams()
 	}
 
 	result, err := a.transport.Submit(&runtime.ClientOperation{
-		ID:                 "ModifyRule",
-		Method:             "POST",
-		PathPattern:        "/rule/update",
+		ID:                 "GetRule",
+		Method:             "GET",
+		PathPattern:        "/rule",
 		ProducesMediaTypes: []string{"application/json"},
 		ConsumesMediaTypes: []string{"application/json"},
 		Schemes:            []string{"https"},
 		Params:             params,
-		Reader:             &ModifyRuleReader{formats: a.formats},
+		Reader:             &GetRuleReader{formats: a.formats},
 		Context:            params.Context,
 		Client:             params.HTTPClient,
 	})
 	if err != nil {
 		return nil, err
 	}
-	success, ok := result.(*ModifyRuleOK)
+	success, ok := result.(*GetRuleOK)
 	if ok {
 		return success, nil
 	}
 	// unexpected success response
 	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
-	msg := fmt.Sprintf("unexpected success response for ModifyRule: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	msg := fmt.Sprintf("unexpected success response for GetRule: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  GetScheduledQuery gets scheduled query details
+*/
+func (a *Client) GetScheduledQuery(params *GetScheduledQueryParams) (*GetScheduledQueryOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetScheduledQueryParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "GetScheduledQuery",
+		Method:             "GET",
+		PathPattern:        "/query",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &GetScheduledQueryReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetScheduledQueryOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for GetScheduledQuery: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  GetVersionDiff compares two versions of a policy rule or global
+*/
+func (a *Client) GetVersionDiff(params *GetVersionDiffParams) (*GetVersionDiffOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetVersionDiffParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "GetVersionDiff",
+		Method:             "GET",
+		PathPattern:        "/version/diff",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &GetVersionDiffReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetVersionDiffOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for GetVersionDiff: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  InstallPack installs or upgrade an analysis pack
+*/
+func (a *Client) InstallPack(params *InstallPackParams) (*InstallPackOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewInstallPackParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "InstallPack",
+		Method:             "POST",
+		PathPattern:        "/pack",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &InstallPackReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*InstallPackOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for InstallPack: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  ListCorrelationRules pages through correlation rules in a customer s account
+*/
+func (a *Client) ListCorrelationRules(params *ListCorrelationRulesParams) (*ListCorrelationRulesOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListCorrelationRulesParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "ListCorrelationRules",
+		Method:             "GET",
+		PathPattern:        "/correlation/list",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ListCorrelationRulesReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListCorrelationRulesOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for ListCorrelationRules: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  ListDataModels lists the canonical field mappings of all log types
+*/
+func (a *Client) ListDataModels(params *ListDataModelsParams) (*ListDataModelsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListDataModelsParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "ListDataModels",
+		Method:             "GET",
+		PathPattern:        "/datamodels",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ListDataModelsReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListDataModelsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for ListDataModels: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  ListExceptions lists policy exceptions ordered by expiration
+*/
+func (a *Client) ListExceptions(params *ListExceptionsParams) (*ListExceptionsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListExceptionsParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "ListExceptions",
+		Method:             "GET",
+		PathPattern:        "/exception/list",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ListExceptionsReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListExceptionsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for ListExceptions: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  ListGlobals pages through globals in a customer s account
+*/
+func (a *Client) ListGlobals(params *ListGlobalsParams) (*ListGlobalsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListGlobalsParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "ListGlobals",
+		Method:             "GET",
+		PathPattern:        "/global/list",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ListGlobalsReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListGlobalsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for ListGlobals: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  ListPacks lists installed analysis packs
+*/
+func (a *Client) ListPacks(params *ListPacksParams) (*ListPacksOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListPacksParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "ListPacks",
+		Method:             "GET",
+		PathPattern:        "/pack/list",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ListPacksReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListPacksOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for ListPacks: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  ListPolicies pages through policies in a customer s account
+*/
+func (a *Client) ListPolicies(params *ListPoliciesParams) (*ListPoliciesOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListPoliciesParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "ListPolicies",
+		Method:             "GET",
+		PathPattern:        "/list",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ListPoliciesReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListPoliciesOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for ListPolicies: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  ListRules pages through rules in a customer s account
+*/
+func (a *Client) ListRules(params *ListRulesParams) (*ListRulesOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListRulesParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "ListRules",
+		Method:             "GET",
+		PathPattern:        "/rule/list",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ListRulesReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListRulesOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for ListRules: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  ListScheduledQueries pages through scheduled queries in a customer s account
+*/
+func (a *Client) ListScheduledQueries(params *ListScheduledQueriesParams) (*ListScheduledQueriesOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListScheduledQueriesParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "ListScheduledQueries",
+		Method:             "GET",
+		PathPattern:        "/query/list",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ListScheduledQueriesReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListScheduledQueriesOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for ListScheduledQueries: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  ListVersions lists the version history of a policy rule or global
+*/
+func (a *Client) ListVersions(params *ListVersionsParams) (*ListVersionsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListVersionsParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "ListVersions",
+		Method:             "GET",
+		PathPattern:        "/version/list",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ListVersionsReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListVersionsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for ListVersions: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  ModifyCorrelationRule modifies an existing correlation rule
+*/
+func (a *Client) ModifyCorrelationRule(params *ModifyCorrelationRuleParams) (*ModifyCorrelationRuleOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewModifyCorrelationRuleParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "ModifyCorrelationRule",
+		Method:             "POST",
+		PathPattern:        "/correlation/update",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ModifyCorrelationRuleReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ModifyCorrelationRuleOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for ModifyCorrelationRule: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  ModifyGlobal modifies an existing global
+*/
+func (a *Client) ModifyGlobal(params *ModifyGlobalParams) (*ModifyGlobalOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewModifyGlobalParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "ModifyGlobal",
+		Method:             "POST",
+		PathPattern:        "/global/update",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ModifyGlobalReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ModifyGlobalOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for ModifyGlobal: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  ModifyPack enables or disable an analysis pack
+*/
+func (a *Client) ModifyPack(params *ModifyPackParams) (*ModifyPackOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewModifyPackParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "ModifyPack",
+		Method:             "POST",
+		PathPattern:        "/pack/update",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ModifyPackReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ModifyPackOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for ModifyPack: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  ModifyPolicy modifies an existing policy
+*/
+func (a *Client) ModifyPolicy(params *ModifyPolicyParams) (*ModifyPolicyOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewModifyPolicyParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "ModifyPolicy",
+		Method:             "POST",
+		PathPattern:        "/update",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ModifyPolicyReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ModifyPolicyOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for ModifyPolicy: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  ModifyRule modifies an existing rule
+*/
+func (a *Client) ModifyRule(params *ModifyRuleParams) (*ModifyRuleOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewModifyRuleParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "ModifyRule",
+		Method:             "POST",
+		PathPattern:        "/rule/update",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ModifyRuleReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ModifyRuleOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for ModifyRule: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  ModifyScheduledQuery modifies an existing scheduled query
+*/
+func (a *Client) ModifyScheduledQuery(params *ModifyScheduledQueryParams) (*ModifyScheduledQueryOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewModifyScheduledQueryParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "ModifyScheduledQuery",
+		Method:             "POST",
+		PathPattern:        "/query/update",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ModifyScheduledQueryReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ModifyScheduledQueryOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for ModifyScheduledQuery: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  RevertToVersion restores a previous version of a policy rule or global
+*/
+func (a *Client) RevertToVersion(params *RevertToVersionParams) (*RevertToVersionOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewRevertToVersionParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "RevertToVersion",
+		Method:             "POST",
+		PathPattern:        "/version/revert",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &RevertToVersionReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*RevertToVersionOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for RevertToVersion: API contract not enforced by server. Client expected to get an error, but got: %T", result)
 	panic(msg)
 }
 
@@ -0,0 +1,180 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+)
+
+// RevertToVersionReader is a Reader for the RevertToVersion structure.
+type RevertToVersionReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *RevertToVersionReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewRevertToVersionOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewRevertToVersionBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 404:
+		result := NewRevertToVersionNotFound()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewRevertToVersionInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewRevertToVersionOK creates a RevertToVersionOK with default headers values
+func NewRevertToVersionOK() *RevertToVersionOK {
+	return &RevertToVersionOK{}
+}
+
+/*RevertToVersionOK handles this case with default header values.
+
+OK
+*/
+type RevertToVersionOK struct {
+	Payload *models.VersionSummary
+}
+
+func (o *RevertToVersionOK) Error() string {
+	return fmt.Sprintf("[POST /version/revert][%d] revertToVersionOK  %+v", 200, o.Payload)
+}
+
+func (o *RevertToVersionOK) GetPayload() *models.VersionSummary {
+	return o.Payload
+}
+
+func (o *RevertToVersionOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.VersionSummary)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewRevertToVersionBadRequest creates a RevertToVersionBadRequest with default headers values
+func NewRevertToVersionBadRequest() *RevertToVersionBadRequest {
+	return &RevertToVersionBadRequest{}
+}
+
+/*RevertToVersionBadRequest handles this case with default header values.
+
+Bad request
+*/
+type RevertToVersionBadRequest struct {
+	Payload *models.Error
+}
+
+func (o *RevertToVersionBadRequest) Error() string {
+	return fmt.Sprintf("[POST /version/revert][%d] revertToVersionBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *RevertToVersionBadRequest) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *RevertToVersionBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewRevertToVersionNotFound creates a RevertToVersionNotFound with default headers values
+func NewRevertToVersionNotFound() *RevertToVersionNotFound {
+	return &RevertToVersionNotFound{}
+}
+
+/*RevertToVersionNotFound handles this case with default header values.
+
+Policy, rule, global or version does not exist
+*/
+type RevertToVersionNotFound struct {
+}
+
+func (o *RevertToVersionNotFound) Error() string {
+	return fmt.Sprintf("[POST /version/revert][%d] revertToVersionNotFound ", 404)
+}
+
+func (o *RevertToVersionNotFound) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// NewRevertToVersionInternalServerError creates a RevertToVersionInternalServerError with default headers values
+func NewRevertToVersionInternalServerError() *RevertToVersionInternalServerError {
+	return &RevertToVersionInternalServerError{}
+}
+
+/*RevertToVersionInternalServerError handles this case with default header values.
+
+Internal server error
+*/
+type RevertToVersionInternalServerError struct {
+}
+
+func (o *RevertToVersionInternalServerError) Error() string {
+	return fmt.Sprintf("[POST /version/revert][%d] revertToVersionInternalServerError ", 500)
+}
+
+func (o *RevertToVersionInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
@@ -89,6 +89,7 @@ type EventAnalysis struct {
 	Errored    []PolicyError `json:"errored"`
 	Matched    []string      `json:"matched"`    // set of rule IDs which returned True
 	NotMatched []string      `json:"notMatched"` // set of rule IDs which returned False
+	Dedup      string        `json:"dedup"`      // the dedup string of the matched rule
 }
 
 // ToResult normalizes an event analysis rule result into a Result.
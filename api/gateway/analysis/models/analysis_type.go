@@ -46,6 +46,12 @@ const (
 
 	// AnalysisTypeRULE captures enum value "RULE"
 	AnalysisTypeRULE AnalysisType = "RULE"
+
+	// AnalysisTypeSCHEDULEDQUERY captures enum value "SCHEDULED_QUERY"
+	AnalysisTypeSCHEDULEDQUERY AnalysisType = "SCHEDULED_QUERY"
+
+	// AnalysisTypeCORRELATIONRULE captures enum value "CORRELATION_RULE"
+	AnalysisTypeCORRELATIONRULE AnalysisType = "CORRELATION_RULE"
 )
 
 // for schema
@@ -53,7 +59,7 @@ var analysisTypeEnum []interface{}
 
 func init() {
 	var res []AnalysisType
-	if err := json.Unmarshal([]byte(`["GLOBAL","POLICY","RULE"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["GLOBAL","POLICY","RULE","SCHEDULED_QUERY","CORRELATION_RULE"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -0,0 +1,261 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"encoding/json"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// BacktestResult backtest result
+//
+// swagger:model BacktestResult
+type BacktestResult struct {
+
+	// Unique identifier used to get the results of the backtest
+	// Required: true
+	BacktestID *string `json:"backtestId"`
+
+	// Why the backtest failed (if the status is FAILED)
+	ErrorMessage string `json:"errorMessage,omitempty"`
+
+	// Number of events for which the rule raised an exception
+	// Required: true
+	EventsErrored *int64 `json:"eventsErrored"`
+
+	// Number of events for which the rule returned True
+	// Required: true
+	EventsMatched *int64 `json:"eventsMatched"`
+
+	// Number of events the rule was evaluated against
+	// Required: true
+	EventsScanned *int64 `json:"eventsScanned"`
+
+	// Number of alerts the rule would have generated after deduplication and thresholding
+	// Required: true
+	ProjectedAlerts *int64 `json:"projectedAlerts"`
+
+	// A sample of the errors raised by the rule
+	// Required: true
+	SampleErrors []string `json:"sampleErrors"`
+
+	// A sample of the matching events (JSON)
+	// Required: true
+	SampleMatches []string `json:"sampleMatches"`
+
+	// status
+	// Required: true
+	// Enum: [RUNNING SUCCEEDED FAILED]
+	Status *string `json:"status"`
+
+	// True if maxEvents was reached before the end of the time range
+	// Required: true
+	Truncated *bool `json:"truncated"`
+}
+
+// Validate validates this backtest result
+func (m *BacktestResult) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateBacktestID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateEventsErrored(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateEventsMatched(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateEventsScanned(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateProjectedAlerts(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSampleErrors(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSampleMatches(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateStatus(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateTruncated(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *BacktestResult) validateBacktestID(formats strfmt.Registry) error {
+
+	if err := validate.Required("backtestId", "body", m.BacktestID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *BacktestResult) validateEventsErrored(formats strfmt.Registry) error {
+
+	if err := validate.Required("eventsErrored", "body", m.EventsErrored); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *BacktestResult) validateEventsMatched(formats strfmt.Registry) error {
+
+	if err := validate.Required("eventsMatched", "body", m.EventsMatched); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *BacktestResult) validateEventsScanned(formats strfmt.Registry) error {
+
+	if err := validate.Required("eventsScanned", "body", m.EventsScanned); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *BacktestResult) validateProjectedAlerts(formats strfmt.Registry) error {
+
+	if err := validate.Required("projectedAlerts", "body", m.ProjectedAlerts); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *BacktestResult) validateSampleErrors(formats strfmt.Registry) error {
+
+	if err := validate.Required("sampleErrors", "body", m.SampleErrors); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *BacktestResult) validateSampleMatches(formats strfmt.Registry) error {
+
+	if err := validate.Required("sampleMatches", "body", m.SampleMatches); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var backtestResultTypeStatusPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["RUNNING","SUCCEEDED","FAILED"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		backtestResultTypeStatusPropEnum = append(backtestResultTypeStatusPropEnum, v)
+	}
+}
+
+const (
+
+	// BacktestResultStatusRUNNING captures enum value "RUNNING"
+	BacktestResultStatusRUNNING string = "RUNNING"
+
+	// BacktestResultStatusSUCCEEDED captures enum value "SUCCEEDED"
+	BacktestResultStatusSUCCEEDED string = "SUCCEEDED"
+
+	// BacktestResultStatusFAILED captures enum value "FAILED"
+	BacktestResultStatusFAILED string = "FAILED"
+)
+
+// prop value enum
+func (m *BacktestResult) validateStatusEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, backtestResultTypeStatusPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *BacktestResult) validateStatus(formats strfmt.Registry) error {
+
+	if err := validate.Required("status", "body", m.Status); err != nil {
+		return err
+	}
+
+	// value enum
+	if err := m.validateStatusEnum("status", "body", *m.Status); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *BacktestResult) validateTruncated(formats strfmt.Registry) error {
+
+	if err := validate.Required("truncated", "body", m.Truncated); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *BacktestResult) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *BacktestResult) UnmarshalBinary(b []byte) error {
+	var res BacktestResult
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,251 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// BacktestRule backtest rule
+//
+// swagger:model BacktestRule
+type BacktestRule struct {
+
+	// body
+	// Required: true
+	Body Body `json:"body"`
+
+	// dedup period minutes
+	DedupPeriodMinutes DedupPeriodMinutes `json:"dedupPeriodMinutes,omitempty"`
+
+	// Only include events before this time
+	// Required: true
+	// Format: date-time
+	EndTime *strfmt.DateTime `json:"endTime"`
+
+	// log types
+	// Required: true
+	LogTypes TypeSet `json:"logTypes"`
+
+	// Stop after scanning this many events
+	// Maximum: 20000
+	// Minimum: 1
+	MaxEvents int64 `json:"maxEvents,omitempty"`
+
+	// Maximum number of matching events to return
+	// Maximum: 100
+	// Minimum: 0
+	SampleSize *int64 `json:"sampleSize,omitempty"`
+
+	// Only include events at or after this time
+	// Required: true
+	// Format: date-time
+	StartTime *strfmt.DateTime `json:"startTime"`
+
+	// threshold
+	Threshold Threshold `json:"threshold,omitempty"`
+}
+
+// Validate validates this backtest rule
+func (m *BacktestRule) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateBody(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateDedupPeriodMinutes(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateEndTime(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateLogTypes(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateMaxEvents(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSampleSize(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateStartTime(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateThreshold(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *BacktestRule) validateBody(formats strfmt.Registry) error {
+
+	if err := m.Body.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("body")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *BacktestRule) validateDedupPeriodMinutes(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.DedupPeriodMinutes) { // not required
+		return nil
+	}
+
+	if err := m.DedupPeriodMinutes.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("dedupPeriodMinutes")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *BacktestRule) validateEndTime(formats strfmt.Registry) error {
+
+	if err := validate.Required("endTime", "body", m.EndTime); err != nil {
+		return err
+	}
+
+	if err := validate.FormatOf("endTime", "body", "date-time", m.EndTime.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *BacktestRule) validateLogTypes(formats strfmt.Registry) error {
+
+	if err := validate.Required("logTypes", "body", m.LogTypes); err != nil {
+		return err
+	}
+
+	if err := m.LogTypes.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("logTypes")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *BacktestRule) validateMaxEvents(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.MaxEvents) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("maxEvents", "body", int64(m.MaxEvents), 1, false); err != nil {
+		return err
+	}
+
+	if err := validate.MaximumInt("maxEvents", "body", int64(m.MaxEvents), 20000, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *BacktestRule) validateSampleSize(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.SampleSize) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("sampleSize", "body", int64(*m.SampleSize), 0, false); err != nil {
+		return err
+	}
+
+	if err := validate.MaximumInt("sampleSize", "body", int64(*m.SampleSize), 100, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *BacktestRule) validateStartTime(formats strfmt.Registry) error {
+
+	if err := validate.Required("startTime", "body", m.StartTime); err != nil {
+		return err
+	}
+
+	if err := validate.FormatOf("startTime", "body", "date-time", m.StartTime.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *BacktestRule) validateThreshold(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Threshold) { // not required
+		return nil
+	}
+
+	if err := m.Threshold.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("threshold")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *BacktestRule) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *BacktestRule) UnmarshalBinary(b []byte) error {
+	var res BacktestRule
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
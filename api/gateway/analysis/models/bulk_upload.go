@@ -34,10 +34,17 @@ import (
 // swagger:model BulkUpload
 type BulkUpload struct {
 
+	// Save either all of the items or none of them. Only uploads with at most 25 changed items are committed in a single transaction, see the transactions field of the result.
+	//
+	Atomic bool `json:"atomic,omitempty"`
+
 	// data
 	// Required: true
 	Data Base64zipfile `json:"data"`
 
+	// Validate and test every item and return the plan without saving anything
+	DryRun bool `json:"dryRun,omitempty"`
+
 	// user Id
 	// Required: true
 	UserID UserID `json:"userId"`
@@ -0,0 +1,235 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// BulkUploadPlanItem bulk upload plan item
+//
+// swagger:model BulkUploadPlanItem
+type BulkUploadPlanItem struct {
+
+	// action
+	// Required: true
+	// Enum: [CREATE MODIFY UNCHANGED FAIL DELETE]
+	Action *string `json:"action"`
+
+	// analysis type
+	// Required: true
+	AnalysisType AnalysisType `json:"analysisType"`
+
+	// Unified diff of the source code against the current version (MODIFY only)
+	BodyDiff string `json:"bodyDiff,omitempty"`
+
+	// Fields which would change (MODIFY only)
+	Changes []*FieldChange `json:"changes"`
+
+	// The item was edited outside of its pack, and those edits would be overwritten
+	Drifted bool `json:"drifted,omitempty"`
+
+	// Why the item would fail (FAIL only)
+	Error string `json:"error,omitempty"`
+
+	// id
+	// Required: true
+	ID ID `json:"id"`
+
+	// test results
+	TestResults *TestPolicyResult `json:"testResults,omitempty"`
+}
+
+// Validate validates this bulk upload plan item
+func (m *BulkUploadPlanItem) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateAction(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateAnalysisType(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateChanges(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateTestResults(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+var bulkUploadPlanItemTypeActionPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["CREATE","MODIFY","UNCHANGED","FAIL","DELETE"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		bulkUploadPlanItemTypeActionPropEnum = append(bulkUploadPlanItemTypeActionPropEnum, v)
+	}
+}
+
+const (
+
+	// BulkUploadPlanItemActionCREATE captures enum value "CREATE"
+	BulkUploadPlanItemActionCREATE string = "CREATE"
+
+	// BulkUploadPlanItemActionMODIFY captures enum value "MODIFY"
+	BulkUploadPlanItemActionMODIFY string = "MODIFY"
+
+	// BulkUploadPlanItemActionUNCHANGED captures enum value "UNCHANGED"
+	BulkUploadPlanItemActionUNCHANGED string = "UNCHANGED"
+
+	// BulkUploadPlanItemActionFAIL captures enum value "FAIL"
+	BulkUploadPlanItemActionFAIL string = "FAIL"
+
+	// BulkUploadPlanItemActionDELETE captures enum value "DELETE"
+	BulkUploadPlanItemActionDELETE string = "DELETE"
+)
+
+// prop value enum
+func (m *BulkUploadPlanItem) validateActionEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, bulkUploadPlanItemTypeActionPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *BulkUploadPlanItem) validateAction(formats strfmt.Registry) error {
+
+	if err := validate.Required("action", "body", m.Action); err != nil {
+		return err
+	}
+
+	// value enum
+	if err := m.validateActionEnum("action", "body", *m.Action); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *BulkUploadPlanItem) validateAnalysisType(formats strfmt.Registry) error {
+
+	if err := m.AnalysisType.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("analysisType")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *BulkUploadPlanItem) validateChanges(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Changes) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Changes); i++ {
+		if swag.IsZero(m.Changes[i]) { // not required
+			continue
+		}
+
+		if m.Changes[i] != nil {
+			if err := m.Changes[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("changes" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *BulkUploadPlanItem) validateID(formats strfmt.Registry) error {
+
+	if err := m.ID.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("id")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *BulkUploadPlanItem) validateTestResults(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.TestResults) { // not required
+		return nil
+	}
+
+	if m.TestResults != nil {
+		if err := m.TestResults.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("testResults")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *BulkUploadPlanItem) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *BulkUploadPlanItem) UnmarshalBinary(b []byte) error {
+	var res BulkUploadPlanItem
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -24,6 +24,8 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"strconv"
+
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
@@ -65,6 +67,9 @@ type BulkUploadResult struct {
 	// Minimum: 0
 	NewRules *int64 `json:"newRules"`
 
+	// What would happen to each item (only set for a dry run)
+	Plan []*BulkUploadPlanItem `json:"plan"`
+
 	// total globals
 	// Required: true
 	// Minimum: 0
@@ -79,6 +84,11 @@ type BulkUploadResult struct {
 	// Required: true
 	// Minimum: 0
 	TotalRules *int64 `json:"totalRules"`
+
+	// Number of transactions an atomic upload was committed in. If it is more than 1, the upload was not isolated from concurrent readers and would only have been rolled back on a best-effort basis.
+	//
+	// Minimum: 0
+	Transactions *int64 `json:"transactions,omitempty"`
 }
 
 // Validate validates this bulk upload result
@@ -109,6 +119,10 @@ func (m *BulkUploadResult) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validatePlan(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateTotalGlobals(formats); err != nil {
 		res = append(res, err)
 	}
@@ -121,6 +135,10 @@ func (m *BulkUploadResult) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateTransactions(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -205,6 +223,31 @@ func (m *BulkUploadResult) validateNewRules(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *BulkUploadResult) validatePlan(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Plan) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Plan); i++ {
+		if swag.IsZero(m.Plan[i]) { // not required
+			continue
+		}
+
+		if m.Plan[i] != nil {
+			if err := m.Plan[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("plan" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *BulkUploadResult) validateTotalGlobals(formats strfmt.Registry) error {
 
 	if err := validate.Required("totalGlobals", "body", m.TotalGlobals); err != nil {
@@ -244,6 +287,19 @@ func (m *BulkUploadResult) validateTotalRules(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *BulkUploadResult) validateTransactions(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Transactions) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("transactions", "body", int64(*m.Transactions), 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *BulkUploadResult) MarshalBinary() ([]byte, error) {
 	if m == nil {
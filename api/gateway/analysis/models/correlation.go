@@ -0,0 +1,164 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// Correlation correlation
+//
+// swagger:model Correlation
+type Correlation struct {
+
+	// Event field which must have the same value in every step, e.g. "sourceIPAddress" or "p_any_ip_addresses". Nested fields are separated with dots. For array fields, any shared element joins the events.
+	// Required: true
+	// Max Length: 200
+	// Min Length: 1
+	JoinKey *string `json:"joinKey"`
+
+	// If true, the steps must match in the given order. Otherwise they can match in any order.
+	Ordered bool `json:"ordered,omitempty"`
+
+	// IDs of the rules which must all match within the window
+	// Required: true
+	// Max Items: 10
+	// Min Items: 2
+	Steps []ID `json:"steps"`
+
+	// All steps must match within this many minutes of each other
+	// Required: true
+	// Maximum: 1440
+	// Minimum: 1
+	WindowMinutes *int64 `json:"windowMinutes"`
+}
+
+// Validate validates this correlation
+func (m *Correlation) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateJoinKey(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSteps(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateWindowMinutes(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *Correlation) validateJoinKey(formats strfmt.Registry) error {
+
+	if err := validate.Required("joinKey", "body", m.JoinKey); err != nil {
+		return err
+	}
+
+	if err := validate.MinLength("joinKey", "body", string(*m.JoinKey), 1); err != nil {
+		return err
+	}
+
+	if err := validate.MaxLength("joinKey", "body", string(*m.JoinKey), 200); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *Correlation) validateSteps(formats strfmt.Registry) error {
+
+	if err := validate.Required("steps", "body", m.Steps); err != nil {
+		return err
+	}
+
+	iStepsSize := int64(len(m.Steps))
+
+	if err := validate.MinItems("steps", "body", iStepsSize, 2); err != nil {
+		return err
+	}
+
+	if err := validate.MaxItems("steps", "body", iStepsSize, 10); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(m.Steps); i++ {
+
+		if err := m.Steps[i].Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("steps" + "." + strconv.Itoa(i))
+			}
+			return err
+		}
+
+	}
+
+	return nil
+}
+
+func (m *Correlation) validateWindowMinutes(formats strfmt.Registry) error {
+
+	if err := validate.Required("windowMinutes", "body", m.WindowMinutes); err != nil {
+		return err
+	}
+
+	if err := validate.MinimumInt("windowMinutes", "body", int64(*m.WindowMinutes), 1, false); err != nil {
+		return err
+	}
+
+	if err := validate.MaximumInt("windowMinutes", "body", int64(*m.WindowMinutes), 1440, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *Correlation) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *Correlation) UnmarshalBinary(b []byte) error {
+	var res Correlation
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,205 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// CreateException create exception
+//
+// swagger:model CreateException
+type CreateException struct {
+
+	// approver
+	// Required: true
+	Approver UserID `json:"approver"`
+
+	// expires at
+	// Required: true
+	// Format: date-time
+	ExpiresAt ExceptionExpiration `json:"expiresAt"`
+
+	// justification
+	// Required: true
+	Justification ExceptionJustification `json:"justification"`
+
+	// owner
+	// Required: true
+	Owner ExceptionOwner `json:"owner"`
+
+	// policy Id
+	// Required: true
+	PolicyID ID `json:"policyId"`
+
+	// resource pattern
+	// Required: true
+	ResourcePattern ResourcePattern `json:"resourcePattern"`
+
+	// user Id
+	// Required: true
+	UserID UserID `json:"userId"`
+}
+
+// Validate validates this create exception
+func (m *CreateException) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateApprover(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateExpiresAt(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateJustification(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateOwner(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePolicyID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateResourcePattern(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateUserID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *CreateException) validateApprover(formats strfmt.Registry) error {
+
+	if err := m.Approver.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("approver")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *CreateException) validateExpiresAt(formats strfmt.Registry) error {
+
+	if err := m.ExpiresAt.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("expiresAt")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *CreateException) validateJustification(formats strfmt.Registry) error {
+
+	if err := m.Justification.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("justification")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *CreateException) validateOwner(formats strfmt.Registry) error {
+
+	if err := m.Owner.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("owner")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *CreateException) validatePolicyID(formats strfmt.Registry) error {
+
+	if err := m.PolicyID.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("policyId")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *CreateException) validateResourcePattern(formats strfmt.Registry) error {
+
+	if err := m.ResourcePattern.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("resourcePattern")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *CreateException) validateUserID(formats strfmt.Registry) error {
+
+	if err := m.UserID.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("userId")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *CreateException) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *CreateException) UnmarshalBinary(b []byte) error {
+	var res CreateException
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,99 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// DataModels data models
+//
+// swagger:model DataModels
+type DataModels struct {
+
+	// mappings
+	// Required: true
+	Mappings map[string]map[string]string `json:"mappings"`
+
+	// models
+	// Required: true
+	Models map[string][]string `json:"models"`
+}
+
+// Validate validates this data models
+func (m *DataModels) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateMappings(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateModels(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *DataModels) validateMappings(formats strfmt.Registry) error {
+
+	for k := range m.Mappings {
+
+		if err := validate.Required("mappings"+"."+k, "body", m.Mappings[k]); err != nil {
+			return err
+		}
+
+	}
+
+	return nil
+}
+
+func (m *DataModels) validateModels(formats strfmt.Registry) error {
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *DataModels) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *DataModels) UnmarshalBinary(b []byte) error {
+	var res DataModels
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
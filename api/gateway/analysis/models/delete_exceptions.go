@@ -0,0 +1,132 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// DeleteExceptions delete exceptions
+//
+// swagger:model DeleteExceptions
+type DeleteExceptions struct {
+
+	// exceptions
+	// Required: true
+	// Max Items: 1000
+	// Min Items: 1
+	Exceptions []*ExceptionKey `json:"exceptions"`
+
+	// user Id
+	// Required: true
+	UserID UserID `json:"userId"`
+}
+
+// Validate validates this delete exceptions
+func (m *DeleteExceptions) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateExceptions(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateUserID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *DeleteExceptions) validateExceptions(formats strfmt.Registry) error {
+
+	if err := validate.Required("exceptions", "body", m.Exceptions); err != nil {
+		return err
+	}
+
+	iExceptionsSize := int64(len(m.Exceptions))
+
+	if err := validate.MinItems("exceptions", "body", iExceptionsSize, 1); err != nil {
+		return err
+	}
+
+	if err := validate.MaxItems("exceptions", "body", iExceptionsSize, 1000); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(m.Exceptions); i++ {
+		if swag.IsZero(m.Exceptions[i]) { // not required
+			continue
+		}
+
+		if m.Exceptions[i] != nil {
+			if err := m.Exceptions[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("exceptions" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *DeleteExceptions) validateUserID(formats strfmt.Registry) error {
+
+	if err := m.UserID.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("userId")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *DeleteExceptions) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *DeleteExceptions) UnmarshalBinary(b []byte) error {
+	var res DeleteExceptions
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
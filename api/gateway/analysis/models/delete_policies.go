@@ -43,6 +43,9 @@ type DeletePolicies struct {
 	// Min Items: 1
 	// Unique: true
 	Policies []*DeleteEntry `json:"policies"`
+
+	// user Id
+	UserID UserID `json:"userId,omitempty"`
 }
 
 // Validate validates this delete policies
@@ -53,6 +56,10 @@ func (m *DeletePolicies) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateUserID(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -98,6 +105,22 @@ func (m *DeletePolicies) validatePolicies(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *DeletePolicies) validateUserID(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.UserID) { // not required
+		return nil
+	}
+
+	if err := m.UserID.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("userId")
+		}
+		return err
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *DeletePolicies) MarshalBinary() ([]byte, error) {
 	if m == nil {
@@ -37,9 +37,18 @@ type EnabledPolicy struct {
 	// body
 	Body Body `json:"body,omitempty"`
 
+	// correlation
+	Correlation *Correlation `json:"correlation,omitempty"`
+
+	// dedup column
+	DedupColumn DedupColumn `json:"dedupColumn,omitempty"`
+
 	// dedup period minutes
 	DedupPeriodMinutes DedupPeriodMinutes `json:"dedupPeriodMinutes,omitempty"`
 
+	// exceptions
+	Exceptions Exceptions `json:"exceptions,omitempty"`
+
 	// id
 	ID ID `json:"id,omitempty"`
 
@@ -52,6 +61,9 @@ type EnabledPolicy struct {
 	// resource types
 	ResourceTypes TypeSet `json:"resourceTypes,omitempty"`
 
+	// schedule
+	Schedule Schedule `json:"schedule,omitempty"`
+
 	// severity
 	Severity Severity `json:"severity,omitempty"`
 
@@ -73,10 +85,22 @@ func (m *EnabledPolicy) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateCorrelation(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateDedupColumn(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateDedupPeriodMinutes(formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := m.validateExceptions(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateID(formats); err != nil {
 		res = append(res, err)
 	}
@@ -93,6 +117,10 @@ func (m *EnabledPolicy) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateSchedule(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateSeverity(formats); err != nil {
 		res = append(res, err)
 	}
@@ -131,6 +159,40 @@ func (m *EnabledPolicy) validateBody(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *EnabledPolicy) validateCorrelation(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Correlation) { // not required
+		return nil
+	}
+
+	if m.Correlation != nil {
+		if err := m.Correlation.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("correlation")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *EnabledPolicy) validateDedupColumn(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.DedupColumn) { // not required
+		return nil
+	}
+
+	if err := m.DedupColumn.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("dedupColumn")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *EnabledPolicy) validateDedupPeriodMinutes(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.DedupPeriodMinutes) { // not required
@@ -147,6 +209,22 @@ func (m *EnabledPolicy) validateDedupPeriodMinutes(formats strfmt.Registry) erro
 	return nil
 }
 
+func (m *EnabledPolicy) validateExceptions(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Exceptions) { // not required
+		return nil
+	}
+
+	if err := m.Exceptions.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("exceptions")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *EnabledPolicy) validateID(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.ID) { // not required
@@ -211,6 +289,22 @@ func (m *EnabledPolicy) validateResourceTypes(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *EnabledPolicy) validateSchedule(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Schedule) { // not required
+		return nil
+	}
+
+	if err := m.Schedule.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("schedule")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *EnabledPolicy) validateSeverity(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Severity) { // not required
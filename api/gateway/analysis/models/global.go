@@ -65,6 +65,9 @@ type Global struct {
 	// Required: true
 	LastModifiedBy UserID `json:"lastModifiedBy"`
 
+	// pack Id
+	PackID ID `json:"packId,omitempty"`
+
 	// tags
 	// Required: true
 	Tags Tags `json:"tags"`
@@ -106,6 +109,10 @@ func (m *Global) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validatePackID(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateTags(formats); err != nil {
 		res = append(res, err)
 	}
@@ -204,6 +211,22 @@ func (m *Global) validateLastModifiedBy(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Global) validatePackID(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.PackID) { // not required
+		return nil
+	}
+
+	if err := m.PackID.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("packId")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *Global) validateTags(formats strfmt.Registry) error {
 
 	if err := validate.Required("tags", "body", m.Tags); err != nil {
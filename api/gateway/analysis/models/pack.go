@@ -0,0 +1,265 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// Pack pack
+//
+// swagger:model Pack
+type Pack struct {
+
+	// created at
+	// Required: true
+	// Format: date-time
+	CreatedAt ModifyTime `json:"createdAt"`
+
+	// created by
+	// Required: true
+	CreatedBy UserID `json:"createdBy"`
+
+	// description
+	Description Description `json:"description,omitempty"`
+
+	// enabled
+	// Required: true
+	Enabled Enabled `json:"enabled"`
+
+	// id
+	// Required: true
+	ID ID `json:"id"`
+
+	// items
+	// Required: true
+	Items []*PackItem `json:"items"`
+
+	// last modified
+	// Required: true
+	// Format: date-time
+	LastModified ModifyTime `json:"lastModified"`
+
+	// last modified by
+	// Required: true
+	LastModifiedBy UserID `json:"lastModifiedBy"`
+
+	// version
+	// Required: true
+	Version PackVersion `json:"version"`
+}
+
+// Validate validates this pack
+func (m *Pack) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateCreatedAt(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateCreatedBy(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateDescription(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateEnabled(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateItems(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateLastModified(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateLastModifiedBy(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateVersion(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *Pack) validateCreatedAt(formats strfmt.Registry) error {
+
+	if err := m.CreatedAt.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("createdAt")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *Pack) validateCreatedBy(formats strfmt.Registry) error {
+
+	if err := m.CreatedBy.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("createdBy")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *Pack) validateDescription(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Description) { // not required
+		return nil
+	}
+
+	if err := m.Description.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("description")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *Pack) validateEnabled(formats strfmt.Registry) error {
+
+	if err := m.Enabled.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("enabled")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *Pack) validateID(formats strfmt.Registry) error {
+
+	if err := m.ID.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("id")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *Pack) validateItems(formats strfmt.Registry) error {
+
+	if err := validate.Required("items", "body", m.Items); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(m.Items); i++ {
+		if swag.IsZero(m.Items[i]) { // not required
+			continue
+		}
+
+		if m.Items[i] != nil {
+			if err := m.Items[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("items" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *Pack) validateLastModified(formats strfmt.Registry) error {
+
+	if err := m.LastModified.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("lastModified")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *Pack) validateLastModifiedBy(formats strfmt.Registry) error {
+
+	if err := m.LastModifiedBy.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("lastModifiedBy")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *Pack) validateVersion(formats strfmt.Registry) error {
+
+	if err := m.Version.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("version")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *Pack) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *Pack) UnmarshalBinary(b []byte) error {
+	var res Pack
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
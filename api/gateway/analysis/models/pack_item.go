@@ -0,0 +1,142 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// PackItem pack item
+//
+// swagger:model PackItem
+type PackItem struct {
+
+	// analysis type
+	// Required: true
+	AnalysisType AnalysisType `json:"analysisType"`
+
+	// The item was edited or deleted after the pack installed it
+	// Required: true
+	Drifted *bool `json:"drifted"`
+
+	// id
+	// Required: true
+	ID ID `json:"id"`
+
+	// version Id
+	// Required: true
+	VersionID VersionID `json:"versionId"`
+}
+
+// Validate validates this pack item
+func (m *PackItem) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateAnalysisType(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateDrifted(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateVersionID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *PackItem) validateAnalysisType(formats strfmt.Registry) error {
+
+	if err := m.AnalysisType.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("analysisType")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *PackItem) validateDrifted(formats strfmt.Registry) error {
+
+	if err := validate.Required("drifted", "body", m.Drifted); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *PackItem) validateID(formats strfmt.Registry) error {
+
+	if err := m.ID.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("id")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *PackItem) validateVersionID(formats strfmt.Registry) error {
+
+	if err := m.VersionID.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("versionId")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *PackItem) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *PackItem) UnmarshalBinary(b []byte) error {
+	var res PackItem
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
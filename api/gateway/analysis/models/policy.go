@@ -72,6 +72,9 @@ type Policy struct {
 	// Required: true
 	Enabled Enabled `json:"enabled"`
 
+	// exceptions
+	Exceptions Exceptions `json:"exceptions,omitempty"`
+
 	// id
 	// Required: true
 	ID ID `json:"id"`
@@ -89,6 +92,9 @@ type Policy struct {
 	// Required: true
 	OutputIds OutputIds `json:"outputIds"`
 
+	// pack Id
+	PackID ID `json:"packId,omitempty"`
+
 	// reference
 	// Required: true
 	Reference Reference `json:"reference"`
@@ -166,6 +172,10 @@ func (m *Policy) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateExceptions(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateID(formats); err != nil {
 		res = append(res, err)
 	}
@@ -182,6 +192,10 @@ func (m *Policy) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validatePackID(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateReference(formats); err != nil {
 		res = append(res, err)
 	}
@@ -332,6 +346,22 @@ func (m *Policy) validateEnabled(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Policy) validateExceptions(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Exceptions) { // not required
+		return nil
+	}
+
+	if err := m.Exceptions.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("exceptions")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *Policy) validateID(formats strfmt.Registry) error {
 
 	if err := m.ID.Validate(formats); err != nil {
@@ -384,6 +414,22 @@ func (m *Policy) validateOutputIds(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Policy) validatePackID(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.PackID) { // not required
+		return nil
+	}
+
+	if err := m.PackID.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("packId")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *Policy) validateReference(formats strfmt.Registry) error {
 
 	if err := m.Reference.Validate(formats); err != nil {
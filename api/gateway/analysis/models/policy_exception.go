@@ -0,0 +1,299 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// PolicyException policy exception
+//
+// swagger:model PolicyException
+type PolicyException struct {
+
+	// approver
+	// Required: true
+	Approver UserID `json:"approver"`
+
+	// created at
+	// Required: true
+	// Format: date-time
+	CreatedAt ModifyTime `json:"createdAt"`
+
+	// created by
+	// Required: true
+	CreatedBy UserID `json:"createdBy"`
+
+	// exception Id
+	// Required: true
+	ExceptionID ExceptionID `json:"exceptionId"`
+
+	// The exception has expired and no longer suppresses any resources
+	Expired bool `json:"expired,omitempty"`
+
+	// expires at
+	// Required: true
+	// Format: date-time
+	ExpiresAt ExceptionExpiration `json:"expiresAt"`
+
+	// justification
+	// Required: true
+	Justification ExceptionJustification `json:"justification"`
+
+	// owner
+	// Required: true
+	Owner ExceptionOwner `json:"owner"`
+
+	// policy Id
+	// Required: true
+	PolicyID ID `json:"policyId"`
+
+	// resource pattern
+	// Required: true
+	ResourcePattern ResourcePattern `json:"resourcePattern"`
+
+	// The exception was revoked and no longer suppresses any resources
+	Revoked bool `json:"revoked,omitempty"`
+
+	// revoked at
+	// Format: date-time
+	RevokedAt ModifyTime `json:"revokedAt,omitempty"`
+
+	// revoked by
+	RevokedBy UserID `json:"revokedBy,omitempty"`
+}
+
+// Validate validates this policy exception
+func (m *PolicyException) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateApprover(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateCreatedAt(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateCreatedBy(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateExceptionID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateExpiresAt(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateJustification(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateOwner(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePolicyID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateResourcePattern(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateRevokedAt(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateRevokedBy(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *PolicyException) validateApprover(formats strfmt.Registry) error {
+
+	if err := m.Approver.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("approver")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *PolicyException) validateCreatedAt(formats strfmt.Registry) error {
+
+	if err := m.CreatedAt.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("createdAt")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *PolicyException) validateCreatedBy(formats strfmt.Registry) error {
+
+	if err := m.CreatedBy.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("createdBy")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *PolicyException) validateExceptionID(formats strfmt.Registry) error {
+
+	if err := m.ExceptionID.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("exceptionId")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *PolicyException) validateExpiresAt(formats strfmt.Registry) error {
+
+	if err := m.ExpiresAt.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("expiresAt")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *PolicyException) validateJustification(formats strfmt.Registry) error {
+
+	if err := m.Justification.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("justification")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *PolicyException) validateOwner(formats strfmt.Registry) error {
+
+	if err := m.Owner.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("owner")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *PolicyException) validatePolicyID(formats strfmt.Registry) error {
+
+	if err := m.PolicyID.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("policyId")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *PolicyException) validateResourcePattern(formats strfmt.Registry) error {
+
+	if err := m.ResourcePattern.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("resourcePattern")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *PolicyException) validateRevokedAt(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.RevokedAt) { // not required
+		return nil
+	}
+
+	if err := m.RevokedAt.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("revokedAt")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *PolicyException) validateRevokedBy(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.RevokedBy) { // not required
+		return nil
+	}
+
+	if err := m.RevokedBy.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("revokedBy")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *PolicyException) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *PolicyException) UnmarshalBinary(b []byte) error {
+	var res PolicyException
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -39,6 +39,9 @@ type Rule struct {
 	// Required: true
 	Body Body `json:"body"`
 
+	// correlation
+	Correlation *Correlation `json:"correlation,omitempty"`
+
 	// created at
 	// Required: true
 	// Format: date-time
@@ -48,6 +51,9 @@ type Rule struct {
 	// Required: true
 	CreatedBy UserID `json:"createdBy"`
 
+	// dedup column
+	DedupColumn DedupColumn `json:"dedupColumn,omitempty"`
+
 	// dedup period minutes
 	// Required: true
 	DedupPeriodMinutes DedupPeriodMinutes `json:"dedupPeriodMinutes"`
@@ -85,6 +91,9 @@ type Rule struct {
 	// Required: true
 	OutputIds OutputIds `json:"outputIds"`
 
+	// pack Id
+	PackID ID `json:"packId,omitempty"`
+
 	// reference
 	// Required: true
 	Reference Reference `json:"reference"`
@@ -97,6 +106,9 @@ type Rule struct {
 	// Required: true
 	Runbook Runbook `json:"runbook"`
 
+	// schedule
+	Schedule Schedule `json:"schedule,omitempty"`
+
 	// severity
 	// Required: true
 	Severity Severity `json:"severity"`
@@ -126,6 +138,10 @@ func (m *Rule) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateCorrelation(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateCreatedAt(formats); err != nil {
 		res = append(res, err)
 	}
@@ -134,6 +150,10 @@ func (m *Rule) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateDedupColumn(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateDedupPeriodMinutes(formats); err != nil {
 		res = append(res, err)
 	}
@@ -170,6 +190,10 @@ func (m *Rule) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validatePackID(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateReference(formats); err != nil {
 		res = append(res, err)
 	}
@@ -182,6 +206,10 @@ func (m *Rule) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateSchedule(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateSeverity(formats); err != nil {
 		res = append(res, err)
 	}
@@ -220,6 +248,24 @@ func (m *Rule) validateBody(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Rule) validateCorrelation(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Correlation) { // not required
+		return nil
+	}
+
+	if m.Correlation != nil {
+		if err := m.Correlation.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("correlation")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *Rule) validateCreatedAt(formats strfmt.Registry) error {
 
 	if err := m.CreatedAt.Validate(formats); err != nil {
@@ -244,6 +290,22 @@ func (m *Rule) validateCreatedBy(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Rule) validateDedupColumn(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.DedupColumn) { // not required
+		return nil
+	}
+
+	if err := m.DedupColumn.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("dedupColumn")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *Rule) validateDedupPeriodMinutes(formats strfmt.Registry) error {
 
 	if err := m.DedupPeriodMinutes.Validate(formats); err != nil {
@@ -360,6 +422,22 @@ func (m *Rule) validateOutputIds(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Rule) validatePackID(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.PackID) { // not required
+		return nil
+	}
+
+	if err := m.PackID.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("packId")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *Rule) validateReference(formats strfmt.Registry) error {
 
 	if err := m.Reference.Validate(formats); err != nil {
@@ -396,6 +474,22 @@ func (m *Rule) validateRunbook(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Rule) validateSchedule(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Schedule) { // not required
+		return nil
+	}
+
+	if err := m.Schedule.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("schedule")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *Rule) validateSeverity(formats strfmt.Registry) error {
 
 	if err := m.Severity.Validate(formats); err != nil {
@@ -0,0 +1,355 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// UpdateCorrelationRule update correlation rule
+//
+// swagger:model UpdateCorrelationRule
+type UpdateCorrelationRule struct {
+
+	// correlation
+	// Required: true
+	Correlation *Correlation `json:"correlation"`
+
+	// dedup period minutes
+	DedupPeriodMinutes DedupPeriodMinutes `json:"dedupPeriodMinutes,omitempty"`
+
+	// description
+	Description Description `json:"description,omitempty"`
+
+	// display name
+	DisplayName DisplayName `json:"displayName,omitempty"`
+
+	// enabled
+	// Required: true
+	Enabled Enabled `json:"enabled"`
+
+	// id
+	// Required: true
+	ID ID `json:"id"`
+
+	// output ids
+	OutputIds OutputIds `json:"outputIds,omitempty"`
+
+	// reference
+	Reference Reference `json:"reference,omitempty"`
+
+	// reports
+	Reports Reports `json:"reports,omitempty"`
+
+	// runbook
+	Runbook Runbook `json:"runbook,omitempty"`
+
+	// severity
+	// Required: true
+	Severity Severity `json:"severity"`
+
+	// tags
+	Tags Tags `json:"tags,omitempty"`
+
+	// user Id
+	// Required: true
+	UserID UserID `json:"userId"`
+}
+
+// Validate validates this update correlation rule
+func (m *UpdateCorrelationRule) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateCorrelation(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateDedupPeriodMinutes(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateDescription(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateDisplayName(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateEnabled(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateOutputIds(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateReference(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateReports(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateRunbook(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSeverity(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateTags(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateUserID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *UpdateCorrelationRule) validateCorrelation(formats strfmt.Registry) error {
+
+	if err := validate.Required("correlation", "body", m.Correlation); err != nil {
+		return err
+	}
+
+	if m.Correlation != nil {
+		if err := m.Correlation.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("correlation")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *UpdateCorrelationRule) validateDedupPeriodMinutes(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.DedupPeriodMinutes) { // not required
+		return nil
+	}
+
+	if err := m.DedupPeriodMinutes.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("dedupPeriodMinutes")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *UpdateCorrelationRule) validateDescription(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Description) { // not required
+		return nil
+	}
+
+	if err := m.Description.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("description")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *UpdateCorrelationRule) validateDisplayName(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.DisplayName) { // not required
+		return nil
+	}
+
+	if err := m.DisplayName.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("displayName")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *UpdateCorrelationRule) validateEnabled(formats strfmt.Registry) error {
+
+	if err := m.Enabled.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("enabled")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *UpdateCorrelationRule) validateID(formats strfmt.Registry) error {
+
+	if err := m.ID.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("id")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *UpdateCorrelationRule) validateOutputIds(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.OutputIds) { // not required
+		return nil
+	}
+
+	if err := m.OutputIds.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("outputIds")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *UpdateCorrelationRule) validateReference(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Reference) { // not required
+		return nil
+	}
+
+	if err := m.Reference.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("reference")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *UpdateCorrelationRule) validateReports(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Reports) { // not required
+		return nil
+	}
+
+	if err := m.Reports.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("reports")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *UpdateCorrelationRule) validateRunbook(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Runbook) { // not required
+		return nil
+	}
+
+	if err := m.Runbook.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("runbook")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *UpdateCorrelationRule) validateSeverity(formats strfmt.Registry) error {
+
+	if err := m.Severity.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("severity")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *UpdateCorrelationRule) validateTags(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Tags) { // not required
+		return nil
+	}
+
+	if err := m.Tags.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("tags")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *UpdateCorrelationRule) validateUserID(formats strfmt.Registry) error {
+
+	if err := m.UserID.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("userId")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *UpdateCorrelationRule) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *UpdateCorrelationRule) UnmarshalBinary(b []byte) error {
+	var res UpdateCorrelationRule
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
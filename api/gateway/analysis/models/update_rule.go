@@ -38,6 +38,9 @@ type UpdateRule struct {
 	// Required: true
 	Body Body `json:"body"`
 
+	// dedup column
+	DedupColumn DedupColumn `json:"dedupColumn,omitempty"`
+
 	// dedup period minutes
 	DedupPeriodMinutes DedupPeriodMinutes `json:"dedupPeriodMinutes,omitempty"`
 
@@ -70,6 +73,9 @@ type UpdateRule struct {
 	// runbook
 	Runbook Runbook `json:"runbook,omitempty"`
 
+	// schedule
+	Schedule Schedule `json:"schedule,omitempty"`
+
 	// severity
 	// Required: true
 	Severity Severity `json:"severity"`
@@ -96,6 +102,10 @@ func (m *UpdateRule) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateDedupColumn(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateDedupPeriodMinutes(formats); err != nil {
 		res = append(res, err)
 	}
@@ -136,6 +146,10 @@ func (m *UpdateRule) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateSchedule(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateSeverity(formats); err != nil {
 		res = append(res, err)
 	}
@@ -174,6 +188,22 @@ func (m *UpdateRule) validateBody(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *UpdateRule) validateDedupColumn(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.DedupColumn) { // not required
+		return nil
+	}
+
+	if err := m.DedupColumn.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("dedupColumn")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *UpdateRule) validateDedupPeriodMinutes(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.DedupPeriodMinutes) { // not required
@@ -326,6 +356,22 @@ func (m *UpdateRule) validateRunbook(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *UpdateRule) validateSchedule(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Schedule) { // not required
+		return nil
+	}
+
+	if err := m.Schedule.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("schedule")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *UpdateRule) validateSeverity(formats strfmt.Registry) error {
 
 	if err := m.Severity.Validate(formats); err != nil {
@@ -0,0 +1,146 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// VersionSummary version summary
+//
+// swagger:model VersionSummary
+type VersionSummary struct {
+
+	// True if this is the current version
+	// Required: true
+	IsLatest *bool `json:"isLatest"`
+
+	// last modified
+	// Required: true
+	// Format: date-time
+	LastModified ModifyTime `json:"lastModified"`
+
+	// last modified by
+	LastModifiedBy UserID `json:"lastModifiedBy,omitempty"`
+
+	// version Id
+	// Required: true
+	VersionID VersionID `json:"versionId"`
+}
+
+// Validate validates this version summary
+func (m *VersionSummary) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateIsLatest(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateLastModified(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateLastModifiedBy(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateVersionID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *VersionSummary) validateIsLatest(formats strfmt.Registry) error {
+
+	if err := validate.Required("isLatest", "body", m.IsLatest); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *VersionSummary) validateLastModified(formats strfmt.Registry) error {
+
+	if err := m.LastModified.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("lastModified")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *VersionSummary) validateLastModifiedBy(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.LastModifiedBy) { // not required
+		return nil
+	}
+
+	if err := m.LastModifiedBy.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("lastModifiedBy")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *VersionSummary) validateVersionID(formats strfmt.Registry) error {
+
+	if err := m.VersionID.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("versionId")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *VersionSummary) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *VersionSummary) UnmarshalBinary(b []byte) error {
+	var res VersionSummary
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,233 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// NewExportComplianceReportParams creates a new ExportComplianceReportParams object
+// with the default values initialized.
+func NewExportComplianceReportParams() *ExportComplianceReportParams {
+	var (
+		formatDefault = string("csv")
+	)
+	return &ExportComplianceReportParams{
+		Format: &formatDefault,
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewExportComplianceReportParamsWithTimeout creates a new ExportComplianceReportParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewExportComplianceReportParamsWithTimeout(timeout time.Duration) *ExportComplianceReportParams {
+	var (
+		formatDefault = string("csv")
+	)
+	return &ExportComplianceReportParams{
+		Format: &formatDefault,
+
+		timeout: timeout,
+	}
+}
+
+// NewExportComplianceReportParamsWithContext creates a new ExportComplianceReportParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewExportComplianceReportParamsWithContext(ctx context.Context) *ExportComplianceReportParams {
+	var (
+		formatDefault = string("csv")
+	)
+	return &ExportComplianceReportParams{
+		Format: &formatDefault,
+
+		Context: ctx,
+	}
+}
+
+// NewExportComplianceReportParamsWithHTTPClient creates a new ExportComplianceReportParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewExportComplianceReportParamsWithHTTPClient(client *http.Client) *ExportComplianceReportParams {
+	var (
+		formatDefault = string("csv")
+	)
+	return &ExportComplianceReportParams{
+		Format:     &formatDefault,
+		HTTPClient: client,
+	}
+}
+
+/*ExportComplianceReportParams contains all the parameters to send to the API endpoint
+for the export compliance report operation typically these are written to a http.Request
+*/
+type ExportComplianceReportParams struct {
+
+	/*Format
+	  File format of the exported report
+
+	*/
+	Format *string
+	/*Framework
+	  URL-encoded compliance framework name, e.g. CIS (a key in the policy reports)
+
+	*/
+	Framework string
+	/*IntegrationID
+	  Only report on resources from this integration
+
+	*/
+	IntegrationID *string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the export compliance report params
+func (o *ExportComplianceReportParams) WithTimeout(timeout time.Duration) *ExportComplianceReportParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the export compliance report params
+func (o *ExportComplianceReportParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the export compliance report params
+func (o *ExportComplianceReportParams) WithContext(ctx context.Context) *ExportComplianceReportParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the export compliance report params
+func (o *ExportComplianceReportParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the export compliance report params
+func (o *ExportComplianceReportParams) WithHTTPClient(client *http.Client) *ExportComplianceReportParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the export compliance report params
+func (o *ExportComplianceReportParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithFormat adds the format to the export compliance report params
+func (o *ExportComplianceReportParams) WithFormat(format *string) *ExportComplianceReportParams {
+	o.SetFormat(format)
+	return o
+}
+
+// SetFormat adds the format to the export compliance report params
+func (o *ExportComplianceReportParams) SetFormat(format *string) {
+	o.Format = format
+}
+
+// WithFramework adds the framework to the export compliance report params
+func (o *ExportComplianceReportParams) WithFramework(framework string) *ExportComplianceReportParams {
+	o.SetFramework(framework)
+	return o
+}
+
+// SetFramework adds the framework to the export compliance report params
+func (o *ExportComplianceReportParams) SetFramework(framework string) {
+	o.Framework = framework
+}
+
+// WithIntegrationID adds the integrationID to the export compliance report params
+func (o *ExportComplianceReportParams) WithIntegrationID(integrationID *string) *ExportComplianceReportParams {
+	o.SetIntegrationID(integrationID)
+	return o
+}
+
+// SetIntegrationID adds the integrationId to the export compliance report params
+func (o *ExportComplianceReportParams) SetIntegrationID(integrationID *string) {
+	o.IntegrationID = integrationID
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *ExportComplianceReportParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	if o.Format != nil {
+
+		// query param format
+		var qrFormat string
+		if o.Format != nil {
+			qrFormat = *o.Format
+		}
+		qFormat := qrFormat
+		if qFormat != "" {
+			if err := r.SetQueryParam("format", qFormat); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	// query param framework
+	qrFramework := o.Framework
+	qFramework := qrFramework
+	if qFramework != "" {
+		if err := r.SetQueryParam("framework", qFramework); err != nil {
+			return err
+		}
+	}
+
+	if o.IntegrationID != nil {
+
+		// query param integrationId
+		var qrIntegrationID string
+		if o.IntegrationID != nil {
+			qrIntegrationID = *o.IntegrationID
+		}
+		qIntegrationID := qrIntegrationID
+		if qIntegrationID != "" {
+			if err := r.SetQueryParam("integrationId", qIntegrationID); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/compliance/models"
+)
+
+// ExportComplianceReportReader is a Reader for the ExportComplianceReport structure.
+type ExportComplianceReportReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *ExportComplianceReportReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewExportComplianceReportOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewExportComplianceReportBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewExportComplianceReportInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewExportComplianceReportOK creates a ExportComplianceReportOK with default headers values
+func NewExportComplianceReportOK() *ExportComplianceReportOK {
+	return &ExportComplianceReportOK{}
+}
+
+/*ExportComplianceReportOK handles this case with default header values.
+
+OK
+*/
+type ExportComplianceReportOK struct {
+	Payload *models.ReportExport
+}
+
+func (o *ExportComplianceReportOK) Error() string {
+	return fmt.Sprintf("[GET /report/export][%d] exportComplianceReportOK  %+v", 200, o.Payload)
+}
+
+func (o *ExportComplianceReportOK) GetPayload() *models.ReportExport {
+	return o.Payload
+}
+
+func (o *ExportComplianceReportOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.ReportExport)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewExportComplianceReportBadRequest creates a ExportComplianceReportBadRequest with default headers values
+func NewExportComplianceReportBadRequest() *ExportComplianceReportBadRequest {
+	return &ExportComplianceReportBadRequest{}
+}
+
+/*ExportComplianceReportBadRequest handles this case with default header values.
+
+Bad request
+*/
+type ExportComplianceReportBadRequest struct {
+	Payload *models.Error
+}
+
+func (o *ExportComplianceReportBadRequest) Error() string {
+	return fmt.Sprintf("[GET /report/export][%d] exportComplianceReportBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *ExportComplianceReportBadRequest) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *ExportComplianceReportBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewExportComplianceReportInternalServerError creates a ExportComplianceReportInternalServerError with default headers values
+func NewExportComplianceReportInternalServerError() *ExportComplianceReportInternalServerError {
+	return &ExportComplianceReportInternalServerError{}
+}
+
+/*ExportComplianceReportInternalServerError handles this case with default header values.
+
+Internal server error
+*/
+type ExportComplianceReportInternalServerError struct {
+}
+
+func (o *ExportComplianceReportInternalServerError) Error() string {
+	return fmt.Sprintf("[GET /report/export][%d] exportComplianceReportInternalServerError ", 500)
+}
+
+func (o *ExportComplianceReportInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
@@ -0,0 +1,189 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// NewGetComplianceReportParams creates a new GetComplianceReportParams object
+// with the default values initialized.
+func NewGetComplianceReportParams() *GetComplianceReportParams {
+	var ()
+	return &GetComplianceReportParams{
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewGetComplianceReportParamsWithTimeout creates a new GetComplianceReportParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewGetComplianceReportParamsWithTimeout(timeout time.Duration) *GetComplianceReportParams {
+	var ()
+	return &GetComplianceReportParams{
+
+		timeout: timeout,
+	}
+}
+
+// NewGetComplianceReportParamsWithContext creates a new GetComplianceReportParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewGetComplianceReportParamsWithContext(ctx context.Context) *GetComplianceReportParams {
+	var ()
+	return &GetComplianceReportParams{
+
+		Context: ctx,
+	}
+}
+
+// NewGetComplianceReportParamsWithHTTPClient creates a new GetComplianceReportParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewGetComplianceReportParamsWithHTTPClient(client *http.Client) *GetComplianceReportParams {
+	var ()
+	return &GetComplianceReportParams{
+		HTTPClient: client,
+	}
+}
+
+/*GetComplianceReportParams contains all the parameters to send to the API endpoint
+for the get compliance report operation typically these are written to a http.Request
+*/
+type GetComplianceReportParams struct {
+
+	/*Framework
+	  URL-encoded compliance framework name, e.g. CIS (a key in the policy reports)
+
+	*/
+	Framework string
+	/*IntegrationID
+	  Only report on resources from this integration
+
+	*/
+	IntegrationID *string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the get compliance report params
+func (o *GetComplianceReportParams) WithTimeout(timeout time.Duration) *GetComplianceReportParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the get compliance report params
+func (o *GetComplianceReportParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the get compliance report params
+func (o *GetComplianceReportParams) WithContext(ctx context.Context) *GetComplianceReportParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the get compliance report params
+func (o *GetComplianceReportParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the get compliance report params
+func (o *GetComplianceReportParams) WithHTTPClient(client *http.Client) *GetComplianceReportParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the get compliance report params
+func (o *GetComplianceReportParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithFramework adds the framework to the get compliance report params
+func (o *GetComplianceReportParams) WithFramework(framework string) *GetComplianceReportParams {
+	o.SetFramework(framework)
+	return o
+}
+
+// SetFramework adds the framework to the get compliance report params
+func (o *GetComplianceReportParams) SetFramework(framework string) {
+	o.Framework = framework
+}
+
+// WithIntegrationID adds the integrationID to the get compliance report params
+func (o *GetComplianceReportParams) WithIntegrationID(integrationID *string) *GetComplianceReportParams {
+	o.SetIntegrationID(integrationID)
+	return o
+}
+
+// SetIntegrationID adds the integrationId to the get compliance report params
+func (o *GetComplianceReportParams) SetIntegrationID(integrationID *string) {
+	o.IntegrationID = integrationID
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *GetComplianceReportParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	// query param framework
+	qrFramework := o.Framework
+	qFramework := qrFramework
+	if qFramework != "" {
+		if err := r.SetQueryParam("framework", qFramework); err != nil {
+			return err
+		}
+	}
+
+	if o.IntegrationID != nil {
+
+		// query param integrationId
+		var qrIntegrationID string
+		if o.IntegrationID != nil {
+			qrIntegrationID = *o.IntegrationID
+		}
+		qIntegrationID := qrIntegrationID
+		if qIntegrationID != "" {
+			if err := r.SetQueryParam("integrationId", qIntegrationID); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/compliance/models"
+)
+
+// GetComplianceReportReader is a Reader for the GetComplianceReport structure.
+type GetComplianceReportReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *GetComplianceReportReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewGetComplianceReportOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewGetComplianceReportBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewGetComplianceReportInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewGetComplianceReportOK creates a GetComplianceReportOK with default headers values
+func NewGetComplianceReportOK() *GetComplianceReportOK {
+	return &GetComplianceReportOK{}
+}
+
+/*GetComplianceReportOK handles this case with default header values.
+
+OK
+*/
+type GetComplianceReportOK struct {
+	Payload *models.ComplianceReport
+}
+
+func (o *GetComplianceReportOK) Error() string {
+	return fmt.Sprintf("[GET /report][%d] getComplianceReportOK  %+v", 200, o.Payload)
+}
+
+func (o *GetComplianceReportOK) GetPayload() *models.ComplianceReport {
+	return o.Payload
+}
+
+func (o *GetComplianceReportOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.ComplianceReport)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewGetComplianceReportBadRequest creates a GetComplianceReportBadRequest with default headers values
+func NewGetComplianceReportBadRequest() *GetComplianceReportBadRequest {
+	return &GetComplianceReportBadRequest{}
+}
+
+/*GetComplianceReportBadRequest handles this case with default header values.
+
+Bad request
+*/
+type GetComplianceReportBadRequest struct {
+	Payload *models.Error
+}
+
+func (o *GetComplianceReportBadRequest) Error() string {
+	return fmt.Sprintf("[GET /report][%d] getComplianceReportBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *GetComplianceReportBadRequest) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *GetComplianceReportBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewGetComplianceReportInternalServerError creates a GetComplianceReportInternalServerError with default headers values
+func NewGetComplianceReportInternalServerError() *GetComplianceReportInternalServerError {
+	return &GetComplianceReportInternalServerError{}
+}
+
+/*GetComplianceReportInternalServerError handles this case with default header values.
+
+Internal server error
+*/
+type GetComplianceReportInternalServerError struct {
+}
+
+func (o *GetComplianceReportInternalServerError) Error() string {
+	return fmt.Sprintf("[GET /report][%d] getComplianceReportInternalServerError ", 500)
+}
+
+func (o *GetComplianceReportInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
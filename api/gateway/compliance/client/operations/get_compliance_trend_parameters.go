@@ -0,0 +1,272 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// NewGetComplianceTrendParams creates a new GetComplianceTrendParams object
+// with the default values initialized.
+func NewGetComplianceTrendParams() *GetComplianceTrendParams {
+	var (
+		dimensionDefault = string("org")
+	)
+	return &GetComplianceTrendParams{
+		Dimension: &dimensionDefault,
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewGetComplianceTrendParamsWithTimeout creates a new GetComplianceTrendParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewGetComplianceTrendParamsWithTimeout(timeout time.Duration) *GetComplianceTrendParams {
+	var (
+		dimensionDefault = string("org")
+	)
+	return &GetComplianceTrendParams{
+		Dimension: &dimensionDefault,
+
+		timeout: timeout,
+	}
+}
+
+// NewGetComplianceTrendParamsWithContext creates a new GetComplianceTrendParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewGetComplianceTrendParamsWithContext(ctx context.Context) *GetComplianceTrendParams {
+	var (
+		dimensionDefault = string("org")
+	)
+	return &GetComplianceTrendParams{
+		Dimension: &dimensionDefault,
+
+		Context: ctx,
+	}
+}
+
+// NewGetComplianceTrendParamsWithHTTPClient creates a new GetComplianceTrendParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewGetComplianceTrendParamsWithHTTPClient(client *http.Client) *GetComplianceTrendParams {
+	var (
+		dimensionDefault = string("org")
+	)
+	return &GetComplianceTrendParams{
+		Dimension:  &dimensionDefault,
+		HTTPClient: client,
+	}
+}
+
+/*GetComplianceTrendParams contains all the parameters to send to the API endpoint
+for the get compliance trend operation typically these are written to a http.Request
+*/
+type GetComplianceTrendParams struct {
+
+	/*Dimension
+	  How the compliance snapshots are grouped
+
+	*/
+	Dimension *string
+	/*EndDate
+	  Last day of the time series (YYYY-MM-DD, default today)
+
+	*/
+	EndDate *strfmt.Date
+	/*StartDate
+	  First day of the time series (YYYY-MM-DD, default 90 days ago)
+
+	*/
+	StartDate *strfmt.Date
+	/*Value
+	  URL-encoded dimension value, e.g. a policy ID or tag (required unless the dimension is "org")
+
+	*/
+	Value *string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the get compliance trend params
+func (o *GetComplianceTrendParams) WithTimeout(timeout time.Duration) *GetComplianceTrendParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the get compliance trend params
+func (o *GetComplianceTrendParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the get compliance trend params
+func (o *GetComplianceTrendParams) WithContext(ctx context.Context) *GetComplianceTrendParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the get compliance trend params
+func (o *GetComplianceTrendParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the get compliance trend params
+func (o *GetComplianceTrendParams) WithHTTPClient(client *http.Client) *GetComplianceTrendParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the get compliance trend params
+func (o *GetComplianceTrendParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithDimension adds the dimension to the get compliance trend params
+func (o *GetComplianceTrendParams) WithDimension(dimension *string) *GetComplianceTrendParams {
+	o.SetDimension(dimension)
+	return o
+}
+
+// SetDimension adds the dimension to the get compliance trend params
+func (o *GetComplianceTrendParams) SetDimension(dimension *string) {
+	o.Dimension = dimension
+}
+
+// WithEndDate adds the endDate to the get compliance trend params
+func (o *GetComplianceTrendParams) WithEndDate(endDate *strfmt.Date) *GetComplianceTrendParams {
+	o.SetEndDate(endDate)
+	return o
+}
+
+// SetEndDate adds the endDate to the get compliance trend params
+func (o *GetComplianceTrendParams) SetEndDate(endDate *strfmt.Date) {
+	o.EndDate = endDate
+}
+
+// WithStartDate adds the startDate to the get compliance trend params
+func (o *GetComplianceTrendParams) WithStartDate(startDate *strfmt.Date) *GetComplianceTrendParams {
+	o.SetStartDate(startDate)
+	return o
+}
+
+// SetStartDate adds the startDate to the get compliance trend params
+func (o *GetComplianceTrendParams) SetStartDate(startDate *strfmt.Date) {
+	o.StartDate = startDate
+}
+
+// WithValue adds the value to the get compliance trend params
+func (o *GetComplianceTrendParams) WithValue(value *string) *GetComplianceTrendParams {
+	o.SetValue(value)
+	return o
+}
+
+// SetValue adds the value to the get compliance trend params
+func (o *GetComplianceTrendParams) SetValue(value *string) {
+	o.Value = value
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *GetComplianceTrendParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	if o.Dimension != nil {
+
+		// query param dimension
+		var qrDimension string
+		if o.Dimension != nil {
+			qrDimension = *o.Dimension
+		}
+		qDimension := qrDimension
+		if qDimension != "" {
+			if err := r.SetQueryParam("dimension", qDimension); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.EndDate != nil {
+
+		// query param endDate
+		var qrEndDate strfmt.Date
+		if o.EndDate != nil {
+			qrEndDate = *o.EndDate
+		}
+		qEndDate := qrEndDate.String()
+		if qEndDate != "" {
+			if err := r.SetQueryParam("endDate", qEndDate); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.StartDate != nil {
+
+		// query param startDate
+		var qrStartDate strfmt.Date
+		if o.StartDate != nil {
+			qrStartDate = *o.StartDate
+		}
+		qStartDate := qrStartDate.String()
+		if qStartDate != "" {
+			if err := r.SetQueryParam("startDate", qStartDate); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.Value != nil {
+
+		// query param value
+		var qrValue string
+		if o.Value != nil {
+			qrValue = *o.Value
+		}
+		qValue := qrValue
+		if qValue != "" {
+			if err := r.SetQueryParam("value", qValue); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/compliance/models"
+)
+
+// GetComplianceTrendReader is a Reader for the GetComplianceTrend structure.
+type GetComplianceTrendReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *GetComplianceTrendReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewGetComplianceTrendOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewGetComplianceTrendBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewGetComplianceTrendInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewGetComplianceTrendOK creates a GetComplianceTrendOK with default headers values
+func NewGetComplianceTrendOK() *GetComplianceTrendOK {
+	return &GetComplianceTrendOK{}
+}
+
+/*GetComplianceTrendOK handles this case with default header values.
+
+OK
+*/
+type GetComplianceTrendOK struct {
+	Payload *models.ComplianceTrend
+}
+
+func (o *GetComplianceTrendOK) Error() string {
+	return fmt.Sprintf("[GET /trend][%d] getComplianceTrendOK  %+v", 200, o.Payload)
+}
+
+func (o *GetComplianceTrendOK) GetPayload() *models.ComplianceTrend {
+	return o.Payload
+}
+
+func (o *GetComplianceTrendOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.ComplianceTrend)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewGetComplianceTrendBadRequest creates a GetComplianceTrendBadRequest with default headers values
+func NewGetComplianceTrendBadRequest() *GetComplianceTrendBadRequest {
+	return &GetComplianceTrendBadRequest{}
+}
+
+/*GetComplianceTrendBadRequest handles this case with default header values.
+
+Bad request
+*/
+type GetComplianceTrendBadRequest struct {
+	Payload *models.Error
+}
+
+func (o *GetComplianceTrendBadRequest) Error() string {
+	return fmt.Sprintf("[GET /trend][%d] getComplianceTrendBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *GetComplianceTrendBadRequest) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *GetComplianceTrendBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewGetComplianceTrendInternalServerError creates a GetComplianceTrendInternalServerError with default headers values
+func NewGetComplianceTrendInternalServerError() *GetComplianceTrendInternalServerError {
+	return &GetComplianceTrendInternalServerError{}
+}
+
+/*GetComplianceTrendInternalServerError handles this case with default header values.
+
+Internal server error
+*/
+type GetComplianceTrendInternalServerError struct {
+}
+
+func (o *GetComplianceTrendInternalServerError) Error() string {
+	return fmt.Sprintf("[GET /trend][%d] getComplianceTrendInternalServerError ", 500)
+}
+
+func (o *GetComplianceTrendInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
@@ -53,12 +53,22 @@ type ClientService interface {
 
 	DescribeResource(params *DescribeResourceParams) (*DescribeResourceOK, error)
 
+	ExportComplianceReport(params *ExportComplianceReportParams) (*ExportComplianceReportOK, error)
+
+	GetComplianceReport(params *GetComplianceReportParams) (*GetComplianceReportOK, error)
+
+	GetComplianceTrend(params *GetComplianceTrendParams) (*GetComplianceTrendOK, error)
+
 	GetOrgOverview(params *GetOrgOverviewParams) (*GetOrgOverviewOK, error)
 
 	GetStatus(params *GetStatusParams) (*GetStatusOK, error)
 
+	ListReportFrameworks(params *ListReportFrameworksParams) (*ListReportFrameworksOK, error)
+
 	SetStatus(params *SetStatusParams) (*SetStatusCreated, error)
 
+	TakeComplianceSnapshot(params *TakeComplianceSnapshotParams) (*TakeComplianceSnapshotOK, error)
+
 	UpdateMetadata(params *UpdateMetadataParams) (*UpdateMetadataOK, error)
 
 	SetTransport(transport runtime.ClientTransport)
@@ -200,6 +210,108 @@ func (a *Client) DescribeResource(params *DescribeResourceParams) (*DescribeReso
 	panic(msg)
 }
 
+/*
+  ExportComplianceReport exports a compliance framework report as c s v JSON or HTML
+*/
+func (a *Client) ExportComplianceReport(params *ExportComplianceReportParams) (*ExportComplianceReportOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewExportComplianceReportParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "ExportComplianceReport",
+		Method:             "GET",
+		PathPattern:        "/report/export",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ExportComplianceReportReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ExportComplianceReportOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for ExportComplianceReport: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  GetComplianceReport gets the per control status of a compliance framework
+*/
+func (a *Client) GetComplianceReport(params *GetComplianceReportParams) (*GetComplianceReportOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetComplianceReportParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "GetComplianceReport",
+		Method:             "GET",
+		PathPattern:        "/report",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &GetComplianceReportReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetComplianceReportOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for GetComplianceReport: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  GetComplianceTrend gets the daily pass fail counts for a slice of the organization
+*/
+func (a *Client) GetComplianceTrend(params *GetComplianceTrendParams) (*GetComplianceTrendOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetComplianceTrendParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "GetComplianceTrend",
+		Method:             "GET",
+		PathPattern:        "/trend",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &GetComplianceTrendReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetComplianceTrendOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for GetComplianceTrend: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
   GetOrgOverview gets account totals and top failing policies resources
 */
@@ -268,6 +380,40 @@ func (a *Client) GetStatus(params *GetStatusParams) (*GetStatusOK, error) {
 	panic(msg)
 }
 
+/*
+  ListReportFrameworks lists the compliance frameworks referenced by policy results
+*/
+func (a *Client) ListReportFrameworks(params *ListReportFrameworksParams) (*ListReportFrameworksOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListReportFrameworksParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "ListReportFrameworks",
+		Method:             "GET",
+		PathPattern:        "/report/frameworks",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ListReportFrameworksReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListReportFrameworksOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for ListReportFrameworks: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
   SetStatus sets the compliance status for a batch of resource policy pairs
 */
@@ -302,6 +448,40 @@ func (a *Client) SetStatus(params *SetStatusParams) (*SetStatusCreated, error) {
 	panic(msg)
 }
 
+/*
+  TakeComplianceSnapshot stores today s aggregate compliance counts for every trend dimension
+*/
+func (a *Client) TakeComplianceSnapshot(params *TakeComplianceSnapshotParams) (*TakeComplianceSnapshotOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewTakeComplianceSnapshotParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "TakeComplianceSnapshot",
+		Method:             "POST",
+		PathPattern:        "/trend/snapshot",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &TakeComplianceSnapshotReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*TakeComplianceSnapshotOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for TakeComplianceSnapshot: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
   UpdateMetadata updates
 */
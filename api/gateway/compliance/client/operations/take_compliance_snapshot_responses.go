@@ -0,0 +1,114 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/compliance/models"
+)
+
+// TakeComplianceSnapshotReader is a Reader for the TakeComplianceSnapshot structure.
+type TakeComplianceSnapshotReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *TakeComplianceSnapshotReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewTakeComplianceSnapshotOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 500:
+		result := NewTakeComplianceSnapshotInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewTakeComplianceSnapshotOK creates a TakeComplianceSnapshotOK with default headers values
+func NewTakeComplianceSnapshotOK() *TakeComplianceSnapshotOK {
+	return &TakeComplianceSnapshotOK{}
+}
+
+/*TakeComplianceSnapshotOK handles this case with default header values.
+
+OK
+*/
+type TakeComplianceSnapshotOK struct {
+	Payload *models.ComplianceSnapshot
+}
+
+func (o *TakeComplianceSnapshotOK) Error() string {
+	return fmt.Sprintf("[POST /trend/snapshot][%d] takeComplianceSnapshotOK  %+v", 200, o.Payload)
+}
+
+func (o *TakeComplianceSnapshotOK) GetPayload() *models.ComplianceSnapshot {
+	return o.Payload
+}
+
+func (o *TakeComplianceSnapshotOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.ComplianceSnapshot)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewTakeComplianceSnapshotInternalServerError creates a TakeComplianceSnapshotInternalServerError with default headers values
+func NewTakeComplianceSnapshotInternalServerError() *TakeComplianceSnapshotInternalServerError {
+	return &TakeComplianceSnapshotInternalServerError{}
+}
+
+/*TakeComplianceSnapshotInternalServerError handles this case with default header values.
+
+Internal server error
+*/
+type TakeComplianceSnapshotInternalServerError struct {
+}
+
+func (o *TakeComplianceSnapshotInternalServerError) Error() string {
+	return fmt.Sprintf("[POST /trend/snapshot][%d] takeComplianceSnapshotInternalServerError ", 500)
+}
+
+func (o *TakeComplianceSnapshotInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
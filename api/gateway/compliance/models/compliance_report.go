@@ -0,0 +1,168 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// ComplianceReport compliance report
+//
+// swagger:model ComplianceReport
+type ComplianceReport struct {
+
+	// controls
+	// Required: true
+	Controls []*ReportControl `json:"controls"`
+
+	// framework
+	// Required: true
+	Framework *string `json:"framework"`
+
+	// generated at
+	// Required: true
+	// Format: date-time
+	GeneratedAt *strfmt.DateTime `json:"generatedAt"`
+
+	// The report only covers this integration (if specified)
+	IntegrationID string `json:"integrationId,omitempty"`
+
+	// Number of controls in each status
+	// Required: true
+	Summary *StatusCount `json:"summary"`
+}
+
+// Validate validates this compliance report
+func (m *ComplianceReport) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateControls(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateFramework(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateGeneratedAt(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSummary(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *ComplianceReport) validateControls(formats strfmt.Registry) error {
+
+	if err := validate.Required("controls", "body", m.Controls); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(m.Controls); i++ {
+		if swag.IsZero(m.Controls[i]) { // not required
+			continue
+		}
+
+		if m.Controls[i] != nil {
+			if err := m.Controls[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("controls" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *ComplianceReport) validateFramework(formats strfmt.Registry) error {
+
+	if err := validate.Required("framework", "body", m.Framework); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *ComplianceReport) validateGeneratedAt(formats strfmt.Registry) error {
+
+	if err := validate.Required("generatedAt", "body", m.GeneratedAt); err != nil {
+		return err
+	}
+
+	if err := validate.FormatOf("generatedAt", "body", "date-time", m.GeneratedAt.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *ComplianceReport) validateSummary(formats strfmt.Registry) error {
+
+	if err := validate.Required("summary", "body", m.Summary); err != nil {
+		return err
+	}
+
+	if m.Summary != nil {
+		if err := m.Summary.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("summary")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *ComplianceReport) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *ComplianceReport) UnmarshalBinary(b []byte) error {
+	var res ComplianceReport
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
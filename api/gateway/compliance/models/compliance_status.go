@@ -54,10 +54,16 @@ type ComplianceStatus struct {
 	// Required: true
 	PolicyID PolicyID `json:"policyId"`
 
+	// policy reports
+	PolicyReports PolicyReports `json:"policyReports,omitempty"`
+
 	// policy severity
 	// Required: true
 	PolicySeverity PolicySeverity `json:"policySeverity"`
 
+	// policy tags
+	PolicyTags PolicyTags `json:"policyTags,omitempty"`
+
 	// resource Id
 	// Required: true
 	ResourceID ResourceID `json:"resourceId"`
@@ -99,10 +105,18 @@ func (m *ComplianceStatus) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validatePolicyReports(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validatePolicySeverity(formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := m.validatePolicyTags(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateResourceID(formats); err != nil {
 		res = append(res, err)
 	}
@@ -189,6 +203,22 @@ func (m *ComplianceStatus) validatePolicyID(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *ComplianceStatus) validatePolicyReports(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.PolicyReports) { // not required
+		return nil
+	}
+
+	if err := m.PolicyReports.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("policyReports")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *ComplianceStatus) validatePolicySeverity(formats strfmt.Registry) error {
 
 	if err := m.PolicySeverity.Validate(formats); err != nil {
@@ -201,6 +231,22 @@ func (m *ComplianceStatus) validatePolicySeverity(formats strfmt.Registry) error
 	return nil
 }
 
+func (m *ComplianceStatus) validatePolicyTags(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.PolicyTags) { // not required
+		return nil
+	}
+
+	if err := m.PolicyTags.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("policyTags")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *ComplianceStatus) validateResourceID(formats strfmt.Registry) error {
 
 	if err := m.ResourceID.Validate(formats); err != nil {
@@ -0,0 +1,120 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// ComplianceTrend compliance trend
+//
+// swagger:model ComplianceTrend
+type ComplianceTrend struct {
+
+	// dimension
+	// Required: true
+	Dimension *string `json:"dimension"`
+
+	// points
+	// Required: true
+	Points []*TrendPoint `json:"points"`
+
+	// value
+	Value string `json:"value,omitempty"`
+}
+
+// Validate validates this compliance trend
+func (m *ComplianceTrend) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateDimension(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePoints(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *ComplianceTrend) validateDimension(formats strfmt.Registry) error {
+
+	if err := validate.Required("dimension", "body", m.Dimension); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *ComplianceTrend) validatePoints(formats strfmt.Registry) error {
+
+	if err := validate.Required("points", "body", m.Points); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(m.Points); i++ {
+		if swag.IsZero(m.Points[i]) { // not required
+			continue
+		}
+
+		if m.Points[i] != nil {
+			if err := m.Points[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("points" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *ComplianceTrend) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *ComplianceTrend) UnmarshalBinary(b []byte) error {
+	var res ComplianceTrend
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,185 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// ControlEvidence control evidence
+//
+// swagger:model ControlEvidence
+type ControlEvidence struct {
+
+	// integration Id
+	// Required: true
+	IntegrationID IntegrationID `json:"integrationId"`
+
+	// last updated
+	// Required: true
+	// Format: date-time
+	LastUpdated LastUpdated `json:"lastUpdated"`
+
+	// policy Id
+	// Required: true
+	PolicyID PolicyID `json:"policyId"`
+
+	// resource Id
+	// Required: true
+	ResourceID ResourceID `json:"resourceId"`
+
+	// resource type
+	// Required: true
+	ResourceType ResourceType `json:"resourceType"`
+
+	// status
+	// Required: true
+	Status Status `json:"status"`
+}
+
+// Validate validates this control evidence
+func (m *ControlEvidence) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateIntegrationID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateLastUpdated(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePolicyID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateResourceID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateResourceType(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateStatus(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *ControlEvidence) validateIntegrationID(formats strfmt.Registry) error {
+
+	if err := m.IntegrationID.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("integrationId")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *ControlEvidence) validateLastUpdated(formats strfmt.Registry) error {
+
+	if err := m.LastUpdated.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("lastUpdated")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *ControlEvidence) validatePolicyID(formats strfmt.Registry) error {
+
+	if err := m.PolicyID.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("policyId")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *ControlEvidence) validateResourceID(formats strfmt.Registry) error {
+
+	if err := m.ResourceID.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("resourceId")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *ControlEvidence) validateResourceType(formats strfmt.Registry) error {
+
+	if err := m.ResourceType.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("resourceType")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *ControlEvidence) validateStatus(formats strfmt.Registry) error {
+
+	if err := m.Status.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("status")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *ControlEvidence) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *ControlEvidence) UnmarshalBinary(b []byte) error {
+	var res ControlEvidence
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
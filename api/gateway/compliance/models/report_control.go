@@ -0,0 +1,223 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// ReportControl report control
+//
+// swagger:model ReportControl
+type ReportControl struct {
+
+	// accounts
+	// Required: true
+	Accounts []*ControlAccount `json:"accounts"`
+
+	// control
+	// Required: true
+	Control *string `json:"control"`
+
+	// Number of resources in each status
+	// Required: true
+	Count *StatusCount `json:"count"`
+
+	// Status of each policy and resource, only included in exported reports
+	Evidence []*ControlEvidence `json:"evidence"`
+
+	// policies
+	// Required: true
+	Policies []PolicyID `json:"policies"`
+
+	// status
+	// Required: true
+	Status Status `json:"status"`
+}
+
+// Validate validates this report control
+func (m *ReportControl) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateAccounts(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateControl(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateCount(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateEvidence(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePolicies(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateStatus(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *ReportControl) validateAccounts(formats strfmt.Registry) error {
+
+	if err := validate.Required("accounts", "body", m.Accounts); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(m.Accounts); i++ {
+		if swag.IsZero(m.Accounts[i]) { // not required
+			continue
+		}
+
+		if m.Accounts[i] != nil {
+			if err := m.Accounts[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("accounts" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *ReportControl) validateControl(formats strfmt.Registry) error {
+
+	if err := validate.Required("control", "body", m.Control); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *ReportControl) validateCount(formats strfmt.Registry) error {
+
+	if err := validate.Required("count", "body", m.Count); err != nil {
+		return err
+	}
+
+	if m.Count != nil {
+		if err := m.Count.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("count")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *ReportControl) validateEvidence(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Evidence) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Evidence); i++ {
+		if swag.IsZero(m.Evidence[i]) { // not required
+			continue
+		}
+
+		if m.Evidence[i] != nil {
+			if err := m.Evidence[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("evidence" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *ReportControl) validatePolicies(formats strfmt.Registry) error {
+
+	if err := validate.Required("policies", "body", m.Policies); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(m.Policies); i++ {
+
+		if err := m.Policies[i].Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("policies" + "." + strconv.Itoa(i))
+			}
+			return err
+		}
+
+	}
+
+	return nil
+}
+
+func (m *ReportControl) validateStatus(formats strfmt.Registry) error {
+
+	if err := m.Status.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("status")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *ReportControl) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *ReportControl) UnmarshalBinary(b []byte) error {
+	var res ReportControl
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
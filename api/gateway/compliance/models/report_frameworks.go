@@ -0,0 +1,100 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// ReportFrameworks report frameworks
+//
+// swagger:model ReportFrameworks
+type ReportFrameworks struct {
+
+	// frameworks
+	// Required: true
+	Frameworks []*ReportFramework `json:"frameworks"`
+}
+
+// Validate validates this report frameworks
+func (m *ReportFrameworks) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateFrameworks(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *ReportFrameworks) validateFrameworks(formats strfmt.Registry) error {
+
+	if err := validate.Required("frameworks", "body", m.Frameworks); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(m.Frameworks); i++ {
+		if swag.IsZero(m.Frameworks[i]) { // not required
+			continue
+		}
+
+		if m.Frameworks[i] != nil {
+			if err := m.Frameworks[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("frameworks" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *ReportFrameworks) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *ReportFrameworks) UnmarshalBinary(b []byte) error {
+	var res ReportFrameworks
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
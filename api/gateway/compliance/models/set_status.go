@@ -45,10 +45,16 @@ type SetStatus struct {
 	// Required: true
 	PolicyID PolicyID `json:"policyId"`
 
+	// policy reports
+	PolicyReports PolicyReports `json:"policyReports,omitempty"`
+
 	// policy severity
 	// Required: true
 	PolicySeverity PolicySeverity `json:"policySeverity"`
 
+	// policy tags
+	PolicyTags PolicyTags `json:"policyTags,omitempty"`
+
 	// resource Id
 	// Required: true
 	ResourceID ResourceID `json:"resourceId"`
@@ -82,10 +88,18 @@ func (m *SetStatus) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validatePolicyReports(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validatePolicySeverity(formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := m.validatePolicyTags(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateResourceID(formats); err != nil {
 		res = append(res, err)
 	}
@@ -148,6 +162,22 @@ func (m *SetStatus) validatePolicyID(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *SetStatus) validatePolicyReports(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.PolicyReports) { // not required
+		return nil
+	}
+
+	if err := m.PolicyReports.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("policyReports")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *SetStatus) validatePolicySeverity(formats strfmt.Registry) error {
 
 	if err := m.PolicySeverity.Validate(formats); err != nil {
@@ -160,6 +190,22 @@ func (m *SetStatus) validatePolicySeverity(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *SetStatus) validatePolicyTags(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.PolicyTags) { // not required
+		return nil
+	}
+
+	if err := m.PolicyTags.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("policyTags")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *SetStatus) validateResourceID(formats strfmt.Registry) error {
 
 	if err := m.ResourceID.Validate(formats); err != nil {
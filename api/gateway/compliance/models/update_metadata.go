@@ -38,12 +38,18 @@ type UpdateMetadata struct {
 	// Required: true
 	PolicyID PolicyID `json:"policyId"`
 
+	// reports
+	Reports PolicyReports `json:"reports,omitempty"`
+
 	// severity
 	// Required: true
 	Severity PolicySeverity `json:"severity"`
 
 	// suppressions
 	Suppressions IgnoreSet `json:"suppressions,omitempty"`
+
+	// tags
+	Tags PolicyTags `json:"tags,omitempty"`
 }
 
 // Validate validates this update metadata
@@ -54,6 +60,10 @@ func (m *UpdateMetadata) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateReports(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateSeverity(formats); err != nil {
 		res = append(res, err)
 	}
@@ -62,6 +72,10 @@ func (m *UpdateMetadata) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateTags(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -80,6 +94,22 @@ func (m *UpdateMetadata) validatePolicyID(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *UpdateMetadata) validateReports(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Reports) { // not required
+		return nil
+	}
+
+	if err := m.Reports.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("reports")
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (m *UpdateMetadata) validateSeverity(formats strfmt.Registry) error {
 
 	if err := m.Severity.Validate(formats); err != nil {
@@ -108,6 +138,22 @@ func (m *UpdateMetadata) validateSuppressions(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *UpdateMetadata) validateTags(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Tags) { // not required
+		return nil
+	}
+
+	if err := m.Tags.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("tags")
+		}
+		return err
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *UpdateMetadata) MarshalBinary() ([]byte, error) {
 	if m == nil {
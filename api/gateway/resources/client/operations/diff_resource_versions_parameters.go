@@ -0,0 +1,207 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// NewDiffResourceVersionsParams creates a new DiffResourceVersionsParams object
+// with the default values initialized.
+func NewDiffResourceVersionsParams() *DiffResourceVersionsParams {
+	var ()
+	return &DiffResourceVersionsParams{
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewDiffResourceVersionsParamsWithTimeout creates a new DiffResourceVersionsParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewDiffResourceVersionsParamsWithTimeout(timeout time.Duration) *DiffResourceVersionsParams {
+	var ()
+	return &DiffResourceVersionsParams{
+
+		timeout: timeout,
+	}
+}
+
+// NewDiffResourceVersionsParamsWithContext creates a new DiffResourceVersionsParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewDiffResourceVersionsParamsWithContext(ctx context.Context) *DiffResourceVersionsParams {
+	var ()
+	return &DiffResourceVersionsParams{
+
+		Context: ctx,
+	}
+}
+
+// NewDiffResourceVersionsParamsWithHTTPClient creates a new DiffResourceVersionsParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewDiffResourceVersionsParamsWithHTTPClient(client *http.Client) *DiffResourceVersionsParams {
+	var ()
+	return &DiffResourceVersionsParams{
+		HTTPClient: client,
+	}
+}
+
+/*DiffResourceVersionsParams contains all the parameters to send to the API endpoint
+for the diff resource versions operation typically these are written to a http.Request
+*/
+type DiffResourceVersionsParams struct {
+
+	/*FromVersion
+	  The older snapshot version
+
+	*/
+	FromVersion string
+	/*ResourceID
+	  URL-encoded unique resource identifier
+
+	*/
+	ResourceID string
+	/*ToVersion
+	  The newer snapshot version
+
+	*/
+	ToVersion string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the diff resource versions params
+func (o *DiffResourceVersionsParams) WithTimeout(timeout time.Duration) *DiffResourceVersionsParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the diff resource versions params
+func (o *DiffResourceVersionsParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the diff resource versions params
+func (o *DiffResourceVersionsParams) WithContext(ctx context.Context) *DiffResourceVersionsParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the diff resource versions params
+func (o *DiffResourceVersionsParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the diff resource versions params
+func (o *DiffResourceVersionsParams) WithHTTPClient(client *http.Client) *DiffResourceVersionsParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the diff resource versions params
+func (o *DiffResourceVersionsParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithFromVersion adds the fromVersion to the diff resource versions params
+func (o *DiffResourceVersionsParams) WithFromVersion(fromVersion string) *DiffResourceVersionsParams {
+	o.SetFromVersion(fromVersion)
+	return o
+}
+
+// SetFromVersion adds the fromVersion to the diff resource versions params
+func (o *DiffResourceVersionsParams) SetFromVersion(fromVersion string) {
+	o.FromVersion = fromVersion
+}
+
+// WithResourceID adds the resourceID to the diff resource versions params
+func (o *DiffResourceVersionsParams) WithResourceID(resourceID string) *DiffResourceVersionsParams {
+	o.SetResourceID(resourceID)
+	return o
+}
+
+// SetResourceID adds the resourceId to the diff resource versions params
+func (o *DiffResourceVersionsParams) SetResourceID(resourceID string) {
+	o.ResourceID = resourceID
+}
+
+// WithToVersion adds the toVersion to the diff resource versions params
+func (o *DiffResourceVersionsParams) WithToVersion(toVersion string) *DiffResourceVersionsParams {
+	o.SetToVersion(toVersion)
+	return o
+}
+
+// SetToVersion adds the toVersion to the diff resource versions params
+func (o *DiffResourceVersionsParams) SetToVersion(toVersion string) {
+	o.ToVersion = toVersion
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *DiffResourceVersionsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	// query param fromVersion
+	qrFromVersion := o.FromVersion
+	qFromVersion := qrFromVersion
+	if qFromVersion != "" {
+		if err := r.SetQueryParam("fromVersion", qFromVersion); err != nil {
+			return err
+		}
+	}
+
+	// query param resourceId
+	qrResourceID := o.ResourceID
+	qResourceID := qrResourceID
+	if qResourceID != "" {
+		if err := r.SetQueryParam("resourceId", qResourceID); err != nil {
+			return err
+		}
+	}
+
+	// query param toVersion
+	qrToVersion := o.ToVersion
+	qToVersion := qrToVersion
+	if qToVersion != "" {
+		if err := r.SetQueryParam("toVersion", qToVersion); err != nil {
+			return err
+		}
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
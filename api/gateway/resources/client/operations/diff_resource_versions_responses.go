@@ -0,0 +1,180 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/resources/models"
+)
+
+// DiffResourceVersionsReader is a Reader for the DiffResourceVersions structure.
+type DiffResourceVersionsReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *DiffResourceVersionsReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewDiffResourceVersionsOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewDiffResourceVersionsBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 404:
+		result := NewDiffResourceVersionsNotFound()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewDiffResourceVersionsInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewDiffResourceVersionsOK creates a DiffResourceVersionsOK with default headers values
+func NewDiffResourceVersionsOK() *DiffResourceVersionsOK {
+	return &DiffResourceVersionsOK{}
+}
+
+/*DiffResourceVersionsOK handles this case with default header values.
+
+OK
+*/
+type DiffResourceVersionsOK struct {
+	Payload *models.ResourceDiff
+}
+
+func (o *DiffResourceVersionsOK) Error() string {
+	return fmt.Sprintf("[GET /resource/diff][%d] diffResourceVersionsOK  %+v", 200, o.Payload)
+}
+
+func (o *DiffResourceVersionsOK) GetPayload() *models.ResourceDiff {
+	return o.Payload
+}
+
+func (o *DiffResourceVersionsOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.ResourceDiff)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewDiffResourceVersionsBadRequest creates a DiffResourceVersionsBadRequest with default headers values
+func NewDiffResourceVersionsBadRequest() *DiffResourceVersionsBadRequest {
+	return &DiffResourceVersionsBadRequest{}
+}
+
+/*DiffResourceVersionsBadRequest handles this case with default header values.
+
+Bad request
+*/
+type DiffResourceVersionsBadRequest struct {
+	Payload *models.Error
+}
+
+func (o *DiffResourceVersionsBadRequest) Error() string {
+	return fmt.Sprintf("[GET /resource/diff][%d] diffResourceVersionsBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *DiffResourceVersionsBadRequest) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *DiffResourceVersionsBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewDiffResourceVersionsNotFound creates a DiffResourceVersionsNotFound with default headers values
+func NewDiffResourceVersionsNotFound() *DiffResourceVersionsNotFound {
+	return &DiffResourceVersionsNotFound{}
+}
+
+/*DiffResourceVersionsNotFound handles this case with default header values.
+
+Version does not exist
+*/
+type DiffResourceVersionsNotFound struct {
+}
+
+func (o *DiffResourceVersionsNotFound) Error() string {
+	return fmt.Sprintf("[GET /resource/diff][%d] diffResourceVersionsNotFound ", 404)
+}
+
+func (o *DiffResourceVersionsNotFound) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// NewDiffResourceVersionsInternalServerError creates a DiffResourceVersionsInternalServerError with default headers values
+func NewDiffResourceVersionsInternalServerError() *DiffResourceVersionsInternalServerError {
+	return &DiffResourceVersionsInternalServerError{}
+}
+
+/*DiffResourceVersionsInternalServerError handles this case with default header values.
+
+Internal server error
+*/
+type DiffResourceVersionsInternalServerError struct {
+}
+
+func (o *DiffResourceVersionsInternalServerError) Error() string {
+	return fmt.Sprintf("[GET /resource/diff][%d] diffResourceVersionsInternalServerError ", 500)
+}
+
+func (o *DiffResourceVersionsInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
@@ -0,0 +1,234 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// NewGetResourceHistoryParams creates a new GetResourceHistoryParams object
+// with the default values initialized.
+func NewGetResourceHistoryParams() *GetResourceHistoryParams {
+	var (
+		pageSizeDefault = int64(25)
+	)
+	return &GetResourceHistoryParams{
+		PageSize: &pageSizeDefault,
+
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewGetResourceHistoryParamsWithTimeout creates a new GetResourceHistoryParams object
+// with the default values initialized, and the ability to set a timeout on a request
+func NewGetResourceHistoryParamsWithTimeout(timeout time.Duration) *GetResourceHistoryParams {
+	var (
+		pageSizeDefault = int64(25)
+	)
+	return &GetResourceHistoryParams{
+		PageSize: &pageSizeDefault,
+
+		timeout: timeout,
+	}
+}
+
+// NewGetResourceHistoryParamsWithContext creates a new GetResourceHistoryParams object
+// with the default values initialized, and the ability to set a context for a request
+func NewGetResourceHistoryParamsWithContext(ctx context.Context) *GetResourceHistoryParams {
+	var (
+		pageSizeDefault = int64(25)
+	)
+	return &GetResourceHistoryParams{
+		PageSize: &pageSizeDefault,
+
+		Context: ctx,
+	}
+}
+
+// NewGetResourceHistoryParamsWithHTTPClient creates a new GetResourceHistoryParams object
+// with the default values initialized, and the ability to set a custom HTTPClient for a request
+func NewGetResourceHistoryParamsWithHTTPClient(client *http.Client) *GetResourceHistoryParams {
+	var (
+		pageSizeDefault = int64(25)
+	)
+	return &GetResourceHistoryParams{
+		PageSize:   &pageSizeDefault,
+		HTTPClient: client,
+	}
+}
+
+/*GetResourceHistoryParams contains all the parameters to send to the API endpoint
+for the get resource history operation typically these are written to a http.Request
+*/
+type GetResourceHistoryParams struct {
+
+	/*Before
+	  Only include versions older than this one (for paging)
+
+	*/
+	Before *string
+	/*PageSize
+	  Maximum number of versions to return
+
+	*/
+	PageSize *int64
+	/*ResourceID
+	  URL-encoded unique resource identifier
+
+	*/
+	ResourceID string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithTimeout adds the timeout to the get resource history params
+func (o *GetResourceHistoryParams) WithTimeout(timeout time.Duration) *GetResourceHistoryParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the get resource history params
+func (o *GetResourceHistoryParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the get resource history params
+func (o *GetResourceHistoryParams) WithContext(ctx context.Context) *GetResourceHistoryParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the get resource history params
+func (o *GetResourceHistoryParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the get resource history params
+func (o *GetResourceHistoryParams) WithHTTPClient(client *http.Client) *GetResourceHistoryParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the get resource history params
+func (o *GetResourceHistoryParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithBefore adds the before to the get resource history params
+func (o *GetResourceHistoryParams) WithBefore(before *string) *GetResourceHistoryParams {
+	o.SetBefore(before)
+	return o
+}
+
+// SetBefore adds the before to the get resource history params
+func (o *GetResourceHistoryParams) SetBefore(before *string) {
+	o.Before = before
+}
+
+// WithPageSize adds the pageSize to the get resource history params
+func (o *GetResourceHistoryParams) WithPageSize(pageSize *int64) *GetResourceHistoryParams {
+	o.SetPageSize(pageSize)
+	return o
+}
+
+// SetPageSize adds the pageSize to the get resource history params
+func (o *GetResourceHistoryParams) SetPageSize(pageSize *int64) {
+	o.PageSize = pageSize
+}
+
+// WithResourceID adds the resourceID to the get resource history params
+func (o *GetResourceHistoryParams) WithResourceID(resourceID string) *GetResourceHistoryParams {
+	o.SetResourceID(resourceID)
+	return o
+}
+
+// SetResourceID adds the resourceId to the get resource history params
+func (o *GetResourceHistoryParams) SetResourceID(resourceID string) {
+	o.ResourceID = resourceID
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *GetResourceHistoryParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	if o.Before != nil {
+
+		// query param before
+		var qrBefore string
+		if o.Before != nil {
+			qrBefore = *o.Before
+		}
+		qBefore := qrBefore
+		if qBefore != "" {
+			if err := r.SetQueryParam("before", qBefore); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.PageSize != nil {
+
+		// query param pageSize
+		var qrPageSize int64
+		if o.PageSize != nil {
+			qrPageSize = *o.PageSize
+		}
+		qPageSize := swag.FormatInt64(qrPageSize)
+		if qPageSize != "" {
+			if err := r.SetQueryParam("pageSize", qPageSize); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	// query param resourceId
+	qrResourceID := o.ResourceID
+	qResourceID := qrResourceID
+	if qResourceID != "" {
+		if err := r.SetQueryParam("resourceId", qResourceID); err != nil {
+			return err
+		}
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package operations
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/panther-labs/panther/api/gateway/resources/models"
+)
+
+// GetResourceHistoryReader is a Reader for the GetResourceHistory structure.
+type GetResourceHistoryReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *GetResourceHistoryReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewGetResourceHistoryOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewGetResourceHistoryBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewGetResourceHistoryInternalServerError()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+
+	default:
+		return nil, runtime.NewAPIError("unknown error", response, response.Code())
+	}
+}
+
+// NewGetResourceHistoryOK creates a GetResourceHistoryOK with default headers values
+func NewGetResourceHistoryOK() *GetResourceHistoryOK {
+	return &GetResourceHistoryOK{}
+}
+
+/*GetResourceHistoryOK handles this case with default header values.
+
+OK
+*/
+type GetResourceHistoryOK struct {
+	Payload *models.ResourceHistory
+}
+
+func (o *GetResourceHistoryOK) Error() string {
+	return fmt.Sprintf("[GET /resource/history][%d] getResourceHistoryOK  %+v", 200, o.Payload)
+}
+
+func (o *GetResourceHistoryOK) GetPayload() *models.ResourceHistory {
+	return o.Payload
+}
+
+func (o *GetResourceHistoryOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.ResourceHistory)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewGetResourceHistoryBadRequest creates a GetResourceHistoryBadRequest with default headers values
+func NewGetResourceHistoryBadRequest() *GetResourceHistoryBadRequest {
+	return &GetResourceHistoryBadRequest{}
+}
+
+/*GetResourceHistoryBadRequest handles this case with default header values.
+
+Bad request
+*/
+type GetResourceHistoryBadRequest struct {
+	Payload *models.Error
+}
+
+func (o *GetResourceHistoryBadRequest) Error() string {
+	return fmt.Sprintf("[GET /resource/history][%d] getResourceHistoryBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *GetResourceHistoryBadRequest) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *GetResourceHistoryBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewGetResourceHistoryInternalServerError creates a GetResourceHistoryInternalServerError with default headers values
+func NewGetResourceHistoryInternalServerError() *GetResourceHistoryInternalServerError {
+	return &GetResourceHistoryInternalServerError{}
+}
+
+/*GetResourceHistoryInternalServerError handles this case with default header values.
+
+Internal server error
+*/
+type GetResourceHistoryInternalServerError struct {
+}
+
+func (o *GetResourceHistoryInternalServerError) Error() string {
+	return fmt.Sprintf("[GET /resource/history][%d] getResourceHistoryInternalServerError ", 500)
+}
+
+func (o *GetResourceHistoryInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
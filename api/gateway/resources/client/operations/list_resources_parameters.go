@@ -39,16 +39,18 @@ import (
 // with the default values initialized.
 func NewListResourcesParams() *ListResourcesParams {
 	var (
-		pageDefault     = int64(1)
-		pageSizeDefault = int64(25)
-		sortByDefault   = string("id")
-		sortDirDefault  = string("ascending")
+		pageDefault       = int64(1)
+		pageSizeDefault   = int64(25)
+		paginationDefault = string("token")
+		sortByDefault     = string("id")
+		sortDirDefault    = string("ascending")
 	)
 	return &ListResourcesParams{
-		Page:     &pageDefault,
-		PageSize: &pageSizeDefault,
-		SortBy:   &sortByDefault,
-		SortDir:  &sortDirDefault,
+		Page:       &pageDefault,
+		PageSize:   &pageSizeDefault,
+		Pagination: &paginationDefault,
+		SortBy:     &sortByDefault,
+		SortDir:    &sortDirDefault,
 
 		timeout: cr.DefaultTimeout,
 	}
@@ -58,16 +60,18 @@ func NewListResourcesParams() *ListResourcesParams {
 // with the default values initialized, and the ability to set a timeout on a request
 func NewListResourcesParamsWithTimeout(timeout time.Duration) *ListResourcesParams {
 	var (
-		pageDefault     = int64(1)
-		pageSizeDefault = int64(25)
-		sortByDefault   = string("id")
-		sortDirDefault  = string("ascending")
+		pageDefault       = int64(1)
+		pageSizeDefault   = int64(25)
+		paginationDefault = string("token")
+		sortByDefault     = string("id")
+		sortDirDefault    = string("ascending")
 	)
 	return &ListResourcesParams{
-		Page:     &pageDefault,
-		PageSize: &pageSizeDefault,
-		SortBy:   &sortByDefault,
-		SortDir:  &sortDirDefault,
+		Page:       &pageDefault,
+		PageSize:   &pageSizeDefault,
+		Pagination: &paginationDefault,
+		SortBy:     &sortByDefault,
+		SortDir:    &sortDirDefault,
 
 		timeout: timeout,
 	}
@@ -77,16 +81,18 @@ func NewListResourcesParamsWithTimeout(timeout time.Duration) *ListResourcesPara
 // with the default values initialized, and the ability to set a context for a request
 func NewListResourcesParamsWithContext(ctx context.Context) *ListResourcesParams {
 	var (
-		pageDefault     = int64(1)
-		pageSizeDefault = int64(25)
-		sortByDefault   = string("id")
-		sortDirDefault  = string("ascending")
+		pageDefault       = int64(1)
+		pageSizeDefault   = int64(25)
+		paginationDefault = string("token")
+		sortByDefault     = string("id")
+		sortDirDefault    = string("ascending")
 	)
 	return &ListResourcesParams{
-		Page:     &pageDefault,
-		PageSize: &pageSizeDefault,
-		SortBy:   &sortByDefault,
-		SortDir:  &sortDirDefault,
+		Page:       &pageDefault,
+		PageSize:   &pageSizeDefault,
+		Pagination: &paginationDefault,
+		SortBy:     &sortByDefault,
+		SortDir:    &sortDirDefault,
 
 		Context: ctx,
 	}
@@ -96,14 +102,16 @@ func NewListResourcesParamsWithContext(ctx context.Context) *ListResourcesParams
 // with the default values initialized, and the ability to set a custom HTTPClient for a request
 func NewListResourcesParamsWithHTTPClient(client *http.Client) *ListResourcesParams {
 	var (
-		pageDefault     = int64(1)
-		pageSizeDefault = int64(25)
-		sortByDefault   = string("id")
-		sortDirDefault  = string("ascending")
+		pageDefault       = int64(1)
+		pageSizeDefault   = int64(25)
+		paginationDefault = string("token")
+		sortByDefault     = string("id")
+		sortDirDefault    = string("ascending")
 	)
 	return &ListResourcesParams{
 		Page:       &pageDefault,
 		PageSize:   &pageSizeDefault,
+		Pagination: &paginationDefault,
 		SortBy:     &sortByDefault,
 		SortDir:    &sortDirDefault,
 		HTTPClient: client,
@@ -130,6 +138,12 @@ type ListResourcesParams struct {
 
 	*/
 	Fields []string
+	/*Filters
+	  Only include resources whose attributes match all of these URL-encoded filters, each in the form "path=value" or "path!=value", e.g. "Tags.Environment=prod"
+
+
+	*/
+	Filters []string
 	/*IDContains
 	  Only include resources whose ID contains this URL-encoded substring (case-insensitive)
 
@@ -146,7 +160,7 @@ type ListResourcesParams struct {
 	*/
 	IntegrationType *string
 	/*Page
-	  Which page of results to retrieve
+	  Which page of results to retrieve (offset pagination only)
 
 	*/
 	Page *int64
@@ -155,6 +169,16 @@ type ListResourcesParams struct {
 
 	*/
 	PageSize *int64
+	/*PageToken
+	  Opaque continuation token from the previous page (token pagination only)
+
+	*/
+	PageToken *string
+	/*Pagination
+	  Continuation tokens (token) or numbered pages sorted in memory (offset)
+
+	*/
+	Pagination *string
 	/*SortBy
 	  Name of the field to sort by
 
@@ -242,6 +266,17 @@ func (o *ListResourcesParams) SetFields(fields []string) {
 	o.Fields = fields
 }
 
+// WithFilters adds the filters to the list resources params
+func (o *ListResourcesParams) WithFilters(filters []string) *ListResourcesParams {
+	o.SetFilters(filters)
+	return o
+}
+
+// SetFilters adds the filters to the list resources params
+func (o *ListResourcesParams) SetFilters(filters []string) {
+	o.Filters = filters
+}
+
 // WithIDContains adds the iDContains to the list resources params
 func (o *ListResourcesParams) WithIDContains(iDContains *string) *ListResourcesParams {
 	o.SetIDContains(iDContains)
@@ -297,6 +332,28 @@ func (o *ListResourcesParams) SetPageSize(pageSize *int64) {
 	o.PageSize = pageSize
 }
 
+// WithPageToken adds the pageToken to the list resources params
+func (o *ListResourcesParams) WithPageToken(pageToken *string) *ListResourcesParams {
+	o.SetPageToken(pageToken)
+	return o
+}
+
+// SetPageToken adds the pageToken to the list resources params
+func (o *ListResourcesParams) SetPageToken(pageToken *string) {
+	o.PageToken = pageToken
+}
+
+// WithPagination adds the pagination to the list resources params
+func (o *ListResourcesParams) WithPagination(pagination *string) *ListResourcesParams {
+	o.SetPagination(pagination)
+	return o
+}
+
+// SetPagination adds the pagination to the list resources params
+func (o *ListResourcesParams) SetPagination(pagination *string) {
+	o.Pagination = pagination
+}
+
 // WithSortBy adds the sortBy to the list resources params
 func (o *ListResourcesParams) WithSortBy(sortBy *string) *ListResourcesParams {
 	o.SetSortBy(sortBy)
@@ -378,6 +435,14 @@ func (o *ListResourcesParams) WriteToRequest(r runtime.ClientRequest, reg strfmt
 		return err
 	}
 
+	valuesFilters := o.Filters
+
+	joinedFilters := swag.JoinByFormat(valuesFilters, "multi")
+	// query array param filters
+	if err := r.SetQueryParam("filters", joinedFilters...); err != nil {
+		return err
+	}
+
 	if o.IDContains != nil {
 
 		// query param idContains
@@ -458,6 +523,38 @@ func (o *ListResourcesParams) WriteToRequest(r runtime.ClientRequest, reg strfmt
 
 	}
 
+	if o.PageToken != nil {
+
+		// query param pageToken
+		var qrPageToken string
+		if o.PageToken != nil {
+			qrPageToken = *o.PageToken
+		}
+		qPageToken := qrPageToken
+		if qPageToken != "" {
+			if err := r.SetQueryParam("pageToken", qPageToken); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	if o.Pagination != nil {
+
+		// query param pagination
+		var qrPagination string
+		if o.Pagination != nil {
+			qrPagination = *o.Pagination
+		}
+		qPagination := qrPagination
+		if qPagination != "" {
+			if err := r.SetQueryParam("pagination", qPagination); err != nil {
+				return err
+			}
+		}
+
+	}
+
 	if o.SortBy != nil {
 
 		// query param sortBy
@@ -49,10 +49,14 @@ type ClientService interface {
 
 	DeleteResources(params *DeleteResourcesParams) (*DeleteResourcesOK, error)
 
+	DiffResourceVersions(params *DiffResourceVersionsParams) (*DiffResourceVersionsOK, error)
+
 	GetOrgOverview(params *GetOrgOverviewParams) (*GetOrgOverviewOK, error)
 
 	GetResource(params *GetResourceParams) (*GetResourceOK, error)
 
+	GetResourceHistory(params *GetResourceHistoryParams) (*GetResourceHistoryOK, error)
+
 	ListResources(params *ListResourcesParams) (*ListResourcesOK, error)
 
 	SetTransport(transport runtime.ClientTransport)
@@ -126,6 +130,40 @@ func (a *Client) DeleteResources(params *DeleteResourcesParams) (*DeleteResource
 	panic(msg)
 }
 
+/*
+  DiffResourceVersions compares the attributes of two snapshot versions of a resource
+*/
+func (a *Client) DiffResourceVersions(params *DiffResourceVersionsParams) (*DiffResourceVersionsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewDiffResourceVersionsParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "DiffResourceVersions",
+		Method:             "GET",
+		PathPattern:        "/resource/diff",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &DiffResourceVersionsReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*DiffResourceVersionsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for DiffResourceVersions: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
   GetOrgOverview gets an overview of the resources in an organization
 */
@@ -194,6 +232,40 @@ func (a *Client) GetResource(params *GetResourceParams) (*GetResourceOK, error)
 	panic(msg)
 }
 
+/*
+  GetResourceHistory lists the snapshot versions of a resource and which attributes changed in each
+*/
+func (a *Client) GetResourceHistory(params *GetResourceHistoryParams) (*GetResourceHistoryOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetResourceHistoryParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "GetResourceHistory",
+		Method:             "GET",
+		PathPattern:        "/resource/history",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &GetResourceHistoryReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetResourceHistoryOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for GetResourceHistory: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
   ListResources lists resources for a customer account
 */
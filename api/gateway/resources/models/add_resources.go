@@ -42,6 +42,9 @@ type AddResources struct {
 	// Max Items: 500
 	// Min Items: 1
 	Resources []*AddResourceEntry `json:"resources"`
+
+	// trigger
+	Trigger *ResourceTrigger `json:"trigger,omitempty"`
 }
 
 // Validate validates this add resources
@@ -52,6 +55,10 @@ func (m *AddResources) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateTrigger(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -93,6 +100,24 @@ func (m *AddResources) validateResources(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *AddResources) validateTrigger(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Trigger) { // not required
+		return nil
+	}
+
+	if m.Trigger != nil {
+		if err := m.Trigger.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("trigger")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *AddResources) MarshalBinary() ([]byte, error) {
 	if m == nil {
@@ -37,9 +37,11 @@ import (
 // swagger:model ResourceList
 type ResourceList struct {
 
-	// paging
-	// Required: true
-	Paging *Paging `json:"paging"`
+	// Continuation token for the next page (token pagination only, empty on the last page)
+	NextPageToken string `json:"nextPageToken,omitempty"`
+
+	// Page numbers and totals (offset pagination only)
+	Paging *Paging `json:"paging,omitempty"`
 
 	// resources
 	// Required: true
@@ -66,8 +68,8 @@ func (m *ResourceList) Validate(formats strfmt.Registry) error {
 
 func (m *ResourceList) validatePaging(formats strfmt.Registry) error {
 
-	if err := validate.Required("paging", "body", m.Paging); err != nil {
-		return err
+	if swag.IsZero(m.Paging) { // not required
+		return nil
 	}
 
 	if m.Paging != nil {
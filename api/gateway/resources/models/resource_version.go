@@ -0,0 +1,147 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// ResourceVersion resource version
+//
+// swagger:model ResourceVersion
+type ResourceVersion struct {
+
+	// Top-level attributes which changed since the previous version
+	// Required: true
+	ChangedAttributes []string `json:"changedAttributes"`
+
+	// deleted
+	// Required: true
+	Deleted Deleted `json:"deleted"`
+
+	// trigger
+	Trigger *ResourceTrigger `json:"trigger,omitempty"`
+
+	// version
+	// Required: true
+	Version SnapshotVersion `json:"version"`
+}
+
+// Validate validates this resource version
+func (m *ResourceVersion) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateChangedAttributes(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateDeleted(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateTrigger(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateVersion(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *ResourceVersion) validateChangedAttributes(formats strfmt.Registry) error {
+
+	if err := validate.Required("changedAttributes", "body", m.ChangedAttributes); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *ResourceVersion) validateDeleted(formats strfmt.Registry) error {
+
+	if err := m.Deleted.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("deleted")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *ResourceVersion) validateTrigger(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Trigger) { // not required
+		return nil
+	}
+
+	if m.Trigger != nil {
+		if err := m.Trigger.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("trigger")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *ResourceVersion) validateVersion(formats strfmt.Registry) error {
+
+	if err := m.Version.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("version")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *ResourceVersion) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *ResourceVersion) UnmarshalBinary(b []byte) error {
+	var res ResourceVersion
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
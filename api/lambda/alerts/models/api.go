@@ -25,6 +25,19 @@ type LambdaInput struct {
 	GetAlert          *GetAlertInput          `json:"getAlert"`
 	ListAlerts        *ListAlertsInput        `json:"listAlerts"`
 	UpdateAlertStatus *UpdateAlertStatusInput `json:"updateAlertStatus"`
+	AddAlertTicket    *AddAlertTicketInput    `json:"addAlertTicket"`
+	ListAlertTickets  *ListAlertTicketsInput  `json:"listAlertTickets"`
+	SyncAlertTickets  *SyncAlertTicketsInput  `json:"syncAlertTickets"`
+	AssignAlert       *AssignAlertInput       `json:"assignAlert"`
+	AddAlertComment   *AddAlertCommentInput   `json:"addAlertComment"`
+	ListAlertActivity *ListAlertActivityInput `json:"listAlertActivity"`
+	AddAlertActivity  *AddAlertActivityInput  `json:"addAlertActivity"`
+
+	BulkUpdateAlertStatus *BulkUpdateAlertStatusInput `json:"bulkUpdateAlertStatus"`
+	SaveAlertFilter       *SaveAlertFilterInput       `json:"saveAlertFilter"`
+	ListAlertFilters      *ListAlertFiltersInput      `json:"listAlertFilters"`
+	DeleteAlertFilter     *DeleteAlertFilterInput     `json:"deleteAlertFilter"`
+	ListPendingAlerts     *ListPendingAlertsInput     `json:"listPendingAlerts"`
 }
 
 // GetAlertInput retrieves details for a single alert.
@@ -112,12 +125,346 @@ type UpdateAlertStatusInput struct {
 	Status *string `json:"status" validate:"oneof=OPEN TRIAGED CLOSED RESOLVED"`
 
 	// User who made the change
-	UserID *string `json:"userId" validate:"uuid4"`
+	UserID *string `json:"userId" validate:"required,uuid4"`
 }
 
 // UpdateAlertStatusOutput the returne alert summary after an update
 type UpdateAlertStatusOutput = AlertSummary
 
+// BulkUpdateAlertStatusInput updates the status of many alerts at once.
+//
+// The alerts are selected either by id or with the same filters as ListAlertsInput
+// (its pagination and sorting fields are ignored).
+// {
+//     "bulkUpdateAlertStatus": {
+//         "alertIds": ["84c3e4b27c702a1c31e6eb412fc377f6"],
+//         "filter": {
+//             "severity": ["INFO"],
+//             "status": ["OPEN"],
+//             "createdAtBefore": "2020-06-17T15:49:40Z"
+//         },
+//         "status": "CLOSED",
+//         "userId": "5f54cf4a-ec56-44c2-83bc-8b742600f307"
+//     }
+// }
+type BulkUpdateAlertStatusInput struct {
+	// Exactly one of AlertIDs or Filter must be set
+	AlertIDs []string        `json:"alertIds" validate:"omitempty,max=1000,dive,hexadecimal,len=32"`
+	Filter   *ListAlertsInput `json:"filter"`
+
+	Status *string `json:"status" validate:"required,oneof=OPEN TRIAGED CLOSED RESOLVED"`
+	UserID *string `json:"userId" validate:"required,uuid4"`
+}
+
+// BulkUpdateAlertStatusOutput reports which alerts were updated.
+type BulkUpdateAlertStatusOutput struct {
+	UpdatedAlertIDs []string             `json:"updatedAlertIds"`
+	Failures        []*BulkUpdateFailure `json:"failures"`
+
+	// Truncated is true if the filter matched more alerts than can be updated in a single request.
+	// Running the same request again updates the next alerts if the filter excludes the new status.
+	Truncated bool `json:"truncated"`
+}
+
+// BulkUpdateFailure is an alert which could not be updated
+type BulkUpdateFailure struct {
+	AlertID string `json:"alertId"`
+	Error   string `json:"error"`
+}
+
+// SaveAlertFilterInput creates or replaces a saved filter for the alert list.
+// {
+//     "saveAlertFilter": {
+//         "userId": "5f54cf4a-ec56-44c2-83bc-8b742600f307",
+//         "filterId": "7d1c5854-f3ea-491c-8a52-0aa0d58cb456", // omit to create a new filter
+//         "name": "Open high severity",
+//         "filter": {
+//             "severity": ["HIGH", "CRITICAL"],
+//             "status": ["OPEN"]
+//         }
+//     }
+// }
+type SaveAlertFilterInput struct {
+	UserID   *string          `json:"userId" validate:"required,uuid4"`
+	FilterID string           `json:"filterId" validate:"omitempty,uuid4"`
+	Name     *string          `json:"name" validate:"required,min=1,max=100"`
+	Filter   *ListAlertsInput `json:"filter" validate:"required"`
+}
+
+// SaveAlertFilterOutput is the saved filter
+type SaveAlertFilterOutput = AlertFilter
+
+// ListAlertFiltersInput lists the saved filters of a user.
+// {
+//     "listAlertFilters": {
+//         "userId": "5f54cf4a-ec56-44c2-83bc-8b742600f307"
+//     }
+// }
+type ListAlertFiltersInput struct {
+	UserID *string `json:"userId" validate:"required,uuid4"`
+}
+
+// ListAlertFiltersOutput is the saved filters of a user, sorted by name.
+type ListAlertFiltersOutput = []*AlertFilter
+
+// DeleteAlertFilterInput deletes a saved filter.
+// {
+//     "deleteAlertFilter": {
+//         "userId": "5f54cf4a-ec56-44c2-83bc-8b742600f307",
+//         "filterId": "7d1c5854-f3ea-491c-8a52-0aa0d58cb456"
+//     }
+// }
+type DeleteAlertFilterInput struct {
+	UserID   *string `json:"userId" validate:"required,uuid4"`
+	FilterID *string `json:"filterId" validate:"required,uuid4"`
+}
+
+// AlertFilter is a named set of alert list filters saved by a user
+type AlertFilter struct {
+	UserID    string           `json:"userId"`
+	FilterID  string           `json:"filterId"`
+	Name      string           `json:"name"`
+	Filter    *ListAlertsInput `json:"filter"`
+	UpdatedAt time.Time        `json:"updatedAt"`
+}
+
+// ListPendingAlertsInput lists the dedup groups of rules which have matched events, but not enough
+// events to reach the rule threshold and generate an alert.
+//
+// If "ruleId" is not set, pending alerts for all rules are returned.
+// {
+//     "listPendingAlerts": {
+//         "ruleId": "My.Rule",
+//         "pageSize": 25,
+//         "exclusiveStartKey": "abcdef"
+//     }
+// }
+type ListPendingAlertsInput struct {
+	RuleID            *string `json:"ruleId"`
+	PageSize          *int    `json:"pageSize" validate:"omitempty,min=1,max=50"`
+	ExclusiveStartKey *string `json:"exclusiveStartKey"`
+}
+
+// ListPendingAlertsOutput is a page of pending alerts.
+type ListPendingAlertsOutput struct {
+	PendingAlerts []*PendingAlert `json:"pendingAlerts"`
+	// LastEvaluatedKey is set if there are more pending alerts
+	LastEvaluatedKey *string `json:"lastEvaluatedKey,omitempty"`
+}
+
+// PendingAlert is a dedup group of a rule which has not (yet) reached the rule threshold.
+//
+// It is stored by the alert forwarder and removed once the group becomes an alert or the dedup window expires.
+type PendingAlert struct {
+	RuleID      string `json:"ruleId"`
+	DedupString string `json:"dedup"`
+	RuleVersion string `json:"ruleVersion"`
+
+	// EventCount is the number of events matched so far, an alert is generated when it reaches Threshold
+	EventCount int64 `json:"eventCount"`
+	Threshold  int64 `json:"threshold"`
+
+	CreationTime time.Time `json:"creationTime"`
+	UpdateTime   time.Time `json:"updateTime"`
+	// ExpiresAt is the end of the dedup window, later events start a new dedup group
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// AddAlertTicketInput links an alert to an issue created for it in a ticketing system.
+// This is called by the alert delivery function after it creates a Jira or Github issue.
+// {
+//     "addAlertTicket": {
+//         "alertId": "84c3e4b27c702a1c31e6eb412fc377f6",
+//         "ticket": {
+//             "outputId": "7d1c5854-f3ea-491c-8a52-0aa0d58cb456",
+//             "outputType": "jira",
+//             "key": "SEC-123",
+//             "url": "https://example.atlassian.net/browse/SEC-123"
+//         }
+//     }
+// }
+type AddAlertTicketInput struct {
+	AlertID *string `json:"alertId" validate:"required,hexadecimal,len=32"`
+	Ticket  *Ticket `json:"ticket" validate:"required"`
+}
+
+// ListAlertTicketsInput lists every alert which has tickets that are still being synced.
+//
+// An alert stops being synced once it is CLOSED or RESOLVED.
+// {
+//     "listAlertTickets": {}
+// }
+type ListAlertTicketsInput struct{}
+
+// SyncAlertTicketsInput applies the status of its tickets to an alert.
+// This is called by the alert delivery function, there is no user behind the change.
+//
+// The alert stops being synced. If the status is omitted (e.g. the ticket outputs were deleted),
+// only the sync is stopped and the alert keeps its current status.
+// {
+//     "syncAlertTickets": {
+//         "alertId": "84c3e4b27c702a1c31e6eb412fc377f6",
+//         "status": "RESOLVED"
+//     }
+// }
+type SyncAlertTicketsInput struct {
+	AlertID *string `json:"alertId" validate:"required,hexadecimal,len=32"`
+	Status  *string `json:"status" validate:"omitempty,oneof=CLOSED RESOLVED"`
+}
+
+// ListAlertTicketsOutput is the list of alerts with tickets to sync.
+type ListAlertTicketsOutput = []*AlertTickets
+
+// AlertTickets are the tickets linked to a single alert.
+type AlertTickets struct {
+	AlertID *string   `json:"alertId"`
+	Status  string    `json:"status,omitempty"`
+	Tickets []*Ticket `json:"tickets"`
+}
+
+// Ticket is an issue created for an alert in an external ticketing system (Jira or Github).
+type Ticket struct {
+	// OutputID is the alert output which created the ticket
+	OutputID string `json:"outputId" validate:"required"`
+
+	// OutputType is the kind of ticketing system: "jira" or "github"
+	OutputType string `json:"outputType" validate:"oneof=jira github"`
+
+	// Key identifies the ticket in the ticketing system, e.g. "SEC-123" (Jira) or "42" (Github)
+	Key string `json:"key" validate:"required"`
+
+	// URL is a link to the ticket for users
+	URL string `json:"url,omitempty"`
+
+	// CreatedAt is when the ticket was created
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// AssignAlertInput sets (or clears) the user responsible for an alert.
+// {
+//     "assignAlert": {
+//         "alertId": "84c3e4b27c702a1c31e6eb412fc377f6",
+//         "assigneeId": "8304cc90-750d-4b8f-9a63-b90a4543c707",
+//         "userId": "97c4db4e-61d5-40a7-82de-6dd63b199bd2"
+//     }
+// }
+type AssignAlertInput struct {
+	AlertID *string `json:"alertId" validate:"required,hexadecimal,len=32"`
+	// AssigneeID is the users-api id of the new assignee, empty to unassign the alert
+	AssigneeID string `json:"assigneeId" validate:"omitempty,uuid4"`
+	// User who made the change
+	UserID *string `json:"userId" validate:"required,uuid4"`
+}
+
+// AssignAlertOutput is the alert summary after the assignment
+type AssignAlertOutput = AlertSummary
+
+// AddAlertCommentInput adds a free-text comment to an alert.
+// {
+//     "addAlertComment": {
+//         "alertId": "84c3e4b27c702a1c31e6eb412fc377f6",
+//         "comment": "This is expected, the deploy role was rotated",
+//         "userId": "97c4db4e-61d5-40a7-82de-6dd63b199bd2"
+//     }
+// }
+type AddAlertCommentInput struct {
+	AlertID *string `json:"alertId" validate:"required,hexadecimal,len=32"`
+	Comment *string `json:"comment" validate:"required,min=1,max=10000"`
+	UserID  *string `json:"userId" validate:"required,uuid4"`
+}
+
+// AddAlertCommentOutput is the activity entry of the new comment
+type AddAlertCommentOutput = AlertActivity
+
+// ListAlertActivityInput lists the activity timeline of an alert, oldest first.
+// {
+//     "listAlertActivity": {
+//         "alertId": "84c3e4b27c702a1c31e6eb412fc377f6",
+//         "pageSize": 25,
+//         "exclusiveStartKey": "abcdef"
+//     }
+// }
+type ListAlertActivityInput struct {
+	AlertID           *string `json:"alertId" validate:"required,hexadecimal,len=32"`
+	PageSize          *int    `json:"pageSize" validate:"omitempty,min=1,max=50"`
+	ExclusiveStartKey *string `json:"exclusiveStartKey"`
+}
+
+// ListAlertActivityOutput is a page of the activity timeline of an alert.
+type ListAlertActivityOutput struct {
+	Activity []*AlertActivity `json:"activity"`
+	// LastEvaluatedKey is set if there are more activity entries
+	LastEvaluatedKey *string `json:"lastEvaluatedKey,omitempty"`
+}
+
+// AddAlertActivityInput records activity done by Panther itself (e.g. alert deliveries).
+//
+// User activity is recorded by the AssignAlert, AddAlertComment and UpdateAlertStatus routes.
+// {
+//     "addAlertActivity": {
+//         "activity": [
+//             {
+//                 "alertId": "84c3e4b27c702a1c31e6eb412fc377f6",
+//                 "type": "DELIVERY",
+//                 "outputId": "7d1c5854-f3ea-491c-8a52-0aa0d58cb456",
+//                 "success": true
+//             }
+//         ]
+//     }
+// }
+type AddAlertActivityInput struct {
+	Activity []*AlertActivity `json:"activity" validate:"min=1,max=25,dive,required"`
+}
+
+// Types of alert activity
+const (
+	StatusChangeActivity = "STATUS_CHANGE"
+	AssignmentActivity   = "ASSIGNMENT"
+	CommentActivity      = "COMMENT"
+	DeliveryActivity     = "DELIVERY"
+	EventCountActivity   = "EVENT_COUNT"
+)
+
+// activityIDTimeLayout is fixed width so activity ids sort in time order
+const activityIDTimeLayout = "2006-01-02T15:04:05.000000000Z"
+
+// AlertActivity is an entry in the append-only activity log of an alert.
+//
+// Only the fields relevant for the activity type are set.
+type AlertActivity struct {
+	AlertID *string `json:"alertId" validate:"required,hexadecimal,len=32"`
+
+	// ActivityID sorts the activity of an alert by time, it is generated when the activity is stored
+	ActivityID string `json:"activityId"`
+
+	Type string    `json:"type" validate:"oneof=STATUS_CHANGE ASSIGNMENT COMMENT DELIVERY EVENT_COUNT"`
+	Time time.Time `json:"time"`
+
+	// UserID is the user who did the activity, empty when it was done by Panther
+	UserID string `json:"userId,omitempty"`
+
+	// STATUS_CHANGE: the new status
+	Status string `json:"status,omitempty"`
+
+	// ASSIGNMENT: the new assignee, empty if the alert was unassigned
+	AssigneeID string `json:"assigneeId,omitempty"`
+
+	// COMMENT: the comment text
+	Comment string `json:"comment,omitempty"`
+
+	// DELIVERY: the output the alert was sent to and the result
+	OutputID string `json:"outputId,omitempty"`
+	Success  *bool  `json:"success,omitempty"`
+
+	// EVENT_COUNT: the number of events matched by the alert
+	EventCount int `json:"eventCount,omitempty"`
+}
+
+// NewActivityID generates a unique activity id which sorts in time order.
+func NewActivityID(t time.Time, unique string) string {
+	return t.UTC().Format(activityIDTimeLayout) + "#" + unique
+}
+
 // Constants defined for alert statuses
 const (
 	// Open is strictly used for updating/filtering and is not explicitly set on an alert
@@ -159,6 +506,8 @@ type AlertSummary struct {
 	Title             *string    `json:"title" validate:"required"`
 	LastUpdatedBy     string     `json:"lastUpdatedBy,omitempty"`
 	LastUpdatedByTime time.Time  `json:"lastUpdatedByTime,omitempty"`
+	Tickets           []*Ticket  `json:"tickets,omitempty"`
+	AssigneeID        string     `json:"assigneeId,omitempty"`
 }
 
 // Alert contains the details of an alert
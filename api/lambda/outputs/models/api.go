@@ -47,6 +47,7 @@ type AddOutputInput struct {
 	DisplayName        *string       `json:"displayName" validate:"required,min=1,excludesall='<>&\""`
 	OutputConfig       *OutputConfig `json:"outputConfig" validate:"required"`
 	DefaultForSeverity []*string     `json:"defaultForSeverity"`
+	DigestConfig       *DigestConfig `json:"digestConfig"`
 }
 
 // AddOutputOutput returns a randomly generated UUID for the output.
@@ -86,6 +87,7 @@ type UpdateOutputInput struct {
 	OutputID           *string       `json:"outputId" validate:"required,uuid4"`
 	OutputConfig       *OutputConfig `json:"outputConfig"`
 	DefaultForSeverity []*string     `json:"defaultForSeverity"`
+	DigestConfig       *DigestConfig `json:"digestConfig"`
 }
 
 // UpdateOutputOutput returns the new updated output
@@ -161,6 +163,50 @@ type AlertOutput struct {
 
 	// DefaultForSeverity defines the alert severities that will be forwarded through this output
 	DefaultForSeverity []*string `json:"defaultForSeverity"`
+
+	// DigestConfig batches low severity alerts into periodic summaries (nil if disabled)
+	DigestConfig *DigestConfig `json:"digestConfig,omitempty"`
+}
+
+// DigestConfig defines how alerts are batched into digests for an output.
+//
+// Alerts with a severity in ImmediateSeverities (HIGH and CRITICAL by default) are always
+// delivered right away. All other alerts are accumulated and delivered as one summary
+// message once the oldest pending alert is older than WindowMinutes.
+//
+// Only slack, msteams, sns and customwebhook outputs support digests.
+type DigestConfig struct {
+	// WindowMinutes is how long alerts are accumulated before the digest is sent.
+	// A value of 0 disables the digest and restores immediate delivery.
+	WindowMinutes int `json:"windowMinutes" validate:"min=0,max=1440"`
+
+	// ImmediateSeverities bypass the digest and are delivered as soon as they arrive.
+	ImmediateSeverities []string `json:"immediateSeverities,omitempty" validate:"omitempty,dive,oneof=INFO LOW MEDIUM HIGH CRITICAL"`
+}
+
+// DefaultImmediateSeverities are delivered right away if a digest does not specify its own.
+var DefaultImmediateSeverities = []string{"HIGH", "CRITICAL"}
+
+// Enabled returns true if alerts should be accumulated for this output.
+func (c *DigestConfig) Enabled() bool {
+	return c != nil && c.WindowMinutes > 0
+}
+
+// IsImmediate returns true if alerts of the given severity should bypass the digest.
+func (c *DigestConfig) IsImmediate(severity string) bool {
+	if !c.Enabled() {
+		return true
+	}
+	immediate := c.ImmediateSeverities
+	if len(immediate) == 0 {
+		immediate = DefaultImmediateSeverities
+	}
+	for _, s := range immediate {
+		if s == severity {
+			return true
+		}
+	}
+	return false
 }
 
 // OutputConfig contains the configuration for the output
@@ -194,6 +240,18 @@ type OutputConfig struct {
 
 	// CustomWebhook contains the configuration for a Custom Webhook alert output
 	CustomWebhook *CustomWebhookConfig `json:"customWebhook,omitempty"`
+
+	// Email contains the configuration for an SMTP email alert output
+	Email *EmailConfig `json:"email,omitempty"`
+
+	// Splunk contains the configuration for a Splunk HTTP Event Collector alert output
+	Splunk *SplunkConfig `json:"splunk,omitempty"`
+
+	// ServiceNow contains the configuration for a ServiceNow incident alert output
+	ServiceNow *ServiceNowConfig `json:"serviceNow,omitempty"`
+
+	// GoogleChat contains the configuration for a Google Chat alert output
+	GoogleChat *GoogleChatConfig `json:"googleChat,omitempty"`
 }
 
 // SlackConfig defines options for each Slack output.
@@ -252,3 +310,34 @@ type AsanaConfig struct {
 type CustomWebhookConfig struct {
 	WebhookURL string `json:"webhookURL" validate:"omitempty,url"`
 }
+
+// EmailConfig defines options for each email output, sent through an SMTP server
+type EmailConfig struct {
+	Host     string   `json:"host" validate:"omitempty,hostname_rfc1123|ip"`
+	Port     *int     `json:"port,omitempty" validate:"omitempty,min=1,max=65535"` // defaults to 587
+	UserName string   `json:"userName"`
+	Password string   `json:"password"`
+	From     string   `json:"from" validate:"omitempty,email"`
+	To       []string `json:"to" validate:"omitempty,min=1,dive,email"`
+}
+
+// SplunkConfig defines options for each Splunk HTTP Event Collector output
+type SplunkConfig struct {
+	HecURL     string `json:"hecUrl" validate:"omitempty,url"` // https://splunk.example.com:8088
+	Token      string `json:"token" validate:"omitempty,uuid"`
+	Index      string `json:"index"`
+	SourceType string `json:"sourceType"`
+}
+
+// ServiceNowConfig defines options for each ServiceNow output
+type ServiceNowConfig struct {
+	InstanceURL     string `json:"instanceUrl" validate:"omitempty,url"` // https://<instance>.service-now.com
+	UserName        string `json:"userName"`
+	Password        string `json:"password"`
+	AssignmentGroup string `json:"assignmentGroup"`
+}
+
+// GoogleChatConfig defines options for each Google Chat output
+type GoogleChatConfig struct {
+	WebhookURL string `json:"webhookURL" validate:"omitempty,url"` // https://chat.googleapis.com/v1/spaces/...
+}
@@ -34,8 +34,9 @@ type LambdaInput struct {
 	UpdateIntegrationLastScanEnd   *UpdateIntegrationLastScanEndInput   `json:"updateIntegrationLastScanEnd"`
 	UpdateIntegrationLastScanStart *UpdateIntegrationLastScanStartInput `json:"updateIntegrationLastScanStart"`
 
-	FullScan     *FullScanInput     `json:"fullScan"`
-	UpdateStatus *UpdateStatusInput `json:"updateStatus"`
+	FullScan          *FullScanInput          `json:"fullScan"`
+	SyncOrganizations *SyncOrganizationsInput `json:"syncOrganizations"`
+	UpdateStatus      *UpdateStatusInput      `json:"updateStatus"`
 }
 
 //
@@ -45,10 +46,10 @@ type LambdaInput struct {
 // CheckIntegrationInput is used to check the health of a potential configuration.
 type CheckIntegrationInput struct {
 	AWSAccountID     string `genericapi:"redact" json:"awsAccountId" validate:"omitempty,len=12,numeric"`
-	IntegrationType  string `json:"integrationType" validate:"oneof=aws-scan aws-s3 aws-sqs"`
+	IntegrationType  string `json:"integrationType" validate:"oneof=aws-scan aws-organization aws-s3 aws-sqs gcp-scan"`
 	IntegrationLabel string `json:"integrationLabel" validate:"required,integrationLabel"`
 
-	// Checks for cloudsec integrations
+	// Checks for cloudsec and organization integrations
 	EnableCWESetup    *bool `json:"enableCWESetup"`
 	EnableRemediation *bool `json:"enableRemediation"`
 
@@ -59,6 +60,10 @@ type CheckIntegrationInput struct {
 
 	// Checks for Sqs configuration
 	SqsConfig *SqsConfig `json:"sqsConfig,omitempty"`
+
+	// Checks for GCP projects
+	GCPProjectID         string `json:"gcpProjectId" validate:"omitempty,gcpProjectId"`
+	GCPServiceAccountKey string `genericapi:"redact" json:"gcpServiceAccountKey"`
 }
 
 //
@@ -73,7 +78,7 @@ type PutIntegrationInput struct {
 // PutIntegrationSettings are all the settings for the new integration.
 type PutIntegrationSettings struct {
 	IntegrationLabel   string   `json:"integrationLabel" validate:"required,integrationLabel,excludesall='<>&\""`
-	IntegrationType    string   `json:"integrationType" validate:"oneof=aws-scan aws-s3 aws-sqs"`
+	IntegrationType    string   `json:"integrationType" validate:"oneof=aws-scan aws-organization aws-s3 aws-sqs gcp-scan"`
 	UserID             string   `json:"userId" validate:"required,uuid4"`
 	AWSAccountID       string   `genericapi:"redact" json:"awsAccountId" validate:"omitempty,len=12,numeric"`
 	CWEEnabled         *bool    `json:"cweEnabled"`
@@ -84,7 +89,13 @@ type PutIntegrationSettings struct {
 	KmsKey             string   `json:"kmsKey" validate:"omitempty,kmsKeyArn"`
 	LogTypes           []string `json:"logTypes" validate:"omitempty,min=1"`
 
-	SqsConfig *SqsConfig `json:"sqsConfig,omitempty"`
+	OrganizationConfig *OrganizationConfig `json:"organizationConfig,omitempty"`
+	SqsConfig          *SqsConfig          `json:"sqsConfig,omitempty"`
+
+	// The JSON key of the service account which scans a GCP project. It is kept in Secrets Manager
+	// and never returned by the API.
+	GCPProjectID         string `json:"gcpProjectId" validate:"omitempty,gcpProjectId"`
+	GCPServiceAccountKey string `genericapi:"redact" json:"gcpServiceAccountKey"`
 }
 
 //
@@ -93,7 +104,7 @@ type PutIntegrationSettings struct {
 
 // ListIntegrationsInput allows filtering by the IntegrationType field
 type ListIntegrationsInput struct {
-	IntegrationType *string `json:"integrationType" validate:"omitempty,oneof=aws-scan aws-s3 aws-sqs"`
+	IntegrationType *string `json:"integrationType" validate:"omitempty,oneof=aws-scan aws-organization aws-s3 aws-sqs gcp-scan"`
 }
 
 // UpdateIntegrationSettingsInput is used to update integration settings.
@@ -108,7 +119,11 @@ type UpdateIntegrationSettingsInput struct {
 	KmsKey             string   `json:"kmsKey" validate:"omitempty,kmsKeyArn"`
 	LogTypes           []string `json:"logTypes" validate:"omitempty,min=1"`
 
-	SqsConfig *SqsConfig `json:"sqsConfig,omitempty"`
+	OrganizationConfig *OrganizationConfig `json:"organizationConfig,omitempty"`
+	SqsConfig          *SqsConfig          `json:"sqsConfig,omitempty"`
+
+	// Replaces the service account key of a gcp-scan integration, if set
+	GCPServiceAccountKey string `genericapi:"redact" json:"gcpServiceAccountKey"`
 }
 
 // DeleteIntegrationInput is used to delete a specific item from the database.
@@ -125,6 +140,15 @@ type FullScanInput struct {
 	Integrations []*SourceIntegrationMetadata
 }
 
+//
+// SyncOrganizations: Used by a scheduled event to onboard and offboard organization member accounts
+//
+
+// SyncOrganizationsInput reconciles one (or, by default, every) AWS Organizations integration.
+type SyncOrganizationsInput struct {
+	IntegrationID string `json:"integrationId" validate:"omitempty,uuid4"`
+}
+
 //
 // GetIntegrationTemplate: Used by the frontend to provide templates for users
 //
@@ -61,6 +61,17 @@ type SourceIntegrationMetadata struct {
 	LogProcessingRole  string     `json:"logProcessingRole,omitempty"`
 	StackName          string     `json:"stackName,omitempty"`
 	SqsConfig          *SqsConfig `json:"sqsConfig,omitempty"`
+
+	// Settings of an aws-organization integration
+	OrganizationConfig *OrganizationConfig `json:"organizationConfig,omitempty"`
+
+	// Set on the aws-scan integrations which are managed by an aws-organization integration
+	ParentIntegrationID    string `json:"parentIntegrationId,omitempty"`
+	OrganizationalUnitID   string `json:"organizationalUnitId,omitempty"`
+	OrganizationalUnitPath string `json:"organizationalUnitPath,omitempty"`
+
+	// Set on gcp-scan integrations
+	GCPProjectID string `json:"gcpProjectId,omitempty"`
 }
 
 type SourceIntegrationHealth struct {
@@ -78,6 +89,12 @@ type SourceIntegrationHealth struct {
 
 	// Checks for Sqs integrations
 	SqsStatus SourceIntegrationItemStatus `json:"sqsStatus"`
+
+	// Checks for organization integrations
+	OrganizationStatus SourceIntegrationItemStatus `json:"organizationStatus,omitempty"`
+
+	// Checks for GCP integrations
+	GCPCredentialsStatus SourceIntegrationItemStatus `json:"gcpCredentialsStatus,omitempty"`
 }
 
 type SourceIntegrationItemStatus struct {
@@ -110,3 +127,33 @@ type SqsConfig struct {
 	// THe URL of the SQS queue
 	QueueURL string `json:"queueUrl"`
 }
+
+// OrganizationConfig selects which member accounts of an AWS Organization are onboarded.
+//
+// An account is onboarded if it is in (or below) one of the included OUs, or if no OUs are included.
+// Accounts in (or below) an excluded OU are never onboarded.
+type OrganizationConfig struct {
+	// Organization root ("r-") or organizational unit ("ou-") IDs
+	IncludeOUs []string `json:"includeOus" validate:"omitempty,dive,organizationalUnitId"`
+	ExcludeOUs []string `json:"excludeOus" validate:"omitempty,dive,organizationalUnitId"`
+}
+
+// OrganizationSyncResult summarizes the changes made while syncing an organization integration.
+type OrganizationSyncResult struct {
+	IntegrationID string `json:"integrationId"`
+
+	// AWS account IDs of the child aws-scan integrations which were created, updated and removed
+	Created []string `json:"created"`
+	Updated []string `json:"updated"`
+	Removed []string `json:"removed"`
+
+	// Member accounts which were skipped because they are already onboarded individually
+	Skipped []string `json:"skipped"`
+
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
+
+// GCPCredentialsSecretName is the name of the secret holding the service account key of a gcp-scan integration.
+func GCPCredentialsSecretName(integrationID string) string {
+	return GCPCredentialsSecretPrefix + integrationID
+}
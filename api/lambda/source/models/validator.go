@@ -31,7 +31,9 @@ const (
 )
 
 var (
-	integrationLabelValidatorRegex = regexp.MustCompile("^[0-9a-zA-Z- ]+$")
+	integrationLabelValidatorRegex   = regexp.MustCompile("^[0-9a-zA-Z- ]+$")
+	organizationalUnitValidatorRegex = regexp.MustCompile("^(r-[0-9a-z]{4,32}|ou-[0-9a-z]{4,32}-[a-z0-9]{8,32})$")
+	gcpProjectIDValidatorRegex       = regexp.MustCompile("^[a-z][a-z0-9-]{4,28}[a-z0-9]$")
 )
 
 // Validator builds a custom struct validator.
@@ -43,6 +45,12 @@ func Validator() (*validator.Validate, error) {
 	if err := result.RegisterValidation("kmsKeyArn", validateKmsKeyArn); err != nil {
 		return nil, err
 	}
+	if err := result.RegisterValidation("organizationalUnitId", validateOrganizationalUnitID); err != nil {
+		return nil, err
+	}
+	if err := result.RegisterValidation("gcpProjectId", validateGCPProjectID); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
@@ -66,3 +74,13 @@ func validateKmsKeyArn(fl validator.FieldLevel) bool {
 	}
 	return true
 }
+
+// Organization roots and OUs, e.g. "r-ab12" or "ou-ab12-cdef3456"
+func validateOrganizationalUnitID(fl validator.FieldLevel) bool {
+	return organizationalUnitValidatorRegex.MatchString(fl.Field().String())
+}
+
+// GCP project IDs are 6 to 30 lowercase letters, digits or hyphens, e.g. "my-project-123"
+func validateGCPProjectID(fl validator.FieldLevel) bool {
+	return gcpProjectIDValidatorRegex.MatchString(fl.Field().String())
+}
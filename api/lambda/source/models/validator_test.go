@@ -80,3 +80,41 @@ func TestValidateKmsKey(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestValidateOrganizationalUnits(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	input := &PutIntegrationInput{
+		PutIntegrationSettings: PutIntegrationSettings{
+			AWSAccountID:     "123456789012",
+			IntegrationLabel: "Organization",
+			IntegrationType:  IntegrationTypeAWSOrganization,
+			UserID:           "cb7663c7-80ed-420b-a287-ed7dc50a0bf7",
+			OrganizationConfig: &OrganizationConfig{
+				IncludeOUs: []string{"r-ab12", "ou-ab12-cdef3456"},
+			},
+		},
+	}
+	require.NoError(t, validator.Struct(input))
+
+	input.OrganizationConfig.ExcludeOUs = []string{"Production"}
+	errorMsg := "Key: 'PutIntegrationInput.PutIntegrationSettings.OrganizationConfig.ExcludeOUs[0]' " +
+		"Error:Field validation for 'ExcludeOUs[0]' failed on the 'organizationalUnitId' tag"
+	require.EqualError(t, validator.Struct(input), errorMsg)
+}
+
+func TestValidateGCPProjectID(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	input := &CheckIntegrationInput{
+		IntegrationLabel: "GCP",
+		IntegrationType:  IntegrationTypeGCPScan,
+		GCPProjectID:     "my-project-123",
+	}
+	require.NoError(t, validator.Struct(input))
+
+	for _, projectID := range []string{"My-Project", "1project", "proj", "project-"} {
+		input.GCPProjectID = projectID
+		require.Error(t, validator.Struct(input), projectID)
+	}
+}
@@ -21,10 +21,18 @@ package models
 const (
 	// IntegrationTypeAWSScan is the integration type for snapshots in customer AWS accounts.
 	IntegrationTypeAWSScan = "aws-scan"
+	// IntegrationTypeAWSOrganization is the integration type for onboarding every account in an AWS Organization.
+	IntegrationTypeAWSOrganization = "aws-organization"
 	// IntegrationTypeAWS3 is the integration type for importing data from customer S3 buckets.
 	IntegrationTypeAWS3 = "aws-s3"
 	// IntegrationTypeSqs is integration type for pulling data from an SQS queue.
 	IntegrationTypeSqs = "aws-sqs"
+	// IntegrationTypeGCPScan is the integration type for snapshots in customer GCP projects.
+	IntegrationTypeGCPScan = "gcp-scan"
+
+	// GCPCredentialsSecretPrefix prefixes the Secrets Manager secret holding the service account key
+	// of a gcp-scan integration. The integration ID completes the secret name.
+	GCPCredentialsSecretPrefix = "panther-gcp-scan/"
 
 	// StatusError is the string set in the database when an error occurs in a scan.
 	StatusError = "error"
@@ -129,8 +129,15 @@ resource "aws_iam_role_policy" "panther_get_tags" {
       {
         Effect : "Allow",
         Action : [
+          "cloudfront:ListTagsForResource",
           "dynamodb:ListTagsOfResource",
+          "ecr:ListTagsForResource",
+          "elasticache:ListTagsForResource",
           "kms:ListResourceTags",
+          "organizations:ListTagsForResource",
+          "route53:ListTagsForResource",
+          "sqs:ListQueueTags",
+          "ssm:ListTagsForResource",
           "waf:ListTagsForResource",
           "waf-regional:ListTagsForResource"
         ],
@@ -140,6 +147,28 @@ resource "aws_iam_role_policy" "panther_get_tags" {
   })
 }
 
+resource "aws_iam_role_policy" "panther_get_resource_policies" {
+  count = var.include_audit_role ? 1 : 0
+  name  = "GetResourcePolicies"
+  role  = aws_iam_role.panther_audit[0].id
+
+  policy = jsonencode({
+    Version : "2012-10-17",
+    Statement : [
+      {
+        Effect : "Allow",
+        Action : [
+          "ecr:GetLifecyclePolicy",
+          "ecr:GetRepositoryPolicy",
+          "elasticfilesystem:DescribeFileSystemPolicy",
+          "secretsmanager:GetResourcePolicy"
+        ],
+        Resource : "*"
+      }
+    ]
+  })
+}
+
 
 ###############################################################
 # CloudFormation StackSet Execution Role
@@ -23,6 +23,7 @@ require (
 	github.com/magefile/mage v1.9.0
 	github.com/modern-go/reflect2 v0.0.0-20180701023420-4b7aa43c6742
 	github.com/pkg/errors v0.9.1
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/stretchr/testify v1.6.1
 	github.com/tidwall/gjson v1.6.0
 	go.uber.org/zap v1.15.0
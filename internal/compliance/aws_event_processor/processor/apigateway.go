@@ -0,0 +1,66 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func classifyAPIGateway(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazonapigateway.html
+	switch metadata.eventName {
+	case "CreateStage", "DeleteStage", "UpdateStage":
+		restAPIID := detail.Get("requestParameters.restApiId").Str
+		stageName := detail.Get("requestParameters.stageName").Str
+		if stageName == "" {
+			stageName = detail.Get("requestParameters.createStageInput.stageName").Str
+		}
+		if restAPIID != "" && stageName != "" {
+			return []*resourceChange{{
+				AwsAccountID: metadata.accountID,
+				Delete:       metadata.eventName == "DeleteStage",
+				EventName:    metadata.eventName,
+				ResourceID: arn.ARN{
+					Partition: "aws",
+					Service:   "apigateway",
+					Region:    metadata.region,
+					Resource:  "/restapis/" + restAPIID + "/stages/" + stageName,
+				}.String(),
+				ResourceType: schemas.APIGatewayStageSchema,
+			}}
+		}
+		zap.L().Warn("apigateway: unable to parse stage, scanning region", zap.String("eventName", metadata.eventName))
+	case "CreateDeployment", "DeleteRestApi", "ImportRestApi", "PutRestApi", "UpdateRestApi":
+		// These calls change the API that every stage inherits its policy and endpoint configuration from
+	default:
+		zap.L().Info("apigateway: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	return []*resourceChange{{
+		AwsAccountID: metadata.accountID,
+		EventName:    metadata.eventName,
+		Region:       metadata.region,
+		ResourceType: schemas.APIGatewayStageSchema,
+	}}
+}
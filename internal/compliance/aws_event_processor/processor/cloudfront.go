@@ -0,0 +1,79 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+// CloudFront event names carry the API version they were made against, e.g. UpdateDistribution2019_03_26
+var cloudFrontVersionRegex = regexp.MustCompile(`\d{4}_\d{2}_\d{2}$`)
+
+func getCloudFrontBaseEventName(eventName string) string {
+	return cloudFrontVersionRegex.ReplaceAllString(eventName, "")
+}
+
+func classifyCloudFront(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazoncloudfront.html
+	distributionARN := arn.ARN{
+		Partition: "aws",
+		Service:   "cloudfront",
+		AccountID: metadata.accountID,
+		Resource:  "distribution/",
+	}
+	eventName := getCloudFrontBaseEventName(metadata.eventName)
+	switch eventName {
+	case "CreateDistribution", "CreateDistributionWithTags":
+		distributionARN.Resource += detail.Get("responseElements.distribution.id").Str
+	case "DeleteDistribution", "UpdateDistribution":
+		distributionARN.Resource += detail.Get("requestParameters.id").Str
+	case "TagResource", "UntagResource":
+		// Streaming distributions can be tagged as well
+		resourceARN := detail.Get("requestParameters.resource").Str
+		parsed, err := arn.Parse(resourceARN)
+		if err != nil || !strings.HasPrefix(parsed.Resource, "distribution/") {
+			return nil
+		}
+		distributionARN = parsed
+	default:
+		zap.L().Info("cloudfront: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	if distributionARN.Resource == "distribution/" {
+		zap.L().Error("cloudfront: known event name, but failed to parse distribution ID",
+			zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	return []*resourceChange{{
+		AwsAccountID: metadata.accountID,
+		Delete:       eventName == "DeleteDistribution",
+		EventName:    metadata.eventName,
+		ResourceID:   distributionARN.String(),
+		ResourceType: schemas.CloudFrontDistributionSchema,
+	}}
+}
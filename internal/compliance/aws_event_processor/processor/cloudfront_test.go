@@ -0,0 +1,46 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetCloudFrontBaseEventName(t *testing.T) {
+	assert.Equal(t, "UpdateDistribution", getCloudFrontBaseEventName("UpdateDistribution2019_03_26"))
+	assert.Equal(t, "UpdateDistribution", getCloudFrontBaseEventName("UpdateDistribution"))
+}
+
+func TestClassifyCloudFrontCreate(t *testing.T) {
+	detail := gjson.Parse(`{"responseElements": {"distribution": {"id": "E2EXAMPLE0ABCD"}}}`)
+	metadata := &CloudTrailMetadata{
+		region:    "us-east-1",
+		accountID: "123456789012",
+		eventName: "CreateDistributionWithTags2019_03_26",
+	}
+
+	changes := classifyCloudFront(detail, metadata)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "arn:aws:cloudfront::123456789012:distribution/E2EXAMPLE0ABCD", changes[0].ResourceID)
+	assert.False(t, changes[0].Delete)
+}
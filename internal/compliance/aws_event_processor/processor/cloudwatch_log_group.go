@@ -42,6 +42,9 @@ func classifyCloudWatchLogGroup(detail gjson.Result, metadata *CloudTrailMetadat
 		// Not technically the correct resourceID, see classifyCloudFormation for a more detailed
 		// explanation.
 		logGroupARN.Resource += detail.Get("requestParameters.logGroupName").Str
+	case "PutResourcePolicy":
+		// Resource policies apply to the whole account rather than to individual log groups
+		return nil
 	default:
 		zap.L().Info("loggroup: encountered unknown event name", zap.String("eventName", metadata.eventName))
 		return nil
@@ -0,0 +1,59 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func classifyEKS(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazonelastickubernetesservice.html
+	switch metadata.eventName {
+	case "CreateCluster", "CreateFargateProfile", "CreateNodegroup", "DeleteCluster", "DeleteFargateProfile",
+		"DeleteNodegroup", "UpdateClusterConfig", "UpdateClusterVersion", "UpdateNodegroupConfig", "UpdateNodegroupVersion":
+		// The cluster name is always part of the request path, which CloudTrail records as "name"
+	default:
+		zap.L().Info("eks: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	clusterName := detail.Get("requestParameters.name").Str
+	if clusterName == "" {
+		zap.L().Error("eks: known event name, but failed to parse cluster name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	return []*resourceChange{{
+		AwsAccountID: metadata.accountID,
+		Delete:       metadata.eventName == "DeleteCluster",
+		EventName:    metadata.eventName,
+		ResourceID: arn.ARN{
+			Partition: "aws",
+			Service:   "eks",
+			Region:    metadata.region,
+			AccountID: metadata.accountID,
+			Resource:  "cluster/" + clusterName,
+		}.String(),
+		ResourceType: schemas.EksClusterSchema,
+	}}
+}
@@ -0,0 +1,76 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func classifyElastiCache(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_amazonelasticache.html
+	var clusterARN string
+	switch metadata.eventName {
+	case "CreateCacheCluster", "DeleteCacheCluster", "ModifyCacheCluster", "RebootCacheCluster":
+		clusterARN = arn.ARN{
+			Partition: "aws",
+			Service:   "elasticache",
+			Region:    metadata.region,
+			AccountID: metadata.accountID,
+			Resource:  "cluster:" + detail.Get("requestParameters.cacheClusterId").Str,
+		}.String()
+	case "AddTagsToResource", "RemoveTagsFromResource":
+		// Snapshots, parameter groups and other ElastiCache resources can be tagged as well
+		clusterARN = detail.Get("requestParameters.resourceName").Str
+		parsed, err := arn.Parse(clusterARN)
+		if err != nil || !strings.HasPrefix(parsed.Resource, "cluster:") {
+			return nil
+		}
+	case "CreateReplicationGroup", "DecreaseReplicaCount", "DeleteReplicationGroup", "IncreaseReplicaCount",
+		"ModifyReplicationGroup", "ModifyReplicationGroupShardConfiguration", "TestFailover":
+		// Replication groups create, modify and delete member clusters without naming them
+		return []*resourceChange{{
+			AwsAccountID: metadata.accountID,
+			EventName:    metadata.eventName,
+			Region:       metadata.region,
+			ResourceType: schemas.ElastiCacheClusterSchema,
+		}}
+	default:
+		zap.L().Info("elasticache: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	if strings.HasSuffix(clusterARN, ":cluster:") {
+		zap.L().Error("elasticache: known event name, but failed to parse cluster ID", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	return []*resourceChange{{
+		AwsAccountID: metadata.accountID,
+		Delete:       metadata.eventName == "DeleteCacheCluster",
+		EventName:    metadata.eventName,
+		ResourceID:   clusterARN,
+		ResourceType: schemas.ElastiCacheClusterSchema,
+	}}
+}
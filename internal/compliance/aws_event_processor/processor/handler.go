@@ -274,6 +274,9 @@ func submitChanges(changes map[string]*resourceChange) error {
 				ResourceID:       resourceID,
 				ResourceType:     &change.ResourceType,
 				ScanAllResources: aws.Bool(false),
+				EventID:          aws.String(change.EventID),
+				EventName:        aws.String(change.EventName),
+				EventTime:        aws.String(change.EventTime),
 			})
 		}
 	}
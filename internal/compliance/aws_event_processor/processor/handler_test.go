@@ -305,6 +305,9 @@ func TestHandleUpdate(t *testing.T) {
 				ResourceID:       aws.String("arn:aws:s3:::austin-panther"),
 				ResourceType:     aws.String(schemas.S3BucketSchema),
 				ScanAllResources: aws.Bool(false),
+				EventID:          aws.String("43258a7e-eef1-44ef-9aff-1e5b4cfd825d"),
+				EventName:        aws.String("PutBucketPublicAccessBlock"),
+				EventTime:        aws.String("2019-08-01T04:41:47Z"),
 			},
 		},
 	}
@@ -350,6 +353,7 @@ func TestHandleUpdate(t *testing.T) {
 
 	expectedChange := &resourceChange{
 		AwsAccountID:  "111111111111",
+		EventID:       "43258a7e-eef1-44ef-9aff-1e5b4cfd825d",
 		EventName:     "PutBucketPublicAccessBlock",
 		EventTime:     "2019-08-01T04:41:47Z",
 		IntegrationID: "ebb4d69f-177b-4eff-a7a6-9251fdc72d21",
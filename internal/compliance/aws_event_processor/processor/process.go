@@ -31,7 +31,8 @@ type resourceChange struct {
 	AwsAccountID  string `json:"awsAccountId"`  // the 12-digit AWS account ID which owns the resource
 	Delay         int64  `json:"delay"`         // How long in seconds to delay this message in SQS
 	Delete        bool   `json:"delete"`        // True if the resource should be marked deleted (otherwise, update)
-	EventName     string `json:"eventName"`     // CloudTrail event name (for logging only)
+	EventID       string `json:"eventId"`       // CloudTrail event ID (recorded in the resource history)
+	EventName     string `json:"eventName"`     // CloudTrail event name (for logging and resource history)
 	EventTime     string `json:"eventTime"`     // official CloudTrail RFC3339 timestamp
 	IntegrationID string `json:"integrationId"` // account integration ID
 	Region        string `json:"region"`        // Region (for resource type scans only)
@@ -46,21 +47,33 @@ type resourceChange struct {
 var (
 	classifiers = map[string]func(gjson.Result, *CloudTrailMetadata) []*resourceChange{
 		"acm.amazonaws.com":                  classifyACM,
+		"apigateway.amazonaws.com":           classifyAPIGateway,
+		"cloudfront.amazonaws.com":           classifyCloudFront,
 		"cloudformation.amazonaws.com":       classifyCloudFormation,
 		"cloudtrail.amazonaws.com":           classifyCloudTrail,
 		"config.amazonaws.com":               classifyConfig,
 		"dynamodb.amazonaws.com":             classifyDynamoDB,
 		"ec2.amazonaws.com":                  classifyEC2,
+		"ecr.amazonaws.com":                  classifyECR,
 		"ecs.amazonaws.com":                  classifyECS,
+		"eks.amazonaws.com":                  classifyEKS,
+		"elasticache.amazonaws.com":          classifyElastiCache,
+		"elasticfilesystem.amazonaws.com":    classifyEFS,
 		"elasticloadbalancing.amazonaws.com": classifyELBV2,
 		"guardduty.amazonaws.com":            classifyGuardDuty,
 		"iam.amazonaws.com":                  classifyIAM,
 		"kms.amazonaws.com":                  classifyKMS,
 		"lambda.amazonaws.com":               classifyLambda,
 		"logs.amazonaws.com":                 classifyCloudWatchLogGroup,
+		"organizations.amazonaws.com":        classifyOrganizations,
 		"rds.amazonaws.com":                  classifyRDS,
 		"redshift.amazonaws.com":             classifyRedshift,
+		"route53.amazonaws.com":              classifyRoute53,
 		"s3.amazonaws.com":                   classifyS3,
+		"secretsmanager.amazonaws.com":       classifySecretsManager,
+		"sns.amazonaws.com":                  classifySNS,
+		"sqs.amazonaws.com":                  classifySQS,
+		"ssm.amazonaws.com":                  classifySSM,
 		"waf.amazonaws.com":                  classifyWAF,
 		"waf-regional.amazonaws.com":         classifyWAFRegional,
 	}
@@ -93,7 +106,6 @@ var (
 		"PutDestination":       {},
 		"PutDestinationPolicy": {},
 		"PutLogEvents":         {},
-		"PutResourcePolicy":    {},
 		"StartQuery":           {},
 		"StopQuery":            {},
 		"TestMetricFilter":     {},
@@ -145,6 +157,16 @@ var (
 		"CreateInternetGateway":  {}, // Currently we don't have an EC2 InternetGateway resource,
 		"DeleteInternetGateway":  {}, // when we do we will need to handle these
 
+		// ecr
+		"BatchCheckLayerAvailability": {},
+		"BatchDeleteImage":            {},
+		"CompleteLayerUpload":         {},
+		"InitiateLayerUpload":         {},
+		"PutImage":                    {},
+		"StartImageScan":              {},
+		"StartLifecyclePolicyPreview": {},
+		"UploadLayerPart":             {},
+
 		// ecs
 		"DeleteAccountSetting":     {},
 		"DeregisterTaskDefinition": {},
@@ -248,6 +270,32 @@ var (
 		"HeadObject":              {},
 		"PutObject":               {},
 
+		// secretsmanager
+		"PutSecretValue": {},
+
+		// sns
+		"Publish": {},
+
+		// sqs
+		"ChangeMessageVisibility":      {},
+		"ChangeMessageVisibilityBatch": {},
+		"DeleteMessage":                {},
+		"DeleteMessageBatch":           {},
+		"PurgeQueue":                   {},
+		"ReceiveMessage":               {},
+		"SendMessage":                  {},
+		"SendMessageBatch":             {},
+
+		// ssm
+		"PutComplianceItems":              {},
+		"PutInventory":                    {},
+		"ResumeSession":                   {},
+		"SendCommand":                     {},
+		"StartSession":                    {},
+		"TerminateSession":                {},
+		"UpdateInstanceAssociationStatus": {},
+		"UpdateInstanceInformation":       {},
+
 		// waf, waf-regional
 		// TODO get suffixes
 		"DeletePermissionPolicy": {},
@@ -390,6 +438,7 @@ func processCloudTrailLog(detail gjson.Result, metadata *CloudTrailMetadata, cha
 
 	// One event could require multiple scans (e.g. a new VPC peering connection between two VPCs)
 	for _, change := range newChanges {
+		change.EventID = detail.Get("eventID").Str
 		change.EventTime = eventTime
 		change.IntegrationID = integration.IntegrationID
 		zap.L().Info("resource scan required", zap.Any("changeDetail", change))
@@ -0,0 +1,64 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func classifySecretsManager(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_awssecretsmanager.html
+	var secretARN string
+	switch metadata.eventName {
+	case "CreateSecret":
+		secretARN = detail.Get("responseElements.aRN").Str
+		if secretARN == "" {
+			secretARN = detail.Get("responseElements.arn").Str
+		}
+	case "CancelRotateSecret", "DeleteResourcePolicy", "DeleteSecret", "PutResourcePolicy", "RestoreSecret",
+		"RotateSecret", "UpdateSecret", "UpdateSecretVersionStage":
+		// DeleteSecret only schedules the secret for deletion, so it is treated as an update
+		secretARN = detail.Get("requestParameters.secretId").Str
+	default:
+		zap.L().Info("secretsmanager: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	// The secretId may be a friendly name instead of an ARN. Secret ARNs end in a random suffix
+	// which cannot be derived from the name, so the whole region must be scanned instead.
+	if _, err := arn.Parse(secretARN); err != nil {
+		return []*resourceChange{{
+			AwsAccountID: metadata.accountID,
+			EventName:    metadata.eventName,
+			Region:       metadata.region,
+			ResourceType: schemas.SecretsManagerSecretSchema,
+		}}
+	}
+
+	return []*resourceChange{{
+		AwsAccountID: metadata.accountID,
+		EventName:    metadata.eventName,
+		ResourceID:   secretARN,
+		ResourceType: schemas.SecretsManagerSecretSchema,
+	}}
+}
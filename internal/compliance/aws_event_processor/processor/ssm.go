@@ -0,0 +1,86 @@
+package processor
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	schemas "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func classifySSM(detail gjson.Result, metadata *CloudTrailMetadata) []*resourceChange {
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/list_awssystemsmanager.html
+	var parameterNames []string
+	switch metadata.eventName {
+	case "DeleteParameter", "LabelParameterVersion", "PutParameter":
+		parameterNames = []string{detail.Get("requestParameters.name").Str}
+	case "DeleteParameters":
+		for _, name := range detail.Get("requestParameters.names").Array() {
+			parameterNames = append(parameterNames, name.Str)
+		}
+	case "AddTagsToResource", "RemoveTagsFromResource":
+		// Documents, maintenance windows and other SSM resources can be tagged as well
+		if detail.Get("requestParameters.resourceType").Str != "Parameter" {
+			return nil
+		}
+		parameterNames = []string{detail.Get("requestParameters.resourceId").Str}
+	default:
+		zap.L().Info("ssm: encountered unknown event name", zap.String("eventName", metadata.eventName))
+		return nil
+	}
+
+	changes := make([]*resourceChange, 0, len(parameterNames))
+	for _, name := range parameterNames {
+		if name == "" {
+			zap.L().Error("ssm: known event name, but failed to parse parameter name",
+				zap.String("eventName", metadata.eventName))
+			continue
+		}
+		changes = append(changes, &resourceChange{
+			AwsAccountID: metadata.accountID,
+			Delete:       metadata.eventName == "DeleteParameter" || metadata.eventName == "DeleteParameters",
+			EventName:    metadata.eventName,
+			ResourceID:   ssmParameterARN(name, metadata),
+			ResourceType: schemas.SSMParameterSchema,
+		})
+	}
+	return changes
+}
+
+// ssmParameterARN builds a parameter ARN from either its name or its ARN. Hierarchical parameter
+// names begin with a slash which is not repeated in the ARN.
+func ssmParameterARN(name string, metadata *CloudTrailMetadata) string {
+	if _, err := arn.Parse(name); err == nil {
+		return name
+	}
+	if !strings.HasPrefix(name, "/") {
+		name = "/" + name
+	}
+	return arn.ARN{
+		Partition: "aws",
+		Service:   "ssm",
+		Region:    metadata.region,
+		AccountID: metadata.accountID,
+		Resource:  "parameter" + name,
+	}.String()
+}
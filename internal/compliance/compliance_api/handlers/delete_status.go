@@ -70,6 +70,15 @@ func DeleteStatus(request *events.APIGatewayProxyRequest) *events.APIGatewayProx
 		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
 	}
 
+	// Deleting a policy can change the status of the resources it applied to
+	resourceIDs := make([]models.ResourceID, len(deleteRequests))
+	for i, request := range deleteRequests {
+		resourceIDs[i] = models.ResourceID(aws.StringValue(request.DeleteRequest.Key["resourceId"].S))
+	}
+	if err := syncResourceStatus(resourceIDs); err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
 	return &events.APIGatewayProxyResponse{StatusCode: http.StatusOK}
 }
 
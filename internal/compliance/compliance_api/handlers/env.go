@@ -19,8 +19,12 @@ package handlers
  */
 
 type envConfig struct {
-	ComplianceTable string `required:"true" split_words:"true"`
-	IndexName       string `required:"true" split_words:"true"`
+	ComplianceTable    string `required:"true" split_words:"true"`
+	IndexName          string `required:"true" split_words:"true"`
+	ReportsBucket      string `required:"true" split_words:"true"`
+	ResourcesTable     string `required:"true" split_words:"true"`
+	TrendRetentionDays int    `required:"true" split_words:"true"`
+	TrendTable         string `required:"true" split_words:"true"`
 }
 
 // Env is the parsed environment variables
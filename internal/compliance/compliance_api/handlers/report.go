@@ -0,0 +1,299 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/go-openapi/strfmt"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/gateway/compliance/models"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+type reportParams struct {
+	Framework     string
+	IntegrationID string
+}
+
+// Aggregates the status entries which map to a single framework control
+type controlBuilder struct {
+	accounts  map[models.IntegrationID]*models.StatusCount
+	count     *models.StatusCount // counts status entries, not resources
+	evidence  []*models.ControlEvidence
+	policies  map[models.PolicyID]struct{}
+	resources map[models.ResourceID]*models.StatusCount
+
+	// The evidence is only kept for exported reports
+	withEvidence bool
+}
+
+func newControlBuilder(withEvidence bool) *controlBuilder {
+	return &controlBuilder{
+		accounts:     make(map[models.IntegrationID]*models.StatusCount),
+		count:        NewStatusCount(),
+		policies:     make(map[models.PolicyID]struct{}),
+		resources:    make(map[models.ResourceID]*models.StatusCount),
+		withEvidence: withEvidence,
+	}
+}
+
+func (b *controlBuilder) add(item *models.ComplianceStatus) {
+	account, ok := b.accounts[item.IntegrationID]
+	if !ok {
+		account = NewStatusCount()
+		b.accounts[item.IntegrationID] = account
+	}
+	updateStatusCount(account, item.Status)
+
+	resource, ok := b.resources[item.ResourceID]
+	if !ok {
+		resource = NewStatusCount()
+		b.resources[item.ResourceID] = resource
+	}
+	updateStatusCount(resource, item.Status)
+
+	updateStatusCount(b.count, item.Status)
+	b.policies[item.PolicyID] = struct{}{}
+	if !b.withEvidence {
+		return
+	}
+	b.evidence = append(b.evidence, &models.ControlEvidence{
+		IntegrationID: item.IntegrationID,
+		LastUpdated:   item.LastUpdated,
+		PolicyID:      item.PolicyID,
+		ResourceID:    item.ResourceID,
+		ResourceType:  item.ResourceType,
+		Status:        item.Status,
+	})
+}
+
+func (b *controlBuilder) build(control string) *models.ReportControl {
+	result := &models.ReportControl{
+		Accounts: make([]*models.ControlAccount, 0, len(b.accounts)),
+		Control:  aws.String(control),
+		Count:    NewStatusCount(),
+		Evidence: b.evidence,
+		Policies: make([]models.PolicyID, 0, len(b.policies)),
+		Status:   countToStatus(b.count),
+	}
+
+	for integrationID, count := range b.accounts {
+		result.Accounts = append(result.Accounts, &models.ControlAccount{
+			IntegrationID: integrationID,
+			Status:        countToStatus(count),
+		})
+	}
+	sort.Slice(result.Accounts, func(i, j int) bool {
+		return result.Accounts[i].IntegrationID < result.Accounts[j].IntegrationID
+	})
+
+	for policyID := range b.policies {
+		result.Policies = append(result.Policies, policyID)
+	}
+	sort.Slice(result.Policies, func(i, j int) bool { return result.Policies[i] < result.Policies[j] })
+
+	for _, count := range b.resources {
+		updateStatusCount(result.Count, countToStatus(count))
+	}
+
+	sort.Slice(result.Evidence, func(i, j int) bool {
+		left, right := result.Evidence[i], result.Evidence[j]
+		if left.PolicyID != right.PolicyID {
+			return left.PolicyID < right.PolicyID
+		}
+		return left.ResourceID < right.ResourceID
+	})
+
+	return result
+}
+
+// GetComplianceReport returns the status of each control in a compliance framework.
+//
+// Policies map to framework controls via their reports, e.g. {"CIS": ["1.1", "1.2"]}.
+// Like the org overview, suppressed status entries are not included.
+// The evidence of each control is left out, it is only part of the exported report.
+func GetComplianceReport(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	params, err := parseReportParams(request)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	report, err := buildReport(params, time.Now().UTC(), false)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	return gatewayapi.MarshalResponse(report, http.StatusOK)
+}
+
+func parseReportParams(request *events.APIGatewayProxyRequest) (*reportParams, error) {
+	framework, err := url.QueryUnescape(request.QueryStringParameters["framework"])
+	if err != nil {
+		return nil, errors.New("invalid framework: " + err.Error())
+	}
+	if framework == "" {
+		return nil, errors.New("framework is required")
+	}
+
+	return &reportParams{
+		Framework:     framework,
+		IntegrationID: request.QueryStringParameters["integrationId"],
+	}, nil
+}
+
+func buildReport(params *reportParams, now time.Time, withEvidence bool) (*models.ComplianceReport, error) {
+	input, err := buildReportQuery(params)
+	if err != nil {
+		return nil, err
+	}
+
+	controls := make(map[string]*controlBuilder)
+	err = scanPages(input, func(item *models.ComplianceStatus) error {
+		addToControls(controls, item, params.Framework, withEvidence)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buildReportFromControls(params, controls, now), nil
+}
+
+// Add a status entry to every control it provides evidence for
+func addToControls(controls map[string]*controlBuilder, item *models.ComplianceStatus, framework string, withEvidence bool) {
+	for _, control := range item.PolicyReports[framework] {
+		builder, ok := controls[control]
+		if !ok {
+			builder = newControlBuilder(withEvidence)
+			controls[control] = builder
+		}
+		builder.add(item)
+	}
+}
+
+func buildReportFromControls(
+	params *reportParams, controls map[string]*controlBuilder, now time.Time) *models.ComplianceReport {
+
+	generatedAt := strfmt.DateTime(now)
+	result := &models.ComplianceReport{
+		Controls:      make([]*models.ReportControl, 0, len(controls)),
+		Framework:     aws.String(params.Framework),
+		GeneratedAt:   &generatedAt,
+		IntegrationID: params.IntegrationID,
+		Summary:       NewStatusCount(),
+	}
+
+	for control, builder := range controls {
+		reportControl := builder.build(control)
+		updateStatusCount(result.Summary, reportControl.Status)
+		result.Controls = append(result.Controls, reportControl)
+	}
+
+	sort.Slice(result.Controls, func(i, j int) bool {
+		return controlLess(*result.Controls[i].Control, *result.Controls[j].Control)
+	})
+	return result
+}
+
+// Sort control IDs in their natural order, e.g. "1.2" < "1.10" < "2.1"
+func controlLess(first, second string) bool {
+	firstParts, secondParts := strings.Split(first, "."), strings.Split(second, ".")
+	for i := 0; i < len(firstParts) && i < len(secondParts); i++ {
+		if firstParts[i] == secondParts[i] {
+			continue
+		}
+
+		firstNum, firstErr := strconv.Atoi(firstParts[i])
+		secondNum, secondErr := strconv.Atoi(secondParts[i])
+		if firstErr == nil && secondErr == nil {
+			return firstNum < secondNum
+		}
+		return firstParts[i] < secondParts[i]
+	}
+	return len(firstParts) < len(secondParts)
+}
+
+func buildReportQuery(params *reportParams) (*dynamodb.ScanInput, error) {
+	filter := expression.Equal(expression.Name("suppressed"), expression.Value(false))
+	if params.IntegrationID != "" {
+		filter = filter.And(expression.Equal(expression.Name("integrationId"), expression.Value(params.IntegrationID)))
+	}
+
+	expr, err := expression.NewBuilder().WithFilter(filter).Build()
+	if err != nil {
+		zap.L().Error("expression.Build failed", zap.Error(err))
+		return nil, err
+	}
+
+	return &dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		TableName:                 &Env.ComplianceTable,
+	}, nil
+}
+
+// ListReportFrameworks returns every compliance framework referenced by a (non-suppressed) status entry.
+func ListReportFrameworks(_ *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	input, err := buildGetOrgOverviewQuery()
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	frameworks := make(map[string]map[string]struct{})
+	err = scanPages(input, func(item *models.ComplianceStatus) error {
+		for framework, controls := range item.PolicyReports {
+			if frameworks[framework] == nil {
+				frameworks[framework] = make(map[string]struct{})
+			}
+			for _, control := range controls {
+				frameworks[framework][control] = struct{}{}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	result := &models.ReportFrameworks{Frameworks: make([]*models.ReportFramework, 0, len(frameworks))}
+	for name, controls := range frameworks {
+		result.Frameworks = append(result.Frameworks, &models.ReportFramework{
+			Controls: aws.Int64(int64(len(controls))),
+			Name:     aws.String(name),
+		})
+	}
+	sort.Slice(result.Frameworks, func(i, j int) bool {
+		return *result.Frameworks[i].Name < *result.Frameworks[j].Name
+	})
+
+	return gatewayapi.MarshalResponse(result, http.StatusOK)
+}
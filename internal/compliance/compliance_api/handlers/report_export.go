@@ -0,0 +1,238 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/go-openapi/strfmt"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/gateway/compliance/models"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+// Export formats - values should match those in api.yml
+const (
+	formatCSV  = "csv"
+	formatHTML = "html"
+	formatJSON = "json"
+
+	defaultFormat = formatCSV
+
+	// How long the download link of an exported report is valid
+	reportURLExpiration = 15 * time.Minute
+)
+
+var s3Client s3iface.S3API = s3.New(awsSession)
+
+var contentTypes = map[string]string{
+	formatCSV:  "text/csv",
+	formatHTML: "text/html",
+	formatJSON: "application/json",
+}
+
+// Characters which are replaced in the export file name
+var fileNameUnsafe = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+var csvHeader = []string{
+	"framework", "control", "controlStatus", "policyId", "integrationId",
+	"resourceType", "resourceId", "status", "lastUpdated", "reportGeneratedAt",
+}
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"timestamp": formatTimestamp,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Framework}} Compliance Report</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+.PASS { color: #2e7d32; } .FAIL { color: #c62828; } .ERROR { color: #ef6c00; }
+</style>
+</head>
+<body>
+<h1>{{.Framework}} Compliance Report</h1>
+<p>Generated at {{timestamp .GeneratedAt}}{{if .IntegrationID}} for integration {{.IntegrationID}}{{end}}</p>
+<p>Controls: {{.Summary.Pass}} passing, {{.Summary.Fail}} failing, {{.Summary.Error}} with errors</p>
+{{range .Controls}}
+<h2>Control {{.Control}}: <span class="{{.Status}}">{{.Status}}</span></h2>
+<p>Policies: {{range $i, $p := .Policies}}{{if $i}}, {{end}}{{$p}}{{end}}</p>
+<p>Resources: {{.Count.Pass}} passing, {{.Count.Fail}} failing, {{.Count.Error}} with errors</p>
+<table>
+<tr><th>Policy</th><th>Integration</th><th>Resource Type</th><th>Resource</th><th>Status</th><th>Last Updated</th></tr>
+{{range .Evidence}}<tr><td>{{.PolicyID}}</td><td>{{.IntegrationID}}</td><td>{{.ResourceType}}</td><td>{{.ResourceID}}</td><td class="{{.Status}}">{{.Status}}</td><td>{{timestamp .LastUpdated}}</td></tr>
+{{end}}</table>
+{{end}}
+</body>
+</html>
+`))
+
+// ExportComplianceReport generates a compliance framework report file for auditors.
+//
+// Reports with evidence for every resource can be large, so the file is written to S3
+// and the response links to it with a presigned URL.
+func ExportComplianceReport(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	params, err := parseReportParams(request)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	format := request.QueryStringParameters["format"]
+	if format == "" {
+		format = defaultFormat
+	}
+	if _, ok := contentTypes[format]; !ok {
+		return badRequest(fmt.Errorf("invalid format: %s", format))
+	}
+
+	now := time.Now().UTC()
+	report, err := buildReport(params, now, true)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	data, err := renderReport(report, format)
+	if err != nil {
+		zap.L().Error("failed to render compliance report",
+			zap.String("format", format), zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	result, err := uploadReport(data, reportFileName(params.Framework, now, format), contentTypes[format], now)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	return gatewayapi.MarshalResponse(result, http.StatusOK)
+}
+
+// Write a report file to the reports bucket and return a presigned URL to download it
+func uploadReport(data []byte, fileName, contentType string, now time.Time) (*models.ReportExport, error) {
+	// A random prefix, so the same report can be exported more than once a day
+	key := "reports/" + uuid.New().String() + "/" + fileName
+	_, err := s3Client.PutObject(&s3.PutObjectInput{
+		Body:               bytes.NewReader(data),
+		Bucket:             &Env.ReportsBucket,
+		ContentDisposition: aws.String(fmt.Sprintf("attachment; filename=%q", fileName)),
+		ContentType:        &contentType,
+		Key:                &key,
+	})
+	if err != nil {
+		zap.L().Error("s3Client.PutObject failed", zap.String("key", key), zap.Error(err))
+		return nil, err
+	}
+
+	request, _ := s3Client.GetObjectRequest(&s3.GetObjectInput{Bucket: &Env.ReportsBucket, Key: &key})
+	url, err := request.Presign(reportURLExpiration)
+	if err != nil {
+		zap.L().Error("failed to presign report URL", zap.String("key", key), zap.Error(err))
+		return nil, err
+	}
+
+	expiresAt := strfmt.DateTime(now.Add(reportURLExpiration))
+	return &models.ReportExport{
+		ContentType: &contentType,
+		ExpiresAt:   &expiresAt,
+		FileName:    &fileName,
+		URL:         &url,
+	}, nil
+}
+
+func renderReport(report *models.ComplianceReport, format string) ([]byte, error) {
+	switch format {
+	case formatJSON:
+		return jsoniter.MarshalIndent(report, "", "  ")
+	case formatHTML:
+		var buf bytes.Buffer
+		if err := reportTemplate.Execute(&buf, report); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return reportCSV(report)
+	}
+}
+
+// One row for every piece of evidence, grouped by control
+func reportCSV(report *models.ComplianceReport) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(csvHeader); err != nil {
+		return nil, err
+	}
+
+	generatedAt := formatTimestamp(*report.GeneratedAt)
+	for _, control := range report.Controls {
+		for _, evidence := range control.Evidence {
+			row := []string{
+				*report.Framework,
+				*control.Control,
+				string(control.Status),
+				string(evidence.PolicyID),
+				string(evidence.IntegrationID),
+				string(evidence.ResourceType),
+				string(evidence.ResourceID),
+				string(evidence.Status),
+				formatTimestamp(evidence.LastUpdated),
+				generatedAt,
+			}
+			if err := writer.Write(row); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+// Format a strfmt.DateTime or models.LastUpdated as RFC3339
+func formatTimestamp(value interface{}) string {
+	switch t := value.(type) {
+	case models.LastUpdated:
+		return time.Time(t).UTC().Format(time.RFC3339)
+	case strfmt.DateTime:
+		return time.Time(t).UTC().Format(time.RFC3339)
+	case *strfmt.DateTime:
+		return time.Time(*t).UTC().Format(time.RFC3339)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// For example, "panther-CIS-AWS-2020-06-01.csv"
+func reportFileName(framework string, now time.Time, format string) string {
+	return fmt.Sprintf("panther-%s-%s.%s",
+		fileNameUnsafe.ReplaceAllString(framework, "-"), now.Format(dateLayout), format)
+}
@@ -0,0 +1,276 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/csv"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/go-openapi/strfmt"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/gateway/compliance/models"
+)
+
+var (
+	reportNow     = time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	reportUpdated = models.LastUpdated(time.Date(2020, 5, 31, 8, 0, 0, 0, time.UTC))
+
+	reportStatuses = []*models.ComplianceStatus{
+		{
+			IntegrationID: "account-1",
+			LastUpdated:   reportUpdated,
+			PolicyID:      "AWS.IAM.RootMFA",
+			PolicyReports: models.PolicyReports{"CIS": {"1.10"}, "PCI": {"8.3"}},
+			ResourceID:    "root-1",
+			ResourceType:  "AWS.Account",
+			Status:        models.StatusFAIL,
+		},
+		{
+			IntegrationID: "account-2",
+			LastUpdated:   reportUpdated,
+			PolicyID:      "AWS.IAM.RootMFA",
+			PolicyReports: models.PolicyReports{"CIS": {"1.10"}, "PCI": {"8.3"}},
+			ResourceID:    "root-2",
+			ResourceType:  "AWS.Account",
+			Status:        models.StatusPASS,
+		},
+		{
+			IntegrationID: "account-1",
+			LastUpdated:   reportUpdated,
+			PolicyID:      "AWS.CloudTrail.Enabled",
+			PolicyReports: models.PolicyReports{"CIS": {"2.1", "1.2"}},
+			ResourceID:    "trail-1",
+			ResourceType:  "AWS.CloudTrail",
+			Status:        models.StatusPASS,
+		},
+		{
+			IntegrationID: "account-1",
+			LastUpdated:   reportUpdated,
+			PolicyID:      "AWS.S3.Untagged",
+			ResourceID:    "bucket-1",
+			ResourceType:  "AWS.S3.Bucket",
+			Status:        models.StatusERROR,
+		},
+	}
+)
+
+func testReport() *models.ComplianceReport {
+	controls := make(map[string]*controlBuilder)
+	for _, item := range reportStatuses {
+		addToControls(controls, item, "CIS", true)
+	}
+	return buildReportFromControls(&reportParams{Framework: "CIS"}, controls, reportNow)
+}
+
+func TestParseReportParams(t *testing.T) {
+	result, err := parseReportParams(&events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{"framework": "CIS%20AWS", "integrationId": "account-1"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &reportParams{Framework: "CIS AWS", IntegrationID: "account-1"}, result)
+
+	result, err = parseReportParams(&events.APIGatewayProxyRequest{})
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestControlLess(t *testing.T) {
+	controls := []string{"2.1", "1.10", "1.2", "1", "A.1", "1.2.1"}
+	sort.Slice(controls, func(i, j int) bool { return controlLess(controls[i], controls[j]) })
+	assert.Equal(t, []string{"1", "1.2", "1.2.1", "1.10", "2.1", "A.1"}, controls)
+}
+
+func TestBuildReport(t *testing.T) {
+	generatedAt := strfmt.DateTime(reportNow)
+	expected := &models.ComplianceReport{
+		Controls: []*models.ReportControl{
+			{
+				Accounts: []*models.ControlAccount{{IntegrationID: "account-1", Status: models.StatusPASS}},
+				Control:  aws.String("1.2"),
+				Count:    &models.StatusCount{Error: aws.Int64(0), Fail: aws.Int64(0), Pass: aws.Int64(1)},
+				Evidence: []*models.ControlEvidence{
+					{
+						IntegrationID: "account-1",
+						LastUpdated:   reportUpdated,
+						PolicyID:      "AWS.CloudTrail.Enabled",
+						ResourceID:    "trail-1",
+						ResourceType:  "AWS.CloudTrail",
+						Status:        models.StatusPASS,
+					},
+				},
+				Policies: []models.PolicyID{"AWS.CloudTrail.Enabled"},
+				Status:   models.StatusPASS,
+			},
+			{
+				Accounts: []*models.ControlAccount{
+					{IntegrationID: "account-1", Status: models.StatusFAIL},
+					{IntegrationID: "account-2", Status: models.StatusPASS},
+				},
+				Control: aws.String("1.10"),
+				Count:   &models.StatusCount{Error: aws.Int64(0), Fail: aws.Int64(1), Pass: aws.Int64(1)},
+				Evidence: []*models.ControlEvidence{
+					{
+						IntegrationID: "account-1",
+						LastUpdated:   reportUpdated,
+						PolicyID:      "AWS.IAM.RootMFA",
+						ResourceID:    "root-1",
+						ResourceType:  "AWS.Account",
+						Status:        models.StatusFAIL,
+					},
+					{
+						IntegrationID: "account-2",
+						LastUpdated:   reportUpdated,
+						PolicyID:      "AWS.IAM.RootMFA",
+						ResourceID:    "root-2",
+						ResourceType:  "AWS.Account",
+						Status:        models.StatusPASS,
+					},
+				},
+				Policies: []models.PolicyID{"AWS.IAM.RootMFA"},
+				Status:   models.StatusFAIL,
+			},
+			{
+				Accounts: []*models.ControlAccount{{IntegrationID: "account-1", Status: models.StatusPASS}},
+				Control:  aws.String("2.1"),
+				Count:    &models.StatusCount{Error: aws.Int64(0), Fail: aws.Int64(0), Pass: aws.Int64(1)},
+				Evidence: []*models.ControlEvidence{
+					{
+						IntegrationID: "account-1",
+						LastUpdated:   reportUpdated,
+						PolicyID:      "AWS.CloudTrail.Enabled",
+						ResourceID:    "trail-1",
+						ResourceType:  "AWS.CloudTrail",
+						Status:        models.StatusPASS,
+					},
+				},
+				Policies: []models.PolicyID{"AWS.CloudTrail.Enabled"},
+				Status:   models.StatusPASS,
+			},
+		},
+		Framework:   aws.String("CIS"),
+		GeneratedAt: &generatedAt,
+		Summary:     &models.StatusCount{Error: aws.Int64(0), Fail: aws.Int64(1), Pass: aws.Int64(2)},
+	}
+	assert.Equal(t, expected, testReport())
+}
+
+func TestBuildReportWithoutEvidence(t *testing.T) {
+	controls := make(map[string]*controlBuilder)
+	for _, item := range reportStatuses {
+		addToControls(controls, item, "CIS", false)
+	}
+	report := buildReportFromControls(&reportParams{Framework: "CIS"}, controls, reportNow)
+
+	require.Len(t, report.Controls, 3)
+	for _, control := range report.Controls {
+		assert.Nil(t, control.Evidence)
+	}
+	// the summary is the same as with evidence
+	assert.Equal(t, testReport().Summary, report.Summary)
+	assert.Equal(t, testReport().Controls[1].Count, report.Controls[1].Count)
+}
+
+func TestUploadReport(t *testing.T) {
+	// A real client, which signs the presigned URL offline, with the PutObject request captured
+	client := s3.New(session.Must(session.NewSession(&aws.Config{
+		Credentials: credentials.NewStaticCredentials("key", "secret", ""),
+		Region:      aws.String("us-west-2"),
+	})))
+	var uploaded *s3.PutObjectInput
+	client.Handlers.Send.Clear()
+	client.Handlers.Send.PushBack(func(r *request.Request) {
+		uploaded = r.Params.(*s3.PutObjectInput)
+		r.HTTPResponse = &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(""))}
+	})
+	defer func(original s3iface.S3API) { s3Client = original }(s3Client)
+	s3Client = client
+	Env.ReportsBucket = "reports-bucket"
+
+	result, err := uploadReport([]byte("a,b\n"), "panther-CIS-2020-06-01.csv", "text/csv", reportNow)
+	require.NoError(t, err)
+
+	require.NotNil(t, uploaded)
+	assert.Equal(t, "reports-bucket", *uploaded.Bucket)
+	assert.True(t, strings.HasPrefix(*uploaded.Key, "reports/"))
+	assert.True(t, strings.HasSuffix(*uploaded.Key, "/panther-CIS-2020-06-01.csv"))
+	assert.Equal(t, `attachment; filename="panther-CIS-2020-06-01.csv"`, *uploaded.ContentDisposition)
+
+	assert.Equal(t, "text/csv", *result.ContentType)
+	assert.Equal(t, "panther-CIS-2020-06-01.csv", *result.FileName)
+	assert.Equal(t, strfmt.DateTime(reportNow.Add(reportURLExpiration)), *result.ExpiresAt)
+	assert.Contains(t, *result.URL, "reports-bucket")
+	assert.Contains(t, *result.URL, "X-Amz-Signature=")
+	assert.Contains(t, *result.URL, "X-Amz-Expires=900")
+}
+
+func TestReportCSV(t *testing.T) {
+	data, err := renderReport(testReport(), formatCSV)
+	require.NoError(t, err)
+
+	rows, err := csv.NewReader(strings.NewReader(string(data))).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 5) // header + 4 evidence rows
+	assert.Equal(t, csvHeader, rows[0])
+	assert.Equal(t, []string{
+		"CIS", "1.10", "FAIL", "AWS.IAM.RootMFA", "account-1", "AWS.Account", "root-1", "FAIL",
+		"2020-05-31T08:00:00Z", "2020-06-01T12:00:00Z",
+	}, rows[2])
+}
+
+func TestReportJSON(t *testing.T) {
+	data, err := renderReport(testReport(), formatJSON)
+	require.NoError(t, err)
+
+	var result models.ComplianceReport
+	require.NoError(t, jsoniter.Unmarshal(data, &result))
+	assert.Equal(t, "CIS", *result.Framework)
+	assert.Len(t, result.Controls, 3)
+}
+
+func TestReportHTML(t *testing.T) {
+	data, err := renderReport(testReport(), formatHTML)
+	require.NoError(t, err)
+
+	html := string(data)
+	assert.Contains(t, html, "<h1>CIS Compliance Report</h1>")
+	assert.Contains(t, html, "Generated at 2020-06-01T12:00:00Z")
+	assert.Contains(t, html, "Controls: 2 passing, 1 failing, 0 with errors")
+	assert.Contains(t, html, `Control 1.10: <span class="FAIL">FAIL</span>`)
+	assert.Contains(t, html, "<td>root-2</td>")
+}
+
+func TestReportFileName(t *testing.T) {
+	assert.Equal(t, "panther-CIS-AWS-v1.2-2020-06-01.csv", reportFileName("CIS AWS v1.2", reportNow, formatCSV))
+	assert.Equal(t, "panther-PCI-2020-06-01.html", reportFileName("PCI", reportNow, formatHTML))
+}
@@ -0,0 +1,128 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/gateway/compliance/models"
+)
+
+// Copy the overall pass/fail status of each resource to the resources table.
+//
+// The resources-api lists resources by compliance status from an index on this attribute,
+// so it has to be updated every time the status entries for a resource change.
+func syncResourceStatus(resourceIDs []models.ResourceID) error {
+	synced := make(map[models.ResourceID]bool, len(resourceIDs))
+	for _, resourceID := range resourceIDs {
+		if synced[resourceID] {
+			continue
+		}
+		synced[resourceID] = true
+
+		status, err := resourceStatus(resourceID)
+		if err != nil {
+			return err
+		}
+		if err := updateResourceStatus(resourceID, status); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Read every status entry for a resource and summarize them the same way as DescribeOrg
+func resourceStatus(resourceID models.ResourceID) (models.Status, error) {
+	keyCondition := expression.Key("resourceId").Equal(expression.Value(resourceID))
+	projection := expression.NamesList(expression.Name("status"), expression.Name("suppressed"))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).WithProjection(projection).Build()
+	if err != nil {
+		zap.L().Error("expression.Build failed", zap.Error(err))
+		return "", err
+	}
+
+	input := &dynamodb.QueryInput{
+		ConsistentRead:            aws.Bool(true),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 &Env.ComplianceTable,
+	}
+
+	count := NewStatusCount()
+	for {
+		response, err := dynamoClient.Query(input)
+		if err != nil {
+			zap.L().Error("dynamoClient.Query failed", zap.Error(err))
+			return "", err
+		}
+
+		var entries []*models.ComplianceStatus
+		if err := dynamodbattribute.UnmarshalListOfMaps(response.Items, &entries); err != nil {
+			zap.L().Error("dynamodbattribute.UnmarshalListOfMaps failed", zap.Error(err))
+			return "", err
+		}
+		for _, entry := range entries {
+			if !entry.Suppressed {
+				updateStatusCount(count, entry.Status)
+			}
+		}
+
+		if response.LastEvaluatedKey == nil {
+			return countToStatus(count), nil
+		}
+		input.ExclusiveStartKey = response.LastEvaluatedKey
+	}
+}
+
+func updateResourceStatus(resourceID models.ResourceID, status models.Status) error {
+	expr, err := expression.NewBuilder().
+		WithCondition(expression.AttributeExists(expression.Name("id"))).
+		WithUpdate(expression.Set(expression.Name("complianceStatus"), expression.Value(status))).
+		Build()
+	if err != nil {
+		zap.L().Error("expression.Build failed", zap.Error(err))
+		return err
+	}
+
+	_, err = dynamoClient.UpdateItem(&dynamodb.UpdateItemInput{
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		Key:                       map[string]*dynamodb.AttributeValue{"id": {S: aws.String(string(resourceID))}},
+		TableName:                 &Env.ResourcesTable,
+		UpdateExpression:          expr.Update(),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			// The resource has expired from the resources table, there's nothing to update
+			zap.L().Debug("resource no longer exists", zap.String("resourceId", string(resourceID)))
+			return nil
+		}
+		zap.L().Error("dynamoClient.UpdateItem failed", zap.Error(err))
+		return err
+	}
+	return nil
+}
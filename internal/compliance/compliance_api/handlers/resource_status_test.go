@@ -0,0 +1,98 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/gateway/compliance/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func testStatusEntry(status string, suppressed bool) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		"status":     {S: aws.String(status)},
+		"suppressed": {BOOL: aws.Bool(suppressed)},
+	}
+}
+
+func TestSyncResourceStatus(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	dynamoClient = mockClient
+	Env.ComplianceTable = "test-compliance"
+	Env.ResourcesTable = "test-resources"
+
+	queryFor := func(resourceID string) interface{} {
+		return mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+			return *input.ExpressionAttributeValues[":0"].S == resourceID
+		})
+	}
+	updateFor := func(resourceID, status string) interface{} {
+		return mock.MatchedBy(func(input *dynamodb.UpdateItemInput) bool {
+			return *input.TableName == "test-resources" && *input.Key["id"].S == resourceID &&
+				*input.ExpressionAttributeValues[":0"].S == status
+		})
+	}
+
+	// The suppressed failure is ignored, so the bucket is passing
+	mockClient.On("Query", queryFor("bucket")).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{
+			testStatusEntry("PASS", false), testStatusEntry("FAIL", true)},
+	}, nil).Once()
+	mockClient.On("UpdateItem", updateFor("bucket", "PASS")).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+
+	// Errors take precedence over failures, across pages
+	mockClient.On("Query", queryFor("key")).Return(&dynamodb.QueryOutput{
+		Items:            []map[string]*dynamodb.AttributeValue{testStatusEntry("FAIL", false)},
+		LastEvaluatedKey: map[string]*dynamodb.AttributeValue{"resourceId": {S: aws.String("key")}},
+	}, nil).Once()
+	mockClient.On("Query", queryFor("key")).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{testStatusEntry("ERROR", false)},
+	}, nil).Once()
+	mockClient.On("UpdateItem", updateFor("key", "ERROR")).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+
+	// A resource which has expired from the resources table is skipped
+	mockClient.On("Query", queryFor("role")).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{testStatusEntry("FAIL", false)},
+	}, nil).Once()
+	mockClient.On("UpdateItem", updateFor("role", "FAIL")).Return(
+		(*dynamodb.UpdateItemOutput)(nil),
+		awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "", nil)).Once()
+
+	// Duplicate resource IDs are only synced once
+	require.NoError(t, syncResourceStatus([]models.ResourceID{"bucket", "key", "bucket", "role"}))
+	mockClient.AssertExpectations(t)
+}
+
+func TestSyncResourceStatusError(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	dynamoClient = mockClient
+
+	mockClient.On("Query", mock.Anything).Return(
+		(*dynamodb.QueryOutput)(nil), awserr.New(dynamodb.ErrCodeInternalServerError, "", nil))
+	assert.Error(t, syncResourceStatus([]models.ResourceID{"bucket"}))
+	mockClient.AssertNotCalled(t, "UpdateItem", mock.Anything)
+}
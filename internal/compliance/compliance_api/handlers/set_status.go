@@ -52,14 +52,18 @@ func SetStatus(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyRe
 	now := time.Now()
 	expiresAt := now.Add(statusLifetime).Unix()
 	writeRequests := make([]*dynamodb.WriteRequest, len(input.Entries))
+	resourceIDs := make([]models.ResourceID, len(input.Entries))
 	for i, entry := range input.Entries {
+		resourceIDs[i] = entry.ResourceID
 		status := &models.ComplianceStatus{
 			ErrorMessage:   entry.ErrorMessage,
 			ExpiresAt:      models.ExpiresAt(expiresAt),
 			IntegrationID:  entry.IntegrationID,
 			LastUpdated:    models.LastUpdated(now),
 			PolicyID:       entry.PolicyID,
+			PolicyReports:  entry.PolicyReports,
 			PolicySeverity: entry.PolicySeverity,
+			PolicyTags:     entry.PolicyTags,
 			ResourceID:     entry.ResourceID,
 			ResourceType:   entry.ResourceType,
 			Status:         entry.Status,
@@ -84,6 +88,10 @@ func SetStatus(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyRe
 		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
 	}
 
+	if err := syncResourceStatus(resourceIDs); err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
 	return &events.APIGatewayProxyResponse{StatusCode: http.StatusCreated}
 }
 
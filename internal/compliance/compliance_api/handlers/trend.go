@@ -0,0 +1,298 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/go-openapi/strfmt"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/gateway/compliance/models"
+	"github.com/panther-labs/panther/pkg/awsbatch/dynamodbbatch"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+const (
+	dateLayout = "2006-01-02"
+
+	// Default time range when the trend start date is not specified
+	defaultTrendDays = 90
+)
+
+// The ways in which compliance snapshots are grouped - values should match those in api.yml
+const (
+	dimensionOrg          = "org"
+	dimensionPolicy       = "policy"
+	dimensionSeverity     = "severity"
+	dimensionResourceType = "resourceType"
+	dimensionIntegration  = "integration"
+	dimensionTag          = "tag"
+)
+
+var validDimensions = map[string]bool{
+	dimensionOrg:          true,
+	dimensionPolicy:       true,
+	dimensionSeverity:     true,
+	dimensionResourceType: true,
+	dimensionIntegration:  true,
+	dimensionTag:          true,
+}
+
+// A single point in a compliance time series, stored in the trend table
+type trendItem struct {
+	Key       string              `json:"key"`  // "dimension#value"
+	Date      string              `json:"date"` // YYYY-MM-DD
+	Dimension string              `json:"dimension"`
+	Value     string              `json:"value"`
+	Count     *models.StatusCount `json:"count"`
+	ExpiresAt int64               `json:"expiresAt"`
+}
+
+type trendKey struct {
+	dimension, value string
+}
+
+func (k trendKey) String() string {
+	return k.dimension + "#" + k.value
+}
+
+// Pass/fail counts for each resource within a single slice of the organization
+type trendSlice map[models.ResourceID]*models.StatusCount
+
+// TakeComplianceSnapshot stores today's resource pass/fail counts for every trend dimension.
+//
+// It runs once daily on a schedule, running it again on the same day replaces that day's snapshot.
+func TakeComplianceSnapshot(_ *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	input, err := buildGetOrgOverviewQuery() // every status entry which isn't suppressed
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	slices := make(map[trendKey]trendSlice, 100)
+	err = scanPages(input, func(item *models.ComplianceStatus) error {
+		addToSlices(slices, item)
+		return nil
+	})
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	now := time.Now().UTC()
+	writes, err := buildTrendWrites(slices, now)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	if len(writes) > 0 {
+		batchInput := &dynamodb.BatchWriteItemInput{
+			RequestItems: map[string][]*dynamodb.WriteRequest{Env.TrendTable: writes},
+		}
+		if err := dynamodbbatch.BatchWriteItem(dynamoClient, maxWriteBackoff, batchInput); err != nil {
+			zap.L().Error("dynamodbbatch.BatchWriteItem failed", zap.Error(err))
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		}
+	}
+
+	date := strfmt.Date(now)
+	return gatewayapi.MarshalResponse(
+		&models.ComplianceSnapshot{Date: &date, Series: aws.Int64(int64(len(writes)))}, http.StatusOK)
+}
+
+// Add a status entry to every slice of the organization it belongs to
+func addToSlices(slices map[trendKey]trendSlice, item *models.ComplianceStatus) {
+	keys := []trendKey{
+		{dimension: dimensionOrg},
+		{dimension: dimensionPolicy, value: string(item.PolicyID)},
+		{dimension: dimensionSeverity, value: string(item.PolicySeverity)},
+		{dimension: dimensionResourceType, value: string(item.ResourceType)},
+		{dimension: dimensionIntegration, value: string(item.IntegrationID)},
+	}
+	for _, tag := range item.PolicyTags {
+		keys = append(keys, trendKey{dimension: dimensionTag, value: tag})
+	}
+
+	for _, key := range keys {
+		slice, ok := slices[key]
+		if !ok {
+			slice = make(trendSlice)
+			slices[key] = slice
+		}
+
+		count, ok := slice[item.ResourceID]
+		if !ok {
+			count = NewStatusCount()
+			slice[item.ResourceID] = count
+		}
+		updateStatusCount(count, item.Status)
+	}
+}
+
+// Count the passing/failing resources in each slice and build the trend table writes
+func buildTrendWrites(slices map[trendKey]trendSlice, now time.Time) ([]*dynamodb.WriteRequest, error) {
+	date := now.Format(dateLayout)
+	expiresAt := now.Add(time.Duration(Env.TrendRetentionDays) * 24 * time.Hour).Unix()
+
+	writes := make([]*dynamodb.WriteRequest, 0, len(slices))
+	for key, slice := range slices {
+		count := NewStatusCount()
+		for _, resourceCount := range slice {
+			updateStatusCount(count, countToStatus(resourceCount))
+		}
+
+		marshalled, err := dynamodbattribute.MarshalMap(&trendItem{
+			Key:       key.String(),
+			Date:      date,
+			Dimension: key.dimension,
+			Value:     key.value,
+			Count:     count,
+			ExpiresAt: expiresAt,
+		})
+		if err != nil {
+			zap.L().Error("dynamodbattribute.MarshalMap failed", zap.Error(err))
+			return nil, err
+		}
+		writes = append(writes, &dynamodb.WriteRequest{PutRequest: &dynamodb.PutRequest{Item: marshalled}})
+	}
+	return writes, nil
+}
+
+type getComplianceTrendParams struct {
+	Key       trendKey
+	StartDate string
+	EndDate   string
+}
+
+// GetComplianceTrend returns the daily resource pass/fail counts for one slice of the organization.
+func GetComplianceTrend(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	params, err := parseGetComplianceTrend(request, time.Now().UTC())
+	if err != nil {
+		return badRequest(err)
+	}
+
+	input, err := buildTrendQuery(params)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	result := &models.ComplianceTrend{
+		Dimension: aws.String(params.Key.dimension),
+		Points:    make([]*models.TrendPoint, 0, defaultTrendDays),
+		Value:     params.Key.value,
+	}
+	var unmarshalErr error
+	err = dynamoClient.QueryPages(input, func(page *dynamodb.QueryOutput, lastPage bool) bool {
+		var items []*trendItem
+		if unmarshalErr = dynamodbattribute.UnmarshalListOfMaps(page.Items, &items); unmarshalErr != nil {
+			return false // stop paging
+		}
+		for _, item := range items {
+			date, parseErr := time.Parse(dateLayout, item.Date)
+			if parseErr != nil {
+				zap.L().Warn("skipping trend item with invalid date", zap.String("date", item.Date))
+				continue
+			}
+			point := strfmt.Date(date)
+			result.Points = append(result.Points, &models.TrendPoint{Count: item.Count, Date: &point})
+		}
+		return true
+	})
+
+	if unmarshalErr != nil {
+		zap.L().Error("dynamodbattribute.UnmarshalListOfMaps failed", zap.Error(unmarshalErr))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	if err != nil {
+		zap.L().Error("dynamoClient.QueryPages failed", zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	return gatewayapi.MarshalResponse(result, http.StatusOK)
+}
+
+func parseGetComplianceTrend(request *events.APIGatewayProxyRequest, now time.Time) (*getComplianceTrendParams, error) {
+	dimension := request.QueryStringParameters["dimension"]
+	if dimension == "" {
+		dimension = dimensionOrg
+	}
+	if !validDimensions[dimension] {
+		return nil, fmt.Errorf("invalid dimension: %s", dimension)
+	}
+
+	value, err := url.QueryUnescape(request.QueryStringParameters["value"])
+	if err != nil {
+		return nil, errors.New("invalid value: " + err.Error())
+	}
+	if dimension == dimensionOrg {
+		value = ""
+	} else if value == "" {
+		return nil, fmt.Errorf("value is required for dimension %s", dimension)
+	}
+
+	result := getComplianceTrendParams{
+		Key:       trendKey{dimension: dimension, value: value},
+		StartDate: now.AddDate(0, 0, 1-defaultTrendDays).Format(dateLayout),
+		EndDate:   now.Format(dateLayout),
+	}
+
+	if start := request.QueryStringParameters["startDate"]; start != "" {
+		if _, err := time.Parse(dateLayout, start); err != nil {
+			return nil, errors.New("invalid startDate: " + err.Error())
+		}
+		result.StartDate = start
+	}
+	if end := request.QueryStringParameters["endDate"]; end != "" {
+		if _, err := time.Parse(dateLayout, end); err != nil {
+			return nil, errors.New("invalid endDate: " + err.Error())
+		}
+		result.EndDate = end
+	}
+	if result.StartDate > result.EndDate {
+		return nil, errors.New("startDate cannot be after endDate")
+	}
+
+	return &result, nil
+}
+
+func buildTrendQuery(params *getComplianceTrendParams) (*dynamodb.QueryInput, error) {
+	keyCondition := expression.Key("key").Equal(expression.Value(params.Key.String())).
+		And(expression.Key("date").Between(expression.Value(params.StartDate), expression.Value(params.EndDate)))
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+	if err != nil {
+		zap.L().Error("expression.Build failed", zap.Error(err))
+		return nil, err
+	}
+
+	return &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 &Env.TrendTable,
+	}, nil
+}
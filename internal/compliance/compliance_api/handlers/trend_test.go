@@ -0,0 +1,140 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/gateway/compliance/models"
+)
+
+var trendNow = time.Date(2020, 5, 20, 13, 0, 0, 0, time.UTC)
+
+func TestParseGetComplianceTrendDefaults(t *testing.T) {
+	result, err := parseGetComplianceTrend(&events.APIGatewayProxyRequest{}, trendNow)
+	require.NoError(t, err)
+	expected := &getComplianceTrendParams{
+		Key:       trendKey{dimension: dimensionOrg},
+		StartDate: "2020-02-21",
+		EndDate:   "2020-05-20",
+	}
+	assert.Equal(t, expected, result)
+}
+
+func TestParseGetComplianceTrend(t *testing.T) {
+	result, err := parseGetComplianceTrend(&events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{
+			"dimension": "resourceType",
+			"value":     "AWS.S3.Bucket",
+			"startDate": "2020-05-01",
+			"endDate":   "2020-05-10",
+		},
+	}, trendNow)
+	require.NoError(t, err)
+	expected := &getComplianceTrendParams{
+		Key:       trendKey{dimension: dimensionResourceType, value: "AWS.S3.Bucket"},
+		StartDate: "2020-05-01",
+		EndDate:   "2020-05-10",
+	}
+	assert.Equal(t, expected, result)
+	assert.Equal(t, "resourceType#AWS.S3.Bucket", result.Key.String())
+}
+
+func TestParseGetComplianceTrendOrgIgnoresValue(t *testing.T) {
+	result, err := parseGetComplianceTrend(&events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{"value": "ignored"},
+	}, trendNow)
+	require.NoError(t, err)
+	assert.Equal(t, "org#", result.Key.String())
+}
+
+func TestParseGetComplianceTrendErrors(t *testing.T) {
+	for _, params := range []map[string]string{
+		{"dimension": "account"},
+		{"dimension": "policy"},
+		{"startDate": "yesterday"},
+		{"endDate": "2020-13-01"},
+		{"startDate": "2020-05-02", "endDate": "2020-05-01"},
+	} {
+		result, err := parseGetComplianceTrend(&events.APIGatewayProxyRequest{QueryStringParameters: params}, trendNow)
+		assert.Error(t, err, params)
+		assert.Nil(t, result)
+	}
+}
+
+func TestAddToSlices(t *testing.T) {
+	slices := make(map[trendKey]trendSlice)
+	for _, item := range []*models.ComplianceStatus{
+		{
+			IntegrationID:  "integration",
+			PolicyID:       "policy-a",
+			PolicySeverity: models.PolicySeverityHIGH,
+			PolicyTags:     models.PolicyTags{"CIS"},
+			ResourceID:     "bucket-1",
+			ResourceType:   "AWS.S3.Bucket",
+			Status:         models.StatusFAIL,
+		},
+		{
+			IntegrationID:  "integration",
+			PolicyID:       "policy-b",
+			PolicySeverity: models.PolicySeverityLOW,
+			ResourceID:     "bucket-1",
+			ResourceType:   "AWS.S3.Bucket",
+			Status:         models.StatusPASS,
+		},
+		{
+			IntegrationID:  "integration",
+			PolicyID:       "policy-a",
+			PolicySeverity: models.PolicySeverityHIGH,
+			PolicyTags:     models.PolicyTags{"CIS"},
+			ResourceID:     "bucket-2",
+			ResourceType:   "AWS.S3.Bucket",
+			Status:         models.StatusPASS,
+		},
+	} {
+		addToSlices(slices, item)
+	}
+
+	// org, 2 policies, 2 severities, 1 resource type, 1 integration, 1 tag
+	assert.Len(t, slices, 8)
+
+	writes, err := buildTrendWrites(slices, trendNow)
+	require.NoError(t, err)
+	assert.Len(t, writes, 8)
+
+	// bucket-1 fails one policy, bucket-2 passes everything
+	org := slices[trendKey{dimension: dimensionOrg}]
+	require.Len(t, org, 2)
+	assert.Equal(t, models.StatusFAIL, countToStatus(org["bucket-1"]))
+	assert.Equal(t, models.StatusPASS, countToStatus(org["bucket-2"]))
+
+	// Only policy-b applies to bucket-1 in the LOW severity slice
+	low := slices[trendKey{dimension: dimensionSeverity, value: "LOW"}]
+	require.Len(t, low, 1)
+	assert.Equal(t, &models.StatusCount{Error: aws.Int64(0), Fail: aws.Int64(0), Pass: aws.Int64(1)}, low["bucket-1"])
+
+	tag := slices[trendKey{dimension: dimensionTag, value: "CIS"}]
+	assert.Len(t, tag, 2)
+}
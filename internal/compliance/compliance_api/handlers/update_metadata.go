@@ -33,14 +33,14 @@ import (
 	"github.com/panther-labs/panther/pkg/awsbatch/dynamodbbatch"
 )
 
-// UpdateMetadata updates status entries for a given policy with a new severity / suppression set.
+// UpdateMetadata updates status entries for a given policy with a new severity / suppression set / tags / reports.
 func UpdateMetadata(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
 	input, err := parseUpdateMetadata(request)
 	if err != nil {
 		return badRequest(err)
 	}
 
-	writes, errResponse := itemsToUpdate(input)
+	writes, suppressionChanges, errResponse := itemsToUpdate(input)
 	if errResponse != nil {
 		return errResponse
 	}
@@ -61,6 +61,10 @@ func UpdateMetadata(request *events.APIGatewayProxyRequest) *events.APIGatewayPr
 		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
 	}
 
+	if err := syncResourceStatus(suppressionChanges); err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
 	return &events.APIGatewayProxyResponse{StatusCode: http.StatusOK}
 }
 
@@ -73,15 +77,19 @@ func parseUpdateMetadata(request *events.APIGatewayProxyRequest) (*models.Update
 	return &result, result.Validate(nil)
 }
 
-func itemsToUpdate(input *models.UpdateMetadata) ([]*dynamodb.WriteRequest, *events.APIGatewayProxyResponse) {
+// Returns the updated entries and the resources whose suppression changed (which can change their status)
+func itemsToUpdate(
+	input *models.UpdateMetadata) ([]*dynamodb.WriteRequest, []models.ResourceID, *events.APIGatewayProxyResponse) {
+
 	query, err := buildDescribePolicyQuery(input.PolicyID)
 	if err != nil {
-		return nil, &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		return nil, nil, &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
 	}
 
 	zap.L().Info("querying items to update",
 		zap.String("policyId", string(input.PolicyID)))
 	var writes []*dynamodb.WriteRequest
+	var suppressionChanges []models.ResourceID
 	err = queryPages(query, func(item *models.ComplianceStatus) error {
 		ignored, patternErr := isIgnored(string(item.ResourceID), input.Suppressions)
 		if patternErr != nil {
@@ -89,8 +97,15 @@ func itemsToUpdate(input *models.UpdateMetadata) ([]*dynamodb.WriteRequest, *eve
 		}
 
 		// This status entry has changed - we need to rewrite it
-		if bool(item.Suppressed) != ignored || item.PolicySeverity != input.Severity {
+		if bool(item.Suppressed) != ignored || item.PolicySeverity != input.Severity ||
+			!tagsEqual(item.PolicyTags, input.Tags) || !reportsEqual(item.PolicyReports, input.Reports) {
+
+			if bool(item.Suppressed) != ignored {
+				suppressionChanges = append(suppressionChanges, item.ResourceID)
+			}
+			item.PolicyReports = input.Reports
 			item.PolicySeverity = input.Severity
+			item.PolicyTags = input.Tags
 			item.Suppressed = models.Suppressed(ignored)
 
 			marshalled, err := dynamodbattribute.MarshalMap(item)
@@ -108,10 +123,10 @@ func itemsToUpdate(input *models.UpdateMetadata) ([]*dynamodb.WriteRequest, *eve
 
 	if err != nil {
 		if err == path.ErrBadPattern {
-			return nil, badRequest(errors.New("invalid suppression pattern: " + err.Error()))
+			return nil, nil, badRequest(errors.New("invalid suppression pattern: " + err.Error()))
 		}
-		return nil, &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		return nil, nil, &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
 	}
 
-	return writes, nil
+	return writes, suppressionChanges, nil
 }
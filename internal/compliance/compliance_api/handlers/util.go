@@ -217,3 +217,30 @@ func isIgnored(resourceID string, ignoreSet []string) (bool, error) {
 
 	return false, nil
 }
+
+// Returns true if the two tag lists have the same elements in the same order
+func tagsEqual(first, second models.PolicyTags) bool {
+	if len(first) != len(second) {
+		return false
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns true if the two report mappings have the same controls for every framework
+func reportsEqual(first, second models.PolicyReports) bool {
+	if len(first) != len(second) {
+		return false
+	}
+	for framework, controls := range first {
+		other, ok := second[framework]
+		if !ok || !tagsEqual(controls, other) {
+			return false
+		}
+	}
+	return true
+}
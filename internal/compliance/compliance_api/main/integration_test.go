@@ -19,7 +19,9 @@ package main
  */
 
 import (
+	"encoding/csv"
 	"fmt"
+	"net/http"
 	"os"
 	"sort"
 	"strings"
@@ -79,7 +81,9 @@ var (
 		},
 		{
 			PolicyID:       models.PolicyID("AWS-S3-BlockPublicAccess"),
+			PolicyReports:  models.PolicyReports{"CIS": {"2.1"}},
 			PolicySeverity: models.PolicySeverityCRITICAL,
+			PolicyTags:     models.PolicyTags{"CIS"},
 			ResourceID:     models.ResourceID("arn:aws:s3:::my-bucket"),
 			ResourceType:   models.ResourceType("AWS.S3.Bucket"),
 			Status:         models.StatusPASS,
@@ -124,6 +128,7 @@ func TestIntegrationAPI(t *testing.T) {
 
 	// Reset Dynamo table and build API client
 	require.NoError(t, testutils.ClearDynamoTable(awsSession, "panther-compliance"))
+	require.NoError(t, testutils.ClearDynamoTable(awsSession, "panther-compliance-trend"))
 	require.NotEmpty(t, endpoint)
 	apiClient = client.NewHTTPClientWithConfig(nil, client.DefaultTransportConfig().
 		WithBasePath("/v1").WithHost(endpoint))
@@ -166,6 +171,17 @@ func TestIntegrationAPI(t *testing.T) {
 		t.Run("GetOrgOverviewCustomLimit", getOrgOverviewCustomLimit)
 	})
 	t.Run("DescribePolicyPageAndFilter", describePolicyPageAndFilter)
+	t.Run("Trend", func(t *testing.T) {
+		t.Run("GetTrendEmpty", getTrendEmpty)
+		t.Run("TakeSnapshot", takeSnapshot)
+		t.Run("GetTrend", getTrend)
+	})
+
+	t.Run("Report", func(t *testing.T) {
+		t.Run("ListReportFrameworks", listReportFrameworks)
+		t.Run("GetReport", getReport)
+		t.Run("ExportReport", exportReport)
+	})
 
 	t.Run("Update", update)
 	t.Run("Delete", deleteBatch)
@@ -743,6 +759,131 @@ func getOrgOverviewCustomLimit(t *testing.T) {
 	assert.Equal(t, models.ResourceID("arn:aws:s3:::my-bucket"), resources[0].ID)
 }
 
+func getTrendEmpty(t *testing.T) {
+	result, err := apiClient.Operations.GetComplianceTrend(&operations.GetComplianceTrendParams{
+		HTTPClient: httpClient,
+	})
+	require.NoError(t, err)
+
+	expected := &models.ComplianceTrend{
+		Dimension: aws.String("org"),
+		Points:    []*models.TrendPoint{},
+	}
+	assert.Equal(t, expected, result.Payload)
+}
+
+func takeSnapshot(t *testing.T) {
+	result, err := apiClient.Operations.TakeComplianceSnapshot(&operations.TakeComplianceSnapshotParams{
+		HTTPClient: httpClient,
+	})
+	require.NoError(t, err)
+
+	// org, 4 policies, 3 severities, 2 resource types, 1 integration, 1 tag (suppressed entries are skipped)
+	assert.Equal(t, int64(12), *result.Payload.Series)
+	require.NotNil(t, result.Payload.Date)
+}
+
+func getTrend(t *testing.T) {
+	t.Run("Org", func(t *testing.T) {
+		result, err := apiClient.Operations.GetComplianceTrend(&operations.GetComplianceTrendParams{
+			HTTPClient: httpClient,
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Payload.Points, 1)
+
+		// my-bucket: ERROR, my-other-bucket: FAIL, my-trail: PASS
+		expected := &models.StatusCount{Error: aws.Int64(1), Fail: aws.Int64(1), Pass: aws.Int64(1)}
+		assert.Equal(t, expected, result.Payload.Points[0].Count)
+	})
+
+	t.Run("ResourceType", func(t *testing.T) {
+		result, err := apiClient.Operations.GetComplianceTrend(&operations.GetComplianceTrendParams{
+			Dimension:  aws.String("resourceType"),
+			Value:      aws.String("AWS.S3.Bucket"),
+			HTTPClient: httpClient,
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Payload.Points, 1)
+
+		expected := &models.StatusCount{Error: aws.Int64(1), Fail: aws.Int64(1), Pass: aws.Int64(0)}
+		assert.Equal(t, expected, result.Payload.Points[0].Count)
+	})
+
+	t.Run("Tag", func(t *testing.T) {
+		result, err := apiClient.Operations.GetComplianceTrend(&operations.GetComplianceTrendParams{
+			Dimension:  aws.String("tag"),
+			Value:      aws.String("CIS"),
+			HTTPClient: httpClient,
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Payload.Points, 1)
+
+		expected := &models.StatusCount{Error: aws.Int64(0), Fail: aws.Int64(0), Pass: aws.Int64(1)}
+		assert.Equal(t, expected, result.Payload.Points[0].Count)
+	})
+
+	t.Run("MissingValue", func(t *testing.T) {
+		result, err := apiClient.Operations.GetComplianceTrend(&operations.GetComplianceTrendParams{
+			Dimension:  aws.String("policy"),
+			HTTPClient: httpClient,
+		})
+		assert.Nil(t, result)
+		require.Error(t, err)
+		assert.IsType(t, &operations.GetComplianceTrendBadRequest{}, err)
+	})
+}
+
+func listReportFrameworks(t *testing.T) {
+	t.Parallel()
+	result, err := apiClient.Operations.ListReportFrameworks(&operations.ListReportFrameworksParams{
+		HTTPClient: httpClient,
+	})
+	require.NoError(t, err)
+
+	expected := &models.ReportFrameworks{
+		Frameworks: []*models.ReportFramework{{Controls: aws.Int64(1), Name: aws.String("CIS")}},
+	}
+	assert.Equal(t, expected, result.Payload)
+}
+
+func getReport(t *testing.T) {
+	t.Parallel()
+	result, err := apiClient.Operations.GetComplianceReport(&operations.GetComplianceReportParams{
+		Framework:  "CIS",
+		HTTPClient: httpClient,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "CIS", *result.Payload.Framework)
+	require.Len(t, result.Payload.Controls, 1)
+	control := result.Payload.Controls[0]
+	assert.Equal(t, "2.1", *control.Control)
+	assert.Equal(t, models.StatusPASS, control.Status)
+	assert.Equal(t, []models.PolicyID{"AWS-S3-BlockPublicAccess"}, control.Policies)
+	assert.Equal(t, []*models.ControlAccount{{IntegrationID: integrationID, Status: models.StatusPASS}}, control.Accounts)
+	// evidence is only included in the exported report
+	assert.Empty(t, control.Evidence)
+}
+
+func exportReport(t *testing.T) {
+	t.Parallel()
+	result, err := apiClient.Operations.ExportComplianceReport(&operations.ExportComplianceReportParams{
+		Format:     aws.String("csv"),
+		Framework:  "CIS",
+		HTTPClient: httpClient,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "text/csv", *result.Payload.ContentType)
+	response, err := http.Get(*result.Payload.URL)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	rows, err := csv.NewReader(response.Body).ReadAll()
+	require.NoError(t, err)
+	assert.Len(t, rows, 2) // header + 1 evidence row
+}
+
 func update(t *testing.T) {
 	result, err := apiClient.Operations.UpdateMetadata(&operations.UpdateMetadataParams{
 		Body: &models.UpdateMetadata{
@@ -31,11 +31,16 @@ var methodHandlers = map[string]gatewayapi.RequestHandler{
 	"GET /describe-policy":   handlers.DescribePolicy,
 	"GET /describe-resource": handlers.DescribeResource,
 	"GET /org-overview":      handlers.GetOrgOverview,
+	"GET /report":            handlers.GetComplianceReport,
+	"GET /report/export":     handlers.ExportComplianceReport,
+	"GET /report/frameworks": handlers.ListReportFrameworks,
 	"GET /status":            handlers.GetStatus,
+	"GET /trend":             handlers.GetComplianceTrend,
 
-	"POST /delete": handlers.DeleteStatus,
-	"POST /status": handlers.SetStatus,
-	"POST /update": handlers.UpdateMetadata,
+	"POST /delete":         handlers.DeleteStatus,
+	"POST /status":         handlers.SetStatus,
+	"POST /trend/snapshot": handlers.TakeComplianceSnapshot,
+	"POST /update":         handlers.UpdateMetadata,
 }
 
 func main() {
@@ -145,15 +145,16 @@ func (r *batchResults) analyzeUpdatedPolicy(policy *analysismodels.Policy) error
 			ResourceTypes: policy.ResourceTypes,
 			Severity:      policy.Severity,
 			Suppressions:  policy.Suppressions,
+			Exceptions:    policy.Exceptions,
 			VersionID:     policy.VersionID,
 		},
 	}
 
 	// Analyze each page of resources
 	// TODO - check for duplicates here
-	var totalPages int64 = 1
-	for pageno := int64(1); pageno <= totalPages; pageno++ {
-		resources, pageCount, err := getResources(policy.ResourceTypes, pageno)
+	var pageToken *string
+	for {
+		resources, nextPageToken, err := getResources(policy.ResourceTypes, pageToken)
 		if err != nil {
 			return err
 		}
@@ -161,10 +162,12 @@ func (r *batchResults) analyzeUpdatedPolicy(policy *analysismodels.Policy) error
 		if err := r.analyze(resources, policies); err != nil {
 			return err
 		}
-		totalPages = pageCount
-	}
 
-	return nil
+		if nextPageToken == "" {
+			return nil
+		}
+		pageToken = &nextPageToken
+	}
 }
 
 // Analyze each org in turn and report status entries and alert notifications across the entire batch
@@ -336,7 +339,9 @@ func buildStatus(
 
 	return &compliancemodels.SetStatus{
 		PolicyID:       compliancemodels.PolicyID(policy.ID),
+		PolicyReports:  compliancemodels.PolicyReports(policy.Reports),
 		PolicySeverity: compliancemodels.PolicySeverity(policy.Severity),
+		PolicyTags:     compliancemodels.PolicyTags(policy.Tags),
 		ResourceID:     compliancemodels.ResourceID(resource.ID),
 		ResourceType:   compliancemodels.ResourceType(resource.Type),
 		Suppressed:     compliancemodels.Suppressed(isSuppressed(string(resource.ID), policy)),
@@ -346,29 +351,21 @@ func buildStatus(
 	}
 }
 
-// Returns true if the resource is suppressed by the given policy
+// Returns true if the resource is suppressed by the given policy, or by one of its exceptions which
+// has not expired or been revoked. Other exceptions are ignored, so those resources fail again.
 func isSuppressed(resourceID string, policy *analysismodels.EnabledPolicy) bool {
 	for _, pattern := range policy.Suppressions {
-		// Convert the glob pattern (e.g "prod.*.bucket") to regex ("prod\..*\.bucket")
-
-		// First, escape any regex special characters
-		escaped := regexp.QuoteMeta(pattern)
+		if matchesPattern(resourceID, pattern) {
+			return true
+		}
+	}
 
-		// Wildcards in the original pattern are now escaped literals - convert back
-		// NOTE: currently no way for user to specify a glob that would match a literal '*'
-		regex := "^" + strings.ReplaceAll(escaped, `\*`, `.*`) + "$"
-		matcher, err := regexp.Compile(regex)
-		if err != nil {
-			// We are building the regex, so it should always be valid
-			zap.L().Error("invalid regex",
-				zap.String("originalPattern", pattern),
-				zap.String("transformedRegex", regex),
-				zap.Error(err),
-			)
+	now := time.Now()
+	for _, exception := range policy.Exceptions {
+		if exception.Revoked || !time.Time(exception.ExpiresAt).After(now) {
 			continue
 		}
-
-		if matcher.MatchString(resourceID) {
+		if matchesPattern(resourceID, string(exception.ResourcePattern)) {
 			return true
 		}
 	}
@@ -376,6 +373,30 @@ func isSuppressed(resourceID string, policy *analysismodels.EnabledPolicy) bool
 	return false
 }
 
+// Returns true if the resource ID matches a suppression glob pattern
+func matchesPattern(resourceID, pattern string) bool {
+	// Convert the glob pattern (e.g "prod.*.bucket") to regex ("prod\..*\.bucket")
+
+	// First, escape any regex special characters
+	escaped := regexp.QuoteMeta(pattern)
+
+	// Wildcards in the original pattern are now escaped literals - convert back
+	// NOTE: currently no way for user to specify a glob that would match a literal '*'
+	regex := "^" + strings.ReplaceAll(escaped, `\*`, `.*`) + "$"
+	matcher, err := regexp.Compile(regex)
+	if err != nil {
+		// We are building the regex, so it should always be valid
+		zap.L().Error("invalid regex",
+			zap.String("originalPattern", pattern),
+			zap.String("transformedRegex", regex),
+			zap.Error(err),
+		)
+		return false
+	}
+
+	return matcher.MatchString(resourceID)
+}
+
 // Deliver all analysis results to compliance-api and alert-processor
 func (r *batchResults) deliver() error {
 	if len(r.StatusEntries) == 0 {
@@ -20,15 +20,36 @@ package processor
 
 import (
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/go-openapi/strfmt"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	enginemodels "github.com/panther-labs/panther/api/gateway/analysis"
 	analysismodels "github.com/panther-labs/panther/api/gateway/analysis/models"
+	complianceapi "github.com/panther-labs/panther/api/gateway/compliance/client"
+	complianceops "github.com/panther-labs/panther/api/gateway/compliance/client/operations"
+	compliancemodels "github.com/panther-labs/panther/api/gateway/compliance/models"
 	resourcemodels "github.com/panther-labs/panther/api/gateway/resources/models"
 	"github.com/panther-labs/panther/internal/compliance/resource_processor/models"
+	"github.com/panther-labs/panther/pkg/testutils"
 )
 
+// mockComplianceOps returns the previous compliance status of a policy/resource pair
+type mockComplianceOps struct {
+	complianceops.ClientService
+	mock.Mock
+}
+
+func (m *mockComplianceOps) GetStatus(params *complianceops.GetStatusParams) (*complianceops.GetStatusOK, error) {
+	args := m.Called(params)
+	return args.Get(0).(*complianceops.GetStatusOK), args.Error(1)
+}
+
 func TestParseQueueMsgResource(t *testing.T) {
 	resourceIn := &resourcemodels.Resource{
 		Attributes: "{}",
@@ -102,3 +123,83 @@ func TestIsSuppressed(t *testing.T) {
 		Suppressions: []string{"not", "this", "one", "but", "here:", "*.us-west-2/*"},
 	}))
 }
+
+func TestIsSuppressedException(t *testing.T) {
+	resourceID := "prod.panther.us-west-2/device"
+	active := analysismodels.ExceptionExpiration(strfmt.DateTime(time.Now().Add(time.Hour)))
+	expired := analysismodels.ExceptionExpiration(strfmt.DateTime(time.Now().Add(-time.Hour)))
+
+	assert.True(t, isSuppressed(resourceID, &analysismodels.EnabledPolicy{
+		Exceptions: analysismodels.Exceptions{
+			{ResourcePattern: "prod.panther.*", ExpiresAt: active},
+		},
+	}))
+	assert.False(t, isSuppressed(resourceID, &analysismodels.EnabledPolicy{
+		Exceptions: analysismodels.Exceptions{
+			{ResourcePattern: "prod.panther.*", ExpiresAt: expired},
+		},
+	}))
+	assert.False(t, isSuppressed(resourceID, &analysismodels.EnabledPolicy{
+		Exceptions: analysismodels.Exceptions{
+			{ResourcePattern: "dev.*", ExpiresAt: active},
+		},
+	}))
+	assert.False(t, isSuppressed(resourceID, &analysismodels.EnabledPolicy{
+		Exceptions: analysismodels.Exceptions{
+			{ResourcePattern: "prod.panther.*", ExpiresAt: active, Revoked: true},
+		},
+	}))
+}
+
+func TestAnalyzeExpiredException(t *testing.T) {
+	resource := &resourcemodels.Resource{
+		ID:   "arn:aws:s3:::example-website",
+		Type: "AWS.S3.Bucket",
+	}
+	policy := &analysismodels.EnabledPolicy{
+		ID:            "AWS.S3.BucketPublicAccess",
+		ResourceTypes: []string{"AWS.S3.Bucket"},
+		Severity:      analysismodels.SeverityHIGH,
+	}
+
+	// The policy engine fails the resource every time
+	payload, err := jsoniter.Marshal(&enginemodels.PolicyEngineOutput{
+		Resources: []enginemodels.Result{{ID: string(resource.ID), Failed: []string{string(policy.ID)}}},
+	})
+	require.NoError(t, err)
+	mockLambda := &testutils.LambdaMock{}
+	lambdaClient = mockLambda
+	mockLambda.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{Payload: payload}, nil)
+
+	mockCompliance := &mockComplianceOps{}
+	complianceClient = &complianceapi.PantherCompliance{Operations: mockCompliance}
+	mockCompliance.On("GetStatus", mock.Anything).Return(&complianceops.GetStatusOK{
+		Payload: &compliancemodels.ComplianceStatus{Status: compliancemodels.StatusFAIL},
+	}, nil).Once()
+
+	analyze := func(expiresAt time.Time) *batchResults {
+		policy.Exceptions = analysismodels.Exceptions{{
+			ExpiresAt:       analysismodels.ExceptionExpiration(strfmt.DateTime(expiresAt)),
+			ResourcePattern: "arn:aws:s3:::example-*",
+		}}
+		results := &batchResults{}
+		require.NoError(t, results.analyze(
+			resourceMap{string(resource.ID): resource}, policyMap{string(policy.ID): policy}))
+		require.Len(t, results.StatusEntries, 1)
+		return results
+	}
+
+	// While the exception is active, the failing resource is suppressed
+	results := analyze(time.Now().Add(time.Hour))
+	assert.Equal(t, compliancemodels.StatusFAIL, results.StatusEntries[0].Status)
+	assert.True(t, bool(results.StatusEntries[0].Suppressed))
+	assert.Empty(t, results.Alerts)
+	mockCompliance.AssertNotCalled(t, "GetStatus", mock.Anything)
+
+	// Once it expires, the resource fails again and goes through the remediation flow
+	results = analyze(time.Now().Add(-time.Hour))
+	assert.Equal(t, compliancemodels.StatusFAIL, results.StatusEntries[0].Status)
+	assert.False(t, bool(results.StatusEntries[0].Suppressed))
+	assert.Len(t, results.Alerts, 1)
+	mockCompliance.AssertExpectations(t)
+}
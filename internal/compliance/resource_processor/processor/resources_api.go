@@ -32,12 +32,12 @@ const resourcePageSize = 2000
 
 // Get a page of resources from the resources-api
 //
-// Returns {resourceID: resource}, totalPages, error
-func getResources(resourceTypes []string, pageno int64) (resourceMap, int64, error) {
+// Returns {resourceID: resource}, nextPageToken (empty on the last page), error
+func getResources(resourceTypes []string, pageToken *string) (resourceMap, string, error) {
 	result := make(resourceMap)
 
 	zap.L().Debug("listing resources from resources-api",
-		zap.Int64("pageNo", pageno),
+		zap.Stringp("pageToken", pageToken),
 		zap.Int("pageSize", resourcePageSize),
 		zap.Strings("resourceTypes", resourceTypes),
 	)
@@ -45,20 +45,21 @@ func getResources(resourceTypes []string, pageno int64) (resourceMap, int64, err
 	page, err := resourceClient.Operations.ListResources(&operations.ListResourcesParams{
 		Deleted:    aws.Bool(false),
 		Fields:     []string{"attributes", "id", "integrationId", "integrationType", "type"},
-		Page:       &pageno,
 		PageSize:   aws.Int64(resourcePageSize),
+		PageToken:  pageToken,
+		Pagination: aws.String("token"),
 		Types:      resourceTypes,
 		HTTPClient: httpClient,
 	})
 	if err != nil {
 		zap.L().Error("failed to list resources", zap.Error(err))
-		return nil, 0, err
+		return nil, "", err
 	}
 
 	for _, resource := range page.Payload.Resources {
 		result[string(resource.ID)] = resource
 	}
-	return result, *page.Payload.Paging.TotalPages, nil
+	return result, page.Payload.NextPageToken, nil
 }
 
 func getResource(resourceID string) (*resourcemodels.Resource, error) {
@@ -29,6 +29,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	jsoniter "github.com/json-iterator/go"
 	"go.uber.org/zap"
@@ -53,8 +54,16 @@ func AddResources(request *events.APIGatewayProxyRequest) *events.APIGatewayProx
 		return badRequest(err)
 	}
 
+	// Items are replaced entirely, so the compliance status has to be carried over
+	stored, err := storedItems(input.Resources)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
 	now := models.LastModified(time.Now())
+	version := newVersion(time.Time(now))
 	writeRequests := make([]*dynamodb.WriteRequest, len(input.Resources))
+	historyRequests := make([]*dynamodb.WriteRequest, 0, len(input.Resources))
 	sqsEntries := make([]*sqs.SendMessageBatchRequestEntry, len(input.Resources))
 	for i, r := range input.Resources {
 		item := resourceItem{
@@ -67,6 +76,27 @@ func AddResources(request *events.APIGatewayProxyRequest) *events.APIGatewayProx
 			Type:            r.Type,
 			LowerID:         strings.ToLower(string(r.ID)),
 			ExpiresAt:       time.Now().Unix() + deleteMissWindow,
+
+			ComplianceStatus: models.ComplianceStatusPASS, // no policies have been evaluated against a new resource yet
+			DeletedKey:       deletedKey(false),
+		}
+		if previous := stored[r.ID]; previous != nil {
+			item.ComplianceStatus = previous.ComplianceStatus
+			item.HistoryVersion = previous.HistoryVersion
+		}
+
+		// Most scans find the resource unchanged, and those do not need a new snapshot
+		changed, err := needsSnapshot(stored[r.ID], r.Attributes, time.Time(now))
+		if err != nil {
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		}
+		if changed {
+			historyRequest, err := historyWriteRequest(&item, version, input.Trigger)
+			if err != nil {
+				return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+			}
+			historyRequests = append(historyRequests, historyRequest)
+			item.HistoryVersion = version
 		}
 
 		marshalled, err := dynamodbattribute.MarshalMap(item)
@@ -90,6 +120,9 @@ func AddResources(request *events.APIGatewayProxyRequest) *events.APIGatewayProx
 	dynamoInput := &dynamodb.BatchWriteItemInput{
 		RequestItems: map[string][]*dynamodb.WriteRequest{env.ResourcesTable: writeRequests},
 	}
+	if len(historyRequests) > 0 {
+		dynamoInput.RequestItems[env.HistoryTable] = historyRequests
+	}
 	if err := dynamodbbatch.BatchWriteItem(dynamoClient, maxBackoff, dynamoInput); err != nil {
 		zap.L().Error("dynamodbbatch.BatchWriteItem failed", zap.Error(err))
 		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
@@ -111,6 +144,57 @@ func AddResources(request *events.APIGatewayProxyRequest) *events.APIGatewayProx
 	return &events.APIGatewayProxyResponse{StatusCode: http.StatusCreated}
 }
 
+// Read the current state of the given resources from the table (if they exist)
+func storedItems(resources []*models.AddResourceEntry) (map[models.ResourceID]*resourceItem, error) {
+	keys := make([]map[string]*dynamodb.AttributeValue, 0, len(resources))
+	requested := make(map[models.ResourceID]bool, len(resources))
+	for _, r := range resources {
+		if !requested[r.ID] { // duplicate keys are not allowed in a batch get
+			requested[r.ID] = true
+			keys = append(keys, tableKey(r.ID))
+		}
+	}
+
+	projection := expression.NamesList(
+		expression.Name("id"),
+		expression.Name("attributes"),
+		expression.Name("complianceStatus"),
+		expression.Name("deleted"),
+		expression.Name("historyVersion"),
+	)
+	expr, err := expression.NewBuilder().WithProjection(projection).Build()
+	if err != nil {
+		zap.L().Error("expr.Build failed", zap.Error(err))
+		return nil, err
+	}
+
+	response, err := dynamodbbatch.BatchGetItem(dynamoClient, &dynamodb.BatchGetItemInput{
+		RequestItems: map[string]*dynamodb.KeysAndAttributes{
+			env.ResourcesTable: {
+				ExpressionAttributeNames: expr.Names(),
+				Keys:                     keys,
+				ProjectionExpression:     expr.Projection(),
+			},
+		},
+	})
+	if err != nil {
+		zap.L().Error("dynamodbbatch.BatchGetItem failed", zap.Error(err))
+		return nil, err
+	}
+
+	var items []*resourceItem
+	if err := dynamodbattribute.UnmarshalListOfMaps(response.Responses[env.ResourcesTable], &items); err != nil {
+		zap.L().Error("dynamodbattribute.UnmarshalListOfMaps failed", zap.Error(err))
+		return nil, err
+	}
+
+	result := make(map[models.ResourceID]*resourceItem, len(items))
+	for _, item := range items {
+		result[item.ID] = item
+	}
+	return result, nil
+}
+
 func handleBigMessages(bigMessages []*sqs.SendMessageBatchRequestEntry, input *models.AddResources) *events.APIGatewayProxyResponse {
 	sqsRetries := make([]*sqs.SendMessageBatchRequestEntry, len(bigMessages))
 	for i, message := range bigMessages {
@@ -46,6 +46,7 @@ var (
 type envConfig struct {
 	ComplianceAPIHost string `required:"true" split_words:"true"`
 	ComplianceAPIPath string `required:"true" split_words:"true"`
+	HistoryTable      string `required:"true" split_words:"true"`
 	ResourcesQueueURL string `required:"true" split_words:"true"`
 	ResourcesTable    string `required:"true" split_words:"true"`
 }
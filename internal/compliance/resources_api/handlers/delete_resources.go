@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
 	jsoniter "github.com/json-iterator/go"
 	"go.uber.org/zap"
@@ -30,6 +31,7 @@ import (
 	complianceops "github.com/panther-labs/panther/api/gateway/compliance/client/operations"
 	compliance "github.com/panther-labs/panther/api/gateway/compliance/models"
 	"github.com/panther-labs/panther/api/gateway/resources/models"
+	"github.com/panther-labs/panther/pkg/awsbatch/dynamodbbatch"
 )
 
 // Deleted resources are retained for 30 days in the database
@@ -43,8 +45,12 @@ func DeleteResources(request *events.APIGatewayProxyRequest) *events.APIGatewayP
 	}
 
 	deletes := make([]*compliance.DeleteStatus, len(input.Resources))
+	historyRequests := make([]*dynamodb.WriteRequest, 0, len(input.Resources))
+	version := newVersion(time.Now())
 	update := expression.
 		Set(expression.Name("deleted"), expression.Value(true)).
+		Set(expression.Name("deletedKey"), expression.Value(deletedKey(true))).
+		Set(expression.Name("historyVersion"), expression.Value(version)).
 		Set(expression.Name("expiresAt"), expression.Value(time.Now().Unix()+deleteWindowSecs))
 	for i, entry := range input.Resources {
 		deletes[i] = &compliance.DeleteStatus{
@@ -52,10 +58,15 @@ func DeleteResources(request *events.APIGatewayProxyRequest) *events.APIGatewayP
 		}
 
 		// Dynamo does not support batch update, so these are sequential
-		response := doUpdate(update, entry.ID)
+		item, response := doUpdate(update, entry.ID)
 		switch response.StatusCode {
 		case http.StatusOK:
-			continue
+			// The deletion is recorded as a new snapshot with the last known attributes
+			historyRequest, err := historyWriteRequest(item, version, nil)
+			if err != nil {
+				return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+			}
+			historyRequests = append(historyRequests, historyRequest)
 		case http.StatusNotFound:
 			// If the resource wasn't found, log a warning but we don't need to fail the operation.
 			zap.L().Warn("resource no longer exists", zap.Any("deleteEntry", entry))
@@ -64,6 +75,16 @@ func DeleteResources(request *events.APIGatewayProxyRequest) *events.APIGatewayP
 		}
 	}
 
+	if len(historyRequests) > 0 {
+		dynamoInput := &dynamodb.BatchWriteItemInput{
+			RequestItems: map[string][]*dynamodb.WriteRequest{env.HistoryTable: historyRequests},
+		}
+		if err := dynamodbbatch.BatchWriteItem(dynamoClient, maxBackoff, dynamoInput); err != nil {
+			zap.L().Error("dynamodbbatch.BatchWriteItem failed", zap.Error(err))
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		}
+	}
+
 	// Delete affected compliance states
 	zap.L().Info("deleting compliance status entries", zap.Int("itemCount", len(deletes)))
 	_, err = complianceClient.Operations.DeleteStatus(&complianceops.DeleteStatusParams{
@@ -20,6 +20,7 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-sdk-go/aws"
@@ -42,9 +43,21 @@ type resourceItem struct {
 	LastModified    models.LastModified    `json:"lastModified"`
 	Type            models.ResourceType    `json:"type"`
 
+	// Kept up to date by the compliance-api
+	ComplianceStatus models.ComplianceStatus `json:"complianceStatus,omitempty"`
+
+	// The newest snapshot of this resource in the history table
+	HistoryVersion models.SnapshotVersion `json:"historyVersion,omitempty"`
+
 	// Internal fields: TTL and more efficient filtering
-	ExpiresAt int64  `json:"expiresAt,omitempty"`
-	LowerID   string `json:"lowerId"` // lowercase ID for efficient ID substring filtering
+	DeletedKey string `json:"deletedKey,omitempty"` // "true" or "false" - index keys can't be booleans
+	ExpiresAt  int64  `json:"expiresAt,omitempty"`
+	LowerID    string `json:"lowerId"` // lowercase ID for efficient ID substring filtering
+}
+
+// The deleted flag as it is stored in the deleted-index
+func deletedKey(deleted bool) string {
+	return strconv.FormatBool(deleted)
 }
 
 // Convert dynamo item to external models.Resource
@@ -73,22 +86,23 @@ func existsCondition(resourceID models.ResourceID) expression.ConditionBuilder {
 	return expression.Name("id").Equal(expression.Value(resourceID))
 }
 
-// Complete a conditional Dynamo update and return the appropriate status code
-func doUpdate(update expression.UpdateBuilder, resourceID models.ResourceID) *events.APIGatewayProxyResponse {
+// Complete a conditional Dynamo update and return the updated item with the appropriate status code
+func doUpdate(update expression.UpdateBuilder, resourceID models.ResourceID) (*resourceItem, *events.APIGatewayProxyResponse) {
 	condition := existsCondition(resourceID)
 	expr, err := expression.NewBuilder().WithCondition(condition).WithUpdate(update).Build()
 	if err != nil {
 		zap.L().Error("expr.Build failed", zap.Error(err))
-		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		return nil, &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
 	}
 
 	zap.L().Info("submitting dynamo item update",
 		zap.String("resourceId", string(resourceID)))
-	_, err = dynamoClient.UpdateItem(&dynamodb.UpdateItemInput{
+	response, err := dynamoClient.UpdateItem(&dynamodb.UpdateItemInput{
 		ConditionExpression:       expr.Condition(),
 		ExpressionAttributeNames:  expr.Names(),
 		ExpressionAttributeValues: expr.Values(),
 		Key:                       tableKey(resourceID),
+		ReturnValues:              aws.String(dynamodb.ReturnValueAllNew),
 		TableName:                 &env.ResourcesTable,
 		UpdateExpression:          expr.Update(),
 	})
@@ -96,13 +110,18 @@ func doUpdate(update expression.UpdateBuilder, resourceID models.ResourceID) *ev
 	if err != nil {
 		aerr, ok := err.(awserr.Error)
 		if ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
-			return &events.APIGatewayProxyResponse{StatusCode: http.StatusNotFound}
+			return nil, &events.APIGatewayProxyResponse{StatusCode: http.StatusNotFound}
 		}
 		zap.L().Error("dynamoClient.UpdateItem failed", zap.Error(err))
-		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		return nil, &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
 	}
 
-	return &events.APIGatewayProxyResponse{StatusCode: http.StatusOK}
+	var item resourceItem
+	if err := dynamodbattribute.UnmarshalMap(response.Attributes, &item); err != nil {
+		zap.L().Error("dynamodbattribute.UnmarshalMap failed", zap.Error(err))
+		return nil, &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	return &item, &events.APIGatewayProxyResponse{StatusCode: http.StatusOK}
 }
 
 // Wrapper around dynamoClient.ScanPages that accepts a handler function to process each item.
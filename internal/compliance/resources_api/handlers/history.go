@@ -0,0 +1,366 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/gateway/resources/client/operations"
+	"github.com/panther-labs/panther/api/gateway/resources/models"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+const (
+	// Snapshot versions are retained for 90 days in the history table
+	historyWindowSecs = 90 * 24 * 60 * 60
+
+	// Unchanged resources get a fresh snapshot well before their newest one expires
+	historyRefreshInterval = 60 * 24 * time.Hour
+
+	// Fixed-width UTC timestamp, so versions sort lexically in the table
+	versionLayout = "2006-01-02T15:04:05.000000000Z"
+)
+
+// An immutable snapshot of a resource, written every time the resource changes or is deleted
+type historyItem struct {
+	Attributes models.Attributes       `json:"attributes"`
+	Deleted    models.Deleted          `json:"deleted"`
+	ID         models.ResourceID       `json:"id"`
+	Trigger    *models.ResourceTrigger `json:"trigger,omitempty"`
+	Version    models.SnapshotVersion  `json:"version"`
+
+	ExpiresAt int64 `json:"expiresAt"`
+}
+
+func newVersion(t time.Time) models.SnapshotVersion {
+	return models.SnapshotVersion(t.UTC().Format(versionLayout))
+}
+
+// Build the request which stores a new snapshot of the resource in the history table
+func historyWriteRequest(
+	item *resourceItem, version models.SnapshotVersion, trigger *models.ResourceTrigger) (*dynamodb.WriteRequest, error) {
+
+	snapshot := historyItem{
+		Attributes: item.Attributes,
+		Deleted:    item.Deleted,
+		ID:         item.ID,
+		Trigger:    trigger,
+		Version:    version,
+		ExpiresAt:  time.Now().Unix() + historyWindowSecs,
+	}
+
+	marshalled, err := dynamodbattribute.MarshalMap(snapshot)
+	if err != nil {
+		zap.L().Error("dynamodbattribute.MarshalMap failed", zap.Error(err))
+		return nil, err
+	}
+	return &dynamodb.WriteRequest{PutRequest: &dynamodb.PutRequest{Item: marshalled}}, nil
+}
+
+// Whether a scanned resource differs from its stored state and should be recorded in the history table
+func needsSnapshot(stored *resourceItem, attributes models.Attributes, now time.Time) (bool, error) {
+	if stored == nil || bool(stored.Deleted) || stored.HistoryVersion == "" {
+		return true, nil
+	}
+
+	if last, err := time.Parse(versionLayout, string(stored.HistoryVersion)); err != nil || now.Sub(last) > historyRefreshInterval {
+		return true, nil
+	}
+
+	// Round trip the scanned attributes so they compare equal to what dynamo stored (e.g. empty strings become null)
+	marshalled, err := dynamodbattribute.Marshal(attributes)
+	if err != nil {
+		zap.L().Error("dynamodbattribute.Marshal failed", zap.Error(err))
+		return false, err
+	}
+	var normalized models.Attributes
+	if err := dynamodbattribute.Unmarshal(marshalled, &normalized); err != nil {
+		zap.L().Error("dynamodbattribute.Unmarshal failed", zap.Error(err))
+		return false, err
+	}
+	return !reflect.DeepEqual(stored.Attributes, normalized), nil
+}
+
+// GetResourceHistory lists the snapshot versions of a single resource, newest first.
+func GetResourceHistory(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	params, err := parseGetResourceHistory(request)
+	if err != nil {
+		return badRequest(err)
+	}
+	resourceID := models.ResourceID(params.ResourceID)
+
+	// One extra (older) version is needed to know what changed in the last version on the page
+	snapshots, err := queryHistory(resourceID, aws.StringValue(params.Before), *params.PageSize+1)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	result := &models.ResourceHistory{
+		ResourceID: resourceID,
+		Versions:   make([]*models.ResourceVersion, 0, len(snapshots)),
+	}
+	for i, snapshot := range snapshots {
+		if i == int(*params.PageSize) {
+			break
+		}
+
+		var previous models.Attributes
+		if i+1 < len(snapshots) {
+			previous = snapshots[i+1].Attributes
+		}
+		result.Versions = append(result.Versions, &models.ResourceVersion{
+			ChangedAttributes: changedAttributes(previous, snapshot.Attributes),
+			Deleted:           snapshot.Deleted,
+			Trigger:           snapshot.Trigger,
+			Version:           snapshot.Version,
+		})
+	}
+
+	return gatewayapi.MarshalResponse(result, http.StatusOK)
+}
+
+func parseGetResourceHistory(request *events.APIGatewayProxyRequest) (*operations.GetResourceHistoryParams, error) {
+	resourceID, err := parseGetResource(request)
+	if err != nil {
+		return nil, err
+	}
+
+	result := operations.NewGetResourceHistoryParams() // initialize with default values
+	result.ResourceID = string(resourceID)
+
+	if before := request.QueryStringParameters["before"]; before != "" {
+		if err := validateVersion(before); err != nil {
+			return nil, errors.New("invalid before: " + err.Error())
+		}
+		result.Before = aws.String(before)
+	}
+
+	if rawSize := request.QueryStringParameters["pageSize"]; rawSize != "" {
+		val, err := strconv.ParseInt(rawSize, 10, 64)
+		if err != nil {
+			return nil, errors.New("invalid pageSize: " + err.Error())
+		}
+		if val < 1 || val > 100 {
+			return nil, fmt.Errorf("invalid pageSize: %d is not in the range [1, 100]", val)
+		}
+		result.PageSize = &val
+	}
+
+	return result, nil
+}
+
+// DiffResourceVersions compares the attributes of two snapshots of the same resource.
+func DiffResourceVersions(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	params, err := parseDiffResourceVersions(request)
+	if err != nil {
+		return badRequest(err)
+	}
+	resourceID := models.ResourceID(params.ResourceID)
+
+	from, err := getHistory(resourceID, models.SnapshotVersion(params.FromVersion))
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	to, err := getHistory(resourceID, models.SnapshotVersion(params.ToVersion))
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	if from == nil || to == nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusNotFound}
+	}
+
+	return gatewayapi.MarshalResponse(&models.ResourceDiff{
+		Changes:     diffAttributes(from.Attributes, to.Attributes),
+		FromVersion: from.Version,
+		ResourceID:  resourceID,
+		ToVersion:   to.Version,
+	}, http.StatusOK)
+}
+
+func parseDiffResourceVersions(request *events.APIGatewayProxyRequest) (*operations.DiffResourceVersionsParams, error) {
+	resourceID, err := parseGetResource(request)
+	if err != nil {
+		return nil, err
+	}
+
+	result := operations.NewDiffResourceVersionsParams()
+	result.ResourceID = string(resourceID)
+	result.FromVersion = request.QueryStringParameters["fromVersion"]
+	result.ToVersion = request.QueryStringParameters["toVersion"]
+
+	if err := validateVersion(result.FromVersion); err != nil {
+		return nil, errors.New("invalid fromVersion: " + err.Error())
+	}
+	if err := validateVersion(result.ToVersion); err != nil {
+		return nil, errors.New("invalid toVersion: " + err.Error())
+	}
+	return result, nil
+}
+
+func validateVersion(version string) error {
+	_, err := time.Parse(versionLayout, version)
+	return err
+}
+
+// Query the newest snapshots of a resource, optionally starting before a given version
+func queryHistory(resourceID models.ResourceID, before string, limit int64) ([]*historyItem, error) {
+	keyCondition := expression.Key("id").Equal(expression.Value(resourceID))
+	if before != "" {
+		keyCondition = keyCondition.And(expression.Key("version").LessThan(expression.Value(before)))
+	}
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+	if err != nil {
+		zap.L().Error("expr.Build failed", zap.Error(err))
+		return nil, err
+	}
+
+	response, err := dynamoClient.Query(&dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		Limit:                     &limit,
+		ScanIndexForward:          aws.Bool(false), // newest first
+		TableName:                 &env.HistoryTable,
+	})
+	if err != nil {
+		zap.L().Error("dynamoClient.Query failed", zap.Error(err))
+		return nil, err
+	}
+
+	var result []*historyItem
+	if err := dynamodbattribute.UnmarshalListOfMaps(response.Items, &result); err != nil {
+		zap.L().Error("dynamodbattribute.UnmarshalListOfMaps failed", zap.Error(err))
+		return nil, err
+	}
+	return result, nil
+}
+
+// Load a single snapshot of a resource - returns nil if it does not exist
+func getHistory(resourceID models.ResourceID, version models.SnapshotVersion) (*historyItem, error) {
+	response, err := dynamoClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"id":      {S: aws.String(string(resourceID))},
+			"version": {S: aws.String(string(version))},
+		},
+		TableName: &env.HistoryTable,
+	})
+	if err != nil {
+		zap.L().Error("dynamoClient.GetItem failed", zap.Error(err))
+		return nil, err
+	}
+
+	if len(response.Item) == 0 {
+		return nil, nil
+	}
+
+	var item historyItem
+	if err := dynamodbattribute.UnmarshalMap(response.Item, &item); err != nil {
+		zap.L().Error("dynamodbattribute.UnmarshalMap failed", zap.Error(err))
+		return nil, err
+	}
+	return &item, nil
+}
+
+// Sorted list of top-level attribute names whose values differ between two snapshots
+func changedAttributes(before, after models.Attributes) []string {
+	oldMap, _ := before.(map[string]interface{})
+	newMap, _ := after.(map[string]interface{})
+
+	result := make([]string, 0)
+	for key, value := range newMap {
+		if oldValue, ok := oldMap[key]; !ok || !reflect.DeepEqual(oldValue, value) {
+			result = append(result, key)
+		}
+	}
+	for key := range oldMap {
+		if _, ok := newMap[key]; !ok {
+			result = append(result, key)
+		}
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+// Compare every nested attribute value between two snapshots, sorted by path
+func diffAttributes(before, after models.Attributes) []*models.AttributeChange {
+	oldValues, newValues := make(map[string]interface{}), make(map[string]interface{})
+	flattenAttributes("", before, oldValues)
+	flattenAttributes("", after, newValues)
+
+	result := make([]*models.AttributeChange, 0)
+	for path, value := range newValues {
+		if oldValue, ok := oldValues[path]; !ok || !reflect.DeepEqual(oldValue, value) {
+			result = append(result, &models.AttributeChange{After: value, Before: oldValue, Path: aws.String(path)})
+		}
+	}
+	for path, oldValue := range oldValues {
+		if _, ok := newValues[path]; !ok {
+			result = append(result, &models.AttributeChange{Before: oldValue, Path: aws.String(path)})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return *result[i].Path < *result[j].Path })
+	return result
+}
+
+// Map each leaf value to its JSON path, e.g. "Tags.env" or "Grants[0].Permission"
+//
+// Empty objects and lists are treated as leaf values so they still show up in a diff.
+func flattenAttributes(path string, value interface{}, result map[string]interface{}) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		if len(typed) == 0 && path != "" {
+			result[path] = typed
+		}
+		for key, nested := range typed {
+			if path == "" {
+				flattenAttributes(key, nested, result)
+			} else {
+				flattenAttributes(path+"."+key, nested, result)
+			}
+		}
+	case []interface{}:
+		if len(typed) == 0 {
+			result[path] = typed
+		}
+		for i, nested := range typed {
+			flattenAttributes(fmt.Sprintf("%s[%d]", path, i), nested, result)
+		}
+	case nil:
+		if path != "" {
+			result[path] = nil
+		}
+	default:
+		result[path] = typed
+	}
+}
@@ -0,0 +1,134 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/gateway/resources/models"
+)
+
+func TestNewVersion(t *testing.T) {
+	version := newVersion(time.Date(2020, 6, 1, 12, 0, 0, 5000, time.FixedZone("PDT", -7*60*60)))
+	assert.Equal(t, models.SnapshotVersion("2020-06-01T19:00:00.000005000Z"), version)
+	assert.NoError(t, validateVersion(string(version)))
+	assert.Error(t, validateVersion("2020-06-01"))
+}
+
+func TestParseGetResourceHistory(t *testing.T) {
+	result, err := parseGetResourceHistory(&events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{"resourceId": "arn%3Aaws%3As3%3A%3A%3Amy-bucket"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "arn:aws:s3:::my-bucket", result.ResourceID)
+	assert.Nil(t, result.Before)
+	assert.Equal(t, int64(25), *result.PageSize)
+
+	_, err = parseGetResourceHistory(&events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{"resourceId": "my-bucket", "pageSize": "101"},
+	})
+	assert.Error(t, err)
+
+	_, err = parseGetResourceHistory(&events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{"resourceId": "my-bucket", "before": "yesterday"},
+	})
+	assert.Error(t, err)
+}
+
+func TestChangedAttributes(t *testing.T) {
+	before := map[string]interface{}{"Name": "my-bucket", "Versioning": "Suspended", "Policy": "{}"}
+	after := map[string]interface{}{"Name": "my-bucket", "Versioning": "Enabled", "Tags": map[string]interface{}{}}
+
+	assert.Equal(t, []string{"Policy", "Tags", "Versioning"}, changedAttributes(before, after))
+	assert.Equal(t, []string{}, changedAttributes(after, after))
+	assert.Equal(t, []string{"Name", "Tags", "Versioning"}, changedAttributes(nil, after))
+}
+
+func TestNeedsSnapshot(t *testing.T) {
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	stored := &resourceItem{
+		Attributes:     map[string]interface{}{"Name": "my-bucket", "Policy": nil, "Tags": map[string]interface{}{"env": "prod"}},
+		HistoryVersion: newVersion(now.Add(-24 * time.Hour)),
+	}
+
+	// Empty strings are stored as null, so they are not a change
+	unchanged := map[string]interface{}{"Name": "my-bucket", "Policy": "", "Tags": map[string]interface{}{"env": "prod"}}
+	result, err := needsSnapshot(stored, unchanged, now)
+	require.NoError(t, err)
+	assert.False(t, result)
+
+	changed := map[string]interface{}{"Name": "my-bucket", "Policy": "", "Tags": map[string]interface{}{"env": "dev"}}
+	result, err = needsSnapshot(stored, changed, now)
+	require.NoError(t, err)
+	assert.True(t, result)
+
+	// New resources, re-discovered resources and old snapshots are always recorded
+	result, err = needsSnapshot(nil, unchanged, now)
+	require.NoError(t, err)
+	assert.True(t, result)
+
+	deleted := *stored
+	deleted.Deleted = true
+	result, err = needsSnapshot(&deleted, unchanged, now)
+	require.NoError(t, err)
+	assert.True(t, result)
+
+	expiring := *stored
+	expiring.HistoryVersion = newVersion(now.Add(-61 * 24 * time.Hour))
+	result, err = needsSnapshot(&expiring, unchanged, now)
+	require.NoError(t, err)
+	assert.True(t, result)
+}
+
+func TestDiffAttributes(t *testing.T) {
+	before := map[string]interface{}{
+		"Grants": []interface{}{
+			map[string]interface{}{"Permission": "READ"},
+			map[string]interface{}{"Permission": "WRITE"},
+		},
+		"Name": "my-bucket",
+		"Tags": map[string]interface{}{"env": "prod"},
+	}
+	after := map[string]interface{}{
+		"Grants": []interface{}{
+			map[string]interface{}{"Permission": "FULL_CONTROL"},
+		},
+		"Name":     "my-bucket",
+		"Policy":   nil,
+		"Tags":     map[string]interface{}{},
+		"Versions": []interface{}{},
+	}
+
+	expected := []*models.AttributeChange{
+		{After: "FULL_CONTROL", Before: "READ", Path: aws.String("Grants[0].Permission")},
+		{Before: "WRITE", Path: aws.String("Grants[1].Permission")},
+		{Path: aws.String("Policy")},
+		{After: map[string]interface{}{}, Path: aws.String("Tags")},
+		{Before: "prod", Path: aws.String("Tags.env")},
+		{After: []interface{}{}, Path: aws.String("Versions")},
+	}
+	assert.Equal(t, expected, diffAttributes(before, after))
+	assert.Empty(t, diffAttributes(before, before))
+}
@@ -0,0 +1,384 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/gateway/resources/client/operations"
+	"github.com/panther-labs/panther/api/gateway/resources/models"
+)
+
+// Secondary indices on the resources table - names should match those in cloud_security.yml
+const (
+	typeIndex        = "type-index"
+	integrationIndex = "integration-index"
+	statusIndex      = "status-index"
+	deletedIndex     = "deleted-index"
+)
+
+// Bound the number of Dynamo requests needed to fill a single page of token-paginated results.
+//
+// When most resources are filtered out, the page is returned early (with a continuation token)
+// instead of reading the rest of the table in a single request.
+const maxPageRequests = 20
+
+// Attribute paths, e.g. "Tags.Environment" or "Grants[0].Permission"
+var attributePathRegex = regexp.MustCompile(`^[\w:-]+(\[\d+\])*(\.[\w:-]+(\[\d+\])*)*$`)
+
+// A filter on a resource attribute, e.g. "Tags.Environment=prod"
+type attributeFilter struct {
+	Path     string
+	Value    string
+	NotEqual bool
+}
+
+// How a list request is read from the resources table.
+//
+// Each partition key value is queried in turn from the index, or the whole table is scanned.
+type listQuery struct {
+	Index      string
+	KeyName    string
+	KeyValues  []string
+	Descending bool
+
+	filter     expression.ConditionBuilder
+	projection *expression.ProjectionBuilder
+}
+
+// The opaque continuation token returned to the caller
+type pageToken struct {
+	Query     uint32                              `json:"q"` // identifies the index and filters
+	Partition int                                 `json:"p"` // position in listQuery.KeyValues
+	Key       map[string]*dynamodb.AttributeValue `json:"k"` // ExclusiveStartKey within the partition
+}
+
+// Parse "path=value" and "path!=value" attribute filters
+func parseAttributeFilters(rawFilters []string) ([]*attributeFilter, error) {
+	result := make([]*attributeFilter, 0, len(rawFilters))
+	for _, raw := range rawFilters {
+		filter, err := url.QueryUnescape(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter %s: %s", raw, err)
+		}
+
+		var parsed attributeFilter
+		if i := strings.Index(filter, "!="); i >= 0 {
+			parsed = attributeFilter{Path: filter[:i], Value: filter[i+2:], NotEqual: true}
+		} else if i := strings.Index(filter, "="); i >= 0 {
+			parsed = attributeFilter{Path: filter[:i], Value: filter[i+1:]}
+		} else {
+			return nil, fmt.Errorf("invalid filter %s: expected path=value or path!=value", filter)
+		}
+
+		parsed.Path = strings.TrimSpace(parsed.Path)
+		if !attributePathRegex.MatchString(parsed.Path) {
+			return nil, fmt.Errorf("invalid filter %s: invalid attribute path", filter)
+		}
+		result = append(result, &parsed)
+	}
+	return result, nil
+}
+
+// Build the filter condition for an attribute filter.
+//
+// Query strings are untyped, so values which look like numbers or booleans also match
+// attributes of that type. For example, "MaxSessionDuration=3600" matches the number 3600.
+func (f *attributeFilter) condition() expression.ConditionBuilder {
+	name := expression.Name("attributes." + f.Path)
+	values := []interface{}{f.Value}
+	if number, err := strconv.ParseFloat(f.Value, 64); err == nil {
+		values = append(values, number)
+	}
+	if boolean, err := strconv.ParseBool(f.Value); err == nil && (f.Value == "true" || f.Value == "false") {
+		values = append(values, boolean)
+	}
+
+	var result expression.ConditionBuilder
+	for i, value := range values {
+		var next expression.ConditionBuilder
+		if f.NotEqual {
+			next = name.NotEqual(expression.Value(value))
+		} else {
+			next = name.Equal(expression.Value(value))
+		}
+
+		switch {
+		case i == 0:
+			result = next
+		case f.NotEqual:
+			result = result.And(next)
+		default:
+			result = result.Or(next)
+		}
+	}
+	return result
+}
+
+// Choose the index and build the filter and projection for a list request
+//
+// Token pagination always reads from an index, so every page is sorted by id (within each type).
+// Offset pagination sorts the results itself and scans the table when no index applies, which also
+// includes resources written before the deleted and status indices existed.
+func buildListQuery(params *operations.ListResourcesParams, filters []*attributeFilter) *listQuery {
+	result := &listQuery{Descending: aws.StringValue(params.SortDir) == "descending"}
+	tokenPagination := aws.StringValue(params.Pagination) == "token"
+
+	switch {
+	case len(params.Types) > 0:
+		result.Index, result.KeyName = typeIndex, "type"
+		result.KeyValues = append(result.KeyValues, params.Types...)
+		sort.Strings(result.KeyValues)
+	case params.IntegrationID != nil:
+		result.Index, result.KeyName = integrationIndex, "integrationId"
+		result.KeyValues = []string{*params.IntegrationID}
+	case params.ComplianceStatus != nil && tokenPagination:
+		result.Index, result.KeyName = statusIndex, "complianceStatus"
+		result.KeyValues = []string{*params.ComplianceStatus}
+	case params.Deleted != nil:
+		result.Index, result.KeyName = deletedIndex, "deletedKey"
+		result.KeyValues = []string{deletedKey(*params.Deleted)}
+	case tokenPagination:
+		// Active resources first, then deleted ones
+		result.Index, result.KeyName = deletedIndex, "deletedKey"
+		result.KeyValues = []string{deletedKey(false), deletedKey(true)}
+	}
+	if result.Descending {
+		sort.Sort(sort.Reverse(sort.StringSlice(result.KeyValues)))
+	}
+
+	for _, field := range params.Fields {
+		if field == "complianceStatus" && !tokenPagination {
+			continue // offset pagination reads the status from compliance-api to sort by top failing
+		}
+
+		if result.projection == nil {
+			projection := expression.NamesList(expression.Name(field))
+			result.projection = &projection
+		} else {
+			*result.projection = result.projection.AddNames(expression.Name(field))
+		}
+	}
+
+	// Start with a dummy filter just so we have one we can add onto.
+	filter := expression.AttributeExists(expression.Name("type"))
+
+	if params.Deleted != nil && result.Index != deletedIndex {
+		filter = filter.And(expression.Equal(
+			expression.Name("deleted"), expression.Value(*params.Deleted)))
+	}
+
+	if params.ComplianceStatus != nil && tokenPagination && result.Index != statusIndex {
+		statusFilter := expression.Equal(expression.Name("complianceStatus"), expression.Value(*params.ComplianceStatus))
+		if *params.ComplianceStatus == string(models.ComplianceStatusPASS) {
+			// Resources are passing until the compliance-api reports otherwise
+			statusFilter = statusFilter.Or(expression.AttributeNotExists(expression.Name("complianceStatus")))
+		}
+		filter = filter.And(statusFilter)
+	}
+
+	if params.IDContains != nil {
+		filter = filter.And(expression.Contains(expression.Name("lowerId"), *params.IDContains))
+	}
+
+	if params.IntegrationID != nil && result.Index != integrationIndex {
+		filter = filter.And(expression.Equal(
+			expression.Name("integrationId"), expression.Value(*params.IntegrationID)))
+	}
+	if params.IntegrationType != nil {
+		filter = filter.And(expression.Equal(
+			expression.Name("integrationType"), expression.Value(*params.IntegrationType)))
+	}
+
+	for _, attrFilter := range filters {
+		filter = filter.And(attrFilter.condition())
+	}
+
+	result.filter = filter
+	return result
+}
+
+// The number of partitions to read (a table scan has a single partition)
+func (q *listQuery) partitions() int {
+	if q.Index == "" {
+		return 1
+	}
+	return len(q.KeyValues)
+}
+
+// Read up to limit items (before filtering) from one partition, starting after the given key.
+//
+// A limit of 0 reads the entire partition. Returns the LastEvaluatedKey (nil if the partition is done).
+func (q *listQuery) fetch(
+	partition int,
+	startKey map[string]*dynamodb.AttributeValue,
+	limit int64,
+) ([]*resourceItem, map[string]*dynamodb.AttributeValue, error) {
+
+	builder := expression.NewBuilder().WithFilter(q.filter)
+	if q.projection != nil {
+		builder = builder.WithProjection(*q.projection)
+	}
+	if q.Index != "" {
+		builder = builder.WithKeyCondition(
+			expression.Key(q.KeyName).Equal(expression.Value(q.KeyValues[partition])))
+	}
+
+	expr, err := builder.Build()
+	if err != nil {
+		zap.L().Error("failed to build list query", zap.Error(err))
+		return nil, nil, err
+	}
+
+	var items []map[string]*dynamodb.AttributeValue
+	var lastKey map[string]*dynamodb.AttributeValue
+	if q.Index == "" {
+		input := &dynamodb.ScanInput{
+			ExclusiveStartKey:         startKey,
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+			FilterExpression:          expr.Filter(),
+			ProjectionExpression:      expr.Projection(),
+			TableName:                 &env.ResourcesTable,
+		}
+		if limit > 0 {
+			input.Limit = aws.Int64(limit)
+		}
+
+		output, err := dynamoClient.Scan(input)
+		if err != nil {
+			zap.L().Error("dynamoClient.Scan failed", zap.Error(err))
+			return nil, nil, err
+		}
+		items, lastKey = output.Items, output.LastEvaluatedKey
+	} else {
+		input := &dynamodb.QueryInput{
+			ExclusiveStartKey:         startKey,
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+			FilterExpression:          expr.Filter(),
+			IndexName:                 aws.String(q.Index),
+			KeyConditionExpression:    expr.KeyCondition(),
+			ProjectionExpression:      expr.Projection(),
+			ScanIndexForward:          aws.Bool(!q.Descending),
+			TableName:                 &env.ResourcesTable,
+		}
+		if limit > 0 {
+			input.Limit = aws.Int64(limit)
+		}
+
+		output, err := dynamoClient.Query(input)
+		if err != nil {
+			zap.L().Error("dynamoClient.Query failed", zap.Error(err))
+			return nil, nil, err
+		}
+		items, lastKey = output.Items, output.LastEvaluatedKey
+	}
+
+	var result []*resourceItem
+	if err := dynamodbattribute.UnmarshalListOfMaps(items, &result); err != nil {
+		zap.L().Error("dynamodbattribute.UnmarshalListOfMaps failed", zap.Error(err))
+		return nil, nil, err
+	}
+	return result, lastKey, nil
+}
+
+// Read every matching item from every partition
+func (q *listQuery) fetchAll(handler func(*resourceItem) error) error {
+	for partition := 0; partition < q.partitions(); partition++ {
+		var startKey map[string]*dynamodb.AttributeValue
+		for {
+			items, lastKey, err := q.fetch(partition, startKey, 0)
+			if err != nil {
+				return err
+			}
+			for _, item := range items {
+				if err := handler(item); err != nil {
+					return err
+				}
+			}
+
+			if lastKey == nil {
+				break
+			}
+			startKey = lastKey
+		}
+	}
+	return nil
+}
+
+// Identifies the list request (except for paging) so a token can't be used with different filters.
+func listQueryHash(params *operations.ListResourcesParams, filters []*attributeFilter) uint32 {
+	hash := fnv.New32a()
+	_, _ = fmt.Fprintf(hash, "%s|%v|%v|%s|%s|%s|%v|%s",
+		aws.StringValue(params.ComplianceStatus),
+		aws.BoolValue(params.Deleted), params.Deleted == nil,
+		aws.StringValue(params.IDContains),
+		aws.StringValue(params.IntegrationID),
+		aws.StringValue(params.IntegrationType),
+		params.Types,
+		aws.StringValue(params.SortDir),
+	)
+	for _, filter := range filters {
+		_, _ = fmt.Fprintf(hash, "|%s|%s|%v", filter.Path, filter.Value, filter.NotEqual)
+	}
+	return hash.Sum32()
+}
+
+func encodePageToken(token *pageToken) (string, error) {
+	data, err := jsoniter.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodePageToken(raw string, queryHash uint32, partitions int) (*pageToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, errors.New("invalid pageToken")
+	}
+
+	var token pageToken
+	if err := jsoniter.Unmarshal(data, &token); err != nil {
+		return nil, errors.New("invalid pageToken")
+	}
+	if token.Query != queryHash {
+		return nil, errors.New("invalid pageToken: the filters have changed since the previous page")
+	}
+	if token.Partition < 0 || token.Partition >= partitions {
+		return nil, errors.New("invalid pageToken")
+	}
+	return &token, nil
+}
@@ -0,0 +1,263 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/gateway/resources/client/operations"
+	"github.com/panther-labs/panther/api/gateway/resources/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func testListItem(id, resourceType string) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		"id":   {S: aws.String(id)},
+		"type": {S: aws.String(resourceType)},
+	}
+}
+
+func TestParseAttributeFilters(t *testing.T) {
+	result, err := parseAttributeFilters([]string{
+		"Tags.Environment=prod", "Grants[0].Permission!=FULL_CONTROL", "Name%3Dmy%3Dbucket",
+	})
+	require.NoError(t, err)
+	expected := []*attributeFilter{
+		{Path: "Tags.Environment", Value: "prod"},
+		{Path: "Grants[0].Permission", Value: "FULL_CONTROL", NotEqual: true},
+		{Path: "Name", Value: "my=bucket"},
+	}
+	assert.Equal(t, expected, result)
+
+	for _, filter := range []string{"Tags.Environment", "=prod", "Tags..Environment=prod", "Tags[x]=prod"} {
+		_, err := parseAttributeFilters([]string{filter})
+		assert.Error(t, err, filter)
+	}
+}
+
+func TestAttributeFilterCondition(t *testing.T) {
+	expr, err := expression.NewBuilder().WithFilter(
+		(&attributeFilter{Path: "MaxSessionDuration", Value: "3600"}).condition()).Build()
+	require.NoError(t, err)
+	assert.Equal(t, "(#0.#1 = :0) OR (#0.#1 = :1)", *expr.Filter())
+	assert.Equal(t, "3600", *expr.Values()[":0"].S)
+	assert.Equal(t, "3600", *expr.Values()[":1"].N)
+
+	expr, err = expression.NewBuilder().WithFilter(
+		(&attributeFilter{Path: "Versioning.Enabled", Value: "true", NotEqual: true}).condition()).Build()
+	require.NoError(t, err)
+	assert.Equal(t, "(#0.#1.#2 <> :0) AND (#0.#1.#2 <> :1)", *expr.Filter())
+	assert.True(t, *expr.Values()[":1"].BOOL)
+}
+
+func TestBuildListQueryIndex(t *testing.T) {
+	params := &operations.ListResourcesParams{
+		Types:         []string{"AWS.S3.Bucket", "AWS.KMS.Key"},
+		IntegrationID: aws.String("df6652ff-22d7-4c6a-a9ec-3fe50fadbbbf"),
+		SortDir:       aws.String("descending"),
+	}
+	query := buildListQuery(params, nil)
+	assert.Equal(t, typeIndex, query.Index)
+	assert.Equal(t, []string{"AWS.S3.Bucket", "AWS.KMS.Key"}, query.KeyValues)
+	assert.Equal(t, 2, query.partitions())
+	assert.True(t, query.Descending)
+
+	params.Types = nil
+	query = buildListQuery(params, nil)
+	assert.Equal(t, integrationIndex, query.Index)
+	assert.Equal(t, []string{"df6652ff-22d7-4c6a-a9ec-3fe50fadbbbf"}, query.KeyValues)
+
+	params.IntegrationID = nil
+	query = buildListQuery(params, nil)
+	assert.Equal(t, "", query.Index)
+	assert.Equal(t, 1, query.partitions())
+
+	params.Deleted = aws.Bool(false)
+	query = buildListQuery(params, nil)
+	assert.Equal(t, deletedIndex, query.Index)
+	assert.Equal(t, []string{"false"}, query.KeyValues)
+
+	// Token pagination always reads from an index
+	params.Pagination = aws.String("token")
+	params.ComplianceStatus = aws.String("FAIL")
+	query = buildListQuery(params, nil)
+	assert.Equal(t, statusIndex, query.Index)
+	assert.Equal(t, []string{"FAIL"}, query.KeyValues)
+
+	params.ComplianceStatus, params.Deleted = nil, nil
+	query = buildListQuery(params, nil)
+	assert.Equal(t, deletedIndex, query.Index)
+	assert.Equal(t, []string{"true", "false"}, query.KeyValues) // descending
+}
+
+func TestBuildListQueryStatusFilter(t *testing.T) {
+	params := &operations.ListResourcesParams{
+		ComplianceStatus: aws.String("PASS"),
+		Pagination:       aws.String("token"),
+		Types:            []string{"AWS.S3.Bucket"},
+	}
+	query := buildListQuery(params, nil)
+	expr, err := expression.NewBuilder().WithFilter(query.filter).Build()
+	require.NoError(t, err)
+
+	// Resources without a stored status are passing
+	assert.Equal(t, "(attribute_exists (#0)) AND ((#1 = :0) OR (attribute_not_exists (#1)))", *expr.Filter())
+	assert.Equal(t, "complianceStatus", *expr.Names()["#1"])
+}
+
+func TestPageToken(t *testing.T) {
+	token := &pageToken{Query: 123, Partition: 1, Key: testListItem("arn:aws:kms:key", "AWS.KMS.Key")}
+	encoded, err := encodePageToken(token)
+	require.NoError(t, err)
+
+	decoded, err := decodePageToken(encoded, 123, 2)
+	require.NoError(t, err)
+	assert.Equal(t, token, decoded)
+
+	_, err = decodePageToken(encoded, 456, 2)
+	assert.Error(t, err) // filters changed
+	_, err = decodePageToken(encoded, 123, 1)
+	assert.Error(t, err) // partition out of range
+	_, err = decodePageToken("not a token", 123, 2)
+	assert.Error(t, err)
+}
+
+func TestParseListResourcesPagination(t *testing.T) {
+	result, err := parseListResources(&events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{"pagination": "token", "pageToken": "abc"},
+		MultiValueQueryStringParameters: map[string][]string{
+			"filters": {"Tags.Environment=prod", "Tags.Team=security"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "token", *result.Pagination)
+	assert.Equal(t, "abc", *result.PageToken)
+	assert.Equal(t, []string{"Tags.Environment=prod", "Tags.Team=security"}, result.Filters)
+
+	// Token pagination is the default
+	result, err = parseListResources(&events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{"pageToken": "abc"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "token", *result.Pagination)
+
+	for _, query := range []map[string]string{
+		{"pagination": "offset", "pageToken": "abc"},
+		{"sortBy": "lastModified"},
+		{"page": "2"},
+	} {
+		_, err = parseListResources(&events.APIGatewayProxyRequest{QueryStringParameters: query})
+		assert.Error(t, err, query)
+	}
+
+	result, err = parseListResources(&events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{"pagination": "offset", "sortBy": "lastModified", "page": "2"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), *result.Page)
+}
+
+func TestListResourcePage(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	dynamoClient = mockClient
+	env.ResourcesTable = "test-resources"
+
+	params := operations.NewListResourcesParams()
+	params.Fields = []string{"id", "type"}
+	params.PageSize = aws.Int64(2)
+	params.Types = []string{"AWS.S3.Bucket", "AWS.KMS.Key"}
+	query := buildListQuery(params, nil)
+
+	// Page 1: the first KMS key, then the page is full
+	mockClient.On("Query", mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+		return *input.Limit == 2 && input.ExclusiveStartKey == nil &&
+			*input.ExpressionAttributeValues[":0"].S == "AWS.KMS.Key"
+	})).Return(&dynamodb.QueryOutput{
+		Items:            []map[string]*dynamodb.AttributeValue{testListItem("key-1", "AWS.KMS.Key")},
+		LastEvaluatedKey: testListItem("key-1", "AWS.KMS.Key"),
+	}, nil).Once()
+	mockClient.On("Query", mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+		return *input.Limit == 1 && input.ExclusiveStartKey != nil
+	})).Return(&dynamodb.QueryOutput{
+		Items:            []map[string]*dynamodb.AttributeValue{testListItem("key-2", "AWS.KMS.Key")},
+		LastEvaluatedKey: testListItem("key-2", "AWS.KMS.Key"),
+	}, nil).Once()
+
+	page, err := listResourcePage(query, params, &pageToken{Query: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []*models.Resource{
+		{ID: "key-1", Type: "AWS.KMS.Key"},
+		{ID: "key-2", Type: "AWS.KMS.Key"},
+	}, page.Resources)
+	require.NotEmpty(t, page.NextPageToken)
+	mockClient.AssertExpectations(t)
+
+	// Page 2: the KMS keys are done, then the only bucket
+	token, err := decodePageToken(page.NextPageToken, 1, query.partitions())
+	require.NoError(t, err)
+	mockClient.On("Query", mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+		return *input.ExpressionAttributeValues[":0"].S == "AWS.KMS.Key"
+	})).Return(&dynamodb.QueryOutput{}, nil).Once()
+	mockClient.On("Query", mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+		return *input.ExpressionAttributeValues[":0"].S == "AWS.S3.Bucket" && input.ExclusiveStartKey == nil
+	})).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{testListItem("bucket-1", "AWS.S3.Bucket")},
+	}, nil).Once()
+
+	page, err = listResourcePage(query, params, token)
+	require.NoError(t, err)
+	assert.Equal(t, []*models.Resource{{ID: "bucket-1", Type: "AWS.S3.Bucket"}}, page.Resources)
+	assert.Empty(t, page.NextPageToken)
+	mockClient.AssertExpectations(t)
+}
+
+func TestListResourcePageStoredStatus(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	dynamoClient = mockClient
+	env.ResourcesTable = "test-resources"
+
+	params := operations.NewListResourcesParams()
+	params.Fields = []string{"complianceStatus", "id"}
+	query := buildListQuery(params, nil)
+
+	failing := testListItem("bucket-1", "AWS.S3.Bucket")
+	failing["complianceStatus"] = &dynamodb.AttributeValue{S: aws.String("FAIL")}
+	mockClient.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{failing, testListItem("bucket-2", "AWS.S3.Bucket")},
+	}, nil).Once()
+	mockClient.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{}, nil).Once()
+
+	// The status is read from the resources table instead of the compliance-api
+	page, err := listResourcePage(query, params, &pageToken{Query: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []*models.Resource{
+		{ComplianceStatus: "FAIL", ID: "bucket-1", Type: "AWS.S3.Bucket"},
+		{ComplianceStatus: "PASS", ID: "bucket-2", Type: "AWS.S3.Bucket"},
+	}, page.Resources)
+	assert.Empty(t, page.NextPageToken)
+	mockClient.AssertExpectations(t)
+}
@@ -29,8 +29,6 @@ import (
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/dynamodb"
-	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
 	"github.com/go-openapi/strfmt"
 	"go.uber.org/zap"
 
@@ -71,12 +69,31 @@ func ListResources(request *events.APIGatewayProxyRequest) *events.APIGatewayPro
 		return badRequest(err)
 	}
 
-	scanInput, err := buildListScan(params)
+	filters, err := parseAttributeFilters(params.Filters)
 	if err != nil {
-		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		return badRequest(err)
+	}
+	query := buildListQuery(params, filters)
+
+	if aws.StringValue(params.Pagination) == "token" {
+		var token *pageToken
+		queryHash := listQueryHash(params, filters)
+		if params.PageToken != nil {
+			if token, err = decodePageToken(*params.PageToken, queryHash, query.partitions()); err != nil {
+				return badRequest(err)
+			}
+		} else {
+			token = &pageToken{Query: queryHash}
+		}
+
+		result, err := listResourcePage(query, params, token)
+		if err != nil {
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		}
+		return gatewayapi.MarshalResponse(result, http.StatusOK)
 	}
 
-	resources, err := listFilteredResources(scanInput, params)
+	resources, err := listFilteredResources(query, params)
 	if err != nil {
 		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
 	}
@@ -139,6 +156,12 @@ func parseListResources(request *events.APIGatewayProxyRequest) (*operations.Lis
 		result.Types = strings.Split(types, ",")
 	}
 
+	if filters := request.MultiValueQueryStringParameters["filters"]; len(filters) > 0 {
+		result.Filters = filters
+	} else if filter := request.QueryStringParameters["filters"]; filter != "" {
+		result.Filters = []string{filter}
+	}
+
 	// ***** Projection *****
 	if fields := request.QueryStringParameters["fields"]; fields != "" {
 		for _, field := range strings.Split(fields, ",") {
@@ -188,112 +211,131 @@ func parseListResources(request *events.APIGatewayProxyRequest) (*operations.Lis
 		result.Page = aws.Int64(page)
 	}
 
-	return result, nil
-}
+	if pagination := request.QueryStringParameters["pagination"]; pagination != "" {
+		if pagination != "offset" && pagination != "token" {
+			return nil, errors.New("invalid pagination: must be offset or token")
+		}
+		result.Pagination = aws.String(pagination)
+	}
 
-func buildListScan(params *operations.ListResourcesParams) (*dynamodb.ScanInput, error) {
-	var projection expression.ProjectionBuilder
-	for i, field := range params.Fields {
-		if field == "complianceStatus" {
-			continue
+	if aws.StringValue(result.Pagination) == "token" {
+		// Index order can't be changed and there are no page numbers
+		if sortBy := aws.StringValue(result.SortBy); sortBy != "id" {
+			return nil, errors.New("invalid sortBy: " + sortBy + " requires pagination=offset")
+		}
+		if request.QueryStringParameters["page"] != "" {
+			return nil, errors.New("invalid page: page requires pagination=offset")
 		}
+	}
 
-		if i == 0 {
-			projection = expression.NamesList(expression.Name(field))
-		} else {
-			projection = projection.AddNames(expression.Name(field))
+	if token := request.QueryStringParameters["pageToken"]; token != "" {
+		if aws.StringValue(result.Pagination) != "token" {
+			return nil, errors.New("pageToken requires pagination=token")
 		}
+		result.PageToken = aws.String(token)
 	}
 
-	// Start with a dummy filter just so we have one we can add onto.
-	filter := expression.AttributeExists(expression.Name("type"))
+	return result, nil
+}
 
-	if params.Deleted != nil {
-		filter = filter.And(expression.Equal(
-			expression.Name("deleted"), expression.Value(*params.Deleted)))
-	}
+// Read every matching resource, applying the compliance status filter before returning the results
+func listFilteredResources(query *listQuery, params *operations.ListResourcesParams) ([]*models.Resource, error) {
+	result := make([]*models.Resource, 0)
+	includeCompliance := includeComplianceStatus(params)
 
-	if params.IDContains != nil {
-		filter = filter.And(expression.Contains(expression.Name("lowerId"), *params.IDContains))
-	}
+	err := query.fetchAll(func(item *resourceItem) error {
+		resource, err := filterCompliance(item, params, includeCompliance)
+		if err != nil || resource == nil {
+			return err
+		}
 
-	if params.IntegrationID != nil {
-		filter = filter.And(expression.Equal(
-			expression.Name("integrationId"), expression.Value(*params.IntegrationID)))
-	}
-	if params.IntegrationType != nil {
-		filter = filter.And(expression.Equal(
-			expression.Name("integrationType"), expression.Value(*params.IntegrationType)))
-	}
+		// Resource passed all of the filters - add it to the result set
+		result = append(result, resource)
+		return nil
+	})
 
-	if len(params.Types) > 0 {
-		var typeFilter expression.ConditionBuilder
-		nameExpression := expression.Name("type")
+	return result, err
+}
+
+// Read a single page of resources, starting from the position in the continuation token
+func listResourcePage(
+	query *listQuery, params *operations.ListResourcesParams, token *pageToken) (*models.ResourceList, error) {
+
+	pageSize := int(*params.PageSize)
+	includeCompliance := includeComplianceStatus(params)
+	result := &models.ResourceList{Resources: make([]*models.Resource, 0, pageSize)}
 
-		// Chain OR filters to match one of the specified resource types
-		for i, resourceType := range params.Types {
-			if i == 0 {
-				typeFilter = expression.Equal(nameExpression, expression.Value(resourceType))
-			} else {
-				typeFilter = typeFilter.Or(expression.Equal(nameExpression, expression.Value(resourceType)))
+	// Each request reads at most the remaining page size, so the page never overflows and the
+	// LastEvaluatedKey is always the correct place to resume.
+	for requests := 0; requests < maxPageRequests && len(result.Resources) < pageSize; requests++ {
+		limit := int64(pageSize - len(result.Resources))
+		items, lastKey, err := query.fetch(token.Partition, token.Key, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		// The compliance status is stored with each resource and has already been filtered
+		for _, item := range items {
+			status := item.ComplianceStatus
+			if status == "" && includeCompliance {
+				status = models.ComplianceStatusPASS
 			}
+			result.Resources = append(result.Resources, item.Resource(status))
 		}
 
-		filter = filter.And(typeFilter)
-	}
+		token.Key = lastKey
+		if lastKey != nil {
+			continue
+		}
 
-	expr, err := expression.NewBuilder().
-		WithFilter(filter).
-		WithProjection(projection).
-		Build()
+		// This partition is done - move on to the next one
+		token.Partition++
+		if token.Partition >= query.partitions() {
+			return result, nil // no more results, the nextPageToken is empty
+		}
+	}
 
+	nextToken, err := encodePageToken(token)
 	if err != nil {
-		zap.L().Error("failed to build list query", zap.Error(err))
+		zap.L().Error("failed to encode pageToken", zap.Error(err))
 		return nil, err
 	}
-
-	return &dynamodb.ScanInput{
-		ExpressionAttributeNames:  expr.Names(),
-		ExpressionAttributeValues: expr.Values(),
-		FilterExpression:          expr.Filter(),
-		ProjectionExpression:      expr.Projection(),
-		TableName:                 &env.ResourcesTable,
-	}, nil
+	result.NextPageToken = nextToken
+	return result, nil
 }
 
-// Scan the table for resources, applying additional filters before returning the results
-func listFilteredResources(scanInput *dynamodb.ScanInput, params *operations.ListResourcesParams) ([]*models.Resource, error) {
-	result := make([]*models.Resource, 0)
-	includeCompliance := false
+// Compliance status is needed to filter or to include it in the result
+func includeComplianceStatus(params *operations.ListResourcesParams) bool {
+	if params.ComplianceStatus != nil {
+		return true
+	}
 	for _, field := range params.Fields {
 		if field == "complianceStatus" {
-			includeCompliance = true
-			break
+			return true
 		}
 	}
+	return false
+}
 
-	err := scanPages(scanInput, func(item *resourceItem) error {
-		if !includeCompliance {
-			result = append(result, item.Resource(""))
-			return nil
-		}
-
-		status, err := getComplianceStatus(item.ID)
-		if err != nil {
-			return err
-		}
+// Compliance status isn't stored in the resources table, so we filter it out here if needed.
+//
+// Returns nil if the resource does not have the requested status.
+func filterCompliance(
+	item *resourceItem, params *operations.ListResourcesParams, includeCompliance bool) (*models.Resource, error) {
 
-		// Compliance status isn't stored in this table, so we filter it out here if needed
-		if params.ComplianceStatus != nil && *params.ComplianceStatus != string(status.Status) {
-			return nil
-		}
+	if !includeCompliance {
+		return item.Resource(""), nil
+	}
 
-		// Resource passed all of the filters - add it to the result set
-		result = append(result, item.Resource(status.Status))
-		return nil
-	})
+	status, err := getComplianceStatus(item.ID)
+	if err != nil {
+		return nil, err
+	}
 
-	return result, err
+	if params.ComplianceStatus != nil && *params.ComplianceStatus != string(status.Status) {
+		return nil, nil
+	}
+	return item.Resource(status.Status), nil
 }
 
 func sortResources(resources []*models.Resource, sortBy string, ascending bool) {
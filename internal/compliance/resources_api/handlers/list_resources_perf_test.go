@@ -0,0 +1,259 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Performance tests for ListResources against a local DynamoDB stand-in.
+//
+// These are skipped unless DYNAMODB_LOCAL_ENDPOINT is set, for example:
+//
+//     docker run -d -p 8000:8000 amazon/dynamodb-local
+//     DYNAMODB_LOCAL_ENDPOINT=http://localhost:8000 go test ./internal/compliance/resources_api/handlers -run TestPerf -v
+//
+// PERF_RESOURCE_COUNT controls the table size (default 20000).
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/gateway/resources/client/operations"
+	"github.com/panther-labs/panther/api/gateway/resources/models"
+	"github.com/panther-labs/panther/pkg/awsbatch/dynamodbbatch"
+)
+
+const (
+	perfIntegrations = 80
+	perfPageSize     = 1000
+)
+
+var perfTypes = []string{"AWS.EC2.Instance", "AWS.IAM.Role", "AWS.KMS.Key", "AWS.S3.Bucket"}
+
+// Counts the Dynamo requests issued by the list handlers
+type countingDynamo struct {
+	dynamodbiface.DynamoDBAPI
+	requests int
+}
+
+func (c *countingDynamo) Query(input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+	c.requests++
+	return c.DynamoDBAPI.Query(input)
+}
+
+func (c *countingDynamo) Scan(input *dynamodb.ScanInput) (*dynamodb.ScanOutput, error) {
+	c.requests++
+	return c.DynamoDBAPI.Scan(input)
+}
+
+func perfIntegrationID(i int) string {
+	return fmt.Sprintf("00000000-0000-0000-0000-%012d", i%perfIntegrations)
+}
+
+// Create a resources table with the same key schema and indices as cloud_security.yml (ResourcesTableIndexes)
+func createPerfTable(t *testing.T, client dynamodbiface.DynamoDBAPI, name string) {
+	index := func(name, key string) *dynamodb.GlobalSecondaryIndex {
+		return &dynamodb.GlobalSecondaryIndex{
+			IndexName: aws.String(name),
+			KeySchema: []*dynamodb.KeySchemaElement{
+				{AttributeName: aws.String(key), KeyType: aws.String("HASH")},
+				{AttributeName: aws.String("id"), KeyType: aws.String("RANGE")},
+			},
+			Projection: &dynamodb.Projection{ProjectionType: aws.String("ALL")},
+		}
+	}
+
+	_, err := client.CreateTable(&dynamodb.CreateTableInput{
+		AttributeDefinitions: []*dynamodb.AttributeDefinition{
+			{AttributeName: aws.String("complianceStatus"), AttributeType: aws.String("S")},
+			{AttributeName: aws.String("deletedKey"), AttributeType: aws.String("S")},
+			{AttributeName: aws.String("id"), AttributeType: aws.String("S")},
+			{AttributeName: aws.String("integrationId"), AttributeType: aws.String("S")},
+			{AttributeName: aws.String("type"), AttributeType: aws.String("S")},
+		},
+		BillingMode: aws.String("PAY_PER_REQUEST"),
+		GlobalSecondaryIndexes: []*dynamodb.GlobalSecondaryIndex{
+			index(typeIndex, "type"),
+			index(integrationIndex, "integrationId"),
+			index(deletedIndex, "deletedKey"),
+			index(statusIndex, "complianceStatus"),
+		},
+		KeySchema: []*dynamodb.KeySchemaElement{{AttributeName: aws.String("id"), KeyType: aws.String("HASH")}},
+		TableName: aws.String(name),
+	})
+	require.NoError(t, err)
+}
+
+func loadPerfTable(t *testing.T, client dynamodbiface.DynamoDBAPI, name string, count int) {
+	writes := make([]*dynamodb.WriteRequest, 0, count)
+	for i := 0; i < count; i++ {
+		environment := "dev"
+		if i%10 == 0 {
+			environment = "prod"
+		}
+		status := models.ComplianceStatusPASS
+		if i%7 == 0 {
+			status = models.ComplianceStatusFAIL
+		}
+		id := fmt.Sprintf("resource-%07d", i)
+		item, err := dynamodbattribute.MarshalMap(&resourceItem{
+			Attributes:       map[string]interface{}{"Tags": map[string]string{"Environment": environment}},
+			ComplianceStatus: status,
+			Deleted:          i%50 == 0,
+			DeletedKey:       deletedKey(i%50 == 0),
+			ID:               models.ResourceID(id),
+			IntegrationID:    models.IntegrationID(perfIntegrationID(i)),
+			IntegrationType:  "aws",
+			Type:             models.ResourceType(perfTypes[i%len(perfTypes)]),
+			LowerID:          id,
+		})
+		require.NoError(t, err)
+		writes = append(writes, &dynamodb.WriteRequest{PutRequest: &dynamodb.PutRequest{Item: item}})
+	}
+
+	require.NoError(t, dynamodbbatch.BatchWriteItem(client, time.Minute, &dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]*dynamodb.WriteRequest{name: writes},
+	}))
+}
+
+// Page through every matching resource, returning the number of resources and pages
+func listAllPages(t *testing.T, client *countingDynamo, params *operations.ListResourcesParams) (int, int) {
+	filters, err := parseAttributeFilters(params.Filters)
+	require.NoError(t, err)
+	query := buildListQuery(params, filters)
+	token := &pageToken{Query: listQueryHash(params, filters)}
+
+	resources, pages := 0, 0
+	for {
+		client.requests = 0
+		start := time.Now()
+		page, err := listResourcePage(query, params, token)
+		require.NoError(t, err)
+		pages++
+		resources += len(page.Resources)
+
+		// Every page is bounded, no matter how large the table is
+		assert.LessOrEqual(t, client.requests, maxPageRequests)
+		assert.LessOrEqual(t, len(page.Resources), perfPageSize)
+		t.Logf("page %d: %d resources, %d requests, %v", pages, len(page.Resources), client.requests, time.Since(start))
+
+		if page.NextPageToken == "" {
+			return resources, pages
+		}
+		token, err = decodePageToken(page.NextPageToken, token.Query, query.partitions())
+		require.NoError(t, err)
+	}
+}
+
+func TestPerfListResources(t *testing.T) {
+	endpoint := os.Getenv("DYNAMODB_LOCAL_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("DYNAMODB_LOCAL_ENDPOINT is not set")
+	}
+
+	count := 20000
+	if raw := os.Getenv("PERF_RESOURCE_COUNT"); raw != "" {
+		var err error
+		count, err = strconv.Atoi(raw)
+		require.NoError(t, err)
+	}
+
+	localSession := session.Must(session.NewSession(&aws.Config{
+		Credentials: credentials.NewStaticCredentials("local", "local", ""),
+		Endpoint:    aws.String(endpoint),
+		Region:      aws.String("us-east-1"),
+	}))
+	client := &countingDynamo{DynamoDBAPI: dynamodb.New(localSession)}
+	dynamoClient = client
+	env.ResourcesTable = fmt.Sprintf("perf-resources-%d", time.Now().UnixNano())
+
+	createPerfTable(t, client, env.ResourcesTable)
+	defer func() {
+		_, _ = client.DeleteTable(&dynamodb.DeleteTableInput{TableName: &env.ResourcesTable})
+	}()
+
+	start := time.Now()
+	loadPerfTable(t, client, env.ResourcesTable, count)
+	t.Logf("loaded %d resources in %v", count, time.Since(start))
+
+	newParams := func() *operations.ListResourcesParams {
+		params := operations.NewListResourcesParams()
+		params.Fields = defaultFields
+		params.PageSize = aws.Int64(perfPageSize)
+		return params
+	}
+
+	t.Run("All", func(t *testing.T) {
+		resources, _ := listAllPages(t, client, newParams())
+		assert.Equal(t, count, resources)
+	})
+
+	t.Run("ByStatus", func(t *testing.T) {
+		params := newParams()
+		params.ComplianceStatus = aws.String("FAIL")
+		resources, _ := listAllPages(t, client, params)
+		assert.Equal(t, (count+6)/7, resources)
+	})
+
+	t.Run("ByType", func(t *testing.T) {
+		params := newParams()
+		params.Types = []string{"AWS.S3.Bucket", "AWS.KMS.Key"}
+		resources, _ := listAllPages(t, client, params)
+		assert.Equal(t, count/2, resources)
+	})
+
+	t.Run("ByIntegration", func(t *testing.T) {
+		params := newParams()
+		params.IntegrationID = aws.String(perfIntegrationID(0))
+		params.Deleted = aws.Bool(false)
+		resources, _ := listAllPages(t, client, params)
+
+		expected := 0
+		for i := 0; i < count; i += perfIntegrations {
+			if i%50 != 0 {
+				expected++
+			}
+		}
+		assert.Equal(t, expected, resources)
+	})
+
+	t.Run("AttributeFilter", func(t *testing.T) {
+		params := newParams()
+		params.Types = []string{"AWS.S3.Bucket"}
+		params.Filters = []string{"Tags.Environment=prod"}
+		resources, _ := listAllPages(t, client, params)
+
+		expected := 0
+		for i := 0; i < count; i += len(perfTypes) {
+			if i%10 == 0 {
+				expected++
+			}
+		}
+		assert.Equal(t, expected, resources)
+	})
+}
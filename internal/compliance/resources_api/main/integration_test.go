@@ -97,6 +97,7 @@ func TestIntegrationAPI(t *testing.T) {
 
 	// Reset Dynamo tables and build API client
 	require.NoError(t, testutils.ClearDynamoTable(awsSession, "panther-resources"))
+	require.NoError(t, testutils.ClearDynamoTable(awsSession, "panther-resource-history"))
 	require.NoError(t, testutils.ClearDynamoTable(awsSession, "panther-compliance"))
 	require.NotEmpty(t, endpoint)
 	apiClient = client.NewHTTPClientWithConfig(nil, client.DefaultTransportConfig().
@@ -126,6 +127,7 @@ func TestIntegrationAPI(t *testing.T) {
 		t.Run("ListAll", listAll)
 		t.Run("ListPaged", listPaged)
 		t.Run("ListFiltered", listFiltered)
+		t.Run("ListTokenPaged", listTokenPaged)
 	})
 
 	t.Run("DeleteResources", func(t *testing.T) {
@@ -133,6 +135,10 @@ func TestIntegrationAPI(t *testing.T) {
 		t.Run("DeleteNotFound", deleteNotFound)
 		t.Run("DeleteSuccess", deleteSuccess)
 	})
+
+	t.Run("ResourceHistory", func(t *testing.T) {
+		t.Run("HistorySuccess", historySuccess)
+	})
 }
 
 func addEmpty(t *testing.T) {
@@ -263,6 +269,7 @@ func getSuccess(t *testing.T) {
 func listAll(t *testing.T) {
 	result, err := apiClient.Operations.ListResources(
 		&operations.ListResourcesParams{
+			Pagination: aws.String("offset"),
 			HTTPClient: httpClient,
 		})
 	require.NoError(t, err)
@@ -313,6 +320,7 @@ func listPaged(t *testing.T) {
 	result, err := apiClient.Operations.ListResources(
 		&operations.ListResourcesParams{
 			PageSize:   aws.Int64(1),
+			Pagination: aws.String("offset"),
 			SortDir:    aws.String("descending"), // sort by ID descending
 			HTTPClient: httpClient,
 		})
@@ -343,6 +351,7 @@ func listPaged(t *testing.T) {
 		&operations.ListResourcesParams{
 			Page:       aws.Int64(2),
 			PageSize:   aws.Int64(1),
+			Pagination: aws.String("offset"),
 			SortDir:    aws.String("descending"),
 			HTTPClient: httpClient,
 		})
@@ -373,6 +382,7 @@ func listPaged(t *testing.T) {
 		&operations.ListResourcesParams{
 			Page:       aws.Int64(3),
 			PageSize:   aws.Int64(1),
+			Pagination: aws.String("offset"),
 			SortDir:    aws.String("descending"),
 			HTTPClient: httpClient,
 		})
@@ -399,6 +409,46 @@ func listPaged(t *testing.T) {
 	assert.Equal(t, expected, result.Payload)
 }
 
+func listTokenPaged(t *testing.T) {
+	var ids []models.ResourceID
+	var pageToken *string
+	for pages := 0; pages < 10; pages++ {
+		result, err := apiClient.Operations.ListResources(
+			&operations.ListResourcesParams{
+				Filters:    []string{"Panther=Labs"},
+				PageSize:   aws.Int64(1),
+				PageToken:  pageToken,
+				Pagination: aws.String("token"),
+				Types:      []string{string(bucket.Type), string(key.Type)},
+				HTTPClient: httpClient,
+			})
+		require.NoError(t, err)
+		assert.Nil(t, result.Payload.Paging)
+
+		for _, resource := range result.Payload.Resources {
+			ids = append(ids, resource.ID)
+		}
+		if result.Payload.NextPageToken == "" {
+			break
+		}
+		pageToken = &result.Payload.NextPageToken
+	}
+
+	// Resources are listed by type, then by ID
+	assert.Equal(t, []models.ResourceID{key.ID, bucket.ID}, ids)
+
+	// No resources match the attribute filter
+	result, err := apiClient.Operations.ListResources(
+		&operations.ListResourcesParams{
+			Filters:    []string{"Panther!=Labs"},
+			Pagination: aws.String("token"),
+			HTTPClient: httpClient,
+		})
+	require.NoError(t, err)
+	assert.Empty(t, result.Payload.Resources)
+	assert.Empty(t, result.Payload.NextPageToken)
+}
+
 func listFiltered(t *testing.T) {
 	result, err := apiClient.Operations.ListResources(
 		&operations.ListResourcesParams{
@@ -407,6 +457,7 @@ func listFiltered(t *testing.T) {
 			IDContains:      aws.String("MY"), // queue + bucket
 			IntegrationID:   aws.String(string(bucket.IntegrationID)),
 			IntegrationType: aws.String(string(bucket.IntegrationType)),
+			Pagination:      aws.String("offset"),
 			Types:           []string{"AWS.S3.Bucket"},
 			HTTPClient:      httpClient,
 		})
@@ -510,6 +561,7 @@ func deleteSuccess(t *testing.T) {
 	list, err := apiClient.Operations.ListResources(
 		&operations.ListResourcesParams{
 			Deleted:    aws.Bool(false),
+			Pagination: aws.String("offset"),
 			HTTPClient: httpClient,
 		})
 	require.NoError(t, err)
@@ -528,8 +580,34 @@ func deleteSuccess(t *testing.T) {
 	list, err = apiClient.Operations.ListResources(
 		&operations.ListResourcesParams{
 			Deleted:    aws.Bool(true),
+			Pagination: aws.String("offset"),
 			HTTPClient: httpClient,
 		})
 	require.NoError(t, err)
 	assert.Len(t, list.Payload.Resources, 3)
 }
+
+func historySuccess(t *testing.T) {
+	result, err := apiClient.Operations.GetResourceHistory(&operations.GetResourceHistoryParams{
+		ResourceID: string(bucket.ID),
+		HTTPClient: httpClient,
+	})
+	require.NoError(t, err)
+
+	// The bucket was added and then deleted: newest version first
+	versions := result.Payload.Versions
+	require.Len(t, versions, 2)
+	assert.True(t, bool(versions[0].Deleted))
+	assert.Empty(t, versions[0].ChangedAttributes)
+	assert.False(t, bool(versions[1].Deleted))
+	assert.NotEmpty(t, versions[1].ChangedAttributes)
+
+	diff, err := apiClient.Operations.DiffResourceVersions(&operations.DiffResourceVersionsParams{
+		FromVersion: string(versions[1].Version),
+		ResourceID:  string(bucket.ID),
+		ToVersion:   string(versions[0].Version),
+		HTTPClient:  httpClient,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, diff.Payload.Changes)
+}
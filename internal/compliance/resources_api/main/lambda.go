@@ -31,6 +31,9 @@ var methodHandlers = map[string]gatewayapi.RequestHandler{
 	"GET /org-overview": handlers.OrgOverview,
 	"GET /resource":     handlers.GetResource,
 	"POST /resource":    handlers.AddResources,
+
+	"GET /resource/diff":    handlers.DiffResourceVersions,
+	"GET /resource/history": handlers.GetResourceHistory,
 }
 
 func main() {
@@ -23,9 +23,11 @@ import (
 
 	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers"
 	awspoller "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws"
+	gcppoller "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/gcp"
 )
 
 func main() {
 	awspoller.Setup()
+	gcppoller.Setup()
 	lambda.Start(pollers.Handle)
 }
@@ -0,0 +1,58 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/apigateway"
+)
+
+const (
+	APIGatewayStageSchema = "AWS.APIGateway.Stage"
+)
+
+// APIGatewayStage contains all the information about a stage of an API Gateway REST API
+type APIGatewayStage struct {
+	// Generic resource fields
+	GenericAWSResource
+	GenericResource
+
+	// Fields embedded from apigateway.Stage
+	AccessLogSettings    *apigateway.AccessLogSettings
+	CacheClusterEnabled  *bool
+	CacheClusterSize     *string
+	CacheClusterStatus   *string
+	CanarySettings       *apigateway.CanarySettings
+	ClientCertificateId  *string
+	DeploymentId         *string
+	Description          *string
+	DocumentationVersion *string
+	LastUpdatedDate      *time.Time
+	MethodSettings       map[string]*apigateway.MethodSetting
+	TracingEnabled       *bool
+	Variables            map[string]*string
+	WebAclArn            *string
+
+	// Fields embedded from the parent apigateway.RestApi
+	RestApiEndpointConfiguration *apigateway.EndpointConfiguration
+	RestApiId                    *string
+	RestApiName                  *string
+	RestApiPolicy                *string
+}
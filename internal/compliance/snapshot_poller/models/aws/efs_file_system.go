@@ -0,0 +1,49 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import "github.com/aws/aws-sdk-go/service/efs"
+
+const (
+	EfsFileSystemSchema = "AWS.EFS.FileSystem"
+)
+
+// EfsFileSystem contains all the information about an EFS File System
+type EfsFileSystem struct {
+	// Generic resource fields
+	GenericAWSResource
+	GenericResource
+
+	// Fields embedded from efs.FileSystemDescription
+	CreationToken                *string
+	Encrypted                    *bool
+	KmsKeyId                     *string
+	LifeCycleState               *string
+	NumberOfMountTargets         *int64
+	OwnerId                      *string
+	PerformanceMode              *string
+	ProvisionedThroughputInMibps *float64
+	SizeInBytes                  *efs.FileSystemSize
+	ThroughputMode               *string
+
+	// Additional fields
+	LifecyclePolicies []*efs.LifecyclePolicy
+	MountTargets      []*efs.MountTargetDescription
+	Policy            *string
+}
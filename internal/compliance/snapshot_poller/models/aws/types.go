@@ -23,6 +23,7 @@ import (
 	"github.com/go-openapi/strfmt"
 
 	resourcesapimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
 )
 
 // Used to populate the GenericAWSResource.Region field for global AWS resources
@@ -59,6 +60,16 @@ type GenericAWSResource struct {
 	ID   *string            `json:"Id,omitempty"`   // The AWS resource identifier
 	Name *string            `json:"Name,omitempty"` // The AWS resource name
 	Tags map[string]*string // A standardized format for key/value resource tags
+
+	// Fields populated only for accounts onboarded through an AWS Organizations integration
+	OrganizationalUnitID   *string `json:"OrganizationalUnitId,omitempty"`   // The root or OU directly above the account
+	OrganizationalUnitPath *string `json:"OrganizationalUnitPath,omitempty"` // OU names from the root down, e.g. "Root/Prod"
+}
+
+// SetOrganizationalUnit records the position of the resource's account in its AWS Organization.
+func (r *GenericAWSResource) SetOrganizationalUnit(id, path string) {
+	r.OrganizationalUnitID = &id
+	r.OrganizationalUnitPath = &path
 }
 
 // ResourcePollerInput contains the metadata to request AWS resource info.
@@ -68,6 +79,9 @@ type ResourcePollerInput struct {
 	IntegrationID       *string
 	Regions             []*string
 	Timestamp           *strfmt.DateTime
+
+	// Counts the AWS errors logged while polling a scan work unit, if set
+	Errors *utils.ErrorCounter
 }
 
 // ResourcePoller represents a function to poll a specific AWS resource.
@@ -0,0 +1,96 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+const (
+	ComputeInstanceSchema = "GCP.Compute.Instance"
+)
+
+// ComputeInstance contains all information about a Compute Engine VM instance
+type ComputeInstance struct {
+	// Generic resource fields
+	GenericGCPResource
+	awsmodels.GenericResource
+
+	// Fields embedded from compute.Instance
+	CanIPForward           *bool `json:"CanIpForward"`
+	DeletionProtection     *bool
+	Disks                  []*ComputeAttachedDisk
+	MachineType            *string
+	NetworkInterfaces      []*ComputeNetworkInterface
+	ServiceAccounts        []*ComputeServiceAccount
+	ShieldedInstanceConfig *ComputeShieldedInstanceConfig
+	Status                 *string
+	Zone                   *string
+
+	// Network tags, used by firewall rules to select instances
+	NetworkTags []*string
+
+	// Security relevant instance metadata, such as enable-oslogin. Other metadata (startup scripts,
+	// SSH keys, ...) may contain secrets and is not recorded.
+	Metadata map[string]*string
+}
+
+// ComputeAttachedDisk is a disk attached to an instance.
+type ComputeAttachedDisk struct {
+	AutoDelete        *bool
+	Boot              *bool
+	DeviceName        *string
+	DiskEncryptionKey *ComputeDiskEncryptionKey
+	Mode              *string
+	Source            *string
+}
+
+// ComputeDiskEncryptionKey identifies the customer managed key of a disk, if any.
+type ComputeDiskEncryptionKey struct {
+	KmsKeyName *string
+}
+
+// ComputeNetworkInterface connects an instance to a VPC network.
+type ComputeNetworkInterface struct {
+	AccessConfigs []*ComputeAccessConfig
+	Name          *string
+	Network       *string
+	NetworkIP     *string `json:"NetworkIP"`
+	Subnetwork    *string
+}
+
+// ComputeAccessConfig is an external IP address of an instance.
+type ComputeAccessConfig struct {
+	Name  *string
+	NatIP *string `json:"NatIP"`
+	Type  *string
+}
+
+// ComputeServiceAccount is the identity an instance runs as.
+type ComputeServiceAccount struct {
+	Email  *string
+	Scopes []*string
+}
+
+// ComputeShieldedInstanceConfig lists the Shielded VM features of an instance.
+type ComputeShieldedInstanceConfig struct {
+	EnableIntegrityMonitoring *bool
+	EnableSecureBoot          *bool
+	EnableVtpm                *bool
+}
@@ -0,0 +1,56 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+const (
+	IAMServiceAccountSchema = "GCP.IAM.ServiceAccount"
+)
+
+// IAMServiceAccount contains all information about a GCP service account
+type IAMServiceAccount struct {
+	// Generic resource fields
+	GenericGCPResource
+	awsmodels.GenericResource
+
+	// Fields embedded from iam.ServiceAccount
+	Description *string
+	Disabled    *bool
+	DisplayName *string
+	Email       *string
+	UniqueID    *string `json:"UniqueId"`
+
+	// Additional fields
+	IAMPolicy *IAMPolicy
+	Keys      []*IAMServiceAccountKey
+}
+
+// IAMServiceAccountKey is a key of a service account. Only key metadata is recorded.
+type IAMServiceAccountKey struct {
+	Disabled        *bool
+	KeyAlgorithm    *string
+	KeyOrigin       *string
+	KeyType         *string
+	Name            *string
+	ValidAfterTime  *string
+	ValidBeforeTime *string
+}
@@ -0,0 +1,59 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+const (
+	KMSCryptoKeySchema = "GCP.KMS.CryptoKey"
+)
+
+// KMSCryptoKey contains all information about a Cloud KMS crypto key
+type KMSCryptoKey struct {
+	// Generic resource fields
+	GenericGCPResource
+	awsmodels.GenericResource
+
+	// Fields embedded from cloudkms.CryptoKey
+	NextRotationTime *string
+	Primary          *KMSCryptoKeyVersion
+	Purpose          *string
+	RotationPeriod   *string
+	VersionTemplate  *KMSCryptoKeyVersionTemplate
+
+	// Additional fields
+	IAMPolicy *IAMPolicy
+	KeyRing   *string
+}
+
+// KMSCryptoKeyVersion is the version of a key used for encryption.
+type KMSCryptoKeyVersion struct {
+	Algorithm       *string
+	Name            *string
+	ProtectionLevel *string
+	State           *string
+}
+
+// KMSCryptoKeyVersionTemplate is the configuration of new key versions.
+type KMSCryptoKeyVersionTemplate struct {
+	Algorithm       *string
+	ProtectionLevel *string
+}
@@ -0,0 +1,48 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+const (
+	ProjectSchema = "GCP.Project"
+)
+
+// Project contains all information about a GCP project
+type Project struct {
+	// Generic resource fields
+	GenericGCPResource
+	awsmodels.GenericResource
+
+	// Fields embedded from cloudresourcemanager.Project
+	LifecycleState *string
+	Parent         *ProjectParent
+	ProjectNumber  *string
+
+	// Additional fields
+	IAMPolicy *IAMPolicy
+}
+
+// ProjectParent is the folder or organization containing a project.
+type ProjectParent struct {
+	ID   *string `json:"Id"`
+	Type *string
+}
@@ -0,0 +1,93 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+const (
+	SQLInstanceSchema = "GCP.SQL.Instance"
+)
+
+// SQLInstance contains all information about a Cloud SQL instance
+type SQLInstance struct {
+	// Generic resource fields
+	GenericGCPResource
+	awsmodels.GenericResource
+
+	// Fields embedded from sqladmin.DatabaseInstance
+	BackendType                 *string
+	DatabaseVersion             *string
+	DiskEncryptionConfiguration *SQLDiskEncryptionConfiguration
+	GceZone                     *string
+	InstanceType                *string
+	IPAddresses                 []*SQLIPMapping `json:"IpAddresses"`
+	ServiceAccountEmailAddress  *string
+	Settings                    *SQLSettings
+	State                       *string
+}
+
+// SQLDiskEncryptionConfiguration identifies the customer managed key of an instance, if any.
+type SQLDiskEncryptionConfiguration struct {
+	KmsKeyName *string
+}
+
+// SQLIPMapping is an IP address assigned to an instance.
+type SQLIPMapping struct {
+	IPAddress *string `json:"IpAddress"`
+	Type      *string
+}
+
+// SQLSettings are the user settings of an instance.
+type SQLSettings struct {
+	ActivationPolicy    *string
+	AvailabilityType    *string
+	BackupConfiguration *SQLBackupConfiguration
+	DatabaseFlags       []*SQLDatabaseFlag
+	IPConfiguration     *SQLIPConfiguration `json:"IpConfiguration"`
+	Tier                *string
+}
+
+// SQLBackupConfiguration reports whether automated backups are enabled.
+type SQLBackupConfiguration struct {
+	BinaryLogEnabled           *bool
+	Enabled                    *bool
+	PointInTimeRecoveryEnabled *bool
+}
+
+// SQLDatabaseFlag is a database engine flag set on an instance.
+type SQLDatabaseFlag struct {
+	Name  *string
+	Value *string
+}
+
+// SQLIPConfiguration controls how an instance can be reached.
+type SQLIPConfiguration struct {
+	AuthorizedNetworks []*SQLAuthorizedNetwork
+	IPv4Enabled        *bool `json:"Ipv4Enabled"`
+	PrivateNetwork     *string
+	RequireSsl         *bool
+}
+
+// SQLAuthorizedNetwork is a CIDR range allowed to connect to an instance.
+type SQLAuthorizedNetwork struct {
+	Name  *string
+	Value *string
+}
@@ -0,0 +1,84 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+const (
+	StorageBucketSchema = "GCP.Storage.Bucket"
+)
+
+// StorageBucket contains all information about a Cloud Storage bucket
+type StorageBucket struct {
+	// Generic resource fields
+	GenericGCPResource
+	awsmodels.GenericResource
+
+	// Fields embedded from storage.Bucket
+	DefaultEventBasedHold *bool
+	Encryption            *BucketEncryption
+	IamConfiguration      *BucketIamConfiguration
+	Location              *string
+	LocationType          *string
+	Logging               *BucketLogging
+	ProjectNumber         *string
+	RetentionPolicy       *BucketRetentionPolicy
+	StorageClass          *string
+	Versioning            *BucketVersioning
+
+	// Additional fields
+	IAMPolicy *IAMPolicy
+}
+
+// BucketEncryption holds the default Cloud KMS key of a bucket.
+type BucketEncryption struct {
+	DefaultKmsKeyName *string
+}
+
+// BucketIamConfiguration controls how access to a bucket is granted.
+type BucketIamConfiguration struct {
+	PublicAccessPrevention   *string
+	UniformBucketLevelAccess *BucketUniformBucketLevelAccess
+}
+
+// BucketUniformBucketLevelAccess reports whether object ACLs are disabled.
+type BucketUniformBucketLevelAccess struct {
+	Enabled    *bool
+	LockedTime *string
+}
+
+// BucketLogging is the access log configuration of a bucket.
+type BucketLogging struct {
+	LogBucket       *string
+	LogObjectPrefix *string
+}
+
+// BucketRetentionPolicy is the minimum time objects are kept in a bucket.
+type BucketRetentionPolicy struct {
+	EffectiveTime   *string
+	IsLocked        *bool
+	RetentionPeriod *string
+}
+
+// BucketVersioning reports whether object versioning is enabled.
+type BucketVersioning struct {
+	Enabled *bool
+}
@@ -0,0 +1,58 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+// Used to populate the GenericGCPResource.Region field for resources which are not tied to a region
+const GlobalRegion = awsmodels.GlobalRegion
+
+// GenericGCPResource contains information that is standard across GCP resources
+type GenericGCPResource struct {
+	// As for AWS resources, ID and Name are omitted when they are not populated while
+	// ProjectID, Region and Labels are always sent downstream.
+	ProjectID *string            `json:"ProjectId"` // The ID of the GCP project the resource resides in
+	Region    *string            `json:"Region"`    // The region or multi-region of the resource, GlobalRegion if global
+	ID        *string            `json:"Id,omitempty"`
+	Name      *string            `json:"Name,omitempty"`
+	Labels    map[string]*string // User defined key/value labels, the GCP equivalent of tags
+}
+
+// IAMPolicy is the IAM policy attached to a GCP resource.
+type IAMPolicy struct {
+	Bindings []*IAMBinding
+	Etag     *string
+	Version  *int64
+}
+
+// IAMBinding grants a role to a list of members, optionally subject to a condition.
+type IAMBinding struct {
+	Condition *IAMCondition
+	Members   []*string
+	Role      *string
+}
+
+// IAMCondition is the CEL expression restricting an IAM binding.
+type IAMCondition struct {
+	Description *string
+	Expression  *string
+	Title       *string
+}
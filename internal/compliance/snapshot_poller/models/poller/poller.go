@@ -34,4 +34,16 @@ type ScanEntry struct {
 	ResourceID       *string `json:"resourceId"`
 	ResourceType     *string `json:"resourceType"`
 	ScanAllResources *bool   `json:"scanAllResources"`
+
+	// Identifies the checkpoints of a resumable account-wide scan, which is requeued with the
+	// same ID until all its work units are done
+	ScanID *string `json:"scanId,omitempty"`
+
+	// Set instead of AWSAccountID for scans of gcp-scan integrations
+	GCPProjectID *string `json:"gcpProjectId,omitempty"`
+
+	// The CloudTrail event which requested this scan (if any), recorded in the resource history
+	EventID   *string `json:"eventId,omitempty"`
+	EventName *string `json:"eventName,omitempty"`
+	EventTime *string `json:"eventTime,omitempty"`
 }
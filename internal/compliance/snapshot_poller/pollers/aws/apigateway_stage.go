@@ -0,0 +1,248 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/aws/aws-sdk-go/service/apigateway/apigatewayiface"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Set as variables to be overridden in testing
+var (
+	APIGatewayClientFunc = setupAPIGatewayClient
+)
+
+func setupAPIGatewayClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return apigateway.New(sess, cfg)
+}
+
+func getAPIGatewayClient(
+	pollerResourceInput *awsmodels.ResourcePollerInput, region string) (apigatewayiface.APIGatewayAPI, error) {
+
+	client, err := getClient(pollerResourceInput, APIGatewayClientFunc, "apigateway", region)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	return client.(apigatewayiface.APIGatewayAPI), nil
+}
+
+// PollAPIGatewayStage polls a single API Gateway Stage resource
+func PollAPIGatewayStage(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getAPIGatewayClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	// The resource portion of the ARN is of the form /restapis/{id}/stages/{name}
+	resourceSplit := strings.Split(resourceARN.Resource, "/")
+	if len(resourceSplit) != 5 || resourceSplit[1] != "restapis" || resourceSplit[3] != "stages" {
+		zap.L().Error("unable to parse API Gateway stage ARN", zap.String("resource", resourceARN.String()))
+		return nil, nil
+	}
+
+	restAPI, err := getRestAPI(client, aws.String(resourceSplit[2]))
+	if err != nil || restAPI == nil {
+		return nil, err
+	}
+	stage, err := getAPIGatewayStage(client, restAPI.Id, aws.String(resourceSplit[4]))
+	if err != nil || stage == nil {
+		return nil, err
+	}
+
+	snapshot := buildAPIGatewayStageSnapshot(restAPI, stage, resourceARN.Region)
+	if snapshot == nil {
+		return nil, nil
+	}
+	// API Gateway ARNs do not include the account ID
+	snapshot.AccountID = aws.String(pollerResourceInput.AuthSourceParsedARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// listRestAPIs returns all REST APIs in the region
+func listRestAPIs(apigatewaySvc apigatewayiface.APIGatewayAPI) (restAPIs []*apigateway.RestApi) {
+	err := apigatewaySvc.GetRestApisPages(&apigateway.GetRestApisInput{},
+		func(page *apigateway.GetRestApisOutput, lastPage bool) bool {
+			restAPIs = append(restAPIs, page.Items...)
+			return true
+		})
+	if err != nil {
+		utils.LogAWSError("APIGateway.GetRestApisPages", err)
+	}
+	return
+}
+
+// getRestAPI returns a single REST API
+func getRestAPI(apigatewaySvc apigatewayiface.APIGatewayAPI, restAPIID *string) (*apigateway.RestApi, error) {
+	restAPI, err := apigatewaySvc.GetRestApi(&apigateway.GetRestApiInput{RestApiId: restAPIID})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == apigateway.ErrCodeNotFoundException {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *restAPIID),
+				zap.String("resourceType", awsmodels.APIGatewayStageSchema))
+			return nil, nil
+		}
+		utils.LogAWSError("APIGateway.GetRestApi", err)
+		return nil, err
+	}
+
+	return restAPI, nil
+}
+
+// listAPIGatewayStages returns all stages of a given REST API
+func listAPIGatewayStages(apigatewaySvc apigatewayiface.APIGatewayAPI, restAPIID *string) ([]*apigateway.Stage, error) {
+	out, err := apigatewaySvc.GetStages(&apigateway.GetStagesInput{RestApiId: restAPIID})
+	if err != nil {
+		utils.LogAWSError("APIGateway.GetStages", err)
+		return nil, err
+	}
+
+	return out.Item, nil
+}
+
+// getAPIGatewayStage returns a single stage of a given REST API
+func getAPIGatewayStage(
+	apigatewaySvc apigatewayiface.APIGatewayAPI, restAPIID *string, stageName *string) (*apigateway.Stage, error) {
+
+	stage, err := apigatewaySvc.GetStage(&apigateway.GetStageInput{RestApiId: restAPIID, StageName: stageName})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == apigateway.ErrCodeNotFoundException {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *restAPIID+"/"+*stageName),
+				zap.String("resourceType", awsmodels.APIGatewayStageSchema))
+			return nil, nil
+		}
+		utils.LogAWSError("APIGateway.GetStage", err)
+		return nil, err
+	}
+
+	return stage, nil
+}
+
+// buildAPIGatewayStageSnapshot returns a complete snapshot of an API Gateway stage
+func buildAPIGatewayStageSnapshot(
+	restAPI *apigateway.RestApi,
+	stage *apigateway.Stage,
+	region string,
+) *awsmodels.APIGatewayStage {
+
+	if restAPI == nil || stage == nil {
+		return nil
+	}
+
+	// API Gateway resources are identified by their REST path rather than a typical ARN
+	stageARN := arn.ARN{
+		Partition: "aws",
+		Service:   "apigateway",
+		Region:    region,
+		Resource:  "/restapis/" + *restAPI.Id + "/stages/" + *stage.StageName,
+	}.String()
+
+	snapshot := &awsmodels.APIGatewayStage{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   aws.String(stageARN),
+			ResourceType: aws.String(awsmodels.APIGatewayStageSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  aws.String(stageARN),
+			Name: stage.StageName,
+			Tags: stage.Tags,
+		},
+		AccessLogSettings:            stage.AccessLogSettings,
+		CacheClusterEnabled:          stage.CacheClusterEnabled,
+		CacheClusterSize:             stage.CacheClusterSize,
+		CacheClusterStatus:           stage.CacheClusterStatus,
+		CanarySettings:               stage.CanarySettings,
+		ClientCertificateId:          stage.ClientCertificateId,
+		DeploymentId:                 stage.DeploymentId,
+		Description:                  stage.Description,
+		DocumentationVersion:         stage.DocumentationVersion,
+		LastUpdatedDate:              stage.LastUpdatedDate,
+		MethodSettings:               stage.MethodSettings,
+		RestApiEndpointConfiguration: restAPI.EndpointConfiguration,
+		RestApiId:                    restAPI.Id,
+		RestApiName:                  restAPI.Name,
+		RestApiPolicy:                restAPI.Policy,
+		TracingEnabled:               stage.TracingEnabled,
+		Variables:                    stage.Variables,
+		WebAclArn:                    stage.WebAclArn,
+	}
+	if stage.CreatedDate != nil {
+		snapshot.TimeCreated = utils.DateTimeFormat(*stage.CreatedDate)
+	}
+
+	return snapshot
+}
+
+// PollAPIGatewayStages gathers information on each stage of each API Gateway REST API for an AWS account.
+func PollAPIGatewayStages(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting API Gateway Stage resource poller")
+	var resources []*apimodels.AddResourceEntry
+
+	for _, regionID := range utils.GetServiceRegions(pollerInput.Regions, "apigateway") {
+		apigatewaySvc, err := getAPIGatewayClient(pollerInput, *regionID)
+		if err != nil {
+			return nil, err // error is logged in getClient()
+		}
+
+		for _, restAPI := range listRestAPIs(apigatewaySvc) {
+			stages, err := listAPIGatewayStages(apigatewaySvc, restAPI.Id)
+			if err != nil {
+				continue
+			}
+
+			for _, stage := range stages {
+				stageSnapshot := buildAPIGatewayStageSnapshot(restAPI, stage, *regionID)
+				if stageSnapshot == nil {
+					continue
+				}
+				stageSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+				stageSnapshot.Region = regionID
+
+				resources = append(resources, &apimodels.AddResourceEntry{
+					Attributes:      stageSnapshot,
+					ID:              apimodels.ResourceID(*stageSnapshot.ARN),
+					IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
+					IntegrationType: apimodels.IntegrationTypeAws,
+					Type:            awsmodels.APIGatewayStageSchema,
+				})
+			}
+		}
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,128 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestAPIGatewayRestAPIsList(t *testing.T) {
+	mockSvc := awstest.BuildMockAPIGatewaySvc([]string{"GetRestApisPages"})
+
+	out := listRestAPIs(mockSvc)
+	assert.NotEmpty(t, out)
+}
+
+func TestAPIGatewayRestAPIsListError(t *testing.T) {
+	mockSvc := awstest.BuildMockAPIGatewaySvcError([]string{"GetRestApisPages"})
+
+	out := listRestAPIs(mockSvc)
+	assert.Nil(t, out)
+}
+
+func TestAPIGatewayStagesList(t *testing.T) {
+	mockSvc := awstest.BuildMockAPIGatewaySvc([]string{"GetStages"})
+
+	out, err := listAPIGatewayStages(mockSvc, awstest.ExampleRestAPI.Id)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestAPIGatewayStagesListError(t *testing.T) {
+	mockSvc := awstest.BuildMockAPIGatewaySvcError([]string{"GetStages"})
+
+	out, err := listAPIGatewayStages(mockSvc, awstest.ExampleRestAPI.Id)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestAPIGatewayStageBuildSnapshot(t *testing.T) {
+	snapshot := buildAPIGatewayStageSnapshot(awstest.ExampleRestAPI, awstest.ExampleAPIGatewayStage, "us-west-2")
+
+	assert.Equal(t, "arn:aws:apigateway:us-west-2::/restapis/a1b2c3d4e5/stages/prod", *snapshot.ARN)
+	assert.Equal(t, "example-api", *snapshot.RestApiName)
+	assert.True(t, *snapshot.TracingEnabled)
+	assert.NotNil(t, snapshot.AccessLogSettings)
+	assert.Equal(t, "Panther", *snapshot.Tags["Application"])
+}
+
+func TestAPIGatewayStageSingleResource(t *testing.T) {
+	awstest.MockAPIGatewayForSetup = awstest.BuildMockAPIGatewaySvc([]string{"GetRestApi", "GetStage"})
+
+	APIGatewayClientFunc = awstest.SetupMockAPIGateway
+
+	resourceARN, err := arn.Parse("arn:aws:apigateway:us-west-2::/restapis/a1b2c3d4e5/stages/prod")
+	require.NoError(t, err)
+
+	out, err := PollAPIGatewayStage(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Timestamp:           &awstest.ExampleTime,
+	}, resourceARN, &pollermodels.ScanEntry{ResourceID: aws.String(resourceARN.String())})
+
+	require.NoError(t, err)
+	snapshot := out.(*awsmodels.APIGatewayStage)
+	assert.Equal(t, resourceARN.String(), *snapshot.ARN)
+	assert.Equal(t, awstest.ExampleAuthSourceParsedARN.AccountID, *snapshot.AccountID)
+}
+
+func TestAPIGatewayPoller(t *testing.T) {
+	awstest.MockAPIGatewayForSetup = awstest.BuildMockAPIGatewaySvcAll()
+
+	APIGatewayClientFunc = awstest.SetupMockAPIGateway
+
+	resources, err := PollAPIGatewayStages(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, resources)
+	assert.Equal(t, awsmodels.APIGatewayStageSchema, string(resources[0].Type))
+}
+
+func TestAPIGatewayPollerError(t *testing.T) {
+	awstest.MockAPIGatewayForSetup = awstest.BuildMockAPIGatewaySvcAllError()
+
+	APIGatewayClientFunc = awstest.SetupMockAPIGateway
+
+	resources, err := PollAPIGatewayStages(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+}
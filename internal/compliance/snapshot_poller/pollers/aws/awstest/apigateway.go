@@ -0,0 +1,203 @@
+package awstest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/aws/aws-sdk-go/service/apigateway/apigatewayiface"
+	"github.com/stretchr/testify/mock"
+)
+
+// Example API Gateway API return values
+var (
+	ExampleRestAPI = &apigateway.RestApi{
+		CreatedDate: ExampleDate,
+		EndpointConfiguration: &apigateway.EndpointConfiguration{
+			Types: aws.StringSlice([]string{"REGIONAL"}),
+		},
+		Id:   aws.String("a1b2c3d4e5"),
+		Name: aws.String("example-api"),
+	}
+
+	ExampleGetRestApis = &apigateway.GetRestApisOutput{
+		Items: []*apigateway.RestApi{ExampleRestAPI},
+	}
+
+	ExampleAPIGatewayStage = &apigateway.Stage{
+		AccessLogSettings: &apigateway.AccessLogSettings{
+			DestinationArn: aws.String("arn:aws:logs:us-west-2:123456789012:log-group:example-api-access"),
+			Format:         aws.String("$context.requestId"),
+		},
+		CacheClusterEnabled: aws.Bool(false),
+		CreatedDate:         ExampleDate,
+		DeploymentId:        aws.String("abc123"),
+		LastUpdatedDate:     ExampleDate,
+		MethodSettings: map[string]*apigateway.MethodSetting{
+			"*/*": {
+				DataTraceEnabled: aws.Bool(false),
+				LoggingLevel:     aws.String("INFO"),
+				MetricsEnabled:   aws.Bool(true),
+			},
+		},
+		StageName: aws.String("prod"),
+		Tags: map[string]*string{
+			"Application": aws.String("Panther"),
+		},
+		TracingEnabled: aws.Bool(true),
+	}
+
+	ExampleGetStages = &apigateway.GetStagesOutput{
+		Item: []*apigateway.Stage{ExampleAPIGatewayStage},
+	}
+
+	svcAPIGatewaySetupCalls = map[string]func(*MockAPIGateway){
+		"GetRestApisPages": func(svc *MockAPIGateway) {
+			svc.On("GetRestApisPages", mock.Anything).
+				Return(nil)
+		},
+		"GetRestApi": func(svc *MockAPIGateway) {
+			svc.On("GetRestApi", mock.Anything).
+				Return(ExampleRestAPI, nil)
+		},
+		"GetStages": func(svc *MockAPIGateway) {
+			svc.On("GetStages", mock.Anything).
+				Return(ExampleGetStages, nil)
+		},
+		"GetStage": func(svc *MockAPIGateway) {
+			svc.On("GetStage", mock.Anything).
+				Return(ExampleAPIGatewayStage, nil)
+		},
+	}
+
+	svcAPIGatewaySetupCallsError = map[string]func(*MockAPIGateway){
+		"GetRestApisPages": func(svc *MockAPIGateway) {
+			svc.On("GetRestApisPages", mock.Anything).
+				Return(errors.New("APIGateway.GetRestApisPages error"))
+		},
+		"GetRestApi": func(svc *MockAPIGateway) {
+			svc.On("GetRestApi", mock.Anything).
+				Return(&apigateway.RestApi{},
+					errors.New("APIGateway.GetRestApi error"),
+				)
+		},
+		"GetStages": func(svc *MockAPIGateway) {
+			svc.On("GetStages", mock.Anything).
+				Return(&apigateway.GetStagesOutput{},
+					errors.New("APIGateway.GetStages error"),
+				)
+		},
+		"GetStage": func(svc *MockAPIGateway) {
+			svc.On("GetStage", mock.Anything).
+				Return(&apigateway.Stage{},
+					errors.New("APIGateway.GetStage error"),
+				)
+		},
+	}
+
+	MockAPIGatewayForSetup = &MockAPIGateway{}
+)
+
+// API Gateway mock
+
+// SetupMockAPIGateway is used to override the API Gateway Client initializer
+func SetupMockAPIGateway(sess *session.Session, cfg *aws.Config) interface{} {
+	return MockAPIGatewayForSetup
+}
+
+// MockAPIGateway is a mock API Gateway client
+type MockAPIGateway struct {
+	apigatewayiface.APIGatewayAPI
+	mock.Mock
+}
+
+// BuildMockAPIGatewaySvc builds and returns a MockAPIGateway struct
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockAPIGatewaySvc(funcs []string) (mockSvc *MockAPIGateway) {
+	mockSvc = &MockAPIGateway{}
+	for _, f := range funcs {
+		svcAPIGatewaySetupCalls[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockAPIGatewaySvcError builds and returns a MockAPIGateway struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockAPIGatewaySvcError(funcs []string) (mockSvc *MockAPIGateway) {
+	mockSvc = &MockAPIGateway{}
+	for _, f := range funcs {
+		svcAPIGatewaySetupCallsError[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockAPIGatewaySvcAll builds and returns a MockAPIGateway struct
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockAPIGatewaySvcAll() (mockSvc *MockAPIGateway) {
+	mockSvc = &MockAPIGateway{}
+	for _, f := range svcAPIGatewaySetupCalls {
+		f(mockSvc)
+	}
+	return
+}
+
+// BuildMockAPIGatewaySvcAllError builds and returns a MockAPIGateway struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockAPIGatewaySvcAllError() (mockSvc *MockAPIGateway) {
+	mockSvc = &MockAPIGateway{}
+	for _, f := range svcAPIGatewaySetupCallsError {
+		f(mockSvc)
+	}
+	return
+}
+
+func (m *MockAPIGateway) GetRestApisPages(
+	in *apigateway.GetRestApisInput,
+	paginationFunction func(*apigateway.GetRestApisOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleGetRestApis, true)
+	return args.Error(0)
+}
+
+func (m *MockAPIGateway) GetRestApi(in *apigateway.GetRestApiInput) (*apigateway.RestApi, error) {
+	args := m.Called(in)
+	return args.Get(0).(*apigateway.RestApi), args.Error(1)
+}
+
+func (m *MockAPIGateway) GetStages(in *apigateway.GetStagesInput) (*apigateway.GetStagesOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*apigateway.GetStagesOutput), args.Error(1)
+}
+
+func (m *MockAPIGateway) GetStage(in *apigateway.GetStageInput) (*apigateway.Stage, error) {
+	args := m.Called(in)
+	return args.Get(0).(*apigateway.Stage), args.Error(1)
+}
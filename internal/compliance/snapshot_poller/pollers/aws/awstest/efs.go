@@ -0,0 +1,217 @@
+package awstest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/efs"
+	"github.com/aws/aws-sdk-go/service/efs/efsiface"
+	"github.com/stretchr/testify/mock"
+)
+
+// Example EFS API return values
+var (
+	ExampleFileSystemID = aws.String("fs-01234567")
+
+	ExampleDescribeFileSystems = &efs.DescribeFileSystemsOutput{
+		FileSystems: []*efs.FileSystemDescription{
+			{
+				CreationTime:         ExampleDate,
+				CreationToken:        aws.String("example-token"),
+				Encrypted:            aws.Bool(true),
+				FileSystemId:         ExampleFileSystemID,
+				KmsKeyId:             aws.String("arn:aws:kms:us-west-2:123456789012:key/188c57ed-b28a-4c0e-9821-f4940d15cb0a"),
+				LifeCycleState:       aws.String("available"),
+				Name:                 aws.String("example-file-system"),
+				NumberOfMountTargets: aws.Int64(1),
+				OwnerId:              aws.String("123456789012"),
+				PerformanceMode:      aws.String("generalPurpose"),
+				SizeInBytes: &efs.FileSystemSize{
+					Value: aws.Int64(6144),
+				},
+				Tags: []*efs.Tag{
+					{
+						Key:   aws.String("Name"),
+						Value: aws.String("example-file-system"),
+					},
+				},
+				ThroughputMode: aws.String("bursting"),
+			},
+		},
+	}
+
+	ExampleDescribeFileSystemPolicy = &efs.DescribeFileSystemPolicyOutput{
+		FileSystemId: ExampleFileSystemID,
+		Policy:       aws.String("{\"Version\":\"2012-10-17\",\"Statement\":[{\"Effect\":\"Deny\",\"Principal\":{\"AWS\":\"*\"},\"Action\":\"*\",\"Condition\":{\"Bool\":{\"aws:SecureTransport\":\"false\"}}}]}"),
+	}
+
+	ExampleDescribeLifecycleConfiguration = &efs.DescribeLifecycleConfigurationOutput{
+		LifecyclePolicies: []*efs.LifecyclePolicy{
+			{TransitionToIA: aws.String("AFTER_30_DAYS")},
+		},
+	}
+
+	ExampleDescribeMountTargets = &efs.DescribeMountTargetsOutput{
+		MountTargets: []*efs.MountTargetDescription{
+			{
+				FileSystemId:       ExampleFileSystemID,
+				IpAddress:          aws.String("10.0.0.10"),
+				LifeCycleState:     aws.String("available"),
+				MountTargetId:      aws.String("fsmt-01234567"),
+				NetworkInterfaceId: aws.String("eni-0123456789abcdef0"),
+				OwnerId:            aws.String("123456789012"),
+				SubnetId:           aws.String("subnet-0123456789abcdef0"),
+			},
+		},
+	}
+
+	svcEfsSetupCalls = map[string]func(*MockEfs){
+		"DescribeFileSystemsPages": func(svc *MockEfs) {
+			svc.On("DescribeFileSystemsPages", mock.Anything).
+				Return(nil)
+		},
+		"DescribeFileSystemPolicy": func(svc *MockEfs) {
+			svc.On("DescribeFileSystemPolicy", mock.Anything).
+				Return(ExampleDescribeFileSystemPolicy, nil)
+		},
+		"DescribeLifecycleConfiguration": func(svc *MockEfs) {
+			svc.On("DescribeLifecycleConfiguration", mock.Anything).
+				Return(ExampleDescribeLifecycleConfiguration, nil)
+		},
+		"DescribeMountTargets": func(svc *MockEfs) {
+			svc.On("DescribeMountTargets", mock.Anything).
+				Return(ExampleDescribeMountTargets, nil)
+		},
+	}
+
+	svcEfsSetupCallsError = map[string]func(*MockEfs){
+		"DescribeFileSystemsPages": func(svc *MockEfs) {
+			svc.On("DescribeFileSystemsPages", mock.Anything).
+				Return(errors.New("EFS.DescribeFileSystemsPages error"))
+		},
+		"DescribeFileSystemPolicy": func(svc *MockEfs) {
+			svc.On("DescribeFileSystemPolicy", mock.Anything).
+				Return(&efs.DescribeFileSystemPolicyOutput{},
+					errors.New("EFS.DescribeFileSystemPolicy error"),
+				)
+		},
+		"DescribeLifecycleConfiguration": func(svc *MockEfs) {
+			svc.On("DescribeLifecycleConfiguration", mock.Anything).
+				Return(&efs.DescribeLifecycleConfigurationOutput{},
+					errors.New("EFS.DescribeLifecycleConfiguration error"),
+				)
+		},
+		"DescribeMountTargets": func(svc *MockEfs) {
+			svc.On("DescribeMountTargets", mock.Anything).
+				Return(&efs.DescribeMountTargetsOutput{},
+					errors.New("EFS.DescribeMountTargets error"),
+				)
+		},
+	}
+
+	MockEfsForSetup = &MockEfs{}
+)
+
+// EFS mock
+
+// SetupMockEfs is used to override the EFS Client initializer
+func SetupMockEfs(sess *session.Session, cfg *aws.Config) interface{} {
+	return MockEfsForSetup
+}
+
+// MockEfs is a mock EFS client
+type MockEfs struct {
+	efsiface.EFSAPI
+	mock.Mock
+}
+
+// BuildMockEfsSvc builds and returns a MockEfs struct
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockEfsSvc(funcs []string) (mockSvc *MockEfs) {
+	mockSvc = &MockEfs{}
+	for _, f := range funcs {
+		svcEfsSetupCalls[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockEfsSvcError builds and returns a MockEfs struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockEfsSvcError(funcs []string) (mockSvc *MockEfs) {
+	mockSvc = &MockEfs{}
+	for _, f := range funcs {
+		svcEfsSetupCallsError[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockEfsSvcAll builds and returns a MockEfs struct
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockEfsSvcAll() (mockSvc *MockEfs) {
+	mockSvc = &MockEfs{}
+	for _, f := range svcEfsSetupCalls {
+		f(mockSvc)
+	}
+	return
+}
+
+// BuildMockEfsSvcAllError builds and returns a MockEfs struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockEfsSvcAllError() (mockSvc *MockEfs) {
+	mockSvc = &MockEfs{}
+	for _, f := range svcEfsSetupCallsError {
+		f(mockSvc)
+	}
+	return
+}
+
+func (m *MockEfs) DescribeFileSystemsPages(
+	in *efs.DescribeFileSystemsInput,
+	paginationFunction func(*efs.DescribeFileSystemsOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleDescribeFileSystems, true)
+	return args.Error(0)
+}
+
+func (m *MockEfs) DescribeFileSystemPolicy(in *efs.DescribeFileSystemPolicyInput) (*efs.DescribeFileSystemPolicyOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*efs.DescribeFileSystemPolicyOutput), args.Error(1)
+}
+
+func (m *MockEfs) DescribeLifecycleConfiguration(in *efs.DescribeLifecycleConfigurationInput) (*efs.DescribeLifecycleConfigurationOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*efs.DescribeLifecycleConfigurationOutput), args.Error(1)
+}
+
+func (m *MockEfs) DescribeMountTargets(in *efs.DescribeMountTargetsInput) (*efs.DescribeMountTargetsOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*efs.DescribeMountTargetsOutput), args.Error(1)
+}
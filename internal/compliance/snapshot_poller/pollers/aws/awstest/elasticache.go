@@ -0,0 +1,172 @@
+package awstest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/elasticache/elasticacheiface"
+	"github.com/stretchr/testify/mock"
+)
+
+// Example ElastiCache API return values
+var (
+	ExampleCacheClusterID = aws.String("example-cluster-001")
+
+	ExampleDescribeCacheClusters = &elasticache.DescribeCacheClustersOutput{
+		CacheClusters: []*elasticache.CacheCluster{
+			{
+				ARN:                     aws.String("arn:aws:elasticache:us-west-2:123456789012:cluster:example-cluster-001"),
+				AtRestEncryptionEnabled: aws.Bool(false),
+				AuthTokenEnabled:        aws.Bool(false),
+				AutoMinorVersionUpgrade: aws.Bool(true),
+				CacheClusterCreateTime:  ExampleDate,
+				CacheClusterId:          ExampleCacheClusterID,
+				CacheClusterStatus:      aws.String("available"),
+				CacheNodeType:           aws.String("cache.t3.micro"),
+				CacheSubnetGroupName:    aws.String("default"),
+				Engine:                  aws.String("redis"),
+				EngineVersion:           aws.String("5.0.6"),
+				NumCacheNodes:           aws.Int64(1),
+				ReplicationGroupId:      aws.String("example-cluster"),
+				SecurityGroups: []*elasticache.SecurityGroupMembership{
+					{
+						SecurityGroupId: aws.String("sg-0123456789abcdef0"),
+						Status:          aws.String("active"),
+					},
+				},
+				SnapshotRetentionLimit:   aws.Int64(0),
+				TransitEncryptionEnabled: aws.Bool(false),
+			},
+		},
+	}
+
+	ExampleListTagsForResourceElastiCache = &elasticache.TagListMessage{
+		TagList: []*elasticache.Tag{
+			{
+				Key:   aws.String("Application"),
+				Value: aws.String("Panther"),
+			},
+		},
+	}
+
+	svcElastiCacheSetupCalls = map[string]func(*MockElastiCache){
+		"DescribeCacheClustersPages": func(svc *MockElastiCache) {
+			svc.On("DescribeCacheClustersPages", mock.Anything).
+				Return(nil)
+		},
+		"ListTagsForResource": func(svc *MockElastiCache) {
+			svc.On("ListTagsForResource", mock.Anything).
+				Return(ExampleListTagsForResourceElastiCache, nil)
+		},
+	}
+
+	svcElastiCacheSetupCallsError = map[string]func(*MockElastiCache){
+		"DescribeCacheClustersPages": func(svc *MockElastiCache) {
+			svc.On("DescribeCacheClustersPages", mock.Anything).
+				Return(errors.New("ElastiCache.DescribeCacheClustersPages error"))
+		},
+		"ListTagsForResource": func(svc *MockElastiCache) {
+			svc.On("ListTagsForResource", mock.Anything).
+				Return(&elasticache.TagListMessage{},
+					errors.New("ElastiCache.ListTagsForResource error"),
+				)
+		},
+	}
+
+	MockElastiCacheForSetup = &MockElastiCache{}
+)
+
+// ElastiCache mock
+
+// SetupMockElastiCache is used to override the ElastiCache Client initializer
+func SetupMockElastiCache(sess *session.Session, cfg *aws.Config) interface{} {
+	return MockElastiCacheForSetup
+}
+
+// MockElastiCache is a mock ElastiCache client
+type MockElastiCache struct {
+	elasticacheiface.ElastiCacheAPI
+	mock.Mock
+}
+
+// BuildMockElastiCacheSvc builds and returns a MockElastiCache struct
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockElastiCacheSvc(funcs []string) (mockSvc *MockElastiCache) {
+	mockSvc = &MockElastiCache{}
+	for _, f := range funcs {
+		svcElastiCacheSetupCalls[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockElastiCacheSvcError builds and returns a MockElastiCache struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockElastiCacheSvcError(funcs []string) (mockSvc *MockElastiCache) {
+	mockSvc = &MockElastiCache{}
+	for _, f := range funcs {
+		svcElastiCacheSetupCallsError[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockElastiCacheSvcAll builds and returns a MockElastiCache struct
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockElastiCacheSvcAll() (mockSvc *MockElastiCache) {
+	mockSvc = &MockElastiCache{}
+	for _, f := range svcElastiCacheSetupCalls {
+		f(mockSvc)
+	}
+	return
+}
+
+// BuildMockElastiCacheSvcAllError builds and returns a MockElastiCache struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockElastiCacheSvcAllError() (mockSvc *MockElastiCache) {
+	mockSvc = &MockElastiCache{}
+	for _, f := range svcElastiCacheSetupCallsError {
+		f(mockSvc)
+	}
+	return
+}
+
+func (m *MockElastiCache) DescribeCacheClustersPages(
+	in *elasticache.DescribeCacheClustersInput,
+	paginationFunction func(*elasticache.DescribeCacheClustersOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleDescribeCacheClusters, true)
+	return args.Error(0)
+}
+
+func (m *MockElastiCache) ListTagsForResource(in *elasticache.ListTagsForResourceInput) (*elasticache.TagListMessage, error) {
+	args := m.Called(in)
+	return args.Get(0).(*elasticache.TagListMessage), args.Error(1)
+}
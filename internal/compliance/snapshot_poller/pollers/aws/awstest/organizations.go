@@ -0,0 +1,215 @@
+package awstest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
+	"github.com/stretchr/testify/mock"
+)
+
+// Example Organizations API return values
+var (
+	ExamplePolicySummary = &organizations.PolicySummary{
+		Arn:         aws.String("arn:aws:organizations::123456789012:policy/o-exampleorgid/service_control_policy/p-examplepolicyid111"),
+		AwsManaged:  aws.Bool(false),
+		Description: aws.String("Deny leaving the organization"),
+		Id:          aws.String("p-examplepolicyid111"),
+		Name:        aws.String("DenyLeaveOrganization"),
+		Type:        aws.String("SERVICE_CONTROL_POLICY"),
+	}
+
+	ExampleListPoliciesOrganizations = &organizations.ListPoliciesOutput{
+		Policies: []*organizations.PolicySummary{ExamplePolicySummary},
+	}
+
+	ExampleDescribePolicyOrganizations = &organizations.DescribePolicyOutput{
+		Policy: &organizations.Policy{
+			Content:       aws.String("{\"Version\":\"2012-10-17\",\"Statement\":[{\"Effect\":\"Deny\",\"Action\":\"organizations:LeaveOrganization\",\"Resource\":\"*\"}]}"),
+			PolicySummary: ExamplePolicySummary,
+		},
+	}
+
+	ExampleListTargetsForPolicyOrganizations = &organizations.ListTargetsForPolicyOutput{
+		Targets: []*organizations.PolicyTargetSummary{
+			{
+				Arn:      aws.String("arn:aws:organizations::123456789012:root/o-exampleorgid/r-examplerootid111"),
+				Name:     aws.String("Root"),
+				TargetId: aws.String("r-examplerootid111"),
+				Type:     aws.String("ROOT"),
+			},
+		},
+	}
+
+	ExampleListTagsForResourceOrganizations = &organizations.ListTagsForResourceOutput{
+		Tags: []*organizations.Tag{
+			{
+				Key:   aws.String("Owner"),
+				Value: aws.String("security"),
+			},
+		},
+	}
+
+	svcOrganizationsSetupCalls = map[string]func(*MockOrganizations){
+		"ListPoliciesPages": func(svc *MockOrganizations) {
+			svc.On("ListPoliciesPages", mock.Anything).
+				Return(nil)
+		},
+		"DescribePolicy": func(svc *MockOrganizations) {
+			svc.On("DescribePolicy", mock.Anything).
+				Return(ExampleDescribePolicyOrganizations, nil)
+		},
+		"ListTargetsForPolicyPages": func(svc *MockOrganizations) {
+			svc.On("ListTargetsForPolicyPages", mock.Anything).
+				Return(nil)
+		},
+		"ListTagsForResourcePages": func(svc *MockOrganizations) {
+			svc.On("ListTagsForResourcePages", mock.Anything).
+				Return(nil)
+		},
+	}
+
+	svcOrganizationsSetupCallsError = map[string]func(*MockOrganizations){
+		"ListPoliciesPages": func(svc *MockOrganizations) {
+			svc.On("ListPoliciesPages", mock.Anything).
+				Return(errors.New("Organizations.ListPoliciesPages error"))
+		},
+		"DescribePolicy": func(svc *MockOrganizations) {
+			svc.On("DescribePolicy", mock.Anything).
+				Return(&organizations.DescribePolicyOutput{},
+					errors.New("Organizations.DescribePolicy error"),
+				)
+		},
+		"ListTargetsForPolicyPages": func(svc *MockOrganizations) {
+			svc.On("ListTargetsForPolicyPages", mock.Anything).
+				Return(errors.New("Organizations.ListTargetsForPolicyPages error"))
+		},
+		"ListTagsForResourcePages": func(svc *MockOrganizations) {
+			svc.On("ListTagsForResourcePages", mock.Anything).
+				Return(errors.New("Organizations.ListTagsForResourcePages error"))
+		},
+	}
+
+	MockOrganizationsForSetup = &MockOrganizations{}
+)
+
+// Organizations mock
+
+// SetupMockOrganizations is used to override the Organizations Client initializer
+func SetupMockOrganizations(sess *session.Session, cfg *aws.Config) interface{} {
+	return MockOrganizationsForSetup
+}
+
+// MockOrganizations is a mock Organizations client
+type MockOrganizations struct {
+	organizationsiface.OrganizationsAPI
+	mock.Mock
+}
+
+// BuildMockOrganizationsSvc builds and returns a MockOrganizations struct
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockOrganizationsSvc(funcs []string) (mockSvc *MockOrganizations) {
+	mockSvc = &MockOrganizations{}
+	for _, f := range funcs {
+		svcOrganizationsSetupCalls[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockOrganizationsSvcError builds and returns a MockOrganizations struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockOrganizationsSvcError(funcs []string) (mockSvc *MockOrganizations) {
+	mockSvc = &MockOrganizations{}
+	for _, f := range funcs {
+		svcOrganizationsSetupCallsError[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockOrganizationsSvcAll builds and returns a MockOrganizations struct
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockOrganizationsSvcAll() (mockSvc *MockOrganizations) {
+	mockSvc = &MockOrganizations{}
+	for _, f := range svcOrganizationsSetupCalls {
+		f(mockSvc)
+	}
+	return
+}
+
+// BuildMockOrganizationsSvcAllError builds and returns a MockOrganizations struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockOrganizationsSvcAllError() (mockSvc *MockOrganizations) {
+	mockSvc = &MockOrganizations{}
+	for _, f := range svcOrganizationsSetupCallsError {
+		f(mockSvc)
+	}
+	return
+}
+
+func (m *MockOrganizations) ListPoliciesPages(
+	in *organizations.ListPoliciesInput,
+	paginationFunction func(*organizations.ListPoliciesOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleListPoliciesOrganizations, true)
+	return args.Error(0)
+}
+
+func (m *MockOrganizations) DescribePolicy(in *organizations.DescribePolicyInput) (*organizations.DescribePolicyOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*organizations.DescribePolicyOutput), args.Error(1)
+}
+
+func (m *MockOrganizations) ListTargetsForPolicyPages(
+	in *organizations.ListTargetsForPolicyInput,
+	paginationFunction func(*organizations.ListTargetsForPolicyOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleListTargetsForPolicyOrganizations, true)
+	return args.Error(0)
+}
+
+func (m *MockOrganizations) ListTagsForResourcePages(
+	in *organizations.ListTagsForResourceInput,
+	paginationFunction func(*organizations.ListTagsForResourceOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleListTagsForResourceOrganizations, true)
+	return args.Error(0)
+}
@@ -0,0 +1,160 @@
+package awstest
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"github.com/stretchr/testify/mock"
+)
+
+// Example SSM API return values
+var (
+	ExampleSSMParameterName = aws.String("/example/database/password")
+
+	ExampleDescribeParameters = &ssm.DescribeParametersOutput{
+		Parameters: []*ssm.ParameterMetadata{
+			{
+				DataType:         aws.String("text"),
+				Description:      aws.String("example database password"),
+				KeyId:            aws.String("alias/aws/ssm"),
+				LastModifiedDate: ExampleDate,
+				LastModifiedUser: aws.String("arn:aws:iam::123456789012:user/example"),
+				Name:             ExampleSSMParameterName,
+				Tier:             aws.String("Standard"),
+				Type:             aws.String("SecureString"),
+				Version:          aws.Int64(3),
+			},
+		},
+	}
+
+	ExampleListTagsForResourceSSM = &ssm.ListTagsForResourceOutput{
+		TagList: []*ssm.Tag{
+			{
+				Key:   aws.String("Environment"),
+				Value: aws.String("production"),
+			},
+		},
+	}
+
+	svcSSMSetupCalls = map[string]func(*MockSSM){
+		"DescribeParametersPages": func(svc *MockSSM) {
+			svc.On("DescribeParametersPages", mock.Anything).
+				Return(nil)
+		},
+		"ListTagsForResource": func(svc *MockSSM) {
+			svc.On("ListTagsForResource", mock.Anything).
+				Return(ExampleListTagsForResourceSSM, nil)
+		},
+	}
+
+	svcSSMSetupCallsError = map[string]func(*MockSSM){
+		"DescribeParametersPages": func(svc *MockSSM) {
+			svc.On("DescribeParametersPages", mock.Anything).
+				Return(errors.New("SSM.DescribeParametersPages error"))
+		},
+		"ListTagsForResource": func(svc *MockSSM) {
+			svc.On("ListTagsForResource", mock.Anything).
+				Return(&ssm.ListTagsForResourceOutput{},
+					errors.New("SSM.ListTagsForResource error"),
+				)
+		},
+	}
+
+	MockSSMForSetup = &MockSSM{}
+)
+
+// SSM mock
+
+// SetupMockSSM is used to override the SSM Client initializer
+func SetupMockSSM(sess *session.Session, cfg *aws.Config) interface{} {
+	return MockSSMForSetup
+}
+
+// MockSSM is a mock SSM client
+type MockSSM struct {
+	ssmiface.SSMAPI
+	mock.Mock
+}
+
+// BuildMockSSMSvc builds and returns a MockSSM struct
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockSSMSvc(funcs []string) (mockSvc *MockSSM) {
+	mockSvc = &MockSSM{}
+	for _, f := range funcs {
+		svcSSMSetupCalls[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockSSMSvcError builds and returns a MockSSM struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made based on the strings passed in
+func BuildMockSSMSvcError(funcs []string) (mockSvc *MockSSM) {
+	mockSvc = &MockSSM{}
+	for _, f := range funcs {
+		svcSSMSetupCallsError[f](mockSvc)
+	}
+	return
+}
+
+// BuildMockSSMSvcAll builds and returns a MockSSM struct
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockSSMSvcAll() (mockSvc *MockSSM) {
+	mockSvc = &MockSSM{}
+	for _, f := range svcSSMSetupCalls {
+		f(mockSvc)
+	}
+	return
+}
+
+// BuildMockSSMSvcAllError builds and returns a MockSSM struct with errors set
+//
+// Additionally, the appropriate calls to On and Return are made for all possible function calls
+func BuildMockSSMSvcAllError() (mockSvc *MockSSM) {
+	mockSvc = &MockSSM{}
+	for _, f := range svcSSMSetupCallsError {
+		f(mockSvc)
+	}
+	return
+}
+
+func (m *MockSSM) DescribeParametersPages(
+	in *ssm.DescribeParametersInput,
+	paginationFunction func(*ssm.DescribeParametersOutput, bool) bool,
+) error {
+
+	args := m.Called(in)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+	paginationFunction(ExampleDescribeParameters, true)
+	return args.Error(0)
+}
+
+func (m *MockSSM) ListTagsForResource(in *ssm.ListTagsForResourceInput) (*ssm.ListTagsForResourceOutput, error) {
+	args := m.Called(in)
+	return args.Get(0).(*ssm.ListTagsForResourceOutput), args.Error(1)
+}
@@ -0,0 +1,237 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/pkg/errors"
+)
+
+const (
+	checkpointPending = "pending"
+	checkpointRunning = "running"
+	checkpointDone    = "done"
+	checkpointFailed  = "failed"
+
+	// Checkpoints of finished (or abandoned) scans are removed by the table TTL
+	checkpointRetention = 7 * 24 * time.Hour
+)
+
+var (
+	checkpointTable  = os.Getenv("CHECKPOINT_TABLE_NAME")
+	checkpointClient dynamodbiface.DynamoDBAPI
+)
+
+// checkpoint is the progress of a single work unit of a resumable scan, as stored in the
+// panther-snapshot-checkpoints table.
+type checkpoint struct {
+	ScanID       string  `json:"scanId"`
+	UnitID       string  `json:"unitId"`
+	ResourceType string  `json:"resourceType"`
+	Region       string  `json:"region"`
+	PageToken    *string `json:"pageToken,omitempty"`
+	Status       string  `json:"status"`
+	StartedAt    int64   `json:"startedAt,omitempty"`
+	PollErrors   int     `json:"pollErrors,omitempty"`
+
+	// The invocation working on the unit, which holds it until the lease expires
+	Owner          string `json:"owner,omitempty"`
+	LeaseExpiresAt int64  `json:"leaseExpiresAt,omitempty"`
+
+	ExpiresAt int64 `json:"expiresAt"`
+}
+
+func checkpointKey(scanID string, unit *workUnit) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		"scanId": {S: aws.String(scanID)},
+		"unitId": {S: aws.String(unit.id())},
+	}
+}
+
+// loadCheckpoints returns the checkpoints of every work unit in a scan.
+func loadCheckpoints(scanID string) ([]*checkpoint, error) {
+	input := &dynamodb.QueryInput{
+		ConsistentRead:            aws.Bool(true),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{":scanId": {S: aws.String(scanID)}},
+		KeyConditionExpression:    aws.String("scanId = :scanId"),
+		TableName:                 &checkpointTable,
+	}
+
+	var checkpoints []*checkpoint
+	for {
+		output, err := checkpointClient.Query(input)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to query scan checkpoints")
+		}
+
+		var items []*checkpoint
+		if err = dynamodbattribute.UnmarshalListOfMaps(output.Items, &items); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal scan checkpoints")
+		}
+		checkpoints = append(checkpoints, items...)
+
+		if len(output.LastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = output.LastEvaluatedKey
+	}
+	return checkpoints, nil
+}
+
+// createCheckpoints stores a pending checkpoint for each work unit of a new scan.
+func createCheckpoints(scanID string, units []*workUnit) error {
+	expiresAt := time.Now().Add(checkpointRetention).Unix()
+	requests := make([]*dynamodb.WriteRequest, 0, len(units))
+	for _, unit := range units {
+		item, err := dynamodbattribute.MarshalMap(&checkpoint{
+			ScanID:       scanID,
+			UnitID:       unit.id(),
+			ResourceType: unit.ResourceType,
+			Region:       unit.Region,
+			Status:       checkpointPending,
+			ExpiresAt:    expiresAt,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal scan checkpoint")
+		}
+		requests = append(requests, &dynamodb.WriteRequest{PutRequest: &dynamodb.PutRequest{Item: item}})
+	}
+
+	// BatchWriteItem accepts at most 25 requests
+	const maxBatchSize = 25
+	for len(requests) > 0 {
+		batchSize := len(requests)
+		if batchSize > maxBatchSize {
+			batchSize = maxBatchSize
+		}
+		batch := map[string][]*dynamodb.WriteRequest{checkpointTable: requests[:batchSize]}
+		requests = requests[batchSize:]
+
+		for len(batch) > 0 {
+			output, err := checkpointClient.BatchWriteItem(&dynamodb.BatchWriteItemInput{RequestItems: batch})
+			if err != nil {
+				return errors.Wrap(err, "failed to store scan checkpoints")
+			}
+			batch = output.UnprocessedItems
+		}
+	}
+	return nil
+}
+
+// claimCheckpoint takes ownership of a work unit until the lease expires, and loads the page token
+// it should resume from along with the time it was first claimed and the errors logged so far. It returns false if the unit is
+// finished or held by another invocation.
+func claimCheckpoint(scanID string, unit *workUnit, owner string, lease time.Time) (bool, error) {
+	now := time.Now()
+	update := expression.
+		Set(expression.Name("status"), expression.Value(checkpointRunning)).
+		Set(expression.Name("owner"), expression.Value(owner)).
+		Set(expression.Name("leaseExpiresAt"), expression.Value(lease.Unix())).
+		Set(expression.Name("startedAt"), expression.IfNotExists(expression.Name("startedAt"), expression.Value(now.Unix())))
+	condition := expression.Name("status").Equal(expression.Value(checkpointPending)).Or(
+		expression.Name("status").Equal(expression.Value(checkpointRunning)).And(
+			expression.Name("leaseExpiresAt").LessThan(expression.Value(now.Unix()))))
+
+	output, err := updateCheckpoint(scanID, unit, update, &condition)
+	if err != nil {
+		if awsErr, ok := errors.Cause(err).(awserr.Error); ok &&
+			awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+
+			return false, nil
+		}
+		return false, err
+	}
+
+	var item checkpoint
+	if err = dynamodbattribute.UnmarshalMap(output.Attributes, &item); err != nil {
+		return false, errors.Wrap(err, "failed to unmarshal scan checkpoint")
+	}
+	unit.PageToken = item.PageToken
+	if item.StartedAt != 0 {
+		unit.StartedAt = time.Unix(item.StartedAt, 0)
+	}
+	unit.PollErrors = item.PollErrors
+	return true, nil
+}
+
+// saveCheckpoint records the page token a claimed work unit should resume from, along with the
+// errors logged so far.
+func saveCheckpoint(scanID string, unit *workUnit, owner string) error {
+	update := expression.
+		Set(expression.Name("pageToken"), expression.Value(unit.PageToken)).
+		Set(expression.Name("pollErrors"), expression.Value(unit.pollErrors()))
+	condition := expression.Name("owner").Equal(expression.Value(owner))
+	_, err := updateCheckpoint(scanID, unit, update, &condition)
+	return err
+}
+
+// finishCheckpoint marks a claimed work unit with a final status, or releases it back to pending.
+func finishCheckpoint(scanID string, unit *workUnit, status string) error {
+	update := expression.
+		Set(expression.Name("status"), expression.Value(status)).
+		Set(expression.Name("pollErrors"), expression.Value(unit.pollErrors())).
+		Remove(expression.Name("owner")).
+		Remove(expression.Name("leaseExpiresAt"))
+	if unit.PageToken != nil {
+		update = update.Set(expression.Name("pageToken"), expression.Value(unit.PageToken))
+	} else {
+		update = update.Remove(expression.Name("pageToken"))
+	}
+	_, err := updateCheckpoint(scanID, unit, update, nil)
+	return err
+}
+
+func updateCheckpoint(
+	scanID string,
+	unit *workUnit,
+	update expression.UpdateBuilder,
+	condition *expression.ConditionBuilder,
+) (*dynamodb.UpdateItemOutput, error) {
+
+	builder := expression.NewBuilder().WithUpdate(update)
+	if condition != nil {
+		builder = builder.WithCondition(*condition)
+	}
+	expr, err := builder.Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build scan checkpoint update")
+	}
+
+	output, err := checkpointClient.UpdateItem(&dynamodb.UpdateItemInput{
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		Key:                       checkpointKey(scanID, unit),
+		ReturnValues:              aws.String(dynamodb.ReturnValueAllNew),
+		TableName:                 &checkpointTable,
+		UpdateExpression:          expr.Update(),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update scan checkpoint %s/%s", scanID, unit.id())
+	}
+	return output, nil
+}
@@ -19,16 +19,25 @@ package aws
  */
 
 import (
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"go.uber.org/zap"
 
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
 	"github.com/panther-labs/panther/pkg/awsretry"
 )
 
@@ -61,15 +70,49 @@ type cachedClient struct {
 	Credentials *credentials.Credentials
 }
 
-var clientCache = make(map[clientKey]cachedClient)
+var (
+	clientCache     = make(map[clientKey]cachedClient)
+	clientCacheLock sync.Mutex
+)
+
+var (
+	// Requests to each API are limited per region, well below the throttling limits AWS documents,
+	// so that parallel scan workers leave room for the retries of other requests.
+	defaultAPIRateLimit = utils.RateLimit{PerSecond: 10, Burst: 20}
+	apiRateLimits       = map[string]utils.RateLimit{
+		cloudformation.ServiceName: {PerSecond: 4, Burst: 8},
+		ec2.ServiceName:            {PerSecond: 20, Burst: 50},
+		iam.ServiceName:            {PerSecond: 5, Burst: 10},
+		organizations.ServiceName:  {PerSecond: 2, Burst: 4},
+		s3.ServiceName:             {PerSecond: 50, Burst: 100},
+	}
+	apiRateLimiter = utils.NewRateLimiter(defaultAPIRateLimit, apiRateLimits)
+)
 
 func Setup() {
 	awsConfig := aws.NewConfig().WithMaxRetries(maxRetries)
 	awsConfig.Retryer = awsretry.NewConnectionErrRetryer()
 	snapshotPollerSession = session.Must(session.NewSession(awsConfig))
+	// Every client built from this session waits for the rate limiter before sending a request,
+	// including each of its retries
+	snapshotPollerSession.Handlers.Send.PushFrontNamed(request.NamedHandler{
+		Name: "panther.APIRateLimiter",
+		Fn:   waitForRateLimit,
+	})
+
+	checkpointClient = dynamodb.New(session.Must(session.NewSession()))
+}
+
+// waitForRateLimit blocks a request until its API has capacity in the request's region.
+func waitForRateLimit(r *request.Request) {
+	if err := apiRateLimiter.Wait(r.Context(), r.ClientInfo.ServiceName, aws.StringValue(r.Config.Region)); err != nil {
+		r.Error = err
+	}
 }
 
 // getClient returns a valid client for a given integration, service, and region using caching.
+// Clients for a scan work unit are built from the cached credentials with the unit's error counter
+// attached.
 func getClient(pollerInput *awsmodels.ResourcePollerInput,
 	clientFunc func(session *session.Session, config *aws.Config) interface{},
 	service string, region string) (interface{}, error) {
@@ -80,36 +123,42 @@ func getClient(pollerInput *awsmodels.ResourcePollerInput,
 		Region:        region,
 	}
 
-	// Return the cached client if the credentials used to build it are not expired
-	if cachedClient, exists := clientCache[cacheKey]; exists {
-		if !cachedClient.Credentials.IsExpired() {
-			if cachedClient.Client != nil {
-				return cachedClient.Client, nil
-			}
-			zap.L().Debug("expired client was cached", zap.Any("cache key", cacheKey))
+	clientCacheLock.Lock()
+	defer clientCacheLock.Unlock()
+
+	// Use the cached client if the credentials used to build it are not expired
+	cached, exists := clientCache[cacheKey]
+	if !exists || cached.Credentials.IsExpired() || cached.Client == nil {
+		// Build a new client on cache miss OR if the client in the cache has expired credentials
+		creds := assumeRoleFunc(pollerInput, snapshotPollerSession, region)
+		err := verifyAssumedCredsFunc(creds, region)
+		if err != nil {
+			zap.L().Error(clientErrMessage,
+				zap.Error(err),
+				zap.String("service", service),
+				zap.String("region", region),
+				zap.Any("pollerInput", *pollerInput))
+			return nil, err
 		}
+		cached = cachedClient{
+			Client: clientFunc(snapshotPollerSession, &aws.Config{
+				Credentials: creds,
+				Region:      &region,
+			}),
+			Credentials: creds,
+		}
+		clientCache[cacheKey] = cached
 	}
 
-	// Build a new client on cache miss OR if the client in the cache has expired credentials
-	creds := assumeRoleFunc(pollerInput, snapshotPollerSession, region)
-	err := verifyAssumedCredsFunc(creds, region)
-	if err != nil {
-		zap.L().Error(clientErrMessage,
-			zap.Error(err),
-			zap.String("service", service),
-			zap.String("region", region),
-			zap.Any("pollerInput", *pollerInput))
-		return nil, err
+	if pollerInput.Errors == nil {
+		return cached.Client, nil
 	}
-	client := clientFunc(snapshotPollerSession, &aws.Config{
-		Credentials: creds,
+	sess := snapshotPollerSession.Copy()
+	sess.Handlers.Complete.PushBackNamed(pollerInput.Errors.Handler())
+	return clientFunc(sess, &aws.Config{
+		Credentials: cached.Credentials,
 		Region:      &region,
-	})
-	clientCache[cacheKey] = cachedClient{
-		Client:      client,
-		Credentials: creds,
-	}
-	return client, nil
+	}), nil
 }
 
 //  assumes an IAM role associated with an AWS Snapshot Integration.
@@ -0,0 +1,215 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/aws/aws-sdk-go/service/cloudfront/cloudfrontiface"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Set as variables to be overridden in testing
+var (
+	CloudFrontClientFunc = setupCloudFrontClient
+)
+
+func setupCloudFrontClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return cloudfront.New(sess, cfg)
+}
+
+func getCloudFrontClient(
+	pollerResourceInput *awsmodels.ResourcePollerInput, region string) (cloudfrontiface.CloudFrontAPI, error) {
+
+	client, err := getClient(pollerResourceInput, CloudFrontClientFunc, "cloudfront", region)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	return client.(cloudfrontiface.CloudFrontAPI), nil
+}
+
+// PollCloudFrontDistribution polls a single CloudFront Distribution resource
+func PollCloudFrontDistribution(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getCloudFrontClient(pollerResourceInput, defaultRegion)
+	if err != nil {
+		return nil, err
+	}
+
+	// The resource portion of the ARN is of the form distribution/id
+	distribution, err := getCloudFrontDistribution(client, aws.String(strings.TrimPrefix(resourceARN.Resource, "distribution/")))
+	if err != nil || distribution == nil {
+		return nil, err
+	}
+
+	snapshot := buildCloudFrontDistributionSnapshot(client, distribution)
+	if snapshot == nil {
+		return nil, nil
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(awsmodels.GlobalRegion)
+	return snapshot, nil
+}
+
+// listCloudFrontDistributions returns the IDs of all CloudFront distributions in the account
+func listCloudFrontDistributions(cloudfrontSvc cloudfrontiface.CloudFrontAPI) (distributionIDs []*string) {
+	err := cloudfrontSvc.ListDistributionsPages(&cloudfront.ListDistributionsInput{},
+		func(page *cloudfront.ListDistributionsOutput, lastPage bool) bool {
+			for _, distribution := range page.DistributionList.Items {
+				distributionIDs = append(distributionIDs, distribution.Id)
+			}
+			return true
+		})
+	if err != nil {
+		utils.LogAWSError("CloudFront.ListDistributionsPages", err)
+	}
+	return
+}
+
+// getCloudFrontDistribution returns the full configuration of a CloudFront distribution
+func getCloudFrontDistribution(cloudfrontSvc cloudfrontiface.CloudFrontAPI, id *string) (*cloudfront.Distribution, error) {
+	out, err := cloudfrontSvc.GetDistribution(&cloudfront.GetDistributionInput{Id: id})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == cloudfront.ErrCodeNoSuchDistribution {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *id),
+				zap.String("resourceType", awsmodels.CloudFrontDistributionSchema))
+			return nil, nil
+		}
+		utils.LogAWSError("CloudFront.GetDistribution", err)
+		return nil, err
+	}
+
+	return out.Distribution, nil
+}
+
+// listTagsCloudFront returns the tags for a given CloudFront distribution
+func listTagsCloudFront(cloudfrontSvc cloudfrontiface.CloudFrontAPI, arn *string) (map[string]*string, error) {
+	out, err := cloudfrontSvc.ListTagsForResource(&cloudfront.ListTagsForResourceInput{Resource: arn})
+	if err != nil {
+		utils.LogAWSError("CloudFront.ListTagsForResource", err)
+		return nil, err
+	}
+	if out.Tags == nil {
+		return nil, nil
+	}
+
+	return utils.ParseTagSlice(out.Tags.Items), nil
+}
+
+// buildCloudFrontDistributionSnapshot returns a complete snapshot of a CloudFront distribution
+func buildCloudFrontDistributionSnapshot(
+	cloudfrontSvc cloudfrontiface.CloudFrontAPI,
+	distribution *cloudfront.Distribution,
+) *awsmodels.CloudFrontDistribution {
+
+	if distribution == nil || distribution.DistributionConfig == nil {
+		return nil
+	}
+
+	config := distribution.DistributionConfig
+	snapshot := &awsmodels.CloudFrontDistribution{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   distribution.ARN,
+			ResourceType: aws.String(awsmodels.CloudFrontDistributionSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN: distribution.ARN,
+			ID:  distribution.Id,
+		},
+		ActiveTrustedSigners:          distribution.ActiveTrustedSigners,
+		DomainName:                    distribution.DomainName,
+		InProgressInvalidationBatches: distribution.InProgressInvalidationBatches,
+		LastModifiedTime:              distribution.LastModifiedTime,
+		Status:                        distribution.Status,
+
+		Aliases:              config.Aliases,
+		CacheBehaviors:       config.CacheBehaviors,
+		Comment:              config.Comment,
+		CustomErrorResponses: config.CustomErrorResponses,
+		DefaultCacheBehavior: config.DefaultCacheBehavior,
+		DefaultRootObject:    config.DefaultRootObject,
+		Enabled:              config.Enabled,
+		HttpVersion:          config.HttpVersion,
+		IsIPV6Enabled:        config.IsIPV6Enabled,
+		Logging:              config.Logging,
+		OriginGroups:         config.OriginGroups,
+		Origins:              config.Origins,
+		PriceClass:           config.PriceClass,
+		Restrictions:         config.Restrictions,
+		ViewerCertificate:    config.ViewerCertificate,
+		WebACLId:             config.WebACLId,
+	}
+
+	tags, err := listTagsCloudFront(cloudfrontSvc, distribution.ARN)
+	if err == nil {
+		snapshot.Tags = tags
+	}
+
+	return snapshot
+}
+
+// PollCloudFrontDistributions gathers information on each CloudFront distribution for an AWS account.
+func PollCloudFrontDistributions(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting CloudFront Distribution resource poller")
+	cloudfrontSvc, err := getCloudFrontClient(pollerInput, defaultRegion)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	var resources []*apimodels.AddResourceEntry
+	for _, distributionID := range listCloudFrontDistributions(cloudfrontSvc) {
+		distribution, err := getCloudFrontDistribution(cloudfrontSvc, distributionID)
+		if err != nil || distribution == nil {
+			continue
+		}
+
+		distributionSnapshot := buildCloudFrontDistributionSnapshot(cloudfrontSvc, distribution)
+		if distributionSnapshot == nil {
+			continue
+		}
+		distributionSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		distributionSnapshot.Region = aws.String(awsmodels.GlobalRegion)
+
+		resources = append(resources, &apimodels.AddResourceEntry{
+			Attributes:      distributionSnapshot,
+			ID:              apimodels.ResourceID(*distributionSnapshot.ARN),
+			IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
+			IntegrationType: apimodels.IntegrationTypeAws,
+			Type:            awsmodels.CloudFrontDistributionSchema,
+		})
+	}
+
+	return resources, nil
+}
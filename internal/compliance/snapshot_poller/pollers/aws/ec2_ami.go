@@ -87,11 +87,6 @@ func getAMI(svc ec2iface.EC2API, imageID *string) *ec2.Image {
 
 // buildImageList creates the ec2Ami cache if it does not exist, and populates it for a given region
 func buildImageList(svc ec2iface.EC2API, region string) (err error) {
-	// If ec2Amis is nil there is no cache yet at all
-	if ec2Amis == nil {
-		ec2Amis = make(map[string][]*string)
-	}
-
 	// Get all the instances in this region
 	instances, err := describeInstances(svc)
 	if err != nil {
@@ -107,10 +102,23 @@ func buildImageList(svc ec2iface.EC2API, region string) (err error) {
 			imagesUnique[*instance.ImageId] = struct{}{}
 		}
 	}
+	ec2AmisLock.Lock()
+	defer ec2AmisLock.Unlock()
+	// If ec2Amis is nil there is no cache yet at all
+	if ec2Amis == nil {
+		ec2Amis = make(map[string][]*string)
+	}
 	ec2Amis[region] = images
 	return nil
 }
 
+// cachedInstanceImages returns the AMIs in use in a region, or nil if they are not cached.
+func cachedInstanceImages(region string) []*string {
+	ec2AmisLock.Lock()
+	defer ec2AmisLock.Unlock()
+	return ec2Amis[region]
+}
+
 // describeImages returns all the EC2 AMIs the account has access to
 func describeImages(svc ec2iface.EC2API, region string) ([]*ec2.Image, error) {
 	// Start with the list of images this account owns
@@ -125,7 +133,7 @@ func describeImages(svc ec2iface.EC2API, region string) ([]*ec2.Image, error) {
 	}
 
 	// Additionally, check all images this account is using in this region
-	imageIDs := ec2Amis[region]
+	imageIDs := cachedInstanceImages(region)
 
 	// If imageIDs is nil there is no cache for this region from running the EC2 instance poller
 	if imageIDs == nil {
@@ -133,7 +141,7 @@ func describeImages(svc ec2iface.EC2API, region string) ([]*ec2.Image, error) {
 		if err != nil {
 			return nil, err
 		}
-		imageIDs = ec2Amis[region]
+		imageIDs = cachedInstanceImages(region)
 	}
 
 	// If imageIDs contains no elements, there are no EC2 AMIs in use in this region
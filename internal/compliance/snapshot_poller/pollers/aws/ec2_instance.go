@@ -20,6 +20,7 @@ package aws
 
 import (
 	"strings"
+	"sync"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/arn"
@@ -36,7 +37,13 @@ import (
 )
 
 var (
-	ec2Amis map[string][]*string
+	// ec2Amis caches the AMIs in use by the instances of each region for the EC2 AMI poller
+	ec2Amis     map[string][]*string
+	ec2AmisLock sync.Mutex
+
+	// ec2AmisPolling collects the AMIs of regions whose instances are being paged through, and each
+	// list is moved to ec2Amis after the last page so the cache never holds a partial list
+	ec2AmisPolling = make(map[string][]*string)
 )
 
 // PollEC2Instance polls a single EC2 Instance resource
@@ -101,6 +108,25 @@ func describeInstances(ec2Svc ec2iface.EC2API) (instances []*ec2.Instance, err e
 	return
 }
 
+// describeInstancesPage returns one page of EC2 instances in the current region, and the token of
+// the next page
+func describeInstancesPage(ec2Svc ec2iface.EC2API, pageToken *string) (
+	instances []*ec2.Instance, nextPageToken *string, err error) {
+
+	err = ec2Svc.DescribeInstancesPages(&ec2.DescribeInstancesInput{NextToken: pageToken},
+		func(page *ec2.DescribeInstancesOutput, lastPage bool) bool {
+			for _, reservation := range page.Reservations {
+				instances = append(instances, reservation.Instances...)
+			}
+			nextPageToken = page.NextToken
+			return false
+		})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "EC2.DescribeInstances")
+	}
+	return
+}
+
 // buildEc2InstanceSnapshot makes the necessary API calls to build a full Ec2InstanceSnapshot
 func buildEc2InstanceSnapshot(_ ec2iface.EC2API, instance *ec2.Instance) *awsmodels.Ec2Instance {
 	if instance == nil {
@@ -165,63 +191,57 @@ func buildEc2InstanceSnapshot(_ ec2iface.EC2API, instance *ec2.Instance) *awsmod
 // PollEc2Instances gathers information on each EC2 instance in an AWS account.
 func PollEc2Instances(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
 	zap.L().Debug("starting EC2 Instance resource poller")
-	ec2InstanceSnapshots := make(map[string]*awsmodels.Ec2Instance)
-
-	// Reset list of AMIs
-	ec2Amis = make(map[string][]*string)
+	return pollAllPages(pollerInput, utils.GetServiceRegions(pollerInput.Regions, "ec2"), PollEc2InstancesPage)
+}
 
-	for _, regionID := range utils.GetServiceRegions(pollerInput.Regions, "ec2") {
-		ec2Svc, err := getEC2Client(pollerInput, *regionID)
-		if err != nil {
-			return nil, err // error is logged in getClient()
-		}
+// PollEc2InstancesPage gathers information on one page of EC2 instances in a region.
+func PollEc2InstancesPage(
+	pollerInput *awsmodels.ResourcePollerInput,
+	region string,
+	pageToken *string,
+) ([]*apimodels.AddResourceEntry, *string, error) {
 
-		// Start with generating a list of all EC2 instances
-		instances, err := describeInstances(ec2Svc)
-		if err != nil {
-			return nil, errors.Wrapf(err, "PollEc2Instances(%#v) in region %s", *pollerInput, *regionID)
-		}
+	ec2Svc, err := getEC2Client(pollerInput, region)
+	if err != nil {
+		return nil, nil, err // error is logged in getClient()
+	}
 
-		// For each instance, build out a full snapshot
-		zap.L().Debug("building EC2 Instance snapshots", zap.String("region", *regionID))
-		for _, instance := range instances {
-			ec2Instance := buildEc2InstanceSnapshot(ec2Svc, instance)
-
-			// arn:aws:ec2:region:account-id:instance/instance-id
-			resourceID := strings.Join(
-				[]string{
-					"arn",
-					pollerInput.AuthSourceParsedARN.Partition,
-					"ec2",
-					*regionID,
-					pollerInput.AuthSourceParsedARN.AccountID,
-					"instance/" + *ec2Instance.ID,
-				},
-				":",
-			)
-
-			// Populate generic fields
-			ec2Instance.ResourceID = aws.String(resourceID)
-
-			// Populate AWS generic fields
-			ec2Instance.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
-			ec2Instance.Region = regionID
-			ec2Instance.ARN = aws.String(resourceID)
-
-			ec2Amis[*regionID] = append(ec2Amis[*regionID], ec2Instance.ImageId)
-			if _, ok := ec2InstanceSnapshots[resourceID]; !ok {
-				ec2InstanceSnapshots[resourceID] = ec2Instance
-			} else {
-				zap.L().Info("overwriting existing EC2 Instance snapshot", zap.String("resourceId", resourceID))
-				ec2InstanceSnapshots[resourceID] = ec2Instance
-			}
-		}
+	instances, nextPageToken, err := describeInstancesPage(ec2Svc, pageToken)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "PollEc2Instances(%#v) in region %s", *pollerInput, region)
 	}
 
-	resources := make([]*apimodels.AddResourceEntry, 0, len(ec2InstanceSnapshots))
-	for resourceID, ec2InstanceSnapshot := range ec2InstanceSnapshots {
+	// For each instance, build out a full snapshot
+	zap.L().Debug("building EC2 Instance snapshots", zap.String("region", region))
+	resources := make([]*apimodels.AddResourceEntry, 0, len(instances))
+	images := make([]*string, 0, len(instances))
+	for _, instance := range instances {
+		ec2Instance := buildEc2InstanceSnapshot(ec2Svc, instance)
+
+		// arn:aws:ec2:region:account-id:instance/instance-id
+		resourceID := strings.Join(
+			[]string{
+				"arn",
+				pollerInput.AuthSourceParsedARN.Partition,
+				"ec2",
+				region,
+				pollerInput.AuthSourceParsedARN.AccountID,
+				"instance/" + *ec2Instance.ID,
+			},
+			":",
+		)
+
+		// Populate generic fields
+		ec2Instance.ResourceID = aws.String(resourceID)
+
+		// Populate AWS generic fields
+		ec2Instance.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		ec2Instance.Region = aws.String(region)
+		ec2Instance.ARN = aws.String(resourceID)
+
+		images = append(images, ec2Instance.ImageId)
 		resources = append(resources, &apimodels.AddResourceEntry{
-			Attributes:      ec2InstanceSnapshot,
+			Attributes:      ec2Instance,
 			ID:              apimodels.ResourceID(resourceID),
 			IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
 			IntegrationType: apimodels.IntegrationTypeAws,
@@ -229,5 +249,33 @@ func PollEc2Instances(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.
 		})
 	}
 
-	return resources, nil
+	cacheInstanceImages(region, pageToken == nil, nextPageToken == nil, images)
+	return resources, nextPageToken, nil
+}
+
+// cacheInstanceImages records the AMIs used by a page of instances for the EC2 AMI poller.
+func cacheInstanceImages(region string, firstPage, lastPage bool, images []*string) {
+	ec2AmisLock.Lock()
+	defer ec2AmisLock.Unlock()
+
+	polling, ok := ec2AmisPolling[region]
+	if !ok && !firstPage {
+		// The earlier pages of this region were polled by another invocation
+		return
+	}
+	polling = append(polling, images...)
+
+	if !lastPage {
+		ec2AmisPolling[region] = polling
+		return
+	}
+	delete(ec2AmisPolling, region)
+	if ec2Amis == nil {
+		ec2Amis = make(map[string][]*string)
+	}
+	if polling == nil {
+		// An empty (but not nil) list records that the region has no AMIs in use
+		polling = []*string{}
+	}
+	ec2Amis[region] = polling
 }
@@ -22,6 +22,7 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -91,3 +92,40 @@ func TestEC2PollInstancesError(t *testing.T) {
 	require.Error(t, err)
 	assert.Empty(t, resources)
 }
+
+func TestEC2PollInstancesPage(t *testing.T) {
+	awstest.MockEC2ForSetup = awstest.BuildMockEC2SvcAll()
+
+	EC2ClientFunc = awstest.SetupMockEC2
+	ec2Amis = nil
+
+	resources, nextPageToken, err := PollEc2InstancesPage(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	}, "us-west-2", nil)
+
+	require.NoError(t, err)
+	assert.Nil(t, nextPageToken)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "arn:aws:ec2:us-west-2:123456789012:instance/instance-aabbcc123", string(resources[0].ID))
+
+	// The AMIs of the region are cached for the EC2 AMI poller after its last page
+	assert.Equal(t, []*string{awstest.ExampleInstance.ImageId}, ec2Amis["us-west-2"])
+	assert.Empty(t, ec2AmisPolling)
+}
+
+func TestEC2CacheInstanceImagesResumedRegion(t *testing.T) {
+	ec2Amis = nil
+
+	// The first pages of the region were polled by another invocation
+	cacheInstanceImages("us-east-1", false, true, []*string{aws.String("ami-1")})
+	assert.Nil(t, ec2Amis["us-east-1"])
+
+	cacheInstanceImages("us-east-1", true, false, []*string{aws.String("ami-1")})
+	assert.Nil(t, ec2Amis["us-east-1"])
+	cacheInstanceImages("us-east-1", false, true, []*string{aws.String("ami-2")})
+	assert.Equal(t, []*string{aws.String("ami-1"), aws.String("ami-2")}, ec2Amis["us-east-1"])
+}
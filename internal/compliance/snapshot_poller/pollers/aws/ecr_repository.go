@@ -0,0 +1,227 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/aws/aws-sdk-go/service/ecr/ecriface"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Set as variables to be overridden in testing
+var (
+	EcrClientFunc = setupEcrClient
+)
+
+func setupEcrClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return ecr.New(sess, cfg)
+}
+
+func getEcrClient(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (ecriface.ECRAPI, error) {
+	client, err := getClient(pollerResourceInput, EcrClientFunc, "ecr", region)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	return client.(ecriface.ECRAPI), nil
+}
+
+// PollECRRepository polls a single ECR Repository resource
+func PollECRRepository(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getEcrClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	// The resource portion of the ARN is of the form repository/name, where the name may itself
+	// contain slashes
+	repositoryName := strings.TrimPrefix(resourceARN.Resource, "repository/")
+	repositories, err := describeEcrRepositories(client, []*string{aws.String(repositoryName)})
+	if err != nil {
+		return nil, err
+	}
+	if len(repositories) == 0 {
+		zap.L().Warn("tried to scan non-existent resource",
+			zap.String("resource", resourceARN.String()),
+			zap.String("resourceType", awsmodels.EcrRepositorySchema))
+		return nil, nil
+	}
+
+	snapshot := buildEcrRepositorySnapshot(client, repositories[0])
+	if snapshot == nil {
+		return nil, nil
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// describeEcrRepositories returns the given ECR repositories, or all repositories in the region
+// if no names are given
+func describeEcrRepositories(ecrSvc ecriface.ECRAPI, names []*string) (repositories []*ecr.Repository, err error) {
+	err = ecrSvc.DescribeRepositoriesPages(&ecr.DescribeRepositoriesInput{RepositoryNames: names},
+		func(page *ecr.DescribeRepositoriesOutput, lastPage bool) bool {
+			repositories = append(repositories, page.Repositories...)
+			return true
+		})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == ecr.ErrCodeRepositoryNotFoundException {
+			return nil, nil
+		}
+		utils.LogAWSError("ECR.DescribeRepositoriesPages", err)
+		return nil, err
+	}
+	return repositories, nil
+}
+
+// getEcrRepositoryPolicy returns the resource policy of an ECR repository, if one is set
+func getEcrRepositoryPolicy(ecrSvc ecriface.ECRAPI, name *string) (*string, error) {
+	out, err := ecrSvc.GetRepositoryPolicy(&ecr.GetRepositoryPolicyInput{RepositoryName: name})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == ecr.ErrCodeRepositoryPolicyNotFoundException {
+			zap.L().Debug("no ECR repository policy set", zap.String("repository", *name))
+			return nil, nil
+		}
+		utils.LogAWSError("ECR.GetRepositoryPolicy", err)
+		return nil, err
+	}
+
+	return out.PolicyText, nil
+}
+
+// getEcrLifecyclePolicy returns the lifecycle policy of an ECR repository, if one is set
+func getEcrLifecyclePolicy(ecrSvc ecriface.ECRAPI, name *string) (*string, error) {
+	out, err := ecrSvc.GetLifecyclePolicy(&ecr.GetLifecyclePolicyInput{RepositoryName: name})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == ecr.ErrCodeLifecyclePolicyNotFoundException {
+			zap.L().Debug("no ECR lifecycle policy set", zap.String("repository", *name))
+			return nil, nil
+		}
+		utils.LogAWSError("ECR.GetLifecyclePolicy", err)
+		return nil, err
+	}
+
+	return out.LifecyclePolicyText, nil
+}
+
+// listTagsEcr returns the tags for a given ECR repository
+func listTagsEcr(ecrSvc ecriface.ECRAPI, arn *string) (map[string]*string, error) {
+	out, err := ecrSvc.ListTagsForResource(&ecr.ListTagsForResourceInput{ResourceArn: arn})
+	if err != nil {
+		utils.LogAWSError("ECR.ListTagsForResource", err)
+		return nil, err
+	}
+
+	return utils.ParseTagSlice(out.Tags), nil
+}
+
+// buildEcrRepositorySnapshot returns a complete snapshot of an ECR repository
+func buildEcrRepositorySnapshot(ecrSvc ecriface.ECRAPI, repository *ecr.Repository) *awsmodels.EcrRepository {
+	if repository == nil {
+		return nil
+	}
+
+	ecrRepository := &awsmodels.EcrRepository{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   repository.RepositoryArn,
+			ResourceType: aws.String(awsmodels.EcrRepositorySchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  repository.RepositoryArn,
+			Name: repository.RepositoryName,
+		},
+		ImageScanningConfiguration: repository.ImageScanningConfiguration,
+		ImageTagMutability:         repository.ImageTagMutability,
+		RegistryId:                 repository.RegistryId,
+		RepositoryUri:              repository.RepositoryUri,
+	}
+	if repository.CreatedAt != nil {
+		ecrRepository.TimeCreated = utils.DateTimeFormat(*repository.CreatedAt)
+	}
+
+	tags, err := listTagsEcr(ecrSvc, repository.RepositoryArn)
+	if err == nil {
+		ecrRepository.Tags = tags
+	}
+
+	policy, err := getEcrRepositoryPolicy(ecrSvc, repository.RepositoryName)
+	if err == nil {
+		ecrRepository.Policy = policy
+	}
+
+	lifecyclePolicy, err := getEcrLifecyclePolicy(ecrSvc, repository.RepositoryName)
+	if err == nil {
+		ecrRepository.LifecyclePolicy = lifecyclePolicy
+	}
+
+	return ecrRepository
+}
+
+// PollEcrRepositories gathers information on each ECR repository for an AWS account.
+func PollEcrRepositories(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting ECR Repository resource poller")
+	var resources []*apimodels.AddResourceEntry
+
+	for _, regionID := range utils.GetServiceRegions(pollerInput.Regions, ecr.EndpointsID) {
+		ecrSvc, err := getEcrClient(pollerInput, *regionID)
+		if err != nil {
+			return nil, err // error is logged in getClient()
+		}
+
+		repositories, err := describeEcrRepositories(ecrSvc, nil)
+		if err != nil {
+			continue
+		}
+
+		for _, repository := range repositories {
+			ecrRepositorySnapshot := buildEcrRepositorySnapshot(ecrSvc, repository)
+			if ecrRepositorySnapshot == nil {
+				continue
+			}
+			ecrRepositorySnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+			ecrRepositorySnapshot.Region = regionID
+
+			resources = append(resources, &apimodels.AddResourceEntry{
+				Attributes:      ecrRepositorySnapshot,
+				ID:              apimodels.ResourceID(*ecrRepositorySnapshot.ARN),
+				IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
+				IntegrationType: apimodels.IntegrationTypeAws,
+				Type:            awsmodels.EcrRepositorySchema,
+			})
+		}
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,135 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestEcrRepositoryDescribe(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvc([]string{"DescribeRepositoriesPages"})
+
+	out, err := describeEcrRepositories(mockSvc, nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestEcrRepositoryDescribeError(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvcError([]string{"DescribeRepositoriesPages"})
+
+	out, err := describeEcrRepositories(mockSvc, nil)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestEcrRepositoryGetPolicy(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvc([]string{"GetRepositoryPolicy"})
+
+	out, err := getEcrRepositoryPolicy(mockSvc, awstest.ExampleEcrRepositoryName)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestEcrRepositoryGetPolicyError(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvcError([]string{"GetRepositoryPolicy"})
+
+	out, err := getEcrRepositoryPolicy(mockSvc, awstest.ExampleEcrRepositoryName)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestEcrRepositoryGetLifecyclePolicy(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvc([]string{"GetLifecyclePolicy"})
+
+	out, err := getEcrLifecyclePolicy(mockSvc, awstest.ExampleEcrRepositoryName)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestEcrRepositoryGetLifecyclePolicyError(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvcError([]string{"GetLifecyclePolicy"})
+
+	out, err := getEcrLifecyclePolicy(mockSvc, awstest.ExampleEcrRepositoryName)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestEcrRepositoryBuildSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvcAll()
+
+	snapshot := buildEcrRepositorySnapshot(mockSvc, awstest.ExampleDescribeRepositories.Repositories[0])
+
+	assert.Equal(t, "arn:aws:ecr:us-west-2:123456789012:repository/example-repository", *snapshot.ARN)
+	assert.True(t, *snapshot.ImageScanningConfiguration.ScanOnPush)
+	assert.Equal(t, "Panther", *snapshot.Tags["Application"])
+	assert.NotEmpty(t, snapshot.Policy)
+	assert.NotEmpty(t, snapshot.LifecyclePolicy)
+}
+
+func TestEcrRepositoryBuildSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockEcrSvcAllError()
+
+	snapshot := buildEcrRepositorySnapshot(mockSvc, awstest.ExampleDescribeRepositories.Repositories[0])
+
+	assert.NotNil(t, snapshot)
+	assert.Nil(t, snapshot.Tags)
+	assert.Nil(t, snapshot.Policy)
+	assert.Nil(t, snapshot.LifecyclePolicy)
+}
+
+func TestEcrPoller(t *testing.T) {
+	awstest.MockEcrForSetup = awstest.BuildMockEcrSvcAll()
+
+	EcrClientFunc = awstest.SetupMockEcr
+
+	resources, err := PollEcrRepositories(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, resources)
+	assert.Equal(t, awsmodels.EcrRepositorySchema, string(resources[0].Type))
+}
+
+func TestEcrPollerError(t *testing.T) {
+	awstest.MockEcrForSetup = awstest.BuildMockEcrSvcAllError()
+
+	EcrClientFunc = awstest.SetupMockEcr
+
+	resources, err := PollEcrRepositories(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+}
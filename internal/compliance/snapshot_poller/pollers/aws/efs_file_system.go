@@ -0,0 +1,245 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/efs"
+	"github.com/aws/aws-sdk-go/service/efs/efsiface"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Set as variables to be overridden in testing
+var (
+	EfsClientFunc = setupEfsClient
+)
+
+func setupEfsClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return efs.New(sess, cfg)
+}
+
+func getEfsClient(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (efsiface.EFSAPI, error) {
+	client, err := getClient(pollerResourceInput, EfsClientFunc, "efs", region)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	return client.(efsiface.EFSAPI), nil
+}
+
+// PollEFSFileSystem polls a single EFS File System resource
+func PollEFSFileSystem(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getEfsClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	// The resource portion of the ARN is of the form file-system/fs-id
+	fileSystemID := strings.TrimPrefix(resourceARN.Resource, "file-system/")
+	fileSystems, err := describeFileSystems(client, aws.String(fileSystemID))
+	if err != nil {
+		return nil, err
+	}
+	if len(fileSystems) == 0 {
+		zap.L().Warn("tried to scan non-existent resource",
+			zap.String("resource", resourceARN.String()),
+			zap.String("resourceType", awsmodels.EfsFileSystemSchema))
+		return nil, nil
+	}
+
+	snapshot := buildEfsFileSystemSnapshot(client, fileSystems[0], resourceARN.Region)
+	if snapshot == nil {
+		return nil, nil
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// describeFileSystems returns the given EFS file system, or all file systems in the region if no
+// ID is given
+func describeFileSystems(efsSvc efsiface.EFSAPI, fileSystemID *string) (fileSystems []*efs.FileSystemDescription, err error) {
+	err = efsSvc.DescribeFileSystemsPages(&efs.DescribeFileSystemsInput{FileSystemId: fileSystemID},
+		func(page *efs.DescribeFileSystemsOutput, lastPage bool) bool {
+			fileSystems = append(fileSystems, page.FileSystems...)
+			return true
+		})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == efs.ErrCodeFileSystemNotFound {
+			return nil, nil
+		}
+		utils.LogAWSError("EFS.DescribeFileSystemsPages", err)
+		return nil, err
+	}
+	return fileSystems, nil
+}
+
+// getFileSystemPolicy returns the resource policy of an EFS file system, if one is set
+func getFileSystemPolicy(efsSvc efsiface.EFSAPI, fileSystemID *string) (*string, error) {
+	out, err := efsSvc.DescribeFileSystemPolicy(&efs.DescribeFileSystemPolicyInput{FileSystemId: fileSystemID})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == efs.ErrCodePolicyNotFound {
+			zap.L().Debug("no EFS file system policy set", zap.String("fileSystemId", *fileSystemID))
+			return nil, nil
+		}
+		utils.LogAWSError("EFS.DescribeFileSystemPolicy", err)
+		return nil, err
+	}
+
+	return out.Policy, nil
+}
+
+// getFileSystemLifecyclePolicies returns the lifecycle management policies of an EFS file system
+func getFileSystemLifecyclePolicies(efsSvc efsiface.EFSAPI, fileSystemID *string) ([]*efs.LifecyclePolicy, error) {
+	out, err := efsSvc.DescribeLifecycleConfiguration(
+		&efs.DescribeLifecycleConfigurationInput{FileSystemId: fileSystemID})
+	if err != nil {
+		utils.LogAWSError("EFS.DescribeLifecycleConfiguration", err)
+		return nil, err
+	}
+
+	return out.LifecyclePolicies, nil
+}
+
+// getFileSystemMountTargets returns the mount targets of an EFS file system
+func getFileSystemMountTargets(efsSvc efsiface.EFSAPI, fileSystemID *string) ([]*efs.MountTargetDescription, error) {
+	out, err := efsSvc.DescribeMountTargets(&efs.DescribeMountTargetsInput{FileSystemId: fileSystemID})
+	if err != nil {
+		utils.LogAWSError("EFS.DescribeMountTargets", err)
+		return nil, err
+	}
+
+	return out.MountTargets, nil
+}
+
+// buildEfsFileSystemSnapshot returns a complete snapshot of an EFS file system
+func buildEfsFileSystemSnapshot(
+	efsSvc efsiface.EFSAPI,
+	fileSystem *efs.FileSystemDescription,
+	region string,
+) *awsmodels.EfsFileSystem {
+
+	if fileSystem == nil || fileSystem.OwnerId == nil {
+		return nil
+	}
+
+	// The file system description does not include the ARN, so we construct it
+	fileSystemARN := arn.ARN{
+		Partition: "aws",
+		Service:   "elasticfilesystem",
+		Region:    region,
+		AccountID: *fileSystem.OwnerId,
+		Resource:  "file-system/" + *fileSystem.FileSystemId,
+	}.String()
+
+	snapshot := &awsmodels.EfsFileSystem{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   aws.String(fileSystemARN),
+			ResourceType: aws.String(awsmodels.EfsFileSystemSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  aws.String(fileSystemARN),
+			ID:   fileSystem.FileSystemId,
+			Name: fileSystem.Name,
+			Tags: utils.ParseTagSlice(fileSystem.Tags),
+		},
+		CreationToken:                fileSystem.CreationToken,
+		Encrypted:                    fileSystem.Encrypted,
+		KmsKeyId:                     fileSystem.KmsKeyId,
+		LifeCycleState:               fileSystem.LifeCycleState,
+		NumberOfMountTargets:         fileSystem.NumberOfMountTargets,
+		OwnerId:                      fileSystem.OwnerId,
+		PerformanceMode:              fileSystem.PerformanceMode,
+		ProvisionedThroughputInMibps: fileSystem.ProvisionedThroughputInMibps,
+		SizeInBytes:                  fileSystem.SizeInBytes,
+		ThroughputMode:               fileSystem.ThroughputMode,
+	}
+	if fileSystem.CreationTime != nil {
+		snapshot.TimeCreated = utils.DateTimeFormat(*fileSystem.CreationTime)
+	}
+
+	policy, err := getFileSystemPolicy(efsSvc, fileSystem.FileSystemId)
+	if err == nil {
+		snapshot.Policy = policy
+	}
+
+	lifecyclePolicies, err := getFileSystemLifecyclePolicies(efsSvc, fileSystem.FileSystemId)
+	if err == nil {
+		snapshot.LifecyclePolicies = lifecyclePolicies
+	}
+
+	mountTargets, err := getFileSystemMountTargets(efsSvc, fileSystem.FileSystemId)
+	if err == nil {
+		snapshot.MountTargets = mountTargets
+	}
+
+	return snapshot
+}
+
+// PollEfsFileSystems gathers information on each EFS file system for an AWS account.
+func PollEfsFileSystems(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting EFS File System resource poller")
+	var resources []*apimodels.AddResourceEntry
+
+	for _, regionID := range utils.GetServiceRegions(pollerInput.Regions, efs.EndpointsID) {
+		efsSvc, err := getEfsClient(pollerInput, *regionID)
+		if err != nil {
+			return nil, err // error is logged in getClient()
+		}
+
+		fileSystems, err := describeFileSystems(efsSvc, nil)
+		if err != nil {
+			continue
+		}
+
+		for _, fileSystem := range fileSystems {
+			fileSystemSnapshot := buildEfsFileSystemSnapshot(efsSvc, fileSystem, *regionID)
+			if fileSystemSnapshot == nil {
+				continue
+			}
+			fileSystemSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+			fileSystemSnapshot.Region = regionID
+
+			resources = append(resources, &apimodels.AddResourceEntry{
+				Attributes:      fileSystemSnapshot,
+				ID:              apimodels.ResourceID(*fileSystemSnapshot.ARN),
+				IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
+				IntegrationType: apimodels.IntegrationTypeAws,
+				Type:            awsmodels.EfsFileSystemSchema,
+			})
+		}
+	}
+
+	return resources, nil
+}
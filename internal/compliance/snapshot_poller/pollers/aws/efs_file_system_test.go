@@ -0,0 +1,120 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestEfsFileSystemDescribe(t *testing.T) {
+	mockSvc := awstest.BuildMockEfsSvc([]string{"DescribeFileSystemsPages"})
+
+	out, err := describeFileSystems(mockSvc, nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestEfsFileSystemDescribeError(t *testing.T) {
+	mockSvc := awstest.BuildMockEfsSvcError([]string{"DescribeFileSystemsPages"})
+
+	out, err := describeFileSystems(mockSvc, nil)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestEfsFileSystemGetPolicy(t *testing.T) {
+	mockSvc := awstest.BuildMockEfsSvc([]string{"DescribeFileSystemPolicy"})
+
+	out, err := getFileSystemPolicy(mockSvc, awstest.ExampleFileSystemID)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestEfsFileSystemGetPolicyError(t *testing.T) {
+	mockSvc := awstest.BuildMockEfsSvcError([]string{"DescribeFileSystemPolicy"})
+
+	out, err := getFileSystemPolicy(mockSvc, awstest.ExampleFileSystemID)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestEfsFileSystemBuildSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockEfsSvcAll()
+
+	snapshot := buildEfsFileSystemSnapshot(mockSvc, awstest.ExampleDescribeFileSystems.FileSystems[0], "us-west-2")
+
+	assert.Equal(t, "arn:aws:elasticfilesystem:us-west-2:123456789012:file-system/fs-01234567", *snapshot.ARN)
+	assert.True(t, *snapshot.Encrypted)
+	assert.Equal(t, "example-file-system", *snapshot.Tags["Name"])
+	assert.NotEmpty(t, snapshot.Policy)
+	assert.NotEmpty(t, snapshot.LifecyclePolicies)
+	assert.NotEmpty(t, snapshot.MountTargets)
+}
+
+func TestEfsFileSystemBuildSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockEfsSvcAllError()
+
+	snapshot := buildEfsFileSystemSnapshot(mockSvc, awstest.ExampleDescribeFileSystems.FileSystems[0], "us-west-2")
+
+	assert.NotNil(t, snapshot)
+	assert.Nil(t, snapshot.Policy)
+	assert.Nil(t, snapshot.LifecyclePolicies)
+	assert.Nil(t, snapshot.MountTargets)
+}
+
+func TestEfsPoller(t *testing.T) {
+	awstest.MockEfsForSetup = awstest.BuildMockEfsSvcAll()
+
+	EfsClientFunc = awstest.SetupMockEfs
+
+	resources, err := PollEfsFileSystems(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, resources)
+	assert.Equal(t, awsmodels.EfsFileSystemSchema, string(resources[0].Type))
+}
+
+func TestEfsPollerError(t *testing.T) {
+	awstest.MockEfsForSetup = awstest.BuildMockEfsSvcAllError()
+
+	EfsClientFunc = awstest.SetupMockEfs
+
+	resources, err := PollEfsFileSystems(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+}
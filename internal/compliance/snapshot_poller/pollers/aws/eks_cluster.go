@@ -0,0 +1,217 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/eks/eksiface"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Set as variables to be overridden in testing
+var (
+	EksClientFunc = setupEksClient
+)
+
+func setupEksClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return eks.New(sess, cfg)
+}
+
+func getEksClient(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (eksiface.EKSAPI, error) {
+	client, err := getClient(pollerResourceInput, EksClientFunc, "eks", region)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	return client.(eksiface.EKSAPI), nil
+}
+
+// PollEKSCluster polls a single EKS Cluster resource
+func PollEKSCluster(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getEksClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	// The resource portion of the ARN is of the form cluster/name
+	clusterName := strings.TrimPrefix(resourceARN.Resource, "cluster/")
+	cluster, err := describeEksCluster(client, aws.String(clusterName))
+	if err != nil || cluster == nil {
+		return nil, err
+	}
+
+	snapshot := buildEksClusterSnapshot(client, cluster)
+	if snapshot == nil {
+		return nil, nil
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// listEksClusters returns the names of all EKS clusters in the region
+func listEksClusters(eksSvc eksiface.EKSAPI) (clusters []*string) {
+	err := eksSvc.ListClustersPages(&eks.ListClustersInput{},
+		func(page *eks.ListClustersOutput, lastPage bool) bool {
+			clusters = append(clusters, page.Clusters...)
+			return true
+		})
+	if err != nil {
+		utils.LogAWSError("EKS.ListClustersPages", err)
+	}
+	return
+}
+
+// describeEksCluster provides detailed information for a given EKS cluster
+func describeEksCluster(eksSvc eksiface.EKSAPI, name *string) (*eks.Cluster, error) {
+	out, err := eksSvc.DescribeCluster(&eks.DescribeClusterInput{Name: name})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == eks.ErrCodeResourceNotFoundException {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *name),
+				zap.String("resourceType", awsmodels.EksClusterSchema))
+			return nil, nil
+		}
+		utils.LogAWSError("EKS.DescribeCluster", err)
+		return nil, err
+	}
+
+	return out.Cluster, nil
+}
+
+// listEksNodegroups returns all managed node groups of a given EKS cluster
+func listEksNodegroups(eksSvc eksiface.EKSAPI, clusterName *string) ([]*eks.Nodegroup, error) {
+	var names []*string
+	err := eksSvc.ListNodegroupsPages(&eks.ListNodegroupsInput{ClusterName: clusterName},
+		func(page *eks.ListNodegroupsOutput, lastPage bool) bool {
+			names = append(names, page.Nodegroups...)
+			return true
+		})
+	if err != nil {
+		utils.LogAWSError("EKS.ListNodegroupsPages", err)
+		return nil, err
+	}
+
+	nodegroups := make([]*eks.Nodegroup, 0, len(names))
+	for _, name := range names {
+		out, err := eksSvc.DescribeNodegroup(&eks.DescribeNodegroupInput{
+			ClusterName:   clusterName,
+			NodegroupName: name,
+		})
+		if err != nil {
+			utils.LogAWSError("EKS.DescribeNodegroup", err)
+			return nil, err
+		}
+		nodegroups = append(nodegroups, out.Nodegroup)
+	}
+
+	return nodegroups, nil
+}
+
+// buildEksClusterSnapshot returns a complete snapshot of an EKS cluster
+func buildEksClusterSnapshot(eksSvc eksiface.EKSAPI, cluster *eks.Cluster) *awsmodels.EksCluster {
+	if cluster == nil {
+		return nil
+	}
+
+	eksCluster := &awsmodels.EksCluster{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   cluster.Arn,
+			ResourceType: aws.String(awsmodels.EksClusterSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  cluster.Arn,
+			Name: cluster.Name,
+			Tags: cluster.Tags,
+		},
+		CertificateAuthority: cluster.CertificateAuthority,
+		EncryptionConfig:     cluster.EncryptionConfig,
+		Endpoint:             cluster.Endpoint,
+		Identity:             cluster.Identity,
+		Logging:              cluster.Logging,
+		PlatformVersion:      cluster.PlatformVersion,
+		ResourcesVpcConfig:   cluster.ResourcesVpcConfig,
+		RoleArn:              cluster.RoleArn,
+		Status:               cluster.Status,
+		Version:              cluster.Version,
+	}
+	if cluster.CreatedAt != nil {
+		eksCluster.TimeCreated = utils.DateTimeFormat(*cluster.CreatedAt)
+	}
+
+	nodegroups, err := listEksNodegroups(eksSvc, cluster.Name)
+	if err == nil {
+		eksCluster.NodeGroups = nodegroups
+	}
+
+	return eksCluster
+}
+
+// PollEksClusters gathers information on each EKS cluster for an AWS account.
+func PollEksClusters(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting EKS Cluster resource poller")
+	var resources []*apimodels.AddResourceEntry
+
+	for _, regionID := range utils.GetServiceRegions(pollerInput.Regions, "eks") {
+		eksSvc, err := getEksClient(pollerInput, *regionID)
+		if err != nil {
+			return nil, err // error is logged in getClient()
+		}
+
+		for _, clusterName := range listEksClusters(eksSvc) {
+			cluster, err := describeEksCluster(eksSvc, clusterName)
+			if err != nil || cluster == nil {
+				continue
+			}
+
+			eksClusterSnapshot := buildEksClusterSnapshot(eksSvc, cluster)
+			if eksClusterSnapshot == nil {
+				continue
+			}
+			eksClusterSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+			eksClusterSnapshot.Region = regionID
+
+			resources = append(resources, &apimodels.AddResourceEntry{
+				Attributes:      eksClusterSnapshot,
+				ID:              apimodels.ResourceID(*eksClusterSnapshot.ARN),
+				IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
+				IntegrationType: apimodels.IntegrationTypeAws,
+				Type:            awsmodels.EksClusterSchema,
+			})
+		}
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,130 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestEksClusterList(t *testing.T) {
+	mockSvc := awstest.BuildMockEksSvc([]string{"ListClustersPages"})
+
+	out := listEksClusters(mockSvc)
+	assert.NotEmpty(t, out)
+}
+
+func TestEksClusterListError(t *testing.T) {
+	mockSvc := awstest.BuildMockEksSvcError([]string{"ListClustersPages"})
+
+	out := listEksClusters(mockSvc)
+	assert.Nil(t, out)
+}
+
+func TestEksClusterDescribe(t *testing.T) {
+	mockSvc := awstest.BuildMockEksSvc([]string{"DescribeCluster"})
+
+	out, err := describeEksCluster(mockSvc, awstest.ExampleEksClusterName)
+	require.NoError(t, err)
+	assert.NotNil(t, out)
+}
+
+func TestEksClusterDescribeError(t *testing.T) {
+	mockSvc := awstest.BuildMockEksSvcError([]string{"DescribeCluster"})
+
+	out, err := describeEksCluster(mockSvc, awstest.ExampleEksClusterName)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestEksClusterListNodegroups(t *testing.T) {
+	mockSvc := awstest.BuildMockEksSvc([]string{"ListNodegroupsPages", "DescribeNodegroup"})
+
+	out, err := listEksNodegroups(mockSvc, awstest.ExampleEksClusterName)
+	require.NoError(t, err)
+	assert.Len(t, out, 1)
+}
+
+func TestEksClusterListNodegroupsError(t *testing.T) {
+	mockSvc := awstest.BuildMockEksSvcError([]string{"ListNodegroupsPages"})
+
+	out, err := listEksNodegroups(mockSvc, awstest.ExampleEksClusterName)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestEksClusterBuildSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockEksSvcAll()
+
+	snapshot := buildEksClusterSnapshot(mockSvc, awstest.ExampleDescribeClusterEks.Cluster)
+
+	assert.Equal(t, "arn:aws:eks:us-west-2:123456789012:cluster/example-cluster", *snapshot.ARN)
+	assert.NotEmpty(t, snapshot.Tags)
+	assert.NotEmpty(t, snapshot.NodeGroups)
+	assert.NotNil(t, snapshot.TimeCreated)
+}
+
+func TestEksClusterBuildSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockEksSvcAllError()
+
+	snapshot := buildEksClusterSnapshot(mockSvc, awstest.ExampleDescribeClusterEks.Cluster)
+
+	assert.NotNil(t, snapshot)
+	assert.Nil(t, snapshot.NodeGroups)
+}
+
+func TestEksClusterPoller(t *testing.T) {
+	awstest.MockEksForSetup = awstest.BuildMockEksSvcAll()
+
+	EksClientFunc = awstest.SetupMockEks
+
+	resources, err := PollEksClusters(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, resources)
+	assert.Equal(t, awsmodels.EksClusterSchema, string(resources[0].Type))
+}
+
+func TestEksClusterPollerError(t *testing.T) {
+	awstest.MockEksForSetup = awstest.BuildMockEksSvcAllError()
+
+	EksClientFunc = awstest.SetupMockEks
+
+	resources, err := PollEksClusters(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+}
@@ -0,0 +1,217 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/elasticache/elasticacheiface"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Set as variables to be overridden in testing
+var (
+	ElastiCacheClientFunc = setupElastiCacheClient
+)
+
+func setupElastiCacheClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return elasticache.New(sess, cfg)
+}
+
+func getElastiCacheClient(
+	pollerResourceInput *awsmodels.ResourcePollerInput, region string) (elasticacheiface.ElastiCacheAPI, error) {
+
+	client, err := getClient(pollerResourceInput, ElastiCacheClientFunc, "elasticache", region)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	return client.(elasticacheiface.ElastiCacheAPI), nil
+}
+
+// PollElastiCacheCluster polls a single ElastiCache Cluster resource
+func PollElastiCacheCluster(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getElastiCacheClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	// The resource portion of the ARN is of the form cluster:id
+	clusterID := strings.TrimPrefix(resourceARN.Resource, "cluster:")
+	clusters, err := describeCacheClusters(client, aws.String(clusterID))
+	if err != nil {
+		return nil, err
+	}
+	if len(clusters) == 0 {
+		zap.L().Warn("tried to scan non-existent resource",
+			zap.String("resource", resourceARN.String()),
+			zap.String("resourceType", awsmodels.ElastiCacheClusterSchema))
+		return nil, nil
+	}
+
+	snapshot := buildElastiCacheClusterSnapshot(client, clusters[0])
+	if snapshot == nil {
+		return nil, nil
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// describeCacheClusters returns the given cache cluster, or all cache clusters in the region if
+// no ID is given
+func describeCacheClusters(
+	elasticacheSvc elasticacheiface.ElastiCacheAPI, clusterID *string) (clusters []*elasticache.CacheCluster, err error) {
+
+	input := &elasticache.DescribeCacheClustersInput{
+		CacheClusterId:    clusterID,
+		ShowCacheNodeInfo: aws.Bool(true),
+	}
+	err = elasticacheSvc.DescribeCacheClustersPages(input,
+		func(page *elasticache.DescribeCacheClustersOutput, lastPage bool) bool {
+			clusters = append(clusters, page.CacheClusters...)
+			return true
+		})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == elasticache.ErrCodeCacheClusterNotFoundFault {
+			return nil, nil
+		}
+		utils.LogAWSError("ElastiCache.DescribeCacheClustersPages", err)
+		return nil, err
+	}
+	return clusters, nil
+}
+
+// listTagsElastiCache returns the tags for a given ElastiCache resource
+func listTagsElastiCache(elasticacheSvc elasticacheiface.ElastiCacheAPI, arn *string) (map[string]*string, error) {
+	out, err := elasticacheSvc.ListTagsForResource(&elasticache.ListTagsForResourceInput{ResourceName: arn})
+	if err != nil {
+		utils.LogAWSError("ElastiCache.ListTagsForResource", err)
+		return nil, err
+	}
+
+	return utils.ParseTagSlice(out.TagList), nil
+}
+
+// buildElastiCacheClusterSnapshot returns a complete snapshot of an ElastiCache cache cluster
+func buildElastiCacheClusterSnapshot(
+	elasticacheSvc elasticacheiface.ElastiCacheAPI,
+	cluster *elasticache.CacheCluster,
+) *awsmodels.ElastiCacheCluster {
+
+	if cluster == nil {
+		return nil
+	}
+
+	snapshot := &awsmodels.ElastiCacheCluster{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   cluster.ARN,
+			ResourceType: aws.String(awsmodels.ElastiCacheClusterSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  cluster.ARN,
+			ID:   cluster.CacheClusterId,
+			Name: cluster.CacheClusterId,
+		},
+		AtRestEncryptionEnabled:    cluster.AtRestEncryptionEnabled,
+		AuthTokenEnabled:           cluster.AuthTokenEnabled,
+		AuthTokenLastModifiedDate:  cluster.AuthTokenLastModifiedDate,
+		AutoMinorVersionUpgrade:    cluster.AutoMinorVersionUpgrade,
+		CacheClusterStatus:         cluster.CacheClusterStatus,
+		CacheNodeType:              cluster.CacheNodeType,
+		CacheNodes:                 cluster.CacheNodes,
+		CacheParameterGroup:        cluster.CacheParameterGroup,
+		CacheSecurityGroups:        cluster.CacheSecurityGroups,
+		CacheSubnetGroupName:       cluster.CacheSubnetGroupName,
+		ConfigurationEndpoint:      cluster.ConfigurationEndpoint,
+		Engine:                     cluster.Engine,
+		EngineVersion:              cluster.EngineVersion,
+		NotificationConfiguration:  cluster.NotificationConfiguration,
+		NumCacheNodes:              cluster.NumCacheNodes,
+		PreferredAvailabilityZone:  cluster.PreferredAvailabilityZone,
+		PreferredMaintenanceWindow: cluster.PreferredMaintenanceWindow,
+		ReplicationGroupId:         cluster.ReplicationGroupId,
+		SecurityGroups:             cluster.SecurityGroups,
+		SnapshotRetentionLimit:     cluster.SnapshotRetentionLimit,
+		SnapshotWindow:             cluster.SnapshotWindow,
+		TransitEncryptionEnabled:   cluster.TransitEncryptionEnabled,
+	}
+	if cluster.CacheClusterCreateTime != nil {
+		snapshot.TimeCreated = utils.DateTimeFormat(*cluster.CacheClusterCreateTime)
+	}
+
+	tags, err := listTagsElastiCache(elasticacheSvc, cluster.ARN)
+	if err == nil {
+		snapshot.Tags = tags
+	}
+
+	return snapshot
+}
+
+// PollElastiCacheClusters gathers information on each ElastiCache cache cluster for an AWS account.
+func PollElastiCacheClusters(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting ElastiCache Cluster resource poller")
+	var resources []*apimodels.AddResourceEntry
+
+	for _, regionID := range utils.GetServiceRegions(pollerInput.Regions, "elasticache") {
+		elasticacheSvc, err := getElastiCacheClient(pollerInput, *regionID)
+		if err != nil {
+			return nil, err // error is logged in getClient()
+		}
+
+		clusters, err := describeCacheClusters(elasticacheSvc, nil)
+		if err != nil {
+			continue
+		}
+
+		for _, cluster := range clusters {
+			clusterSnapshot := buildElastiCacheClusterSnapshot(elasticacheSvc, cluster)
+			if clusterSnapshot == nil || clusterSnapshot.ARN == nil {
+				continue
+			}
+			clusterSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+			clusterSnapshot.Region = regionID
+
+			resources = append(resources, &apimodels.AddResourceEntry{
+				Attributes:      clusterSnapshot,
+				ID:              apimodels.ResourceID(*clusterSnapshot.ARN),
+				IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
+				IntegrationType: apimodels.IntegrationTypeAws,
+				Type:            awsmodels.ElastiCacheClusterSchema,
+			})
+		}
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,117 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestElastiCacheClusterDescribe(t *testing.T) {
+	mockSvc := awstest.BuildMockElastiCacheSvc([]string{"DescribeCacheClustersPages"})
+
+	out, err := describeCacheClusters(mockSvc, nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestElastiCacheClusterDescribeError(t *testing.T) {
+	mockSvc := awstest.BuildMockElastiCacheSvcError([]string{"DescribeCacheClustersPages"})
+
+	out, err := describeCacheClusters(mockSvc, nil)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestElastiCacheClusterListTags(t *testing.T) {
+	mockSvc := awstest.BuildMockElastiCacheSvc([]string{"ListTagsForResource"})
+
+	out, err := listTagsElastiCache(mockSvc, awstest.ExampleDescribeCacheClusters.CacheClusters[0].ARN)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestElastiCacheClusterListTagsError(t *testing.T) {
+	mockSvc := awstest.BuildMockElastiCacheSvcError([]string{"ListTagsForResource"})
+
+	out, err := listTagsElastiCache(mockSvc, awstest.ExampleDescribeCacheClusters.CacheClusters[0].ARN)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestElastiCacheClusterBuildSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockElastiCacheSvcAll()
+
+	snapshot := buildElastiCacheClusterSnapshot(mockSvc, awstest.ExampleDescribeCacheClusters.CacheClusters[0])
+
+	assert.Equal(t, *awstest.ExampleCacheClusterID, *snapshot.ID)
+	assert.False(t, *snapshot.AtRestEncryptionEnabled)
+	assert.Equal(t, "redis", *snapshot.Engine)
+	assert.NotNil(t, snapshot.TimeCreated)
+	assert.Equal(t, "Panther", *snapshot.Tags["Application"])
+}
+
+func TestElastiCacheClusterBuildSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockElastiCacheSvcAllError()
+
+	snapshot := buildElastiCacheClusterSnapshot(mockSvc, awstest.ExampleDescribeCacheClusters.CacheClusters[0])
+
+	assert.NotNil(t, snapshot)
+	assert.Nil(t, snapshot.Tags)
+}
+
+func TestElastiCachePoller(t *testing.T) {
+	awstest.MockElastiCacheForSetup = awstest.BuildMockElastiCacheSvcAll()
+
+	ElastiCacheClientFunc = awstest.SetupMockElastiCache
+
+	resources, err := PollElastiCacheClusters(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, resources)
+	assert.Equal(t, awsmodels.ElastiCacheClusterSchema, string(resources[0].Type))
+}
+
+func TestElastiCachePollerError(t *testing.T) {
+	awstest.MockElastiCacheForSetup = awstest.BuildMockElastiCacheSvcAllError()
+
+	ElastiCacheClientFunc = awstest.SetupMockElastiCache
+
+	resources, err := PollElastiCacheClusters(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+}
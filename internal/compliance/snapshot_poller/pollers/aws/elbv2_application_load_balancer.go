@@ -39,7 +39,6 @@ import (
 // Set as variables to be overridden in testing
 var (
 	Elbv2ClientFunc = setupElbv2Client
-	sslPolicies     = map[string]*elbv2.SslPolicy{}
 )
 
 func setupElbv2Client(sess *session.Session, cfg *aws.Config) interface{} {
@@ -74,7 +73,7 @@ func PollELBV2LoadBalancer(
 
 	loadBalancer := getApplicationLoadBalancer(elbv2Client, scanRequest.ResourceID)
 
-	snapshot := buildElbv2ApplicationLoadBalancerSnapshot(elbv2Client, wafClient, loadBalancer)
+	snapshot := buildElbv2ApplicationLoadBalancerSnapshot(elbv2Client, wafClient, loadBalancer, nil)
 	if snapshot == nil {
 		return nil, nil
 	}
@@ -167,23 +166,28 @@ func getWebACLForResource(wafRegionalSvc wafregionaliface.WAFRegionalAPI, arn *s
 	return out.WebACLSummary.WebACLId, nil
 }
 
-// generateSSLPolices sets up the sslPolicies map for reference
-func generateSSLPolicies(svc elbv2iface.ELBV2API) {
+// generateSSLPolicies returns the SSL policies of a region by name, or nil if they could not be described.
+//
+// The map is built for each poll rather than shared, since regions are polled concurrently.
+func generateSSLPolicies(svc elbv2iface.ELBV2API) map[string]*elbv2.SslPolicy {
 	policies, err := describeSSLPolicies(svc)
-	if err == nil {
-		sslPolicies = make(map[string]*elbv2.SslPolicy, len(policies))
-		for _, policy := range policies {
-			sslPolicies[*policy.Name] = policy
-		}
+	if err != nil {
+		return nil
+	}
+	sslPolicies := make(map[string]*elbv2.SslPolicy, len(policies))
+	for _, policy := range policies {
+		sslPolicies[*policy.Name] = policy
 	}
+	return sslPolicies
 }
 
 // buildElbv2ApplicationLoadBalancerSnapshot makes all the calls to build up a snapshot of a given
-// application load balancer
+// application load balancer. The SSL policies of the region are described if sslPolicies is nil.
 func buildElbv2ApplicationLoadBalancerSnapshot(
 	elbv2Svc elbv2iface.ELBV2API,
 	wafRegionalSvc wafregionaliface.WAFRegionalAPI,
 	lb *elbv2.LoadBalancer,
+	sslPolicies map[string]*elbv2.SslPolicy,
 ) *awsmodels.Elbv2ApplicationLoadBalancer {
 
 	if lb == nil {
@@ -226,7 +230,7 @@ func buildElbv2ApplicationLoadBalancerSnapshot(
 				continue
 			}
 			if sslPolicies == nil {
-				generateSSLPolicies(elbv2Svc)
+				sslPolicies = generateSSLPolicies(elbv2Svc)
 			}
 			if policy, ok := sslPolicies[*listener.SslPolicy]; ok {
 				applicationLoadBalancer.SSLPolicies[*listener.SslPolicy] = policy
@@ -283,13 +287,14 @@ func PollElbv2ApplicationLoadBalancers(pollerInput *awsmodels.ResourcePollerInpu
 		}
 
 		// Next generate a list of SSL policies to be shared by the load balancer snapshots
-		generateSSLPolicies(elbv2Svc)
+		sslPolicies := generateSSLPolicies(elbv2Svc)
 
 		for _, loadBalancer := range loadBalancers {
 			elbv2LoadBalancer := buildElbv2ApplicationLoadBalancerSnapshot(
 				elbv2Svc,
 				wafRegionalSvc,
 				loadBalancer,
+				sslPolicies,
 			)
 			if elbv2LoadBalancer == nil {
 				continue
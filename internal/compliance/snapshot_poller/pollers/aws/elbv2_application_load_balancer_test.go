@@ -93,6 +93,13 @@ func TestElbv2DescribeSSLPoliciesError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, out)
 }
+func TestElbv2GenerateSSLPolicies(t *testing.T) {
+	policies := generateSSLPolicies(awstest.BuildMockElbv2Svc([]string{"DescribeSSLPolicies"}))
+	assert.Contains(t, policies, "ELBSecurityPolicy1")
+
+	assert.Nil(t, generateSSLPolicies(awstest.BuildMockElbv2SvcError([]string{"DescribeSSLPolicies"})))
+}
+
 func TestBuildElbv2ApplicationLoadBalancerSnapshot(t *testing.T) {
 	mockElbv2Svc := awstest.BuildMockElbv2SvcAll()
 	mockWafRegionalSvc := awstest.BuildMockWafRegionalSvcAll()
@@ -101,6 +108,7 @@ func TestBuildElbv2ApplicationLoadBalancerSnapshot(t *testing.T) {
 		mockElbv2Svc,
 		mockWafRegionalSvc,
 		awstest.ExampleDescribeLoadBalancersOutput.LoadBalancers[0],
+		nil,
 	)
 
 	assert.NotEmpty(t, elbv2Snapshot.SecurityGroups)
@@ -116,6 +124,7 @@ func TestBuildElbv2ApplicationLoadBalancerSnapshotError(t *testing.T) {
 		mockElbv2Svc,
 		mockWafRegionalSvc,
 		awstest.ExampleDescribeLoadBalancersOutput.LoadBalancers[0],
+		nil,
 	)
 
 	assert.Nil(t, elbv2Snapshot.WebAcl)
@@ -83,17 +83,19 @@ func getRole(svc iamiface.IAMAPI, roleName *string) *iam.Role {
 	return role.Role
 }
 
-// listUsers returns an array of all users in the account, excluding the root account.
-func listRoles(iamSvc iamiface.IAMAPI) (roles []*iam.Role) {
+// listRolesPage returns one page of IAM roles, and the marker of the next page
+func listRolesPage(iamSvc iamiface.IAMAPI, marker *string) (roles []*iam.Role, nextMarker *string) {
 	err := iamSvc.ListRolesPages(
-		&iam.ListRolesInput{},
+		&iam.ListRolesInput{Marker: marker},
 		func(page *iam.ListRolesOutput, lastPage bool) bool {
-			roles = append(roles, page.Roles...)
-			return true
+			roles = page.Roles
+			nextMarker = page.Marker
+			return false
 		},
 	)
 	if err != nil {
 		utils.LogAWSError("IAM.ListRolesPages", err)
+		return nil, nil
 	}
 	return
 }
@@ -205,16 +207,27 @@ func BuildIAMRoleSnapshot(iamSvc iamiface.IAMAPI, role *iam.Role) *awsmodels.IAM
 // PollIAMRoles generates a snapshot for each IAM Role.
 func PollIAMRoles(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
 	zap.L().Debug("starting IAM Role resource poller")
+	return pollAllPages(pollerInput, []*string{aws.String(awsmodels.GlobalRegion)}, PollIAMRolesPage)
+}
+
+// PollIAMRolesPage generates a snapshot for each IAM Role in one page of roles. IAM is a global
+// service, so the region is ignored.
+func PollIAMRolesPage(
+	pollerInput *awsmodels.ResourcePollerInput,
+	_ string,
+	pageToken *string,
+) ([]*apimodels.AddResourceEntry, *string, error) {
+
 	iamSvc, err := getIAMClient(pollerInput, defaultRegion)
 	if err != nil {
-		return nil, err // error is logged in getClient()
+		return nil, nil, err // error is logged in getClient()
 	}
 
-	// List all IAM Roles in the account
-	roles := listRoles(iamSvc)
+	// List a page of IAM Roles in the account
+	roles, nextPageToken := listRolesPage(iamSvc, pageToken)
 	if len(roles) == 0 {
 		zap.L().Debug("no IAM roles found")
-		return nil, nil
+		return nil, nextPageToken, nil
 	}
 
 	// Create IAM Role snapshots
@@ -241,5 +254,5 @@ func PollIAMRoles(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddR
 		})
 	}
 
-	return resources, nil
+	return resources, nextPageToken, nil
 }
@@ -32,14 +32,14 @@ import (
 func TestIAMRolesList(t *testing.T) {
 	mockSvc := awstest.BuildMockIAMSvc([]string{"ListRolesPages"})
 
-	out := listRoles(mockSvc)
+	out, _ := listRolesPage(mockSvc, nil)
 	assert.Equal(t, awstest.ExampleIAMRole, out[0])
 }
 
 func TestIAMRolesListError(t *testing.T) {
 	mockSvc := awstest.BuildMockIAMSvcError([]string{"ListRolesPages"})
 
-	out := listRoles(mockSvc)
+	out, _ := listRolesPage(mockSvc, nil)
 	assert.Nil(t, out)
 }
 
@@ -53,7 +53,7 @@ func TestIAMRolesGetPolicy(t *testing.T) {
 func TestIAMRolesGetPolicyError(t *testing.T) {
 	mockSvc := awstest.BuildMockIAMSvcError([]string{"ListRolesPages"})
 
-	out := listRoles(mockSvc)
+	out, _ := listRolesPage(mockSvc, nil)
 	assert.Nil(t, out)
 }
 
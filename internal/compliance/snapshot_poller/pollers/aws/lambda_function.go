@@ -105,6 +105,24 @@ func listFunctions(lambdaSvc lambdaiface.LambdaAPI) (functions []*lambda.Functio
 	return
 }
 
+// listFunctionsPage returns one page of lambda functions in the current region, and the marker of
+// the next page
+func listFunctionsPage(lambdaSvc lambdaiface.LambdaAPI, marker *string) (
+	functions []*lambda.FunctionConfiguration, nextMarker *string) {
+
+	err := lambdaSvc.ListFunctionsPages(&lambda.ListFunctionsInput{Marker: marker},
+		func(page *lambda.ListFunctionsOutput, lastPage bool) bool {
+			functions = page.Functions
+			nextMarker = page.NextMarker
+			return false
+		})
+	if err != nil {
+		utils.LogAWSError("Lambda.ListFunctionsPages", err)
+		return nil, nil
+	}
+	return
+}
+
 // listTags returns the tags for a given lambda function
 func listTagsLambda(lambdaSvc lambdaiface.LambdaAPI, arn *string) (map[string]*string, error) {
 	out, err := lambdaSvc.ListTags(&lambda.ListTagsInput{Resource: arn})
@@ -187,51 +205,45 @@ func buildLambdaFunctionSnapshot(
 // PollLambdaFunctions gathers information on each Lambda Function for an AWS account.
 func PollLambdaFunctions(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
 	zap.L().Debug("starting Lambda Function resource poller")
-	lambdaFunctionSnapshots := make(map[string]*awsmodels.LambdaFunction)
+	return pollAllPages(pollerInput, utils.GetServiceRegions(pollerInput.Regions, "lambda"), PollLambdaFunctionsPage)
+}
 
-	for _, regionID := range utils.GetServiceRegions(pollerInput.Regions, "lambda") {
-		lambdaSvc, err := getLambdaClient(pollerInput, *regionID)
-		if err != nil {
-			return nil, err // error is logged in getClient()
-		}
+// PollLambdaFunctionsPage gathers information on one page of Lambda Functions in a region.
+func PollLambdaFunctionsPage(
+	pollerInput *awsmodels.ResourcePollerInput,
+	region string,
+	pageToken *string,
+) ([]*apimodels.AddResourceEntry, *string, error) {
 
-		// Start with generating a list of all functions
-		functions := listFunctions(lambdaSvc)
-		if len(functions) == 0 {
-			zap.L().Debug("no Lambda functions found", zap.String("region", *regionID))
-			continue
-		}
+	lambdaSvc, err := getLambdaClient(pollerInput, region)
+	if err != nil {
+		return nil, nil, err // error is logged in getClient()
+	}
 
-		for _, functionConfiguration := range functions {
-			lambdaFunctionSnapshot := buildLambdaFunctionSnapshot(lambdaSvc, functionConfiguration)
-			if lambdaFunctionSnapshot == nil {
-				continue
-			}
-			lambdaFunctionSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
-			lambdaFunctionSnapshot.Region = regionID
-
-			if _, ok := lambdaFunctionSnapshots[*lambdaFunctionSnapshot.ARN]; !ok {
-				lambdaFunctionSnapshots[*lambdaFunctionSnapshot.ARN] = lambdaFunctionSnapshot
-			} else {
-				zap.L().Info(
-					"overwriting existing Lambda Function snapshot",
-					zap.String("resourceId", *lambdaFunctionSnapshot.ARN),
-				)
-				lambdaFunctionSnapshots[*lambdaFunctionSnapshot.ARN] = lambdaFunctionSnapshot
-			}
-		}
+	// Start with generating a list of functions
+	functions, nextPageToken := listFunctionsPage(lambdaSvc, pageToken)
+	if len(functions) == 0 {
+		zap.L().Debug("no Lambda functions found", zap.String("region", region))
+		return nil, nextPageToken, nil
 	}
 
-	resources := make([]*apimodels.AddResourceEntry, 0, len(lambdaFunctionSnapshots))
-	for resourceID, lambdaSnapshot := range lambdaFunctionSnapshots {
+	resources := make([]*apimodels.AddResourceEntry, 0, len(functions))
+	for _, functionConfiguration := range functions {
+		lambdaFunctionSnapshot := buildLambdaFunctionSnapshot(lambdaSvc, functionConfiguration)
+		if lambdaFunctionSnapshot == nil {
+			continue
+		}
+		lambdaFunctionSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		lambdaFunctionSnapshot.Region = aws.String(region)
+
 		resources = append(resources, &apimodels.AddResourceEntry{
-			Attributes:      lambdaSnapshot,
-			ID:              apimodels.ResourceID(resourceID),
+			Attributes:      lambdaFunctionSnapshot,
+			ID:              apimodels.ResourceID(*lambdaFunctionSnapshot.ARN),
 			IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
 			IntegrationType: apimodels.IntegrationTypeAws,
 			Type:            awsmodels.LambdaFunctionSchema,
 		})
 	}
 
-	return resources, nil
+	return resources, nextPageToken, nil
 }
@@ -0,0 +1,249 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Set as variables to be overridden in testing
+var (
+	OrganizationsClientFunc = setupOrganizationsClient
+)
+
+func setupOrganizationsClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return organizations.New(sess, cfg)
+}
+
+func getOrganizationsClient(
+	pollerResourceInput *awsmodels.ResourcePollerInput, region string) (organizationsiface.OrganizationsAPI, error) {
+
+	client, err := getClient(pollerResourceInput, OrganizationsClientFunc, "organizations", region)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	return client.(organizationsiface.OrganizationsAPI), nil
+}
+
+// isOrganizationsUnavailable reports whether an error means that SCPs simply cannot be read from
+// this account, which is the normal case for member accounts and accounts outside an organization
+func isOrganizationsUnavailable(err error) bool {
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch awsErr.Code() {
+		case organizations.ErrCodeAccessDeniedException, organizations.ErrCodeAWSOrganizationsNotInUseException:
+			return true
+		}
+	}
+	return false
+}
+
+// PollOrganizationsSCP polls a single AWS Organizations Service Control Policy
+func PollOrganizationsSCP(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getOrganizationsClient(pollerResourceInput, defaultRegion)
+	if err != nil {
+		return nil, err
+	}
+
+	// The resource portion of the ARN is of the form policy/o-exampleorgid/service_control_policy/p-examplepolicyid
+	resourceParts := strings.Split(resourceARN.Resource, "/")
+	policy, err := describeOrganizationsPolicy(client, aws.String(resourceParts[len(resourceParts)-1]))
+	if err != nil || policy == nil {
+		return nil, err
+	}
+
+	snapshot := buildOrganizationsSCPSnapshot(client, policy)
+	if snapshot == nil {
+		return nil, nil
+	}
+	snapshot.AccountID = aws.String(pollerResourceInput.AuthSourceParsedARN.AccountID)
+	snapshot.Region = aws.String(awsmodels.GlobalRegion)
+	return snapshot, nil
+}
+
+// listOrganizationsSCPs returns the IDs of all service control policies in the organization
+func listOrganizationsSCPs(organizationsSvc organizationsiface.OrganizationsAPI) (policyIDs []*string) {
+	err := organizationsSvc.ListPoliciesPages(
+		&organizations.ListPoliciesInput{Filter: aws.String(organizations.PolicyTypeServiceControlPolicy)},
+		func(page *organizations.ListPoliciesOutput, lastPage bool) bool {
+			for _, policy := range page.Policies {
+				policyIDs = append(policyIDs, policy.Id)
+			}
+			return true
+		})
+	if err != nil {
+		if isOrganizationsUnavailable(err) {
+			zap.L().Debug("service control policies are not readable from this account", zap.Error(err))
+			return nil
+		}
+		utils.LogAWSError("Organizations.ListPoliciesPages", err)
+	}
+	return
+}
+
+// describeOrganizationsPolicy returns the content and summary of a single policy
+func describeOrganizationsPolicy(
+	organizationsSvc organizationsiface.OrganizationsAPI, id *string) (*organizations.Policy, error) {
+
+	out, err := organizationsSvc.DescribePolicy(&organizations.DescribePolicyInput{PolicyId: id})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == organizations.ErrCodePolicyNotFoundException {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *id),
+				zap.String("resourceType", awsmodels.OrganizationsSCPSchema))
+			return nil, nil
+		}
+		if isOrganizationsUnavailable(err) {
+			zap.L().Debug("service control policies are not readable from this account", zap.Error(err))
+			return nil, nil
+		}
+		utils.LogAWSError("Organizations.DescribePolicy", err)
+		return nil, err
+	}
+
+	return out.Policy, nil
+}
+
+// listOrganizationsPolicyTargets returns the roots, OUs and accounts a policy is attached to
+func listOrganizationsPolicyTargets(
+	organizationsSvc organizationsiface.OrganizationsAPI, id *string) (targets []*organizations.PolicyTargetSummary, err error) {
+
+	err = organizationsSvc.ListTargetsForPolicyPages(&organizations.ListTargetsForPolicyInput{PolicyId: id},
+		func(page *organizations.ListTargetsForPolicyOutput, lastPage bool) bool {
+			targets = append(targets, page.Targets...)
+			return true
+		})
+	if err != nil {
+		utils.LogAWSError("Organizations.ListTargetsForPolicyPages", err)
+	}
+	return
+}
+
+// listTagsOrganizations returns the tags for a given policy
+func listTagsOrganizations(organizationsSvc organizationsiface.OrganizationsAPI, id *string) (map[string]*string, error) {
+	var tags []*organizations.Tag
+	err := organizationsSvc.ListTagsForResourcePages(&organizations.ListTagsForResourceInput{ResourceId: id},
+		func(page *organizations.ListTagsForResourceOutput, lastPage bool) bool {
+			tags = append(tags, page.Tags...)
+			return true
+		})
+	if err != nil {
+		utils.LogAWSError("Organizations.ListTagsForResourcePages", err)
+		return nil, err
+	}
+
+	return utils.ParseTagSlice(tags), nil
+}
+
+// buildOrganizationsSCPSnapshot returns a complete snapshot of a service control policy
+func buildOrganizationsSCPSnapshot(
+	organizationsSvc organizationsiface.OrganizationsAPI,
+	policy *organizations.Policy,
+) *awsmodels.OrganizationsSCP {
+
+	if policy == nil || policy.PolicySummary == nil {
+		return nil
+	}
+
+	summary := policy.PolicySummary
+	snapshot := &awsmodels.OrganizationsSCP{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   summary.Arn,
+			ResourceType: aws.String(awsmodels.OrganizationsSCPSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  summary.Arn,
+			ID:   summary.Id,
+			Name: summary.Name,
+		},
+		AwsManaged:  summary.AwsManaged,
+		Content:     policy.Content,
+		Description: summary.Description,
+	}
+
+	targets, err := listOrganizationsPolicyTargets(organizationsSvc, summary.Id)
+	if err == nil {
+		snapshot.Targets = targets
+	}
+
+	// AWS managed policies cannot be tagged
+	if !aws.BoolValue(summary.AwsManaged) {
+		tags, err := listTagsOrganizations(organizationsSvc, summary.Id)
+		if err == nil {
+			snapshot.Tags = tags
+		}
+	}
+
+	return snapshot
+}
+
+// PollOrganizationsSCPs gathers information on each service control policy visible to an AWS account.
+//
+// Only the organization's management account (and delegated administrators) may read SCPs, so
+// every other account quietly returns no resources.
+func PollOrganizationsSCPs(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting Organizations Service Control Policy resource poller")
+	organizationsSvc, err := getOrganizationsClient(pollerInput, defaultRegion)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	var resources []*apimodels.AddResourceEntry
+	for _, policyID := range listOrganizationsSCPs(organizationsSvc) {
+		policy, err := describeOrganizationsPolicy(organizationsSvc, policyID)
+		if err != nil || policy == nil {
+			continue
+		}
+
+		policySnapshot := buildOrganizationsSCPSnapshot(organizationsSvc, policy)
+		if policySnapshot == nil {
+			continue
+		}
+		policySnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		policySnapshot.Region = aws.String(awsmodels.GlobalRegion)
+
+		resources = append(resources, &apimodels.AddResourceEntry{
+			Attributes:      policySnapshot,
+			ID:              apimodels.ResourceID(*policySnapshot.ARN),
+			IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
+			IntegrationType: apimodels.IntegrationTypeAws,
+			Type:            awsmodels.OrganizationsSCPSchema,
+		})
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,88 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestOrganizationsPoller(t *testing.T) {
+	awstest.MockOrganizationsForSetup = awstest.BuildMockOrganizationsSvcAll()
+
+	OrganizationsClientFunc = awstest.SetupMockOrganizations
+
+	resources, err := PollOrganizationsSCPs(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, resources)
+	assert.Equal(t, awsmodels.OrganizationsSCPSchema, string(resources[0].Type))
+}
+
+func TestOrganizationsPollerError(t *testing.T) {
+	awstest.MockOrganizationsForSetup = awstest.BuildMockOrganizationsSvcAllError()
+
+	OrganizationsClientFunc = awstest.SetupMockOrganizations
+
+	resources, err := PollOrganizationsSCPs(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+}
+
+func TestOrganizationsPollerNotInUse(t *testing.T) {
+	mockSvc := &awstest.MockOrganizations{}
+	mockSvc.On("ListPoliciesPages", mock.Anything).
+		Return(awserr.New(organizations.ErrCodeAWSOrganizationsNotInUseException, "not in an organization", nil))
+	awstest.MockOrganizationsForSetup = mockSvc
+
+	OrganizationsClientFunc = awstest.SetupMockOrganizations
+
+	resources, err := PollOrganizationsSCPs(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+	mockSvc.AssertNotCalled(t, "DescribePolicy", mock.Anything)
+}
@@ -19,11 +19,14 @@ package aws
  */
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
@@ -45,35 +48,53 @@ var (
 
 	auditRoleName = os.Getenv("AUDIT_ROLE_NAME")
 
+	// How long work units are leased for when Scan is called without a deadline
+	maxScanDuration = 15 * time.Minute
+
+	// requeueFunc sends unfinished scans back to the poller queue, and is overridden in tests
+	requeueFunc = utils.Requeue
+
 	// IndividualARNResourcePollers maps resource types to their corresponding individual polling
 	// functions for resources whose ID is their ARN.
 	IndividualARNResourcePollers = map[string]func(
 		input *awsmodels.ResourcePollerInput, arn arn.ARN, entry *pollermodels.ScanEntry) (interface{}, error){
-		awsmodels.AcmCertificateSchema:      PollACMCertificate,
-		awsmodels.CloudFormationStackSchema: PollCloudFormationStack,
-		awsmodels.CloudTrailSchema:          PollCloudTrailTrail,
-		awsmodels.CloudWatchLogGroupSchema:  PollCloudWatchLogsLogGroup,
-		awsmodels.DynamoDBTableSchema:       PollDynamoDBTable,
-		awsmodels.Ec2AmiSchema:              PollEC2Image,
-		awsmodels.Ec2InstanceSchema:         PollEC2Instance,
-		awsmodels.Ec2NetworkAclSchema:       PollEC2NetworkACL,
-		awsmodels.Ec2SecurityGroupSchema:    PollEC2SecurityGroup,
-		awsmodels.Ec2VolumeSchema:           PollEC2Volume,
-		awsmodels.Ec2VpcSchema:              PollEC2VPC,
-		awsmodels.EcsClusterSchema:          PollECSCluster,
-		awsmodels.Elbv2LoadBalancerSchema:   PollELBV2LoadBalancer,
-		awsmodels.IAMGroupSchema:            PollIAMGroup,
-		awsmodels.IAMPolicySchema:           PollIAMPolicy,
-		awsmodels.IAMRoleSchema:             PollIAMRole,
-		awsmodels.IAMUserSchema:             PollIAMUser,
-		awsmodels.IAMRootUserSchema:         PollIAMRootUser,
-		awsmodels.KmsKeySchema:              PollKMSKey,
-		awsmodels.LambdaFunctionSchema:      PollLambdaFunction,
-		awsmodels.RDSInstanceSchema:         PollRDSInstance,
-		awsmodels.RedshiftClusterSchema:     PollRedshiftCluster,
-		awsmodels.S3BucketSchema:            PollS3Bucket,
-		awsmodels.WafWebAclSchema:           PollWAFWebACL,
-		awsmodels.WafRegionalWebAclSchema:   PollWAFRegionalWebACL,
+		awsmodels.AcmCertificateSchema:         PollACMCertificate,
+		awsmodels.APIGatewayStageSchema:        PollAPIGatewayStage,
+		awsmodels.CloudFrontDistributionSchema: PollCloudFrontDistribution,
+		awsmodels.CloudFormationStackSchema:    PollCloudFormationStack,
+		awsmodels.CloudTrailSchema:             PollCloudTrailTrail,
+		awsmodels.CloudWatchLogGroupSchema:     PollCloudWatchLogsLogGroup,
+		awsmodels.DynamoDBTableSchema:          PollDynamoDBTable,
+		awsmodels.Ec2AmiSchema:                 PollEC2Image,
+		awsmodels.Ec2InstanceSchema:            PollEC2Instance,
+		awsmodels.Ec2NetworkAclSchema:          PollEC2NetworkACL,
+		awsmodels.Ec2SecurityGroupSchema:       PollEC2SecurityGroup,
+		awsmodels.Ec2VolumeSchema:              PollEC2Volume,
+		awsmodels.Ec2VpcSchema:                 PollEC2VPC,
+		awsmodels.EcrRepositorySchema:          PollECRRepository,
+		awsmodels.EcsClusterSchema:             PollECSCluster,
+		awsmodels.EfsFileSystemSchema:          PollEFSFileSystem,
+		awsmodels.EksClusterSchema:             PollEKSCluster,
+		awsmodels.ElastiCacheClusterSchema:     PollElastiCacheCluster,
+		awsmodels.Elbv2LoadBalancerSchema:      PollELBV2LoadBalancer,
+		awsmodels.IAMGroupSchema:               PollIAMGroup,
+		awsmodels.IAMPolicySchema:              PollIAMPolicy,
+		awsmodels.IAMRoleSchema:                PollIAMRole,
+		awsmodels.IAMUserSchema:                PollIAMUser,
+		awsmodels.IAMRootUserSchema:            PollIAMRootUser,
+		awsmodels.KmsKeySchema:                 PollKMSKey,
+		awsmodels.LambdaFunctionSchema:         PollLambdaFunction,
+		awsmodels.OrganizationsSCPSchema:       PollOrganizationsSCP,
+		awsmodels.RDSInstanceSchema:            PollRDSInstance,
+		awsmodels.RedshiftClusterSchema:        PollRedshiftCluster,
+		awsmodels.Route53HostedZoneSchema:      PollRoute53HostedZone,
+		awsmodels.S3BucketSchema:               PollS3Bucket,
+		awsmodels.SecretsManagerSecretSchema:   PollSecretsManagerSecret,
+		awsmodels.SnsTopicSchema:               PollSNSTopic,
+		awsmodels.SqsQueueSchema:               PollSQSQueue,
+		awsmodels.SSMParameterSchema:           PollSSMParameter,
+		awsmodels.WafWebAclSchema:              PollWAFWebACL,
+		awsmodels.WafRegionalWebAclSchema:      PollWAFRegionalWebACL,
 	}
 
 	// IndividualResourcePollers maps resource types to their corresponding individual polling
@@ -108,20 +129,153 @@ var (
 		awsmodels.GuardDutySchema:           {"GuardDutyDetector", PollGuardDutyDetectors},
 		awsmodels.IAMUserSchema:             {"IAMUser", PollIAMUsers},
 		// Service scan for the resource type IAMRootUserSchema is not defined! Do not do it!
-		awsmodels.IAMRoleSchema:         {"IAMRoles", PollIAMRoles},
-		awsmodels.IAMGroupSchema:        {"IAMGroups", PollIamGroups},
-		awsmodels.IAMPolicySchema:       {"IAMPolicies", PollIamPolicies},
-		awsmodels.LambdaFunctionSchema:  {"LambdaFunctions", PollLambdaFunctions},
-		awsmodels.PasswordPolicySchema:  {"PasswordPolicy", PollPasswordPolicy},
-		awsmodels.RDSInstanceSchema:     {"RDSInstance", PollRDSInstances},
-		awsmodels.RedshiftClusterSchema: {"RedshiftCluster", PollRedshiftClusters},
+		awsmodels.IAMRoleSchema:                {"IAMRoles", PollIAMRoles},
+		awsmodels.IAMGroupSchema:               {"IAMGroups", PollIamGroups},
+		awsmodels.IAMPolicySchema:              {"IAMPolicies", PollIamPolicies},
+		awsmodels.LambdaFunctionSchema:         {"LambdaFunctions", PollLambdaFunctions},
+		awsmodels.PasswordPolicySchema:         {"PasswordPolicy", PollPasswordPolicy},
+		awsmodels.RDSInstanceSchema:            {"RDSInstance", PollRDSInstances},
+		awsmodels.RedshiftClusterSchema:        {"RedshiftCluster", PollRedshiftClusters},
+		awsmodels.APIGatewayStageSchema:        {"APIGatewayStage", PollAPIGatewayStages},
+		awsmodels.CloudFrontDistributionSchema: {"CloudFrontDistribution", PollCloudFrontDistributions},
+		awsmodels.EcrRepositorySchema:          {"ECRRepository", PollEcrRepositories},
+		awsmodels.EfsFileSystemSchema:          {"EFSFileSystem", PollEfsFileSystems},
+		awsmodels.EksClusterSchema:             {"EKSCluster", PollEksClusters},
+		awsmodels.ElastiCacheClusterSchema:     {"ElastiCacheCluster", PollElastiCacheClusters},
+		awsmodels.OrganizationsSCPSchema:       {"OrganizationsSCP", PollOrganizationsSCPs},
+		awsmodels.Route53HostedZoneSchema:      {"Route53HostedZone", PollRoute53HostedZones},
+		awsmodels.SecretsManagerSecretSchema:   {"SecretsManagerSecret", PollSecretsManagerSecrets},
+		awsmodels.SnsTopicSchema:               {"SNSTopic", PollSnsTopics},
+		awsmodels.SqsQueueSchema:               {"SQSQueue", PollSqsQueues},
+		awsmodels.SSMParameterSchema:           {"SSMParameter", PollSSMParameters},
 	}
 )
 
 // Poll coordinates AWS generatedEvents gathering across all relevant resources for compliance monitoring.
+//
+// Scans which are not of a single resource are split into work units and polled in parallel, but
+// within this invocation only. Account-wide scans which may not fit in one invocation use Scan.
 func Poll(scanRequest *pollermodels.ScanEntry) (
 	generatedEvents []*resourcesapimodels.AddResourceEntry, err error) {
 
+	pollerResourceInput, err := newPollerInput(scanRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	// If this is an individual resource scan or the region is provided,
+	// we don't need to lookup the active regions.
+	//
+	// Individual resource scan
+	if scanRequest.ResourceID != nil {
+		zap.L().Info("processing single resource scan")
+		return singleResourceScan(scanRequest, pollerResourceInput)
+
+		// Single region service scan
+	} else if scanRequest.Region != nil && scanRequest.ResourceType != nil {
+		zap.L().Info("processing single region service scan")
+		if _, ok := ServicePollers[*scanRequest.ResourceType]; !ok {
+			return nil, errors.Errorf("invalid single region resource type '%s' scan requested", *scanRequest.ResourceType)
+		}
+		return pollWorkUnits(pollerResourceInput, planWorkUnits([]string{*scanRequest.ResourceType}, pollerResourceInput.Regions))
+	}
+
+	resourceTypes, err := scanResourceTypes(scanRequest)
+	if err != nil || resourceTypes == nil {
+		return nil, err
+	}
+
+	regions, err := activeRegions(pollerResourceInput)
+	if err != nil || regions == nil {
+		return nil, err
+	}
+	pollerResourceInput.Regions = regions
+
+	return pollWorkUnits(pollerResourceInput, planWorkUnits(resourceTypes, regions))
+}
+
+// Scan polls an account-wide scan as resumable work units, one per resource type and region.
+//
+// Work units are spread across workers and checkpointed in DynamoDB (after every page, for paged
+// resource types) under the scan ID of the request. The resources of each unit are sent to the
+// sink as soon as they are polled, and every unit which finishes is passed to the reconciler. When
+// the invocation deadline approaches, the scan is requeued with the same scan ID and the next
+// invocation continues with the units which are not done yet.
+func Scan(ctx context.Context, scanRequest *pollermodels.ScanEntry, sink ResourceSink, reconcile UnitReconciler) error {
+	if scanRequest.ScanID == nil {
+		return errors.New("no scan ID provided")
+	}
+	scanID := *scanRequest.ScanID
+
+	pollerResourceInput, err := newPollerInput(scanRequest)
+	if err != nil {
+		return err
+	}
+
+	resourceTypes, err := scanResourceTypes(scanRequest)
+	if err != nil || resourceTypes == nil {
+		return err
+	}
+
+	regions, err := activeRegions(pollerResourceInput)
+	if err != nil || regions == nil {
+		return err
+	}
+	pollerResourceInput.Regions = regions
+
+	checkpoints, err := loadCheckpoints(scanID)
+	if err != nil {
+		return err
+	}
+
+	var units []*workUnit
+	if len(checkpoints) == 0 {
+		units = planWorkUnits(resourceTypes, regions)
+		if err = createCheckpoints(scanID, units); err != nil {
+			return err
+		}
+	} else {
+		// Resume a scan started by an earlier invocation
+		for _, item := range checkpoints {
+			if item.Status == checkpointPending || item.Status == checkpointRunning {
+				units = append(units, &workUnit{ResourceType: item.ResourceType, Region: item.Region})
+			}
+		}
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(maxScanDuration)
+	}
+	runner := &unitRunner{
+		pollerInput: pollerResourceInput,
+		sink:        sink,
+		reconcile:   reconcile,
+		scanID:      scanID,
+		owner:       uuid.New().String(),
+		deadline:    deadline,
+	}
+	result := runner.run(units)
+	zap.L().Info("scan invocation finished",
+		zap.String("scanId", scanID),
+		zap.Int("numUnits", len(units)),
+		zap.Int("done", result.done),
+		zap.Int("failed", result.failed),
+		zap.Int("remaining", result.remaining),
+		zap.Int("claimedElsewhere", result.claimed),
+	)
+
+	if result.remaining > 0 {
+		requeueFunc(pollermodels.ScanMsg{Entries: []*pollermodels.ScanEntry{scanRequest}}, 0)
+	}
+	if result.failed > 0 {
+		return errors.Errorf("%d work units of scan %s failed", result.failed, scanID)
+	}
+	return nil
+}
+
+// newPollerInput builds the input shared by all pollers of a scan.
+func newPollerInput(scanRequest *pollermodels.ScanEntry) (*awsmodels.ResourcePollerInput, error) {
 	if scanRequest.AWSAccountID == nil {
 		return nil, errors.New("no valid AWS AccountID provided")
 	}
@@ -142,7 +296,7 @@ func Poll(scanRequest *pollermodels.ScanEntry) (
 		return nil, err
 	}
 
-	pollerResourceInput := &awsmodels.ResourcePollerInput{
+	return &awsmodels.ResourcePollerInput{
 		AuthSource:          &auditRoleARN,
 		AuthSourceParsedARN: roleArn,
 		IntegrationID:       scanRequest.IntegrationID,
@@ -150,93 +304,64 @@ func Poll(scanRequest *pollermodels.ScanEntry) (
 		Regions: []*string{scanRequest.Region},
 		// Note: The resources-api expects a strfmt.DateTime formatted string.
 		Timestamp: utils.DateTimeFormat(utils.TimeNowFunc()),
-	}
-
-	// If this is an individual resource scan or the region is provided,
-	// we don't need to lookup the active regions.
-	//
-	// Individual resource scan
-	if scanRequest.ResourceID != nil {
-		zap.L().Info("processing single resource scan")
-		return singleResourceScan(scanRequest, pollerResourceInput)
-
-		// Single region service scan
-	} else if scanRequest.Region != nil && scanRequest.ResourceType != nil {
-		zap.L().Info("processing single region service scan")
-		if poller, ok := ServicePollers[*scanRequest.ResourceType]; ok {
-			return serviceScan(
-				[]resourcePoller{poller},
-				pollerResourceInput,
-			)
-		} else {
-			return nil, errors.Errorf("invalid single region resource type '%s' scan requested", *scanRequest.ResourceType)
-		}
-	}
-
-	ec2Client, err := getEC2Client(pollerResourceInput, defaultRegion)
-	if err != nil {
-		return nil, err // getClient() logs error
-	}
-
-	regions := utils.GetRegions(ec2Client)
-	if regions == nil {
-		zap.L().Info("no valid regions to scan")
-		return nil, nil
-	}
-	pollerResourceInput.Regions = regions
+	}, nil
+}
 
+// scanResourceTypes returns the resource types polled by an account-wide scan.
+func scanResourceTypes(scanRequest *pollermodels.ScanEntry) ([]string, error) {
 	// Full account scan
 	if scanRequest.ScanAllResources != nil && *scanRequest.ScanAllResources {
-		zap.L().Warn("DEPRECATED: processing full account scan, this operation should not occur during normal operations." +
-			"Either input was malformed or someone has manually initiated this scan.")
-		allPollers := make([]resourcePoller, 0, len(ServicePollers))
-		for _, poller := range ServicePollers {
-			allPollers = append(allPollers, poller)
+		zap.L().Info("processing full account scan")
+		resourceTypes := make([]string, 0, len(ServicePollers))
+		for resourceType := range ServicePollers {
+			resourceTypes = append(resourceTypes, resourceType)
 		}
-		return serviceScan(allPollers, pollerResourceInput)
+		return resourceTypes, nil
 
 		// Account wide resource type scan
 	} else if scanRequest.ResourceType != nil {
 		zap.L().Info("processing full account resource type scan")
-		if poller, ok := ServicePollers[*scanRequest.ResourceType]; ok {
-			return serviceScan(
-				[]resourcePoller{poller},
-				pollerResourceInput,
-			)
-		} else {
-			return nil, errors.Errorf("invalid single region resource type '%s' scan requested", *scanRequest.ResourceType)
+		if _, ok := ServicePollers[*scanRequest.ResourceType]; !ok {
+			return nil, errors.Errorf("invalid resource type '%s' scan requested", *scanRequest.ResourceType)
 		}
+		return []string{*scanRequest.ResourceType}, nil
 	}
 
 	zap.L().Error("Invalid scan request input")
 	return nil, nil
 }
 
-func serviceScan(
-	pollers []resourcePoller,
+// activeRegions returns the regions enabled in the scanned account.
+func activeRegions(pollerInput *awsmodels.ResourcePollerInput) ([]*string, error) {
+	ec2Client, err := getEC2Client(pollerInput, defaultRegion)
+	if err != nil {
+		return nil, err // getClient() logs error
+	}
+
+	regions := utils.GetRegions(ec2Client)
+	if regions == nil {
+		zap.L().Info("no valid regions to scan")
+	}
+	return regions, nil
+}
+
+// pollWorkUnits polls the work units within this invocation and returns all their resources.
+func pollWorkUnits(
 	pollerInput *awsmodels.ResourcePollerInput,
+	units []*workUnit,
 ) (generatedEvents []*resourcesapimodels.AddResourceEntry, err error) {
 
-	var generatedResources []*resourcesapimodels.AddResourceEntry
-	for _, resourcePoller := range pollers {
-		generatedResources, err = resourcePoller.resourcePoller(pollerInput)
-		if err != nil {
-			zap.L().Error(
-				"an error occurred while polling",
-				zap.String("resourcePoller", resourcePoller.description),
-				zap.String("errorMessage", err.Error()),
-			)
-			return
-		} else if generatedResources != nil {
-			zap.L().Info(
-				"resources generated",
-				zap.Int("numResources", len(generatedResources)),
-				zap.String("resourcePoller", resourcePoller.description),
-			)
-			generatedEvents = append(generatedEvents, generatedResources...)
-		}
+	runner := &unitRunner{
+		pollerInput: pollerInput,
+		sink: func(resources []*resourcesapimodels.AddResourceEntry) error {
+			generatedEvents = append(generatedEvents, resources...)
+			return nil
+		},
+	}
+	if result := runner.run(units); result.failed > 0 {
+		return generatedEvents, errors.Errorf("%d of %d work units failed", result.failed, len(units))
 	}
-	return
+	return generatedEvents, nil
 }
 
 func singleResourceScan(
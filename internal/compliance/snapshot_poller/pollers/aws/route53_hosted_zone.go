@@ -0,0 +1,224 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+const route53HostedZonePrefix = "/hostedzone/"
+
+// Set as variables to be overridden in testing
+var (
+	Route53ClientFunc = setupRoute53Client
+)
+
+func setupRoute53Client(sess *session.Session, cfg *aws.Config) interface{} {
+	return route53.New(sess, cfg)
+}
+
+func getRoute53Client(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (route53iface.Route53API, error) {
+	client, err := getClient(pollerResourceInput, Route53ClientFunc, "route53", region)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	return client.(route53iface.Route53API), nil
+}
+
+// PollRoute53HostedZone polls a single Route 53 Hosted Zone resource
+func PollRoute53HostedZone(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getRoute53Client(pollerResourceInput, defaultRegion)
+	if err != nil {
+		return nil, err
+	}
+
+	// The resource portion of the ARN is of the form hostedzone/id
+	hostedZone, err := getRoute53HostedZone(client, aws.String(strings.TrimPrefix(resourceARN.Resource, "hostedzone/")))
+	if err != nil || hostedZone == nil {
+		return nil, err
+	}
+
+	snapshot := buildRoute53HostedZoneSnapshot(client, hostedZone)
+	if snapshot == nil {
+		return nil, nil
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(awsmodels.GlobalRegion)
+	return snapshot, nil
+}
+
+// listRoute53HostedZones returns the IDs of all hosted zones in the account
+func listRoute53HostedZones(route53Svc route53iface.Route53API) (hostedZoneIDs []*string) {
+	err := route53Svc.ListHostedZonesPages(&route53.ListHostedZonesInput{},
+		func(page *route53.ListHostedZonesOutput, lastPage bool) bool {
+			for _, hostedZone := range page.HostedZones {
+				hostedZoneIDs = append(hostedZoneIDs, aws.String(strings.TrimPrefix(*hostedZone.Id, route53HostedZonePrefix)))
+			}
+			return true
+		})
+	if err != nil {
+		utils.LogAWSError("Route53.ListHostedZonesPages", err)
+	}
+	return
+}
+
+// getRoute53HostedZone returns a hosted zone along with its delegation set and associated VPCs
+func getRoute53HostedZone(route53Svc route53iface.Route53API, id *string) (*route53.GetHostedZoneOutput, error) {
+	out, err := route53Svc.GetHostedZone(&route53.GetHostedZoneInput{Id: id})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == route53.ErrCodeNoSuchHostedZone {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *id),
+				zap.String("resourceType", awsmodels.Route53HostedZoneSchema))
+			return nil, nil
+		}
+		utils.LogAWSError("Route53.GetHostedZone", err)
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// listRoute53QueryLoggingConfigs returns the DNS query logging configurations of a hosted zone
+func listRoute53QueryLoggingConfigs(
+	route53Svc route53iface.Route53API, id *string) (configs []*route53.QueryLoggingConfig, err error) {
+
+	err = route53Svc.ListQueryLoggingConfigsPages(&route53.ListQueryLoggingConfigsInput{HostedZoneId: id},
+		func(page *route53.ListQueryLoggingConfigsOutput, lastPage bool) bool {
+			configs = append(configs, page.QueryLoggingConfigs...)
+			return true
+		})
+	if err != nil {
+		utils.LogAWSError("Route53.ListQueryLoggingConfigsPages", err)
+	}
+	return
+}
+
+// listTagsRoute53 returns the tags for a given hosted zone
+func listTagsRoute53(route53Svc route53iface.Route53API, id *string) (map[string]*string, error) {
+	out, err := route53Svc.ListTagsForResource(&route53.ListTagsForResourceInput{
+		ResourceId:   id,
+		ResourceType: aws.String(route53.TagResourceTypeHostedzone),
+	})
+	if err != nil {
+		utils.LogAWSError("Route53.ListTagsForResource", err)
+		return nil, err
+	}
+	if out.ResourceTagSet == nil {
+		return nil, nil
+	}
+
+	return utils.ParseTagSlice(out.ResourceTagSet.Tags), nil
+}
+
+// buildRoute53HostedZoneSnapshot returns a complete snapshot of a Route 53 hosted zone
+func buildRoute53HostedZoneSnapshot(
+	route53Svc route53iface.Route53API,
+	hostedZone *route53.GetHostedZoneOutput,
+) *awsmodels.Route53HostedZone {
+
+	if hostedZone == nil || hostedZone.HostedZone == nil {
+		return nil
+	}
+
+	id := aws.String(strings.TrimPrefix(*hostedZone.HostedZone.Id, route53HostedZonePrefix))
+	zoneARN := aws.String("arn:aws:route53:::hostedzone/" + *id)
+	snapshot := &awsmodels.Route53HostedZone{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   zoneARN,
+			ResourceType: aws.String(awsmodels.Route53HostedZoneSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  zoneARN,
+			ID:   id,
+			Name: hostedZone.HostedZone.Name,
+		},
+		CallerReference:        hostedZone.HostedZone.CallerReference,
+		Config:                 hostedZone.HostedZone.Config,
+		LinkedService:          hostedZone.HostedZone.LinkedService,
+		ResourceRecordSetCount: hostedZone.HostedZone.ResourceRecordSetCount,
+		DelegationSet:          hostedZone.DelegationSet,
+		VPCs:                   hostedZone.VPCs,
+	}
+
+	queryLoggingConfigs, err := listRoute53QueryLoggingConfigs(route53Svc, id)
+	if err == nil {
+		snapshot.QueryLoggingConfigs = queryLoggingConfigs
+	}
+
+	tags, err := listTagsRoute53(route53Svc, id)
+	if err == nil {
+		snapshot.Tags = tags
+	}
+
+	return snapshot
+}
+
+// PollRoute53HostedZones gathers information on each Route 53 hosted zone for an AWS account.
+func PollRoute53HostedZones(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting Route 53 Hosted Zone resource poller")
+	route53Svc, err := getRoute53Client(pollerInput, defaultRegion)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	var resources []*apimodels.AddResourceEntry
+	for _, hostedZoneID := range listRoute53HostedZones(route53Svc) {
+		hostedZone, err := getRoute53HostedZone(route53Svc, hostedZoneID)
+		if err != nil || hostedZone == nil {
+			continue
+		}
+
+		hostedZoneSnapshot := buildRoute53HostedZoneSnapshot(route53Svc, hostedZone)
+		if hostedZoneSnapshot == nil {
+			continue
+		}
+		hostedZoneSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+		hostedZoneSnapshot.Region = aws.String(awsmodels.GlobalRegion)
+
+		resources = append(resources, &apimodels.AddResourceEntry{
+			Attributes:      hostedZoneSnapshot,
+			ID:              apimodels.ResourceID(*hostedZoneSnapshot.ARN),
+			IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
+			IntegrationType: apimodels.IntegrationTypeAws,
+			Type:            awsmodels.Route53HostedZoneSchema,
+		})
+	}
+
+	return resources, nil
+}
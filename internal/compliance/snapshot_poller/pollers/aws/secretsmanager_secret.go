@@ -0,0 +1,211 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Set as variables to be overridden in testing
+var (
+	SecretsManagerClientFunc = setupSecretsManagerClient
+)
+
+func setupSecretsManagerClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return secretsmanager.New(sess, cfg)
+}
+
+func getSecretsManagerClient(
+	pollerResourceInput *awsmodels.ResourcePollerInput, region string) (secretsmanageriface.SecretsManagerAPI, error) {
+
+	client, err := getClient(pollerResourceInput, SecretsManagerClientFunc, "secretsmanager", region)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	return client.(secretsmanageriface.SecretsManagerAPI), nil
+}
+
+// PollSecretsManagerSecret polls a single Secrets Manager Secret resource
+func PollSecretsManagerSecret(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getSecretsManagerClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := describeSecret(client, aws.String(resourceARN.String()))
+	if err != nil || secret == nil {
+		return nil, err
+	}
+
+	snapshot := buildSecretsManagerSecretSnapshot(client, secret)
+	if snapshot == nil {
+		return nil, nil
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// listSecrets returns the metadata of all secrets in the region
+func listSecrets(secretsSvc secretsmanageriface.SecretsManagerAPI) (secrets []*secretsmanager.SecretListEntry) {
+	err := secretsSvc.ListSecretsPages(&secretsmanager.ListSecretsInput{},
+		func(page *secretsmanager.ListSecretsOutput, lastPage bool) bool {
+			secrets = append(secrets, page.SecretList...)
+			return true
+		})
+	if err != nil {
+		utils.LogAWSError("SecretsManager.ListSecretsPages", err)
+	}
+	return
+}
+
+// describeSecret returns the metadata of a single secret in the same form as listSecrets.
+//
+// Unlike ListSecrets, this also finds secrets which are scheduled for deletion.
+func describeSecret(
+	secretsSvc secretsmanageriface.SecretsManagerAPI, secretID *string) (*secretsmanager.SecretListEntry, error) {
+
+	out, err := secretsSvc.DescribeSecret(&secretsmanager.DescribeSecretInput{SecretId: secretID})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == secretsmanager.ErrCodeResourceNotFoundException {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *secretID),
+				zap.String("resourceType", awsmodels.SecretsManagerSecretSchema))
+			return nil, nil
+		}
+		utils.LogAWSError("SecretsManager.DescribeSecret", err)
+		return nil, err
+	}
+
+	return &secretsmanager.SecretListEntry{
+		ARN:               out.ARN,
+		DeletedDate:       out.DeletedDate,
+		Description:       out.Description,
+		KmsKeyId:          out.KmsKeyId,
+		LastAccessedDate:  out.LastAccessedDate,
+		LastChangedDate:   out.LastChangedDate,
+		LastRotatedDate:   out.LastRotatedDate,
+		Name:              out.Name,
+		OwningService:     out.OwningService,
+		RotationEnabled:   out.RotationEnabled,
+		RotationLambdaARN: out.RotationLambdaARN,
+		RotationRules:     out.RotationRules,
+		Tags:              out.Tags,
+	}, nil
+}
+
+// getSecretPolicy returns the resource policy attached to a secret, if one exists
+func getSecretPolicy(secretsSvc secretsmanageriface.SecretsManagerAPI, secretID *string) (*string, error) {
+	out, err := secretsSvc.GetResourcePolicy(&secretsmanager.GetResourcePolicyInput{SecretId: secretID})
+	if err != nil {
+		utils.LogAWSError("SecretsManager.GetResourcePolicy", err)
+		return nil, err
+	}
+
+	return out.ResourcePolicy, nil
+}
+
+// buildSecretsManagerSecretSnapshot returns a complete snapshot of a secret, without its value
+func buildSecretsManagerSecretSnapshot(
+	secretsSvc secretsmanageriface.SecretsManagerAPI,
+	secret *secretsmanager.SecretListEntry,
+) *awsmodels.SecretsManagerSecret {
+
+	if secret == nil {
+		return nil
+	}
+
+	snapshot := &awsmodels.SecretsManagerSecret{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   secret.ARN,
+			ResourceType: aws.String(awsmodels.SecretsManagerSecretSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  secret.ARN,
+			Name: secret.Name,
+			Tags: utils.ParseTagSlice(secret.Tags),
+		},
+		DeletedDate:       secret.DeletedDate,
+		Description:       secret.Description,
+		KmsKeyId:          secret.KmsKeyId,
+		LastAccessedDate:  secret.LastAccessedDate,
+		LastChangedDate:   secret.LastChangedDate,
+		LastRotatedDate:   secret.LastRotatedDate,
+		OwningService:     secret.OwningService,
+		RotationEnabled:   secret.RotationEnabled,
+		RotationLambdaARN: secret.RotationLambdaARN,
+		RotationRules:     secret.RotationRules,
+	}
+
+	policy, err := getSecretPolicy(secretsSvc, secret.ARN)
+	if err == nil {
+		snapshot.Policy = policy
+	}
+
+	return snapshot
+}
+
+// PollSecretsManagerSecrets gathers information on each Secrets Manager secret for an AWS account.
+func PollSecretsManagerSecrets(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting Secrets Manager Secret resource poller")
+	var resources []*apimodels.AddResourceEntry
+
+	for _, regionID := range utils.GetServiceRegions(pollerInput.Regions, "secretsmanager") {
+		secretsSvc, err := getSecretsManagerClient(pollerInput, *regionID)
+		if err != nil {
+			return nil, err // error is logged in getClient()
+		}
+
+		for _, secret := range listSecrets(secretsSvc) {
+			secretSnapshot := buildSecretsManagerSecretSnapshot(secretsSvc, secret)
+			if secretSnapshot == nil {
+				continue
+			}
+			secretSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+			secretSnapshot.Region = regionID
+
+			resources = append(resources, &apimodels.AddResourceEntry{
+				Attributes:      secretSnapshot,
+				ID:              apimodels.ResourceID(*secretSnapshot.ARN),
+				IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
+				IntegrationType: apimodels.IntegrationTypeAws,
+				Type:            awsmodels.SecretsManagerSecretSchema,
+			})
+		}
+	}
+
+	return resources, nil
+}
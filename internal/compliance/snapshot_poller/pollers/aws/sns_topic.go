@@ -0,0 +1,210 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Set as variables to be overridden in testing
+var (
+	SnsClientFunc = setupSnsClient
+)
+
+func setupSnsClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return sns.New(sess, cfg)
+}
+
+func getSnsClient(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (snsiface.SNSAPI, error) {
+	client, err := getClient(pollerResourceInput, SnsClientFunc, "sns", region)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	return client.(snsiface.SNSAPI), nil
+}
+
+// PollSNSTopic polls a single SNS Topic resource
+func PollSNSTopic(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getSnsClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := buildSnsTopicSnapshot(client, aws.String(resourceARN.String()))
+	if snapshot == nil {
+		return nil, nil
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// listSnsTopics returns the ARNs of all SNS topics in the region
+func listSnsTopics(snsSvc snsiface.SNSAPI) (topicARNs []*string) {
+	err := snsSvc.ListTopicsPages(&sns.ListTopicsInput{},
+		func(page *sns.ListTopicsOutput, lastPage bool) bool {
+			for _, topic := range page.Topics {
+				topicARNs = append(topicARNs, topic.TopicArn)
+			}
+			return true
+		})
+	if err != nil {
+		utils.LogAWSError("SNS.ListTopicsPages", err)
+	}
+	return
+}
+
+// getSnsTopicAttributes returns all attributes of a given SNS topic
+func getSnsTopicAttributes(snsSvc snsiface.SNSAPI, topicARN *string) (map[string]*string, error) {
+	out, err := snsSvc.GetTopicAttributes(&sns.GetTopicAttributesInput{TopicArn: topicARN})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == sns.ErrCodeNotFoundException {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *topicARN),
+				zap.String("resourceType", awsmodels.SnsTopicSchema))
+			return nil, err
+		}
+		utils.LogAWSError("SNS.GetTopicAttributes", err)
+		return nil, err
+	}
+
+	return out.Attributes, nil
+}
+
+// listSnsSubscriptions returns all subscriptions to a given SNS topic
+func listSnsSubscriptions(snsSvc snsiface.SNSAPI, topicARN *string) (subscriptions []*sns.Subscription, err error) {
+	err = snsSvc.ListSubscriptionsByTopicPages(&sns.ListSubscriptionsByTopicInput{TopicArn: topicARN},
+		func(page *sns.ListSubscriptionsByTopicOutput, lastPage bool) bool {
+			subscriptions = append(subscriptions, page.Subscriptions...)
+			return true
+		})
+	if err != nil {
+		utils.LogAWSError("SNS.ListSubscriptionsByTopicPages", err)
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// listTagsSns returns the tags for a given SNS topic
+func listTagsSns(snsSvc snsiface.SNSAPI, topicARN *string) (map[string]*string, error) {
+	out, err := snsSvc.ListTagsForResource(&sns.ListTagsForResourceInput{ResourceArn: topicARN})
+	if err != nil {
+		utils.LogAWSError("SNS.ListTagsForResource", err)
+		return nil, err
+	}
+
+	return utils.ParseTagSlice(out.Tags), nil
+}
+
+// buildSnsTopicSnapshot returns a complete snapshot of an SNS topic
+func buildSnsTopicSnapshot(snsSvc snsiface.SNSAPI, topicARN *string) *awsmodels.SnsTopic {
+	if topicARN == nil {
+		return nil
+	}
+
+	attributes, err := getSnsTopicAttributes(snsSvc, topicARN)
+	if err != nil {
+		return nil
+	}
+
+	// Topic ARNs are of the form arn:aws:sns:us-west-2:123456789012:topic-name
+	name := (*topicARN)[strings.LastIndex(*topicARN, ":")+1:]
+	snsTopic := &awsmodels.SnsTopic{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   topicARN,
+			ResourceType: aws.String(awsmodels.SnsTopicSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  topicARN,
+			Name: aws.String(name),
+		},
+		DeliveryPolicy:          attributes["DeliveryPolicy"],
+		DisplayName:             attributes["DisplayName"],
+		EffectiveDeliveryPolicy: attributes["EffectiveDeliveryPolicy"],
+		KmsMasterKeyId:          attributes["KmsMasterKeyId"],
+		Owner:                   attributes["Owner"],
+		Policy:                  attributes["Policy"],
+		SubscriptionsConfirmed:  attributes["SubscriptionsConfirmed"],
+		SubscriptionsDeleted:    attributes["SubscriptionsDeleted"],
+		SubscriptionsPending:    attributes["SubscriptionsPending"],
+	}
+
+	tags, err := listTagsSns(snsSvc, topicARN)
+	if err == nil {
+		snsTopic.Tags = tags
+	}
+
+	subscriptions, err := listSnsSubscriptions(snsSvc, topicARN)
+	if err == nil {
+		snsTopic.Subscriptions = subscriptions
+	}
+
+	return snsTopic
+}
+
+// PollSnsTopics gathers information on each SNS topic for an AWS account.
+func PollSnsTopics(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting SNS Topic resource poller")
+	var resources []*apimodels.AddResourceEntry
+
+	for _, regionID := range utils.GetServiceRegions(pollerInput.Regions, "sns") {
+		snsSvc, err := getSnsClient(pollerInput, *regionID)
+		if err != nil {
+			return nil, err // error is logged in getClient()
+		}
+
+		for _, topicARN := range listSnsTopics(snsSvc) {
+			snsTopicSnapshot := buildSnsTopicSnapshot(snsSvc, topicARN)
+			if snsTopicSnapshot == nil {
+				continue
+			}
+			snsTopicSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+			snsTopicSnapshot.Region = regionID
+
+			resources = append(resources, &apimodels.AddResourceEntry{
+				Attributes:      snsTopicSnapshot,
+				ID:              apimodels.ResourceID(*snsTopicSnapshot.ARN),
+				IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
+				IntegrationType: apimodels.IntegrationTypeAws,
+				Type:            awsmodels.SnsTopicSchema,
+			})
+		}
+	}
+
+	return resources, nil
+}
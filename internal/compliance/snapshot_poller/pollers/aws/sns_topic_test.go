@@ -0,0 +1,130 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestSnsTopicList(t *testing.T) {
+	mockSvc := awstest.BuildMockSnsSvc([]string{"ListTopicsPages"})
+
+	out := listSnsTopics(mockSvc)
+	assert.NotEmpty(t, out)
+}
+
+func TestSnsTopicListError(t *testing.T) {
+	mockSvc := awstest.BuildMockSnsSvcError([]string{"ListTopicsPages"})
+
+	out := listSnsTopics(mockSvc)
+	assert.Nil(t, out)
+}
+
+func TestSnsTopicGetAttributes(t *testing.T) {
+	mockSvc := awstest.BuildMockSnsSvc([]string{"GetTopicAttributes"})
+
+	out, err := getSnsTopicAttributes(mockSvc, awstest.ExampleSnsTopicArn)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestSnsTopicGetAttributesError(t *testing.T) {
+	mockSvc := awstest.BuildMockSnsSvcError([]string{"GetTopicAttributes"})
+
+	out, err := getSnsTopicAttributes(mockSvc, awstest.ExampleSnsTopicArn)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestSnsTopicListSubscriptions(t *testing.T) {
+	mockSvc := awstest.BuildMockSnsSvc([]string{"ListSubscriptionsByTopicPages"})
+
+	out, err := listSnsSubscriptions(mockSvc, awstest.ExampleSnsTopicArn)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestSnsTopicListSubscriptionsError(t *testing.T) {
+	mockSvc := awstest.BuildMockSnsSvcError([]string{"ListSubscriptionsByTopicPages"})
+
+	out, err := listSnsSubscriptions(mockSvc, awstest.ExampleSnsTopicArn)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestSnsTopicBuildSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockSnsSvcAll()
+
+	snapshot := buildSnsTopicSnapshot(mockSvc, awstest.ExampleSnsTopicArn)
+
+	assert.Equal(t, "example-topic", *snapshot.Name)
+	assert.Equal(t, "alias/aws/sns", *snapshot.KmsMasterKeyId)
+	assert.NotEmpty(t, snapshot.Policy)
+	assert.NotEmpty(t, snapshot.Subscriptions)
+	assert.Equal(t, "Panther", *snapshot.Tags["Application"])
+}
+
+func TestSnsTopicBuildSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockSnsSvcAllError()
+
+	snapshot := buildSnsTopicSnapshot(mockSvc, awstest.ExampleSnsTopicArn)
+
+	assert.Nil(t, snapshot)
+}
+
+func TestSnsPoller(t *testing.T) {
+	awstest.MockSnsForSetup = awstest.BuildMockSnsSvcAll()
+
+	SnsClientFunc = awstest.SetupMockSns
+
+	resources, err := PollSnsTopics(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, resources)
+	assert.Equal(t, awsmodels.SnsTopicSchema, string(resources[0].Type))
+}
+
+func TestSnsPollerError(t *testing.T) {
+	awstest.MockSnsForSetup = awstest.BuildMockSnsSvcAllError()
+
+	SnsClientFunc = awstest.SetupMockSns
+
+	resources, err := PollSnsTopics(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+}
@@ -0,0 +1,222 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Set as variables to be overridden in testing
+var (
+	SqsClientFunc = setupSqsClient
+)
+
+func setupSqsClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return sqs.New(sess, cfg)
+}
+
+func getSqsClient(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (sqsiface.SQSAPI, error) {
+	client, err := getClient(pollerResourceInput, SqsClientFunc, "sqs", region)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	return client.(sqsiface.SQSAPI), nil
+}
+
+// PollSQSQueue polls a single SQS Queue resource
+func PollSQSQueue(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getSqsClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	queueURL, err := getSqsQueueURL(client, aws.String(resourceARN.Resource), aws.String(resourceARN.AccountID))
+	if err != nil || queueURL == nil {
+		return nil, err
+	}
+
+	snapshot := buildSqsQueueSnapshot(client, queueURL)
+	if snapshot == nil {
+		return nil, nil
+	}
+	snapshot.AccountID = aws.String(resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// listSqsQueues returns the URLs of all SQS queues in the region
+func listSqsQueues(sqsSvc sqsiface.SQSAPI) (queueURLs []*string) {
+	err := sqsSvc.ListQueuesPages(&sqs.ListQueuesInput{},
+		func(page *sqs.ListQueuesOutput, lastPage bool) bool {
+			queueURLs = append(queueURLs, page.QueueUrls...)
+			return true
+		})
+	if err != nil {
+		utils.LogAWSError("SQS.ListQueuesPages", err)
+	}
+	return
+}
+
+// getSqsQueueURL looks up the URL of a queue from its name and owner
+func getSqsQueueURL(sqsSvc sqsiface.SQSAPI, name *string, owner *string) (*string, error) {
+	out, err := sqsSvc.GetQueueUrl(&sqs.GetQueueUrlInput{
+		QueueName:              name,
+		QueueOwnerAWSAccountId: owner,
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == sqs.ErrCodeQueueDoesNotExist {
+			zap.L().Warn("tried to scan non-existent resource",
+				zap.String("resource", *name),
+				zap.String("resourceType", awsmodels.SqsQueueSchema))
+			return nil, nil
+		}
+		utils.LogAWSError("SQS.GetQueueUrl", err)
+		return nil, err
+	}
+
+	return out.QueueUrl, nil
+}
+
+// getSqsQueueAttributes returns all attributes of a given SQS queue
+func getSqsQueueAttributes(sqsSvc sqsiface.SQSAPI, queueURL *string) (map[string]*string, error) {
+	out, err := sqsSvc.GetQueueAttributes(&sqs.GetQueueAttributesInput{
+		AttributeNames: []*string{aws.String(sqs.QueueAttributeNameAll)},
+		QueueUrl:       queueURL,
+	})
+	if err != nil {
+		utils.LogAWSError("SQS.GetQueueAttributes", err)
+		return nil, err
+	}
+
+	return out.Attributes, nil
+}
+
+// listTagsSqs returns the tags for a given SQS queue
+func listTagsSqs(sqsSvc sqsiface.SQSAPI, queueURL *string) (map[string]*string, error) {
+	out, err := sqsSvc.ListQueueTags(&sqs.ListQueueTagsInput{QueueUrl: queueURL})
+	if err != nil {
+		utils.LogAWSError("SQS.ListQueueTags", err)
+		return nil, err
+	}
+
+	return out.Tags, nil
+}
+
+// buildSqsQueueSnapshot returns a complete snapshot of an SQS queue
+func buildSqsQueueSnapshot(sqsSvc sqsiface.SQSAPI, queueURL *string) *awsmodels.SqsQueue {
+	if queueURL == nil {
+		return nil
+	}
+
+	// Without the attributes we can't even determine the ARN of the queue
+	attributes, err := getSqsQueueAttributes(sqsSvc, queueURL)
+	if err != nil {
+		return nil
+	}
+
+	// Queue URLs are of the form https://sqs.us-east-1.amazonaws.com/123456789012/queue-name
+	name := (*queueURL)[strings.LastIndex(*queueURL, "/")+1:]
+	queueARN := attributes[sqs.QueueAttributeNameQueueArn]
+	sqsQueue := &awsmodels.SqsQueue{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   queueARN,
+			ResourceType: aws.String(awsmodels.SqsQueueSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			ARN:  queueARN,
+			Name: aws.String(name),
+		},
+		ContentBasedDeduplication:     attributes[sqs.QueueAttributeNameContentBasedDeduplication],
+		DelaySeconds:                  attributes[sqs.QueueAttributeNameDelaySeconds],
+		FifoQueue:                     attributes[sqs.QueueAttributeNameFifoQueue],
+		KmsDataKeyReusePeriodSeconds:  attributes[sqs.QueueAttributeNameKmsDataKeyReusePeriodSeconds],
+		KmsMasterKeyId:                attributes[sqs.QueueAttributeNameKmsMasterKeyId],
+		MaximumMessageSize:            attributes[sqs.QueueAttributeNameMaximumMessageSize],
+		MessageRetentionPeriod:        attributes[sqs.QueueAttributeNameMessageRetentionPeriod],
+		Policy:                        attributes[sqs.QueueAttributeNamePolicy],
+		QueueUrl:                      queueURL,
+		ReceiveMessageWaitTimeSeconds: attributes[sqs.QueueAttributeNameReceiveMessageWaitTimeSeconds],
+		RedrivePolicy:                 attributes[sqs.QueueAttributeNameRedrivePolicy],
+		VisibilityTimeout:             attributes[sqs.QueueAttributeNameVisibilityTimeout],
+	}
+	if created := attributes[sqs.QueueAttributeNameCreatedTimestamp]; created != nil {
+		if epoch, err := strconv.ParseInt(*created, 10, 64); err == nil {
+			sqsQueue.TimeCreated = utils.UnixTimeToDateTime(epoch)
+		}
+	}
+
+	tags, err := listTagsSqs(sqsSvc, queueURL)
+	if err == nil {
+		sqsQueue.Tags = tags
+	}
+
+	return sqsQueue
+}
+
+// PollSqsQueues gathers information on each SQS queue for an AWS account.
+func PollSqsQueues(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting SQS Queue resource poller")
+	var resources []*apimodels.AddResourceEntry
+
+	for _, regionID := range utils.GetServiceRegions(pollerInput.Regions, "sqs") {
+		sqsSvc, err := getSqsClient(pollerInput, *regionID)
+		if err != nil {
+			return nil, err // error is logged in getClient()
+		}
+
+		for _, queueURL := range listSqsQueues(sqsSvc) {
+			sqsQueueSnapshot := buildSqsQueueSnapshot(sqsSvc, queueURL)
+			if sqsQueueSnapshot == nil || sqsQueueSnapshot.ARN == nil {
+				continue
+			}
+			sqsQueueSnapshot.AccountID = aws.String(pollerInput.AuthSourceParsedARN.AccountID)
+			sqsQueueSnapshot.Region = regionID
+
+			resources = append(resources, &apimodels.AddResourceEntry{
+				Attributes:      sqsQueueSnapshot,
+				ID:              apimodels.ResourceID(*sqsQueueSnapshot.ARN),
+				IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
+				IntegrationType: apimodels.IntegrationTypeAws,
+				Type:            awsmodels.SqsQueueSchema,
+			})
+		}
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,131 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestSqsQueueList(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvc([]string{"ListQueuesPages"})
+
+	out := listSqsQueues(mockSvc)
+	assert.NotEmpty(t, out)
+}
+
+func TestSqsQueueListError(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvcError([]string{"ListQueuesPages"})
+
+	out := listSqsQueues(mockSvc)
+	assert.Nil(t, out)
+}
+
+func TestSqsQueueGetAttributes(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvc([]string{"GetQueueAttributes"})
+
+	out, err := getSqsQueueAttributes(mockSvc, awstest.ExampleSqsQueueURL)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestSqsQueueGetAttributesError(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvcError([]string{"GetQueueAttributes"})
+
+	out, err := getSqsQueueAttributes(mockSvc, awstest.ExampleSqsQueueURL)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestSqsQueueListTags(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvc([]string{"ListQueueTags"})
+
+	out, err := listTagsSqs(mockSvc, awstest.ExampleSqsQueueURL)
+	require.NoError(t, err)
+	assert.NotEmpty(t, out)
+}
+
+func TestSqsQueueListTagsError(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvcError([]string{"ListQueueTags"})
+
+	out, err := listTagsSqs(mockSvc, awstest.ExampleSqsQueueURL)
+	require.Error(t, err)
+	assert.Nil(t, out)
+}
+
+func TestSqsQueueBuildSnapshot(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvcAll()
+
+	snapshot := buildSqsQueueSnapshot(mockSvc, awstest.ExampleSqsQueueURL)
+
+	assert.Equal(t, "arn:aws:sqs:us-west-2:123456789012:example-queue", *snapshot.ARN)
+	assert.Equal(t, "example-queue", *snapshot.Name)
+	assert.Equal(t, "alias/aws/sqs", *snapshot.KmsMasterKeyId)
+	assert.NotNil(t, snapshot.TimeCreated)
+	assert.NotEmpty(t, snapshot.Policy)
+	assert.NotEmpty(t, snapshot.Tags)
+}
+
+func TestSqsQueueBuildSnapshotErrors(t *testing.T) {
+	mockSvc := awstest.BuildMockSqsSvcAllError()
+
+	snapshot := buildSqsQueueSnapshot(mockSvc, awstest.ExampleSqsQueueURL)
+
+	assert.Nil(t, snapshot)
+}
+
+func TestSqsPoller(t *testing.T) {
+	awstest.MockSqsForSetup = awstest.BuildMockSqsSvcAll()
+
+	SqsClientFunc = awstest.SetupMockSqs
+
+	resources, err := PollSqsQueues(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, resources)
+	assert.Equal(t, awsmodels.SqsQueueSchema, string(resources[0].Type))
+}
+
+func TestSqsPollerError(t *testing.T) {
+	awstest.MockSqsForSetup = awstest.BuildMockSqsSvcAllError()
+
+	SqsClientFunc = awstest.SetupMockSqs
+
+	resources, err := PollSqsQueues(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+}
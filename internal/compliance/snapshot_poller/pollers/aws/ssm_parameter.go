@@ -0,0 +1,213 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+// Set as variables to be overridden in testing
+var (
+	SSMClientFunc = setupSSMClient
+)
+
+func setupSSMClient(sess *session.Session, cfg *aws.Config) interface{} {
+	return ssm.New(sess, cfg)
+}
+
+func getSSMClient(pollerResourceInput *awsmodels.ResourcePollerInput, region string) (ssmiface.SSMAPI, error) {
+	client, err := getClient(pollerResourceInput, SSMClientFunc, "ssm", region)
+	if err != nil {
+		return nil, err // error is logged in getClient()
+	}
+
+	return client.(ssmiface.SSMAPI), nil
+}
+
+// PollSSMParameter polls a single SSM Parameter Store parameter
+func PollSSMParameter(
+	pollerResourceInput *awsmodels.ResourcePollerInput,
+	resourceARN arn.ARN,
+	scanRequest *pollermodels.ScanEntry,
+) (interface{}, error) {
+
+	client, err := getSSMClient(pollerResourceInput, resourceARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	parameters, err := describeSSMParameters(client, aws.String(ssmParameterNameFromARN(resourceARN)))
+	if err != nil {
+		return nil, err
+	}
+	if len(parameters) == 0 {
+		zap.L().Warn("tried to scan non-existent resource",
+			zap.String("resource", resourceARN.String()),
+			zap.String("resourceType", awsmodels.SSMParameterSchema))
+		return nil, nil
+	}
+
+	snapshot := buildSSMParameterSnapshot(client, parameters[0], resourceARN.Region, resourceARN.AccountID)
+	snapshot.Region = aws.String(resourceARN.Region)
+	return snapshot, nil
+}
+
+// ssmParameterNameFromARN recovers a parameter name from its ARN.
+//
+// Hierarchical parameter names always begin with a slash, which the ARN omits, while
+// non-hierarchical names may not contain a slash at all.
+func ssmParameterNameFromARN(resourceARN arn.ARN) string {
+	name := strings.TrimPrefix(resourceARN.Resource, "parameter/")
+	if strings.Contains(name, "/") {
+		return "/" + name
+	}
+	return name
+}
+
+// ssmParameterARN builds the ARN of a parameter, which is not returned by the SSM API
+func ssmParameterARN(region, accountID, name string) string {
+	if !strings.HasPrefix(name, "/") {
+		name = "/" + name
+	}
+	return "arn:aws:ssm:" + region + ":" + accountID + ":parameter" + name
+}
+
+// describeSSMParameters returns the metadata of all parameters in a region, or of a single
+// parameter if a name is given
+func describeSSMParameters(ssmSvc ssmiface.SSMAPI, name *string) (parameters []*ssm.ParameterMetadata, err error) {
+	input := &ssm.DescribeParametersInput{}
+	if name != nil {
+		input.ParameterFilters = []*ssm.ParameterStringFilter{
+			{
+				Key:    aws.String("Name"),
+				Option: aws.String("Equals"),
+				Values: []*string{name},
+			},
+		}
+	}
+
+	err = ssmSvc.DescribeParametersPages(input,
+		func(page *ssm.DescribeParametersOutput, lastPage bool) bool {
+			parameters = append(parameters, page.Parameters...)
+			return true
+		})
+	if err != nil {
+		utils.LogAWSError("SSM.DescribeParametersPages", err)
+	}
+	return
+}
+
+// listTagsSSM returns the tags for a given SSM parameter
+func listTagsSSM(ssmSvc ssmiface.SSMAPI, name *string) (map[string]*string, error) {
+	out, err := ssmSvc.ListTagsForResource(&ssm.ListTagsForResourceInput{
+		ResourceId:   name,
+		ResourceType: aws.String(ssm.ResourceTypeForTaggingParameter),
+	})
+	if err != nil {
+		utils.LogAWSError("SSM.ListTagsForResource", err)
+		return nil, err
+	}
+
+	return utils.ParseTagSlice(out.TagList), nil
+}
+
+// buildSSMParameterSnapshot returns a complete snapshot of an SSM parameter. The parameter
+// value is deliberately never retrieved.
+func buildSSMParameterSnapshot(
+	ssmSvc ssmiface.SSMAPI,
+	parameter *ssm.ParameterMetadata,
+	region, accountID string,
+) *awsmodels.SSMParameter {
+
+	parameterARN := aws.String(ssmParameterARN(region, accountID, *parameter.Name))
+	snapshot := &awsmodels.SSMParameter{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   parameterARN,
+			ResourceType: aws.String(awsmodels.SSMParameterSchema),
+		},
+		GenericAWSResource: awsmodels.GenericAWSResource{
+			AccountID: aws.String(accountID),
+			ARN:       parameterARN,
+			Name:      parameter.Name,
+		},
+		AllowedPattern:   parameter.AllowedPattern,
+		DataType:         parameter.DataType,
+		Description:      parameter.Description,
+		KeyId:            parameter.KeyId,
+		LastModifiedDate: parameter.LastModifiedDate,
+		LastModifiedUser: parameter.LastModifiedUser,
+		Policies:         parameter.Policies,
+		Tier:             parameter.Tier,
+		Type:             parameter.Type,
+		Version:          parameter.Version,
+	}
+
+	tags, err := listTagsSSM(ssmSvc, parameter.Name)
+	if err == nil {
+		snapshot.Tags = tags
+	}
+
+	return snapshot
+}
+
+// PollSSMParameters gathers information on each SSM Parameter Store parameter for an AWS account.
+func PollSSMParameters(pollerInput *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting SSM Parameter resource poller")
+	var resources []*apimodels.AddResourceEntry
+
+	for _, regionID := range utils.GetServiceRegions(pollerInput.Regions, ssm.EndpointsID) {
+		ssmSvc, err := getSSMClient(pollerInput, *regionID)
+		if err != nil {
+			return nil, err // error is logged in getClient()
+		}
+
+		parameters, err := describeSSMParameters(ssmSvc, nil)
+		if err != nil {
+			continue
+		}
+
+		for _, parameter := range parameters {
+			parameterSnapshot := buildSSMParameterSnapshot(
+				ssmSvc, parameter, *regionID, pollerInput.AuthSourceParsedARN.AccountID)
+			parameterSnapshot.Region = regionID
+
+			resources = append(resources, &apimodels.AddResourceEntry{
+				Attributes:      parameterSnapshot,
+				ID:              apimodels.ResourceID(*parameterSnapshot.ARN),
+				IntegrationID:   apimodels.IntegrationID(*pollerInput.IntegrationID),
+				IntegrationType: apimodels.IntegrationTypeAws,
+				Type:            awsmodels.SSMParameterSchema,
+			})
+		}
+	}
+
+	return resources, nil
+}
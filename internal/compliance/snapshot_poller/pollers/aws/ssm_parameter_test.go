@@ -0,0 +1,73 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+)
+
+func TestSSMPoller(t *testing.T) {
+	awstest.MockSSMForSetup = awstest.BuildMockSSMSvcAll()
+
+	SSMClientFunc = awstest.SetupMockSSM
+
+	resources, err := PollSSMParameters(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, resources)
+	assert.Equal(t, awsmodels.SSMParameterSchema, string(resources[0].Type))
+}
+
+func TestSSMPollerError(t *testing.T) {
+	awstest.MockSSMForSetup = awstest.BuildMockSSMSvcAllError()
+
+	SSMClientFunc = awstest.SetupMockSSM
+
+	resources, err := PollSSMParameters(&awsmodels.ResourcePollerInput{
+		AuthSource:          &awstest.ExampleAuthSource,
+		AuthSourceParsedARN: awstest.ExampleAuthSourceParsedARN,
+		IntegrationID:       awstest.ExampleIntegrationID,
+		Regions:             awstest.ExampleRegions,
+		Timestamp:           &awstest.ExampleTime,
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+}
+
+func TestSSMParameterARNRoundTrip(t *testing.T) {
+	for _, name := range []string{"example-parameter", "/example/database/password"} {
+		parameterARN, err := arn.Parse(ssmParameterARN("us-west-2", "123456789012", name))
+		require.NoError(t, err)
+		assert.Equal(t, name, ssmParameterNameFromARN(parameterARN))
+	}
+}
@@ -0,0 +1,373 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+)
+
+const (
+	// The number of work units polled concurrently by a single invocation
+	scanWorkers = 8
+
+	// No new work unit or page is started this close to the invocation deadline, which leaves time
+	// for the last pages to finish and the rest of the scan to be requeued
+	deadlineMargin = 2 * time.Minute
+)
+
+// errDeadlineReached stops a paged work unit between pages so it can be resumed later.
+var errDeadlineReached = errors.New("invocation deadline reached")
+
+// workUnit is the smallest resumable piece of a scan: one resource type in one region, continuing
+// from a page token if the resource type supports paging.
+type workUnit struct {
+	ResourceType string
+	Region       string
+	PageToken    *string
+
+	// When the unit was first claimed, which is in an earlier invocation if it was resumed
+	StartedAt time.Time
+	// The number of AWS errors logged while polling the unit by earlier invocations
+	PollErrors int
+	// The IDs of the resources polled for the unit by this invocation
+	polledIDs map[string]struct{}
+	// Counts the AWS errors logged while polling the unit by this invocation
+	errorCounter *utils.ErrorCounter
+}
+
+func (u *workUnit) id() string {
+	return u.ResourceType + "#" + u.Region
+}
+
+// pollErrors returns the number of AWS errors logged while polling the unit, across invocations.
+func (u *workUnit) pollErrors() int {
+	return u.PollErrors + u.errorCounter.Count()
+}
+
+// pagedResourcePoller polls a single page of resources in one region, and returns the token of the
+// next page or nil after the last page.
+type pagedResourcePoller func(
+	input *awsmodels.ResourcePollerInput, region string, pageToken *string) ([]*apimodels.AddResourceEntry, *string, error)
+
+// ResourceSink receives the resources polled by each work unit (or page of a work unit).
+type ResourceSink func(resources []*apimodels.AddResourceEntry) error
+
+// FinishedUnit is a work unit of a full scan which polled every page without errors, including the
+// errors which pollers log and skip.
+type FinishedUnit struct {
+	ResourceType string
+	// Empty for account-wide resource types
+	Region string
+	// The IDs of the resources polled by this invocation. Earlier pages of a resumed unit were
+	// polled by another invocation, after StartedAt.
+	ResourceIDs map[string]struct{}
+	StartedAt   time.Time
+}
+
+// UnitReconciler is called with each finished work unit of a full scan, to mark the stored
+// resources which were not polled again as deleted.
+type UnitReconciler func(unit *FinishedUnit) error
+
+var (
+	// PagedServicePollers are used instead of the ServicePollers for resource types which can be
+	// numerous enough that polling a single region does not fit in one invocation.
+	PagedServicePollers = map[string]pagedResourcePoller{
+		awsmodels.Ec2InstanceSchema:    PollEc2InstancesPage,
+		awsmodels.IAMRoleSchema:        PollIAMRolesPage,
+		awsmodels.LambdaFunctionSchema: PollLambdaFunctionsPage,
+	}
+
+	// accountWideResourceTypes are polled as one work unit covering every region, either because
+	// the service is global or because the poller aggregates all regions into a meta resource.
+	accountWideResourceTypes = map[string]bool{
+		awsmodels.CloudFrontDistributionSchema: true,
+		awsmodels.CloudTrailSchema:             true,
+		awsmodels.ConfigServiceSchema:          true,
+		awsmodels.GuardDutySchema:              true,
+		awsmodels.IAMGroupSchema:               true,
+		awsmodels.IAMPolicySchema:              true,
+		awsmodels.IAMRoleSchema:                true,
+		awsmodels.IAMUserSchema:                true,
+		awsmodels.OrganizationsSCPSchema:       true,
+		awsmodels.PasswordPolicySchema:         true,
+		awsmodels.Route53HostedZoneSchema:      true,
+		awsmodels.S3BucketSchema:               true,
+		awsmodels.WafWebAclSchema:              true,
+	}
+)
+
+// planWorkUnits splits a scan of the given resource types into one work unit per region, or a
+// single work unit for account-wide resource types.
+func planWorkUnits(resourceTypes []string, regions []*string) []*workUnit {
+	sorted := append([]string(nil), resourceTypes...)
+	sort.Strings(sorted)
+
+	var units []*workUnit
+	for _, resourceType := range sorted {
+		if accountWideResourceTypes[resourceType] {
+			units = append(units, &workUnit{ResourceType: resourceType, Region: awsmodels.GlobalRegion})
+			continue
+		}
+		for _, region := range regions {
+			units = append(units, &workUnit{ResourceType: resourceType, Region: *region})
+		}
+	}
+	return units
+}
+
+// scanResult counts the outcome of the work units of one invocation.
+type scanResult struct {
+	done      int
+	failed    int
+	remaining int
+	claimed   int
+}
+
+// unitRunner polls work units across a pool of workers. For resumable scans, every unit is
+// claimed and checkpointed in the checkpoint table, and units are left for a later invocation
+// once the deadline approaches.
+type unitRunner struct {
+	pollerInput *awsmodels.ResourcePollerInput
+	sink        ResourceSink
+	sinkLock    sync.Mutex
+	reconcile   UnitReconciler
+
+	// Only set for resumable scans
+	scanID   string
+	owner    string
+	deadline time.Time
+}
+
+// run polls the work units and returns how many of them finished, failed or are left over.
+func (r *unitRunner) run(units []*workUnit) scanResult {
+	var (
+		result     scanResult
+		resultLock sync.Mutex
+		wg         sync.WaitGroup
+	)
+
+	queue := make(chan *workUnit)
+	for i := 0; i < scanWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for unit := range queue {
+				outcome := r.runUnit(unit)
+				resultLock.Lock()
+				switch outcome {
+				case checkpointDone:
+					result.done++
+				case checkpointFailed:
+					result.failed++
+				case checkpointPending:
+					result.remaining++
+				default:
+					result.claimed++
+				}
+				resultLock.Unlock()
+			}
+		}()
+	}
+
+	for _, unit := range units {
+		queue <- unit
+	}
+	close(queue)
+	wg.Wait()
+	return result
+}
+
+// runUnit claims, polls and checkpoints a single work unit, returning its resulting status. The
+// running status means the unit is held (or was already finished) by another invocation.
+func (r *unitRunner) runUnit(unit *workUnit) string {
+	if r.expired() {
+		return checkpointPending
+	}
+
+	unit.StartedAt = time.Now()
+	unit.PollErrors = 0
+	unit.polledIDs = make(map[string]struct{})
+	unit.errorCounter = &utils.ErrorCounter{}
+	if r.scanID != "" {
+		claimed, err := claimCheckpoint(r.scanID, unit, r.owner, r.deadline)
+		if err != nil {
+			zap.L().Error("failed to claim work unit", zap.String("unit", unit.id()), zap.Error(err))
+			return checkpointPending
+		}
+		if !claimed {
+			zap.L().Debug("work unit already claimed", zap.String("unit", unit.id()))
+			return checkpointRunning
+		}
+	}
+
+	status := checkpointDone
+	if err := r.poll(unit); err == errDeadlineReached {
+		status = checkpointPending
+	} else if err != nil {
+		zap.L().Error(
+			"an error occurred while polling",
+			zap.String("resourceType", unit.ResourceType),
+			zap.String("region", unit.Region),
+			zap.Error(err),
+		)
+		status = checkpointFailed
+	}
+
+	if r.scanID != "" {
+		if err := finishCheckpoint(r.scanID, unit, status); err != nil {
+			// The lease expires eventually, and the unit is polled again by a later invocation
+			zap.L().Error("failed to checkpoint work unit", zap.String("unit", unit.id()), zap.Error(err))
+		}
+	}
+
+	if status == checkpointDone && r.reconcile != nil {
+		r.reconcileUnit(unit)
+	}
+	return status
+}
+
+// reconcileUnit passes a finished work unit to the reconciler. Errors are only logged, since the
+// polled resources were already sent and the next full scan reconciles the unit again.
+//
+// Units whose pollers logged any AWS errors are not reconciled, since the resources they skipped
+// would look deleted.
+func (r *unitRunner) reconcileUnit(unit *workUnit) {
+	if numErrors := unit.pollErrors(); numErrors > 0 {
+		zap.L().Warn(
+			"skipping reconciliation of work unit with polling errors",
+			zap.String("unit", unit.id()),
+			zap.Int("numErrors", numErrors),
+		)
+		return
+	}
+
+	finished := &FinishedUnit{
+		ResourceType: unit.ResourceType,
+		Region:       unit.Region,
+		ResourceIDs:  unit.polledIDs,
+		StartedAt:    unit.StartedAt,
+	}
+	if accountWideResourceTypes[unit.ResourceType] {
+		finished.Region = ""
+	}
+
+	if err := r.reconcile(finished); err != nil {
+		zap.L().Error("failed to reconcile work unit", zap.String("unit", unit.id()), zap.Error(err))
+	}
+}
+
+// poll runs the poller of a work unit, sending every page of resources to the sink.
+func (r *unitRunner) poll(unit *workUnit) error {
+	input := *r.pollerInput
+	input.Errors = unit.errorCounter
+	if !accountWideResourceTypes[unit.ResourceType] {
+		input.Regions = []*string{aws.String(unit.Region)}
+	}
+
+	pagedPoller, ok := PagedServicePollers[unit.ResourceType]
+	if !ok {
+		resources, err := ServicePollers[unit.ResourceType].resourcePoller(&input)
+		if err != nil {
+			return err
+		}
+		return r.emit(unit, resources)
+	}
+
+	for {
+		resources, nextPageToken, err := pagedPoller(&input, unit.Region, unit.PageToken)
+		if err != nil {
+			return err
+		}
+		if err = r.emit(unit, resources); err != nil {
+			return err
+		}
+
+		unit.PageToken = nextPageToken
+		if unit.PageToken == nil {
+			return nil
+		}
+		if r.scanID != "" {
+			if err = saveCheckpoint(r.scanID, unit, r.owner); err != nil {
+				return err
+			}
+		}
+		if r.expired() {
+			return errDeadlineReached
+		}
+	}
+}
+
+// emit sends the resources of a work unit to the sink, one unit at a time.
+func (r *unitRunner) emit(unit *workUnit, resources []*apimodels.AddResourceEntry) error {
+	for _, resource := range resources {
+		unit.polledIDs[string(resource.ID)] = struct{}{}
+	}
+	if len(resources) == 0 {
+		return nil
+	}
+	zap.L().Info(
+		"resources generated",
+		zap.Int("numResources", len(resources)),
+		zap.String("resourcePoller", ServicePollers[unit.ResourceType].description),
+		zap.String("region", unit.Region),
+	)
+
+	r.sinkLock.Lock()
+	defer r.sinkLock.Unlock()
+	return r.sink(resources)
+}
+
+// expired reports whether the deadline is too close to start polling anything new.
+func (r *unitRunner) expired() bool {
+	return !r.deadline.IsZero() && time.Now().After(r.deadline.Add(-deadlineMargin))
+}
+
+// pollAllPages polls every page of a paged resource type in each of the given regions.
+func pollAllPages(
+	pollerInput *awsmodels.ResourcePollerInput,
+	regions []*string,
+	poller pagedResourcePoller,
+) (resources []*apimodels.AddResourceEntry, err error) {
+
+	for _, region := range regions {
+		var pageToken *string
+		for {
+			var page []*apimodels.AddResourceEntry
+			page, pageToken, err = poller(pollerInput, *region, pageToken)
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, page...)
+			if pageToken == nil {
+				break
+			}
+		}
+	}
+	return resources, nil
+}
@@ -0,0 +1,458 @@
+package aws
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws/awstest"
+	"github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/utils"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+const (
+	testRegionalType = "Test.Regional"
+	testGlobalType   = "Test.Global"
+	testPagedType    = "Test.Paged"
+	testFailingType  = "Test.Failing"
+	testSkippingType = "Test.Skipping"
+)
+
+var testScanIntegrationID = "3e5d1c52-5b5c-4c3a-9a27-3f4c2b0a6d91"
+
+// testPageTokens chains the pages of the fake paged poller
+var testPageTokens = map[string]*string{"": aws.String("2"), "2": aws.String("3"), "3": nil}
+
+// testPollerCalls records the page tokens the fake paged poller was called with
+type testPollerCalls struct {
+	sync.Mutex
+	pageTokens []string
+}
+
+func testResource(id string) *apimodels.AddResourceEntry {
+	return &apimodels.AddResourceEntry{
+		ID:              apimodels.ResourceID(id),
+		IntegrationID:   apimodels.IntegrationID(testScanIntegrationID),
+		IntegrationType: apimodels.IntegrationTypeAws,
+	}
+}
+
+// registerTestPollers adds fake pollers for the test resource types for the duration of a test
+func registerTestPollers(t *testing.T) *testPollerCalls {
+	calls := &testPollerCalls{}
+	ServicePollers[testRegionalType] = resourcePoller{"TestRegional", func(
+		input *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+
+		require.Len(t, input.Regions, 1)
+		return []*apimodels.AddResourceEntry{testResource("regional-" + *input.Regions[0])}, nil
+	}}
+	ServicePollers[testGlobalType] = resourcePoller{"TestGlobal", func(
+		input *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+
+		regions := make([]string, 0, len(input.Regions))
+		for _, region := range input.Regions {
+			regions = append(regions, *region)
+		}
+		return []*apimodels.AddResourceEntry{testResource("global-" + strings.Join(regions, ","))}, nil
+	}}
+	ServicePollers[testFailingType] = resourcePoller{"TestFailing", func(
+		input *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+
+		return nil, errors.New("polling failed")
+	}}
+	ServicePollers[testSkippingType] = resourcePoller{"TestSkipping", func(
+		input *awsmodels.ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+
+		// Logs and skips a resource it failed to describe, like most pollers
+		request := &request.Request{Error: awserr.New("ThrottlingException", "Rate exceeded", nil)}
+		input.Errors.Handler().Fn(request)
+		utils.LogAWSError("Test.Describe", request.Error)
+		return []*apimodels.AddResourceEntry{testResource("skipping-" + *input.Regions[0])}, nil
+	}}
+	ServicePollers[testPagedType] = resourcePoller{"TestPaged", nil}
+	PagedServicePollers[testPagedType] = func(
+		input *awsmodels.ResourcePollerInput, region string, pageToken *string) ([]*apimodels.AddResourceEntry, *string, error) {
+
+		token := aws.StringValue(pageToken)
+		calls.Lock()
+		calls.pageTokens = append(calls.pageTokens, token)
+		calls.Unlock()
+		return []*apimodels.AddResourceEntry{testResource("paged-" + region + "-" + token)}, testPageTokens[token], nil
+	}
+	accountWideResourceTypes[testGlobalType] = true
+
+	t.Cleanup(func() {
+		for _, resourceType := range []string{testRegionalType, testGlobalType, testPagedType, testFailingType, testSkippingType} {
+			delete(ServicePollers, resourceType)
+			delete(PagedServicePollers, resourceType)
+			delete(accountWideResourceTypes, resourceType)
+		}
+	})
+	return calls
+}
+
+// setupScanMocks mocks the regions lookup, checkpoint table and requeueing used by Scan
+func setupScanMocks(t *testing.T) (*testutils.DynamoDBMock, *[]pollermodels.ScanMsg) {
+	awstest.MockEC2ForSetup = awstest.BuildMockEC2Svc([]string{"DescribeRegions"})
+	EC2ClientFunc = awstest.SetupMockEC2
+
+	mockDynamo := &testutils.DynamoDBMock{}
+	checkpointClient = mockDynamo
+
+	var requeued []pollermodels.ScanMsg
+	requeueFunc = func(scanRequest pollermodels.ScanMsg, delay int64) {
+		requeued = append(requeued, scanRequest)
+	}
+
+	originalAuditRoleName := auditRoleName
+	auditRoleName = "PantherAuditRole-us-west-2"
+	t.Cleanup(func() {
+		checkpointClient = nil
+		requeueFunc = utils.Requeue
+		auditRoleName = originalAuditRoleName
+	})
+	return mockDynamo, &requeued
+}
+
+func resourceIDs(resources []*apimodels.AddResourceEntry) []string {
+	ids := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		ids = append(ids, string(resource.ID))
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestPlanWorkUnits(t *testing.T) {
+	registerTestPollers(t)
+
+	units := planWorkUnits([]string{testRegionalType, testGlobalType}, awstest.ExampleRegions)
+	require.Len(t, units, 4)
+	assert.Equal(t, &workUnit{ResourceType: testGlobalType, Region: awsmodels.GlobalRegion}, units[0])
+	assert.Equal(t, &workUnit{ResourceType: testRegionalType, Region: "ap-southeast-2"}, units[1])
+	assert.Equal(t, &workUnit{ResourceType: testRegionalType, Region: "eu-central-1"}, units[2])
+	assert.Equal(t, &workUnit{ResourceType: testRegionalType, Region: "us-west-2"}, units[3])
+	assert.Equal(t, "Test.Regional#us-west-2", units[3].id())
+}
+
+func TestPollWorkUnits(t *testing.T) {
+	calls := registerTestPollers(t)
+
+	input := &awsmodels.ResourcePollerInput{IntegrationID: &testScanIntegrationID, Regions: awstest.ExampleRegions}
+	resources, err := pollWorkUnits(input, planWorkUnits(
+		[]string{testRegionalType, testGlobalType, testPagedType}, []*string{aws.String("us-west-2"), aws.String("eu-central-1")}))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"global-ap-southeast-2,eu-central-1,us-west-2",
+		"paged-eu-central-1-",
+		"paged-eu-central-1-2",
+		"paged-eu-central-1-3",
+		"paged-us-west-2-",
+		"paged-us-west-2-2",
+		"paged-us-west-2-3",
+		"regional-eu-central-1",
+		"regional-us-west-2",
+	}, resourceIDs(resources))
+	assert.Len(t, calls.pageTokens, 6)
+}
+
+func TestPollWorkUnitsError(t *testing.T) {
+	registerTestPollers(t)
+
+	input := &awsmodels.ResourcePollerInput{IntegrationID: &testScanIntegrationID, Regions: awstest.ExampleRegions}
+	resources, err := pollWorkUnits(input, planWorkUnits([]string{testRegionalType, testFailingType}, awstest.ExampleRegions))
+
+	require.Error(t, err)
+	assert.Equal(t, "3 of 6 work units failed", err.Error())
+	assert.Len(t, resources, 3)
+}
+
+func TestScan(t *testing.T) {
+	calls := registerTestPollers(t)
+	mockDynamo, requeued := setupScanMocks(t)
+	mockDynamo.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{}, nil).Once()
+	mockDynamo.On("BatchWriteItem", mock.Anything).Return(&dynamodb.BatchWriteItemOutput{}, nil).Once()
+	mockDynamo.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil)
+
+	var resources []*apimodels.AddResourceEntry
+	var finished []*FinishedUnit
+	var finishedLock sync.Mutex
+	start := time.Now()
+	err := Scan(context.Background(), &pollermodels.ScanEntry{
+		AWSAccountID:  aws.String("123456789012"),
+		IntegrationID: &testScanIntegrationID,
+		ResourceType:  aws.String(testPagedType),
+		ScanID:        aws.String("scan-1"),
+	}, func(unitResources []*apimodels.AddResourceEntry) error {
+		resources = append(resources, unitResources...)
+		return nil
+	}, func(unit *FinishedUnit) error {
+		finishedLock.Lock()
+		defer finishedLock.Unlock()
+		finished = append(finished, unit)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, resources, 9)
+	assert.Len(t, calls.pageTokens, 9)
+	assert.Empty(t, *requeued)
+
+	// Every finished work unit is reconciled with the IDs of all its pages
+	require.Len(t, finished, 3)
+	sort.Slice(finished, func(i, j int) bool { return finished[i].Region < finished[j].Region })
+	assert.Equal(t, "us-west-2", finished[2].Region)
+	assert.Equal(t, testPagedType, finished[2].ResourceType)
+	assert.Equal(t, map[string]struct{}{
+		"paged-us-west-2-": {}, "paged-us-west-2-2": {}, "paged-us-west-2-3": {},
+	}, finished[2].ResourceIDs)
+	assert.False(t, finished[2].StartedAt.Before(start))
+
+	// A pending checkpoint is created for the work unit of each region
+	batchInput := mockDynamo.Calls[1].Arguments.Get(0).(*dynamodb.BatchWriteItemInput)
+	assert.Len(t, batchInput.RequestItems[checkpointTable], 3)
+
+	// Each work unit is claimed, checkpointed after the first two pages and marked done
+	mockDynamo.AssertNumberOfCalls(t, "UpdateItem", 12)
+	mockDynamo.AssertExpectations(t)
+}
+
+func TestScanResume(t *testing.T) {
+	calls := registerTestPollers(t)
+	mockDynamo, requeued := setupScanMocks(t)
+
+	items, err := dynamodbattribute.MarshalList([]*checkpoint{
+		{ScanID: "scan-1", UnitID: "Test.Paged#us-west-2", ResourceType: testPagedType, Region: "us-west-2", Status: checkpointDone},
+		{ScanID: "scan-1", UnitID: "Test.Paged#eu-central-1", ResourceType: testPagedType, Region: "eu-central-1", Status: checkpointPending},
+	})
+	require.NoError(t, err)
+	queryOutput := &dynamodb.QueryOutput{}
+	for _, item := range items {
+		queryOutput.Items = append(queryOutput.Items, item.M)
+	}
+	claimed, err := dynamodbattribute.MarshalMap(&checkpoint{
+		ScanID: "scan-1", UnitID: "Test.Paged#eu-central-1", PageToken: aws.String("3"), Status: checkpointRunning,
+		StartedAt: 1596240000,
+	})
+	require.NoError(t, err)
+
+	mockDynamo.On("Query", mock.Anything).Return(queryOutput, nil).Once()
+	mockDynamo.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{Attributes: claimed}, nil)
+
+	var resources []*apimodels.AddResourceEntry
+	var finished []*FinishedUnit
+	err = Scan(context.Background(), &pollermodels.ScanEntry{
+		AWSAccountID:  aws.String("123456789012"),
+		IntegrationID: &testScanIntegrationID,
+		ResourceType:  aws.String(testPagedType),
+		ScanID:        aws.String("scan-1"),
+	}, func(unitResources []*apimodels.AddResourceEntry) error {
+		resources = append(resources, unitResources...)
+		return nil
+	}, func(unit *FinishedUnit) error {
+		finished = append(finished, unit)
+		return nil
+	})
+
+	// Only the last page of the pending work unit is polled
+	require.NoError(t, err)
+	assert.Equal(t, []string{"paged-eu-central-1-3"}, resourceIDs(resources))
+	assert.Equal(t, []string{"3"}, calls.pageTokens)
+
+	// The reconciler sees when the first page was polled by the earlier invocation
+	require.Len(t, finished, 1)
+	assert.Equal(t, map[string]struct{}{"paged-eu-central-1-3": {}}, finished[0].ResourceIDs)
+	assert.Equal(t, time.Unix(1596240000, 0), finished[0].StartedAt)
+	assert.Empty(t, *requeued)
+	mockDynamo.AssertNotCalled(t, "BatchWriteItem", mock.Anything)
+	mockDynamo.AssertNumberOfCalls(t, "UpdateItem", 2)
+}
+
+func TestScanDeadlineRequeues(t *testing.T) {
+	calls := registerTestPollers(t)
+	mockDynamo, requeued := setupScanMocks(t)
+	mockDynamo.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{}, nil).Once()
+	mockDynamo.On("BatchWriteItem", mock.Anything).Return(&dynamodb.BatchWriteItemOutput{}, nil).Once()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	scanRequest := &pollermodels.ScanEntry{
+		AWSAccountID:  aws.String("123456789012"),
+		IntegrationID: &testScanIntegrationID,
+		ResourceType:  aws.String(testPagedType),
+		ScanID:        aws.String("scan-1"),
+	}
+	err := Scan(ctx, scanRequest, func(unitResources []*apimodels.AddResourceEntry) error {
+		t.Fatal("no resources should be polled this close to the deadline")
+		return nil
+	}, nil)
+
+	require.NoError(t, err)
+	assert.Empty(t, calls.pageTokens)
+	require.Len(t, *requeued, 1)
+	assert.Equal(t, []*pollermodels.ScanEntry{scanRequest}, (*requeued)[0].Entries)
+	mockDynamo.AssertNotCalled(t, "UpdateItem", mock.Anything)
+}
+
+func TestScanFailedWorkUnits(t *testing.T) {
+	registerTestPollers(t)
+	mockDynamo, requeued := setupScanMocks(t)
+	mockDynamo.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{}, nil).Once()
+	mockDynamo.On("BatchWriteItem", mock.Anything).Return(&dynamodb.BatchWriteItemOutput{}, nil).Once()
+	mockDynamo.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil)
+
+	err := Scan(context.Background(), &pollermodels.ScanEntry{
+		AWSAccountID:  aws.String("123456789012"),
+		IntegrationID: &testScanIntegrationID,
+		ResourceType:  aws.String(testFailingType),
+		ScanID:        aws.String("scan-1"),
+	}, func(unitResources []*apimodels.AddResourceEntry) error { return nil }, func(unit *FinishedUnit) error {
+		t.Fatal("failed work units should not be reconciled")
+		return nil
+	})
+
+	require.Error(t, err)
+	assert.Equal(t, "3 work units of scan scan-1 failed", err.Error())
+	assert.Empty(t, *requeued)
+
+	// Failed work units are not retried by later invocations
+	update := mockDynamo.Calls[len(mockDynamo.Calls)-1].Arguments.Get(0).(*dynamodb.UpdateItemInput)
+	var values []string
+	for _, value := range update.ExpressionAttributeValues {
+		values = append(values, aws.StringValue(value.S))
+	}
+	assert.Contains(t, values, checkpointFailed)
+}
+
+func TestScanSkipsReconcilingUnitsWithErrors(t *testing.T) {
+	registerTestPollers(t)
+	mockDynamo, requeued := setupScanMocks(t)
+	mockDynamo.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{}, nil).Once()
+	mockDynamo.On("BatchWriteItem", mock.Anything).Return(&dynamodb.BatchWriteItemOutput{}, nil).Once()
+	mockDynamo.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil)
+
+	var resources []*apimodels.AddResourceEntry
+	err := Scan(context.Background(), &pollermodels.ScanEntry{
+		AWSAccountID:  aws.String("123456789012"),
+		IntegrationID: &testScanIntegrationID,
+		ResourceType:  aws.String(testSkippingType),
+		ScanID:        aws.String("scan-1"),
+	}, func(unitResources []*apimodels.AddResourceEntry) error {
+		resources = append(resources, unitResources...)
+		return nil
+	}, func(unit *FinishedUnit) error {
+		t.Fatal("work units with polling errors should not be reconciled")
+		return nil
+	})
+
+	// The units still finish, and the resources that were polled are sent
+	require.NoError(t, err)
+	assert.Len(t, resources, 3)
+	assert.Empty(t, *requeued)
+
+	update := mockDynamo.Calls[len(mockDynamo.Calls)-1].Arguments.Get(0).(*dynamodb.UpdateItemInput)
+	var values []string
+	for _, value := range update.ExpressionAttributeValues {
+		values = append(values, aws.StringValue(value.S), aws.StringValue(value.N))
+	}
+	assert.Contains(t, values, checkpointDone)
+	assert.Contains(t, values, "1")
+}
+
+func TestScanResumeWithEarlierErrors(t *testing.T) {
+	registerTestPollers(t)
+	mockDynamo, _ := setupScanMocks(t)
+
+	items, err := dynamodbattribute.MarshalList([]*checkpoint{
+		{ScanID: "scan-1", UnitID: "Test.Paged#us-west-2", ResourceType: testPagedType, Region: "us-west-2", Status: checkpointDone},
+		{ScanID: "scan-1", UnitID: "Test.Paged#eu-central-1", ResourceType: testPagedType, Region: "eu-central-1", Status: checkpointPending},
+	})
+	require.NoError(t, err)
+	queryOutput := &dynamodb.QueryOutput{}
+	for _, item := range items {
+		queryOutput.Items = append(queryOutput.Items, item.M)
+	}
+	// An earlier invocation logged an error while polling the first pages
+	claimed, err := dynamodbattribute.MarshalMap(&checkpoint{
+		ScanID: "scan-1", UnitID: "Test.Paged#eu-central-1", PageToken: aws.String("3"), Status: checkpointRunning,
+		StartedAt: 1596240000, PollErrors: 1,
+	})
+	require.NoError(t, err)
+
+	mockDynamo.On("Query", mock.Anything).Return(queryOutput, nil).Once()
+	mockDynamo.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{Attributes: claimed}, nil)
+
+	err = Scan(context.Background(), &pollermodels.ScanEntry{
+		AWSAccountID:  aws.String("123456789012"),
+		IntegrationID: &testScanIntegrationID,
+		ResourceType:  aws.String(testPagedType),
+		ScanID:        aws.String("scan-1"),
+	}, func(unitResources []*apimodels.AddResourceEntry) error { return nil }, func(unit *FinishedUnit) error {
+		t.Fatal("work units with polling errors in earlier invocations should not be reconciled")
+		return nil
+	})
+
+	require.NoError(t, err)
+	mockDynamo.AssertNumberOfCalls(t, "UpdateItem", 2)
+}
+
+func TestScanMissingScanID(t *testing.T) {
+	err := Scan(context.Background(), &pollermodels.ScanEntry{
+		AWSAccountID:     aws.String("123456789012"),
+		IntegrationID:    &testScanIntegrationID,
+		ScanAllResources: aws.Bool(true),
+	}, nil, nil)
+	require.Error(t, err)
+}
+
+func TestClaimCheckpointHeldElsewhere(t *testing.T) {
+	mockDynamo := &testutils.DynamoDBMock{}
+	checkpointClient = mockDynamo
+	defer func() { checkpointClient = nil }()
+
+	mockDynamo.On("UpdateItem", mock.Anything).Return(
+		&dynamodb.UpdateItemOutput{},
+		awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "the conditional request failed", nil),
+	).Once()
+
+	claimed, err := claimCheckpoint(
+		"scan-1", &workUnit{ResourceType: testPagedType, Region: "us-west-2"}, "owner", time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.False(t, claimed)
+	mockDynamo.AssertExpectations(t)
+}
@@ -0,0 +1,363 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Resource pollers only need read access
+	readOnlyScope = "https://www.googleapis.com/auth/cloud-platform.read-only"
+	// The lifetime requested for access tokens, which is also the maximum GCP allows
+	tokenLifetime = time.Hour
+	// Tokens are refreshed when they have less than this left before expiring
+	tokenExpiryMargin = time.Minute
+
+	httpTimeout = time.Minute
+)
+
+// The GCP REST endpoints, overridden in unit tests
+var (
+	// Access tokens are only ever requested from Google, whatever token_uri the key file declares
+	tokenEndpoint = "https://oauth2.googleapis.com/token"
+
+	cloudResourceManagerEndpoint = "https://cloudresourcemanager.googleapis.com/v1"
+	cloudKMSEndpoint             = "https://cloudkms.googleapis.com/v1"
+	computeEndpoint              = "https://compute.googleapis.com/compute/v1"
+	iamEndpoint                  = "https://iam.googleapis.com/v1"
+	sqlAdminEndpoint             = "https://sqladmin.googleapis.com/sql/v1beta4"
+	storageEndpoint              = "https://storage.googleapis.com/storage/v1"
+)
+
+// serviceAccountKey is the JSON key file of a GCP service account.
+type serviceAccountKey struct {
+	Type         string `json:"type"`
+	ProjectID    string `json:"project_id"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	ClientEmail  string `json:"client_email"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// Client calls the GCP REST APIs as a service account.
+//
+// There are no GCP client libraries in this module, so the OAuth 2.0 JWT bearer flow is done by hand.
+type Client struct {
+	httpClient *http.Client
+	key        *serviceAccountKey
+	privateKey *rsa.PrivateKey
+
+	accessToken string
+	tokenExpiry time.Time
+}
+
+// APIError is a non-2xx response from a GCP API.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("GCP API returned %d: %s", e.StatusCode, e.Message)
+}
+
+// isForbidden is true if the API is disabled in the project or the service account lacks permissions.
+func isForbidden(err error) bool {
+	apiErr, ok := errors.Cause(err).(*APIError)
+	return ok && apiErr.StatusCode == http.StatusForbidden
+}
+
+// NewClient builds a Client from the contents of a service account JSON key file.
+func NewClient(keyJSON []byte) (*Client, error) {
+	key := &serviceAccountKey{}
+	if err := jsoniter.Unmarshal(keyJSON, key); err != nil {
+		return nil, errors.Wrap(err, "invalid service account key")
+	}
+	if key.Type != "service_account" || key.ClientEmail == "" {
+		return nil, errors.New("invalid service account key: expected a service_account key with a client_email")
+	}
+	// The signed JWT would be sent to this URI, so the key file must not point it anywhere else
+	if key.TokenURI != "" && key.TokenURI != tokenEndpoint {
+		return nil, errors.New("invalid service account key: token_uri must be " + tokenEndpoint)
+	}
+
+	privateKey, err := parsePrivateKey(key.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		httpClient: &http.Client{Timeout: httpTimeout},
+		key:        key,
+		privateKey: privateKey,
+	}, nil
+}
+
+// parsePrivateKey decodes the PEM encoded RSA key of a service account.
+func parsePrivateKey(pemKey string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(pemKey))
+	if block == nil {
+		return nil, errors.New("invalid service account key: private_key is not PEM encoded")
+	}
+
+	// GCP issues PKCS8 keys, PKCS1 is accepted for keys converted by other tools
+	if parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		if privateKey, ok := parsed.(*rsa.PrivateKey); ok {
+			return privateKey, nil
+		}
+		return nil, errors.New("invalid service account key: private_key is not an RSA key")
+	}
+	privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid service account key: failed to parse private_key")
+	}
+	return privateKey, nil
+}
+
+// token returns a cached access token, exchanging a new signed JWT for one if needed.
+func (c *Client) token() (string, error) {
+	if c.accessToken != "" && time.Now().Add(tokenExpiryMargin).Before(c.tokenExpiry) {
+		return c.accessToken, nil
+	}
+
+	assertion, err := c.signedJWT(time.Now())
+	if err != nil {
+		return "", err
+	}
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	response, err := c.httpClient.PostForm(tokenEndpoint, form)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to request GCP access token")
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read GCP access token")
+	}
+	if response.StatusCode != http.StatusOK {
+		return "", errors.Wrap(&APIError{StatusCode: response.StatusCode, Message: string(body)},
+			"failed to exchange service account JWT")
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err = jsoniter.Unmarshal(body, &result); err != nil || result.AccessToken == "" {
+		return "", errors.New("invalid GCP access token response")
+	}
+
+	c.accessToken = result.AccessToken
+	c.tokenExpiry = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
+	return c.accessToken, nil
+}
+
+// signedJWT builds the RS256 signed assertion for the token endpoint.
+func (c *Client) signedJWT(now time.Time) (string, error) {
+	header, err := jsoniter.Marshal(map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+		"kid": c.key.PrivateKeyID,
+	})
+	if err != nil {
+		return "", err
+	}
+	claims, err := jsoniter.Marshal(map[string]interface{}{
+		"iss":   c.key.ClientEmail,
+		"scope": readOnlyScope,
+		"aud":   tokenEndpoint,
+		"iat":   now.Unix(),
+		"exp":   now.Add(tokenLifetime).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	encoding := base64.RawURLEncoding
+	unsigned := encoding.EncodeToString(header) + "." + encoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, c.privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign service account JWT")
+	}
+	return unsigned + "." + encoding.EncodeToString(signature), nil
+}
+
+// get unmarshals the response of a GET request into out.
+func (c *Client) get(requestURL string, out interface{}) error {
+	return c.do(http.MethodGet, requestURL, nil, out)
+}
+
+// post unmarshals the response of a POST request with a JSON body into out.
+func (c *Client) post(requestURL string, body interface{}, out interface{}) error {
+	return c.do(http.MethodPost, requestURL, body, out)
+}
+
+func (c *Client) do(method, requestURL string, body interface{}, out interface{}) error {
+	token, err := c.token()
+	if err != nil {
+		return err
+	}
+
+	var requestBody []byte
+	if body != nil {
+		if requestBody, err = jsoniter.Marshal(body); err != nil {
+			return err
+		}
+	}
+	request, err := http.NewRequest(method, requestURL, bytes.NewReader(requestBody))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", "Bearer "+token)
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return errors.Wrapf(err, "%s %s failed", method, requestURL)
+	}
+	defer response.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return errors.Wrapf(err, "%s %s failed", method, requestURL)
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return errors.Wrapf(&APIError{StatusCode: response.StatusCode, Message: errorMessage(responseBody)},
+			"%s %s failed", method, requestURL)
+	}
+	return errors.Wrapf(jsoniter.Unmarshal(responseBody, out), "%s %s returned invalid JSON", method, requestURL)
+}
+
+// errorMessage extracts the message from the standard GCP error body.
+func errorMessage(body []byte) string {
+	var result struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := jsoniter.Unmarshal(body, &result); err != nil || result.Error.Message == "" {
+		return strings.TrimSpace(string(body))
+	}
+	return result.Error.Message
+}
+
+// list follows nextPageToken and returns the elements of the itemsKey array across all pages.
+func (c *Client) list(requestURL string, itemsKey string) ([]jsoniter.RawMessage, error) {
+	var items []jsoniter.RawMessage
+	err := c.pages(requestURL, func(page map[string]jsoniter.RawMessage) error {
+		raw, ok := page[itemsKey]
+		if !ok {
+			return nil
+		}
+		var pageItems []jsoniter.RawMessage
+		if err := jsoniter.Unmarshal(raw, &pageItems); err != nil {
+			return errors.Wrapf(err, "GET %s returned invalid %s", requestURL, itemsKey)
+		}
+		items = append(items, pageItems...)
+		return nil
+	})
+	return items, err
+}
+
+// listAggregated returns the elements of the itemsKey array in each scope (zone or region) of a
+// Compute Engine aggregatedList response.
+func (c *Client) listAggregated(requestURL string, itemsKey string) ([]jsoniter.RawMessage, error) {
+	var items []jsoniter.RawMessage
+	err := c.pages(requestURL, func(page map[string]jsoniter.RawMessage) error {
+		raw, ok := page["items"]
+		if !ok {
+			return nil
+		}
+		// Scopes without resources only hold a warning
+		var scopes map[string]map[string]jsoniter.RawMessage
+		if err := jsoniter.Unmarshal(raw, &scopes); err != nil {
+			return errors.Wrapf(err, "GET %s returned invalid items", requestURL)
+		}
+		for scope, scopeItems := range scopes {
+			scopeRaw, ok := scopeItems[itemsKey]
+			if !ok {
+				continue
+			}
+			var pageItems []jsoniter.RawMessage
+			if err := jsoniter.Unmarshal(scopeRaw, &pageItems); err != nil {
+				return errors.Wrapf(err, "GET %s returned invalid %s in %s", requestURL, itemsKey, scope)
+			}
+			items = append(items, pageItems...)
+		}
+		return nil
+	})
+	return items, err
+}
+
+// pages calls handler with each page of a list request, following nextPageToken.
+func (c *Client) pages(requestURL string, handler func(page map[string]jsoniter.RawMessage) error) error {
+	pageToken := ""
+	for {
+		pageURL := requestURL
+		if pageToken != "" {
+			separator := "?"
+			if strings.Contains(requestURL, "?") {
+				separator = "&"
+			}
+			pageURL += separator + "pageToken=" + url.QueryEscape(pageToken)
+		}
+
+		var page map[string]jsoniter.RawMessage
+		if err := c.get(pageURL, &page); err != nil {
+			return err
+		}
+		if err := handler(page); err != nil {
+			return err
+		}
+
+		pageToken = ""
+		if raw, ok := page["nextPageToken"]; ok {
+			if err := jsoniter.Unmarshal(raw, &pageToken); err != nil {
+				return errors.Wrapf(err, "GET %s returned invalid nextPageToken", pageURL)
+			}
+		}
+		if pageToken == "" {
+			return nil
+		}
+	}
+}
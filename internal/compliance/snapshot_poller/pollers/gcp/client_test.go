@@ -0,0 +1,300 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testProjectID   = "example-project"
+	testAccessToken = "ya29.test-access-token"
+)
+
+// testServer replays the recorded GCP API responses in testdata.
+type testServer struct {
+	*httptest.Server
+	privateKey *rsa.PrivateKey
+	// Maps "METHOD /path" (with "?pageToken=..." for later pages) to a file in testdata
+	routes map[string]string
+	// Maps "METHOD /path" to a status code to return instead of a recorded response
+	statusCodes   map[string]int
+	tokenRequests int
+}
+
+// defaultRoutes serve one recorded response per API call made by the pollers.
+var defaultRoutes = map[string]string{
+	"GET /crm/projects/example-project":               "project.json",
+	"POST /crm/projects/example-project:getIamPolicy": "project_iam_policy.json",
+	"GET /storage/b": "storage_buckets_page1.json",
+	"GET /storage/b?pageToken=CgxleGFtcGxlLWxvZ3M=":                                                               "storage_buckets_page2.json",
+	"GET /storage/b/example-public/iam":                                                                           "storage_bucket_iam_policy.json",
+	"GET /storage/b/example-logs/iam":                                                                             "storage_bucket_iam_policy.json",
+	"GET /compute/projects/example-project/aggregated/instances":                                                  "compute_instances_aggregated.json",
+	"GET /compute/projects/example-project/global/firewalls":                                                      "compute_firewalls.json",
+	"GET /sql/projects/example-project/instances":                                                                 "sql_instances.json",
+	"GET /kms/projects/example-project/locations":                                                                 "kms_locations.json",
+	"GET /kms/projects/example-project/locations/global/keyRings":                                                 "kms_key_rings_global.json",
+	"GET /kms/projects/example-project/locations/us-east1/keyRings":                                               "kms_key_rings_us-east1.json",
+	"GET /kms/projects/example-project/locations/global/keyRings/app/cryptoKeys":                                  "kms_crypto_keys.json",
+	"GET /kms/projects/example-project/locations/global/keyRings/app/cryptoKeys/secrets:getIamPolicy":             "kms_crypto_key_iam_policy.json",
+	"GET /iam/projects/example-project/serviceAccounts":                                                           "iam_service_accounts.json",
+	"GET /iam/projects/example-project/serviceAccounts/app@example-project.iam.gserviceaccount.com/keys":          "iam_service_account_keys.json",
+	"POST /iam/projects/example-project/serviceAccounts/app@example-project.iam.gserviceaccount.com:getIamPolicy": "iam_service_account_iam_policy.json",
+}
+
+// newTestServer starts a fake token endpoint and GCP APIs, and points the pollers at them.
+func newTestServer(t *testing.T) *testServer {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	server := &testServer{
+		privateKey:  privateKey,
+		routes:      make(map[string]string, len(defaultRoutes)),
+		statusCodes: make(map[string]int),
+	}
+	for route, file := range defaultRoutes {
+		server.routes[route] = file
+	}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.handle))
+	t.Cleanup(server.Close)
+
+	endpoints := map[*string]string{
+		&tokenEndpoint:                "/token",
+		&cloudResourceManagerEndpoint: "/crm",
+		&cloudKMSEndpoint:             "/kms",
+		&computeEndpoint:              "/compute",
+		&iamEndpoint:                  "/iam",
+		&sqlAdminEndpoint:             "/sql",
+		&storageEndpoint:              "/storage",
+	}
+	for endpoint, path := range endpoints {
+		original := *endpoint
+		*endpoint = server.URL + path
+		endpoint := endpoint
+		t.Cleanup(func() { *endpoint = original })
+	}
+	return server
+}
+
+// serviceAccountKey returns a JSON key file for the test server's token endpoint.
+func (s *testServer) serviceAccountKey(t *testing.T) []byte {
+	privateKey, err := x509.MarshalPKCS8PrivateKey(s.privateKey)
+	require.NoError(t, err)
+	key, err := jsoniter.Marshal(&serviceAccountKey{
+		Type:         "service_account",
+		ProjectID:    testProjectID,
+		PrivateKeyID: "0123456789abcdef",
+		PrivateKey:   string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKey})),
+		ClientEmail:  "panther@example-project.iam.gserviceaccount.com",
+		TokenURI:     s.URL + "/token",
+	})
+	require.NoError(t, err)
+	return key
+}
+
+func (s *testServer) client(t *testing.T) *Client {
+	client, err := NewClient(s.serviceAccountKey(t))
+	require.NoError(t, err)
+	return client
+}
+
+func (s *testServer) pollerInput(t *testing.T) *ResourcePollerInput {
+	return &ResourcePollerInput{
+		Client:        s.client(t),
+		IntegrationID: "5a7d8f0e-3b1c-4a2e-9f6d-0c1b2a3d4e5f",
+		ProjectID:     testProjectID,
+	}
+}
+
+func (s *testServer) handle(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/token" {
+		s.handleToken(w, r)
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer "+testAccessToken {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	route := r.Method + " " + r.URL.Path
+	if code, ok := s.statusCodes[route]; ok {
+		w.WriteHeader(code)
+		_, _ = w.Write(readTestData("permission_denied.json"))
+		return
+	}
+	if pageToken := r.URL.Query().Get("pageToken"); pageToken != "" {
+		route += "?pageToken=" + pageToken
+	}
+	file, ok := s.routes[route]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error": {"code": 404, "message": "no recorded response for ` + route + `"}}`))
+		return
+	}
+	_, _ = w.Write(readTestData(file))
+}
+
+// handleToken verifies the signed JWT the same way the Google token endpoint does.
+func (s *testServer) handleToken(w http.ResponseWriter, r *http.Request) {
+	s.tokenRequests++
+	if err := r.ParseForm(); err != nil || r.Form.Get("grant_type") != "urn:ietf:params:oauth:grant-type:jwt-bearer" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	parts := strings.Split(r.Form.Get("assertion"), ".")
+	if len(parts) != 3 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if rsa.VerifyPKCS1v15(&s.privateKey.PublicKey, crypto.SHA256, digest[:], signature) != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error": "invalid_grant", "error_description": "Invalid JWT Signature."}`))
+		return
+	}
+
+	_, _ = w.Write([]byte(`{"access_token": "` + testAccessToken + `", "expires_in": 3599, "token_type": "Bearer"}`))
+}
+
+func readTestData(file string) []byte {
+	body, err := ioutil.ReadFile(filepath.Join("testdata", file))
+	if err != nil {
+		panic(err)
+	}
+	return body
+}
+
+func TestNewClientInvalidKey(t *testing.T) {
+	_, err := NewClient([]byte(`{"type": "authorized_user"}`))
+	assert.Error(t, err)
+
+	_, err = NewClient([]byte(`{"type": "service_account", "client_email": "a@b.com", "private_key": "not a key"}`))
+	assert.Error(t, err)
+
+	// The signed JWT is never sent to a token endpoint other than Google's
+	_, err = NewClient([]byte(`{"type": "service_account", "client_email": "a@b.com", "token_uri": "https://example.com/token"}`))
+	assert.EqualError(t, err, "invalid service account key: token_uri must be https://oauth2.googleapis.com/token")
+}
+
+func TestClientTokenIsCached(t *testing.T) {
+	server := newTestServer(t)
+	client := server.client(t)
+
+	token, err := client.token()
+	require.NoError(t, err)
+	assert.Equal(t, testAccessToken, token)
+
+	_, err = client.token()
+	require.NoError(t, err)
+	assert.Equal(t, 1, server.tokenRequests)
+
+	// Tokens about to expire are refreshed
+	client.tokenExpiry = time.Now().Add(30 * time.Second)
+	_, err = client.token()
+	require.NoError(t, err)
+	assert.Equal(t, 2, server.tokenRequests)
+}
+
+func TestClientTokenInvalidSignature(t *testing.T) {
+	server := newTestServer(t)
+	client := server.client(t)
+
+	// A key which does not match the one registered for the service account
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	client.privateKey = otherKey
+
+	_, err = client.token()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid JWT Signature")
+}
+
+func TestClientSignedJWTClaims(t *testing.T) {
+	server := newTestServer(t)
+	client := server.client(t)
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	assertion, err := client.signedJWT(now)
+	require.NoError(t, err)
+
+	parts := strings.Split(assertion, ".")
+	require.Len(t, parts, 3)
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	var claims map[string]interface{}
+	require.NoError(t, jsoniter.Unmarshal(claimsJSON, &claims))
+	assert.Equal(t, map[string]interface{}{
+		"iss":   "panther@example-project.iam.gserviceaccount.com",
+		"scope": readOnlyScope,
+		"aud":   server.URL + "/token",
+		"iat":   float64(now.Unix()),
+		"exp":   float64(now.Add(time.Hour).Unix()),
+	}, claims)
+}
+
+func TestClientListPages(t *testing.T) {
+	server := newTestServer(t)
+
+	items, err := server.client(t).list(storageEndpoint+"/b?project="+testProjectID, "items")
+	require.NoError(t, err)
+	assert.Len(t, items, 2)
+}
+
+func TestClientErrors(t *testing.T) {
+	server := newTestServer(t)
+	server.statusCodes["GET /sql/projects/example-project/instances"] = http.StatusForbidden
+	client := server.client(t)
+
+	_, err := client.list(sqlAdminEndpoint+"/projects/example-project/instances", "items")
+	require.Error(t, err)
+	assert.True(t, isForbidden(err))
+	assert.Contains(t, err.Error(), "Cloud SQL Admin API has not been used")
+
+	err = client.get(sqlAdminEndpoint+"/projects/other-project/instances", &struct{}{})
+	require.Error(t, err)
+	require.IsType(t, &APIError{}, errors.Cause(err))
+	assert.Equal(t, http.StatusNotFound, errors.Cause(err).(*APIError).StatusCode)
+	assert.False(t, isForbidden(err))
+}
@@ -0,0 +1,125 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+// computeFirewall is a compute.Firewall
+type computeFirewall struct {
+	Allowed               []*gcpmodels.ComputeFirewallRule    `json:"allowed"`
+	CreationTimestamp     *string                             `json:"creationTimestamp"`
+	Denied                []*gcpmodels.ComputeFirewallRule    `json:"denied"`
+	Description           *string                             `json:"description"`
+	DestinationRanges     []*string                           `json:"destinationRanges"`
+	Direction             *string                             `json:"direction"`
+	Disabled              *bool                               `json:"disabled"`
+	ID                    *string                             `json:"id"`
+	LogConfig             *gcpmodels.ComputeFirewallLogConfig `json:"logConfig"`
+	Name                  *string                             `json:"name"`
+	Network               *string                             `json:"network"`
+	Priority              *int64                              `json:"priority"`
+	SourceRanges          []*string                           `json:"sourceRanges"`
+	SourceServiceAccounts []*string                           `json:"sourceServiceAccounts"`
+	SourceTags            []*string                           `json:"sourceTags"`
+	TargetServiceAccounts []*string                           `json:"targetServiceAccounts"`
+	TargetTags            []*string                           `json:"targetTags"`
+}
+
+// computeFirewallResourceID is the full resource name of a firewall rule.
+func computeFirewallResourceID(projectID, name string) string {
+	return fmt.Sprintf("//compute.googleapis.com/projects/%s/global/firewalls/%s", projectID, name)
+}
+
+// listComputeFirewalls returns all firewall rules of a project.
+func listComputeFirewalls(client *Client, projectID string) ([]*computeFirewall, error) {
+	items, err := client.list(fmt.Sprintf("%s/projects/%s/global/firewalls", computeEndpoint, projectID), "items")
+	if err != nil {
+		return nil, err
+	}
+
+	firewalls := make([]*computeFirewall, 0, len(items))
+	for _, item := range items {
+		firewall := &computeFirewall{}
+		if err = jsoniter.Unmarshal(item, firewall); err != nil {
+			return nil, errors.Wrap(err, "invalid firewall")
+		}
+		firewalls = append(firewalls, firewall)
+	}
+	return firewalls, nil
+}
+
+// buildComputeFirewallSnapshot returns a complete snapshot of a firewall rule.
+func buildComputeFirewallSnapshot(firewall *computeFirewall, projectID string) *gcpmodels.ComputeFirewall {
+	return &gcpmodels.ComputeFirewall{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   aws.String(computeFirewallResourceID(projectID, *firewall.Name)),
+			ResourceType: aws.String(gcpmodels.ComputeFirewallSchema),
+			TimeCreated:  parseTime(firewall.CreationTimestamp),
+		},
+		GenericGCPResource: gcpmodels.GenericGCPResource{
+			ProjectID: aws.String(projectID),
+			Region:    aws.String(gcpmodels.GlobalRegion),
+			ID:        firewall.ID,
+			Name:      firewall.Name,
+			// Firewall rules do not support labels
+			Labels: labels(nil),
+		},
+		Allowed:               firewall.Allowed,
+		Denied:                firewall.Denied,
+		Description:           firewall.Description,
+		DestinationRanges:     firewall.DestinationRanges,
+		Direction:             firewall.Direction,
+		Disabled:              firewall.Disabled,
+		LogConfig:             firewall.LogConfig,
+		Network:               firewall.Network,
+		Priority:              firewall.Priority,
+		SourceRanges:          firewall.SourceRanges,
+		SourceServiceAccounts: firewall.SourceServiceAccounts,
+		SourceTags:            firewall.SourceTags,
+		TargetServiceAccounts: firewall.TargetServiceAccounts,
+		TargetTags:            firewall.TargetTags,
+	}
+}
+
+// PollComputeFirewalls gathers information on each VPC firewall rule of a GCP project.
+func PollComputeFirewalls(pollerInput *ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting GCP Compute Firewall resource poller")
+	firewalls, err := listComputeFirewalls(pollerInput.Client, pollerInput.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]*apimodels.AddResourceEntry, 0, len(firewalls))
+	for _, firewall := range firewalls {
+		snapshot := buildComputeFirewallSnapshot(firewall, pollerInput.ProjectID)
+		resources = append(resources, resourceEntry(pollerInput, *snapshot.ResourceID, gcpmodels.ComputeFirewallSchema, snapshot))
+	}
+	return resources, nil
+}
@@ -0,0 +1,53 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+func TestComputeFirewallPoller(t *testing.T) {
+	server := newTestServer(t)
+
+	resources, err := PollComputeFirewalls(server.pollerInput(t))
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+
+	assert.Equal(t,
+		apimodels.ResourceID("//compute.googleapis.com/projects/example-project/global/firewalls/default-allow-ssh"),
+		resources[0].ID)
+	firewall := resources[0].Attributes.(*gcpmodels.ComputeFirewall)
+	assert.Equal(t, aws.String(gcpmodels.GlobalRegion), firewall.Region)
+	assert.Equal(t, aws.String("default-allow-ssh"), firewall.Name)
+	assert.Equal(t, aws.String("INGRESS"), firewall.Direction)
+	assert.Equal(t, aws.Int64(65534), firewall.Priority)
+	assert.Equal(t, []*string{aws.String("0.0.0.0/0")}, firewall.SourceRanges)
+	assert.Equal(t, &gcpmodels.ComputeFirewallRule{
+		IPProtocol: aws.String("tcp"),
+		Ports:      []*string{aws.String("22")},
+	}, firewall.Allowed[0])
+	assert.False(t, *firewall.LogConfig.Enable)
+}
@@ -0,0 +1,163 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+// Instance metadata keys which control security features. All other metadata is dropped, since
+// startup scripts and SSH keys frequently contain secrets.
+var computeSecurityMetadataKeys = map[string]struct{}{
+	"block-project-ssh-keys":     {},
+	"enable-oslogin":             {},
+	"enable-oslogin-2fa":         {},
+	"serial-port-enable":         {},
+	"serial-port-logging-enable": {},
+}
+
+// computeInstance is a compute.Instance
+type computeInstance struct {
+	CanIPForward           *bool                                    `json:"canIpForward"`
+	CreationTimestamp      *string                                  `json:"creationTimestamp"`
+	DeletionProtection     *bool                                    `json:"deletionProtection"`
+	Disks                  []*gcpmodels.ComputeAttachedDisk         `json:"disks"`
+	ID                     *string                                  `json:"id"`
+	Labels                 map[string]*string                       `json:"labels"`
+	MachineType            *string                                  `json:"machineType"`
+	Metadata               *computeMetadata                         `json:"metadata"`
+	Name                   *string                                  `json:"name"`
+	NetworkInterfaces      []*gcpmodels.ComputeNetworkInterface     `json:"networkInterfaces"`
+	ServiceAccounts        []*gcpmodels.ComputeServiceAccount       `json:"serviceAccounts"`
+	ShieldedInstanceConfig *gcpmodels.ComputeShieldedInstanceConfig `json:"shieldedInstanceConfig"`
+	Status                 *string                                  `json:"status"`
+	Tags                   *computeTags                             `json:"tags"`
+	Zone                   *string                                  `json:"zone"`
+}
+
+type computeMetadata struct {
+	Items []*struct {
+		Key   string  `json:"key"`
+		Value *string `json:"value"`
+	} `json:"items"`
+}
+
+type computeTags struct {
+	Items []*string `json:"items"`
+}
+
+// computeInstanceResourceID is the full resource name of an instance.
+func computeInstanceResourceID(projectID, zone, name string) string {
+	return fmt.Sprintf("//compute.googleapis.com/projects/%s/zones/%s/instances/%s", projectID, zone, name)
+}
+
+// zoneRegion returns the region of a zone, e.g. us-central1 for us-central1-a.
+func zoneRegion(zone string) string {
+	if i := strings.LastIndex(zone, "-"); i > 0 {
+		return zone[:i]
+	}
+	return zone
+}
+
+// listComputeInstances returns all instances in all zones of a project.
+func listComputeInstances(client *Client, projectID string) ([]*computeInstance, error) {
+	items, err := client.listAggregated(fmt.Sprintf("%s/projects/%s/aggregated/instances", computeEndpoint, projectID), "instances")
+	if err != nil {
+		return nil, err
+	}
+
+	instances := make([]*computeInstance, 0, len(items))
+	for _, item := range items {
+		instance := &computeInstance{}
+		if err = jsoniter.Unmarshal(item, instance); err != nil {
+			return nil, errors.Wrap(err, "invalid instance")
+		}
+		instances = append(instances, instance)
+	}
+	return instances, nil
+}
+
+// buildComputeInstanceSnapshot returns a complete snapshot of an instance.
+func buildComputeInstanceSnapshot(instance *computeInstance, projectID string) *gcpmodels.ComputeInstance {
+	// The zone and machine type are returned as URLs
+	zone := aws.StringValue(lastSegment(instance.Zone))
+	snapshot := &gcpmodels.ComputeInstance{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   aws.String(computeInstanceResourceID(projectID, zone, *instance.Name)),
+			ResourceType: aws.String(gcpmodels.ComputeInstanceSchema),
+			TimeCreated:  parseTime(instance.CreationTimestamp),
+		},
+		GenericGCPResource: gcpmodels.GenericGCPResource{
+			ProjectID: aws.String(projectID),
+			Region:    aws.String(zoneRegion(zone)),
+			ID:        instance.ID,
+			Name:      instance.Name,
+			Labels:    labels(instance.Labels),
+		},
+		CanIPForward:           instance.CanIPForward,
+		DeletionProtection:     instance.DeletionProtection,
+		Disks:                  instance.Disks,
+		MachineType:            lastSegment(instance.MachineType),
+		NetworkInterfaces:      instance.NetworkInterfaces,
+		ServiceAccounts:        instance.ServiceAccounts,
+		ShieldedInstanceConfig: instance.ShieldedInstanceConfig,
+		Status:                 instance.Status,
+		Zone:                   aws.String(zone),
+		Metadata:               make(map[string]*string),
+	}
+
+	if instance.Tags != nil {
+		snapshot.NetworkTags = instance.Tags.Items
+	}
+	if instance.Metadata != nil {
+		for _, item := range instance.Metadata.Items {
+			if _, ok := computeSecurityMetadataKeys[item.Key]; ok {
+				snapshot.Metadata[item.Key] = item.Value
+			}
+		}
+	}
+
+	return snapshot
+}
+
+// PollComputeInstances gathers information on each Compute Engine instance of a GCP project.
+func PollComputeInstances(pollerInput *ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting GCP Compute Instance resource poller")
+	instances, err := listComputeInstances(pollerInput.Client, pollerInput.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]*apimodels.AddResourceEntry, 0, len(instances))
+	for _, instance := range instances {
+		snapshot := buildComputeInstanceSnapshot(instance, pollerInput.ProjectID)
+		resources = append(resources, resourceEntry(pollerInput, *snapshot.ResourceID, gcpmodels.ComputeInstanceSchema, snapshot))
+	}
+	return resources, nil
+}
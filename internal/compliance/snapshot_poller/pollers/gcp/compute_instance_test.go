@@ -0,0 +1,64 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+func TestComputeInstancePoller(t *testing.T) {
+	server := newTestServer(t)
+
+	resources, err := PollComputeInstances(server.pollerInput(t))
+	require.NoError(t, err)
+	// Zones without instances are skipped
+	require.Len(t, resources, 1)
+
+	assert.Equal(t,
+		apimodels.ResourceID("//compute.googleapis.com/projects/example-project/zones/us-central1-a/instances/web-1"),
+		resources[0].ID)
+	instance := resources[0].Attributes.(*gcpmodels.ComputeInstance)
+	assert.Equal(t, aws.String("us-central1"), instance.Region)
+	assert.Equal(t, aws.String("us-central1-a"), instance.Zone)
+	assert.Equal(t, aws.String("n1-standard-1"), instance.MachineType)
+	assert.Equal(t, aws.String("4567890123456789012"), instance.ID)
+	assert.Equal(t, []*string{aws.String("http-server")}, instance.NetworkTags)
+	assert.Equal(t, aws.String("203.0.113.10"), instance.NetworkInterfaces[0].AccessConfigs[0].NatIP)
+	assert.Equal(t, aws.String("10.128.0.2"), instance.NetworkInterfaces[0].NetworkIP)
+	assert.False(t, *instance.CanIPForward)
+	assert.True(t, *instance.ShieldedInstanceConfig.EnableVtpm)
+	assert.Equal(t, aws.String("https://www.googleapis.com/auth/cloud-platform"), instance.ServiceAccounts[0].Scopes[0])
+	assert.Equal(t, "2020-04-05T17:20:30.123Z", instance.TimeCreated.String())
+
+	// Startup scripts and SSH keys are never recorded
+	assert.Equal(t, map[string]*string{"enable-oslogin": aws.String("TRUE")}, instance.Metadata)
+}
+
+func TestZoneRegion(t *testing.T) {
+	assert.Equal(t, "us-central1", zoneRegion("us-central1-a"))
+	assert.Equal(t, "europe-west1", zoneRegion("europe-west1-b"))
+	assert.Equal(t, "global", zoneRegion("global"))
+}
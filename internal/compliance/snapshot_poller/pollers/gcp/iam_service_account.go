@@ -0,0 +1,147 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+// iamServiceAccount is an iam.ServiceAccount
+type iamServiceAccount struct {
+	Description *string `json:"description"`
+	Disabled    *bool   `json:"disabled"`
+	DisplayName *string `json:"displayName"`
+	Email       *string `json:"email"`
+	Name        *string `json:"name"`
+	UniqueID    *string `json:"uniqueId"`
+}
+
+// iamServiceAccountResourceID is the full resource name of a service account.
+func iamServiceAccountResourceID(projectID, email string) string {
+	return fmt.Sprintf("//iam.googleapis.com/projects/%s/serviceAccounts/%s", projectID, email)
+}
+
+// listIAMServiceAccounts returns all service accounts of a project.
+func listIAMServiceAccounts(client *Client, projectID string) ([]*iamServiceAccount, error) {
+	items, err := client.list(fmt.Sprintf("%s/projects/%s/serviceAccounts", iamEndpoint, projectID), "accounts")
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]*iamServiceAccount, 0, len(items))
+	for _, item := range items {
+		account := &iamServiceAccount{}
+		if err = jsoniter.Unmarshal(item, account); err != nil {
+			return nil, errors.Wrap(err, "invalid service account")
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
+
+// listIAMServiceAccountKeys returns the metadata of the keys of a service account.
+func listIAMServiceAccountKeys(client *Client, name string) ([]*gcpmodels.IAMServiceAccountKey, error) {
+	items, err := client.list(fmt.Sprintf("%s/%s/keys", iamEndpoint, name), "keys")
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*gcpmodels.IAMServiceAccountKey, 0, len(items))
+	for _, item := range items {
+		key := &gcpmodels.IAMServiceAccountKey{}
+		if err = jsoniter.Unmarshal(item, key); err != nil {
+			return nil, errors.Wrap(err, "invalid service account key")
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// getIAMServiceAccountIAMPolicy returns the policy listing who can act as a service account.
+func getIAMServiceAccountIAMPolicy(client *Client, name string) (*gcpmodels.IAMPolicy, error) {
+	policy := &gcpmodels.IAMPolicy{}
+	if err := client.post(fmt.Sprintf("%s/%s:getIamPolicy", iamEndpoint, name), struct{}{}, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// buildIAMServiceAccountSnapshot returns a complete snapshot of a service account.
+func buildIAMServiceAccountSnapshot(client *Client, account *iamServiceAccount, projectID string) *gcpmodels.IAMServiceAccount {
+	snapshot := &gcpmodels.IAMServiceAccount{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   aws.String(iamServiceAccountResourceID(projectID, *account.Email)),
+			ResourceType: aws.String(gcpmodels.IAMServiceAccountSchema),
+		},
+		GenericGCPResource: gcpmodels.GenericGCPResource{
+			ProjectID: aws.String(projectID),
+			Region:    aws.String(gcpmodels.GlobalRegion),
+			ID:        account.UniqueID,
+			Name:      account.Email,
+			// Service accounts do not support labels
+			Labels: labels(nil),
+		},
+		Description: account.Description,
+		Disabled:    account.Disabled,
+		DisplayName: account.DisplayName,
+		Email:       account.Email,
+		UniqueID:    account.UniqueID,
+	}
+
+	keys, err := listIAMServiceAccountKeys(client, *account.Name)
+	if err != nil {
+		zap.L().Warn("unable to list service account keys", zap.String("serviceAccount", *account.Email), zap.Error(err))
+	} else {
+		snapshot.Keys = keys
+	}
+
+	policy, err := getIAMServiceAccountIAMPolicy(client, *account.Name)
+	if err != nil {
+		zap.L().Warn("unable to get service account IAM policy", zap.String("serviceAccount", *account.Email), zap.Error(err))
+	} else {
+		snapshot.IAMPolicy = policy
+	}
+
+	return snapshot
+}
+
+// PollIAMServiceAccounts gathers information on each service account of a GCP project.
+func PollIAMServiceAccounts(pollerInput *ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting GCP IAM Service Account resource poller")
+	accounts, err := listIAMServiceAccounts(pollerInput.Client, pollerInput.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]*apimodels.AddResourceEntry, 0, len(accounts))
+	for _, account := range accounts {
+		snapshot := buildIAMServiceAccountSnapshot(pollerInput.Client, account, pollerInput.ProjectID)
+		resources = append(resources, resourceEntry(pollerInput, *snapshot.ResourceID, gcpmodels.IAMServiceAccountSchema, snapshot))
+	}
+	return resources, nil
+}
@@ -0,0 +1,51 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+func TestIAMServiceAccountPoller(t *testing.T) {
+	server := newTestServer(t)
+
+	resources, err := PollIAMServiceAccounts(server.pollerInput(t))
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+
+	assert.Equal(t,
+		apimodels.ResourceID("//iam.googleapis.com/projects/example-project/serviceAccounts/app@example-project.iam.gserviceaccount.com"),
+		resources[0].ID)
+	account := resources[0].Attributes.(*gcpmodels.IAMServiceAccount)
+	assert.Equal(t, aws.String("112233445566778899001"), account.ID)
+	assert.Equal(t, aws.String("app@example-project.iam.gserviceaccount.com"), account.Name)
+	assert.Equal(t, aws.String("Application"), account.DisplayName)
+	require.Len(t, account.Keys, 2)
+	assert.Equal(t, aws.String("USER_MANAGED"), account.Keys[0].KeyType)
+	assert.Equal(t, aws.String("9999-12-31T23:59:59Z"), account.Keys[0].ValidBeforeTime)
+	require.NotNil(t, account.IAMPolicy)
+	assert.Equal(t, []*string{aws.String("group:developers@example.com")}, account.IAMPolicy.Bindings[0].Members)
+}
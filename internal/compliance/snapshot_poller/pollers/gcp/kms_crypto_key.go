@@ -0,0 +1,181 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+// kmsCryptoKey is a cloudkms.CryptoKey
+type kmsCryptoKey struct {
+	CreateTime       *string                                `json:"createTime"`
+	Labels           map[string]*string                     `json:"labels"`
+	Name             *string                                `json:"name"`
+	NextRotationTime *string                                `json:"nextRotationTime"`
+	Primary          *gcpmodels.KMSCryptoKeyVersion         `json:"primary"`
+	Purpose          *string                                `json:"purpose"`
+	RotationPeriod   *string                                `json:"rotationPeriod"`
+	VersionTemplate  *gcpmodels.KMSCryptoKeyVersionTemplate `json:"versionTemplate"`
+}
+
+// kmsCryptoKeyResourceID is the full resource name of a crypto key, whose name is of the form
+// projects/*/locations/*/keyRings/*/cryptoKeys/*
+func kmsCryptoKeyResourceID(name string) string {
+	return "//cloudkms.googleapis.com/" + name
+}
+
+// listKMSLocations returns the IDs of the locations where Cloud KMS is available to a project.
+func listKMSLocations(client *Client, projectID string) ([]string, error) {
+	items, err := client.list(fmt.Sprintf("%s/projects/%s/locations", cloudKMSEndpoint, projectID), "locations")
+	if err != nil {
+		return nil, err
+	}
+
+	locations := make([]string, 0, len(items))
+	for _, item := range items {
+		var location struct {
+			LocationID string `json:"locationId"`
+		}
+		if err = jsoniter.Unmarshal(item, &location); err != nil {
+			return nil, errors.Wrap(err, "invalid KMS location")
+		}
+		locations = append(locations, location.LocationID)
+	}
+	return locations, nil
+}
+
+// listKMSKeyRings returns the names of the key rings in a location.
+func listKMSKeyRings(client *Client, projectID, location string) ([]string, error) {
+	items, err := client.list(fmt.Sprintf("%s/projects/%s/locations/%s/keyRings", cloudKMSEndpoint, projectID, location), "keyRings")
+	if err != nil {
+		return nil, err
+	}
+
+	keyRings := make([]string, 0, len(items))
+	for _, item := range items {
+		var keyRing struct {
+			Name string `json:"name"`
+		}
+		if err = jsoniter.Unmarshal(item, &keyRing); err != nil {
+			return nil, errors.Wrap(err, "invalid KMS key ring")
+		}
+		keyRings = append(keyRings, keyRing.Name)
+	}
+	return keyRings, nil
+}
+
+// listKMSCryptoKeys returns the crypto keys of a key ring.
+func listKMSCryptoKeys(client *Client, keyRing string) ([]*kmsCryptoKey, error) {
+	items, err := client.list(fmt.Sprintf("%s/%s/cryptoKeys", cloudKMSEndpoint, keyRing), "cryptoKeys")
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*kmsCryptoKey, 0, len(items))
+	for _, item := range items {
+		key := &kmsCryptoKey{}
+		if err = jsoniter.Unmarshal(item, key); err != nil {
+			return nil, errors.Wrap(err, "invalid KMS crypto key")
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// getKMSCryptoKeyIAMPolicy returns the IAM policy of a crypto key.
+func getKMSCryptoKeyIAMPolicy(client *Client, name string) (*gcpmodels.IAMPolicy, error) {
+	policy := &gcpmodels.IAMPolicy{}
+	requestURL := fmt.Sprintf("%s/%s:getIamPolicy?options.requestedPolicyVersion=%d", cloudKMSEndpoint, name, iamPolicyVersion)
+	if err := client.get(requestURL, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// buildKMSCryptoKeySnapshot returns a complete snapshot of a crypto key.
+func buildKMSCryptoKeySnapshot(client *Client, key *kmsCryptoKey, keyRing, location, projectID string) *gcpmodels.KMSCryptoKey {
+	snapshot := &gcpmodels.KMSCryptoKey{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   aws.String(kmsCryptoKeyResourceID(*key.Name)),
+			ResourceType: aws.String(gcpmodels.KMSCryptoKeySchema),
+			TimeCreated:  parseTime(key.CreateTime),
+		},
+		GenericGCPResource: gcpmodels.GenericGCPResource{
+			ProjectID: aws.String(projectID),
+			Region:    aws.String(location),
+			ID:        key.Name,
+			Name:      lastSegment(key.Name),
+			Labels:    labels(key.Labels),
+		},
+		KeyRing:          lastSegment(aws.String(keyRing)),
+		NextRotationTime: key.NextRotationTime,
+		Primary:          key.Primary,
+		Purpose:          key.Purpose,
+		RotationPeriod:   key.RotationPeriod,
+		VersionTemplate:  key.VersionTemplate,
+	}
+
+	policy, err := getKMSCryptoKeyIAMPolicy(client, *key.Name)
+	if err != nil {
+		zap.L().Warn("unable to get crypto key IAM policy", zap.String("cryptoKey", *key.Name), zap.Error(err))
+	} else {
+		snapshot.IAMPolicy = policy
+	}
+
+	return snapshot
+}
+
+// PollKMSCryptoKeys gathers information on each Cloud KMS crypto key of a GCP project.
+func PollKMSCryptoKeys(pollerInput *ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting GCP KMS Crypto Key resource poller")
+	locations, err := listKMSLocations(pollerInput.Client, pollerInput.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []*apimodels.AddResourceEntry
+	for _, location := range locations {
+		keyRings, err := listKMSKeyRings(pollerInput.Client, pollerInput.ProjectID, location)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, keyRing := range keyRings {
+			keys, err := listKMSCryptoKeys(pollerInput.Client, keyRing)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, key := range keys {
+				snapshot := buildKMSCryptoKeySnapshot(pollerInput.Client, key, keyRing, location, pollerInput.ProjectID)
+				resources = append(resources, resourceEntry(pollerInput, *snapshot.ResourceID, gcpmodels.KMSCryptoKeySchema, snapshot))
+			}
+		}
+	}
+	return resources, nil
+}
@@ -0,0 +1,52 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+func TestKMSCryptoKeyPoller(t *testing.T) {
+	server := newTestServer(t)
+
+	resources, err := PollKMSCryptoKeys(server.pollerInput(t))
+	require.NoError(t, err)
+	// Only the global location has a key ring
+	require.Len(t, resources, 1)
+
+	assert.Equal(t,
+		apimodels.ResourceID("//cloudkms.googleapis.com/projects/example-project/locations/global/keyRings/app/cryptoKeys/secrets"),
+		resources[0].ID)
+	key := resources[0].Attributes.(*gcpmodels.KMSCryptoKey)
+	assert.Equal(t, aws.String("global"), key.Region)
+	assert.Equal(t, aws.String("secrets"), key.Name)
+	assert.Equal(t, aws.String("app"), key.KeyRing)
+	assert.Equal(t, aws.String("7776000s"), key.RotationPeriod)
+	assert.Equal(t, aws.String("ENABLED"), key.Primary.State)
+	assert.Equal(t, aws.String("SOFTWARE"), key.VersionTemplate.ProtectionLevel)
+	require.NotNil(t, key.IAMPolicy)
+	assert.Equal(t, aws.String("roles/cloudkms.cryptoKeyEncrypterDecrypter"), key.IAMPolicy.Bindings[0].Role)
+}
@@ -0,0 +1,226 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"github.com/go-openapi/strfmt"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+)
+
+// ResourcePollerInput contains the client and metadata to request GCP resource info.
+type ResourcePollerInput struct {
+	Client        *Client
+	IntegrationID string
+	ProjectID     string
+}
+
+// ResourcePoller represents a function to poll a specific GCP resource type.
+type ResourcePoller func(input *ResourcePollerInput) ([]*apimodels.AddResourceEntry, error)
+
+// resourcePoller is a simple struct to be used only for invoking the ResourcePollers in order.
+type resourcePoller struct {
+	description    string
+	resourcePoller ResourcePoller
+}
+
+var (
+	secretsClient secretsmanageriface.SecretsManagerAPI
+
+	// getServiceAccountKeyFunc returns the service account key of a gcp-scan integration.
+	getServiceAccountKeyFunc = getServiceAccountKey
+
+	// ServicePollers maps a resource type to its Poll function
+	ServicePollers = map[string]resourcePoller{
+		gcpmodels.ComputeFirewallSchema:   {"ComputeFirewall", PollComputeFirewalls},
+		gcpmodels.ComputeInstanceSchema:   {"ComputeInstance", PollComputeInstances},
+		gcpmodels.IAMServiceAccountSchema: {"IAMServiceAccount", PollIAMServiceAccounts},
+		gcpmodels.KMSCryptoKeySchema:      {"KMSCryptoKey", PollKMSCryptoKeys},
+		gcpmodels.ProjectSchema:           {"Project", PollProjects},
+		gcpmodels.SQLInstanceSchema:       {"SQLInstance", PollSQLInstances},
+		gcpmodels.StorageBucketSchema:     {"StorageBucket", PollStorageBuckets},
+	}
+)
+
+func Setup() {
+	secretsClient = secretsmanager.New(session.Must(session.NewSession()))
+}
+
+// getServiceAccountKey reads the service account key stored when the integration was onboarded.
+func getServiceAccountKey(integrationID string) ([]byte, error) {
+	output, err := secretsClient.GetSecretValue(&secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(models.GCPCredentialsSecretName(integrationID)),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load GCP service account key")
+	}
+	return []byte(aws.StringValue(output.SecretString)), nil
+}
+
+// Poll gathers the resources of a GCP project for compliance monitoring.
+func Poll(scanRequest *pollermodels.ScanEntry) (generatedEvents []*apimodels.AddResourceEntry, err error) {
+	if scanRequest.GCPProjectID == nil || scanRequest.IntegrationID == nil {
+		return nil, errors.New("no valid GCP project provided")
+	}
+
+	keyJSON, err := getServiceAccountKeyFunc(*scanRequest.IntegrationID)
+	if err != nil {
+		return nil, err
+	}
+	client, err := NewClient(keyJSON)
+	if err != nil {
+		return nil, err
+	}
+	pollerInput := &ResourcePollerInput{
+		Client:        client,
+		IntegrationID: *scanRequest.IntegrationID,
+		ProjectID:     *scanRequest.GCPProjectID,
+	}
+
+	// There are no events to trigger scans of individual GCP resources, the whole project is
+	// scanned on the integration's schedule one resource type at a time.
+	if scanRequest.ResourceID != nil {
+		return nil, errors.New("individual GCP resource scans are not supported")
+	}
+
+	if scanRequest.ScanAllResources != nil && *scanRequest.ScanAllResources {
+		zap.L().Info("processing full GCP project scan")
+		allPollers := make([]resourcePoller, 0, len(ServicePollers))
+		for _, poller := range ServicePollers {
+			allPollers = append(allPollers, poller)
+		}
+		return serviceScan(allPollers, pollerInput)
+	} else if scanRequest.ResourceType != nil {
+		zap.L().Info("processing GCP project resource type scan")
+		if poller, ok := ServicePollers[*scanRequest.ResourceType]; ok {
+			return serviceScan([]resourcePoller{poller}, pollerInput)
+		}
+		return nil, errors.Errorf("invalid GCP resource type '%s' scan requested", *scanRequest.ResourceType)
+	}
+
+	zap.L().Error("Invalid scan request input")
+	return nil, nil
+}
+
+// CheckCredentials verifies that a service account key can read the given project.
+func CheckCredentials(projectID string, keyJSON []byte) error {
+	client, err := NewClient(keyJSON)
+	if err != nil {
+		return err
+	}
+	_, err = getProject(client, projectID)
+	return err
+}
+
+func serviceScan(
+	pollers []resourcePoller,
+	pollerInput *ResourcePollerInput,
+) (generatedEvents []*apimodels.AddResourceEntry, err error) {
+
+	var generatedResources []*apimodels.AddResourceEntry
+	for _, resourcePoller := range pollers {
+		generatedResources, err = resourcePoller.resourcePoller(pollerInput)
+		if err != nil {
+			// The API is disabled in the project or the service account was not granted access to
+			// it. Neither will be fixed by retrying, so the resource type is skipped.
+			if isForbidden(err) {
+				zap.L().Warn(
+					"access denied while polling, skipping resource type",
+					zap.String("resourcePoller", resourcePoller.description),
+					zap.String("projectId", pollerInput.ProjectID),
+					zap.Error(err),
+				)
+				err = nil
+				continue
+			}
+			zap.L().Error(
+				"an error occurred while polling",
+				zap.String("resourcePoller", resourcePoller.description),
+				zap.String("errorMessage", err.Error()),
+			)
+			return
+		} else if generatedResources != nil {
+			zap.L().Info(
+				"resources generated",
+				zap.Int("numResources", len(generatedResources)),
+				zap.String("resourcePoller", resourcePoller.description),
+			)
+			generatedEvents = append(generatedEvents, generatedResources...)
+		}
+	}
+	return
+}
+
+// resourceEntry wraps a snapshot for the resources-api.
+func resourceEntry(input *ResourcePollerInput, resourceID, resourceType string, snapshot interface{}) *apimodels.AddResourceEntry {
+	return &apimodels.AddResourceEntry{
+		Attributes:      snapshot,
+		ID:              apimodels.ResourceID(resourceID),
+		IntegrationID:   apimodels.IntegrationID(input.IntegrationID),
+		IntegrationType: apimodels.IntegrationTypeGcp,
+		Type:            apimodels.ResourceType(resourceType),
+	}
+}
+
+// parseTime converts an RFC3339 timestamp from a GCP API to the resources-api format, in UTC like
+// the timestamps of AWS resources.
+func parseTime(timestamp *string) *strfmt.DateTime {
+	if timestamp == nil {
+		return nil
+	}
+	parsed, err := strfmt.ParseDateTime(*timestamp)
+	if err != nil {
+		zap.L().Debug("unable to parse timestamp", zap.String("timestamp", *timestamp), zap.Error(err))
+		return nil
+	}
+	parsed = strfmt.DateTime(time.Time(parsed).UTC())
+	return &parsed
+}
+
+// labels makes sure labels are always sent downstream, even if empty.
+func labels(resourceLabels map[string]*string) map[string]*string {
+	if resourceLabels == nil {
+		return map[string]*string{}
+	}
+	return resourceLabels
+}
+
+// lastSegment returns the name at the end of a resource path or URL.
+func lastSegment(path *string) *string {
+	if path == nil {
+		return nil
+	}
+	for i := len(*path) - 1; i >= 0; i-- {
+		if (*path)[i] == '/' {
+			return aws.String((*path)[i+1:])
+		}
+	}
+	return path
+}
@@ -0,0 +1,143 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
+)
+
+// mockServiceAccountKey replaces the Secrets Manager lookup of the service account key.
+func mockServiceAccountKey(t *testing.T, server *testServer) {
+	key := server.serviceAccountKey(t)
+	getServiceAccountKeyFunc = func(integrationID string) ([]byte, error) {
+		return key, nil
+	}
+	t.Cleanup(func() { getServiceAccountKeyFunc = getServiceAccountKey })
+}
+
+func testScanEntry() *pollermodels.ScanEntry {
+	return &pollermodels.ScanEntry{
+		GCPProjectID:  aws.String(testProjectID),
+		IntegrationID: aws.String("5a7d8f0e-3b1c-4a2e-9f6d-0c1b2a3d4e5f"),
+	}
+}
+
+func TestPollAllResources(t *testing.T) {
+	server := newTestServer(t)
+	mockServiceAccountKey(t, server)
+	entry := testScanEntry()
+	entry.ScanAllResources = aws.Bool(true)
+
+	resources, err := Poll(entry)
+	require.NoError(t, err)
+
+	counts := make(map[apimodels.ResourceType]int)
+	for _, resource := range resources {
+		assert.Equal(t, apimodels.IntegrationTypeGcp, resource.IntegrationType)
+		counts[resource.Type]++
+	}
+	assert.Equal(t, map[apimodels.ResourceType]int{
+		gcpmodels.ComputeFirewallSchema:   1,
+		gcpmodels.ComputeInstanceSchema:   1,
+		gcpmodels.IAMServiceAccountSchema: 1,
+		gcpmodels.KMSCryptoKeySchema:      1,
+		gcpmodels.ProjectSchema:           1,
+		gcpmodels.SQLInstanceSchema:       1,
+		gcpmodels.StorageBucketSchema:     2,
+	}, counts)
+	// A single access token is used for the whole scan
+	assert.Equal(t, 1, server.tokenRequests)
+}
+
+func TestPollResourceType(t *testing.T) {
+	server := newTestServer(t)
+	mockServiceAccountKey(t, server)
+	entry := testScanEntry()
+	entry.ResourceType = aws.String(gcpmodels.ComputeFirewallSchema)
+
+	resources, err := Poll(entry)
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, apimodels.ResourceType(gcpmodels.ComputeFirewallSchema), resources[0].Type)
+
+	entry.ResourceType = aws.String("GCP.Unknown")
+	_, err = Poll(entry)
+	assert.Error(t, err)
+}
+
+func TestPollDisabledAPIIsSkipped(t *testing.T) {
+	server := newTestServer(t)
+	mockServiceAccountKey(t, server)
+	server.statusCodes["GET /sql/projects/example-project/instances"] = http.StatusForbidden
+	entry := testScanEntry()
+	entry.ScanAllResources = aws.Bool(true)
+
+	resources, err := Poll(entry)
+	require.NoError(t, err)
+	assert.Len(t, resources, 7)
+	for _, resource := range resources {
+		assert.NotEqual(t, apimodels.ResourceType(gcpmodels.SQLInstanceSchema), resource.Type)
+	}
+}
+
+func TestPollInvalidRequests(t *testing.T) {
+	server := newTestServer(t)
+	mockServiceAccountKey(t, server)
+
+	_, err := Poll(&pollermodels.ScanEntry{IntegrationID: aws.String("5a7d8f0e-3b1c-4a2e-9f6d-0c1b2a3d4e5f")})
+	assert.Error(t, err)
+
+	entry := testScanEntry()
+	entry.ResourceID = aws.String(projectResourceID(testProjectID))
+	_, err = Poll(entry)
+	assert.Error(t, err)
+}
+
+func TestPollMissingCredentials(t *testing.T) {
+	getServiceAccountKeyFunc = func(integrationID string) ([]byte, error) {
+		return nil, errors.New("ResourceNotFoundException")
+	}
+	t.Cleanup(func() { getServiceAccountKeyFunc = getServiceAccountKey })
+
+	entry := testScanEntry()
+	entry.ScanAllResources = aws.Bool(true)
+	resources, err := Poll(entry)
+	require.Error(t, err)
+	assert.Nil(t, resources)
+}
+
+func TestCheckCredentials(t *testing.T) {
+	server := newTestServer(t)
+	key := server.serviceAccountKey(t)
+
+	assert.NoError(t, CheckCredentials(testProjectID, key))
+	// No recorded response, the service account can't see the project
+	assert.Error(t, CheckCredentials("other-project", key))
+	assert.Error(t, CheckCredentials(testProjectID, []byte("{}")))
+}
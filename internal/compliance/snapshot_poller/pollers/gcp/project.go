@@ -0,0 +1,119 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+// The IAM policy version which supports conditional role bindings
+const iamPolicyVersion = 3
+
+// project is a cloudresourcemanager.Project
+type project struct {
+	CreateTime     *string                  `json:"createTime"`
+	Labels         map[string]*string       `json:"labels"`
+	LifecycleState *string                  `json:"lifecycleState"`
+	Name           *string                  `json:"name"`
+	Parent         *gcpmodels.ProjectParent `json:"parent"`
+	ProjectID      *string                  `json:"projectId"`
+	ProjectNumber  *string                  `json:"projectNumber"`
+}
+
+// projectResourceID is the full resource name of a project.
+func projectResourceID(projectID string) string {
+	return fmt.Sprintf("//cloudresourcemanager.googleapis.com/projects/%s", projectID)
+}
+
+// getProject returns the metadata of a project.
+func getProject(client *Client, projectID string) (*project, error) {
+	result := &project{}
+	if err := client.get(fmt.Sprintf("%s/projects/%s", cloudResourceManagerEndpoint, projectID), result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// getProjectIAMPolicy returns the IAM policy of a project.
+func getProjectIAMPolicy(client *Client, projectID string) (*gcpmodels.IAMPolicy, error) {
+	policy := &gcpmodels.IAMPolicy{}
+	body := map[string]interface{}{
+		"options": map[string]int{"requestedPolicyVersion": iamPolicyVersion},
+	}
+	err := client.post(fmt.Sprintf("%s/projects/%s:getIamPolicy", cloudResourceManagerEndpoint, projectID), body, policy)
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// buildProjectSnapshot returns a complete snapshot of a project.
+func buildProjectSnapshot(client *Client, projectID string) (*gcpmodels.Project, error) {
+	metadata, err := getProject(client, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &gcpmodels.Project{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   aws.String(projectResourceID(projectID)),
+			ResourceType: aws.String(gcpmodels.ProjectSchema),
+			TimeCreated:  parseTime(metadata.CreateTime),
+		},
+		GenericGCPResource: gcpmodels.GenericGCPResource{
+			ProjectID: metadata.ProjectID,
+			Region:    aws.String(gcpmodels.GlobalRegion),
+			ID:        metadata.ProjectID,
+			Name:      metadata.Name,
+			Labels:    labels(metadata.Labels),
+		},
+		LifecycleState: metadata.LifecycleState,
+		Parent:         metadata.Parent,
+		ProjectNumber:  metadata.ProjectNumber,
+	}
+
+	policy, err := getProjectIAMPolicy(client, projectID)
+	if err != nil {
+		zap.L().Warn("unable to get project IAM policy", zap.String("projectId", projectID), zap.Error(err))
+	} else {
+		snapshot.IAMPolicy = policy
+	}
+
+	return snapshot, nil
+}
+
+// PollProjects gathers information on the GCP project of an integration.
+func PollProjects(pollerInput *ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting GCP Project resource poller")
+	snapshot, err := buildProjectSnapshot(pollerInput.Client, pollerInput.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*apimodels.AddResourceEntry{
+		resourceEntry(pollerInput, *snapshot.ResourceID, gcpmodels.ProjectSchema, snapshot),
+	}, nil
+}
@@ -0,0 +1,79 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+func TestProjectPoller(t *testing.T) {
+	server := newTestServer(t)
+	input := server.pollerInput(t)
+
+	resources, err := PollProjects(input)
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+
+	resource := resources[0]
+	assert.Equal(t, apimodels.ResourceID("//cloudresourcemanager.googleapis.com/projects/example-project"), resource.ID)
+	assert.Equal(t, apimodels.IntegrationTypeGcp, resource.IntegrationType)
+	assert.Equal(t, apimodels.IntegrationID(input.IntegrationID), resource.IntegrationID)
+	assert.Equal(t, apimodels.ResourceType(gcpmodels.ProjectSchema), resource.Type)
+
+	project := resource.Attributes.(*gcpmodels.Project)
+	assert.Equal(t, aws.String("example-project"), project.ProjectID)
+	assert.Equal(t, aws.String(gcpmodels.GlobalRegion), project.Region)
+	assert.Equal(t, aws.String("123456789012"), project.ProjectNumber)
+	assert.Equal(t, aws.String("organization"), project.Parent.Type)
+	assert.Equal(t, aws.String("987654321098"), project.Parent.ID)
+	assert.Equal(t, map[string]*string{"env": aws.String("prod")}, project.Labels)
+	assert.Equal(t, "2020-04-01T17:31:21.123Z", project.TimeCreated.String())
+
+	require.NotNil(t, project.IAMPolicy)
+	require.Len(t, project.IAMPolicy.Bindings, 2)
+	assert.Equal(t, []*string{aws.String("allUsers")}, project.IAMPolicy.Bindings[1].Members)
+	assert.Equal(t, aws.String("public-assets"), project.IAMPolicy.Bindings[1].Condition.Title)
+}
+
+func TestProjectPollerMissingIAMPolicy(t *testing.T) {
+	server := newTestServer(t)
+	server.statusCodes["POST /crm/projects/example-project:getIamPolicy"] = http.StatusForbidden
+
+	resources, err := PollProjects(server.pollerInput(t))
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Nil(t, resources[0].Attributes.(*gcpmodels.Project).IAMPolicy)
+}
+
+func TestProjectPollerError(t *testing.T) {
+	server := newTestServer(t)
+	server.statusCodes["GET /crm/projects/example-project"] = http.StatusForbidden
+
+	resources, err := PollProjects(server.pollerInput(t))
+	require.Error(t, err)
+	assert.Nil(t, resources)
+}
@@ -0,0 +1,126 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+// sqlInstance is a sqladmin.DatabaseInstance
+type sqlInstance struct {
+	BackendType                 *string                                   `json:"backendType"`
+	CreateTime                  *string                                   `json:"createTime"`
+	DatabaseVersion             *string                                   `json:"databaseVersion"`
+	DiskEncryptionConfiguration *gcpmodels.SQLDiskEncryptionConfiguration `json:"diskEncryptionConfiguration"`
+	GceZone                     *string                                   `json:"gceZone"`
+	InstanceType                *string                                   `json:"instanceType"`
+	IPAddresses                 []*gcpmodels.SQLIPMapping                 `json:"ipAddresses"`
+	Name                        *string                                   `json:"name"`
+	Region                      *string                                   `json:"region"`
+	ServiceAccountEmailAddress  *string                                   `json:"serviceAccountEmailAddress"`
+	Settings                    *sqlSettings                              `json:"settings"`
+	State                       *string                                   `json:"state"`
+}
+
+type sqlSettings struct {
+	gcpmodels.SQLSettings
+	UserLabels map[string]*string `json:"userLabels"`
+}
+
+// sqlInstanceResourceID is the full resource name of an instance.
+func sqlInstanceResourceID(projectID, name string) string {
+	return fmt.Sprintf("//sqladmin.googleapis.com/projects/%s/instances/%s", projectID, name)
+}
+
+// listSQLInstances returns all Cloud SQL instances of a project.
+func listSQLInstances(client *Client, projectID string) ([]*sqlInstance, error) {
+	items, err := client.list(fmt.Sprintf("%s/projects/%s/instances", sqlAdminEndpoint, projectID), "items")
+	if err != nil {
+		return nil, err
+	}
+
+	instances := make([]*sqlInstance, 0, len(items))
+	for _, item := range items {
+		instance := &sqlInstance{}
+		if err = jsoniter.Unmarshal(item, instance); err != nil {
+			return nil, errors.Wrap(err, "invalid Cloud SQL instance")
+		}
+		instances = append(instances, instance)
+	}
+	return instances, nil
+}
+
+// buildSQLInstanceSnapshot returns a complete snapshot of a Cloud SQL instance.
+func buildSQLInstanceSnapshot(instance *sqlInstance, projectID string) *gcpmodels.SQLInstance {
+	snapshot := &gcpmodels.SQLInstance{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   aws.String(sqlInstanceResourceID(projectID, *instance.Name)),
+			ResourceType: aws.String(gcpmodels.SQLInstanceSchema),
+			TimeCreated:  parseTime(instance.CreateTime),
+		},
+		GenericGCPResource: gcpmodels.GenericGCPResource{
+			ProjectID: aws.String(projectID),
+			Region:    instance.Region,
+			ID:        instance.Name,
+			Name:      instance.Name,
+			Labels:    labels(nil),
+		},
+		BackendType:                 instance.BackendType,
+		DatabaseVersion:             instance.DatabaseVersion,
+		DiskEncryptionConfiguration: instance.DiskEncryptionConfiguration,
+		GceZone:                     instance.GceZone,
+		InstanceType:                instance.InstanceType,
+		IPAddresses:                 instance.IPAddresses,
+		ServiceAccountEmailAddress:  instance.ServiceAccountEmailAddress,
+		State:                       instance.State,
+	}
+
+	// Cloud SQL keeps labels with the rest of the user settings
+	if instance.Settings != nil {
+		snapshot.Settings = &instance.Settings.SQLSettings
+		snapshot.Labels = labels(instance.Settings.UserLabels)
+	}
+
+	return snapshot
+}
+
+// PollSQLInstances gathers information on each Cloud SQL instance of a GCP project.
+func PollSQLInstances(pollerInput *ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting GCP SQL Instance resource poller")
+	instances, err := listSQLInstances(pollerInput.Client, pollerInput.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]*apimodels.AddResourceEntry, 0, len(instances))
+	for _, instance := range instances {
+		snapshot := buildSQLInstanceSnapshot(instance, pollerInput.ProjectID)
+		resources = append(resources, resourceEntry(pollerInput, *snapshot.ResourceID, gcpmodels.SQLInstanceSchema, snapshot))
+	}
+	return resources, nil
+}
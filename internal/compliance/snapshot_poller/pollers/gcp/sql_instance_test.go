@@ -0,0 +1,53 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+func TestSQLInstancePoller(t *testing.T) {
+	server := newTestServer(t)
+
+	resources, err := PollSQLInstances(server.pollerInput(t))
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+
+	assert.Equal(t, apimodels.ResourceID("//sqladmin.googleapis.com/projects/example-project/instances/orders-db"), resources[0].ID)
+	instance := resources[0].Attributes.(*gcpmodels.SQLInstance)
+	assert.Equal(t, aws.String("us-central1"), instance.Region)
+	assert.Equal(t, aws.String("POSTGRES_12"), instance.DatabaseVersion)
+	assert.Equal(t, map[string]*string{"env": aws.String("prod")}, instance.Labels)
+	assert.Equal(t, aws.String("203.0.113.20"), instance.IPAddresses[0].IPAddress)
+
+	settings := instance.Settings
+	require.NotNil(t, settings)
+	assert.True(t, *settings.IPConfiguration.IPv4Enabled)
+	assert.False(t, *settings.IPConfiguration.RequireSsl)
+	assert.Equal(t, aws.String("0.0.0.0/0"), settings.IPConfiguration.AuthorizedNetworks[0].Value)
+	assert.True(t, *settings.BackupConfiguration.PointInTimeRecoveryEnabled)
+	assert.Equal(t, aws.String("log_connections"), settings.DatabaseFlags[0].Name)
+}
@@ -0,0 +1,141 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+// storageBucket is a storage.Bucket
+type storageBucket struct {
+	DefaultEventBasedHold *bool                             `json:"defaultEventBasedHold"`
+	Encryption            *gcpmodels.BucketEncryption       `json:"encryption"`
+	IamConfiguration      *gcpmodels.BucketIamConfiguration `json:"iamConfiguration"`
+	ID                    *string                           `json:"id"`
+	Labels                map[string]*string                `json:"labels"`
+	Location              *string                           `json:"location"`
+	LocationType          *string                           `json:"locationType"`
+	Logging               *gcpmodels.BucketLogging          `json:"logging"`
+	Name                  *string                           `json:"name"`
+	ProjectNumber         *string                           `json:"projectNumber"`
+	RetentionPolicy       *gcpmodels.BucketRetentionPolicy  `json:"retentionPolicy"`
+	StorageClass          *string                           `json:"storageClass"`
+	TimeCreated           *string                           `json:"timeCreated"`
+	Versioning            *gcpmodels.BucketVersioning       `json:"versioning"`
+}
+
+// storageBucketResourceID is the full resource name of a bucket. Bucket names are globally unique.
+func storageBucketResourceID(name string) string {
+	return fmt.Sprintf("//storage.googleapis.com/projects/_/buckets/%s", name)
+}
+
+// listStorageBuckets returns all buckets in a project.
+func listStorageBuckets(client *Client, projectID string) ([]*storageBucket, error) {
+	items, err := client.list(fmt.Sprintf("%s/b?project=%s", storageEndpoint, url.QueryEscape(projectID)), "items")
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make([]*storageBucket, 0, len(items))
+	for _, item := range items {
+		bucket := &storageBucket{}
+		if err = jsoniter.Unmarshal(item, bucket); err != nil {
+			return nil, errors.Wrap(err, "invalid bucket")
+		}
+		buckets = append(buckets, bucket)
+	}
+	return buckets, nil
+}
+
+// getStorageBucketIAMPolicy returns the IAM policy of a bucket.
+func getStorageBucketIAMPolicy(client *Client, name string) (*gcpmodels.IAMPolicy, error) {
+	policy := &gcpmodels.IAMPolicy{}
+	requestURL := fmt.Sprintf("%s/b/%s/iam?optionsRequestedPolicyVersion=%d", storageEndpoint, url.PathEscape(name), iamPolicyVersion)
+	if err := client.get(requestURL, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// buildStorageBucketSnapshot returns a complete snapshot of a bucket.
+func buildStorageBucketSnapshot(client *Client, bucket *storageBucket, projectID string) *gcpmodels.StorageBucket {
+	snapshot := &gcpmodels.StorageBucket{
+		GenericResource: awsmodels.GenericResource{
+			ResourceID:   aws.String(storageBucketResourceID(*bucket.Name)),
+			ResourceType: aws.String(gcpmodels.StorageBucketSchema),
+			TimeCreated:  parseTime(bucket.TimeCreated),
+		},
+		GenericGCPResource: gcpmodels.GenericGCPResource{
+			ProjectID: aws.String(projectID),
+			ID:        bucket.ID,
+			Name:      bucket.Name,
+			Labels:    labels(bucket.Labels),
+		},
+		DefaultEventBasedHold: bucket.DefaultEventBasedHold,
+		Encryption:            bucket.Encryption,
+		IamConfiguration:      bucket.IamConfiguration,
+		Location:              bucket.Location,
+		LocationType:          bucket.LocationType,
+		Logging:               bucket.Logging,
+		ProjectNumber:         bucket.ProjectNumber,
+		RetentionPolicy:       bucket.RetentionPolicy,
+		StorageClass:          bucket.StorageClass,
+		Versioning:            bucket.Versioning,
+	}
+	// Locations are upper case (US, EUROPE-WEST1), regions elsewhere are lower case
+	if bucket.Location != nil {
+		snapshot.Region = aws.String(strings.ToLower(*bucket.Location))
+	}
+
+	policy, err := getStorageBucketIAMPolicy(client, *bucket.Name)
+	if err != nil {
+		zap.L().Warn("unable to get bucket IAM policy", zap.String("bucket", *bucket.Name), zap.Error(err))
+	} else {
+		snapshot.IAMPolicy = policy
+	}
+
+	return snapshot
+}
+
+// PollStorageBuckets gathers information on each Cloud Storage bucket of a GCP project.
+func PollStorageBuckets(pollerInput *ResourcePollerInput) ([]*apimodels.AddResourceEntry, error) {
+	zap.L().Debug("starting GCP Storage Bucket resource poller")
+	buckets, err := listStorageBuckets(pollerInput.Client, pollerInput.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]*apimodels.AddResourceEntry, 0, len(buckets))
+	for _, bucket := range buckets {
+		snapshot := buildStorageBucketSnapshot(pollerInput.Client, bucket, pollerInput.ProjectID)
+		resources = append(resources, resourceEntry(pollerInput, *snapshot.ResourceID, gcpmodels.StorageBucketSchema, snapshot))
+	}
+	return resources, nil
+}
@@ -0,0 +1,60 @@
+package gcp
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apimodels "github.com/panther-labs/panther/api/gateway/resources/models"
+	gcpmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/gcp"
+)
+
+func TestStorageBucketPoller(t *testing.T) {
+	server := newTestServer(t)
+
+	resources, err := PollStorageBuckets(server.pollerInput(t))
+	require.NoError(t, err)
+	// The buckets are split over two pages
+	require.Len(t, resources, 2)
+
+	assert.Equal(t, apimodels.ResourceID("//storage.googleapis.com/projects/_/buckets/example-public"), resources[0].ID)
+	public := resources[0].Attributes.(*gcpmodels.StorageBucket)
+	assert.Equal(t, aws.String("us"), public.Region)
+	assert.Equal(t, aws.String("example-project"), public.ProjectID)
+	assert.Equal(t, map[string]*string{}, public.Labels)
+	assert.True(t, *public.IamConfiguration.UniformBucketLevelAccess.Enabled)
+	assert.Nil(t, public.Versioning)
+	require.NotNil(t, public.IAMPolicy)
+	assert.Equal(t, aws.String("roles/storage.objectViewer"), public.IAMPolicy.Bindings[1].Role)
+
+	logs := resources[1].Attributes.(*gcpmodels.StorageBucket)
+	assert.Equal(t, aws.String("europe-west1"), logs.Region)
+	assert.Equal(t, aws.String("security"), logs.Labels["team"])
+	assert.True(t, *logs.Versioning.Enabled)
+	assert.Equal(t, aws.String("access/"), logs.Logging.LogObjectPrefix)
+	assert.Equal(t, aws.String("enforced"), logs.IamConfiguration.PublicAccessPrevention)
+	assert.Equal(t,
+		aws.String("projects/example-project/locations/europe-west1/keyRings/storage/cryptoKeys/logs"),
+		logs.Encryption.DefaultKmsKeyName)
+	assert.Equal(t, aws.String("2592000"), logs.RetentionPolicy.RetentionPeriod)
+}
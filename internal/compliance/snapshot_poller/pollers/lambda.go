@@ -20,9 +20,13 @@ package pollers
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/go-openapi/strfmt"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -31,6 +35,7 @@ import (
 	api "github.com/panther-labs/panther/api/gateway/resources/models"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
 	pollers "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws"
+	gcppollers "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/gcp"
 	"github.com/panther-labs/panther/pkg/lambdalogger"
 	"github.com/panther-labs/panther/pkg/oplog"
 )
@@ -60,6 +65,22 @@ func batchResources(resources []*api.AddResourceEntry) (batches [][]*api.AddReso
 	return
 }
 
+// scanTrigger returns the CloudTrail event which requested the scan, or nil for scheduled scans.
+func scanTrigger(entry *pollermodels.ScanEntry) *api.ResourceTrigger {
+	if entry.EventName == nil {
+		return nil
+	}
+
+	trigger := &api.ResourceTrigger{
+		EventID:   aws.StringValue(entry.EventID),
+		EventName: *entry.EventName,
+	}
+	if eventTime, err := time.Parse(time.RFC3339, aws.StringValue(entry.EventTime)); err == nil {
+		trigger.EventTime = strfmt.DateTime(eventTime)
+	}
+	return trigger
+}
+
 // Handle is the main Lambda Handler.
 func Handle(ctx context.Context, event events.SQSEvent) (err error) {
 	lc, _ := lambdalogger.ConfigureGlobal(ctx, nil)
@@ -79,35 +100,48 @@ func Handle(ctx context.Context, event events.SQSEvent) (err error) {
 			continue
 		}
 
-		for _, entry := range scanRequest.Entries {
+		for entryIndx, entry := range scanRequest.Entries {
+			integrationType := "aws"
+			if entry.GCPProjectID != nil {
+				integrationType = "gcp"
+			}
 			zap.L().Debug("starting poller",
 				zap.Any("sqsEntry", entry),
 				zap.Int("messageNumber", indx),
-				zap.String("integrationType", "aws"))
+				zap.String("integrationType", integrationType))
 
-			resources, pollErr := pollers.Poll(entry)
+			var resources []*api.AddResourceEntry
+			var pollErr error
+			switch {
+			case entry.GCPProjectID != nil:
+				resources, pollErr = gcppollers.Poll(entry)
+			case entry.ResourceID == nil && entry.Region == nil:
+				// Account-wide scans are resumable, and send the resources of each work unit as it
+				// finishes. A redelivered message resumes the same scan.
+				if entry.ScanID == nil {
+					entry.ScanID = aws.String(fmt.Sprintf("%s-%d", message.MessageId, entryIndx))
+				}
+				pollErr = pollers.Scan(ctx, entry, func(unitResources []*api.AddResourceEntry) error {
+					return sendResources(entry, unitResources)
+				}, func(unit *pollers.FinishedUnit) error {
+					return reconcileResources(aws.StringValue(entry.IntegrationID), unit)
+				})
+			default:
+				resources, pollErr = pollers.Poll(entry)
+			}
 			if pollErr != nil {
 				operation.LogError(errors.Wrap(pollErr, "poll failed"), zap.Any("sqsEntry", entry))
 				continue
 			}
 
-			// Send data to the Resources API
 			if resources != nil {
 				zap.L().Debug("total resources generated",
 					zap.Int("messageNumber", indx),
 					zap.Int("numResources", len(resources)),
-					zap.String("integrationType", "aws"),
+					zap.String("integrationType", integrationType),
 				)
-
-				for _, batch := range batchResources(resources) {
-					params := &operations.AddResourcesParams{
-						Body:       &api.AddResources{Resources: batch},
-						HTTPClient: httpClient,
-					}
-					zap.L().Debug("adding new resources", zap.Any("params.Body", params.Body))
-					if _, err = apiClient.Operations.AddResources(params); err != nil {
-						return err
-					}
+				if err = sendResources(entry, resources); err != nil {
+					return err
 				}
 			}
 		}
@@ -115,3 +149,26 @@ func Handle(ctx context.Context, event events.SQSEvent) (err error) {
 
 	return nil
 }
+
+// sendResources sends the resources polled for a scan entry to the Resources API.
+func sendResources(entry *pollermodels.ScanEntry, resources []*api.AddResourceEntry) error {
+	// GCP projects are not part of an AWS Organization
+	if entry.IntegrationID != nil && entry.GCPProjectID == nil {
+		if err := setOrganizationalUnits(resources, *entry.IntegrationID); err != nil {
+			return errors.Wrap(err, "failed to load integrations")
+		}
+	}
+
+	trigger := scanTrigger(entry)
+	for _, batch := range batchResources(resources) {
+		params := &operations.AddResourcesParams{
+			Body:       &api.AddResources{Resources: batch, Trigger: trigger},
+			HTTPClient: httpClient,
+		}
+		zap.L().Debug("adding new resources", zap.Any("params.Body", params.Body))
+		if _, err := apiClient.Operations.AddResources(params); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -20,12 +20,15 @@ package pollers
 
 import (
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	resourcesapi "github.com/panther-labs/panther/api/gateway/resources/models"
 	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
 )
 
 var testIntegrationID = "0aab70c6-da66-4bb9-a83c-bbe8f5717fde"
@@ -50,6 +53,20 @@ func TestBatchResources(t *testing.T) {
 	assert.Len(t, testBatches[2], 100)
 }
 
+func TestScanTrigger(t *testing.T) {
+	assert.Nil(t, scanTrigger(&pollermodels.ScanEntry{ResourceType: aws.String("AWS.S3.Bucket")}))
+
+	trigger := scanTrigger(&pollermodels.ScanEntry{
+		EventID:   aws.String("43258a7e-eef1-44ef-9aff-1e5b4cfd825d"),
+		EventName: aws.String("PutBucketPublicAccessBlock"),
+		EventTime: aws.String("2019-08-01T04:41:47Z"),
+	})
+	require.NotNil(t, trigger)
+	assert.Equal(t, "43258a7e-eef1-44ef-9aff-1e5b4cfd825d", trigger.EventID)
+	assert.Equal(t, "PutBucketPublicAccessBlock", trigger.EventName)
+	assert.Equal(t, time.Date(2019, 8, 1, 4, 41, 47, 0, time.UTC), time.Time(trigger.EventTime).UTC())
+}
+
 /*
  skipping until resources-api mock is in place
 
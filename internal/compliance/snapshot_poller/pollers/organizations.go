@@ -0,0 +1,97 @@
+package pollers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+	"go.uber.org/zap"
+
+	api "github.com/panther-labs/panther/api/gateway/resources/models"
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+const (
+	integrationsRefreshInterval = 5 * time.Minute
+	sourceAPIFunctionName       = "panther-source-api"
+)
+
+var (
+	// aws-scan integrations, keyed on integrationID
+	integrations            = make(map[string]*models.SourceIntegration)
+	integrationsLastUpdated time.Time
+
+	lambdaClient lambdaiface.LambdaAPI = lambda.New(awsSession)
+)
+
+// organizationalResource is implemented by every resource which embeds awsmodels.GenericAWSResource
+type organizationalResource interface {
+	SetOrganizationalUnit(id, path string)
+}
+
+// refreshIntegrations caches the aws-scan integrations from the source-api.
+func refreshIntegrations() error {
+	if len(integrations) != 0 && integrationsLastUpdated.Add(integrationsRefreshInterval).After(time.Now()) {
+		zap.L().Debug("using cached integrations")
+		return nil
+	}
+
+	zap.L().Debug("populating integration cache")
+	input := &models.LambdaInput{
+		ListIntegrations: &models.ListIntegrationsInput{
+			IntegrationType: aws.String(models.IntegrationTypeAWSScan),
+		},
+	}
+	var output []*models.SourceIntegration
+	if err := genericapi.Invoke(lambdaClient, sourceAPIFunctionName, input, &output); err != nil {
+		return err
+	}
+
+	integrations = make(map[string]*models.SourceIntegration, len(output))
+	for _, integration := range output {
+		integrations[integration.IntegrationID] = integration
+	}
+	integrationsLastUpdated = time.Now()
+	return nil
+}
+
+// setOrganizationalUnits adds the OU of the scanned account to each resource, so policies can filter on it.
+//
+// Resources from accounts which are not part of an organization integration are left unchanged.
+func setOrganizationalUnits(resources []*api.AddResourceEntry, integrationID string) error {
+	if err := refreshIntegrations(); err != nil {
+		return err
+	}
+
+	integration, ok := integrations[integrationID]
+	if !ok || integration.OrganizationalUnitID == "" {
+		return nil
+	}
+
+	for _, resource := range resources {
+		if attributes, ok := resource.Attributes.(organizationalResource); ok {
+			attributes.SetOrganizationalUnit(integration.OrganizationalUnitID, integration.OrganizationalUnitPath)
+		}
+	}
+	return nil
+}
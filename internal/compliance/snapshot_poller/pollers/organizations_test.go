@@ -0,0 +1,63 @@
+package pollers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/panther-labs/panther/api/gateway/resources/models"
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	awsmodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/aws"
+)
+
+func TestSetOrganizationalUnits(t *testing.T) {
+	memberID := "6a8a5c2d-4f8e-4b3e-9b5e-6f1d2a7c9e01"
+	integrations = map[string]*models.SourceIntegration{
+		memberID: {SourceIntegrationMetadata: models.SourceIntegrationMetadata{
+			IntegrationID:          memberID,
+			ParentIntegrationID:    "d3e6b4a1-0c2f-4e8a-9b7d-5a1c3e2f4b6d",
+			OrganizationalUnitID:   "ou-ab12-cdef3456",
+			OrganizationalUnitPath: "Root/Production",
+		}},
+		testIntegrationID: {SourceIntegrationMetadata: models.SourceIntegrationMetadata{
+			IntegrationID: testIntegrationID,
+		}},
+	}
+	integrationsLastUpdated = time.Now()
+
+	bucket := &awsmodels.S3Bucket{}
+	resources := []*api.AddResourceEntry{
+		{Attributes: bucket},
+		{Attributes: map[string]string{"not": "a generic resource"}},
+	}
+	require.NoError(t, setOrganizationalUnits(resources, memberID))
+	assert.Equal(t, aws.String("ou-ab12-cdef3456"), bucket.OrganizationalUnitID)
+	assert.Equal(t, aws.String("Root/Production"), bucket.OrganizationalUnitPath)
+
+	// Accounts onboarded individually have no OU
+	bucket = &awsmodels.S3Bucket{}
+	require.NoError(t, setOrganizationalUnits([]*api.AddResourceEntry{{Attributes: bucket}}, testIntegrationID))
+	assert.Nil(t, bucket.OrganizationalUnitID)
+	assert.Nil(t, bucket.OrganizationalUnitPath)
+}
@@ -0,0 +1,121 @@
+package pollers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/gateway/resources/client/operations"
+	api "github.com/panther-labs/panther/api/gateway/resources/models"
+	pollers "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws"
+)
+
+const (
+	reconcilePageSize = 1000
+
+	// The Resources API deletes at most 1000 resources per request
+	deleteBatchSize = 1000
+)
+
+// reconcileResources marks the stored resources of a finished work unit as deleted if the scan did
+// not poll them again. This catches deletions whose CloudTrail events were missed, for example
+// because CloudWatch Events are not set up for the account. Deleting a resource also removes its
+// compliance status.
+func reconcileResources(integrationID string, unit *pollers.FinishedUnit) error {
+	stored, err := listStoredResources(integrationID, unit)
+	if err != nil {
+		return err
+	}
+
+	var missing []*api.DeleteEntry
+	for id, lastModified := range stored {
+		if _, ok := unit.ResourceIDs[id]; ok {
+			continue
+		}
+		// Resources modified since the unit started were either polled by an earlier invocation of
+		// a resumed unit, or added by the event processor while the scan was running
+		if !lastModified.Before(unit.StartedAt) {
+			continue
+		}
+		missing = append(missing, &api.DeleteEntry{ID: api.ResourceID(id)})
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	zap.L().Info(
+		"deleting resources missing from scan",
+		zap.String("integrationId", integrationID),
+		zap.String("resourceType", unit.ResourceType),
+		zap.String("region", unit.Region),
+		zap.Int("numStored", len(stored)),
+		zap.Int("numMissing", len(missing)),
+	)
+	for len(missing) > 0 {
+		batchSize := len(missing)
+		if batchSize > deleteBatchSize {
+			batchSize = deleteBatchSize
+		}
+		params := &operations.DeleteResourcesParams{
+			Body:       &api.DeleteResources{Resources: missing[:batchSize]},
+			HTTPClient: httpClient,
+		}
+		if _, err = apiClient.Operations.DeleteResources(params); err != nil {
+			return err
+		}
+		missing = missing[batchSize:]
+	}
+	return nil
+}
+
+// listStoredResources returns the last modified time of each active resource stored for a work
+// unit, by resource ID.
+func listStoredResources(integrationID string, unit *pollers.FinishedUnit) (map[string]time.Time, error) {
+	params := &operations.ListResourcesParams{
+		Deleted:       aws.Bool(false),
+		Fields:        []string{"id", "lastModified"},
+		IntegrationID: aws.String(integrationID),
+		PageSize:      aws.Int64(reconcilePageSize),
+		Pagination:    aws.String("token"),
+		Types:         []string{unit.ResourceType},
+		HTTPClient:    httpClient,
+	}
+	if unit.Region != "" {
+		params.Filters = []string{"Region=" + unit.Region}
+	}
+
+	result := make(map[string]time.Time)
+	for {
+		page, err := apiClient.Operations.ListResources(params)
+		if err != nil {
+			return nil, err
+		}
+		for _, resource := range page.Payload.Resources {
+			result[string(resource.ID)] = time.Time(resource.LastModified)
+		}
+
+		if page.Payload.NextPageToken == "" {
+			return result, nil
+		}
+		params.PageToken = aws.String(page.Payload.NextPageToken)
+	}
+}
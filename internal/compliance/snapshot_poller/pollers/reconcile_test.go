@@ -0,0 +1,155 @@
+package pollers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/gateway/resources/client/operations"
+	api "github.com/panther-labs/panther/api/gateway/resources/models"
+	pollers "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws"
+)
+
+// mockResourcesAPI mocks the Resources API operations used by reconciliation
+type mockResourcesAPI struct {
+	operations.ClientService
+	mock.Mock
+}
+
+func (m *mockResourcesAPI) ListResources(params *operations.ListResourcesParams) (*operations.ListResourcesOK, error) {
+	args := m.Called(params)
+	return args.Get(0).(*operations.ListResourcesOK), args.Error(1)
+}
+
+func (m *mockResourcesAPI) DeleteResources(params *operations.DeleteResourcesParams) (*operations.DeleteResourcesOK, error) {
+	args := m.Called(params)
+	return args.Get(0).(*operations.DeleteResourcesOK), args.Error(1)
+}
+
+func setupMockResourcesAPI(t *testing.T) *mockResourcesAPI {
+	mockAPI := &mockResourcesAPI{}
+	original := apiClient.Operations
+	apiClient.Operations = mockAPI
+	t.Cleanup(func() { apiClient.Operations = original })
+	return mockAPI
+}
+
+func storedResources(nextPageToken string, lastModified time.Time, ids ...string) *operations.ListResourcesOK {
+	list := &api.ResourceList{NextPageToken: nextPageToken, Resources: []*api.Resource{}}
+	for _, id := range ids {
+		list.Resources = append(list.Resources, &api.Resource{
+			ID:           api.ResourceID(id),
+			LastModified: api.LastModified(strfmt.DateTime(lastModified)),
+		})
+	}
+	return &operations.ListResourcesOK{Payload: list}
+}
+
+func deletedIDs(params *operations.DeleteResourcesParams) []string {
+	ids := make([]string, 0, len(params.Body.Resources))
+	for _, entry := range params.Body.Resources {
+		ids = append(ids, string(entry.ID))
+	}
+	return ids
+}
+
+func TestReconcileResources(t *testing.T) {
+	mockAPI := setupMockResourcesAPI(t)
+	startedAt := time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	mockAPI.On("ListResources", mock.Anything).Return(
+		storedResources("", startedAt.Add(-24*time.Hour), "instance-1", "instance-2", "instance-3"), nil).Once()
+	mockAPI.On("DeleteResources", mock.Anything).Return(&operations.DeleteResourcesOK{}, nil).Once()
+
+	err := reconcileResources(testIntegrationID, &pollers.FinishedUnit{
+		ResourceType: "AWS.EC2.Instance",
+		Region:       "us-west-2",
+		ResourceIDs:  map[string]struct{}{"instance-1": {}, "instance-2": {}},
+		StartedAt:    startedAt,
+	})
+
+	require.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+
+	listParams := mockAPI.Calls[0].Arguments.Get(0).(*operations.ListResourcesParams)
+	assert.Equal(t, testIntegrationID, *listParams.IntegrationID)
+	assert.Equal(t, []string{"AWS.EC2.Instance"}, listParams.Types)
+	assert.Equal(t, []string{"Region=us-west-2"}, listParams.Filters)
+	assert.False(t, *listParams.Deleted)
+
+	deleteParams := mockAPI.Calls[1].Arguments.Get(0).(*operations.DeleteResourcesParams)
+	assert.Equal(t, []string{"instance-3"}, deletedIDs(deleteParams))
+}
+
+func TestReconcileResourcesAccountWide(t *testing.T) {
+	mockAPI := setupMockResourcesAPI(t)
+	startedAt := time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	mockAPI.On("ListResources", mock.Anything).Return(
+		storedResources("page-2", startedAt.Add(-time.Hour), "bucket-1", "bucket-2"), nil).Once()
+	// Added by the event processor while the scan was running
+	mockAPI.On("ListResources", mock.Anything).Return(
+		storedResources("", startedAt.Add(time.Minute), "bucket-3"), nil).Once()
+	mockAPI.On("DeleteResources", mock.Anything).Return(&operations.DeleteResourcesOK{}, nil).Once()
+
+	err := reconcileResources(testIntegrationID, &pollers.FinishedUnit{
+		ResourceType: "AWS.S3.Bucket",
+		ResourceIDs:  map[string]struct{}{"bucket-1": {}},
+		StartedAt:    startedAt,
+	})
+
+	require.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+
+	firstPage := mockAPI.Calls[0].Arguments.Get(0).(*operations.ListResourcesParams)
+	assert.Empty(t, firstPage.Filters)
+	secondPage := mockAPI.Calls[1].Arguments.Get(0).(*operations.ListResourcesParams)
+	assert.Equal(t, "page-2", *secondPage.PageToken)
+
+	deleteParams := mockAPI.Calls[2].Arguments.Get(0).(*operations.DeleteResourcesParams)
+	assert.Equal(t, []string{"bucket-2"}, deletedIDs(deleteParams))
+}
+
+func TestReconcileResourcesAllMissing(t *testing.T) {
+	mockAPI := setupMockResourcesAPI(t)
+	startedAt := time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	mockAPI.On("ListResources", mock.Anything).Return(
+		storedResources("", startedAt.Add(-time.Hour), "role-1"), nil).Once()
+	mockAPI.On("DeleteResources", mock.Anything).Return(&operations.DeleteResourcesOK{}, nil).Once()
+
+	// The last stored resource of a unit is deleted when a scan without errors no longer finds it
+	err := reconcileResources(testIntegrationID, &pollers.FinishedUnit{
+		ResourceType: "AWS.IAM.Role",
+		ResourceIDs:  map[string]struct{}{},
+		StartedAt:    startedAt,
+	})
+
+	require.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+
+	deleteParams := mockAPI.Calls[1].Arguments.Get(0).(*operations.DeleteResourcesParams)
+	assert.Equal(t, []string{"role-1"}, deletedIDs(deleteParams))
+}
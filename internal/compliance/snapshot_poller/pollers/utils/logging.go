@@ -19,7 +19,10 @@ package utils
  */
 
 import (
+	"sync/atomic"
+
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
@@ -34,4 +37,46 @@ func LogAWSError(apiCall string, err error) {
 			zap.Error(errors.Wrap(err, "AWS API call failed")),
 		)
 	}
+	if counted, ok := err.(*countedError); ok {
+		atomic.AddInt32(&counted.counter.count, 1)
+	}
+}
+
+// ErrorCounter counts the AWS errors logged by pollers using the clients it is attached to. Most
+// pollers log and skip the resources they fail to describe, so the count tells a complete listing
+// apart from one with resources missing.
+type ErrorCounter struct {
+	count int32
+}
+
+// countedError is an AWS error returned by a client with an ErrorCounter attached.
+type countedError struct {
+	err     awserr.Error
+	counter *ErrorCounter
+}
+
+func (e *countedError) Error() string   { return e.err.Error() }
+func (e *countedError) Code() string    { return e.err.Code() }
+func (e *countedError) Message() string { return e.err.Message() }
+func (e *countedError) OrigErr() error  { return e.err.OrigErr() }
+
+// Count returns the number of errors logged so far.
+func (c *ErrorCounter) Count() int {
+	if c == nil {
+		return 0
+	}
+	return int(atomic.LoadInt32(&c.count))
+}
+
+// Handler returns a request handler which attaches the counter to the AWS errors of a client, so
+// that they are counted once they are logged with LogAWSError.
+func (c *ErrorCounter) Handler() request.NamedHandler {
+	return request.NamedHandler{
+		Name: "panther.ErrorCounter",
+		Fn: func(r *request.Request) {
+			if awsErr, ok := r.Error.(awserr.Error); ok {
+				r.Error = &countedError{err: awsErr, counter: c}
+			}
+		},
+	}
 }
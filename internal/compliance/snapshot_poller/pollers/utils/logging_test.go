@@ -0,0 +1,50 @@
+package utils
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCounter(t *testing.T) {
+	counter := &ErrorCounter{}
+	handler := counter.Handler()
+
+	awsRequest := &request.Request{Error: awserr.New("AccessDeniedException", "not authorized", nil)}
+	handler.Fn(awsRequest)
+	otherRequest := &request.Request{Error: errors.New("not an AWS error")}
+	handler.Fn(otherRequest)
+
+	// Errors are only counted once they are logged
+	assert.Equal(t, 0, counter.Count())
+	LogAWSError("Test.Describe", awsRequest.Error)
+	LogAWSError("Test.Describe", otherRequest.Error)
+	LogAWSError("Test.Describe", awserr.New("AccessDeniedException", "from another client", nil))
+	assert.Equal(t, 1, counter.Count())
+
+	// The counted error keeps its AWS error code for the pollers
+	awsErr, ok := awsRequest.Error.(awserr.Error)
+	assert.True(t, ok)
+	assert.Equal(t, "AccessDeniedException", awsErr.Code())
+}
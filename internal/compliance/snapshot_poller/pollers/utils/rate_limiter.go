@@ -0,0 +1,120 @@
+package utils
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimit is the sustained request rate and burst size of a token bucket.
+type RateLimit struct {
+	PerSecond float64
+	Burst     int
+}
+
+// tokenBucket holds the tokens available to one API in one region.
+type tokenBucket struct {
+	limit   RateLimit
+	tokens  float64
+	updated time.Time
+}
+
+// RateLimiter throttles requests with a separate token bucket for every API and region, so that
+// parallel workers polling the same service share its rate limit instead of racing into throttling
+// errors and retries.
+type RateLimiter struct {
+	mu           sync.Mutex
+	defaultLimit RateLimit
+	limits       map[string]RateLimit
+	buckets      map[string]*tokenBucket
+
+	// Overridden in tests
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewRateLimiter returns a RateLimiter using the given limits per API, and the default limit for
+// any API not listed.
+func NewRateLimiter(defaultLimit RateLimit, limits map[string]RateLimit) *RateLimiter {
+	return &RateLimiter{
+		defaultLimit: defaultLimit,
+		limits:       limits,
+		buckets:      make(map[string]*tokenBucket),
+		now:          time.Now,
+		sleep:        sleepContext,
+	}
+}
+
+// Wait blocks until a request to the given API in the given region may be sent, or the context is done.
+func (l *RateLimiter) Wait(ctx context.Context, api, region string) error {
+	for {
+		delay := l.reserve(api, region)
+		if delay <= 0 {
+			return nil
+		}
+		if err := l.sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve takes a token from the bucket if one is available, otherwise it returns how long to wait
+// before the next token is added.
+func (l *RateLimiter) reserve(api, region string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	key := api + "/" + region
+	bucket, ok := l.buckets[key]
+	if !ok {
+		limit, ok := l.limits[api]
+		if !ok {
+			limit = l.defaultLimit
+		}
+		bucket = &tokenBucket{limit: limit, tokens: float64(limit.Burst), updated: now}
+		l.buckets[key] = bucket
+	}
+
+	// Refill the bucket for the time elapsed since it was last used
+	bucket.tokens += now.Sub(bucket.updated).Seconds() * bucket.limit.PerSecond
+	if burst := float64(bucket.limit.Burst); bucket.tokens > burst {
+		bucket.tokens = burst
+	}
+	bucket.updated = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return 0
+	}
+	return time.Duration((1 - bucket.tokens) / bucket.limit.PerSecond * float64(time.Second))
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,96 @@
+package utils
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock advances only when the rate limiter sleeps.
+type fakeClock struct {
+	now   time.Time
+	slept time.Duration
+}
+
+func newTestRateLimiter(limits map[string]RateLimit) (*RateLimiter, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := NewRateLimiter(RateLimit{PerSecond: 10, Burst: 2}, limits)
+	limiter.now = func() time.Time { return clock.now }
+	limiter.sleep = func(_ context.Context, d time.Duration) error {
+		clock.now = clock.now.Add(d)
+		clock.slept += d
+		return nil
+	}
+	return limiter, clock
+}
+
+func TestRateLimiterBurst(t *testing.T) {
+	limiter, clock := newTestRateLimiter(nil)
+
+	require.NoError(t, limiter.Wait(context.Background(), "ec2", "us-west-2"))
+	require.NoError(t, limiter.Wait(context.Background(), "ec2", "us-west-2"))
+	assert.Zero(t, clock.slept)
+
+	// The bucket is empty, the third request waits for a token to be added
+	require.NoError(t, limiter.Wait(context.Background(), "ec2", "us-west-2"))
+	assert.Equal(t, 100*time.Millisecond, clock.slept)
+}
+
+func TestRateLimiterRefill(t *testing.T) {
+	limiter, clock := newTestRateLimiter(nil)
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, limiter.Wait(context.Background(), "ec2", "us-west-2"))
+	}
+	clock.now = clock.now.Add(time.Second)
+
+	// The bucket refills up to its burst size only
+	for i := 0; i < 2; i++ {
+		require.NoError(t, limiter.Wait(context.Background(), "ec2", "us-west-2"))
+	}
+	assert.Zero(t, clock.slept)
+	require.NoError(t, limiter.Wait(context.Background(), "ec2", "us-west-2"))
+	assert.Equal(t, 100*time.Millisecond, clock.slept)
+}
+
+func TestRateLimiterPerAPIAndRegion(t *testing.T) {
+	limiter, clock := newTestRateLimiter(map[string]RateLimit{"iam": {PerSecond: 1, Burst: 1}})
+
+	require.NoError(t, limiter.Wait(context.Background(), "iam", "us-east-1"))
+	require.NoError(t, limiter.Wait(context.Background(), "ec2", "us-west-2"))
+	require.NoError(t, limiter.Wait(context.Background(), "ec2", "us-east-1"))
+	assert.Zero(t, clock.slept)
+
+	require.NoError(t, limiter.Wait(context.Background(), "iam", "us-east-1"))
+	assert.Equal(t, time.Second, clock.slept)
+}
+
+func TestRateLimiterContextDone(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{PerSecond: 0.001, Burst: 1}, nil)
+	require.NoError(t, limiter.Wait(context.Background(), "ec2", "us-west-2"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, limiter.Wait(ctx, "ec2", "us-west-2"))
+}
@@ -71,16 +71,26 @@ func PollAndIssueNewScans() error {
 	)
 }
 
+// The integration types which are scanned on a schedule
+var scanIntegrationTypes = []string{models.IntegrationTypeAWSScan, models.IntegrationTypeGCPScan}
+
 // getEnabledIntegrations lists enabled integrations from the snapshot-api.
 func getEnabledIntegrations() (integrations []*models.SourceIntegration, err error) {
-	err = genericapi.Invoke(
-		lambdaClient,
-		sourceAPIFunctionName,
-		&models.LambdaInput{ListIntegrations: &models.ListIntegrationsInput{
-			IntegrationType: aws.String("aws-scan"),
-		}},
-		&integrations,
-	)
+	for _, integrationType := range scanIntegrationTypes {
+		var typeIntegrations []*models.SourceIntegration
+		err = genericapi.Invoke(
+			lambdaClient,
+			sourceAPIFunctionName,
+			&models.LambdaInput{ListIntegrations: &models.ListIntegrationsInput{
+				IntegrationType: aws.String(integrationType),
+			}},
+			&typeIntegrations,
+		)
+		if err != nil {
+			return nil, err
+		}
+		integrations = append(integrations, typeIntegrations...)
+	}
 
 	return
 }
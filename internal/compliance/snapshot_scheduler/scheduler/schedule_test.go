@@ -56,10 +56,10 @@ func (client *mockLambdaClient) Invoke(input *lambda.InvokeInput) (*lambda.Invok
 //
 
 // getTestInvokeInput returns an example Lambda.Invoke input for the SnapshotAPI.
-func getTestInvokeInput() *lambda.InvokeInput {
+func getTestInvokeInput(integrationType string) *lambda.InvokeInput {
 	input := &models.LambdaInput{
 		ListIntegrations: &models.ListIntegrationsInput{
-			IntegrationType: aws.String("aws-scan"),
+			IntegrationType: aws.String(integrationType),
 		},
 	}
 	payload, err := jsoniter.Marshal(input)
@@ -166,6 +166,22 @@ var (
 	}
 )
 
+var (
+	emptyIntegrations []*models.SourceIntegration
+
+	exampleGCPIntegrations = []*models.SourceIntegration{
+		{
+			SourceIntegrationMetadata: models.SourceIntegrationMetadata{
+				IntegrationID:    "3f1e2d4c-5b6a-4798-8a9b-0c1d2e3f4a5b",
+				IntegrationLabel: "ProdGCP",
+				IntegrationType:  models.IntegrationTypeGCPScan,
+				GCPProjectID:     "example-project",
+				ScanIntervalMins: 60,
+			},
+		},
+	}
+)
+
 func TestPollAndIssueNewScansNoneToRun(t *testing.T) {
 	mockLambda := &mockLambdaClient{}
 
@@ -174,9 +190,12 @@ func TestPollAndIssueNewScansNoneToRun(t *testing.T) {
 		// Pass in the first integration, which won't need a new scan.
 		Return(getTestInvokeOutput(exampleIntegrations[:1], 200), nil)
 	mockLambda.
-		On("Invoke", getTestInvokeInput()).
+		On("Invoke", getTestInvokeInput("aws-scan")).
 		// Pass in the first integration, which won't need a new scan.
 		Return(getTestInvokeOutput(exampleIntegrations[:1], 200), nil)
+	mockLambda.
+		On("Invoke", getTestInvokeInput("gcp-scan")).
+		Return(getTestInvokeOutput(emptyIntegrations, 200), nil)
 	lambdaClient = mockLambda
 
 	result := PollAndIssueNewScans()
@@ -187,12 +206,13 @@ func TestPollAndIssueNewScansNoneToRun(t *testing.T) {
 
 func TestPollAndIssueNewScansZeroIntegrations(t *testing.T) {
 	mockLambda := &mockLambdaClient{}
-	var emptyOutput []*models.SourceIntegration
 
 	mockLambda.
-		On("Invoke", getTestInvokeInput()).
-		// Pass in the first integration, which won't need a new scan.
-		Return(getTestInvokeOutput(emptyOutput, 200), nil)
+		On("Invoke", getTestInvokeInput("aws-scan")).
+		Return(getTestInvokeOutput(emptyIntegrations, 200), nil)
+	mockLambda.
+		On("Invoke", getTestInvokeInput("gcp-scan")).
+		Return(getTestInvokeOutput(emptyIntegrations, 200), nil)
 	lambdaClient = mockLambda
 
 	result := PollAndIssueNewScans()
@@ -244,14 +264,18 @@ func TestGetEnabledIntegrations(t *testing.T) {
 	lambdaClient = mockLambda
 
 	mockLambda.
-		On("Invoke", getTestInvokeInput()).
+		On("Invoke", getTestInvokeInput("aws-scan")).
 		Return(getTestInvokeOutput(exampleIntegrations, 200), nil)
+	mockLambda.
+		On("Invoke", getTestInvokeInput("gcp-scan")).
+		Return(getTestInvokeOutput(exampleGCPIntegrations, 200), nil)
 
 	integrations, err := getEnabledIntegrations()
 
 	mockLambda.AssertExpectations(t)
 	require.NoError(t, err)
-	assert.Len(t, integrations, len(exampleIntegrations))
+	assert.Len(t, integrations, len(exampleIntegrations)+len(exampleGCPIntegrations))
+	assert.Equal(t, models.IntegrationTypeGCPScan, integrations[len(integrations)-1].IntegrationType)
 }
 
 func TestGetEnabledIntegrationsError(t *testing.T) {
@@ -259,7 +283,7 @@ func TestGetEnabledIntegrationsError(t *testing.T) {
 	lambdaClient = mockLambda
 
 	mockLambda.
-		On("Invoke", getTestInvokeInput()).
+		On("Invoke", getTestInvokeInput("aws-scan")).
 		Return(&lambda.InvokeOutput{}, errors.New("fake error"))
 
 	_, err := getEnabledIntegrations()
@@ -0,0 +1,66 @@
+package delivery
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	alertapimodels "github.com/panther-labs/panther/api/lambda/alerts/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+// addDeliveryActivity adds the result of each delivery attempt to the alert timeline.
+func addDeliveryActivity(alert *alertmodels.Alert, statuses []outputStatus) error {
+	// Policy failures are not stored in the alerts table, they have no timeline
+	if alert.AlertID == nil || len(statuses) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	activity := make([]*alertapimodels.AlertActivity, 0, len(statuses))
+	for _, status := range statuses {
+		activity = append(activity, &alertapimodels.AlertActivity{
+			AlertID:  alert.AlertID,
+			Type:     alertapimodels.DeliveryActivity,
+			Time:     now,
+			OutputID: status.outputID,
+			Success:  aws.Bool(status.success),
+		})
+	}
+
+	// The alerts-api accepts up to 25 entries per request
+	const maxActivityPerRequest = 25
+	for len(activity) > 0 {
+		batchSize := len(activity)
+		if batchSize > maxActivityPerRequest {
+			batchSize = maxActivityPerRequest
+		}
+		input := alertapimodels.LambdaInput{
+			AddAlertActivity: &alertapimodels.AddAlertActivityInput{Activity: activity[:batchSize]},
+		}
+		if err := genericapi.Invoke(lambdaClient, alertsAPI, &input, nil); err != nil {
+			return err
+		}
+		activity = activity[batchSize:]
+	}
+	return nil
+}
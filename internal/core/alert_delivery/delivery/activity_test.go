@@ -0,0 +1,98 @@
+package delivery
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	alertapimodels "github.com/panther-labs/panther/api/lambda/alerts/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/outputs"
+)
+
+func TestDispatchAddsDeliveryActivity(t *testing.T) {
+	setCaches()
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+	mockLambda := &mockLambdaClient{}
+	lambdaClient = mockLambda
+
+	alert := sampleAlert()
+	alert.AlertID = aws.String("alert-id")
+	mockClient.On("Slack", alert, alertOutput.OutputConfig.Slack).Return(&outputs.AlertDeliveryError{Permanent: true}).Once()
+	mockLambda.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{}, nil).Once()
+
+	assert.True(t, dispatch(alert))
+
+	input := invokedInput(t, mockLambda.Calls[0])
+	require.NotNil(t, input.AddAlertActivity)
+	require.Len(t, input.AddAlertActivity.Activity, 1)
+	activity := input.AddAlertActivity.Activity[0]
+	assert.Equal(t, "alert-id", *activity.AlertID)
+	assert.Equal(t, alertapimodels.DeliveryActivity, activity.Type)
+	assert.Equal(t, "output-id", activity.OutputID)
+	assert.False(t, *activity.Success)
+	mockClient.AssertExpectations(t)
+	mockLambda.AssertExpectations(t)
+}
+
+func TestDispatchDeliveryActivityFailureIsNotRetried(t *testing.T) {
+	setCaches()
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+	mockLambda := &mockLambdaClient{}
+	lambdaClient = mockLambda
+
+	alert := sampleAlert()
+	alert.AlertID = aws.String("alert-id")
+	mockClient.On("Slack", alert, alertOutput.OutputConfig.Slack).Return((*outputs.AlertDeliveryError)(nil)).Once()
+	mockLambda.On("Invoke", mock.Anything).Return((*lambda.InvokeOutput)(nil), assert.AnError).Once()
+
+	assert.True(t, dispatch(alert))
+	mockLambda.AssertExpectations(t)
+}
+
+func TestAddDeliveryActivityPolicyAlert(t *testing.T) {
+	mockLambda := &mockLambdaClient{}
+	lambdaClient = mockLambda
+
+	// Policy alerts have no alert id and no timeline
+	require.NoError(t, addDeliveryActivity(sampleAlert(), []outputStatus{{outputID: "output-id", success: true}}))
+	mockLambda.AssertNotCalled(t, "Invoke", mock.Anything)
+}
+
+func TestAddDeliveryActivityBatches(t *testing.T) {
+	mockLambda := &mockLambdaClient{}
+	lambdaClient = mockLambda
+	mockLambda.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{}, nil).Twice()
+
+	alert := sampleAlert()
+	alert.AlertID = aws.String("alert-id")
+	statuses := make([]outputStatus, 30)
+	require.NoError(t, addDeliveryActivity(alert, statuses))
+
+	assert.Len(t, invokedInput(t, mockLambda.Calls[0]).AddAlertActivity.Activity, 25)
+	assert.Len(t, invokedInput(t, mockLambda.Calls[1]).AddAlertActivity.Activity, 5)
+	mockLambda.AssertExpectations(t)
+}
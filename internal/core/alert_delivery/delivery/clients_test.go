@@ -30,6 +30,11 @@ func TestGetSQSClient(t *testing.T) {
 	assert.NotNil(t, getSQSClient())
 }
 
+func TestGetDynamoClient(t *testing.T) {
+	dynamoClient = nil
+	assert.NotNil(t, getDynamoClient())
+}
+
 // 95 ms / op
 func BenchmarkSessionCreation(b *testing.B) {
 	for i := 0; i < b.N; i++ {
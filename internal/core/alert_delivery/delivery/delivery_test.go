@@ -23,6 +23,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 	"github.com/stretchr/testify/mock"
 
+	alertapimodels "github.com/panther-labs/panther/api/lambda/alerts/models"
 	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
 	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
 	"github.com/panther-labs/panther/internal/core/alert_delivery/outputs"
@@ -38,6 +39,42 @@ func (m *mockOutputsClient) Slack(alert *alertmodels.Alert, config *outputmodels
 	return args.Get(0).(*outputs.AlertDeliveryError)
 }
 
+func (m *mockOutputsClient) SlackDigest(digest *alertmodels.Digest, config *outputmodels.SlackConfig) *outputs.AlertDeliveryError {
+	args := m.Called(digest, config)
+	return args.Get(0).(*outputs.AlertDeliveryError)
+}
+
+func (m *mockOutputsClient) SnsDigest(digest *alertmodels.Digest, config *outputmodels.SnsConfig) *outputs.AlertDeliveryError {
+	args := m.Called(digest, config)
+	return args.Get(0).(*outputs.AlertDeliveryError)
+}
+
+func (m *mockOutputsClient) Jira(alert *alertmodels.Alert, config *outputmodels.JiraConfig) (*alertapimodels.Ticket, *outputs.AlertDeliveryError) {
+	args := m.Called(alert, config)
+	return args.Get(0).(*alertapimodels.Ticket), args.Get(1).(*outputs.AlertDeliveryError)
+}
+
+func (m *mockOutputsClient) JiraComment(
+	alert *alertmodels.Alert, ticket *alertapimodels.Ticket, config *outputmodels.JiraConfig) *outputs.AlertDeliveryError {
+
+	args := m.Called(alert, ticket, config)
+	return args.Get(0).(*outputs.AlertDeliveryError)
+}
+
+func (m *mockOutputsClient) JiraTicketStatus(
+	ticket *alertapimodels.Ticket, config *outputmodels.JiraConfig) (string, *outputs.AlertDeliveryError) {
+
+	args := m.Called(ticket, config)
+	return args.String(0), args.Get(1).(*outputs.AlertDeliveryError)
+}
+
+func (m *mockOutputsClient) GithubTicketStatus(
+	ticket *alertapimodels.Ticket, config *outputmodels.GithubConfig) (string, *outputs.AlertDeliveryError) {
+
+	args := m.Called(ticket, config)
+	return args.String(0), args.Get(1).(*outputs.AlertDeliveryError)
+}
+
 type mockLambdaClient struct {
 	lambdaiface.LambdaAPI
 	mock.Mock
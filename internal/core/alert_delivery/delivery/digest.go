@@ -0,0 +1,240 @@
+package delivery
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/outputs"
+	"github.com/panther-labs/panther/pkg/awsbatch/dynamodbbatch"
+)
+
+const (
+	// The most alerts that will be summarized in a single digest.
+	// Anything beyond this (or beyond the output payload size limit) is delivered in the next digest.
+	maxDigestAlerts = 500
+
+	// Pending alerts for outputs that were deleted are eventually expired by Dynamo
+	digestEntryTTL = 7 * 24 * time.Hour
+
+	maxDigestBackoff = 30 * time.Second
+)
+
+var digestTable = os.Getenv("DIGEST_TABLE_NAME")
+
+// digestEntry is an alert waiting in the digest table to be delivered to an output.
+type digestEntry struct {
+	// OutputID is the partition key
+	OutputID string `json:"outputId"`
+
+	// EntryID is the sort key: it starts with the alert creation time so that entries are
+	// returned oldest first. It is deterministic so that a retried alert is not queued twice.
+	EntryID string `json:"entryId"`
+
+	Alert *alertmodels.Alert `json:"alert"`
+
+	// ExpiresAt is the Dynamo TTL attribute (seconds since epoch)
+	ExpiresAt int64 `json:"expiresAt"`
+}
+
+func digestEntryID(alert *alertmodels.Alert) string {
+	return alert.CreatedAt.UTC().Format(time.RFC3339Nano) + "#" + alert.AnalysisID + "#" + aws.StringValue(alert.AlertID)
+}
+
+// queueDigestAlert saves an alert to be included in the next digest for the given output.
+func queueDigestAlert(alert *alertmodels.Alert, output *outputmodels.AlertOutput) error {
+	item, err := dynamodbattribute.MarshalMap(&digestEntry{
+		OutputID:  *output.OutputID,
+		EntryID:   digestEntryID(alert),
+		Alert:     alert,
+		ExpiresAt: time.Now().Add(digestEntryTTL).Unix(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal digest entry")
+	}
+
+	_, err = getDynamoClient().PutItem(&dynamodb.PutItemInput{Item: item, TableName: aws.String(digestTable)})
+	return errors.Wrap(err, "dynamodb.PutItem")
+}
+
+// pendingDigestEntries returns up to limit queued entries for an output, oldest first.
+func pendingDigestEntries(outputID string, limit int) ([]*digestEntry, error) {
+	keyCondition := expression.Key("outputId").Equal(expression.Value(outputID))
+	queryExpression, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build digest query")
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(digestTable),
+		ExpressionAttributeNames:  queryExpression.Names(),
+		ExpressionAttributeValues: queryExpression.Values(),
+		KeyConditionExpression:    queryExpression.KeyCondition(),
+		ScanIndexForward:          aws.Bool(true),
+	}
+
+	var result []*digestEntry
+	for len(result) < limit {
+		input.Limit = aws.Int64(int64(limit - len(result)))
+		output, err := getDynamoClient().Query(input)
+		if err != nil {
+			return nil, errors.Wrap(err, "dynamodb.Query")
+		}
+
+		var page []*digestEntry
+		if err = dynamodbattribute.UnmarshalListOfMaps(output.Items, &page); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal digest entries")
+		}
+		result = append(result, page...)
+
+		if output.LastEvaluatedKey == nil {
+			break
+		}
+		input.ExclusiveStartKey = output.LastEvaluatedKey
+	}
+	return result, nil
+}
+
+func deleteDigestEntries(entries []*digestEntry) error {
+	requests := make([]*dynamodb.WriteRequest, len(entries))
+	for i, entry := range entries {
+		requests[i] = &dynamodb.WriteRequest{
+			DeleteRequest: &dynamodb.DeleteRequest{
+				Key: map[string]*dynamodb.AttributeValue{
+					"outputId": {S: aws.String(entry.OutputID)},
+					"entryId":  {S: aws.String(entry.EntryID)},
+				},
+			},
+		}
+	}
+
+	input := &dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]*dynamodb.WriteRequest{digestTable: requests},
+	}
+	return dynamodbbatch.BatchWriteItem(getDynamoClient(), maxDigestBackoff, input)
+}
+
+// FlushDigests delivers the pending digest for every output whose digest window has elapsed.
+//
+// This is invoked on a schedule. Pending alerts for outputs which no longer have a digest
+// configured are delivered right away.
+func FlushDigests() error {
+	if err := refreshOutputs(); err != nil {
+		return errors.Wrap(err, "failed to get outputs")
+	}
+
+	for _, output := range cache.Outputs {
+		if err := flushDigest(output, time.Now().UTC()); err != nil {
+			// Entries are left in the table to be retried on the next scheduled flush
+			zap.L().Error("failed to flush digest", zap.String("outputID", *output.OutputID), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+func flushDigest(output *outputmodels.AlertOutput, now time.Time) error {
+	oldest, err := pendingDigestEntries(*output.OutputID, 1)
+	if err != nil || len(oldest) == 0 {
+		return err
+	}
+
+	if output.DigestConfig.Enabled() {
+		window := time.Duration(output.DigestConfig.WindowMinutes) * time.Minute
+		if now.Sub(oldest[0].Alert.CreatedAt) < window {
+			return nil
+		}
+	}
+
+	entries, err := pendingDigestEntries(*output.OutputID, maxDigestAlerts)
+	if err != nil {
+		return err
+	}
+
+	digest, entries, err := buildDigest(output, entries)
+	if err != nil {
+		return err
+	}
+
+	zap.L().Info("sending digest",
+		zap.String("outputID", *output.OutputID),
+		zap.String("name", aws.StringValue(output.DisplayName)),
+		zap.Int("alerts", digest.AlertCount),
+	)
+	if deliveryErr := sendDigest(digest, output); deliveryErr != nil {
+		if !deliveryErr.Permanent {
+			return deliveryErr
+		}
+		// There is no point in retrying, drop the pending alerts
+		zap.L().Error("permanently failed to send digest",
+			zap.String("outputID", *output.OutputID), zap.Error(deliveryErr))
+	}
+
+	return deleteDigestEntries(entries)
+}
+
+// buildDigest summarizes the oldest entries whose digest payload fits in the output size limit.
+//
+// The entries left out are delivered with the next digest.
+func buildDigest(output *outputmodels.AlertOutput, entries []*digestEntry) (*alertmodels.Digest, []*digestEntry, error) {
+	for {
+		alerts := make([]*alertmodels.Alert, len(entries))
+		for i, entry := range entries {
+			alerts[i] = entry.Alert
+		}
+		digest := alertmodels.NewDigest(*output.OutputID, alerts)
+
+		size, err := outputs.DigestPayloadSize(digest, *output.OutputType)
+		if err != nil {
+			return nil, nil, err
+		}
+		// A single alert which is still too large is left for the output to reject
+		if size <= outputs.MaxDigestPayloadBytes || len(entries) == 1 {
+			return digest, entries, nil
+		}
+		entries = entries[:len(entries)/2]
+	}
+}
+
+func sendDigest(digest *alertmodels.Digest, output *outputmodels.AlertOutput) *outputs.AlertDeliveryError {
+	switch *output.OutputType {
+	case "slack":
+		return outputClient.SlackDigest(digest, output.OutputConfig.Slack)
+	case "msteams":
+		return outputClient.MsTeamsDigest(digest, output.OutputConfig.MsTeams)
+	case "sns":
+		return outputClient.SnsDigest(digest, output.OutputConfig.Sns)
+	case "customwebhook":
+		return outputClient.CustomWebhookDigest(digest, output.OutputConfig.CustomWebhook)
+	default:
+		return &outputs.AlertDeliveryError{
+			Message:   "digests are not supported for output type " + *output.OutputType,
+			Permanent: true,
+		}
+	}
+}
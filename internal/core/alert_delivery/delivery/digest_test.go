@@ -0,0 +1,228 @@
+package delivery
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/outputs"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+var digestOutput = &outputmodels.AlertOutput{
+	OutputType:  aws.String("slack"),
+	DisplayName: aws.String("slack:digest"),
+	OutputConfig: &outputmodels.OutputConfig{
+		Slack: &outputmodels.SlackConfig{WebhookURL: "https://slack.com"},
+	},
+	OutputID:     aws.String("output-id"),
+	DigestConfig: &outputmodels.DigestConfig{WindowMinutes: 15},
+}
+
+func digestQueryOutput(t *testing.T, alerts ...*alertmodels.Alert) *dynamodb.QueryOutput {
+	var items []map[string]*dynamodb.AttributeValue
+	for _, alert := range alerts {
+		item, err := dynamodbattribute.MarshalMap(&digestEntry{
+			OutputID: "output-id",
+			EntryID:  digestEntryID(alert),
+			Alert:    alert,
+		})
+		require.NoError(t, err)
+		items = append(items, item)
+	}
+	return &dynamodb.QueryOutput{Items: items}
+}
+
+func TestSendQueuesDigestAlert(t *testing.T) {
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+	mockDynamo := &testutils.DynamoDBMock{}
+	dynamoClient = mockDynamo
+	mockDynamo.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil)
+
+	ch := make(chan outputStatus, 1)
+	send(sampleAlert(), digestOutput, ch)
+	assert.Equal(t, outputStatus{outputID: *digestOutput.OutputID, success: true}, <-ch)
+	mockClient.AssertExpectations(t) // nothing sent right away
+	mockDynamo.AssertExpectations(t)
+}
+
+func TestSendQueueDigestFailure(t *testing.T) {
+	mockDynamo := &testutils.DynamoDBMock{}
+	dynamoClient = mockDynamo
+	mockDynamo.On("PutItem", mock.Anything).Return((*dynamodb.PutItemOutput)(nil), assert.AnError)
+
+	ch := make(chan outputStatus, 1)
+	send(sampleAlert(), digestOutput, ch)
+	assert.Equal(t, outputStatus{outputID: *digestOutput.OutputID, needsRetry: true}, <-ch)
+	mockDynamo.AssertExpectations(t)
+}
+
+func TestSendImmediateSeverityBypassesDigest(t *testing.T) {
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+	mockDynamo := &testutils.DynamoDBMock{}
+	dynamoClient = mockDynamo
+	mockClient.On("Slack", mock.Anything, mock.Anything).Return((*outputs.AlertDeliveryError)(nil))
+
+	alert := sampleAlert()
+	alert.Severity = "CRITICAL"
+	ch := make(chan outputStatus, 1)
+	send(alert, digestOutput, ch)
+	assert.Equal(t, outputStatus{outputID: *digestOutput.OutputID, success: true}, <-ch)
+	mockClient.AssertExpectations(t)
+	mockDynamo.AssertExpectations(t)
+}
+
+func TestFlushDigestWindowNotElapsed(t *testing.T) {
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+	mockDynamo := &testutils.DynamoDBMock{}
+	dynamoClient = mockDynamo
+
+	now := time.Now().UTC()
+	alert := sampleAlert()
+	alert.CreatedAt = now.Add(-5 * time.Minute)
+	mockDynamo.On("Query", mock.Anything).Return(digestQueryOutput(t, alert), nil).Once()
+
+	require.NoError(t, flushDigest(digestOutput, now))
+	mockClient.AssertExpectations(t)
+	mockDynamo.AssertExpectations(t)
+}
+
+func TestFlushDigestEmpty(t *testing.T) {
+	mockDynamo := &testutils.DynamoDBMock{}
+	dynamoClient = mockDynamo
+	mockDynamo.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{}, nil).Once()
+
+	require.NoError(t, flushDigest(digestOutput, time.Now()))
+	mockDynamo.AssertExpectations(t)
+}
+
+func TestFlushDigest(t *testing.T) {
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+	mockDynamo := &testutils.DynamoDBMock{}
+	dynamoClient = mockDynamo
+
+	now := time.Now().UTC()
+	first, second := sampleAlert(), sampleAlert()
+	first.CreatedAt = now.Add(-20 * time.Minute)
+	second.CreatedAt = now.Add(-time.Minute)
+	mockDynamo.On("Query", mock.Anything).Return(digestQueryOutput(t, first), nil).Once()
+	mockDynamo.On("Query", mock.Anything).Return(digestQueryOutput(t, first, second), nil).Once()
+	mockClient.On("SlackDigest", mock.MatchedBy(func(digest *alertmodels.Digest) bool {
+		return digest.AlertCount == 2 && len(digest.Groups) == 1
+	}), digestOutput.OutputConfig.Slack).Return((*outputs.AlertDeliveryError)(nil))
+	mockDynamo.On("BatchWriteItem", mock.MatchedBy(func(input *dynamodb.BatchWriteItemInput) bool {
+		return len(input.RequestItems[digestTable]) == 2
+	})).Return(&dynamodb.BatchWriteItemOutput{}, nil)
+
+	require.NoError(t, flushDigest(digestOutput, now))
+	mockClient.AssertExpectations(t)
+	mockDynamo.AssertExpectations(t)
+}
+
+func TestFlushDigestTransientFailure(t *testing.T) {
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+	mockDynamo := &testutils.DynamoDBMock{}
+	dynamoClient = mockDynamo
+
+	now := time.Now().UTC()
+	alert := sampleAlert()
+	alert.CreatedAt = now.Add(-time.Hour)
+	mockDynamo.On("Query", mock.Anything).Return(digestQueryOutput(t, alert), nil).Twice()
+	mockClient.On("SlackDigest", mock.Anything, mock.Anything).Return(&outputs.AlertDeliveryError{})
+
+	// Entries are not deleted so they can be retried
+	require.Error(t, flushDigest(digestOutput, now))
+	mockClient.AssertExpectations(t)
+	mockDynamo.AssertExpectations(t)
+}
+
+func TestFlushDigestDisabled(t *testing.T) {
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+	mockDynamo := &testutils.DynamoDBMock{}
+	dynamoClient = mockDynamo
+
+	// Pending alerts are delivered right away once the digest is turned off
+	alert := sampleAlert()
+	alert.CreatedAt = time.Now()
+	mockDynamo.On("Query", mock.Anything).Return(digestQueryOutput(t, alert), nil).Twice()
+	mockClient.On("SlackDigest", mock.Anything, mock.Anything).Return((*outputs.AlertDeliveryError)(nil))
+	mockDynamo.On("BatchWriteItem", mock.Anything).Return(&dynamodb.BatchWriteItemOutput{}, nil)
+
+	require.NoError(t, flushDigest(alertOutput, time.Now()))
+	mockClient.AssertExpectations(t)
+	mockDynamo.AssertExpectations(t)
+}
+
+func TestFlushDigestPayloadLimit(t *testing.T) {
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+	mockDynamo := &testutils.DynamoDBMock{}
+	dynamoClient = mockDynamo
+
+	snsOutput := &outputmodels.AlertOutput{
+		OutputType:  aws.String("sns"),
+		DisplayName: aws.String("sns:digest"),
+		OutputConfig: &outputmodels.OutputConfig{
+			Sns: &outputmodels.SnsConfig{TopicArn: "arn:aws:sns:us-west-2:123456789012:test-sns-output"},
+		},
+		OutputID:     aws.String("output-id"),
+		DigestConfig: &outputmodels.DigestConfig{WindowMinutes: 15},
+	}
+
+	// Each alert is ~100KB, so only two of them fit in a single SNS message
+	now := time.Now().UTC()
+	alerts := make([]*alertmodels.Alert, 4)
+	for i := range alerts {
+		alerts[i] = sampleAlert()
+		alerts[i].AlertID = aws.String(string(rune('a' + i)))
+		alerts[i].AnalysisDescription = aws.String(strings.Repeat("x", 100*1024))
+		alerts[i].CreatedAt = now.Add(time.Duration(i-60) * time.Minute)
+	}
+	mockDynamo.On("Query", mock.Anything).Return(digestQueryOutput(t, alerts[0]), nil).Once()
+	mockDynamo.On("Query", mock.Anything).Return(digestQueryOutput(t, alerts...), nil).Once()
+	mockClient.On("SnsDigest", mock.MatchedBy(func(digest *alertmodels.Digest) bool {
+		return digest.AlertCount == 2 && digest.Start.Equal(alerts[0].CreatedAt)
+	}), snsOutput.OutputConfig.Sns).Return((*outputs.AlertDeliveryError)(nil))
+
+	// Only the delivered entries are deleted, the rest go out with the next digest
+	mockDynamo.On("BatchWriteItem", mock.MatchedBy(func(input *dynamodb.BatchWriteItemInput) bool {
+		return len(input.RequestItems[digestTable]) == 2
+	})).Return(&dynamodb.BatchWriteItemOutput{}, nil)
+
+	require.NoError(t, flushDigest(snsOutput, now))
+	mockClient.AssertExpectations(t)
+	mockDynamo.AssertExpectations(t)
+}
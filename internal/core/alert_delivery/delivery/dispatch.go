@@ -21,6 +21,7 @@ package delivery
 import (
 	"go.uber.org/zap"
 
+	alertapimodels "github.com/panther-labs/panther/api/lambda/alerts/models"
 	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
 	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
 	"github.com/panther-labs/panther/internal/core/alert_delivery/outputs"
@@ -50,23 +51,36 @@ func send(alert *alertmodels.Alert, output *outputmodels.AlertOutput, statusChan
 		}
 	}()
 
+	// Low severity alerts for outputs with a digest are saved and delivered later in one summary
+	if !output.DigestConfig.IsImmediate(alert.Severity) {
+		if err := queueDigestAlert(alert, output); err != nil {
+			zap.L().Warn("failed to queue alert for digest", append(commonFields, zap.Error(err))...)
+			statusChannel <- outputStatus{outputID: *output.OutputID, success: false, needsRetry: true}
+			return
+		}
+		zap.L().Info("alert queued for digest", commonFields...)
+		statusChannel <- outputStatus{outputID: *output.OutputID, success: true, needsRetry: false}
+		return
+	}
+
 	zap.L().Info(
 		"sending alert",
 		append(commonFields, zap.String("name", *output.DisplayName))...,
 	)
 
 	var alertDeliveryError *outputs.AlertDeliveryError
+	var ticket *alertapimodels.Ticket
 	switch *output.OutputType {
 	case "slack":
 		alertDeliveryError = outputClient.Slack(alert, output.OutputConfig.Slack)
 	case "pagerduty":
 		alertDeliveryError = outputClient.PagerDuty(alert, output.OutputConfig.PagerDuty)
 	case "github":
-		alertDeliveryError = outputClient.Github(alert, output.OutputConfig.Github)
+		ticket, alertDeliveryError = outputClient.Github(alert, output.OutputConfig.Github)
 	case "opsgenie":
 		alertDeliveryError = outputClient.Opsgenie(alert, output.OutputConfig.Opsgenie)
 	case "jira":
-		alertDeliveryError = outputClient.Jira(alert, output.OutputConfig.Jira)
+		ticket, alertDeliveryError = outputClient.Jira(alert, output.OutputConfig.Jira)
 	case "msteams":
 		alertDeliveryError = outputClient.MsTeams(alert, output.OutputConfig.MsTeams)
 	case "sqs":
@@ -77,6 +91,14 @@ func send(alert *alertmodels.Alert, output *outputmodels.AlertOutput, statusChan
 		alertDeliveryError = outputClient.Asana(alert, output.OutputConfig.Asana)
 	case "customwebhook":
 		alertDeliveryError = outputClient.CustomWebhook(alert, output.OutputConfig.CustomWebhook)
+	case "email":
+		alertDeliveryError = outputClient.Email(alert, output.OutputConfig.Email)
+	case "splunk":
+		alertDeliveryError = outputClient.Splunk(alert, output.OutputConfig.Splunk)
+	case "servicenow":
+		alertDeliveryError = outputClient.ServiceNow(alert, output.OutputConfig.ServiceNow)
+	case "googlechat":
+		alertDeliveryError = outputClient.GoogleChat(alert, output.OutputConfig.GoogleChat)
 	default:
 		zap.L().Warn("unsupported output type", commonFields...)
 		statusChannel <- outputStatus{outputID: *output.OutputID, success: false, needsRetry: false}
@@ -89,6 +111,14 @@ func send(alert *alertmodels.Alert, output *outputmodels.AlertOutput, statusChan
 		return
 	}
 
+	if ticket != nil {
+		// The issue was already created: failing to link it is logged but not retried,
+		// otherwise we would create duplicate issues.
+		if err := addAlertTicket(alert, output, ticket); err != nil {
+			zap.L().Error("failed to link ticket to alert", append(commonFields, zap.Error(err))...)
+		}
+	}
+
 	zap.L().Info("alert success", commonFields...)
 	statusChannel <- outputStatus{outputID: *output.OutputID, success: true, needsRetry: false}
 }
@@ -125,8 +155,10 @@ func dispatch(alert *alertmodels.Alert) bool {
 
 	// Wait until all outputs have finished, gathering any that need to be retried.
 	var retryOutputs []string
+	statuses := make([]outputStatus, 0, len(alertOutputs))
 	for range alertOutputs {
 		status := <-statusChannel
+		statuses = append(statuses, status)
 		if status.needsRetry {
 			retryOutputs = append(retryOutputs, status.outputID)
 		} else if !status.success {
@@ -137,6 +169,11 @@ func dispatch(alert *alertmodels.Alert) bool {
 		}
 	}
 
+	// The deliveries already happened, a missing timeline entry should not retry the alert
+	if err := addDeliveryActivity(alert, statuses); err != nil {
+		zap.L().Error("failed to record delivery activity", zap.String("policyId", alert.AnalysisID), zap.Error(err))
+	}
+
 	if len(retryOutputs) > 0 {
 		alert.OutputIds = retryOutputs // Replace the outputs with the set that failed
 		return false
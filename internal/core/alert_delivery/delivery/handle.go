@@ -47,7 +47,14 @@ func HandleAlerts(alerts []*models.Alert) {
 	zap.L().Info("starting processing alerts", zap.Int("alerts", len(alerts)))
 
 	for _, alert := range alerts {
-		if !dispatch(alert) {
+		var success bool
+		if alert.Update != nil {
+			success = updateTickets(alert)
+		} else {
+			success = dispatch(alert)
+		}
+
+		if !success {
 			if time.Since(alert.CreatedAt) > getMaxRetryDuration() {
 				zap.L().Error(
 					"alert delivery permanently failed, exceeded max retry duration",
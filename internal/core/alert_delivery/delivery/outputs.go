@@ -49,20 +49,28 @@ var (
 	refreshInterval = getRefreshInterval()
 )
 
-// Get output ids for an alert
-func getAlertOutputs(alert *alertmodels.Alert) ([]*outputmodels.AlertOutput, error) {
+// Refresh the cached outputs if they are missing or stale
+func refreshOutputs() error {
 	if cache == nil || time.Since(cache.Timestamp) > refreshInterval {
 		zap.L().Debug("getting cached default outputs")
 		input := outputmodels.LambdaInput{GetOutputsWithSecrets: &outputmodels.GetOutputsWithSecretsInput{}}
 		var outputs outputmodels.GetOutputsOutput
 		if err := genericapi.Invoke(lambdaClient, outputsAPI, &input, &outputs); err != nil {
-			return nil, err
+			return err
 		}
 		cache = &outputsCache{
 			Outputs:   outputs,
 			Timestamp: time.Now().UTC(),
 		}
 	}
+	return nil
+}
+
+// Get output ids for an alert
+func getAlertOutputs(alert *alertmodels.Alert) ([]*outputmodels.AlertOutput, error) {
+	if err := refreshOutputs(); err != nil {
+		return nil, err
+	}
 
 	// If alert doesn't have outputs IDs specified, return the defaults for the severity
 	if len(alert.OutputIds) == 0 {
@@ -80,6 +88,19 @@ func getAlertOutputs(alert *alertmodels.Alert) ([]*outputmodels.AlertOutput, err
 	return result, nil
 }
 
+// Get a cached output by its id, nil if it doesn't exist
+func getCachedOutput(outputID string) *outputmodels.AlertOutput {
+	if cache == nil {
+		return nil
+	}
+	for _, output := range cache.Outputs {
+		if *output.OutputID == outputID {
+			return output
+		}
+	}
+	return nil
+}
+
 func getOutputsBySeverity(severity string) []*outputmodels.AlertOutput {
 	result := []*outputmodels.AlertOutput{}
 	if cache == nil {
@@ -0,0 +1,177 @@
+package delivery
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	alertapimodels "github.com/panther-labs/panther/api/lambda/alerts/models"
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/outputs"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+var alertsAPI = os.Getenv("ALERTS_API")
+
+// addAlertTicket links a newly created Jira or Github issue to its alert.
+func addAlertTicket(alert *alertmodels.Alert, output *outputmodels.AlertOutput, ticket *alertapimodels.Ticket) error {
+	// Policy failures are not stored in the alerts table, there is nothing to sync
+	if alert.AlertID == nil {
+		return nil
+	}
+
+	ticket.OutputID = *output.OutputID
+	ticket.CreatedAt = time.Now().UTC()
+	input := alertapimodels.LambdaInput{
+		AddAlertTicket: &alertapimodels.AddAlertTicketInput{AlertID: alert.AlertID, Ticket: ticket},
+	}
+	return genericapi.Invoke(lambdaClient, alertsAPI, &input, nil)
+}
+
+// updateTickets comments on the tickets of an alert with its new activity.
+//
+// Returns true if the tickets were updated, false if some of them need to be retried.
+func updateTickets(alert *alertmodels.Alert) bool {
+	if err := refreshOutputs(); err != nil {
+		zap.L().Warn("failed to get outputs", zap.String("policyId", alert.AnalysisID), zap.Error(err))
+		return false
+	}
+
+	var retryTickets []*alertapimodels.Ticket
+	for _, ticket := range alert.Update.Tickets {
+		output := getCachedOutput(ticket.OutputID)
+		if output == nil {
+			zap.L().Info("ticket output no longer exists", zap.String("outputID", ticket.OutputID))
+			continue
+		}
+
+		var alertDeliveryError *outputs.AlertDeliveryError
+		switch ticket.OutputType {
+		case "jira":
+			alertDeliveryError = outputClient.JiraComment(alert, ticket, output.OutputConfig.Jira)
+		case "github":
+			alertDeliveryError = outputClient.GithubComment(alert, ticket, output.OutputConfig.Github)
+		default:
+			zap.L().Warn("unsupported ticket type", zap.String("outputType", ticket.OutputType))
+			continue
+		}
+
+		if alertDeliveryError != nil {
+			zap.L().Warn("failed to update ticket",
+				zap.String("outputID", ticket.OutputID),
+				zap.String("ticket", ticket.Key),
+				zap.Error(alertDeliveryError),
+			)
+			if !alertDeliveryError.Permanent {
+				retryTickets = append(retryTickets, ticket)
+			}
+		}
+	}
+
+	if len(retryTickets) > 0 {
+		alert.Update.Tickets = retryTickets // Only retry the tickets which failed
+		return false
+	}
+	return true
+}
+
+// SyncTickets sets the status of alerts whose tickets were resolved or closed.
+//
+// An alert is updated only once all of its tickets are done: it is RESOLVED if any ticket
+// was resolved, and CLOSED if they were all closed without a fix.
+func SyncTickets() error {
+	if err := refreshOutputs(); err != nil {
+		return errors.Wrap(err, "failed to get outputs")
+	}
+
+	input := alertapimodels.LambdaInput{ListAlertTickets: &alertapimodels.ListAlertTicketsInput{}}
+	var alerts alertapimodels.ListAlertTicketsOutput
+	if err := genericapi.Invoke(lambdaClient, alertsAPI, &input, &alerts); err != nil {
+		return errors.Wrap(err, "failed to list alert tickets")
+	}
+
+	for _, alert := range alerts {
+		status, synced, err := ticketsStatus(alert.Tickets)
+		if err != nil {
+			// The alert will be synced again on the next schedule
+			zap.L().Warn("failed to get ticket status", zap.String("alertId", *alert.AlertID), zap.Error(err))
+			continue
+		}
+
+		syncInput := &alertapimodels.SyncAlertTicketsInput{AlertID: alert.AlertID}
+		switch {
+		case !synced:
+			// The outputs of all its tickets were deleted, the alert can't be synced anymore
+			zap.L().Info("stopping ticket sync", zap.String("alertId", *alert.AlertID))
+		case status == "" || status == alert.Status:
+			continue
+		default:
+			zap.L().Info("syncing alert status from tickets",
+				zap.String("alertId", *alert.AlertID), zap.String("status", status))
+			syncInput.Status = &status
+		}
+
+		input := alertapimodels.LambdaInput{SyncAlertTickets: syncInput}
+		if err := genericapi.Invoke(lambdaClient, alertsAPI, &input, nil); err != nil {
+			zap.L().Error("failed to sync alert tickets", zap.String("alertId", *alert.AlertID), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// ticketsStatus returns the alert status matching a set of tickets, or "" if any of them is still open.
+//
+// synced is false if none of the tickets can be synced anymore because their outputs were deleted.
+func ticketsStatus(tickets []*alertapimodels.Ticket) (status string, synced bool, err error) {
+	for _, ticket := range tickets {
+		output := getCachedOutput(ticket.OutputID)
+		if output == nil {
+			// The output was deleted, this ticket can't be synced anymore
+			continue
+		}
+		synced = true
+
+		var ticketStatus string
+		var alertDeliveryError *outputs.AlertDeliveryError
+		switch ticket.OutputType {
+		case "jira":
+			ticketStatus, alertDeliveryError = outputClient.JiraTicketStatus(ticket, output.OutputConfig.Jira)
+		case "github":
+			ticketStatus, alertDeliveryError = outputClient.GithubTicketStatus(ticket, output.OutputConfig.Github)
+		default:
+			continue
+		}
+
+		if alertDeliveryError != nil {
+			return "", true, alertDeliveryError
+		}
+		if ticketStatus == "" {
+			return "", true, nil // still open
+		}
+		if status != alertapimodels.ResolvedStatus {
+			status = ticketStatus
+		}
+	}
+	return status, synced, nil
+}
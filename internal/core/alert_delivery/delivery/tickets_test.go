@@ -0,0 +1,208 @@
+package delivery
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	alertapimodels "github.com/panther-labs/panther/api/lambda/alerts/models"
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+	"github.com/panther-labs/panther/internal/core/alert_delivery/outputs"
+)
+
+var jiraOutput = &outputmodels.AlertOutput{
+	OutputType:  aws.String("jira"),
+	DisplayName: aws.String("jira:alerts"),
+	OutputConfig: &outputmodels.OutputConfig{
+		Jira: &outputmodels.JiraConfig{OrgDomain: "https://example.atlassian.net", ProjectKey: "SEC"},
+	},
+	OutputID: aws.String("jira-output-id"),
+}
+
+func setTicketCaches() {
+	setCaches()
+	cache.Outputs = append(cache.Outputs, jiraOutput)
+}
+
+// invokedInput returns the alerts-api request sent with a lambda invocation
+func invokedInput(t *testing.T, call mock.Call) *alertapimodels.LambdaInput {
+	var input alertapimodels.LambdaInput
+	require.NoError(t, jsoniter.Unmarshal(call.Arguments.Get(0).(*lambda.InvokeInput).Payload, &input))
+	return &input
+}
+
+func TestSendLinksTicket(t *testing.T) {
+	setTicketCaches()
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+	mockLambda := &mockLambdaClient{}
+	lambdaClient = mockLambda
+
+	alert := sampleAlert()
+	alert.AlertID = aws.String("alert-id")
+	ticket := &alertapimodels.Ticket{OutputType: "jira", Key: "SEC-1"}
+	mockClient.On("Jira", alert, jiraOutput.OutputConfig.Jira).Return(ticket, (*outputs.AlertDeliveryError)(nil))
+	mockLambda.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{}, nil).Once()
+
+	ch := make(chan outputStatus, 1)
+	send(alert, jiraOutput, ch)
+	assert.Equal(t, outputStatus{outputID: "jira-output-id", success: true}, <-ch)
+
+	input := invokedInput(t, mockLambda.Calls[0])
+	require.NotNil(t, input.AddAlertTicket)
+	assert.Equal(t, "alert-id", *input.AddAlertTicket.AlertID)
+	assert.Equal(t, "jira-output-id", input.AddAlertTicket.Ticket.OutputID)
+	assert.Equal(t, "SEC-1", input.AddAlertTicket.Ticket.Key)
+	assert.False(t, input.AddAlertTicket.Ticket.CreatedAt.IsZero())
+	mockClient.AssertExpectations(t)
+}
+
+func TestSendLinkTicketFailureIsNotRetried(t *testing.T) {
+	setTicketCaches()
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+	mockLambda := &mockLambdaClient{}
+	lambdaClient = mockLambda
+
+	alert := sampleAlert()
+	alert.AlertID = aws.String("alert-id")
+	ticket := &alertapimodels.Ticket{OutputType: "jira", Key: "SEC-1"}
+	mockClient.On("Jira", alert, jiraOutput.OutputConfig.Jira).Return(ticket, (*outputs.AlertDeliveryError)(nil))
+	mockLambda.On("Invoke", mock.Anything).Return((*lambda.InvokeOutput)(nil), assert.AnError).Once()
+
+	ch := make(chan outputStatus, 1)
+	send(alert, jiraOutput, ch)
+	assert.Equal(t, outputStatus{outputID: "jira-output-id", success: true}, <-ch)
+	mockLambda.AssertExpectations(t)
+}
+
+func TestHandleAlertsUpdatesTickets(t *testing.T) {
+	setTicketCaches()
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+
+	ticket := &alertapimodels.Ticket{OutputID: "jira-output-id", OutputType: "jira", Key: "SEC-1"}
+	alert := sampleAlert()
+	alert.Update = &alertmodels.AlertUpdate{EventCount: 10, Tickets: []*alertapimodels.Ticket{
+		ticket,
+		{OutputID: "deleted-output-id", OutputType: "github", Key: "1"},
+	}}
+	mockClient.On("JiraComment", alert, ticket, jiraOutput.OutputConfig.Jira).Return((*outputs.AlertDeliveryError)(nil)).Once()
+
+	HandleAlerts([]*alertmodels.Alert{alert})
+	mockClient.AssertExpectations(t)
+}
+
+func TestUpdateTicketsRetriesFailedTickets(t *testing.T) {
+	setTicketCaches()
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+
+	failing := &alertapimodels.Ticket{OutputID: "jira-output-id", OutputType: "jira", Key: "SEC-1"}
+	permanent := &alertapimodels.Ticket{OutputID: "jira-output-id", OutputType: "jira", Key: "SEC-2"}
+	alert := sampleAlert()
+	alert.Update = &alertmodels.AlertUpdate{EventCount: 10, Tickets: []*alertapimodels.Ticket{failing, permanent}}
+	mockClient.On("JiraComment", alert, failing, mock.Anything).Return(&outputs.AlertDeliveryError{}).Once()
+	mockClient.On("JiraComment", alert, permanent, mock.Anything).Return(&outputs.AlertDeliveryError{Permanent: true}).Once()
+
+	assert.False(t, updateTickets(alert))
+	assert.Equal(t, []*alertapimodels.Ticket{failing}, alert.Update.Tickets)
+	mockClient.AssertExpectations(t)
+}
+
+func TestSyncTickets(t *testing.T) {
+	setTicketCaches()
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+	mockLambda := &mockLambdaClient{}
+	lambdaClient = mockLambda
+
+	done := &alertapimodels.Ticket{OutputID: "jira-output-id", OutputType: "jira", Key: "SEC-1"}
+	wontDo := &alertapimodels.Ticket{OutputID: "jira-output-id", OutputType: "jira", Key: "SEC-2"}
+	open := &alertapimodels.Ticket{OutputID: "jira-output-id", OutputType: "jira", Key: "SEC-3"}
+	alerts := alertapimodels.ListAlertTicketsOutput{
+		// resolved: one ticket was fixed, the other was closed
+		{AlertID: aws.String("alert-1"), Status: alertapimodels.OpenStatus, Tickets: []*alertapimodels.Ticket{wontDo, done}},
+		// still open
+		{AlertID: aws.String("alert-2"), Status: alertapimodels.TriagedStatus, Tickets: []*alertapimodels.Ticket{open}},
+	}
+	payload, err := jsoniter.Marshal(alerts)
+	require.NoError(t, err)
+
+	mockLambda.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{Payload: payload}, nil).Once()
+	mockLambda.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{Payload: []byte("{}")}, nil).Once()
+	mockClient.On("JiraTicketStatus", done, mock.Anything).Return(alertapimodels.ResolvedStatus, (*outputs.AlertDeliveryError)(nil))
+	mockClient.On("JiraTicketStatus", wontDo, mock.Anything).Return(alertapimodels.ClosedStatus, (*outputs.AlertDeliveryError)(nil))
+	mockClient.On("JiraTicketStatus", open, mock.Anything).Return("", (*outputs.AlertDeliveryError)(nil))
+
+	require.NoError(t, SyncTickets())
+	mockClient.AssertExpectations(t)
+	mockLambda.AssertExpectations(t)
+
+	require.NotNil(t, invokedInput(t, mockLambda.Calls[0]).ListAlertTickets)
+	update := invokedInput(t, mockLambda.Calls[1]).SyncAlertTickets
+	require.NotNil(t, update)
+	assert.Equal(t, "alert-1", *update.AlertID)
+	assert.Equal(t, alertapimodels.ResolvedStatus, *update.Status)
+}
+
+func TestSyncTicketsDeletedOutputs(t *testing.T) {
+	setTicketCaches()
+	mockClient := &mockOutputsClient{}
+	outputClient = mockClient
+	mockLambda := &mockLambdaClient{}
+	lambdaClient = mockLambda
+
+	deleted := &alertapimodels.Ticket{OutputID: "deleted-output-id", OutputType: "jira", Key: "SEC-1"}
+	alerts := alertapimodels.ListAlertTicketsOutput{
+		{AlertID: aws.String("alert-1"), Status: alertapimodels.OpenStatus, Tickets: []*alertapimodels.Ticket{deleted}},
+	}
+	payload, err := jsoniter.Marshal(alerts)
+	require.NoError(t, err)
+
+	mockLambda.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{Payload: payload}, nil).Once()
+	mockLambda.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{Payload: []byte("{}")}, nil).Once()
+
+	require.NoError(t, SyncTickets())
+	mockClient.AssertExpectations(t) // no ticket status lookup
+	mockLambda.AssertExpectations(t)
+
+	// The alert is removed from the sync without changing its status
+	update := invokedInput(t, mockLambda.Calls[1]).SyncAlertTickets
+	require.NotNil(t, update)
+	assert.Equal(t, "alert-1", *update.AlertID)
+	assert.Nil(t, update.Status)
+}
+
+func TestSyncTicketsListError(t *testing.T) {
+	setTicketCaches()
+	mockLambda := &mockLambdaClient{}
+	lambdaClient = mockLambda
+	mockLambda.On("Invoke", mock.Anything).Return((*lambda.InvokeOutput)(nil), assert.AnError).Once()
+
+	require.Error(t, SyncTickets())
+}
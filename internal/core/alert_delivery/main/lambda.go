@@ -20,6 +20,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
@@ -37,7 +38,36 @@ import (
 
 var validate = validator.New()
 
-func lambdaHandler(ctx context.Context, event events.SQSEvent) (err error) {
+func lambdaHandler(ctx context.Context, input json.RawMessage) error {
+	// There are two different kinds of requests handled by this function:
+	// scheduled events which flush alert digests and sync tickets, and batches of alerts from the SQS queue
+	var scheduledEvent events.CloudWatchEvent
+	if err := jsoniter.Unmarshal(input, &scheduledEvent); err == nil && scheduledEvent.DetailType == "Scheduled Event" {
+		return scheduledHandler(ctx)
+	}
+
+	var event events.SQSEvent
+	if err := jsoniter.Unmarshal(input, &event); err != nil {
+		return errors.Wrap(err, "failed to unmarshal lambda input")
+	}
+	return alertsHandler(ctx, event)
+}
+
+func scheduledHandler(ctx context.Context) (err error) {
+	lc, _ := lambdalogger.ConfigureGlobal(ctx, nil)
+	operation := oplog.NewManager("core", "alert_delivery").Start(lc.InvokedFunctionArn).WithMemUsed(lambdacontext.MemoryLimitInMB)
+	defer func() {
+		operation.Stop().Log(err, zap.String("action", "scheduled"))
+	}()
+
+	if err = delivery.FlushDigests(); err != nil {
+		operation.LogError(errors.Wrap(err, "failed to flush digests"))
+	}
+	err = delivery.SyncTickets()
+	return err
+}
+
+func alertsHandler(ctx context.Context, event events.SQSEvent) (err error) {
 	var alerts []*models.Alert
 
 	lc, _ := lambdalogger.ConfigureGlobal(ctx, nil)
@@ -18,7 +18,11 @@ package models
  * along with this program.  If not, see <https://www.gnu.org/licenses/>.
  */
 
-import "time"
+import (
+	"time"
+
+	alertapimodels "github.com/panther-labs/panther/api/lambda/alerts/models"
+)
 
 const (
 	// RuleType identifies the Alert to be for a Policy
@@ -65,4 +69,17 @@ type Alert struct {
 
 	// Title is the optional title for the alert generated by Python Rules engine
 	Title *string `json:"title,omitempty"`
+
+	// Update is set when the message reports new activity on an alert which already has tickets.
+	// Instead of being delivered again, the alert's tickets are updated.
+	Update *AlertUpdate `json:"update,omitempty"`
+}
+
+// AlertUpdate is new activity on an existing alert
+type AlertUpdate struct {
+	// EventCount is the total number of events which matched the alert
+	EventCount int `json:"eventCount"`
+
+	// Tickets are the issues to update, as stored on the alert
+	Tickets []*alertapimodels.Ticket `json:"tickets" validate:"min=1,dive,required"`
 }
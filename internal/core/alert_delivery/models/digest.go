@@ -0,0 +1,103 @@
+package models
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"sort"
+	"time"
+)
+
+// Severity ordering used to sort digest groups, most severe first.
+var severityRank = map[string]int{
+	"CRITICAL": 0,
+	"HIGH":     1,
+	"MEDIUM":   2,
+	"LOW":      3,
+	"INFO":     4,
+}
+
+// Digest is a summary of all alerts accumulated for one output during its digest window.
+type Digest struct {
+	// OutputID is the output the digest is delivered to.
+	OutputID string `json:"outputId"`
+
+	// Start is the creation time of the oldest alert in the digest.
+	Start time.Time `json:"start"`
+
+	// End is the creation time of the newest alert in the digest.
+	End time.Time `json:"end"`
+
+	// AlertCount is the total number of alerts in the digest.
+	AlertCount int `json:"alertCount"`
+
+	// Groups contains the alerts grouped by rule/policy and severity.
+	Groups []*DigestGroup `json:"groups"`
+}
+
+// DigestGroup is the set of alerts in a digest which share a rule/policy and severity.
+type DigestGroup struct {
+	AnalysisID   string   `json:"analysisId"`
+	AnalysisName *string  `json:"analysisName"`
+	Type         string   `json:"type"`
+	Severity     string   `json:"severity"`
+	Alerts       []*Alert `json:"alerts"`
+}
+
+// NewDigest groups alerts by analysis and severity.
+//
+// Groups are ordered by severity (most severe first) and then by the number of alerts.
+func NewDigest(outputID string, alerts []*Alert) *Digest {
+	digest := &Digest{OutputID: outputID, AlertCount: len(alerts)}
+	groups := make(map[[2]string]*DigestGroup)
+
+	for _, alert := range alerts {
+		if digest.Start.IsZero() || alert.CreatedAt.Before(digest.Start) {
+			digest.Start = alert.CreatedAt
+		}
+		if alert.CreatedAt.After(digest.End) {
+			digest.End = alert.CreatedAt
+		}
+
+		key := [2]string{alert.AnalysisID, alert.Severity}
+		group, ok := groups[key]
+		if !ok {
+			group = &DigestGroup{
+				AnalysisID:   alert.AnalysisID,
+				AnalysisName: alert.AnalysisName,
+				Type:         alert.Type,
+				Severity:     alert.Severity,
+			}
+			groups[key] = group
+			digest.Groups = append(digest.Groups, group)
+		}
+		group.Alerts = append(group.Alerts, alert)
+	}
+
+	sort.SliceStable(digest.Groups, func(i, j int) bool {
+		left, right := digest.Groups[i], digest.Groups[j]
+		if left.Severity != right.Severity {
+			return severityRank[left.Severity] < severityRank[right.Severity]
+		}
+		if len(left.Alerts) != len(right.Alerts) {
+			return len(left.Alerts) > len(right.Alerts)
+		}
+		return left.AnalysisID < right.AnalysisID
+	})
+	return digest
+}
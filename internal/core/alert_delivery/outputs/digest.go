@@ -0,0 +1,285 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sns"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+// MaxDigestPayloadBytes is the largest digest payload delivered to SNS or a custom webhook.
+//
+// SNS rejects messages larger than 256KB.
+const MaxDigestPayloadBytes = 256 * 1024
+
+// DigestNotification is the default payload delivered for a digest of many alerts.
+//
+// Like Notification, it never uses `omitempty` so the keys are always present.
+type DigestNotification struct {
+	// [REQUIRED] The title for this digest
+	Title string `json:"title"`
+
+	// [REQUIRED] The creation timestamp (RFC3339) of the oldest alert in the digest
+	Start time.Time `json:"start"`
+
+	// [REQUIRED] The creation timestamp (RFC3339) of the newest alert in the digest
+	End time.Time `json:"end"`
+
+	// [REQUIRED] The total number of alerts in the digest
+	AlertCount int `json:"alertCount"`
+
+	// [REQUIRED] The alerts grouped by rule/policy and severity
+	Groups []DigestNotificationGroup `json:"groups"`
+}
+
+// DigestNotificationGroup summarizes the alerts for one rule/policy and severity.
+type DigestNotificationGroup struct {
+	// [REQUIRED] The Policy or Rule ID
+	ID string `json:"id"`
+
+	// [REQUIRED] The Name of the Rule or Policy
+	Name *string `json:"name"`
+
+	// [REQUIRED] The Type enum if the alerts are for a rule or policy. Will be one of RULE POLICY.
+	Type string `json:"type"`
+
+	// [REQUIRED] The severity enum shared by all alerts in the group
+	Severity string `json:"severity"`
+
+	// [REQUIRED] The number of alerts in the group
+	Count int `json:"count"`
+
+	// [REQUIRED] Link to the most recent alert in Panther UI
+	Link string `json:"link"`
+
+	// [REQUIRED] The individual alert notifications
+	Alerts []Notification `json:"alerts"`
+}
+
+func generateDigestNotification(digest *alertmodels.Digest) DigestNotification {
+	notification := DigestNotification{
+		Title:      generateDigestTitle(digest),
+		Start:      digest.Start,
+		End:        digest.End,
+		AlertCount: digest.AlertCount,
+		Groups:     make([]DigestNotificationGroup, 0, len(digest.Groups)),
+	}
+	for _, group := range digest.Groups {
+		notificationGroup := DigestNotificationGroup{
+			ID:       group.AnalysisID,
+			Name:     group.AnalysisName,
+			Type:     group.Type,
+			Severity: group.Severity,
+			Count:    len(group.Alerts),
+			Link:     generateDigestGroupURL(group),
+			Alerts:   make([]Notification, 0, len(group.Alerts)),
+		}
+		for _, alert := range group.Alerts {
+			notificationGroup.Alerts = append(notificationGroup.Alerts, generateNotificationFromAlert(alert))
+		}
+		notification.Groups = append(notification.Groups, notificationGroup)
+	}
+	gatewayapi.ReplaceMapSliceNils(&notification)
+	return notification
+}
+
+func generateDigestTitle(digest *alertmodels.Digest) string {
+	if digest.AlertCount == 1 {
+		return "Alert Digest: 1 alert"
+	}
+	return fmt.Sprintf("Alert Digest: %d alerts", digest.AlertCount)
+}
+
+func generateDigestGroupTitle(group *alertmodels.DigestGroup) string {
+	name := group.AnalysisID
+	if aws.StringValue(group.AnalysisName) != "" {
+		name = *group.AnalysisName
+	}
+	if len(group.Alerts) == 1 {
+		return fmt.Sprintf("[%s] %s (1 alert)", group.Severity, name)
+	}
+	return fmt.Sprintf("[%s] %s (%d alerts)", group.Severity, name, len(group.Alerts))
+}
+
+// The link for a group points to its most recent alert
+func generateDigestGroupURL(group *alertmodels.DigestGroup) string {
+	latest := group.Alerts[0]
+	for _, alert := range group.Alerts[1:] {
+		if alert.CreatedAt.After(latest.CreatedAt) {
+			latest = alert
+		}
+	}
+	return generateURL(latest)
+}
+
+func generateDetailedDigestMessage(digest *alertmodels.Digest) string {
+	lines := []string{
+		fmt.Sprintf("%s between %s and %s",
+			generateDigestTitle(digest), digest.Start.Format(time.RFC3339), digest.End.Format(time.RFC3339)),
+	}
+	for _, group := range digest.Groups {
+		lines = append(lines, fmt.Sprintf("%s: %s", generateDigestGroupTitle(group), generateDigestGroupURL(group)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// SlackDigest sends a summary of many alerts to a slack channel.
+func (client *OutputClient) SlackDigest(digest *alertmodels.Digest, config *outputmodels.SlackConfig) *AlertDeliveryError {
+	attachments := make([]map[string]interface{}, 0, len(digest.Groups))
+	for _, group := range digest.Groups {
+		attachments = append(attachments, map[string]interface{}{
+			"fallback":   generateDigestGroupTitle(group),
+			"color":      severityColors[group.Severity],
+			"title":      generateDigestGroupTitle(group),
+			"title_link": generateDigestGroupURL(group),
+		})
+	}
+
+	payload := map[string]interface{}{
+		"text":        generateDigestTitle(digest),
+		"attachments": attachments,
+	}
+	postInput := &PostInput{
+		url:  config.WebhookURL,
+		body: payload,
+	}
+
+	return client.httpWrapper.post(postInput)
+}
+
+// MsTeamsDigest sends a summary of many alerts to a Microsoft Teams channel.
+func (client *OutputClient) MsTeamsDigest(
+	digest *alertmodels.Digest, config *outputmodels.MsTeamsConfig) *AlertDeliveryError {
+
+	facts := make([]interface{}, 0, len(digest.Groups))
+	for _, group := range digest.Groups {
+		facts = append(facts, map[string]string{
+			"name":  generateDigestGroupTitle(group),
+			"value": "[Click here to view in the Panther UI](" + generateDigestGroupURL(group) + ")",
+		})
+	}
+
+	msTeamsRequestBody := map[string]interface{}{
+		"@context": "http://schema.org/extensions",
+		"@type":    "MessageCard",
+		"text":     generateDigestTitle(digest),
+		"sections": []interface{}{
+			map[string]interface{}{
+				"facts": facts,
+			},
+		},
+	}
+
+	postInput := &PostInput{
+		url:  config.WebhookURL,
+		body: msTeamsRequestBody,
+	}
+	return client.httpWrapper.post(postInput)
+}
+
+// DigestPayloadSize returns the size in bytes of the payload delivered for a digest.
+//
+// Slack and Microsoft Teams only receive one line per group, so their size is not checked.
+func DigestPayloadSize(digest *alertmodels.Digest, outputType string) (int, error) {
+	switch outputType {
+	case "sns":
+		message, err := snsDigestMessage(digest)
+		return len(message), err
+	case "customwebhook":
+		body, err := jsoniter.Marshal(generateDigestNotification(digest))
+		return len(body), errors.Wrap(err, "failed to serialize digest")
+	default:
+		return 0, nil
+	}
+}
+
+func snsDigestMessage(digest *alertmodels.Digest) (string, error) {
+	serializedDefaultMessage, err := jsoniter.MarshalToString(generateDigestNotification(digest))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to serialize default message")
+	}
+
+	outputMessage := &snsMessage{
+		DefaultMessage: serializedDefaultMessage,
+		EmailMessage:   generateDetailedDigestMessage(digest),
+	}
+
+	serializedMessage, err := jsoniter.MarshalToString(outputMessage)
+	return serializedMessage, errors.Wrap(err, "failed to serialize message")
+}
+
+// SnsDigest sends a summary of many alerts to an SNS Topic.
+func (client *OutputClient) SnsDigest(digest *alertmodels.Digest, config *outputmodels.SnsConfig) *AlertDeliveryError {
+	serializedMessage, err := snsDigestMessage(digest)
+	if err != nil {
+		errorMsg := "Failed to serialize message"
+		zap.L().Error(errorMsg, zap.Error(errors.WithStack(err)))
+		return &AlertDeliveryError{Message: errorMsg, Permanent: true}
+	}
+
+	// Retrying would never succeed, SNS rejects the message every time
+	if len(serializedMessage) > MaxDigestPayloadBytes {
+		errorMsg := "Digest is larger than the SNS message size limit"
+		zap.L().Error(errorMsg, zap.Int("bytes", len(serializedMessage)))
+		return &AlertDeliveryError{Message: errorMsg, Permanent: true}
+	}
+
+	snsMessageInput := &sns.PublishInput{
+		TopicArn:         aws.String(config.TopicArn),
+		Message:          aws.String(serializedMessage),
+		Subject:          aws.String(generateDigestTitle(digest)),
+		MessageStructure: aws.String("json"),
+	}
+
+	snsClient, err := client.getSnsClient(config.TopicArn)
+	if err != nil {
+		errorMsg := "Failed to create SNS client for topic"
+		zap.L().Error(errorMsg, zap.Error(errors.WithStack(err)))
+		return &AlertDeliveryError{Message: errorMsg, Permanent: true}
+	}
+
+	if _, err = snsClient.Publish(snsMessageInput); err != nil {
+		errorMsg := "Failed to send message to SNS topic"
+		zap.L().Error(errorMsg, zap.Error(errors.WithStack(err)))
+		return &AlertDeliveryError{Message: errorMsg}
+	}
+	return nil
+}
+
+// CustomWebhookDigest sends a summary of many alerts to a custom webhook.
+func (client *OutputClient) CustomWebhookDigest(
+	digest *alertmodels.Digest, config *outputmodels.CustomWebhookConfig) *AlertDeliveryError {
+
+	postInput := &PostInput{
+		url:  config.WebhookURL,
+		body: generateDigestNotification(digest),
+	}
+	return client.httpWrapper.post(postInput)
+}
@@ -0,0 +1,195 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+var digestStart = time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func sampleDigest() *alertmodels.Digest {
+	return alertmodels.NewDigest("output-id", []*alertmodels.Alert{
+		{
+			AnalysisID:   "rule.one",
+			AnalysisName: aws.String("Rule One"),
+			AlertID:      aws.String("alert-1"),
+			Type:         alertmodels.RuleType,
+			Severity:     "INFO",
+			CreatedAt:    digestStart,
+		},
+		{
+			AnalysisID: "policy.one",
+			Type:       alertmodels.PolicyType,
+			Severity:   "MEDIUM",
+			CreatedAt:  digestStart.Add(time.Minute),
+		},
+		{
+			AnalysisID:   "rule.one",
+			AnalysisName: aws.String("Rule One"),
+			AlertID:      aws.String("alert-2"),
+			Type:         alertmodels.RuleType,
+			Severity:     "INFO",
+			CreatedAt:    digestStart.Add(2 * time.Minute),
+		},
+	})
+}
+
+func TestNewDigestGroups(t *testing.T) {
+	digest := sampleDigest()
+	assert.Equal(t, 3, digest.AlertCount)
+	assert.Equal(t, digestStart, digest.Start)
+	assert.Equal(t, digestStart.Add(2*time.Minute), digest.End)
+	require.Len(t, digest.Groups, 2)
+
+	// MEDIUM sorts before INFO
+	assert.Equal(t, "policy.one", digest.Groups[0].AnalysisID)
+	assert.Len(t, digest.Groups[0].Alerts, 1)
+	assert.Equal(t, "rule.one", digest.Groups[1].AnalysisID)
+	assert.Len(t, digest.Groups[1].Alerts, 2)
+}
+
+func TestSlackDigest(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client := &OutputClient{httpWrapper: httpWrapper}
+
+	expectedPostInput := &PostInput{
+		url: slackConfig.WebhookURL,
+		body: map[string]interface{}{
+			"text": "Alert Digest: 3 alerts",
+			"attachments": []map[string]interface{}{
+				{
+					"fallback":   "[MEDIUM] policy.one (1 alert)",
+					"color":      "#d9822b",
+					"title":      "[MEDIUM] policy.one (1 alert)",
+					"title_link": "https://panther.io/policies/policy.one",
+				},
+				{
+					"fallback":   "[INFO] Rule One (2 alerts)",
+					"color":      "#47b881",
+					"title":      "[INFO] Rule One (2 alerts)",
+					"title_link": "https://panther.io/alerts/alert-2",
+				},
+			},
+		},
+	}
+	httpWrapper.On("post", expectedPostInput).Return((*AlertDeliveryError)(nil))
+
+	require.Nil(t, client.SlackDigest(sampleDigest(), slackConfig))
+	httpWrapper.AssertExpectations(t)
+}
+
+func TestMsTeamsDigest(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client := &OutputClient{httpWrapper: httpWrapper}
+	config := &outputmodels.MsTeamsConfig{WebhookURL: "msteam-url"}
+
+	httpWrapper.On("post", mock.MatchedBy(func(input *PostInput) bool {
+		body := input.body.(map[string]interface{})
+		return input.url == config.WebhookURL && body["text"] == "Alert Digest: 3 alerts"
+	})).Return((*AlertDeliveryError)(nil))
+
+	require.Nil(t, client.MsTeamsDigest(sampleDigest(), config))
+	httpWrapper.AssertExpectations(t)
+}
+
+func TestCustomWebhookDigest(t *testing.T) {
+	httpWrapper := &mockHTTPWrapper{}
+	client := &OutputClient{httpWrapper: httpWrapper}
+	config := &outputmodels.CustomWebhookConfig{WebhookURL: "custom-webhook-url"}
+
+	httpWrapper.On("post", mock.MatchedBy(func(input *PostInput) bool {
+		notification := input.body.(DigestNotification)
+		return input.url == config.WebhookURL &&
+			notification.AlertCount == 3 &&
+			len(notification.Groups) == 2 &&
+			notification.Groups[1].Count == 2 &&
+			len(notification.Groups[1].Alerts) == 2
+	})).Return((*AlertDeliveryError)(nil))
+
+	require.Nil(t, client.CustomWebhookDigest(sampleDigest(), config))
+	httpWrapper.AssertExpectations(t)
+}
+
+func TestSnsDigest(t *testing.T) {
+	client := &testutils.SnsMock{}
+	outputClient := &OutputClient{snsClients: map[string]snsiface.SNSAPI{"us-west-2": client}}
+	config := &outputmodels.SnsConfig{TopicArn: "arn:aws:sns:us-west-2:123456789012:test-sns-output"}
+
+	client.On("Publish", mock.MatchedBy(func(input *sns.PublishInput) bool {
+		var message snsMessage
+		if err := jsoniter.UnmarshalFromString(*input.Message, &message); err != nil {
+			return false
+		}
+		return *input.Subject == "Alert Digest: 3 alerts" &&
+			message.EmailMessage == "Alert Digest: 3 alerts between 2020-06-01T12:00:00Z and 2020-06-01T12:02:00Z\n"+
+				"[MEDIUM] policy.one (1 alert): https://panther.io/policies/policy.one\n"+
+				"[INFO] Rule One (2 alerts): https://panther.io/alerts/alert-2"
+	})).Return(&sns.PublishOutput{}, nil)
+
+	assert.Nil(t, outputClient.SnsDigest(sampleDigest(), config))
+	client.AssertExpectations(t)
+}
+
+func TestSnsDigestTooLarge(t *testing.T) {
+	client := &testutils.SnsMock{}
+	outputClient := &OutputClient{snsClients: map[string]snsiface.SNSAPI{"us-west-2": client}}
+	config := &outputmodels.SnsConfig{TopicArn: "arn:aws:sns:us-west-2:123456789012:test-sns-output"}
+
+	digest := sampleDigest()
+	digest.Groups[0].Alerts[0].AnalysisDescription = aws.String(strings.Repeat("x", MaxDigestPayloadBytes))
+
+	// The message is never published, and the error is not retried
+	result := outputClient.SnsDigest(digest, config)
+	require.NotNil(t, result)
+	assert.True(t, result.Permanent)
+	client.AssertExpectations(t)
+}
+
+func TestDigestPayloadSize(t *testing.T) {
+	size, err := DigestPayloadSize(sampleDigest(), "slack")
+	require.NoError(t, err)
+	assert.Equal(t, 0, size)
+
+	size, err = DigestPayloadSize(sampleDigest(), "customwebhook")
+	require.NoError(t, err)
+	body, err := jsoniter.Marshal(generateDigestNotification(sampleDigest()))
+	require.NoError(t, err)
+	assert.Equal(t, len(body), size)
+
+	message, err := snsDigestMessage(sampleDigest())
+	require.NoError(t, err)
+	size, err = DigestPayloadSize(sampleDigest(), "sns")
+	require.NoError(t, err)
+	assert.Equal(t, len(message), size)
+}
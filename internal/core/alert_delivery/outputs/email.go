@@ -0,0 +1,122 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+)
+
+const defaultSMTPPort = 587
+
+// sendMail is a variable so tests can capture messages instead of connecting to a server.
+//
+// STARTTLS is used whenever the server supports it.
+var sendMail = smtp.SendMail
+
+const emailHTMLTemplate = `<html><body>
+<h2>%s</h2>
+<p><a href="%s">Click here to view in the Panther UI</a></p>
+<p><strong>Severity:</strong> %s</p>
+<p><strong>Description:</strong> %s</p>
+<p><strong>Runbook:</strong> %s</p>
+<p><strong>Tags:</strong> %s</p>
+</body></html>
+`
+
+// Email alert send an email with a plain text and an HTML body.
+func (client *OutputClient) Email(
+	alert *alertmodels.Alert, config *outputmodels.EmailConfig) *AlertDeliveryError {
+
+	message, err := generateEmailMessage(alert, config)
+	if err != nil {
+		return &AlertDeliveryError{Message: "email message error: " + err.Error(), Permanent: true}
+	}
+
+	port := defaultSMTPPort
+	if config.Port != nil {
+		port = *config.Port
+	}
+
+	var auth smtp.Auth
+	if config.UserName != "" {
+		auth = smtp.PlainAuth("", config.UserName, config.Password, config.Host)
+	}
+
+	address := net.JoinHostPort(config.Host, strconv.Itoa(port))
+	if err = sendMail(address, auth, config.From, config.To, message); err != nil {
+		return &AlertDeliveryError{Message: "smtp error: " + err.Error()}
+	}
+	return nil
+}
+
+func generateEmailMessage(alert *alertmodels.Alert, config *outputmodels.EmailConfig) ([]byte, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	textPart, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {"text/plain; charset=UTF-8"}})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = textPart.Write([]byte(generateDetailedAlertMessage(alert))); err != nil {
+		return nil, err
+	}
+
+	htmlPart, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {"text/html; charset=UTF-8"}})
+	if err != nil {
+		return nil, err
+	}
+	htmlBody := fmt.Sprintf(emailHTMLTemplate,
+		html.EscapeString(generateAlertTitle(alert)),
+		html.EscapeString(generateURL(alert)),
+		html.EscapeString(alert.Severity),
+		html.EscapeString(aws.StringValue(alert.AnalysisDescription)),
+		html.EscapeString(aws.StringValue(alert.Runbook)),
+		html.EscapeString(strings.Join(alert.Tags, ", ")),
+	)
+	if _, err = htmlPart.Write([]byte(htmlBody)); err != nil {
+		return nil, err
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+
+	var message bytes.Buffer
+	message.WriteString("From: " + config.From + "\r\n")
+	message.WriteString("To: " + strings.Join(config.To, ", ") + "\r\n")
+	message.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", generateAlertTitle(alert)) + "\r\n")
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: multipart/alternative; boundary=" + writer.Boundary() + "\r\n")
+	message.WriteString("\r\n")
+	message.Write(body.Bytes())
+	return message.Bytes(), nil
+}
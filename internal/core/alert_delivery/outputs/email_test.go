@@ -0,0 +1,140 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/smtp"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+)
+
+var emailConfig = &outputmodels.EmailConfig{
+	Host:     "smtp.example.com",
+	UserName: "panther",
+	Password: "secret",
+	From:     "alerts@example.com",
+	To:       []string{"soc@example.com", "oncall@example.com"},
+}
+
+type sentMail struct {
+	address string
+	auth    smtp.Auth
+	from    string
+	to      []string
+	message []byte
+}
+
+func captureMail(t *testing.T, err error) *sentMail {
+	sent := &sentMail{}
+	defaultSendMail := sendMail
+	sendMail = func(address string, auth smtp.Auth, from string, to []string, message []byte) error {
+		sent.address, sent.auth, sent.from, sent.to, sent.message = address, auth, from, to, message
+		return err
+	}
+	t.Cleanup(func() { sendMail = defaultSendMail })
+	return sent
+}
+
+func TestEmailAlert(t *testing.T) {
+	sent := captureMail(t, nil)
+	client := &OutputClient{}
+
+	createdAtTime, err := time.Parse(time.RFC3339, "2019-08-03T11:40:13Z")
+	require.NoError(t, err)
+	alert := &alertmodels.Alert{
+		AnalysisID:          "policyId",
+		CreatedAt:           createdAtTime,
+		AnalysisName:        aws.String("<policy name>"),
+		AnalysisDescription: aws.String("description"),
+		Severity:            "HIGH",
+		Tags:                []string{"tag"},
+	}
+
+	require.Nil(t, client.Email(alert, emailConfig))
+	assert.Equal(t, "smtp.example.com:587", sent.address)
+	assert.NotNil(t, sent.auth)
+	assert.Equal(t, "alerts@example.com", sent.from)
+	assert.Equal(t, emailConfig.To, sent.to)
+
+	message, err := mail.ReadMessage(bytes.NewReader(sent.message))
+	require.NoError(t, err)
+	assert.Equal(t, "Policy Failure: <policy name>", decodeHeader(t, message.Header.Get("Subject")))
+	assert.Equal(t, "soc@example.com, oncall@example.com", message.Header.Get("To"))
+
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	reader := multipart.NewReader(message.Body, params["boundary"])
+	textPart, err := reader.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "text/plain; charset=UTF-8", textPart.Header.Get("Content-Type"))
+	text, err := ioutil.ReadAll(textPart)
+	require.NoError(t, err)
+	assert.Equal(t, generateDetailedAlertMessage(alert), string(text))
+
+	htmlPart, err := reader.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "text/html; charset=UTF-8", htmlPart.Header.Get("Content-Type"))
+	htmlBody, err := ioutil.ReadAll(htmlPart)
+	require.NoError(t, err)
+	assert.Contains(t, string(htmlBody), "<h2>Policy Failure: &lt;policy name&gt;</h2>")
+	assert.Contains(t, string(htmlBody), `<a href="https://panther.io/policies/policyId">`)
+}
+
+func TestEmailAlertNoAuthCustomPort(t *testing.T) {
+	sent := captureMail(t, nil)
+	config := &outputmodels.EmailConfig{
+		Host: "relay.internal",
+		Port: aws.Int(25),
+		From: "alerts@example.com",
+		To:   []string{"soc@example.com"},
+	}
+
+	require.Nil(t, (&OutputClient{}).Email(&alertmodels.Alert{AnalysisID: "policyId", Severity: "INFO"}, config))
+	assert.Equal(t, "relay.internal:25", sent.address)
+	assert.Nil(t, sent.auth)
+}
+
+func TestEmailAlertError(t *testing.T) {
+	captureMail(t, errors.New("connection refused"))
+
+	err := (&OutputClient{}).Email(&alertmodels.Alert{AnalysisID: "policyId", Severity: "INFO"}, emailConfig)
+	require.NotNil(t, err)
+	assert.False(t, err.Permanent)
+}
+
+func decodeHeader(t *testing.T, header string) string {
+	decoded, err := new(mime.WordDecoder).DecodeHeader(header)
+	require.NoError(t, err)
+	return decoded
+}
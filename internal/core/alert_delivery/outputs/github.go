@@ -19,23 +19,35 @@ package outputs
  */
 
 import (
+	"strconv"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 
+	alertapimodels "github.com/panther-labs/panther/api/lambda/alerts/models"
 	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
 	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
 )
 
 // Severity colors match those in the Panther UI
 const (
-	githubEndpoint = "https://api.github.com/repos/"
-	requestType    = "/issues"
+	requestType = "/issues"
 )
 
+// githubEndpoint is a variable so tests can point it at a local server
+var githubEndpoint = "https://api.github.com/repos/"
+
+// githubIssue is the part of the Github issue response we need
+type githubIssue struct {
+	Number      int    `json:"number"`
+	HTMLURL     string `json:"html_url"`
+	State       string `json:"state"`
+	StateReason string `json:"state_reason"`
+}
+
 // Github alert send an issue.
 func (client *OutputClient) Github(
-	alert *alertmodels.Alert, config *outputmodels.GithubConfig) *AlertDeliveryError {
+	alert *alertmodels.Alert, config *outputmodels.GithubConfig) (*alertapimodels.Ticket, *AlertDeliveryError) {
 
 	description := "**Description:** " + aws.StringValue(alert.AnalysisDescription)
 	link := "\n [Click here to view in the Panther UI](" + generateURL(alert) + ")"
@@ -48,16 +60,61 @@ func (client *OutputClient) Github(
 		"body":  description + link + runBook + severity + tags,
 	}
 
-	token := "token " + config.Token
-	repoURL := githubEndpoint + config.RepoName + requestType
-	requestHeader := map[string]string{
-		AuthorizationHTTPHeader: token,
+	var issue githubIssue
+	postInput := &PostInput{
+		url:      githubEndpoint + config.RepoName + requestType,
+		body:     githubRequest,
+		headers:  githubHeaders(config),
+		response: &issue,
+	}
+	if err := client.httpWrapper.post(postInput); err != nil {
+		return nil, err
 	}
 
+	return &alertapimodels.Ticket{
+		OutputType: "github",
+		Key:        strconv.Itoa(issue.Number),
+		URL:        issue.HTMLURL,
+	}, nil
+}
+
+// GithubComment adds a comment describing new alert activity to a Github issue.
+func (client *OutputClient) GithubComment(
+	alert *alertmodels.Alert, ticket *alertapimodels.Ticket, config *outputmodels.GithubConfig) *AlertDeliveryError {
+
 	postInput := &PostInput{
-		url:     repoURL,
-		body:    githubRequest,
-		headers: requestHeader,
+		url:     githubEndpoint + config.RepoName + requestType + "/" + ticket.Key + "/comments",
+		body:    map[string]string{"body": generateTicketComment(alert)},
+		headers: githubHeaders(config),
 	}
 	return client.httpWrapper.post(postInput)
 }
+
+// GithubTicketStatus returns the alert status matching a Github issue, or "" if the issue is still open.
+func (client *OutputClient) GithubTicketStatus(
+	ticket *alertapimodels.Ticket, config *outputmodels.GithubConfig) (string, *AlertDeliveryError) {
+
+	var issue githubIssue
+	getInput := &GetInput{
+		url:      githubEndpoint + config.RepoName + requestType + "/" + ticket.Key,
+		headers:  githubHeaders(config),
+		response: &issue,
+	}
+	if err := client.httpWrapper.get(getInput); err != nil {
+		return "", err
+	}
+
+	if issue.State != "closed" {
+		return "", nil
+	}
+	if issue.StateReason == "not_planned" {
+		return alertapimodels.ClosedStatus, nil
+	}
+	return alertapimodels.ResolvedStatus, nil
+}
+
+func githubHeaders(config *outputmodels.GithubConfig) map[string]string {
+	return map[string]string{
+		AuthorizationHTTPHeader: "token " + config.Token,
+	}
+}
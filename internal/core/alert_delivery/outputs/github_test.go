@@ -23,8 +23,10 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	alertapimodels "github.com/panther-labs/panther/api/lambda/alerts/models"
 	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
 	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
 )
@@ -58,13 +60,24 @@ func TestGithubAlert(t *testing.T) {
 	}
 	requestEndpoint := "https://api.github.com/repos/profile/reponame/issues"
 	expectedPostInput := &PostInput{
-		url:     requestEndpoint,
-		body:    githubRequest,
-		headers: requestHeader,
+		url:      requestEndpoint,
+		body:     githubRequest,
+		headers:  requestHeader,
+		response: &githubIssue{},
 	}
 
-	httpWrapper.On("post", expectedPostInput).Return((*AlertDeliveryError)(nil))
+	httpWrapper.On("post", expectedPostInput).Return((*AlertDeliveryError)(nil)).Run(func(args mock.Arguments) {
+		issue := args.Get(0).(*PostInput).response.(*githubIssue)
+		issue.Number = 7
+		issue.HTMLURL = "https://github.com/profile/reponame/issues/7"
+	})
 
-	require.Nil(t, client.Github(alert, githubConfig))
+	ticket, err := client.Github(alert, githubConfig)
+	require.Nil(t, err)
+	require.Equal(t, &alertapimodels.Ticket{
+		OutputType: "github",
+		Key:        "7",
+		URL:        "https://github.com/profile/reponame/issues/7",
+	}, ticket)
 	httpWrapper.AssertExpectations(t)
 }
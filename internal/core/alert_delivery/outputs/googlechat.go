@@ -0,0 +1,40 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+)
+
+// GoogleChat alert send a message to a space.
+func (client *OutputClient) GoogleChat(
+	alert *alertmodels.Alert, config *outputmodels.GoogleChatConfig) *AlertDeliveryError {
+
+	googleChatRequest := map[string]string{
+		// Google Chat formats *text* in bold
+		"text": "*" + generateAlertTitle(alert) + "*\n" + generateDetailedAlertMessage(alert),
+	}
+
+	postInput := &PostInput{
+		url:  config.WebhookURL,
+		body: googleChatRequest,
+	}
+	return client.httpWrapper.post(postInput)
+}
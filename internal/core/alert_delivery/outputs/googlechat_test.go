@@ -0,0 +1,53 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+)
+
+func TestGoogleChatAlert(t *testing.T) {
+	server := newTestServer(t, map[string]string{
+		"POST /v1/spaces/AAAA/messages": `{"name": "spaces/AAAA/messages/1"}`,
+	})
+	defer server.Close()
+
+	alert := &alertmodels.Alert{
+		AnalysisID:   "policyId",
+		AnalysisName: aws.String("policyName"),
+		Severity:     "INFO",
+	}
+	config := &outputmodels.GoogleChatConfig{WebhookURL: server.URL + "/v1/spaces/AAAA/messages"}
+
+	require.Nil(t, newTestClient().GoogleChat(alert, config))
+
+	var request map[string]string
+	require.NoError(t, jsoniter.UnmarshalFromString(server.requests["POST /v1/spaces/AAAA/messages"], &request))
+	assert.Equal(t, map[string]string{
+		"text": "*Policy Failure: policyName*\n" + generateDetailedAlertMessage(alert),
+	}, request)
+}
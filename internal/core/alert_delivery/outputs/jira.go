@@ -24,17 +24,43 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 
+	alertapimodels "github.com/panther-labs/panther/api/lambda/alerts/models"
 	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
 	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
 )
 
 const (
-	jiraEndpoint = "/rest/api/latest/issue/"
+	jiraEndpoint       = "/rest/api/latest/issue/"
+	jiraBrowseEndpoint = "/browse/"
 )
 
+// jiraIssue is the part of the Jira issue response we need
+type jiraIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Status struct {
+			StatusCategory struct {
+				Key string `json:"key"`
+			} `json:"statusCategory"`
+		} `json:"status"`
+		Resolution *struct {
+			Name string `json:"name"`
+		} `json:"resolution"`
+	} `json:"fields"`
+}
+
+// Jira resolutions which mean the issue was not a real problem
+var jiraClosedResolutions = map[string]bool{
+	"Won't Do":         true,
+	"Won't Fix":        true,
+	"Duplicate":        true,
+	"Declined":         true,
+	"Cannot Reproduce": true,
+}
+
 // Jira alert send an issue.
 func (client *OutputClient) Jira(
-	alert *alertmodels.Alert, config *outputmodels.JiraConfig) *AlertDeliveryError {
+	alert *alertmodels.Alert, config *outputmodels.JiraConfig) (*alertapimodels.Ticket, *AlertDeliveryError) {
 
 	description := "*Description:* " + aws.StringValue(alert.AnalysisDescription)
 	link := "\n [Click here to view in the Panther UI](" + generateURL(alert) + ")"
@@ -63,17 +89,63 @@ func (client *OutputClient) Jira(
 		"fields": fields,
 	}
 
-	auth := config.UserName + ":" + config.APIKey
-	basicAuthToken := "Basic " + base64.StdEncoding.EncodeToString([]byte(auth))
-	jiraRestURL := config.OrgDomain + jiraEndpoint
-	requestHeader := map[string]string{
-		AuthorizationHTTPHeader: basicAuthToken,
+	var issue jiraIssue
+	postInput := &PostInput{
+		url:      config.OrgDomain + jiraEndpoint,
+		body:     jiraRequest,
+		headers:  jiraHeaders(config),
+		response: &issue,
+	}
+	if err := client.httpWrapper.post(postInput); err != nil {
+		return nil, err
 	}
 
+	return &alertapimodels.Ticket{
+		OutputType: "jira",
+		Key:        issue.Key,
+		URL:        config.OrgDomain + jiraBrowseEndpoint + issue.Key,
+	}, nil
+}
+
+// JiraComment adds a comment describing new alert activity to a Jira issue.
+func (client *OutputClient) JiraComment(
+	alert *alertmodels.Alert, ticket *alertapimodels.Ticket, config *outputmodels.JiraConfig) *AlertDeliveryError {
+
 	postInput := &PostInput{
-		url:     jiraRestURL,
-		body:    jiraRequest,
-		headers: requestHeader,
+		url:     config.OrgDomain + jiraEndpoint + ticket.Key + "/comment",
+		body:    map[string]string{"body": generateTicketComment(alert)},
+		headers: jiraHeaders(config),
 	}
 	return client.httpWrapper.post(postInput)
 }
+
+// JiraTicketStatus returns the alert status matching a Jira issue, or "" if the issue is still open.
+func (client *OutputClient) JiraTicketStatus(
+	ticket *alertapimodels.Ticket, config *outputmodels.JiraConfig) (string, *AlertDeliveryError) {
+
+	var issue jiraIssue
+	getInput := &GetInput{
+		url:      config.OrgDomain + jiraEndpoint + ticket.Key + "?fields=status,resolution",
+		headers:  jiraHeaders(config),
+		response: &issue,
+	}
+	if err := client.httpWrapper.get(getInput); err != nil {
+		return "", err
+	}
+
+	if issue.Fields.Status.StatusCategory.Key != "done" {
+		return "", nil
+	}
+	if issue.Fields.Resolution != nil && jiraClosedResolutions[issue.Fields.Resolution.Name] {
+		return alertapimodels.ClosedStatus, nil
+	}
+	return alertapimodels.ResolvedStatus, nil
+}
+
+func jiraHeaders(config *outputmodels.JiraConfig) map[string]string {
+	auth := config.UserName + ":" + config.APIKey
+	basicAuthToken := "Basic " + base64.StdEncoding.EncodeToString([]byte(auth))
+	return map[string]string{
+		AuthorizationHTTPHeader: basicAuthToken,
+	}
+}
@@ -24,8 +24,10 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	alertapimodels "github.com/panther-labs/panther/api/lambda/alerts/models"
 	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
 	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
 )
@@ -76,13 +78,22 @@ func TestJiraAlert(t *testing.T) {
 	}
 	requestEndpoint := "https://panther-labs.atlassian.net/rest/api/latest/issue/"
 	expectedPostInput := &PostInput{
-		url:     requestEndpoint,
-		body:    jiraPayload,
-		headers: requestHeader,
+		url:      requestEndpoint,
+		body:     jiraPayload,
+		headers:  requestHeader,
+		response: &jiraIssue{},
 	}
 
-	httpWrapper.On("post", expectedPostInput).Return((*AlertDeliveryError)(nil))
+	httpWrapper.On("post", expectedPostInput).Return((*AlertDeliveryError)(nil)).Run(func(args mock.Arguments) {
+		args.Get(0).(*PostInput).response.(*jiraIssue).Key = "QR-12"
+	})
 
-	require.Nil(t, client.Jira(alert, jiraConfig))
+	ticket, err := client.Jira(alert, jiraConfig)
+	require.Nil(t, err)
+	require.Equal(t, &alertapimodels.Ticket{
+		OutputType: "jira",
+		Key:        "QR-12",
+		URL:        "https://panther-labs.atlassian.net/browse/QR-12",
+	}, ticket)
 	httpWrapper.AssertExpectations(t)
 }
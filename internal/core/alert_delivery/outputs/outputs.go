@@ -29,6 +29,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/sns/snsiface"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 
+	alertapimodels "github.com/panther-labs/panther/api/lambda/alerts/models"
 	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
 	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
 	"github.com/panther-labs/panther/pkg/gatewayapi"
@@ -49,11 +50,21 @@ type PostInput struct {
 	url     string
 	body    interface{}
 	headers map[string]string
+	// If set, the JSON response body is unmarshaled into response
+	response interface{}
+}
+
+// GetInput type
+type GetInput struct {
+	url      string
+	headers  map[string]string
+	response interface{}
 }
 
 // HTTPWrapperiface is the interface for our wrapper around Golang's http client
 type HTTPWrapperiface interface {
 	post(*PostInput) *AlertDeliveryError
+	get(*GetInput) *AlertDeliveryError
 }
 
 // HTTPiface is an interface for http.Client to simplify unit testing.
@@ -65,14 +76,30 @@ type HTTPiface interface {
 type API interface {
 	Slack(*alertmodels.Alert, *outputmodels.SlackConfig) *AlertDeliveryError
 	PagerDuty(*alertmodels.Alert, *outputmodels.PagerDutyConfig) *AlertDeliveryError
-	Github(*alertmodels.Alert, *outputmodels.GithubConfig) *AlertDeliveryError
-	Jira(*alertmodels.Alert, *outputmodels.JiraConfig) *AlertDeliveryError
+	Github(*alertmodels.Alert, *outputmodels.GithubConfig) (*alertapimodels.Ticket, *AlertDeliveryError)
+	Jira(*alertmodels.Alert, *outputmodels.JiraConfig) (*alertapimodels.Ticket, *AlertDeliveryError)
 	Opsgenie(*alertmodels.Alert, *outputmodels.OpsgenieConfig) *AlertDeliveryError
 	MsTeams(*alertmodels.Alert, *outputmodels.MsTeamsConfig) *AlertDeliveryError
 	Sqs(*alertmodels.Alert, *outputmodels.SqsConfig) *AlertDeliveryError
 	Sns(*alertmodels.Alert, *outputmodels.SnsConfig) *AlertDeliveryError
 	Asana(*alertmodels.Alert, *outputmodels.AsanaConfig) *AlertDeliveryError
 	CustomWebhook(*alertmodels.Alert, *outputmodels.CustomWebhookConfig) *AlertDeliveryError
+	Email(*alertmodels.Alert, *outputmodels.EmailConfig) *AlertDeliveryError
+	Splunk(*alertmodels.Alert, *outputmodels.SplunkConfig) *AlertDeliveryError
+	ServiceNow(*alertmodels.Alert, *outputmodels.ServiceNowConfig) *AlertDeliveryError
+	GoogleChat(*alertmodels.Alert, *outputmodels.GoogleChatConfig) *AlertDeliveryError
+
+	// Digests summarize many alerts in a single message
+	SlackDigest(*alertmodels.Digest, *outputmodels.SlackConfig) *AlertDeliveryError
+	MsTeamsDigest(*alertmodels.Digest, *outputmodels.MsTeamsConfig) *AlertDeliveryError
+	SnsDigest(*alertmodels.Digest, *outputmodels.SnsConfig) *AlertDeliveryError
+	CustomWebhookDigest(*alertmodels.Digest, *outputmodels.CustomWebhookConfig) *AlertDeliveryError
+
+	// Tickets created by the Github and Jira outputs are kept in sync with their alert
+	GithubComment(*alertmodels.Alert, *alertapimodels.Ticket, *outputmodels.GithubConfig) *AlertDeliveryError
+	JiraComment(*alertmodels.Alert, *alertapimodels.Ticket, *outputmodels.JiraConfig) *AlertDeliveryError
+	GithubTicketStatus(*alertapimodels.Ticket, *outputmodels.GithubConfig) (string, *AlertDeliveryError)
+	JiraTicketStatus(*alertapimodels.Ticket, *outputmodels.JiraConfig) (string, *AlertDeliveryError)
 }
 
 // OutputClient encapsulates the clients that allow sending alerts to multiple outputs
@@ -180,6 +207,17 @@ func generateDetailedAlertMessage(alert *alertmodels.Alert) string {
 	)
 }
 
+const ticketCommentTemplate = "Panther update: this alert has now matched %d events.\n[Click here to view in the Panther UI](%s)"
+
+// generateTicketComment describes new activity on an alert which already has a ticket
+func generateTicketComment(alert *alertmodels.Alert) string {
+	var eventCount int
+	if alert.Update != nil {
+		eventCount = alert.Update.EventCount
+	}
+	return fmt.Sprintf(ticketCommentTemplate, eventCount, generateURL(alert))
+}
+
 func generateAlertTitle(alert *alertmodels.Alert) string {
 	if alert.Title != nil {
 		return "New Alert: " + *alert.Title
@@ -19,11 +19,16 @@ package outputs
  */
 
 import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	alertModel "github.com/panther-labs/panther/internal/core/alert_delivery/models"
 )
@@ -43,6 +48,11 @@ func (m *mockHTTPWrapper) post(postInput *PostInput) *AlertDeliveryError {
 	return args.Get(0).(*AlertDeliveryError)
 }
 
+func (m *mockHTTPWrapper) get(getInput *GetInput) *AlertDeliveryError {
+	args := m.Called(getInput)
+	return args.Get(0).(*AlertDeliveryError)
+}
+
 func TestGenerateAlertTitleReturnGivenTitle(t *testing.T) {
 	alert := &alertModel.Alert{
 		Title: aws.String("my title"),
@@ -82,3 +92,34 @@ func TestGenerateAlertTitlePolicyId(t *testing.T) {
 	}
 	assert.Equal(t, "Policy Failure: policy.id", generateAlertTitle(alert))
 }
+
+// testServer is a local stand-in for the APIs of the output destinations
+type testServer struct {
+	*httptest.Server
+	requests map[string]string      // "METHOD path" -> request body
+	headers  map[string]http.Header // "METHOD path" -> request headers
+}
+
+func newTestServer(t *testing.T, responses map[string]string) *testServer {
+	server := &testServer{requests: make(map[string]string), headers: make(map[string]http.Header)}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		key := r.Method + " " + r.URL.Path
+		server.requests[key] = string(body)
+		server.headers[key] = r.Header
+
+		response, ok := responses[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err = w.Write([]byte(response))
+		require.NoError(t, err)
+	}))
+	return server
+}
+
+func newTestClient() *OutputClient {
+	return &OutputClient{httpWrapper: &HTTPWrapper{httpClient: &http.Client{Timeout: 5 * time.Second}}}
+}
@@ -41,25 +41,47 @@ func (client *HTTPWrapper) post(input *PostInput) *AlertDeliveryError {
 	if err != nil {
 		return &AlertDeliveryError{Message: "http request error: " + err.Error(), Permanent: true}
 	}
-
 	request.Header.Set("Content-Type", "application/json")
+
+	return client.send(request, input.headers, input.response)
+}
+
+// get reads a JSON response from an endpoint.
+func (client *HTTPWrapper) get(input *GetInput) *AlertDeliveryError {
+	request, err := http.NewRequest("GET", input.url, nil)
+	if err != nil {
+		return &AlertDeliveryError{Message: "http request error: " + err.Error(), Permanent: true}
+	}
+
+	return client.send(request, input.headers, input.response)
+}
+
+// send executes the request and, if a response target is given, unmarshals the JSON body into it.
+func (client *HTTPWrapper) send(request *http.Request, headers map[string]string, response interface{}) *AlertDeliveryError {
 	request.Header.Set("Accept", "application/json")
 
 	//Adding dynamic headers
-	for key, value := range input.headers {
+	for key, value := range headers {
 		request.Header.Set(key, value)
 	}
 
-	response, err := client.httpClient.Do(request)
+	httpResponse, err := client.httpClient.Do(request)
 	if err != nil {
 		return &AlertDeliveryError{Message: "network error: " + err.Error()}
 	}
-	defer response.Body.Close()
+	defer httpResponse.Body.Close()
 
-	if response.StatusCode < 200 || response.StatusCode > 299 {
-		body, _ := ioutil.ReadAll(response.Body)
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode > 299 {
+		body, _ := ioutil.ReadAll(httpResponse.Body)
 		return &AlertDeliveryError{
-			Message: "request failed: " + response.Status + ": " + string(body)}
+			Message: "request failed: " + httpResponse.Status + ": " + string(body)}
+	}
+
+	if response != nil {
+		// The request already succeeded, retrying it could create duplicate resources (e.g. issues)
+		if err = jsoniter.NewDecoder(httpResponse.Body).Decode(response); err != nil {
+			return &AlertDeliveryError{Message: "response unmarshal error: " + err.Error(), Permanent: true}
+		}
 	}
 
 	return nil
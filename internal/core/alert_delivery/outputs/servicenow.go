@@ -0,0 +1,81 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/base64"
+	"strings"
+
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+)
+
+const serviceNowIncidentEndpoint = "/api/now/table/incident"
+
+// ServiceNow urgency and impact range from 1 (high) to 3 (low)
+var pantherToServiceNowUrgency = map[string]string{
+	"CRITICAL": "1",
+	"HIGH":     "1",
+	"MEDIUM":   "2",
+	"LOW":      "3",
+	"INFO":     "3",
+}
+
+var pantherToServiceNowImpact = map[string]string{
+	"CRITICAL": "1",
+	"HIGH":     "2",
+	"MEDIUM":   "2",
+	"LOW":      "3",
+	"INFO":     "3",
+}
+
+// ServiceNow alert create an incident.
+func (client *OutputClient) ServiceNow(
+	alert *alertmodels.Alert, config *outputmodels.ServiceNowConfig) *AlertDeliveryError {
+
+	// The correlation id lets ServiceNow users find the incidents of an alert
+	correlationID := alert.AnalysisID
+	if alert.AlertID != nil {
+		correlationID = *alert.AlertID
+	}
+
+	serviceNowRequest := map[string]string{
+		"short_description":   generateAlertTitle(alert),
+		"description":         generateDetailedAlertMessage(alert),
+		"urgency":             pantherToServiceNowUrgency[alert.Severity],
+		"impact":              pantherToServiceNowImpact[alert.Severity],
+		"correlation_id":      correlationID,
+		"correlation_display": "Panther",
+	}
+	if config.AssignmentGroup != "" {
+		serviceNowRequest["assignment_group"] = config.AssignmentGroup
+	}
+
+	auth := config.UserName + ":" + config.Password
+	requestHeader := map[string]string{
+		AuthorizationHTTPHeader: "Basic " + base64.StdEncoding.EncodeToString([]byte(auth)),
+	}
+
+	postInput := &PostInput{
+		url:     strings.TrimSuffix(config.InstanceURL, "/") + serviceNowIncidentEndpoint,
+		body:    serviceNowRequest,
+		headers: requestHeader,
+	}
+	return client.httpWrapper.post(postInput)
+}
@@ -0,0 +1,85 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+)
+
+func TestServiceNowAlert(t *testing.T) {
+	server := newTestServer(t, map[string]string{
+		"POST /api/now/table/incident": `{"result": {"number": "INC0010001"}}`,
+	})
+	defer server.Close()
+
+	config := &outputmodels.ServiceNowConfig{
+		InstanceURL:     server.URL,
+		UserName:        "admin",
+		Password:        "password",
+		AssignmentGroup: "Security",
+	}
+	alert := &alertmodels.Alert{
+		AnalysisID:   "ruleId",
+		AlertID:      aws.String("alertId"),
+		AnalysisName: aws.String("Rule Name"),
+		Type:         alertmodels.RuleType,
+		Severity:     "CRITICAL",
+	}
+
+	require.Nil(t, newTestClient().ServiceNow(alert, config))
+
+	key := "POST /api/now/table/incident"
+	assert.Equal(t, "Basic "+base64.StdEncoding.EncodeToString([]byte("admin:password")),
+		server.headers[key].Get("Authorization"))
+
+	var request map[string]string
+	require.NoError(t, jsoniter.UnmarshalFromString(server.requests[key], &request))
+	assert.Equal(t, map[string]string{
+		"short_description":   "New Alert: Rule Name",
+		"description":         generateDetailedAlertMessage(alert),
+		"urgency":             "1",
+		"impact":              "1",
+		"correlation_id":      "alertId",
+		"correlation_display": "Panther",
+		"assignment_group":    "Security",
+	}, request)
+}
+
+func TestServiceNowPolicyAlert(t *testing.T) {
+	server := newTestServer(t, map[string]string{"POST /api/now/table/incident": `{}`})
+	defer server.Close()
+
+	alert := &alertmodels.Alert{AnalysisID: "policyId", Type: alertmodels.PolicyType, Severity: "LOW"}
+	require.Nil(t, newTestClient().ServiceNow(alert, &outputmodels.ServiceNowConfig{InstanceURL: server.URL}))
+
+	var request map[string]string
+	require.NoError(t, jsoniter.UnmarshalFromString(server.requests["POST /api/now/table/incident"], &request))
+	assert.Equal(t, "policyId", request["correlation_id"])
+	assert.Equal(t, "3", request["urgency"])
+	assert.NotContains(t, request, "assignment_group")
+}
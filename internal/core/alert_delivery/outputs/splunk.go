@@ -0,0 +1,56 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strings"
+
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+)
+
+const splunkHecEndpoint = "/services/collector/event"
+
+// Splunk alert send an event to the HTTP Event Collector.
+func (client *OutputClient) Splunk(
+	alert *alertmodels.Alert, config *outputmodels.SplunkConfig) *AlertDeliveryError {
+
+	splunkRequest := map[string]interface{}{
+		"time":   alert.CreatedAt.Unix(),
+		"source": "panther",
+		"event":  generateNotificationFromAlert(alert),
+	}
+	if config.Index != "" {
+		splunkRequest["index"] = config.Index
+	}
+	if config.SourceType != "" {
+		splunkRequest["sourcetype"] = config.SourceType
+	}
+
+	requestHeader := map[string]string{
+		AuthorizationHTTPHeader: "Splunk " + config.Token,
+	}
+
+	postInput := &PostInput{
+		url:     strings.TrimSuffix(config.HecURL, "/") + splunkHecEndpoint,
+		body:    splunkRequest,
+		headers: requestHeader,
+	}
+	return client.httpWrapper.post(postInput)
+}
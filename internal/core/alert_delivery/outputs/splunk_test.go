@@ -0,0 +1,82 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+)
+
+func TestSplunkAlert(t *testing.T) {
+	server := newTestServer(t, map[string]string{
+		"POST /services/collector/event": `{"text": "Success", "code": 0}`,
+	})
+	defer server.Close()
+
+	config := &outputmodels.SplunkConfig{
+		HecURL:     server.URL + "/",
+		Token:      "3a2f5ae1-1b0e-4d3c-9c1a-2e1f6f7c8d9e",
+		Index:      "security",
+		SourceType: "panther:alert",
+	}
+	createdAtTime, err := time.Parse(time.RFC3339, "2019-08-03T11:40:13Z")
+	require.NoError(t, err)
+	alert := &alertmodels.Alert{
+		AnalysisID: "ruleId",
+		AlertID:    aws.String("alertId"),
+		Type:       alertmodels.RuleType,
+		CreatedAt:  createdAtTime,
+		Severity:   "MEDIUM",
+	}
+
+	require.Nil(t, newTestClient().Splunk(alert, config))
+
+	key := "POST /services/collector/event"
+	assert.Equal(t, "Splunk 3a2f5ae1-1b0e-4d3c-9c1a-2e1f6f7c8d9e", server.headers[key].Get("Authorization"))
+
+	var request map[string]interface{}
+	require.NoError(t, jsoniter.UnmarshalFromString(server.requests[key], &request))
+	assert.Equal(t, float64(createdAtTime.Unix()), request["time"])
+	assert.Equal(t, "security", request["index"])
+	assert.Equal(t, "panther:alert", request["sourcetype"])
+	event := request["event"].(map[string]interface{})
+	assert.Equal(t, "alertId", event["alertId"])
+	assert.Equal(t, "https://panther.io/alerts/alertId", event["link"])
+}
+
+func TestSplunkAlertInvalidToken(t *testing.T) {
+	server := newTestServer(t, nil) // every request fails
+	defer server.Close()
+
+	err := newTestClient().Splunk(
+		&alertmodels.Alert{AnalysisID: "policyId"},
+		&outputmodels.SplunkConfig{HecURL: server.URL, Token: "token"},
+	)
+	require.NotNil(t, err)
+	assert.Contains(t, err.Message, http.StatusText(http.StatusNotFound))
+}
@@ -0,0 +1,130 @@
+package outputs
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	alertapimodels "github.com/panther-labs/panther/api/lambda/alerts/models"
+	outputmodels "github.com/panther-labs/panther/api/lambda/outputs/models"
+	alertmodels "github.com/panther-labs/panther/internal/core/alert_delivery/models"
+)
+
+var ticketAlert = &alertmodels.Alert{
+	AnalysisID: "ruleId",
+	AlertID:    aws.String("alertId"),
+	Type:       alertmodels.RuleType,
+	Severity:   "HIGH",
+	Update:     &alertmodels.AlertUpdate{EventCount: 42},
+}
+
+func TestJiraTicketLifecycle(t *testing.T) {
+	server := newTestServer(t, map[string]string{
+		"POST /rest/api/latest/issue/":             `{"id": "10000", "key": "QR-1", "self": "ignored"}`,
+		"POST /rest/api/latest/issue/QR-1/comment": `{"id": "1"}`,
+		"GET /rest/api/latest/issue/QR-1":          `{"key": "QR-1", "fields": {"status": {"statusCategory": {"key": "done"}}, "resolution": {"name": "Won't Do"}}}`,
+		"GET /rest/api/latest/issue/QR-2":          `{"key": "QR-2", "fields": {"status": {"statusCategory": {"key": "done"}}, "resolution": {"name": "Done"}}}`,
+		"GET /rest/api/latest/issue/QR-3":          `{"key": "QR-3", "fields": {"status": {"statusCategory": {"key": "indeterminate"}}}}`,
+	})
+	defer server.Close()
+
+	config := &outputmodels.JiraConfig{
+		OrgDomain:  server.URL,
+		ProjectKey: "QR",
+		UserName:   "username",
+		APIKey:     "apikey",
+		Type:       "Task",
+	}
+	client := newTestClient()
+
+	ticket, err := client.Jira(ticketAlert, config)
+	require.Nil(t, err)
+	assert.Equal(t, &alertapimodels.Ticket{OutputType: "jira", Key: "QR-1", URL: server.URL + "/browse/QR-1"}, ticket)
+
+	require.Nil(t, client.JiraComment(ticketAlert, ticket, config))
+	assert.Contains(t, server.requests["POST /rest/api/latest/issue/QR-1/comment"], "matched 42 events")
+
+	status, err := client.JiraTicketStatus(ticket, config)
+	require.Nil(t, err)
+	assert.Equal(t, alertapimodels.ClosedStatus, status)
+
+	status, err = client.JiraTicketStatus(&alertapimodels.Ticket{Key: "QR-2"}, config)
+	require.Nil(t, err)
+	assert.Equal(t, alertapimodels.ResolvedStatus, status)
+
+	status, err = client.JiraTicketStatus(&alertapimodels.Ticket{Key: "QR-3"}, config)
+	require.Nil(t, err)
+	assert.Equal(t, "", status)
+
+	_, err = client.JiraTicketStatus(&alertapimodels.Ticket{Key: "QR-404"}, config)
+	require.NotNil(t, err)
+	assert.False(t, err.Permanent)
+}
+
+func TestGithubTicketLifecycle(t *testing.T) {
+	server := newTestServer(t, map[string]string{
+		"POST /profile/reponame/issues":            `{"number": 5, "html_url": "https://github.com/profile/reponame/issues/5"}`,
+		"POST /profile/reponame/issues/5/comments": `{"id": 1}`,
+		"GET /profile/reponame/issues/5":           `{"number": 5, "state": "closed", "state_reason": "completed"}`,
+		"GET /profile/reponame/issues/6":           `{"number": 6, "state": "closed", "state_reason": "not_planned"}`,
+		"GET /profile/reponame/issues/7":           `{"number": 7, "state": "open"}`,
+	})
+	defer server.Close()
+
+	defaultEndpoint := githubEndpoint
+	githubEndpoint = server.URL + "/"
+	defer func() { githubEndpoint = defaultEndpoint }()
+
+	config := &outputmodels.GithubConfig{RepoName: "profile/reponame", Token: "github-token"}
+	client := newTestClient()
+
+	ticket, err := client.Github(ticketAlert, config)
+	require.Nil(t, err)
+	assert.Equal(t, &alertapimodels.Ticket{
+		OutputType: "github", Key: "5", URL: "https://github.com/profile/reponame/issues/5"}, ticket)
+
+	require.Nil(t, client.GithubComment(ticketAlert, ticket, config))
+	assert.Contains(t, server.requests["POST /profile/reponame/issues/5/comments"], "matched 42 events")
+
+	status, err := client.GithubTicketStatus(ticket, config)
+	require.Nil(t, err)
+	assert.Equal(t, alertapimodels.ResolvedStatus, status)
+
+	status, err = client.GithubTicketStatus(&alertapimodels.Ticket{Key: "6"}, config)
+	require.Nil(t, err)
+	assert.Equal(t, alertapimodels.ClosedStatus, status)
+
+	status, err = client.GithubTicketStatus(&alertapimodels.Ticket{Key: "7"}, config)
+	require.Nil(t, err)
+	assert.Equal(t, "", status)
+}
+
+func TestTicketInvalidResponse(t *testing.T) {
+	server := newTestServer(t, map[string]string{"POST /rest/api/latest/issue/": `not json`})
+	defer server.Close()
+
+	_, err := newTestClient().Jira(ticketAlert, &outputmodels.JiraConfig{OrgDomain: server.URL})
+	require.NotNil(t, err)
+	// The issue may have been created, so it must not be retried
+	assert.True(t, err.Permanent)
+}
@@ -85,6 +85,20 @@ func (e *RuleEngine) TestRule(rule *models.TestPolicy) (models.TestPolicyResult,
 	return testResults, err
 }
 
+// EvaluateEvents runs a single rule against a batch of events and returns the analysis of each event.
+func (e *RuleEngine) EvaluateEvents(rule enginemodels.Rule, events []enginemodels.Event) ([]enginemodels.EventAnalysis, error) {
+	input := enginemodels.RulesEngineInput{
+		Rules:  []enginemodels.Rule{rule},
+		Events: events,
+	}
+
+	var engineOutput enginemodels.RulesEngineOutput
+	if err := genericapi.Invoke(e.lambdaClient, e.lambdaName, &input, &engineOutput); err != nil {
+		return nil, errors.Wrap(err, "error invoking rule engine")
+	}
+	return engineOutput.Events, nil
+}
+
 func makeTestSummaryRule(rule *models.TestPolicy, engineOutput enginemodels.RulesEngineOutput) (models.TestPolicyResult, error) {
 	// Normalize rule engine output to policy engine output to facilitate consistent handling
 	// in makeTestSummary().
@@ -0,0 +1,365 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	enginemodels "github.com/panther-labs/panther/api/gateway/analysis"
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	logmodels "github.com/panther-labs/panther/api/lambda/core/log_analysis/log_processor/models"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+)
+
+const (
+	defaultBacktestMaxEvents  = 10000
+	defaultBacktestSampleSize = 10
+	maxBacktestRange          = 30 * 24 * time.Hour
+
+	// Events are sent to the rules engine in batches, keeping well under the 6 MB Lambda payload limit
+	backtestBatchSize  = 500
+	backtestBatchBytes = 4 * 1024 * 1024
+
+	// Processed log lines can be large, but they are never bigger than this
+	maxLogLineBytes = 10 * 1024 * 1024
+
+	backtestRuleID   = "BacktestRule"
+	eventTimeField   = "p_event_time"
+	maxSampledErrors = 10
+)
+
+var (
+	errBacktestDone   = errors.New("backtest reached maxEvents")
+	errBacktestPaused = errors.New("backtest ran out of time in this invocation")
+)
+
+// backtest runs a rule against historical events, picking up where the job left off.
+type backtest struct {
+	*backtestJob
+	rule enginemodels.Rule
+
+	// the scan pauses when this time is reached
+	deadline time.Time
+
+	// the current batch of events waiting to be analyzed
+	batch      []enginemodels.Event
+	batchBytes int
+	batchLines map[string]string
+	batchTimes map[string]time.Time
+
+	errors map[string]bool
+}
+
+func parseBacktestRule(request *events.APIGatewayProxyRequest) (*models.BacktestRule, error) {
+	var result models.BacktestRule
+	if err := jsoniter.UnmarshalFromString(request.Body, &result); err != nil {
+		return nil, err
+	}
+
+	if err := result.Validate(nil); err != nil {
+		return nil, err
+	}
+
+	if len(result.LogTypes) == 0 {
+		return nil, errors.New("logTypes: at least one log type is required")
+	}
+
+	start, end := time.Time(*result.StartTime), time.Time(*result.EndTime)
+	if !start.Before(end) {
+		return nil, errors.New("startTime must be before endTime")
+	}
+	if end.Sub(start) > maxBacktestRange {
+		return nil, fmt.Errorf("time range can be at most %s", maxBacktestRange)
+	}
+
+	// Fill in defaults
+	if result.DedupPeriodMinutes == 0 {
+		result.DedupPeriodMinutes = defaultDedupPeriodMinutes
+	}
+	if result.Threshold == 0 {
+		result.Threshold = defaultRuleThreshold
+	}
+	if result.MaxEvents == 0 {
+		result.MaxEvents = defaultBacktestMaxEvents
+	}
+	if result.SampleSize == nil {
+		result.SampleSize = aws.Int64(defaultBacktestSampleSize)
+	}
+
+	return &result, nil
+}
+
+func newBacktest(job *backtestJob) *backtest {
+	b := &backtest{
+		backtestJob: job,
+		rule: enginemodels.Rule{
+			Body:     string(job.Input.Body),
+			ID:       backtestRuleID,
+			LogTypes: job.Input.LogTypes,
+		},
+		batchLines: make(map[string]string),
+		batchTimes: make(map[string]time.Time),
+		errors:     make(map[string]bool),
+	}
+	for _, message := range job.Result.SampleErrors {
+		b.errors[message] = true
+	}
+	return b
+}
+
+// Stream the events in the time range through the rules engine until the deadline.
+//
+// Returns false if the backtest was paused and has to be resumed in another invocation.
+func (b *backtest) run(deadline time.Time) (bool, error) {
+	b.deadline = deadline
+
+	err := b.scan()
+	switch err {
+	case nil:
+	case errBacktestDone:
+		b.Result.Truncated = aws.Bool(true)
+	case errBacktestPaused:
+		return false, b.flush()
+	default:
+		return false, err
+	}
+
+	if err := b.flush(); err != nil {
+		return false, err
+	}
+
+	b.Result.ProjectedAlerts = aws.Int64(int64(projectAlerts(
+		b.Matches, time.Duration(b.Input.DedupPeriodMinutes)*time.Minute, int(b.Input.Threshold))))
+	return true, nil
+}
+
+// Read the hourly partitions of each log type in order, starting from the cursor
+func (b *backtest) scan() error {
+	start, end := time.Time(*b.Input.StartTime).UTC(), time.Time(*b.Input.EndTime).UTC()
+	cursor := &b.Cursor
+
+	for ; cursor.LogType < len(b.Input.LogTypes); cursor.LogType++ {
+		if cursor.Hour.IsZero() {
+			cursor.Hour = start.Truncate(time.Hour)
+		}
+		for ; cursor.Hour.Before(end); cursor.Hour = awsglue.GlueTableHourly.Next(cursor.Hour) {
+			if b.expired() {
+				return errBacktestPaused
+			}
+			keys, err := b.listHour(b.Input.LogTypes[cursor.LogType], cursor.Hour)
+			if err != nil {
+				return err
+			}
+
+			for _, key := range keys {
+				if key < cursor.Key {
+					continue // read before the backtest was paused
+				}
+				if key != cursor.Key {
+					cursor.Key, cursor.Line = key, 0
+				}
+				if err := b.scanObject(key, start, end); err != nil {
+					return err
+				}
+			}
+			cursor.Key, cursor.Line = "", 0
+		}
+		cursor.Hour = time.Time{}
+	}
+	return nil
+}
+
+// List the objects in a single hourly partition (S3 returns them in key order)
+func (b *backtest) listHour(logType string, hour time.Time) ([]string, error) {
+	prefix := awsglue.GetPartitionPrefix(logmodels.LogData, logType, awsglue.GlueTableHourly, hour)
+
+	var keys []string
+	err := s3Client.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: &env.ProcessedDataBucket,
+		Prefix: &prefix,
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			keys = append(keys, aws.StringValue(object.Key))
+		}
+		return true
+	})
+	if err != nil {
+		zap.L().Error("s3Client.ListObjectsV2Pages failed", zap.String("prefix", prefix), zap.Error(err))
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Read a single gzipped file of JSON events, skipping the lines read before the backtest was paused
+func (b *backtest) scanObject(key string, start, end time.Time) error {
+	object, err := s3Client.GetObject(&s3.GetObjectInput{Bucket: &env.ProcessedDataBucket, Key: &key})
+	if err != nil {
+		zap.L().Error("s3Client.GetObject failed", zap.String("key", key), zap.Error(err))
+		return err
+	}
+	defer func() {
+		if err := object.Body.Close(); err != nil {
+			zap.L().Error("error closing S3 object", zap.String("key", key), zap.Error(err))
+		}
+	}()
+
+	reader, err := gzip.NewReader(object.Body)
+	if err != nil {
+		zap.L().Error("gzip.NewReader failed", zap.String("key", key), zap.Error(err))
+		return err
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineBytes)
+	for line := 0; scanner.Scan(); line++ {
+		if line < b.Cursor.Line {
+			continue
+		}
+		if b.expired() {
+			return errBacktestPaused
+		}
+		if err := b.addEvent(scanner.Text(), start, end); err != nil {
+			return err
+		}
+		b.Cursor.Line = line + 1
+	}
+	if err := scanner.Err(); err != nil {
+		zap.L().Error("failed to read S3 object", zap.String("key", key), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Add a single log line to the current batch, if it falls within the time range.
+func (b *backtest) addEvent(line string, start, end time.Time) error {
+	var data map[string]interface{}
+	if err := jsoniter.UnmarshalFromString(line, &data); err != nil {
+		zap.L().Warn("skipping invalid log line", zap.Error(err))
+		return nil
+	}
+
+	rawTime, _ := data[eventTimeField].(string)
+	eventTime, err := time.Parse(awsglue.TimestampLayout, rawTime)
+	if err != nil || eventTime.Before(start) || !eventTime.Before(end) {
+		// Partitions are hourly, so the first and last hour can include events outside the range
+		return nil
+	}
+
+	if *b.Result.EventsScanned >= b.Input.MaxEvents {
+		return errBacktestDone
+	}
+	*b.Result.EventsScanned++
+
+	id := strconv.FormatInt(*b.Result.EventsScanned, 10)
+	b.batch = append(b.batch, enginemodels.Event{Data: data, ID: id})
+	b.batchBytes += len(line)
+	b.batchLines[id] = line
+	b.batchTimes[id] = eventTime
+
+	if len(b.batch) >= backtestBatchSize || b.batchBytes >= backtestBatchBytes {
+		return b.flush()
+	}
+	return nil
+}
+
+// Returns true if the backtest has run out of time in this invocation.
+func (b *backtest) expired() bool {
+	return !b.deadline.IsZero() && time.Now().After(b.deadline)
+}
+
+// Send the current batch to the rules engine and record the results.
+func (b *backtest) flush() error {
+	if len(b.batch) == 0 {
+		return nil
+	}
+
+	analyses, err := ruleEngine.EvaluateEvents(b.rule, b.batch)
+	if err != nil {
+		return err
+	}
+
+	for _, analysis := range analyses {
+		if len(analysis.Errored) > 0 {
+			*b.Result.EventsErrored++
+			for _, engineErr := range analysis.Errored {
+				if len(b.errors) < maxSampledErrors && !b.errors[engineErr.Message] {
+					b.errors[engineErr.Message] = true
+					b.Result.SampleErrors = append(b.Result.SampleErrors, engineErr.Message)
+				}
+			}
+			continue
+		}
+
+		if len(analysis.Matched) == 0 {
+			continue
+		}
+		*b.Result.EventsMatched++
+		b.Matches = append(b.Matches, backtestMatch{Time: b.batchTimes[analysis.ID], Dedup: analysis.Dedup})
+		if int64(len(b.Result.SampleMatches)) < *b.Input.SampleSize {
+			b.Result.SampleMatches = append(b.Result.SampleMatches, b.batchLines[analysis.ID])
+		}
+	}
+
+	b.batch = b.batch[:0]
+	b.batchBytes = 0
+	b.batchLines = make(map[string]string)
+	b.batchTimes = make(map[string]time.Time)
+	return nil
+}
+
+// projectAlerts estimates how many alerts a set of matches would have generated.
+//
+// This mirrors the rules engine deduplication: matches are grouped by their dedup string,
+// the first match of a group opens a window of dedupPeriod, all matches of the group within
+// the window are merged together, and they become an alert if there are at least threshold of them.
+func projectAlerts(matches []backtestMatch, dedupPeriod time.Duration, threshold int) int {
+	groups := make(map[string][]time.Time)
+	for _, match := range matches {
+		groups[match.Dedup] = append(groups[match.Dedup], match.Time)
+	}
+
+	alerts := 0
+	for _, matchTimes := range groups {
+		sort.Slice(matchTimes, func(i, j int) bool { return matchTimes[i].Before(matchTimes[j]) })
+
+		for i := 0; i < len(matchTimes); {
+			windowEnd := matchTimes[i].Add(dedupPeriod)
+			count := 0
+			for ; i < len(matchTimes) && matchTimes[i].Before(windowEnd); i++ {
+				count++
+			}
+			if count >= threshold {
+				alerts++
+			}
+		}
+	}
+	return alerts
+}
@@ -0,0 +1,231 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+const (
+	// Each invocation stops scanning with this much time left, to flush the last batch and save its progress
+	backtestInvocationMargin = 30 * time.Second
+
+	// A backtest which has not finished after this many invocations is failed
+	maxBacktestInvocations = 50
+)
+
+// BacktestEvent is sent by the analysis-api to itself (asynchronously) to run a backtest.
+type BacktestEvent struct {
+	BacktestID string `json:"backtestId"`
+}
+
+// backtestJob is the state of a backtest, saved in S3 between invocations and once it finishes.
+type backtestJob struct {
+	Input       *models.BacktestRule   `json:"input"`
+	Result      *models.BacktestResult `json:"result"`
+	Cursor      backtestCursor         `json:"cursor"`
+	Matches     []backtestMatch        `json:"matches"`
+	Invocations int                    `json:"invocations"`
+}
+
+// backtestCursor is where a paused backtest resumes scanning.
+type backtestCursor struct {
+	LogType int       `json:"logType"` // index into the input log types
+	Hour    time.Time `json:"hour"`    // zero at the start of a log type
+	Key     string    `json:"key"`     // the S3 object being read, empty at the start of an hour
+	Line    int       `json:"line"`    // the number of lines already read from the S3 object
+}
+
+// backtestMatch is an event for which the rule returned True.
+type backtestMatch struct {
+	Time  time.Time `json:"time"`
+	Dedup string    `json:"dedup"`
+}
+
+// BacktestRule starts running a rule against the processed logs for a time range.
+func BacktestRule(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	input, err := parseBacktestRule(request)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	job := &backtestJob{
+		Input: input,
+		Result: &models.BacktestResult{
+			BacktestID:      aws.String(uuid.New().String()),
+			EventsErrored:   aws.Int64(0),
+			EventsMatched:   aws.Int64(0),
+			EventsScanned:   aws.Int64(0),
+			ProjectedAlerts: aws.Int64(0),
+			SampleErrors:    []string{},
+			SampleMatches:   []string{},
+			Status:          aws.String(models.BacktestResultStatusRUNNING),
+			Truncated:       aws.Bool(false),
+		},
+	}
+	if err := saveBacktest(job); err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	if err := invokeBacktest(*job.Result.BacktestID); err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	return gatewayapi.MarshalResponse(job.Result, http.StatusAccepted)
+}
+
+// GetBacktest returns the status and results of a backtest.
+func GetBacktest(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	backtestID := request.QueryStringParameters["backtestId"]
+	if _, err := uuid.Parse(backtestID); err != nil {
+		return badRequest(errors.New("invalid backtestId: " + backtestID))
+	}
+
+	job, err := loadBacktest(backtestID)
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == s3.ErrCodeNoSuchKey {
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusNotFound}
+		}
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	return gatewayapi.MarshalResponse(job.Result, http.StatusOK)
+}
+
+// RunBacktest scans for as long as this invocation allows, then saves the progress and
+// invokes itself again to continue (or saves the results once the backtest is done).
+//
+// Errors are only returned if the progress could not be saved, so the invocation is retried.
+func RunBacktest(ctx context.Context, event *BacktestEvent) error {
+	job, err := loadBacktest(event.BacktestID)
+	if err != nil {
+		return err
+	}
+	if aws.StringValue(job.Result.Status) != models.BacktestResultStatusRUNNING {
+		return nil // a retried invocation of a backtest which already finished
+	}
+
+	deadline := time.Now().Add(time.Minute)
+	if lambdaDeadline, ok := ctx.Deadline(); ok {
+		deadline = lambdaDeadline.Add(-backtestInvocationMargin)
+	}
+
+	job.Invocations++
+	done, err := newBacktest(job).run(deadline)
+	switch {
+	case err != nil:
+		zap.L().Error("backtest failed", zap.String("backtestId", event.BacktestID), zap.Error(err))
+		job.Result.Status = aws.String(models.BacktestResultStatusFAILED)
+		job.Result.ErrorMessage = err.Error()
+	case done:
+		job.Result.Status = aws.String(models.BacktestResultStatusSUCCEEDED)
+	case job.Invocations >= maxBacktestInvocations:
+		job.Result.Status = aws.String(models.BacktestResultStatusFAILED)
+		job.Result.ErrorMessage = "backtest did not finish in time, try a shorter time range or fewer log types"
+	}
+
+	if err := saveBacktest(job); err != nil {
+		return err
+	}
+	if aws.StringValue(job.Result.Status) == models.BacktestResultStatusRUNNING {
+		return invokeBacktest(event.BacktestID)
+	}
+	return nil
+}
+
+func backtestKey(backtestID string) string {
+	return "backtests/" + backtestID + ".json"
+}
+
+func loadBacktest(backtestID string) (*backtestJob, error) {
+	key := backtestKey(backtestID)
+	result, err := s3Client.GetObject(&s3.GetObjectInput{Bucket: &env.BacktestBucket, Key: &key})
+	if err != nil {
+		zap.L().Error("s3Client.GetObject failed", zap.String("key", key), zap.Error(err))
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(result.Body)
+	if err != nil {
+		zap.L().Error("ioutil.ReadAll failed", zap.String("key", key), zap.Error(err))
+		return nil, err
+	}
+
+	var job backtestJob
+	if err = jsoniter.Unmarshal(body, &job); err != nil {
+		zap.L().Error("backtest unmarshal failed", zap.String("key", key), zap.Error(err))
+		return nil, err
+	}
+	return &job, nil
+}
+
+func saveBacktest(job *backtestJob) error {
+	body, err := jsoniter.Marshal(job)
+	if err != nil {
+		zap.L().Error("backtest marshal failed", zap.Error(err))
+		return err
+	}
+
+	key := backtestKey(*job.Result.BacktestID)
+	_, err = s3Client.PutObject(&s3.PutObjectInput{
+		Body:   bytes.NewReader(body),
+		Bucket: &env.BacktestBucket,
+		Key:    &key,
+	})
+	if err != nil {
+		zap.L().Error("s3Client.PutObject failed", zap.String("key", key), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Invoke this lambda function asynchronously to run (or continue) a backtest
+func invokeBacktest(backtestID string) error {
+	payload, err := jsoniter.Marshal(&BacktestEvent{BacktestID: backtestID})
+	if err != nil {
+		zap.L().Error("backtest event marshal failed", zap.Error(err))
+		return err
+	}
+
+	_, err = lambdaClient.Invoke(&lambda.InvokeInput{
+		FunctionName:   aws.String(lambdacontext.FunctionName),
+		InvocationType: aws.String(lambda.InvocationTypeEvent), // don't wait for the backtest
+		Payload:        payload,
+	})
+	if err != nil {
+		zap.L().Error("lambdaClient.Invoke failed", zap.String("backtestId", backtestID), zap.Error(err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,293 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/go-openapi/strfmt"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	enginemodels "github.com/panther-labs/panther/api/gateway/analysis"
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	"github.com/panther-labs/panther/internal/core/analysis_api/analysis"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+var backtestStart = time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+
+// Matches with the given dedup string, at each of the given minutes after the backtest start
+func matchesAt(dedup string, minutes ...int) []backtestMatch {
+	result := make([]backtestMatch, len(minutes))
+	for i, m := range minutes {
+		result[i] = backtestMatch{Time: backtestStart.Add(time.Duration(m) * time.Minute), Dedup: dedup}
+	}
+	return result
+}
+
+func TestProjectAlerts(t *testing.T) {
+	assert.Equal(t, 0, projectAlerts(nil, time.Hour, 1))
+	// Out of order matches are grouped into 2 windows: [0, 59] and [60, 90]
+	assert.Equal(t, 2, projectAlerts(matchesAt("a", 90, 0, 30, 59, 60), time.Hour, 1))
+	// Only the first window reaches the threshold
+	assert.Equal(t, 1, projectAlerts(matchesAt("a", 90, 0, 30, 59, 60), time.Hour, 3))
+	assert.Equal(t, 0, projectAlerts(matchesAt("a", 0, 30), time.Hour, 3))
+	// Every match is its own alert with a short dedup period
+	assert.Equal(t, 3, projectAlerts(matchesAt("a", 0, 5, 10), time.Minute, 1))
+}
+
+func TestProjectAlertsDedup(t *testing.T) {
+	// Each dedup string has its own windows
+	matches := append(matchesAt("alice", 0, 30), matchesAt("bob", 10, 20, 90)...)
+	assert.Equal(t, 3, projectAlerts(matches, time.Hour, 1))
+	// Only alice's window and bob's first window reach the threshold
+	assert.Equal(t, 2, projectAlerts(matches, time.Hour, 2))
+	assert.Equal(t, 0, projectAlerts(matches, time.Hour, 3))
+}
+
+func TestParseBacktestRule(t *testing.T) {
+	result, err := parseBacktestRule(&events.APIGatewayProxyRequest{Body: `{
+		"body": "def rule(event): return True",
+		"logTypes": ["AWS.CloudTrail"],
+		"startTime": "2020-03-01T00:00:00Z",
+		"endTime": "2020-03-02T00:00:00Z"
+	}`})
+	require.NoError(t, err)
+	assert.Equal(t, models.DedupPeriodMinutes(defaultDedupPeriodMinutes), result.DedupPeriodMinutes)
+	assert.Equal(t, models.Threshold(defaultRuleThreshold), result.Threshold)
+	assert.Equal(t, int64(defaultBacktestMaxEvents), result.MaxEvents)
+	assert.Equal(t, int64(defaultBacktestSampleSize), *result.SampleSize)
+}
+
+func TestParseBacktestRuleInvalid(t *testing.T) {
+	bodies := []string{
+		// missing log types
+		`{"body": "x", "logTypes": [], "startTime": "2020-03-01T00:00:00Z", "endTime": "2020-03-02T00:00:00Z"}`,
+		// end before start
+		`{"body": "x", "logTypes": ["AWS.CloudTrail"], "startTime": "2020-03-02T00:00:00Z", "endTime": "2020-03-01T00:00:00Z"}`,
+		// range too large (more than 30 days)
+		`{"body": "x", "logTypes": ["AWS.CloudTrail"], "startTime": "2020-01-01T00:00:00Z", "endTime": "2020-03-01T00:00:00Z"}`,
+		`{"body": "x", "logTypes": ["AWS.CloudTrail"], "startTime": "2020-03-01T00:00:00Z", "endTime": "2020-03-31T01:00:00Z"}`,
+		// too many events
+		`{"body": "x", "logTypes": ["AWS.CloudTrail"], "startTime": "2020-03-01T00:00:00Z", "endTime": "2020-03-02T00:00:00Z", "maxEvents": 100000}`,
+		// missing time range
+		`{"body": "x", "logTypes": ["AWS.CloudTrail"]}`,
+	}
+	for _, body := range bodies {
+		_, err := parseBacktestRule(&events.APIGatewayProxyRequest{Body: body})
+		assert.Error(t, err, body)
+	}
+}
+
+func testBacktestJob() *backtestJob {
+	start, end := strfmt.DateTime(backtestStart), strfmt.DateTime(backtestStart.Add(time.Hour))
+	return &backtestJob{
+		Input: &models.BacktestRule{
+			Body:               "def rule(event): return True",
+			DedupPeriodMinutes: 60,
+			EndTime:            &end,
+			LogTypes:           []string{"AWS.CloudTrail"},
+			MaxEvents:          10,
+			SampleSize:         aws.Int64(1),
+			StartTime:          &start,
+			Threshold:          1,
+		},
+		Result: &models.BacktestResult{
+			BacktestID:      aws.String("3601990c-2b89-4f4b-b4e6-b4e1a3b6a1a2"),
+			EventsErrored:   aws.Int64(0),
+			EventsMatched:   aws.Int64(0),
+			EventsScanned:   aws.Int64(0),
+			ProjectedAlerts: aws.Int64(0),
+			SampleErrors:    []string{},
+			SampleMatches:   []string{},
+			Status:          aws.String(models.BacktestResultStatusRUNNING),
+			Truncated:       aws.Bool(false),
+		},
+	}
+}
+
+// A gzipped S3 object with one event per minute
+func backtestObject(t *testing.T, minutes ...int) *s3.GetObjectOutput {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	for _, m := range minutes {
+		eventTime := backtestStart.Add(time.Duration(m) * time.Minute).Format(awsglue.TimestampLayout)
+		_, err := writer.Write([]byte(`{"p_event_time": "` + eventTime + `"}` + "\n"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return &s3.GetObjectOutput{Body: ioutil.NopCloser(&buf)}
+}
+
+func TestBacktestPause(t *testing.T) {
+	b := newBacktest(testBacktestJob())
+	line := `{"p_event_time": "` + backtestStart.Add(time.Minute).Format(awsglue.TimestampLayout) + `"}`
+	require.NoError(t, b.addEvent(line, backtestStart, backtestStart.Add(time.Hour)))
+	assert.Equal(t, int64(1), *b.Result.EventsScanned)
+
+	// The scan pauses without reading anything once the invocation runs out of time
+	b.deadline = time.Now().Add(-time.Second)
+	assert.Equal(t, errBacktestPaused, b.scan())
+	assert.Equal(t, backtestStart, b.Cursor.Hour)
+	assert.Equal(t, int64(1), *b.Result.EventsScanned)
+}
+
+func TestBacktestResume(t *testing.T) {
+	mockS3, mockLambda := &testutils.S3Mock{}, &testutils.LambdaMock{}
+	s3Client = mockS3
+	ruleEngine = analysis.NewRuleEngine(mockLambda, "panther-rules-engine")
+
+	// The first object was partially read (2 lines) before the backtest was paused
+	job := testBacktestJob()
+	job.Cursor = backtestCursor{Hour: backtestStart, Key: "b.json.gz", Line: 2}
+	*job.Result.EventsScanned = 2
+
+	mockS3.On("ListObjectsV2Pages", mock.Anything, mock.Anything).Return(&s3.ListObjectsV2Output{
+		Contents: []*s3.Object{{Key: aws.String("a.json.gz")}, {Key: aws.String("b.json.gz")}, {Key: aws.String("c.json.gz")}},
+	}, nil).Once()
+	mockS3.On("GetObject", &s3.GetObjectInput{Bucket: &env.ProcessedDataBucket, Key: aws.String("b.json.gz")}).
+		Return(backtestObject(t, 0, 1, 2), nil).Once()
+	mockS3.On("GetObject", &s3.GetObjectInput{Bucket: &env.ProcessedDataBucket, Key: aws.String("c.json.gz")}).
+		Return(backtestObject(t, 3, 4), nil).Once()
+
+	engineOutput, err := jsoniter.Marshal(&enginemodels.RulesEngineOutput{Events: []enginemodels.EventAnalysis{
+		{ID: "3", Matched: []string{backtestRuleID}, Dedup: "alice"},
+		{ID: "4", NotMatched: []string{backtestRuleID}},
+		{ID: "5", Matched: []string{backtestRuleID}, Dedup: "bob"},
+	}})
+	require.NoError(t, err)
+	mockLambda.On("Invoke", mock.MatchedBy(func(input *lambda.InvokeInput) bool {
+		var engineInput enginemodels.RulesEngineInput
+		return jsoniter.Unmarshal(input.Payload, &engineInput) == nil && len(engineInput.Events) == 3
+	})).Return(&lambda.InvokeOutput{Payload: engineOutput}, nil).Once()
+
+	done, err := newBacktest(job).run(time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, done)
+	assert.Equal(t, int64(5), *job.Result.EventsScanned)
+	assert.Equal(t, int64(2), *job.Result.EventsMatched)
+	assert.Equal(t, int64(2), *job.Result.ProjectedAlerts)
+	assert.Equal(t, []string{`{"p_event_time": "` + backtestStart.Add(2*time.Minute).Format(awsglue.TimestampLayout) + `"}`},
+		job.Result.SampleMatches)
+	mockS3.AssertExpectations(t)
+	mockLambda.AssertExpectations(t)
+}
+
+func TestBacktestRuleStartsJob(t *testing.T) {
+	mockS3, mockLambda := &testutils.S3Mock{}, &testutils.LambdaMock{}
+	s3Client, lambdaClient = mockS3, mockLambda
+	env.BacktestBucket = "test-backtests"
+
+	var saved backtestJob
+	mockS3.On("PutObject", mock.MatchedBy(func(input *s3.PutObjectInput) bool {
+		body, _ := ioutil.ReadAll(input.Body)
+		return *input.Bucket == "test-backtests" && jsoniter.Unmarshal(body, &saved) == nil
+	})).Return(&s3.PutObjectOutput{}, nil).Once()
+	mockLambda.On("Invoke", mock.MatchedBy(func(input *lambda.InvokeInput) bool {
+		return *input.InvocationType == lambda.InvocationTypeEvent
+	})).Return(&lambda.InvokeOutput{}, nil).Once()
+
+	response := BacktestRule(&events.APIGatewayProxyRequest{Body: `{
+		"body": "def rule(event): return True",
+		"logTypes": ["AWS.CloudTrail"],
+		"startTime": "2020-03-01T00:00:00Z",
+		"endTime": "2020-03-02T00:00:00Z"
+	}`})
+	require.Equal(t, http.StatusAccepted, response.StatusCode)
+
+	var result models.BacktestResult
+	require.NoError(t, jsoniter.UnmarshalFromString(response.Body, &result))
+	assert.Equal(t, models.BacktestResultStatusRUNNING, *result.Status)
+	assert.Equal(t, *result.BacktestID, *saved.Result.BacktestID)
+	mockS3.AssertExpectations(t)
+	mockLambda.AssertExpectations(t)
+}
+
+func TestGetBacktest(t *testing.T) {
+	mockS3 := &testutils.S3Mock{}
+	s3Client = mockS3
+	env.BacktestBucket = "test-backtests"
+
+	job := testBacktestJob()
+	body, err := jsoniter.Marshal(job)
+	require.NoError(t, err)
+	mockS3.On("GetObject", &s3.GetObjectInput{
+		Bucket: aws.String("test-backtests"),
+		Key:    aws.String(backtestKey(*job.Result.BacktestID)),
+	}).Return(&s3.GetObjectOutput{Body: ioutil.NopCloser(bytes.NewReader(body))}, nil).Once()
+
+	response := GetBacktest(&events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{"backtestId": *job.Result.BacktestID},
+	})
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var result models.BacktestResult
+	require.NoError(t, jsoniter.UnmarshalFromString(response.Body, &result))
+	assert.Equal(t, *job.Result, result)
+	mockS3.AssertExpectations(t)
+}
+
+func TestGetBacktestNotFound(t *testing.T) {
+	mockS3 := &testutils.S3Mock{}
+	s3Client = mockS3
+	mockS3.On("GetObject", mock.Anything).Return(
+		(*s3.GetObjectOutput)(nil), awserr.New(s3.ErrCodeNoSuchKey, "not found", nil)).Once()
+
+	response := GetBacktest(&events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{"backtestId": "3601990c-2b89-4f4b-b4e6-b4e1a3b6a1a2"},
+	})
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	response = GetBacktest(&events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{"backtestId": "../reports"},
+	})
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	mockS3.AssertExpectations(t)
+}
+
+func TestRunBacktestFinished(t *testing.T) {
+	mockS3 := &testutils.S3Mock{}
+	s3Client = mockS3
+
+	// A retried invocation does not run the backtest again
+	job := testBacktestJob()
+	job.Result.Status = aws.String(models.BacktestResultStatusSUCCEEDED)
+	body, err := jsoniter.Marshal(job)
+	require.NoError(t, err)
+	mockS3.On("GetObject", mock.Anything).Return(
+		&s3.GetObjectOutput{Body: ioutil.NopCloser(strings.NewReader(string(body)))}, nil).Once()
+
+	require.NoError(t, RunBacktest(context.Background(), &BacktestEvent{BacktestID: *job.Result.BacktestID}))
+	mockS3.AssertExpectations(t)
+}
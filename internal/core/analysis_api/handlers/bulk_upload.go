@@ -60,6 +60,13 @@ func BulkUpload(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyR
 		return badRequest(err)
 	}
 
+	if input.DryRun {
+		return planBulkUpload(policies)
+	}
+	if input.Atomic {
+		return atomicBulkUpload(policies, input.UserID)
+	}
+
 	// Create/modify each policy in parallel
 	results := make(chan writeResult)
 	for _, policy := range policies {
@@ -0,0 +1,164 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+// atomicBulkUpload saves either all of the uploaded items or none of them.
+func atomicBulkUpload(policies map[models.ID]*tableItem, userID models.UserID) *events.APIGatewayProxyResponse {
+	ids := make([]models.ID, 0, len(policies))
+	for id := range policies {
+		ids = append(ids, id)
+	}
+
+	oldItems, err := dynamoBatchGet(ids, true)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	counts, errResponse := atomicWriteItems(policies, oldItems, userID)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	// If at least one global was uploaded, rebuild the global layer
+	if aws.Int64Value(counts.TotalGlobals) > 0 {
+		if err := updateLayer(); err != nil {
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		}
+	}
+
+	return gatewayapi.MarshalResponse(counts, http.StatusOK)
+}
+
+// atomicWriteItems saves either all of the items or none of them, compared against their current versions.
+//
+// Every item is checked before anything is written. New versions are then staged in S3 and
+// committed to Dynamo with transactional writes. If the commit fails, the staged S3 versions
+// are deleted again. Compliance status is only updated after the commit.
+//
+// A transaction holds at most 25 items: with more changes, the commit is only atomic on a best-effort
+// basis (see dynamoTransactPut) and counts.Transactions is more than 1.
+//
+// Written items have their new VersionID set. On failure, the error response is returned instead of the counts.
+func atomicWriteItems(
+	policies, oldItems map[models.ID]*tableItem, userID models.UserID) (*models.BulkUploadResult, *events.APIGatewayProxyResponse) {
+
+	ids := make([]models.ID, 0, len(policies))
+	for id := range policies {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	counts := &models.BulkUploadResult{
+		ModifiedPolicies: aws.Int64(0),
+		NewPolicies:      aws.Int64(0),
+		TotalPolicies:    aws.Int64(0),
+
+		ModifiedRules: aws.Int64(0),
+		NewRules:      aws.Int64(0),
+		TotalRules:    aws.Int64(0),
+
+		ModifiedGlobals: aws.Int64(0),
+		NewGlobals:      aws.Int64(0),
+		TotalGlobals:    aws.Int64(0),
+	}
+
+	// Validate every item against its current version before writing anything
+	var puts []*transactPut
+	for _, id := range ids {
+		item, oldItem := policies[id], oldItems[id]
+		changeType, write, err := prepareItem(oldItem, item, userID, nil)
+		if err == errWrongType {
+			msg := fmt.Sprintf("ID %s does not have expected type %s", item.ID, item.Type)
+			return nil, gatewayapi.MarshalResponse(&models.Error{Message: &msg}, http.StatusConflict)
+		}
+		if err != nil {
+			return nil, &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		}
+
+		countPlanItem(counts, &models.BulkUploadPlanItem{
+			Action:       aws.String(planAction(changeType)),
+			AnalysisType: models.AnalysisType(item.Type),
+		})
+		if write {
+			puts = append(puts, &transactPut{oldItem: oldItem, item: item})
+		}
+	}
+
+	// Stage the new versions in S3
+	staged := make([]*tableItem, 0, len(puts))
+	for _, put := range puts {
+		if err := s3Upload(put.item); err != nil {
+			s3DeleteVersions(staged)
+			return nil, &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		}
+		staged = append(staged, put.item)
+	}
+
+	// Commit to Dynamo - this is the point of no return
+	if err := dynamoTransactPut(puts); err != nil {
+		s3DeleteVersions(staged)
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == dynamodb.ErrCodeTransactionCanceledException {
+			msg := "upload conflicts with a concurrent change, nothing was saved"
+			return nil, gatewayapi.MarshalResponse(&models.Error{Message: &msg}, http.StatusConflict)
+		}
+		return nil, &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	counts.Transactions = aws.Int64(int64(transactCount(len(puts))))
+
+	for _, put := range puts {
+		if put.item.Type != typePolicy {
+			continue
+		}
+		// Updated policies may require changes to the compliance status.
+		if err := updateComplianceStatus(put.oldItem, put.item); err != nil {
+			zap.L().Error("bulk upload successful but failed to update compliance status",
+				zap.String("policyId", string(put.item.ID)), zap.Error(err))
+			// The compliance status will still be updated on the next daily scan / resource change
+		}
+	}
+
+	return counts, nil
+}
+
+// Convert a writeItem change type into the equivalent plan action
+func planAction(changeType int) string {
+	switch changeType {
+	case newItem:
+		return models.BulkUploadPlanItemActionCREATE
+	case updatedItem:
+		return models.BulkUploadPlanItemActionMODIFY
+	default:
+		return models.BulkUploadPlanItemActionUNCHANGED
+	}
+}
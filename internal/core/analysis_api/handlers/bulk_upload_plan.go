@@ -0,0 +1,215 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	"github.com/panther-labs/panther/internal/core/analysis_api/analysis"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+type planResult struct {
+	plan *models.BulkUploadPlanItem
+	err  error
+}
+
+// planBulkUpload previews a bulk upload without writing anything to Dynamo or S3.
+//
+// Every item is compared against its current version and its unit tests are run through the engine.
+func planBulkUpload(policies map[models.ID]*tableItem) *events.APIGatewayProxyResponse {
+	counts := &models.BulkUploadResult{
+		ModifiedPolicies: aws.Int64(0),
+		NewPolicies:      aws.Int64(0),
+		TotalPolicies:    aws.Int64(0),
+
+		ModifiedRules: aws.Int64(0),
+		NewRules:      aws.Int64(0),
+		TotalRules:    aws.Int64(0),
+
+		ModifiedGlobals: aws.Int64(0),
+		NewGlobals:      aws.Int64(0),
+		TotalGlobals:    aws.Int64(0),
+	}
+
+	plans, err := planItems(policies)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	for _, plan := range plans {
+		countPlanItem(counts, plan)
+	}
+	counts.Plan = plans
+
+	return gatewayapi.MarshalResponse(counts, http.StatusOK)
+}
+
+// planItems plans every item in parallel, returning the plans sorted by ID.
+func planItems(policies map[models.ID]*tableItem) ([]*models.BulkUploadPlanItem, error) {
+	results := make(chan planResult)
+	for _, policy := range policies {
+		go func(item *tableItem) {
+			defer func() {
+				// Recover from panic so we don't block forever when waiting for routines to finish.
+				if r := recover(); r != nil {
+					zap.L().Error("panicked while planning item",
+						zap.String("id", string(item.ID)), zap.Any("panic", r))
+					results <- planResult{err: errors.New("panicked goroutine")}
+				}
+			}()
+			plan, err := planItem(item)
+			results <- planResult{plan: plan, err: err}
+		}(policy)
+	}
+
+	// Wait for all the goroutines to finish.
+	plans := make([]*models.BulkUploadPlanItem, 0, len(policies))
+	var err error
+	for range policies {
+		result := <-results
+		if result.err != nil {
+			err = result.err
+			continue
+		}
+		plans = append(plans, result.plan)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(plans, func(i, j int) bool { return plans[i].ID < plans[j].ID })
+	return plans, nil
+}
+
+// planItem determines what a bulk upload would do with a single item.
+//
+// An error is only returned if the plan could not be built, invalid items are marked as FAIL.
+func planItem(item *tableItem) (*models.BulkUploadPlanItem, error) {
+	plan := &models.BulkUploadPlanItem{
+		AnalysisType: models.AnalysisType(item.Type),
+		ID:           item.ID,
+	}
+
+	oldItem, err := dynamoGet(item.ID, true)
+	if err != nil {
+		return nil, err
+	}
+	if oldItem != nil && oldItem.Type != item.Type {
+		plan.Action = aws.String(models.BulkUploadPlanItemActionFAIL)
+		plan.Error = fmt.Sprintf("ID %s does not have expected type %s", item.ID, item.Type)
+		return plan, nil
+	}
+
+	if item.Type != typeGlobal && len(item.Tests) > 0 {
+		testResults, err := testItem(item)
+		if err != nil {
+			if _, ok := err.(*analysis.TestInputError); ok {
+				plan.Action = aws.String(models.BulkUploadPlanItemActionFAIL)
+				plan.Error = err.Error()
+				return plan, nil
+			}
+			return nil, err
+		}
+
+		plan.TestResults = &testResults
+		if !testResults.TestSummary {
+			plan.Action = aws.String(models.BulkUploadPlanItemActionFAIL)
+			plan.Error = "unit tests failed"
+			return plan, nil
+		}
+	}
+
+	return plan, diffPlanItem(plan, oldItem, item)
+}
+
+// Run the unit tests for a policy or rule through the appropriate engine
+func testItem(item *tableItem) (models.TestPolicyResult, error) {
+	input := &models.TestPolicy{
+		AnalysisType:  models.AnalysisType(item.Type),
+		Body:          item.Body,
+		ResourceTypes: item.ResourceTypes,
+		Tests:         item.Tests,
+	}
+	if item.Type == typeRule {
+		return ruleEngine.TestRule(input)
+	}
+	return policyEngine.TestPolicy(input)
+}
+
+// Set the plan action (and diff, if applicable) by comparing an item with its current version
+func diffPlanItem(plan *models.BulkUploadPlanItem, oldItem, item *tableItem) error {
+	if oldItem == nil {
+		plan.Action = aws.String(models.BulkUploadPlanItemActionCREATE)
+		return nil
+	}
+	if !itemUpdated(oldItem, item) {
+		plan.Action = aws.String(models.BulkUploadPlanItemActionUNCHANGED)
+		return nil
+	}
+
+	bodyDiff, err := diffBody(oldItem, item, string(oldItem.VersionID), "upload")
+	if err != nil {
+		return err
+	}
+	changes, err := diffFields(oldItem, item)
+	if err != nil {
+		return err
+	}
+
+	plan.Action = aws.String(models.BulkUploadPlanItemActionMODIFY)
+	plan.BodyDiff = bodyDiff
+	plan.Changes = changes
+	return nil
+}
+
+// Add a planned item to the summary counts, in the same way a real upload would count it
+func countPlanItem(counts *models.BulkUploadResult, plan *models.BulkUploadPlanItem) {
+	action := aws.StringValue(plan.Action)
+	if action == models.BulkUploadPlanItemActionFAIL {
+		return
+	}
+
+	var total, created, modified *int64
+	switch string(plan.AnalysisType) {
+	case typePolicy:
+		total, created, modified = counts.TotalPolicies, counts.NewPolicies, counts.ModifiedPolicies
+	case typeRule:
+		total, created, modified = counts.TotalRules, counts.NewRules, counts.ModifiedRules
+	case typeGlobal:
+		total, created, modified = counts.TotalGlobals, counts.NewGlobals, counts.ModifiedGlobals
+	default:
+		return
+	}
+
+	*total++
+	if action == models.BulkUploadPlanItemActionCREATE {
+		*created++
+	} else if action == models.BulkUploadPlanItemActionMODIFY {
+		*modified++
+	}
+}
@@ -0,0 +1,98 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+)
+
+func TestDiffPlanItemCreate(t *testing.T) {
+	plan := &models.BulkUploadPlanItem{}
+	require.NoError(t, diffPlanItem(plan, nil, &tableItem{ID: "MyRule", Type: typeRule}))
+	assert.Equal(t, models.BulkUploadPlanItemActionCREATE, aws.StringValue(plan.Action))
+	assert.Empty(t, plan.Changes)
+}
+
+func TestDiffPlanItemUnchanged(t *testing.T) {
+	oldItem := &tableItem{Body: "def rule(e): return True", ID: "MyRule", Severity: "LOW", Type: typeRule,
+		VersionID: "TsKejJ6GGi_KdH65g2iu9bcww8JxkkwI", LastModifiedBy: "someone"}
+	item := &tableItem{Body: "def rule(e): return True", ID: "MyRule", Severity: "LOW", Type: typeRule}
+
+	plan := &models.BulkUploadPlanItem{}
+	require.NoError(t, diffPlanItem(plan, oldItem, item))
+	assert.Equal(t, models.BulkUploadPlanItemActionUNCHANGED, aws.StringValue(plan.Action))
+	assert.Empty(t, plan.BodyDiff)
+}
+
+func TestDiffPlanItemModify(t *testing.T) {
+	oldItem := &tableItem{Body: "def rule(e):\n    return True\n", Enabled: true, ID: "MyRule",
+		Severity: "LOW", Type: typeRule, VersionID: "TsKejJ6GGi_KdH65g2iu9bcww8JxkkwI"}
+	item := &tableItem{Body: "def rule(e):\n    return False\n", Enabled: false, ID: "MyRule",
+		Severity: "LOW", Type: typeRule}
+
+	plan := &models.BulkUploadPlanItem{}
+	require.NoError(t, diffPlanItem(plan, oldItem, item))
+	assert.Equal(t, models.BulkUploadPlanItemActionMODIFY, aws.StringValue(plan.Action))
+	assert.Equal(t, []*models.FieldChange{
+		{Field: aws.String("enabled"), From: aws.String("true"), To: aws.String("false")},
+	}, plan.Changes)
+	assert.Equal(t, "--- TsKejJ6GGi_KdH65g2iu9bcww8JxkkwI\n+++ upload\n@@ -1,2 +1,2 @@\n def rule(e):\n-    return True\n+    return False\n",
+		plan.BodyDiff)
+}
+
+func TestCountPlanItem(t *testing.T) {
+	counts := &models.BulkUploadResult{
+		ModifiedPolicies: aws.Int64(0),
+		NewPolicies:      aws.Int64(0),
+		TotalPolicies:    aws.Int64(0),
+		ModifiedRules:    aws.Int64(0),
+		NewRules:         aws.Int64(0),
+		TotalRules:       aws.Int64(0),
+		ModifiedGlobals:  aws.Int64(0),
+		NewGlobals:       aws.Int64(0),
+		TotalGlobals:     aws.Int64(0),
+	}
+
+	for _, plan := range []*models.BulkUploadPlanItem{
+		{Action: aws.String(models.BulkUploadPlanItemActionCREATE), AnalysisType: models.AnalysisTypeRULE},
+		{Action: aws.String(models.BulkUploadPlanItemActionMODIFY), AnalysisType: models.AnalysisTypeRULE},
+		{Action: aws.String(models.BulkUploadPlanItemActionUNCHANGED), AnalysisType: models.AnalysisTypeRULE},
+		{Action: aws.String(models.BulkUploadPlanItemActionFAIL), AnalysisType: models.AnalysisTypeRULE},
+		{Action: aws.String(models.BulkUploadPlanItemActionMODIFY), AnalysisType: models.AnalysisTypePOLICY},
+		{Action: aws.String(models.BulkUploadPlanItemActionCREATE), AnalysisType: models.AnalysisTypeGLOBAL},
+	} {
+		countPlanItem(counts, plan)
+	}
+
+	assert.Equal(t, int64(3), *counts.TotalRules)
+	assert.Equal(t, int64(1), *counts.NewRules)
+	assert.Equal(t, int64(1), *counts.ModifiedRules)
+	assert.Equal(t, int64(1), *counts.TotalPolicies)
+	assert.Equal(t, int64(0), *counts.NewPolicies)
+	assert.Equal(t, int64(1), *counts.ModifiedPolicies)
+	assert.Equal(t, int64(1), *counts.TotalGlobals)
+	assert.Equal(t, int64(1), *counts.NewGlobals)
+	assert.Equal(t, int64(0), *counts.ModifiedGlobals)
+}
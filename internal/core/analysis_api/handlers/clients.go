@@ -53,12 +53,16 @@ var (
 )
 
 type envConfig struct {
+	BacktestBucket       string `required:"true" split_words:"true"`
 	Bucket               string `required:"true" split_words:"true"`
 	ComplianceAPIHost    string `required:"true" split_words:"true"`
 	ComplianceAPIPath    string `required:"true" split_words:"true"`
+	ExceptionTable       string `required:"true" split_words:"true"`
 	LayerManagerQueueURL string `required:"true" split_words:"true"`
+	PackTable            string `required:"true" split_words:"true"`
 	RulesEngine          string `required:"true" split_words:"true"`
 	PolicyEngine         string `required:"true" split_words:"true"`
+	ProcessedDataBucket  string `required:"true" split_words:"true"`
 	ResourceQueueURL     string `required:"true" split_words:"true"`
 	Table                string `required:"true" split_words:"true"`
 }
@@ -166,9 +166,11 @@ func updateComplianceStatus(oldItem, newItem *tableItem) error {
 	// At this point, we know the compliance value (PASS/FAIL) won't change for any (policy, resource) pairs.
 	// In other words, we don't need to re-evaluate the policy with the Python engine.
 	//
-	// But the compliance table has columns for severity and suppression -
-	// if either of those changed, we can update the compliance API directly.
-	if oldItem.Severity != newItem.Severity || !setEquality(oldItem.Suppressions, newItem.Suppressions) {
+	// But the compliance table has columns for severity, suppression, tags and reports -
+	// if any of those changed, we can update the compliance API directly.
+	if oldItem.Severity != newItem.Severity || !setEquality(oldItem.Suppressions, newItem.Suppressions) ||
+		!setEquality(oldItem.Tags, newItem.Tags) || !reportsEquality(oldItem.Reports, newItem.Reports) {
+
 		return updateComplianceMetadata(newItem)
 	}
 
@@ -178,17 +180,23 @@ func updateComplianceStatus(oldItem, newItem *tableItem) error {
 
 // Update compliance status entries directly.
 //
-// This is used when only the policy severity / suppressions change - we don't need to rescan
-// all affected resources in this case.
+// This is used when only the policy severity / suppressions / exceptions / tags / reports change - we don't
+// need to rescan all affected resources in this case.
 func updateComplianceMetadata(policy *tableItem) error {
 	zap.L().Info("updating compliance status entry",
 		zap.String("policyId", string(policy.ID)),
 	)
-	_, err := complianceClient.Operations.UpdateMetadata(&complianceops.UpdateMetadataParams{
+	suppressions, err := activeSuppressions(policy)
+	if err != nil {
+		return err
+	}
+	_, err = complianceClient.Operations.UpdateMetadata(&complianceops.UpdateMetadataParams{
 		Body: &compliancemodels.UpdateMetadata{
 			PolicyID:     compliancemodels.PolicyID(policy.ID),
+			Reports:      compliancemodels.PolicyReports(policy.Reports),
 			Severity:     compliancemodels.PolicySeverity(policy.Severity),
-			Suppressions: compliancemodels.IgnoreSet(policy.Suppressions),
+			Suppressions: compliancemodels.IgnoreSet(suppressions),
+			Tags:         compliancemodels.PolicyTags(policy.Tags),
 		},
 		HTTPClient: httpClient,
 	})
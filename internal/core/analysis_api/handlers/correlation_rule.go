@@ -0,0 +1,153 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+// CreateCorrelationRule adds a new correlation rule to the Dynamo table.
+func CreateCorrelationRule(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	return writeCorrelationRule(request, aws.Bool(false))
+}
+
+// ModifyCorrelationRule updates an existing correlation rule.
+func ModifyCorrelationRule(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	return writeCorrelationRule(request, aws.Bool(true))
+}
+
+func writeCorrelationRule(request *events.APIGatewayProxyRequest, mustExist *bool) *events.APIGatewayProxyResponse {
+	input, err := parseUpdateCorrelationRule(request)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	item, err := correlationRuleItem(input)
+	if err != nil {
+		if err == errStepNotFound {
+			return badRequest(err)
+		}
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	if _, err := writeItem(item, input.UserID, mustExist); err != nil {
+		switch {
+		case err == errExists:
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusConflict}
+		case err == errNotExists || err == errWrongType:
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusNotFound}
+		default:
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		}
+	}
+
+	if *mustExist {
+		return gatewayapi.MarshalResponse(item.Rule(), http.StatusOK)
+	}
+	return gatewayapi.MarshalResponse(item.Rule(), http.StatusCreated)
+}
+
+var errStepNotFound = errors.New("correlation steps must reference existing rules")
+
+// body parsing shared by CreateCorrelationRule and ModifyCorrelationRule
+func parseUpdateCorrelationRule(request *events.APIGatewayProxyRequest) (*models.UpdateCorrelationRule, error) {
+	var result models.UpdateCorrelationRule
+	if err := jsoniter.UnmarshalFromString(request.Body, &result); err != nil {
+		return nil, err
+	}
+
+	if result.DedupPeriodMinutes == 0 {
+		result.DedupPeriodMinutes = defaultDedupPeriodMinutes
+	}
+
+	if err := result.Validate(nil); err != nil {
+		return nil, err
+	}
+
+	// Rule names are embedded in emails, alert outputs, etc. Prevent a possible injection attack
+	if genericapi.ContainsHTML(string(result.DisplayName)) {
+		return nil, fmt.Errorf("display name: %v", genericapi.ErrContainsHTML)
+	}
+
+	seen := make(map[models.ID]bool, len(result.Correlation.Steps))
+	for _, step := range result.Correlation.Steps {
+		if step == result.ID {
+			return nil, errors.New("correlation.steps: a correlation rule cannot reference itself")
+		}
+		if seen[step] {
+			return nil, fmt.Errorf("correlation.steps: %s is listed more than once", step)
+		}
+		seen[step] = true
+	}
+
+	return &result, nil
+}
+
+// Build the table item, checking that every step is an existing rule.
+//
+// The log types of a correlation rule are the union of the log types of its steps.
+func correlationRuleItem(input *models.UpdateCorrelationRule) (*tableItem, error) {
+	steps, err := dynamoBatchGet(input.Correlation.Steps, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var logTypes models.TypeSet
+	seen := make(map[string]bool)
+	for _, id := range input.Correlation.Steps {
+		step := steps[id]
+		if step == nil || step.Type != typeRule {
+			return nil, errStepNotFound
+		}
+		for _, logType := range step.ResourceTypes {
+			if !seen[logType] {
+				seen[logType] = true
+				logTypes = append(logTypes, logType)
+			}
+		}
+	}
+
+	return &tableItem{
+		Correlation:        input.Correlation,
+		DedupPeriodMinutes: input.DedupPeriodMinutes,
+		Description:        input.Description,
+		DisplayName:        input.DisplayName,
+		Enabled:            input.Enabled,
+		ID:                 input.ID,
+		OutputIds:          input.OutputIds,
+		Reference:          input.Reference,
+		Reports:            input.Reports,
+		ResourceTypes:      logTypes,
+		Runbook:            input.Runbook,
+		Severity:           input.Severity,
+		Tags:               input.Tags,
+		Threshold:          1,
+		Type:               typeCorrelationRule,
+	}, nil
+}
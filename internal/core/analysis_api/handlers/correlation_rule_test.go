@@ -0,0 +1,70 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+)
+
+func TestParseUpdateCorrelationRule(t *testing.T) {
+	result, err := parseUpdateCorrelationRule(&events.APIGatewayProxyRequest{Body: `{
+		"correlation": {
+			"joinKey": "p_any_ip_addresses",
+			"ordered": true,
+			"steps": ["Okta.MFA.Reset", "AWS.Console.Login"],
+			"windowMinutes": 30
+		},
+		"enabled": true,
+		"id": "MFA.Reset.Then.Login",
+		"severity": "HIGH",
+		"userId": "3601990c-2b89-4f4b-b4e6-b4e1a3b6a1a2"
+	}`})
+	require.NoError(t, err)
+	assert.Equal(t, []models.ID{"Okta.MFA.Reset", "AWS.Console.Login"}, result.Correlation.Steps)
+	assert.True(t, result.Correlation.Ordered)
+	assert.Equal(t, models.DedupPeriodMinutes(defaultDedupPeriodMinutes), result.DedupPeriodMinutes)
+}
+
+func TestParseUpdateCorrelationRuleInvalid(t *testing.T) {
+	const base = `"enabled": true, "id": "correlation.id", "severity": "HIGH", "userId": "3601990c-2b89-4f4b-b4e6-b4e1a3b6a1a2"`
+	bodies := []string{
+		// missing correlation
+		`{` + base + `}`,
+		// a single step
+		`{` + base + `, "correlation": {"joinKey": "ip", "steps": ["a"], "windowMinutes": 30}}`,
+		// duplicate step
+		`{` + base + `, "correlation": {"joinKey": "ip", "steps": ["a", "a"], "windowMinutes": 30}}`,
+		// references itself
+		`{` + base + `, "correlation": {"joinKey": "ip", "steps": ["a", "correlation.id"], "windowMinutes": 30}}`,
+		// missing join key
+		`{` + base + `, "correlation": {"steps": ["a", "b"], "windowMinutes": 30}}`,
+		// window too large
+		`{` + base + `, "correlation": {"joinKey": "ip", "steps": ["a", "b"], "windowMinutes": 10000}}`,
+	}
+	for _, body := range bodies {
+		_, err := parseUpdateCorrelationRule(&events.APIGatewayProxyRequest{Body: body})
+		assert.Error(t, err, body)
+	}
+}
@@ -0,0 +1,57 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+// ListDataModels returns the canonical field mappings of every log type which has a data model.
+func ListDataModels(_ *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	return gatewayapi.MarshalResponse(listDataModels(registry.Default()), http.StatusOK)
+}
+
+func listDataModels(logTypes *logtypes.Registry) *models.DataModels {
+	result := &models.DataModels{
+		Mappings: make(map[string]map[string]string),
+		Models:   make(map[string][]string, len(logtypes.Models)),
+	}
+	for _, model := range logtypes.Models {
+		result.Models[model.Name] = model.Fields
+	}
+	for _, entry := range logTypes.Entries() {
+		dataModel := entry.DataModel()
+		if len(dataModel) == 0 {
+			continue
+		}
+		mappings := make(map[string]string, len(dataModel))
+		for field, path := range dataModel {
+			mappings[field] = path
+		}
+		result.Mappings[entry.Describe().Name] = mappings
+	}
+	return result
+}
@@ -0,0 +1,58 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+type dataModelTestEvent struct {
+	parsers.PantherLog
+	ClientIP *string `json:"clientIp,omitempty" description:"client ip"`
+}
+
+func TestListDataModels(t *testing.T) {
+	r := &logtypes.Registry{}
+	config := logtypes.Config{
+		Name:         "Test.Mapped",
+		Description:  "mapped logs",
+		ReferenceURL: "-",
+		Schema:       dataModelTestEvent{},
+		NewParser: parsers.FactoryFunc(func(params interface{}) (parsers.Interface, error) {
+			return nil, nil
+		}),
+		DataModel: logtypes.DataModel{logtypes.FieldSourceIP: "clientIp"},
+	}
+	r.MustRegister(config)
+	config.Name = "Test.Unmapped"
+	config.DataModel = nil
+	r.MustRegister(config)
+
+	result := listDataModels(r)
+	require.NoError(t, result.Validate(nil))
+	assert.Equal(t, map[string]map[string]string{"Test.Mapped": {"source_ip": "clientIp"}}, result.Mappings)
+	assert.Len(t, result.Models, len(logtypes.Models))
+	assert.Equal(t, []string{"actor_user", "source_ip", "user_agent"}, result.Models["identity"])
+}
@@ -46,6 +46,14 @@ func DeletePolicies(request *events.APIGatewayProxyRequest) *events.APIGatewayPr
 		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
 	}
 
+	policyIDs := make([]models.ID, len(input.Policies))
+	for i, entry := range input.Policies {
+		policyIDs[i] = entry.ID
+	}
+	if err = exceptionRevokeForPolicies(policyIDs, input.UserID); err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
 	return &events.APIGatewayProxyResponse{StatusCode: http.StatusOK}
 }
 
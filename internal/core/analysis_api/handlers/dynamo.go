@@ -35,10 +35,15 @@ import (
 )
 
 const (
-	typePolicy       = string(models.AnalysisTypePOLICY)
-	typeGlobal       = string(models.AnalysisTypeGLOBAL)
-	typeRule         = string(models.AnalysisTypeRULE)
-	maxDynamoBackoff = 30 * time.Second
+	typePolicy          = string(models.AnalysisTypePOLICY)
+	typeGlobal          = string(models.AnalysisTypeGLOBAL)
+	typeRule            = string(models.AnalysisTypeRULE)
+	typeScheduledQuery  = string(models.AnalysisTypeSCHEDULEDQUERY)
+	typeCorrelationRule = string(models.AnalysisTypeCORRELATIONRULE)
+	maxDynamoBackoff    = 30 * time.Second
+
+	// AWS limit: each TransactWriteItems call can include at most 25 items.
+	maxTransactWriteItems = 25
 )
 
 // The policy struct stored in Dynamo isn't quite the same as the policy struct returned in the API.
@@ -52,6 +57,8 @@ type tableItem struct {
 	Body                      models.Body                      `json:"body"`
 	CreatedAt                 models.ModifyTime                `json:"createdAt"`
 	CreatedBy                 models.UserID                    `json:"createdBy"`
+	Correlation               *models.Correlation              `json:"correlation,omitempty"`
+	DedupColumn               models.DedupColumn               `json:"dedupColumn,omitempty"`
 	DedupPeriodMinutes        models.DedupPeriodMinutes        `json:"dedupPeriodMinutes,omitempty"`
 	Threshold                 models.Threshold                 `json:"threshold,omitempty"`
 	Description               models.Description               `json:"description,omitempty"`
@@ -67,16 +74,18 @@ type tableItem struct {
 	LowerTags        []string `json:"lowerTags,omitempty" dynamodbav:"lowerTags,stringset,omitempty"`
 
 	OutputIds     models.OutputIds    `json:"outputIds,omitempty" dynamodbav:"outputIds,stringset,omitempty"`
+	PackID        models.ID           `json:"packId,omitempty"`
 	Reference     models.Reference    `json:"reference,omitempty"`
 	Reports       models.Reports      `json:"reports,omitempty"`
 	ResourceTypes models.TypeSet      `json:"resourceTypes,omitempty" dynamodbav:"resourceTypes,stringset,omitempty"`
 	Runbook       models.Runbook      `json:"runbook,omitempty"`
+	Schedule      models.Schedule     `json:"schedule,omitempty"`
 	Severity      models.Severity     `json:"severity"`
 	Suppressions  models.Suppressions `json:"suppressions,omitempty" dynamodbav:"suppressions,stringset,omitempty"`
 	Tags          models.Tags         `json:"tags,omitempty" dynamodbav:"tags,stringset,omitempty"`
 	Tests         []*models.UnitTest  `json:"tests,omitempty"`
 
-	// Logic type (policy or rule)
+	// Logic type (policy, rule, global, scheduled query or correlation rule)
 	Type string `json:"type"`
 
 	VersionID models.VersionID `json:"versionId,omitempty"`
@@ -114,6 +123,7 @@ func (r *tableItem) Policy(status models.ComplianceStatus) *models.Policy {
 		LastModified:              r.LastModified,
 		LastModifiedBy:            r.LastModifiedBy,
 		OutputIds:                 r.OutputIds,
+		PackID:                    r.PackID,
 		Reference:                 r.Reference,
 		ResourceTypes:             r.ResourceTypes,
 		Runbook:                   r.Runbook,
@@ -162,6 +172,7 @@ func (r *tableItem) Rule() *models.Rule {
 		LastModifiedBy:     r.LastModifiedBy,
 		LogTypes:           r.ResourceTypes,
 		OutputIds:          r.OutputIds,
+		PackID:             r.PackID,
 		Reference:          r.Reference,
 		Runbook:            r.Runbook,
 		Severity:           r.Severity,
@@ -170,6 +181,9 @@ func (r *tableItem) Rule() *models.Rule {
 		VersionID:          r.VersionID,
 		DedupPeriodMinutes: r.DedupPeriodMinutes,
 		Threshold:          r.Threshold,
+		Schedule:           r.Schedule,
+		DedupColumn:        r.DedupColumn,
+		Correlation:        r.Correlation,
 	}
 	gatewayapi.ReplaceMapSliceNils(result)
 	return result
@@ -186,6 +200,7 @@ func (r *tableItem) Global() *models.Global {
 		ID:             r.ID,
 		LastModified:   r.LastModified,
 		LastModifiedBy: r.LastModifiedBy,
+		PackID:         r.PackID,
 		Tags:           r.Tags,
 		VersionID:      r.VersionID,
 	}
@@ -219,6 +234,42 @@ func dynamoBatchDelete(input *models.DeletePolicies) error {
 	return nil
 }
 
+// Load multiple policies/rules from the Dynamo table, keyed by ID.
+//
+// IDs which don't exist are not included in the result.
+func dynamoBatchGet(policyIDs []models.ID, consistentRead bool) (map[models.ID]*tableItem, error) {
+	result := make(map[models.ID]*tableItem, len(policyIDs))
+	if len(policyIDs) == 0 {
+		return result, nil
+	}
+
+	keys := make([]map[string]*dynamodb.AttributeValue, len(policyIDs))
+	for i, policyID := range policyIDs {
+		keys[i] = tableKey(policyID)
+	}
+
+	response, err := dynamodbbatch.BatchGetItem(dynamoClient, &dynamodb.BatchGetItemInput{
+		RequestItems: map[string]*dynamodb.KeysAndAttributes{
+			env.Table: {ConsistentRead: &consistentRead, Keys: keys},
+		},
+	})
+	if err != nil {
+		zap.L().Error("dynamodbbatch.BatchGetItem failed", zap.Error(err))
+		return nil, err
+	}
+
+	var items []*tableItem
+	if err = dynamodbattribute.UnmarshalListOfMaps(response.Responses[env.Table], &items); err != nil {
+		zap.L().Error("dynamodbattribute.UnmarshalListOfMaps failed", zap.Error(err))
+		return nil, err
+	}
+
+	for _, item := range items {
+		result[item.ID] = item
+	}
+	return result, nil
+}
+
 // Load a policy/rule from the Dynamo table.
 //
 // Returns (nil, nil) if the item doesn't exist.
@@ -321,6 +372,115 @@ func dynamoPut(policy *tableItem) error {
 	return nil
 }
 
+// A single put in a transactional write, along with the item it replaces (nil for new items).
+type transactPut struct {
+	oldItem *tableItem
+	item    *tableItem
+}
+
+// Write policies to the Dynamo table in transactional chunks.
+//
+// Each put only succeeds if the item has not changed since its old version was read.
+// If any chunk fails, the chunks which were already committed are restored to their old versions.
+func dynamoTransactPut(puts []*transactPut) error {
+	for start := 0; start < len(puts); start += maxTransactWriteItems {
+		end := intMin(start+maxTransactWriteItems, len(puts))
+
+		items := make([]*dynamodb.TransactWriteItem, 0, end-start)
+		for _, put := range puts[start:end] {
+			var condition expression.ConditionBuilder
+			if put.oldItem == nil {
+				condition = expression.AttributeNotExists(expression.Name("id"))
+			} else {
+				condition = expression.Equal(expression.Name("versionId"), expression.Value(put.oldItem.VersionID))
+			}
+
+			put.item.addExtraFields()
+			item, err := transactPutItem(put.item, condition)
+			if err != nil {
+				return err
+			}
+			items = append(items, item)
+		}
+
+		if _, err := dynamoClient.TransactWriteItems(&dynamodb.TransactWriteItemsInput{TransactItems: items}); err != nil {
+			zap.L().Error("dynamoClient.TransactWriteItems failed",
+				zap.Int("committedItems", start), zap.Error(err))
+			dynamoTransactRollback(puts[:start])
+			return err
+		}
+	}
+
+	return nil
+}
+
+// The number of transactions dynamoTransactPut uses to write a number of items
+func transactCount(items int) int {
+	return (items + maxTransactWriteItems - 1) / maxTransactWriteItems
+}
+
+// Best-effort restore of items which were committed by dynamoTransactPut.
+func dynamoTransactRollback(puts []*transactPut) {
+	for start := 0; start < len(puts); start += maxTransactWriteItems {
+		end := intMin(start+maxTransactWriteItems, len(puts))
+
+		items := make([]*dynamodb.TransactWriteItem, 0, end-start)
+		for _, put := range puts[start:end] {
+			// Don't clobber any change made after our own write
+			condition := expression.Equal(expression.Name("versionId"), expression.Value(put.item.VersionID))
+
+			if put.oldItem == nil {
+				expr, err := expression.NewBuilder().WithCondition(condition).Build()
+				if err != nil {
+					zap.L().Error("failed to build rollback condition", zap.Error(err))
+					continue
+				}
+				items = append(items, &dynamodb.TransactWriteItem{Delete: &dynamodb.Delete{
+					ConditionExpression:       expr.Condition(),
+					ExpressionAttributeNames:  expr.Names(),
+					ExpressionAttributeValues: expr.Values(),
+					Key:                       tableKey(put.item.ID),
+					TableName:                 &env.Table,
+				}})
+				continue
+			}
+
+			item, err := transactPutItem(put.oldItem, condition)
+			if err != nil {
+				continue
+			}
+			items = append(items, item)
+		}
+
+		if _, err := dynamoClient.TransactWriteItems(&dynamodb.TransactWriteItemsInput{TransactItems: items}); err != nil {
+			zap.L().Error("failed to roll back transactional write", zap.Int("items", len(items)), zap.Error(err))
+		}
+	}
+}
+
+// Build a conditional put for a single item in a transaction
+func transactPutItem(policy *tableItem, condition expression.ConditionBuilder) (*dynamodb.TransactWriteItem, error) {
+	body, err := dynamodbattribute.MarshalMap(policy)
+	if err != nil {
+		zap.L().Error("dynamodbattribute.MarshalMap failed", zap.Error(err))
+		return nil, err
+	}
+
+	expr, err := expression.NewBuilder().WithCondition(condition).Build()
+	if err != nil {
+		zap.L().Error("failed to build put condition", zap.Error(err))
+		return nil, err
+	}
+
+	return &dynamodb.TransactWriteItem{Put: &dynamodb.Put{
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		Item:                      body,
+		TableName:                 &env.Table,
+	}}, nil
+}
+
 // Wrapper around dynamoClient.ScanPages that accepts a handler function to process each item.
 func scanPages(input *dynamodb.ScanInput, handler func(*tableItem) error) error {
 	var handlerErr, unmarshalErr error
@@ -0,0 +1,115 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func testTransactPuts(count int) []*transactPut {
+	puts := make([]*transactPut, count)
+	for i := range puts {
+		puts[i] = &transactPut{
+			item: &tableItem{
+				ID:        models.ID(fmt.Sprintf("Rule.%d", i)),
+				Type:      typeRule,
+				VersionID: "new.version.new.version.new.vers",
+			},
+		}
+		if i%2 == 0 {
+			puts[i].oldItem = &tableItem{
+				ID:        puts[i].item.ID,
+				Type:      typeRule,
+				VersionID: "old.version.old.version.old.vers",
+			}
+		}
+	}
+	return puts
+}
+
+func TestDynamoTransactPut(t *testing.T) {
+	mockDynamo := &testutils.DynamoDBMock{}
+	dynamoClient = mockDynamo
+	mockDynamo.On("TransactWriteItems", mock.Anything).Return(&dynamodb.TransactWriteItemsOutput{}, nil).Twice()
+
+	require.NoError(t, dynamoTransactPut(testTransactPuts(30)))
+	mockDynamo.AssertExpectations(t)
+
+	first := mockDynamo.Calls[0].Arguments.Get(0).(*dynamodb.TransactWriteItemsInput)
+	require.Len(t, first.TransactItems, maxTransactWriteItems)
+	second := mockDynamo.Calls[1].Arguments.Get(0).(*dynamodb.TransactWriteItemsInput)
+	require.Len(t, second.TransactItems, 5)
+
+	// New items must not exist yet, existing items must still be at the version we read
+	assert.Equal(t, "attribute_not_exists (#0)", aws.StringValue(first.TransactItems[1].Put.ConditionExpression))
+	assert.Equal(t, "#0 = :0", aws.StringValue(first.TransactItems[0].Put.ConditionExpression))
+	assert.Equal(t, "old.version.old.version.old.vers",
+		aws.StringValue(first.TransactItems[0].Put.ExpressionAttributeValues[":0"].S))
+	assert.Equal(t, "rule.0", aws.StringValue(first.TransactItems[0].Put.Item["lowerId"].S))
+}
+
+func TestDynamoTransactPutRollback(t *testing.T) {
+	mockDynamo := &testutils.DynamoDBMock{}
+	dynamoClient = mockDynamo
+	mockDynamo.On("TransactWriteItems", mock.Anything).Return(&dynamodb.TransactWriteItemsOutput{}, nil).Once()
+	mockDynamo.On("TransactWriteItems", mock.Anything).Return(
+		&dynamodb.TransactWriteItemsOutput{}, errors.New("transaction cancelled")).Once()
+	mockDynamo.On("TransactWriteItems", mock.Anything).Return(&dynamodb.TransactWriteItemsOutput{}, nil).Once()
+
+	assert.Error(t, dynamoTransactPut(testTransactPuts(30)))
+	mockDynamo.AssertExpectations(t)
+
+	// The first (committed) chunk is restored: old items are put back and new items are deleted
+	rollback := mockDynamo.Calls[2].Arguments.Get(0).(*dynamodb.TransactWriteItemsInput)
+	require.Len(t, rollback.TransactItems, maxTransactWriteItems)
+
+	restored := rollback.TransactItems[0].Put
+	require.NotNil(t, restored)
+	assert.Equal(t, "old.version.old.version.old.vers", aws.StringValue(restored.Item["versionId"].S))
+	assert.Equal(t, "new.version.new.version.new.vers", aws.StringValue(restored.ExpressionAttributeValues[":0"].S))
+
+	deleted := rollback.TransactItems[1].Delete
+	require.NotNil(t, deleted)
+	assert.Equal(t, "Rule.1", aws.StringValue(deleted.Key["id"].S))
+	assert.Equal(t, "new.version.new.version.new.vers", aws.StringValue(deleted.ExpressionAttributeValues[":0"].S))
+}
+
+func TestTransactCount(t *testing.T) {
+	assert.Equal(t, 0, transactCount(0))
+	assert.Equal(t, 1, transactCount(1))
+	assert.Equal(t, 1, transactCount(maxTransactWriteItems))
+	assert.Equal(t, 2, transactCount(maxTransactWriteItems+1))
+}
+
+func TestPlanAction(t *testing.T) {
+	assert.Equal(t, models.BulkUploadPlanItemActionCREATE, planAction(newItem))
+	assert.Equal(t, models.BulkUploadPlanItemActionMODIFY, planAction(updatedItem))
+	assert.Equal(t, models.BulkUploadPlanItemActionUNCHANGED, planAction(noChange))
+}
@@ -0,0 +1,493 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+// A policy exception as stored in the exception table
+type exceptionItem struct {
+	Approver        models.UserID                 `json:"approver"`
+	CreatedAt       models.ModifyTime             `json:"createdAt"`
+	CreatedBy       models.UserID                 `json:"createdBy"`
+	ExceptionID     models.ExceptionID            `json:"exceptionId"`
+	ExpiresAt       models.ExceptionExpiration    `json:"expiresAt"`
+	Justification   models.ExceptionJustification `json:"justification"`
+	Owner           models.ExceptionOwner         `json:"owner"`
+	PolicyID        models.ID                     `json:"policyId"`
+	ResourcePattern models.ResourcePattern        `json:"resourcePattern"`
+
+	// Revoked exceptions are kept as an audit trail
+	RevokedAt *models.ModifyTime `json:"revokedAt,omitempty"`
+	RevokedBy models.UserID      `json:"revokedBy,omitempty"`
+}
+
+func (e *exceptionItem) expired(now time.Time) bool {
+	return !time.Time(e.ExpiresAt).After(now)
+}
+
+func (e *exceptionItem) revoked() bool {
+	return e.RevokedAt != nil
+}
+
+// PolicyException converts a Dynamo row into a PolicyException external model.
+func (e *exceptionItem) PolicyException(now time.Time) *models.PolicyException {
+	result := &models.PolicyException{
+		Approver:        e.Approver,
+		CreatedAt:       e.CreatedAt,
+		CreatedBy:       e.CreatedBy,
+		ExceptionID:     e.ExceptionID,
+		Expired:         e.expired(now),
+		ExpiresAt:       e.ExpiresAt,
+		Justification:   e.Justification,
+		Owner:           e.Owner,
+		PolicyID:        e.PolicyID,
+		ResourcePattern: e.ResourcePattern,
+		Revoked:         e.revoked(),
+		RevokedBy:       e.RevokedBy,
+	}
+	if e.RevokedAt != nil {
+		result.RevokedAt = *e.RevokedAt
+	}
+	return result
+}
+
+// CreateException grants a policy exception for the resources matching a pattern.
+func CreateException(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	input, err := parseCreateException(request)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	policy, err := dynamoGet(input.PolicyID, true)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	if policy == nil || policy.Type != typePolicy {
+		return failedRequest(fmt.Sprintf("Cannot find policy %s", input.PolicyID), http.StatusNotFound)
+	}
+
+	now := time.Now()
+	item := &exceptionItem{
+		Approver:        input.Approver,
+		CreatedAt:       models.ModifyTime(now),
+		CreatedBy:       input.UserID,
+		ExceptionID:     models.ExceptionID(uuid.New().String()),
+		ExpiresAt:       input.ExpiresAt,
+		Justification:   input.Justification,
+		Owner:           input.Owner,
+		PolicyID:        input.PolicyID,
+		ResourcePattern: input.ResourcePattern,
+	}
+	if err = exceptionPut(item); err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	zap.L().Info("created policy exception",
+		zap.String("policyId", string(item.PolicyID)),
+		zap.String("exceptionId", string(item.ExceptionID)),
+		zap.String("createdBy", string(item.CreatedBy)),
+		zap.String("approver", string(item.Approver)))
+
+	// Update compliance status with the new exception
+	if err = updateComplianceMetadata(policy); err != nil {
+		// Log an error, but don't mark the API call as a failure
+		zap.L().Error("failed to update compliance entries with new exception", zap.Error(err))
+	}
+
+	return gatewayapi.MarshalResponse(item.PolicyException(now), http.StatusCreated)
+}
+
+func parseCreateException(request *events.APIGatewayProxyRequest) (*models.CreateException, error) {
+	var result models.CreateException
+	if err := jsoniter.UnmarshalFromString(request.Body, &result); err != nil {
+		return nil, err
+	}
+
+	if err := result.Validate(nil); err != nil {
+		return nil, err
+	}
+
+	if !time.Time(result.ExpiresAt).After(time.Now()) {
+		return nil, errors.New("invalid expiresAt: must be in the future")
+	}
+
+	if result.Approver == result.UserID {
+		return nil, errors.New("invalid approver: exceptions must be approved by another user")
+	}
+
+	return &result, nil
+}
+
+// DeleteExceptions revokes one or more policy exceptions.
+func DeleteExceptions(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	var input models.DeleteExceptions
+	if err := jsoniter.UnmarshalFromString(request.Body, &input); err != nil {
+		return badRequest(err)
+	}
+	if err := input.Validate(nil); err != nil {
+		return badRequest(err)
+	}
+
+	if err := exceptionRevoke(input.Exceptions, input.UserID); err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	// Resources covered only by the revoked exceptions are no longer suppressed
+	policyIDs := make([]models.ID, 0, len(input.Exceptions))
+	seen := make(map[models.ID]bool, len(input.Exceptions))
+	for _, key := range input.Exceptions {
+		if !seen[key.PolicyID] {
+			seen[key.PolicyID] = true
+			policyIDs = append(policyIDs, key.PolicyID)
+		}
+	}
+	policies, err := dynamoBatchGet(policyIDs, true)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	for _, policy := range policies {
+		if err := updateComplianceMetadata(policy); err != nil {
+			// Log an error, but don't mark the API call as a failure
+			zap.L().Error("failed to update compliance entries after revoking exceptions", zap.Error(err))
+		}
+	}
+
+	return &events.APIGatewayProxyResponse{StatusCode: http.StatusOK}
+}
+
+type listExceptionsParams struct {
+	PolicyID          models.ID
+	ExpiresWithinDays int
+	IncludeExpired    bool
+	IncludeRevoked    bool
+}
+
+// ListExceptions lists policy exceptions, soonest to expire first.
+func ListExceptions(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	params, err := parseListExceptions(request)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	var items []*exceptionItem
+	if params.PolicyID != "" {
+		items, err = exceptionQuery(params.PolicyID)
+	} else {
+		items, err = exceptionScan()
+	}
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	now := time.Now()
+	result := &models.ExceptionList{Exceptions: make(models.Exceptions, 0, len(items))}
+	for _, item := range filterExceptions(items, params, now) {
+		result.Exceptions = append(result.Exceptions, item.PolicyException(now))
+	}
+	return gatewayapi.MarshalResponse(result, http.StatusOK)
+}
+
+func parseListExceptions(request *events.APIGatewayProxyRequest) (*listExceptionsParams, error) {
+	var result listExceptionsParams
+
+	if rawID := request.QueryStringParameters["policyId"]; rawID != "" {
+		policyID, err := url.QueryUnescape(rawID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid policyId: %s", err)
+		}
+		result.PolicyID = models.ID(policyID)
+		if err = result.PolicyID.Validate(nil); err != nil {
+			return nil, fmt.Errorf("invalid policyId: %s", err)
+		}
+	}
+
+	if rawDays := request.QueryStringParameters["expiresWithinDays"]; rawDays != "" {
+		days, err := strconv.Atoi(rawDays)
+		if err != nil {
+			return nil, errors.New("invalid expiresWithinDays: " + err.Error())
+		}
+		if days < 1 {
+			return nil, errors.New("invalid expiresWithinDays: must be positive")
+		}
+		result.ExpiresWithinDays = days
+	}
+
+	if rawExpired := request.QueryStringParameters["includeExpired"]; rawExpired != "" {
+		includeExpired, err := strconv.ParseBool(rawExpired)
+		if err != nil {
+			return nil, errors.New("invalid includeExpired: " + err.Error())
+		}
+		result.IncludeExpired = includeExpired
+	}
+
+	if rawRevoked := request.QueryStringParameters["includeRevoked"]; rawRevoked != "" {
+		includeRevoked, err := strconv.ParseBool(rawRevoked)
+		if err != nil {
+			return nil, errors.New("invalid includeRevoked: " + err.Error())
+		}
+		result.IncludeRevoked = includeRevoked
+	}
+
+	return &result, nil
+}
+
+// Apply the list filters and sort the exceptions by expiration.
+func filterExceptions(items []*exceptionItem, params *listExceptionsParams, now time.Time) []*exceptionItem {
+	result := make([]*exceptionItem, 0, len(items))
+	for _, item := range items {
+		if !params.IncludeExpired && item.expired(now) {
+			continue
+		}
+		if !params.IncludeRevoked && item.revoked() {
+			continue
+		}
+		if params.ExpiresWithinDays > 0 &&
+			time.Time(item.ExpiresAt).After(now.AddDate(0, 0, params.ExpiresWithinDays)) {
+
+			continue
+		}
+		result = append(result, item)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		left, right := time.Time(result[i].ExpiresAt), time.Time(result[j].ExpiresAt)
+		if !left.Equal(right) {
+			return left.Before(right)
+		}
+		if result[i].PolicyID != result[j].PolicyID {
+			return result[i].PolicyID < result[j].PolicyID
+		}
+		return result[i].ExceptionID < result[j].ExceptionID
+	})
+	return result
+}
+
+// Load the exceptions of a policy which have not expired or been revoked, soonest to expire first.
+func activeExceptions(policyID models.ID) (models.Exceptions, error) {
+	items, err := exceptionQuery(policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	active := filterExceptions(items, &listExceptionsParams{}, now)
+	result := make(models.Exceptions, 0, len(active))
+	for _, item := range active {
+		result = append(result, item.PolicyException(now))
+	}
+	return result, nil
+}
+
+// Load the active exceptions of every policy, keyed by policy ID.
+func activeExceptionsByPolicy() (map[models.ID]models.Exceptions, error) {
+	items, err := exceptionScan()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := make(map[models.ID]models.Exceptions)
+	for _, item := range filterExceptions(items, &listExceptionsParams{}, now) {
+		result[item.PolicyID] = append(result[item.PolicyID], item.PolicyException(now))
+	}
+	return result, nil
+}
+
+// The suppressions of a policy along with the resource patterns of its active exceptions.
+func activeSuppressions(policy *tableItem) ([]string, error) {
+	exceptions, err := activeExceptions(policy.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(policy.Suppressions)+len(exceptions))
+	result = append(result, policy.Suppressions...)
+	for _, exception := range exceptions {
+		result = append(result, string(exception.ResourcePattern))
+	}
+	return result, nil
+}
+
+func exceptionKey(policyID models.ID, exceptionID models.ExceptionID) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		"policyId":    {S: aws.String(string(policyID))},
+		"exceptionId": {S: aws.String(string(exceptionID))},
+	}
+}
+
+// Write an exception to the exception table.
+func exceptionPut(item *exceptionItem) error {
+	body, err := dynamodbattribute.MarshalMap(item)
+	if err != nil {
+		zap.L().Error("dynamodbattribute.MarshalMap failed", zap.Error(err))
+		return err
+	}
+
+	if _, err = dynamoClient.PutItem(&dynamodb.PutItemInput{Item: body, TableName: &env.ExceptionTable}); err != nil {
+		zap.L().Error("dynamoClient.PutItem failed", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Mark exceptions as revoked by the given user. Exceptions which do not exist or were already
+// revoked are skipped.
+func exceptionRevoke(keys []*models.ExceptionKey, userID models.UserID) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	update := expression.
+		Set(expression.Name("revokedAt"), expression.Value(models.ModifyTime(time.Now()))).
+		Set(expression.Name("revokedBy"), expression.Value(userID))
+	condition := expression.AttributeExists(expression.Name("exceptionId")).And(
+		expression.AttributeNotExists(expression.Name("revokedAt")))
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(condition).Build()
+	if err != nil {
+		zap.L().Error("failed to build update expression", zap.Error(err))
+		return err
+	}
+
+	// Dynamo does not support batch update - proceed sequentially
+	for _, key := range keys {
+		zap.L().Info("revoking policy exception",
+			zap.String("policyId", string(key.PolicyID)),
+			zap.String("exceptionId", string(key.ExceptionID)),
+			zap.String("revokedBy", string(userID)))
+		_, err := dynamoClient.UpdateItem(&dynamodb.UpdateItemInput{
+			ConditionExpression:       expr.Condition(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+			Key:                       exceptionKey(key.PolicyID, key.ExceptionID),
+			TableName:                 &env.ExceptionTable,
+			UpdateExpression:          expr.Update(),
+		})
+
+		if err != nil {
+			aerr, ok := err.(awserr.Error)
+			if ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+				zap.L().Warn("exception not found or already revoked",
+					zap.String("policyId", string(key.PolicyID)),
+					zap.String("exceptionId", string(key.ExceptionID)))
+				continue
+			}
+			zap.L().Error("dynamoClient.UpdateItem failed", zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+// Revoke every exception of the given policies.
+func exceptionRevokeForPolicies(policyIDs []models.ID, userID models.UserID) error {
+	var keys []*models.ExceptionKey
+	for _, policyID := range policyIDs {
+		items, err := exceptionQuery(policyID)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if !item.revoked() {
+				keys = append(keys, &models.ExceptionKey{ExceptionID: item.ExceptionID, PolicyID: item.PolicyID})
+			}
+		}
+	}
+	return exceptionRevoke(keys, userID)
+}
+
+// Load every exception of a single policy.
+func exceptionQuery(policyID models.ID) ([]*exceptionItem, error) {
+	keyCondition := expression.Key("policyId").Equal(expression.Value(string(policyID)))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+	if err != nil {
+		zap.L().Error("failed to build exception query", zap.Error(err))
+		return nil, err
+	}
+
+	var result []*exceptionItem
+	var unmarshalErr error
+	err = dynamoClient.QueryPages(&dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 &env.ExceptionTable,
+	}, func(page *dynamodb.QueryOutput, lastPage bool) bool {
+		var items []*exceptionItem
+		if unmarshalErr = dynamodbattribute.UnmarshalListOfMaps(page.Items, &items); unmarshalErr != nil {
+			return false // stop paginating
+		}
+		result = append(result, items...)
+		return true
+	})
+
+	if unmarshalErr != nil {
+		zap.L().Error("dynamodbattribute.UnmarshalListOfMaps failed", zap.Error(unmarshalErr))
+		return nil, unmarshalErr
+	}
+	if err != nil {
+		zap.L().Error("dynamoClient.QueryPages failed", zap.Error(err))
+		return nil, err
+	}
+	return result, nil
+}
+
+// Load every exception from the exception table.
+func exceptionScan() ([]*exceptionItem, error) {
+	var result []*exceptionItem
+	var unmarshalErr error
+	err := dynamoClient.ScanPages(&dynamodb.ScanInput{TableName: &env.ExceptionTable},
+		func(page *dynamodb.ScanOutput, lastPage bool) bool {
+			var items []*exceptionItem
+			if unmarshalErr = dynamodbattribute.UnmarshalListOfMaps(page.Items, &items); unmarshalErr != nil {
+				return false // stop paginating
+			}
+			result = append(result, items...)
+			return true
+		})
+
+	if unmarshalErr != nil {
+		zap.L().Error("dynamodbattribute.UnmarshalListOfMaps failed", zap.Error(unmarshalErr))
+		return nil, unmarshalErr
+	}
+	if err != nil {
+		zap.L().Error("dynamoClient.ScanPages failed", zap.Error(err))
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,362 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/go-openapi/strfmt"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	complianceapi "github.com/panther-labs/panther/api/gateway/compliance/client"
+	complianceops "github.com/panther-labs/panther/api/gateway/compliance/client/operations"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+var testNow = time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// mockExceptionDynamo adds the paginated reads used by the exception handlers to the Dynamo mock
+type mockExceptionDynamo struct {
+	testutils.DynamoDBMock
+}
+
+func (m *mockExceptionDynamo) QueryPages(input *dynamodb.QueryInput, fn func(*dynamodb.QueryOutput, bool) bool) error {
+	args := m.Called(input, fn)
+	if output := args.Get(0); output != nil {
+		fn(output.(*dynamodb.QueryOutput), true)
+	}
+	return args.Error(1)
+}
+
+func (m *mockExceptionDynamo) BatchGetItemPages(
+	input *dynamodb.BatchGetItemInput, fn func(*dynamodb.BatchGetItemOutput, bool) bool) error {
+
+	args := m.Called(input, fn)
+	if output := args.Get(0); output != nil {
+		fn(output.(*dynamodb.BatchGetItemOutput), true)
+	}
+	return args.Error(1)
+}
+
+// mockComplianceOps records the compliance metadata updates of the exception handlers
+type mockComplianceOps struct {
+	complianceops.ClientService
+	mock.Mock
+}
+
+func (m *mockComplianceOps) UpdateMetadata(params *complianceops.UpdateMetadataParams) (*complianceops.UpdateMetadataOK, error) {
+	args := m.Called(params)
+	return args.Get(0).(*complianceops.UpdateMetadataOK), args.Error(1)
+}
+
+func setupExceptionMocks(t *testing.T) (*mockExceptionDynamo, *mockComplianceOps) {
+	env.Table = "test-analysis"
+	env.ExceptionTable = "test-exceptions"
+	mockDynamo := &mockExceptionDynamo{}
+	mockCompliance := &mockComplianceOps{}
+
+	originalDynamo, originalCompliance := dynamoClient, complianceClient
+	dynamoClient = mockDynamo
+	complianceClient = &complianceapi.PantherCompliance{Operations: mockCompliance}
+	t.Cleanup(func() { dynamoClient, complianceClient = originalDynamo, originalCompliance })
+	return mockDynamo, mockCompliance
+}
+
+func testPolicyItem(t *testing.T, policyID models.ID) map[string]*dynamodb.AttributeValue {
+	item, err := dynamodbattribute.MarshalMap(&tableItem{
+		ID:           policyID,
+		Severity:     models.SeverityHIGH,
+		Suppressions: models.Suppressions{"test.*"},
+		Type:         typePolicy,
+	})
+	require.NoError(t, err)
+	return item
+}
+
+func testExceptionItems(t *testing.T, items ...*exceptionItem) *dynamodb.QueryOutput {
+	output := &dynamodb.QueryOutput{}
+	for _, item := range items {
+		attributes, err := dynamodbattribute.MarshalMap(item)
+		require.NoError(t, err)
+		output.Items = append(output.Items, attributes)
+	}
+	return output
+}
+
+func createExceptionRequest(t *testing.T, input *models.CreateException) *events.APIGatewayProxyRequest {
+	body, err := jsoniter.MarshalToString(input)
+	require.NoError(t, err)
+	return &events.APIGatewayProxyRequest{Body: body}
+}
+
+func testCreateException() *models.CreateException {
+	return &models.CreateException{
+		Approver:        "9d1a4b2e-ec56-44c2-83bc-8b742600f307",
+		ExpiresAt:       models.ExceptionExpiration(strfmt.DateTime(time.Now().Add(24 * time.Hour))),
+		Justification:   "Public website bucket",
+		Owner:           "web-team@example.com",
+		PolicyID:        "AWS.S3.BucketPublicAccess",
+		ResourcePattern: "arn:aws:s3:::example-website*",
+		UserID:          "5f54cf4a-ec56-44c2-83bc-8b742600f307",
+	}
+}
+
+func testException(policyID models.ID, exceptionID models.ExceptionID, expiresIn time.Duration) *exceptionItem {
+	return &exceptionItem{
+		ExceptionID:     exceptionID,
+		ExpiresAt:       models.ExceptionExpiration(strfmt.DateTime(testNow.Add(expiresIn))),
+		PolicyID:        policyID,
+		ResourcePattern: "prod.*",
+	}
+}
+
+func TestExceptionItemExpired(t *testing.T) {
+	assert.False(t, testException("AWS.S3", "1", time.Hour).expired(testNow))
+	assert.True(t, testException("AWS.S3", "1", 0).expired(testNow))
+	assert.True(t, testException("AWS.S3", "1", -time.Hour).expired(testNow))
+
+	result := testException("AWS.S3", "1", -time.Hour).PolicyException(testNow)
+	assert.True(t, result.Expired)
+	assert.False(t, result.Revoked)
+	assert.Equal(t, models.ResourcePattern("prod.*"), result.ResourcePattern)
+
+	revokedAt := models.ModifyTime(testNow)
+	revoked := testException("AWS.S3", "1", time.Hour)
+	revoked.RevokedAt, revoked.RevokedBy = &revokedAt, "5f54cf4a-ec56-44c2-83bc-8b742600f307"
+	result = revoked.PolicyException(testNow)
+	assert.True(t, result.Revoked)
+	assert.Equal(t, revokedAt, result.RevokedAt)
+	assert.Equal(t, models.UserID("5f54cf4a-ec56-44c2-83bc-8b742600f307"), result.RevokedBy)
+}
+
+func TestFilterExceptions(t *testing.T) {
+	day := 24 * time.Hour
+	revokedAt := models.ModifyTime(testNow)
+	revoked := testException("AWS.S3", "revoked", 10*day)
+	revoked.RevokedAt = &revokedAt
+	items := []*exceptionItem{
+		testException("AWS.S3", "later", 30*day),
+		testException("AWS.S3", "expired", -day),
+		testException("AWS.IAM", "soon", 2*day),
+		testException("AWS.EC2", "soon", 2*day),
+		revoked,
+	}
+
+	ids := func(result []*exceptionItem) (out []string) {
+		for _, item := range result {
+			out = append(out, string(item.PolicyID)+"/"+string(item.ExceptionID))
+		}
+		return
+	}
+
+	assert.Equal(t, []string{"AWS.EC2/soon", "AWS.IAM/soon", "AWS.S3/later"},
+		ids(filterExceptions(items, &listExceptionsParams{}, testNow)))
+	assert.Equal(t, []string{"AWS.S3/expired", "AWS.EC2/soon", "AWS.IAM/soon", "AWS.S3/later"},
+		ids(filterExceptions(items, &listExceptionsParams{IncludeExpired: true}, testNow)))
+	assert.Equal(t, []string{"AWS.EC2/soon", "AWS.IAM/soon", "AWS.S3/revoked", "AWS.S3/later"},
+		ids(filterExceptions(items, &listExceptionsParams{IncludeRevoked: true}, testNow)))
+	assert.Equal(t, []string{"AWS.EC2/soon", "AWS.IAM/soon"},
+		ids(filterExceptions(items, &listExceptionsParams{ExpiresWithinDays: 7}, testNow)))
+	assert.Empty(t, filterExceptions(nil, &listExceptionsParams{}, testNow))
+}
+
+func TestParseListExceptions(t *testing.T) {
+	result, err := parseListExceptions(&events.APIGatewayProxyRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, &listExceptionsParams{}, result)
+
+	result, err = parseListExceptions(&events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{
+			"policyId":          "AWS.S3.Bucket%20Encryption",
+			"expiresWithinDays": "14",
+			"includeExpired":    "true",
+			"includeRevoked":    "true",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &listExceptionsParams{
+		PolicyID:          "AWS.S3.Bucket Encryption",
+		ExpiresWithinDays: 14,
+		IncludeExpired:    true,
+		IncludeRevoked:    true,
+	}, result)
+
+	for _, query := range []map[string]string{
+		{"expiresWithinDays": "0"},
+		{"expiresWithinDays": "soon"},
+		{"includeExpired": "maybe"},
+		{"includeRevoked": "maybe"},
+	} {
+		_, err = parseListExceptions(&events.APIGatewayProxyRequest{QueryStringParameters: query})
+		assert.Error(t, err, query)
+	}
+}
+
+func TestExceptionPut(t *testing.T) {
+	env.ExceptionTable = "test-exceptions"
+	mockDynamo := &testutils.DynamoDBMock{}
+	dynamoClient = mockDynamo
+	mockDynamo.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil).Once()
+
+	require.NoError(t, exceptionPut(testException("AWS.S3", "1", time.Hour)))
+	mockDynamo.AssertExpectations(t)
+
+	input := mockDynamo.Calls[0].Arguments.Get(0).(*dynamodb.PutItemInput)
+	assert.Equal(t, "test-exceptions", *input.TableName)
+	assert.Equal(t, "AWS.S3", *input.Item["policyId"].S)
+	assert.Equal(t, "1", *input.Item["exceptionId"].S)
+	assert.Equal(t, "2020-06-01T13:00:00Z", *input.Item["expiresAt"].S)
+}
+
+func TestCreateException(t *testing.T) {
+	mockDynamo, mockCompliance := setupExceptionMocks(t)
+	input := testCreateException()
+
+	mockDynamo.On("GetItem", mock.Anything).Return(
+		&dynamodb.GetItemOutput{Item: testPolicyItem(t, input.PolicyID)}, nil).Once()
+	mockDynamo.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil).Once()
+	mockDynamo.On("QueryPages", mock.Anything, mock.Anything).Return(testExceptionItems(t, &exceptionItem{
+		ExceptionID:     "1",
+		ExpiresAt:       input.ExpiresAt,
+		PolicyID:        input.PolicyID,
+		ResourcePattern: input.ResourcePattern,
+	}), nil).Once()
+	mockCompliance.On("UpdateMetadata", mock.Anything).Return(&complianceops.UpdateMetadataOK{}, nil).Once()
+
+	result := CreateException(createExceptionRequest(t, input))
+
+	require.Equal(t, http.StatusCreated, result.StatusCode)
+	mockDynamo.AssertExpectations(t)
+	mockCompliance.AssertExpectations(t)
+
+	var exception models.PolicyException
+	require.NoError(t, jsoniter.UnmarshalFromString(result.Body, &exception))
+	assert.Equal(t, input.Approver, exception.Approver)
+	assert.Equal(t, input.UserID, exception.CreatedBy)
+	assert.False(t, exception.Expired)
+	assert.False(t, exception.Revoked)
+
+	// The new exception suppresses its resources along with the policy suppressions
+	params := mockCompliance.Calls[0].Arguments.Get(0).(*complianceops.UpdateMetadataParams)
+	assert.Equal(t, []string{"test.*", "arn:aws:s3:::example-website*"}, []string(params.Body.Suppressions))
+}
+
+func TestCreateExceptionInvalid(t *testing.T) {
+	selfApproved := testCreateException()
+	selfApproved.Approver = selfApproved.UserID
+
+	pastExpiry := testCreateException()
+	pastExpiry.ExpiresAt = models.ExceptionExpiration(strfmt.DateTime(time.Now().Add(-time.Hour)))
+
+	for name, input := range map[string]*models.CreateException{
+		"self-approved": selfApproved,
+		"past expiry":   pastExpiry,
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockDynamo, _ := setupExceptionMocks(t)
+			result := CreateException(createExceptionRequest(t, input))
+			assert.Equal(t, http.StatusBadRequest, result.StatusCode)
+			mockDynamo.AssertNotCalled(t, "PutItem", mock.Anything)
+		})
+	}
+}
+
+func TestCreateExceptionUnknownPolicy(t *testing.T) {
+	mockDynamo, mockCompliance := setupExceptionMocks(t)
+	mockDynamo.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{}, nil).Once()
+
+	result := CreateException(createExceptionRequest(t, testCreateException()))
+
+	assert.Equal(t, http.StatusNotFound, result.StatusCode)
+	mockDynamo.AssertExpectations(t)
+	mockDynamo.AssertNotCalled(t, "PutItem", mock.Anything)
+	mockCompliance.AssertNotCalled(t, "UpdateMetadata", mock.Anything)
+}
+
+func TestDeleteExceptions(t *testing.T) {
+	mockDynamo, mockCompliance := setupExceptionMocks(t)
+	userID := models.UserID("5f54cf4a-ec56-44c2-83bc-8b742600f307")
+
+	mockDynamo.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+	// The second exception does not exist or was already revoked
+	mockDynamo.On("UpdateItem", mock.Anything).Return((*dynamodb.UpdateItemOutput)(nil),
+		awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "condition failed", nil)).Once()
+	mockDynamo.On("BatchGetItemPages", mock.Anything, mock.Anything).Return(&dynamodb.BatchGetItemOutput{
+		Responses: map[string][]map[string]*dynamodb.AttributeValue{
+			env.Table: {testPolicyItem(t, "AWS.S3")},
+		},
+	}, nil).Once()
+	revokedAt := models.ModifyTime(time.Now())
+	revoked := testException("AWS.S3", "2f7b1c1e-5b5c-4c3a-9a27-3f4c2b0a6d91", time.Hour)
+	revoked.RevokedAt, revoked.RevokedBy = &revokedAt, userID
+	mockDynamo.On("QueryPages", mock.Anything, mock.Anything).Return(testExceptionItems(t, revoked), nil).Once()
+	mockCompliance.On("UpdateMetadata", mock.Anything).Return(&complianceops.UpdateMetadataOK{}, nil).Once()
+
+	body, err := jsoniter.MarshalToString(&models.DeleteExceptions{
+		Exceptions: []*models.ExceptionKey{
+			{ExceptionID: "2f7b1c1e-5b5c-4c3a-9a27-3f4c2b0a6d91", PolicyID: "AWS.S3"},
+			{ExceptionID: "8c3e6a2f-1d4b-4f7a-9e5c-0b2d7f6a1c38", PolicyID: "AWS.S3"},
+		},
+		UserID: userID,
+	})
+	require.NoError(t, err)
+	result := DeleteExceptions(&events.APIGatewayProxyRequest{Body: body})
+
+	require.Equal(t, http.StatusOK, result.StatusCode)
+	mockDynamo.AssertExpectations(t)
+	mockCompliance.AssertExpectations(t)
+
+	// Exceptions are marked as revoked rather than deleted
+	mockDynamo.AssertNotCalled(t, "BatchWriteItem", mock.Anything)
+	update := mockDynamo.Calls[0].Arguments.Get(0).(*dynamodb.UpdateItemInput)
+	assert.Equal(t, "test-exceptions", *update.TableName)
+	assert.Equal(t, "2f7b1c1e-5b5c-4c3a-9a27-3f4c2b0a6d91", *update.Key["exceptionId"].S)
+	var values []string
+	for _, value := range update.ExpressionAttributeValues {
+		values = append(values, aws.StringValue(value.S))
+	}
+	assert.Contains(t, values, string(userID))
+	assert.NotContains(t, values, "")
+
+	// The revoked exception no longer suppresses any resources
+	params := mockCompliance.Calls[0].Arguments.Get(0).(*complianceops.UpdateMetadataParams)
+	assert.Equal(t, []string{"test.*"}, []string(params.Body.Suppressions))
+}
+
+func TestDeleteExceptionsMissingUser(t *testing.T) {
+	mockDynamo, _ := setupExceptionMocks(t)
+
+	result := DeleteExceptions(&events.APIGatewayProxyRequest{
+		Body: `{"exceptions": [{"exceptionId": "2f7b1c1e-5b5c-4c3a-9a27-3f4c2b0a6d91", "policyId": "AWS.S3"}]}`,
+	})
+
+	assert.Equal(t, http.StatusBadRequest, result.StatusCode)
+	mockDynamo.AssertNotCalled(t, "UpdateItem", mock.Anything)
+}
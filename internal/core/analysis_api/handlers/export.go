@@ -0,0 +1,346 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+// Directory in the exported zipfile for each type of analysis
+var exportDirs = map[string]string{
+	typeGlobal: "globals",
+	typePolicy: "policies",
+	typeRule:   "rules",
+}
+
+// Bulk upload can only read these types back, so nothing else is exported
+var exportTypes = []string{typeGlobal, typePolicy, typeRule}
+
+// Test resources are decoded with UseNumber so large integers survive the trip through YAML
+var exportJSON = jsoniter.Config{UseNumber: true}.Froze()
+
+type exportParams struct {
+	types         []string
+	enabled       *bool
+	tags          []string
+	resourceTypes []string
+}
+
+// The YAML spec written for each exported item.
+//
+// The keys mirror analysis.Config, but empty values are omitted and each unit test only
+// includes the field (Log or Resource) which applies to its analysis type.
+type exportSpec struct {
+	AnalysisType              string              `yaml:"AnalysisType"`
+	Filename                  string              `yaml:"Filename"`
+	GlobalID                  string              `yaml:"GlobalID,omitempty"`
+	PolicyID                  string              `yaml:"PolicyID,omitempty"`
+	RuleID                    string              `yaml:"RuleID,omitempty"`
+	DisplayName               string              `yaml:"DisplayName,omitempty"`
+	Description               string              `yaml:"Description,omitempty"`
+	Enabled                   bool                `yaml:"Enabled"`
+	Severity                  string              `yaml:"Severity,omitempty"`
+	DedupPeriodMinutes        int                 `yaml:"DedupPeriodMinutes,omitempty"`
+	Threshold                 int                 `yaml:"Threshold,omitempty"`
+	LogTypes                  []string            `yaml:"LogTypes,omitempty"`
+	ResourceTypes             []string            `yaml:"ResourceTypes,omitempty"`
+	Tags                      []string            `yaml:"Tags,omitempty"`
+	Reports                   map[string][]string `yaml:"Reports,omitempty"`
+	Reference                 string              `yaml:"Reference,omitempty"`
+	Runbook                   string              `yaml:"Runbook,omitempty"`
+	OutputIds                 []string            `yaml:"OutputIds,omitempty"`
+	Suppressions              []string            `yaml:"Suppressions,omitempty"`
+	AutoRemediationID         string              `yaml:"AutoRemediationID,omitempty"`
+	AutoRemediationParameters map[string]string   `yaml:"AutoRemediationParameters,omitempty"`
+	Tests                     []interface{}       `yaml:"Tests,omitempty"`
+}
+
+type exportRuleTest struct {
+	Name           string      `yaml:"Name"`
+	ExpectedResult bool        `yaml:"ExpectedResult"`
+	Log            interface{} `yaml:"Log"`
+}
+
+type exportPolicyTest struct {
+	Name           string      `yaml:"Name"`
+	ExpectedResult bool        `yaml:"ExpectedResult"`
+	Resource       interface{} `yaml:"Resource"`
+}
+
+// ExportAnalysis builds a zipfile of analysis items which can be re-imported with BulkUpload.
+func ExportAnalysis(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	params, err := parseExport(request)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	scanInput, err := buildExportScan(params)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	var items []*tableItem
+	err = scanPages(scanInput, func(item *tableItem) error {
+		items = append(items, item)
+		return nil
+	})
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	data, err := exportZip(items)
+	if err != nil {
+		zap.L().Error("failed to build export zipfile", zap.Error(err))
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	return gatewayapi.MarshalResponse(&models.ExportResult{
+		Data: models.Base64zipfile(base64.StdEncoding.EncodeToString(data)),
+	}, http.StatusOK)
+}
+
+func parseExport(request *events.APIGatewayProxyRequest) (*exportParams, error) {
+	var result exportParams
+
+	for _, rawType := range strings.Split(request.QueryStringParameters["types"], ",") {
+		if rawType == "" {
+			continue
+		}
+		analysisType := models.AnalysisType(strings.ToUpper(rawType))
+		if err := analysisType.Validate(nil); err != nil {
+			return nil, errors.New("invalid types: " + err.Error())
+		}
+		if _, ok := exportDirs[string(analysisType)]; !ok {
+			return nil, errors.New("invalid types: export does not support " + string(analysisType))
+		}
+		result.types = append(result.types, string(analysisType))
+	}
+
+	if raw := request.QueryStringParameters["enabled"]; raw != "" {
+		enabled, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, errors.New("invalid enabled: " + err.Error())
+		}
+		result.enabled = aws.Bool(enabled)
+	}
+
+	for _, rawTag := range strings.Split(request.QueryStringParameters["tags"], ",") {
+		if rawTag == "" {
+			continue
+		}
+		tag, err := url.QueryUnescape(rawTag)
+		if err != nil {
+			return nil, errors.New("invalid tag: " + err.Error())
+		}
+		result.tags = append(result.tags, strings.ToLower(tag))
+	}
+
+	for i, rawType := range strings.Split(request.QueryStringParameters["resourceTypes"], ",") {
+		if rawType == "" {
+			continue
+		}
+		resourceType, err := url.QueryUnescape(rawType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid resourceTypes[%d]: %s", i, err)
+		}
+		result.resourceTypes = append(result.resourceTypes, resourceType)
+	}
+
+	return &result, nil
+}
+
+func buildExportScan(params *exportParams) (*dynamodb.ScanInput, error) {
+	// Without any type filter, every type bulk upload understands is exported
+	types := params.types
+	if len(types) == 0 {
+		types = exportTypes
+	}
+	values := make([]expression.OperandBuilder, len(types))
+	for i, analysisType := range types {
+		values[i] = expression.Value(analysisType)
+	}
+	filter := expression.Name("type").In(values[0], values[1:]...)
+
+	// Globals are never enabled/disabled and don't apply to any resource types
+	if params.enabled != nil || len(params.resourceTypes) > 0 {
+		detectionFilter := expression.AttributeExists(expression.Name("type"))
+
+		if params.enabled != nil {
+			detectionFilter = detectionFilter.And(
+				expression.Equal(expression.Name("enabled"), expression.Value(*params.enabled)))
+		}
+
+		if len(params.resourceTypes) > 0 {
+			// a policy/rule with no resource types applies to all of them
+			typeFilter := expression.AttributeNotExists(expression.Name("resourceTypes"))
+			for _, typeName := range params.resourceTypes {
+				typeFilter = typeFilter.Or(expression.Contains(expression.Name("resourceTypes"), typeName))
+			}
+			detectionFilter = detectionFilter.And(typeFilter)
+		}
+
+		filter = filter.And(
+			expression.Equal(expression.Name("type"), expression.Value(typeGlobal)).Or(detectionFilter))
+	}
+
+	if len(params.tags) > 0 {
+		tagFilter := expression.AttributeExists(expression.Name("lowerTags"))
+		for _, tag := range params.tags {
+			tagFilter = tagFilter.And(expression.Contains(expression.Name("lowerTags"), tag))
+		}
+		filter = filter.And(tagFilter)
+	}
+
+	expr, err := expression.NewBuilder().WithFilter(filter).Build()
+	if err != nil {
+		zap.L().Error("failed to build export scan", zap.Error(err))
+		return nil, err
+	}
+
+	return &dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		TableName:                 &env.Table,
+	}, nil
+}
+
+// exportZip writes a Python file and a YAML spec for each item, in the layout read by extractZipFile.
+func exportZip(items []*tableItem) ([]byte, error) {
+	// Sort by ID so the same items always produce the same archive
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+
+	for _, item := range items {
+		dir, ok := exportDirs[item.Type]
+		if !ok {
+			return nil, fmt.Errorf("%s has unknown analysis type %s", item.ID, item.Type)
+		}
+
+		// extractZipFile matches specs to bodies by base file name, which is unique because IDs are
+		spec, err := buildExportSpec(item, string(item.ID)+".py")
+		if err != nil {
+			return nil, err
+		}
+		specBody, err := yaml.Marshal(spec)
+		if err != nil {
+			return nil, fmt.Errorf("%s spec marshal failed: %s", item.ID, err)
+		}
+
+		if err := writeZipFile(writer, path.Join(dir, spec.Filename), []byte(item.Body)); err != nil {
+			return nil, err
+		}
+		if err := writeZipFile(writer, path.Join(dir, string(item.ID)+".yml"), specBody); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeZipFile(writer *zip.Writer, name string, content []byte) error {
+	w, err := writer.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to zipfile: %s", name, err)
+	}
+	if _, err := w.Write(content); err != nil {
+		return fmt.Errorf("failed to add %s to zipfile: %s", name, err)
+	}
+	return nil
+}
+
+// Convert a table item into its YAML spec (the reverse of extractZipFile)
+func buildExportSpec(item *tableItem, filename string) (*exportSpec, error) {
+	item.normalize()
+	spec := &exportSpec{
+		AnalysisType:              strings.ToLower(item.Type),
+		Filename:                  filename,
+		DisplayName:               string(item.DisplayName),
+		Description:               string(item.Description),
+		Enabled:                   bool(item.Enabled),
+		Severity:                  string(item.Severity),
+		Tags:                      item.Tags,
+		Reports:                   item.Reports,
+		Reference:                 string(item.Reference),
+		Runbook:                   string(item.Runbook),
+		OutputIds:                 item.OutputIds,
+		Suppressions:              item.Suppressions,
+		AutoRemediationID:         string(item.AutoRemediationID),
+		AutoRemediationParameters: item.AutoRemediationParameters,
+	}
+
+	switch item.Type {
+	case typeGlobal:
+		spec.GlobalID = string(item.ID)
+		// Globals don't have a severity - bulk upload assigns INFO
+		spec.Severity = ""
+	case typePolicy:
+		spec.PolicyID = string(item.ID)
+		spec.ResourceTypes = item.ResourceTypes
+	case typeRule:
+		spec.RuleID = string(item.ID)
+		spec.LogTypes = item.ResourceTypes
+		spec.DedupPeriodMinutes = int(item.DedupPeriodMinutes)
+		spec.Threshold = int(item.Threshold)
+	}
+
+	for _, test := range item.Tests {
+		// Tests are stored as JSON strings, but they are written as YAML objects so they are easy to read
+		var resource interface{}
+		if err := exportJSON.UnmarshalFromString(string(test.Resource), &resource); err != nil {
+			return nil, fmt.Errorf("%s test %s has invalid JSON: %s", item.ID, test.Name, err)
+		}
+
+		if item.Type == typeRule {
+			spec.Tests = append(spec.Tests, &exportRuleTest{
+				Name: string(test.Name), ExpectedResult: bool(test.ExpectedResult), Log: resource})
+		} else {
+			spec.Tests = append(spec.Tests, &exportPolicyTest{
+				Name: string(test.Name), ExpectedResult: bool(test.ExpectedResult), Resource: resource})
+		}
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,202 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+)
+
+const exportUserID = "5f54cf4a-ec56-44c2-83bc-8b742600f307"
+
+// Items as they would be stored after a bulk upload
+func exportTestItems() []*tableItem {
+	return []*tableItem{
+		{
+			AutoRemediationID:         "fix-bucket",
+			AutoRemediationParameters: map[string]string{"KMSMasterKeyID": "", "SSEAlgorithm": "AES256"},
+			Body:                      "def policy(resource):\n    return resource['Encrypted']\n",
+			Description:               "S3 buckets must be encrypted",
+			DisplayName:               "Bucket Encryption",
+			Enabled:                   true,
+			ID:                        "AWS.S3.BucketEncryption",
+			OutputIds:                 []string{"slack"},
+			Reference:                 "https://docs.aws.amazon.com",
+			Reports:                   map[string][]string{"CIS": {"2.1", "2.2"}},
+			ResourceTypes:             []string{"AWS.S3.Bucket"},
+			Runbook:                   "Enable encryption",
+			Severity:                  "HIGH",
+			Suppressions:              []string{"arn:aws:s3:::logs-*"},
+			Tags:                      []string{"AWS", "S3"},
+			Tests: []*models.UnitTest{
+				{
+					ExpectedResult: true,
+					Name:           "Encrypted",
+					Resource:       `{"Encrypted":true,"Size":12345678901234,"Tags":{"yes":"no"}}`,
+				},
+				{
+					ExpectedResult: false,
+					Name:           "Empty",
+					Resource:       `{}`,
+				},
+			},
+			Type: typePolicy,
+		},
+		{
+			Body:               "def rule(event):\n    return event.get('errorCode') == 'AccessDenied'\n",
+			DedupPeriodMinutes: 120,
+			Enabled:            false,
+			ID:                 "AWS.CloudTrail.AccessDenied",
+			ResourceTypes:      []string{"AWS.CloudTrail"},
+			Severity:           "LOW",
+			Tests: []*models.UnitTest{
+				{
+					ExpectedResult: true,
+					Name:           "Denied",
+					Resource:       `{"errorCode":"AccessDenied","requestParameters":null}`,
+				},
+			},
+			Threshold: 5,
+			Type:      typeRule,
+		},
+		{
+			Body:        "def helper():\n    return True\n",
+			Description: "Shared helpers",
+			ID:          "panther",
+			Severity:    "INFO",
+			Tests:       []*models.UnitTest{},
+			Type:        typeGlobal,
+		},
+	}
+}
+
+func TestExportRoundTrip(t *testing.T) {
+	data, err := exportZip(exportTestItems())
+	require.NoError(t, err)
+
+	result, err := extractZipFile(&models.BulkUpload{
+		Data:   models.Base64zipfile(base64.StdEncoding.EncodeToString(data)),
+		UserID: exportUserID,
+	})
+	require.NoError(t, err)
+
+	expected := exportTestItems()
+	require.Len(t, result, len(expected))
+	for _, item := range expected {
+		actual := result[item.ID]
+		require.NotNil(t, actual, item.ID)
+
+		// Test resources are re-encoded, so only their JSON content has to match
+		require.Len(t, actual.Tests, len(item.Tests))
+		for i, test := range item.Tests {
+			assert.Equal(t, test.Name, actual.Tests[i].Name)
+			assert.Equal(t, test.ExpectedResult, actual.Tests[i].ExpectedResult)
+			assert.JSONEq(t, string(test.Resource), string(actual.Tests[i].Resource))
+		}
+		item.Tests, actual.Tests = nil, nil
+
+		item.normalize()
+		actual.normalize()
+		assert.Equal(t, item, actual)
+	}
+}
+
+func TestExportZipLayout(t *testing.T) {
+	data, err := exportZip(exportTestItems())
+	require.NoError(t, err)
+
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	var names []string
+	for _, file := range reader.File {
+		names = append(names, file.Name)
+	}
+	expected := []string{
+		"rules/AWS.CloudTrail.AccessDenied.py",
+		"rules/AWS.CloudTrail.AccessDenied.yml",
+		"policies/AWS.S3.BucketEncryption.py",
+		"policies/AWS.S3.BucketEncryption.yml",
+		"globals/panther.py",
+		"globals/panther.yml",
+	}
+	assert.Equal(t, expected, names)
+}
+
+func TestExportZipEmpty(t *testing.T) {
+	data, err := exportZip(nil)
+	require.NoError(t, err)
+
+	result, err := extractZipFile(&models.BulkUpload{
+		Data:   models.Base64zipfile(base64.StdEncoding.EncodeToString(data)),
+		UserID: exportUserID,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+func TestParseExport(t *testing.T) {
+	result, err := parseExport(&events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{
+			"types":         "rule,GLOBAL",
+			"enabled":       "true",
+			"tags":          "AWS,PCI%20DSS",
+			"resourceTypes": "AWS.CloudTrail",
+		},
+	})
+	require.NoError(t, err)
+	expected := &exportParams{
+		types:         []string{typeRule, typeGlobal},
+		enabled:       aws.Bool(true),
+		tags:          []string{"aws", "pci dss"},
+		resourceTypes: []string{"AWS.CloudTrail"},
+	}
+	assert.Equal(t, expected, result)
+
+	_, err = parseExport(&events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{"types": "DASHBOARD"},
+	})
+	assert.Error(t, err)
+
+	// Bulk upload can't read scheduled queries back, so they can't be exported
+	_, err = parseExport(&events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{"types": "scheduled_query"},
+	})
+	assert.Error(t, err)
+}
+
+func TestBuildExportScanDefaultTypes(t *testing.T) {
+	scan, err := buildExportScan(&exportParams{})
+	require.NoError(t, err)
+
+	var values []string
+	for _, value := range scan.ExpressionAttributeValues {
+		values = append(values, aws.StringValue(value.S))
+	}
+	assert.ElementsMatch(t, []string{typeGlobal, typePolicy, typeRule}, values)
+}
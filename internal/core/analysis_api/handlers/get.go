@@ -45,12 +45,22 @@ func GetRule(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResp
 	return handleGet(request, typeRule)
 }
 
-// GetRule retrieves a rule from Dynamo or S3.
+// GetScheduledQuery retrieves a scheduled query from Dynamo or S3.
+func GetScheduledQuery(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	return handleGet(request, typeScheduledQuery)
+}
+
+// GetCorrelationRule retrieves a correlation rule from Dynamo or S3.
+func GetCorrelationRule(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	return handleGet(request, typeCorrelationRule)
+}
+
+// GetGlobal retrieves a global from Dynamo or S3.
 func GetGlobal(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
 	return handleGet(request, typeGlobal)
 }
 
-// Handle GET request for GetPolicy, GetRule, and GetGlobal
+// Handle GET request for GetPolicy, GetRule, GetGlobal, GetScheduledQuery and GetCorrelationRule
 func handleGet(request *events.APIGatewayProxyRequest, codeType string) *events.APIGatewayProxyResponse {
 	input, err := parseGet(request, codeType)
 	if err != nil {
@@ -84,9 +94,13 @@ func handleGet(request *events.APIGatewayProxyRequest, codeType string) *events.
 		if err != nil {
 			return failedRequest(fmt.Sprintf("Internal error finding %s (%s)", input.ID, codeType), http.StatusInternalServerError)
 		}
-		return gatewayapi.MarshalResponse(item.Policy(status.Status), http.StatusOK)
+		policy := item.Policy(status.Status)
+		if policy.Exceptions, err = activeExceptions(input.ID); err != nil {
+			return failedRequest(fmt.Sprintf("Internal error finding %s (%s)", input.ID, codeType), http.StatusInternalServerError)
+		}
+		return gatewayapi.MarshalResponse(policy, http.StatusOK)
 	}
-	if codeType == typeRule {
+	if codeType == typeRule || codeType == typeScheduledQuery || codeType == typeCorrelationRule {
 		// Backwards compatibility fix
 		// Rules that were created before the introduction of Rule Threshold
 		// will have a default threshold of '0'. However, the minimum threshold we allow is '1'.
@@ -110,6 +124,10 @@ func parseGet(request *events.APIGatewayProxyRequest, codeType string) (*getPara
 		idKey = "ruleId"
 	} else if codeType == typeGlobal {
 		idKey = "globalId"
+	} else if codeType == typeScheduledQuery {
+		idKey = "queryId"
+	} else if codeType == typeCorrelationRule {
+		idKey = "correlationId"
 	}
 	id, err := url.QueryUnescape(request.QueryStringParameters[idKey])
 	if err != nil {
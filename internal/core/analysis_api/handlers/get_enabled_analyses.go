@@ -44,15 +44,27 @@ func GetEnabledAnalyses(request *events.APIGatewayProxyRequest) *events.APIGatew
 		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
 	}
 
+	// Exceptions only apply to policies
+	var exceptions map[models.ID]models.Exceptions
+	if analysisType == typePolicy {
+		if exceptions, err = activeExceptionsByPolicy(); err != nil {
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		}
+	}
+
 	policies := make([]*models.EnabledPolicy, 0, 100)
 	err = scanPages(scanInput, func(policy *tableItem) error {
 		policies = append(policies, &models.EnabledPolicy{
 			Body:               policy.Body,
+			Correlation:        policy.Correlation,
+			DedupColumn:        policy.DedupColumn,
 			DedupPeriodMinutes: policy.DedupPeriodMinutes,
+			Exceptions:         exceptions[policy.ID],
 			ID:                 policy.ID,
 			OutputIds:          policy.OutputIds,
 			Reports:            policy.Reports,
 			ResourceTypes:      policy.ResourceTypes,
+			Schedule:           policy.Schedule,
 			Severity:           policy.Severity,
 			Suppressions:       policy.Suppressions,
 			Tags:               policy.Tags,
@@ -82,11 +94,14 @@ func buildEnabledScan(ruleType string) (*dynamodb.ScanInput, error) {
 	projection := expression.NamesList(
 		// does not include unit tests, last modified, reference, etc
 		expression.Name("body"),
+		expression.Name("correlation"),
+		expression.Name("dedupColumn"),
 		expression.Name("dedupPeriodMinutes"),
 		expression.Name("id"),
 		expression.Name("outputIds"),
 		expression.Name("reports"),
 		expression.Name("resourceTypes"),
+		expression.Name("schedule"),
 		expression.Name("severity"),
 		expression.Name("suppressions"),
 		expression.Name("tags"),
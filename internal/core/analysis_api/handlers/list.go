@@ -89,6 +89,16 @@ func ListRules(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyRe
 	return handleList(request, typeRule)
 }
 
+// ListScheduledQueries pages through scheduled queries from a single organization.
+func ListScheduledQueries(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	return handleList(request, typeScheduledQuery)
+}
+
+// ListCorrelationRules pages through correlation rules from a single organization.
+func ListCorrelationRules(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	return handleList(request, typeCorrelationRule)
+}
+
 func handleList(request *events.APIGatewayProxyRequest, codeType string) *events.APIGatewayProxyResponse {
 	params, err := parseList(request, codeType)
 	if err != nil {
@@ -187,7 +197,7 @@ func parseList(request *events.APIGatewayProxyRequest, codeType string) (*listPa
 	}
 
 	typeKey := "resourceTypes"
-	if codeType == typeRule {
+	if codeType != typePolicy {
 		typeKey = "logTypes"
 	}
 	rawTypes := strings.Split(request.QueryStringParameters[typeKey], ",")
@@ -349,8 +359,8 @@ func buildListScan(params *listParams, codeType string) (*dynamodb.ScanInput, er
 func listFiltered(scanInput *dynamodb.ScanInput, params *listParams) ([]*models.PolicySummary, error) {
 	var result []*models.PolicySummary
 	err := scanPages(scanInput, func(item *tableItem) error {
-		if item.Type == typeRule {
-			// Log analysis rules do not have a compliance status
+		if item.Type != typePolicy {
+			// Log analysis rules, scheduled queries and correlation rules do not have a compliance status
 			result = append(result, item.PolicySummary(""))
 			return nil
 		}
@@ -0,0 +1,498 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+// An analysis pack as stored in the pack table
+type packItem struct {
+	CreatedAt      models.ModifyTime  `json:"createdAt"`
+	CreatedBy      models.UserID      `json:"createdBy"`
+	Description    models.Description `json:"description,omitempty"`
+	Enabled        models.Enabled     `json:"enabled"`
+	ID             models.ID          `json:"id"`
+	Items          []*packEntry       `json:"items"`
+	LastModified   models.ModifyTime  `json:"lastModified"`
+	LastModifiedBy models.UserID      `json:"lastModifiedBy"`
+	Version        models.PackVersion `json:"version"`
+}
+
+// A single policy, rule or global owned by a pack
+type packEntry struct {
+	// The enabled setting the item was installed with, restored when the pack is enabled
+	Enabled models.Enabled `json:"enabled"`
+	ID      models.ID      `json:"id"`
+	Type    string         `json:"type"`
+
+	// The version last written by the pack - any other version means the item has drifted
+	VersionID models.VersionID `json:"versionId"`
+}
+
+// An item has drifted if it was edited or deleted after the pack wrote it.
+func (e *packEntry) drifted(packID models.ID, item *tableItem) bool {
+	return item == nil || item.PackID != packID || item.VersionID != e.VersionID
+}
+
+func (p *packItem) ids() []models.ID {
+	result := make([]models.ID, len(p.Items))
+	for i, entry := range p.Items {
+		result[i] = entry.ID
+	}
+	return result
+}
+
+func (p *packItem) entry(id models.ID) *packEntry {
+	for _, entry := range p.Items {
+		if entry.ID == id {
+			return entry
+		}
+	}
+	return nil
+}
+
+// Pack converts a Dynamo row into a Pack external model, given the current version of each item.
+func (p *packItem) Pack(current map[models.ID]*tableItem) *models.Pack {
+	result := &models.Pack{
+		CreatedAt:      p.CreatedAt,
+		CreatedBy:      p.CreatedBy,
+		Description:    p.Description,
+		Enabled:        p.Enabled,
+		ID:             p.ID,
+		Items:          make([]*models.PackItem, len(p.Items)),
+		LastModified:   p.LastModified,
+		LastModifiedBy: p.LastModifiedBy,
+		Version:        p.Version,
+	}
+	for i, entry := range p.Items {
+		result.Items[i] = &models.PackItem{
+			AnalysisType: models.AnalysisType(entry.Type),
+			Drifted:      aws.Bool(entry.drifted(p.ID, current[entry.ID])),
+			ID:           entry.ID,
+			VersionID:    entry.VersionID,
+		}
+	}
+	return result
+}
+
+// GetPack retrieves an analysis pack and the drift status of its items.
+func GetPack(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	packID, err := url.QueryUnescape(request.QueryStringParameters["packId"])
+	if err != nil {
+		return badRequest(fmt.Errorf("invalid packId: %s", err))
+	}
+	if err := models.ID(packID).Validate(nil); err != nil {
+		return badRequest(fmt.Errorf("invalid packId: %s", err))
+	}
+
+	pack, err := packGet(models.ID(packID))
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	if pack == nil {
+		return failedRequest(fmt.Sprintf("Cannot find pack %s", packID), http.StatusNotFound)
+	}
+
+	result, err := packWithItems(pack)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	return gatewayapi.MarshalResponse(result, http.StatusOK)
+}
+
+// ListPacks lists every installed analysis pack.
+func ListPacks(_ *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	packs, err := packScan()
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	result := &models.PackList{Packs: make([]*models.Pack, 0, len(packs))}
+	for _, pack := range packs {
+		item, err := packWithItems(pack)
+		if err != nil {
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		}
+		result.Packs = append(result.Packs, item)
+	}
+	sort.Slice(result.Packs, func(i, j int) bool { return result.Packs[i].ID < result.Packs[j].ID })
+	return gatewayapi.MarshalResponse(result, http.StatusOK)
+}
+
+// InstallPack installs a new analysis pack or upgrades an existing one.
+//
+// Items are written atomically and marked as owned by the pack. Items which were part of the
+// previous version of the pack, but not the new one, are deleted.
+func InstallPack(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	var input models.InstallPack
+	if err := jsoniter.UnmarshalFromString(request.Body, &input); err != nil {
+		return badRequest(err)
+	}
+	if err := input.Validate(nil); err != nil {
+		return badRequest(err)
+	}
+
+	policies, err := extractZipFile(&models.BulkUpload{Data: input.Data, UserID: input.UserID})
+	if err != nil {
+		return badRequest(err)
+	}
+	if len(policies) == 0 {
+		return badRequest(errors.New("pack does not contain any policies, rules or globals"))
+	}
+
+	oldPack, err := packGet(input.ID)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	ids := make([]models.ID, 0, len(policies))
+	for id := range policies {
+		ids = append(ids, id)
+	}
+	if oldPack != nil {
+		ids = append(ids, oldPack.ids()...)
+	}
+	current, err := dynamoBatchGet(ids, true)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	// A disabled pack stays disabled when it is upgraded
+	enabled := oldPack == nil || bool(oldPack.Enabled)
+	entries := make([]*packEntry, 0, len(policies))
+	for id, item := range policies {
+		if old := current[id]; old != nil && old.PackID != "" && old.PackID != input.ID {
+			msg := fmt.Sprintf("ID %s belongs to pack %s", id, old.PackID)
+			return gatewayapi.MarshalResponse(&models.Error{Message: &msg}, http.StatusConflict)
+		}
+		entries = append(entries, &packEntry{Enabled: item.Enabled, ID: id, Type: item.Type})
+		item.PackID = input.ID
+		if !enabled {
+			item.Enabled = false
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+	removed := removedPackItems(oldPack, policies, current)
+
+	if input.DryRun {
+		return planInstallPack(oldPack, policies, removed, current)
+	}
+
+	counts, errResponse := atomicWriteItems(policies, current, input.UserID)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	if err := deletePackItems(removed); err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	now := models.ModifyTime(time.Now())
+	pack := &packItem{
+		CreatedAt:      now,
+		CreatedBy:      input.UserID,
+		Description:    input.Description,
+		Enabled:        models.Enabled(enabled),
+		ID:             input.ID,
+		Items:          entries,
+		LastModified:   now,
+		LastModifiedBy: input.UserID,
+		Version:        input.Version,
+	}
+	if oldPack != nil {
+		pack.CreatedAt, pack.CreatedBy = oldPack.CreatedAt, oldPack.CreatedBy
+	}
+	for _, entry := range pack.Items {
+		entry.VersionID = policies[entry.ID].VersionID
+		if entry.VersionID == "" {
+			// Identical items are not written again
+			entry.VersionID = current[entry.ID].VersionID
+		}
+	}
+	if err := packPut(pack); err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	if aws.Int64Value(counts.TotalGlobals) > 0 || containsGlobal(removed) {
+		if err := updateLayer(); err != nil {
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		}
+	}
+
+	result, err := packWithItems(pack)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	return gatewayapi.MarshalResponse(&models.InstallPackResult{Pack: result, Result: counts}, http.StatusOK)
+}
+
+// ModifyPack enables or disables every item owned by a pack.
+func ModifyPack(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	var input models.UpdatePack
+	if err := jsoniter.UnmarshalFromString(request.Body, &input); err != nil {
+		return badRequest(err)
+	}
+	if err := input.Validate(nil); err != nil {
+		return badRequest(err)
+	}
+
+	pack, err := packGet(input.ID)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	if pack == nil {
+		return failedRequest(fmt.Sprintf("Cannot find pack %s", input.ID), http.StatusNotFound)
+	}
+
+	current, err := dynamoBatchGet(pack.ids(), true)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	updates := make(map[models.ID]*tableItem)
+	for _, entry := range pack.Items {
+		item := current[entry.ID]
+		if item == nil || item.PackID != pack.ID || item.Type == typeGlobal {
+			continue // deleted, no longer owned by the pack, or can't be disabled
+		}
+		enabled := input.Enabled && entry.Enabled
+		if item.Enabled == enabled {
+			continue
+		}
+		updated := *item
+		updated.Enabled = enabled
+		updates[entry.ID] = &updated
+	}
+
+	if _, errResponse := atomicWriteItems(updates, current, input.UserID); errResponse != nil {
+		return errResponse
+	}
+
+	// Items which had not drifted are still in sync with the pack
+	for _, entry := range pack.Items {
+		updated, ok := updates[entry.ID]
+		if ok && updated.VersionID != "" && !entry.drifted(pack.ID, current[entry.ID]) {
+			entry.VersionID = updated.VersionID
+		}
+	}
+	pack.Enabled = input.Enabled
+	pack.LastModified = models.ModifyTime(time.Now())
+	pack.LastModifiedBy = input.UserID
+	if err := packPut(pack); err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	result, err := packWithItems(pack)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+	return gatewayapi.MarshalResponse(result, http.StatusOK)
+}
+
+// planInstallPack previews a pack install or upgrade without writing anything.
+func planInstallPack(
+	oldPack *packItem, policies map[models.ID]*tableItem, removed []*tableItem,
+	current map[models.ID]*tableItem) *events.APIGatewayProxyResponse {
+
+	plans, err := planItems(policies)
+	if err != nil {
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	counts := &models.BulkUploadResult{
+		ModifiedPolicies: aws.Int64(0),
+		NewPolicies:      aws.Int64(0),
+		TotalPolicies:    aws.Int64(0),
+
+		ModifiedRules: aws.Int64(0),
+		NewRules:      aws.Int64(0),
+		TotalRules:    aws.Int64(0),
+
+		ModifiedGlobals: aws.Int64(0),
+		NewGlobals:      aws.Int64(0),
+		TotalGlobals:    aws.Int64(0),
+	}
+
+	for _, plan := range plans {
+		countPlanItem(counts, plan)
+		action := aws.StringValue(plan.Action)
+		if oldPack == nil || action == models.BulkUploadPlanItemActionUNCHANGED || action == models.BulkUploadPlanItemActionFAIL {
+			continue
+		}
+		// Flag items whose local edits (or deletion) would be overwritten by the upgrade
+		if entry := oldPack.entry(plan.ID); entry != nil && entry.drifted(oldPack.ID, current[plan.ID]) {
+			plan.Drifted = true
+		}
+	}
+
+	for _, item := range removed {
+		plans = append(plans, &models.BulkUploadPlanItem{
+			Action:       aws.String(models.BulkUploadPlanItemActionDELETE),
+			AnalysisType: models.AnalysisType(item.Type),
+			Changes:      []*models.FieldChange{},
+			Drifted:      oldPack.entry(item.ID).drifted(oldPack.ID, item),
+			ID:           item.ID,
+		})
+	}
+	sort.Slice(plans, func(i, j int) bool { return plans[i].ID < plans[j].ID })
+	counts.Plan = plans
+
+	return gatewayapi.MarshalResponse(&models.InstallPackResult{Result: counts}, http.StatusOK)
+}
+
+// Items in the previous version of a pack which are no longer part of it.
+//
+// Items which were deleted in the meantime are skipped.
+func removedPackItems(oldPack *packItem, policies, current map[models.ID]*tableItem) []*tableItem {
+	if oldPack == nil {
+		return nil
+	}
+
+	var result []*tableItem
+	for _, entry := range oldPack.Items {
+		if _, ok := policies[entry.ID]; ok {
+			continue
+		}
+		if item := current[entry.ID]; item != nil && item.PackID == oldPack.ID {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// Delete items (and their compliance status) which were removed from a pack.
+func deletePackItems(items []*tableItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	input := &models.DeletePolicies{Policies: make([]*models.DeleteEntry, len(items))}
+	for i, item := range items {
+		input.Policies[i] = &models.DeleteEntry{ID: item.ID}
+	}
+
+	zap.L().Info("deleting items removed from pack", zap.Int("itemCount", len(items)))
+	if err := dynamoBatchDelete(input); err != nil {
+		return err
+	}
+	if err := s3BatchDelete(input); err != nil {
+		return err
+	}
+	return complianceBatchDelete(input.Policies, []string{})
+}
+
+func containsGlobal(items []*tableItem) bool {
+	for _, item := range items {
+		if item.Type == typeGlobal {
+			return true
+		}
+	}
+	return false
+}
+
+// Convert a pack to the external model, reading the current version of each of its items.
+func packWithItems(pack *packItem) (*models.Pack, error) {
+	current, err := dynamoBatchGet(pack.ids(), true)
+	if err != nil {
+		return nil, err
+	}
+	return pack.Pack(current), nil
+}
+
+// Load a pack from the pack table.
+//
+// Returns (nil, nil) if the pack doesn't exist.
+func packGet(packID models.ID) (*packItem, error) {
+	response, err := dynamoClient.GetItem(&dynamodb.GetItemInput{
+		ConsistentRead: aws.Bool(true),
+		Key:            tableKey(packID),
+		TableName:      &env.PackTable,
+	})
+	if err != nil {
+		zap.L().Error("dynamoClient.GetItem failed", zap.Error(err))
+		return nil, err
+	}
+
+	if len(response.Item) == 0 {
+		return nil, nil
+	}
+
+	var pack packItem
+	if err = dynamodbattribute.UnmarshalMap(response.Item, &pack); err != nil {
+		zap.L().Error("dynamodbattribute.UnmarshalMap failed", zap.Error(err))
+		return nil, err
+	}
+	return &pack, nil
+}
+
+// Write a pack to the pack table.
+func packPut(pack *packItem) error {
+	body, err := dynamodbattribute.MarshalMap(pack)
+	if err != nil {
+		zap.L().Error("dynamodbattribute.MarshalMap failed", zap.Error(err))
+		return err
+	}
+
+	if _, err = dynamoClient.PutItem(&dynamodb.PutItemInput{Item: body, TableName: &env.PackTable}); err != nil {
+		zap.L().Error("dynamoClient.PutItem failed", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Load every pack from the pack table.
+func packScan() ([]*packItem, error) {
+	var result []*packItem
+	var unmarshalErr error
+	err := dynamoClient.ScanPages(&dynamodb.ScanInput{TableName: &env.PackTable},
+		func(page *dynamodb.ScanOutput, lastPage bool) bool {
+			var packs []*packItem
+			if unmarshalErr = dynamodbattribute.UnmarshalListOfMaps(page.Items, &packs); unmarshalErr != nil {
+				return false // stop paginating
+			}
+			result = append(result, packs...)
+			return true
+		})
+
+	if unmarshalErr != nil {
+		zap.L().Error("dynamodbattribute.UnmarshalListOfMaps failed", zap.Error(unmarshalErr))
+		return nil, unmarshalErr
+	}
+	if err != nil {
+		zap.L().Error("dynamoClient.ScanPages failed", zap.Error(err))
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,120 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+var testPack = &packItem{
+	Enabled: true,
+	ID:      "pack.aws",
+	Items: []*packEntry{
+		{Enabled: true, ID: "Rule.Synced", Type: typeRule, VersionID: "v1"},
+		{Enabled: true, ID: "Rule.Edited", Type: typeRule, VersionID: "v1"},
+		{Enabled: false, ID: "Policy.Deleted", Type: typePolicy, VersionID: "v1"},
+		{Enabled: true, ID: "Global.Helpers", Type: typeGlobal, VersionID: "v1"},
+	},
+	Version: "1.0.0",
+}
+
+func testPackCurrent() map[models.ID]*tableItem {
+	return map[models.ID]*tableItem{
+		"Rule.Synced":    {ID: "Rule.Synced", PackID: "pack.aws", Type: typeRule, VersionID: "v1"},
+		"Rule.Edited":    {ID: "Rule.Edited", PackID: "pack.aws", Type: typeRule, VersionID: "v2"},
+		"Global.Helpers": {ID: "Global.Helpers", PackID: "pack.other", Type: typeGlobal, VersionID: "v1"},
+	}
+}
+
+func TestPackEntryDrifted(t *testing.T) {
+	entry := &packEntry{ID: "Rule.Synced", Type: typeRule, VersionID: "v1"}
+	assert.False(t, entry.drifted("pack.aws", &tableItem{PackID: "pack.aws", VersionID: "v1"}))
+	assert.True(t, entry.drifted("pack.aws", nil))
+	assert.True(t, entry.drifted("pack.aws", &tableItem{PackID: "pack.other", VersionID: "v1"}))
+	assert.True(t, entry.drifted("pack.aws", &tableItem{PackID: "pack.aws", VersionID: "v2"}))
+}
+
+func TestPackItemPack(t *testing.T) {
+	result := testPack.Pack(testPackCurrent())
+	assert.Equal(t, models.ID("pack.aws"), result.ID)
+	assert.Equal(t, models.PackVersion("1.0.0"), result.Version)
+	require.Len(t, result.Items, 4)
+
+	drifted := make(map[models.ID]bool, len(result.Items))
+	for _, item := range result.Items {
+		drifted[item.ID] = *item.Drifted
+	}
+	assert.Equal(t, map[models.ID]bool{
+		"Rule.Synced":    false,
+		"Rule.Edited":    true,
+		"Policy.Deleted": true,
+		"Global.Helpers": true,
+	}, drifted)
+	assert.Equal(t, models.AnalysisType(typePolicy), result.Items[2].AnalysisType)
+}
+
+func TestRemovedPackItems(t *testing.T) {
+	assert.Nil(t, removedPackItems(nil, nil, testPackCurrent()))
+
+	// Rule.Synced is still in the new version, Policy.Deleted no longer exists and
+	// Global.Helpers has been taken over by another pack
+	policies := map[models.ID]*tableItem{"Rule.Synced": {ID: "Rule.Synced"}}
+	result := removedPackItems(testPack, policies, testPackCurrent())
+	require.Len(t, result, 1)
+	assert.Equal(t, models.ID("Rule.Edited"), result[0].ID)
+}
+
+func TestContainsGlobal(t *testing.T) {
+	assert.False(t, containsGlobal(nil))
+	assert.False(t, containsGlobal([]*tableItem{{Type: typeRule}, {Type: typePolicy}}))
+	assert.True(t, containsGlobal([]*tableItem{{Type: typeRule}, {Type: typeGlobal}}))
+}
+
+func TestPackGet(t *testing.T) {
+	env.PackTable = "test-packs"
+	mockDynamo := &testutils.DynamoDBMock{}
+	dynamoClient = mockDynamo
+
+	item, err := dynamodbattribute.MarshalMap(testPack)
+	require.NoError(t, err)
+	mockDynamo.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{Item: item}, nil).Once()
+	mockDynamo.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{}, nil).Once()
+
+	result, err := packGet("pack.aws")
+	require.NoError(t, err)
+	assert.Equal(t, testPack, result)
+
+	result, err = packGet("pack.missing")
+	require.NoError(t, err)
+	assert.Nil(t, result)
+	mockDynamo.AssertExpectations(t)
+
+	input := mockDynamo.Calls[0].Arguments.Get(0).(*dynamodb.GetItemInput)
+	assert.Equal(t, "test-packs", *input.TableName)
+	assert.True(t, *input.ConsistentRead)
+}
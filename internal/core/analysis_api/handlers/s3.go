@@ -23,6 +23,7 @@ import (
 	"io/ioutil"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/s3"
 	jsoniter "github.com/json-iterator/go"
 	"go.uber.org/zap"
@@ -79,6 +80,75 @@ func s3Get(policyID models.ID, versionID models.VersionID) (*tableItem, error) {
 	return &policy, nil
 }
 
+// s3VersionNotFound returns true if the S3 error indicates a missing object or object version.
+func s3VersionNotFound(err error) bool {
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch awsErr.Code() {
+		case s3.ErrCodeNoSuchKey, "NoSuchVersion", "InvalidArgument":
+			// S3 returns InvalidArgument for version IDs it has never issued
+			return true
+		}
+	}
+	return false
+}
+
+// List the versions of a single policy, newest first.
+//
+// Returns at most pageSize version IDs which are older than versionIDMarker (if given),
+// as well as the marker for the next page (empty when there are no more versions).
+func s3ListVersions(
+	policyID models.ID, versionIDMarker models.VersionID, pageSize int) ([]*s3.ObjectVersion, models.VersionID, error) {
+
+	input := &s3.ListObjectVersionsInput{
+		Bucket:  &env.Bucket,
+		MaxKeys: aws.Int64(int64(pageSize)),
+		// Other policy IDs can share this prefix, but the exact key always sorts first
+		Prefix: aws.String(string(policyID)),
+	}
+	if versionIDMarker != "" {
+		input.KeyMarker = aws.String(string(policyID))
+		input.VersionIdMarker = aws.String(string(versionIDMarker))
+	}
+
+	result, err := s3Client.ListObjectVersions(input)
+	if err != nil {
+		zap.L().Error("s3Client.ListObjectVersions failed", zap.Error(err))
+		return nil, "", err
+	}
+
+	versions := make([]*s3.ObjectVersion, 0, len(result.Versions))
+	for _, version := range result.Versions {
+		if aws.StringValue(version.Key) != string(policyID) {
+			// We have moved past the versions of this policy - there is nothing left to page through
+			return versions, "", nil
+		}
+		versions = append(versions, version)
+	}
+
+	if aws.BoolValue(result.IsTruncated) && aws.StringValue(result.NextKeyMarker) == string(policyID) {
+		return versions, models.VersionID(aws.StringValue(result.NextVersionIdMarker)), nil
+	}
+	return versions, "", nil
+}
+
+// Permanently delete specific policy versions from S3.
+//
+// Deleting the latest version of an object restores its previous version.
+// This is best-effort: failures are logged and the remaining versions are still deleted.
+func s3DeleteVersions(policies []*tableItem) {
+	for _, policy := range policies {
+		_, err := s3Client.DeleteObject(&s3.DeleteObjectInput{
+			Bucket:    &env.Bucket,
+			Key:       aws.String(string(policy.ID)),
+			VersionId: aws.String(string(policy.VersionID)),
+		})
+		if err != nil {
+			zap.L().Error("s3Client.DeleteObject (version) failed",
+				zap.String("policyId", string(policy.ID)), zap.Error(err))
+		}
+	}
+}
+
 // Upload a policy to S3 and set the VersionID accordingly.
 func s3Upload(policy *tableItem) error {
 	// We don't need to store auto-generated fields - keep the S3 copy clean and minimal
@@ -0,0 +1,110 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	"github.com/panther-labs/panther/internal/log_analysis/scheduled_queries/schedule"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+// CreateScheduledQuery adds a new scheduled query to the Dynamo table.
+func CreateScheduledQuery(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	input, err := parseUpdateScheduledQuery(request)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	item := scheduledQueryItem(input)
+	if _, err := writeItem(item, input.UserID, aws.Bool(false)); err != nil {
+		if err == errExists {
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusConflict}
+		}
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	return gatewayapi.MarshalResponse(item.Rule(), http.StatusCreated)
+}
+
+// ModifyScheduledQuery updates an existing scheduled query.
+func ModifyScheduledQuery(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	input, err := parseUpdateScheduledQuery(request)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	item := scheduledQueryItem(input)
+	if _, err := writeItem(item, input.UserID, aws.Bool(true)); err != nil {
+		if err == errNotExists || err == errWrongType {
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusNotFound}
+		}
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	return gatewayapi.MarshalResponse(item.Rule(), http.StatusOK)
+}
+
+// body parsing shared by CreateScheduledQuery and ModifyScheduledQuery
+func parseUpdateScheduledQuery(request *events.APIGatewayProxyRequest) (*models.UpdateRule, error) {
+	result, err := parseUpdateRule(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Schedule == "" {
+		return nil, errors.New("schedule: a schedule is required for scheduled queries")
+	}
+	if _, err := schedule.Parse(string(result.Schedule)); err != nil {
+		return nil, errors.New("schedule: " + err.Error())
+	}
+
+	// The body is SQL, there is no engine to run unit tests against
+	if len(result.Tests) > 0 {
+		return nil, errors.New("tests: scheduled queries do not support unit tests")
+	}
+
+	return result, nil
+}
+
+func scheduledQueryItem(input *models.UpdateRule) *tableItem {
+	return &tableItem{
+		Body:               input.Body,
+		DedupColumn:        input.DedupColumn,
+		DedupPeriodMinutes: input.DedupPeriodMinutes,
+		Threshold:          input.Threshold,
+		Description:        input.Description,
+		DisplayName:        input.DisplayName,
+		Enabled:            input.Enabled,
+		ID:                 input.ID,
+		OutputIds:          input.OutputIds,
+		Reference:          input.Reference,
+		ResourceTypes:      input.LogTypes,
+		Runbook:            input.Runbook,
+		Schedule:           input.Schedule,
+		Severity:           input.Severity,
+		Tags:               input.Tags,
+		Type:               typeScheduledQuery,
+	}
+}
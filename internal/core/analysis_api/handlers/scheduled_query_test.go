@@ -0,0 +1,97 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+func TestParseUpdateScheduledQuery(t *testing.T) {
+	result, err := parseUpdateScheduledQuery(&events.APIGatewayProxyRequest{Body: `{
+		"body": "SELECT user FROM panther_views.all_logs",
+		"dedupColumn": "user",
+		"enabled": true,
+		"id": "query.id",
+		"schedule": "cron(0 12 ? * MON-FRI *)",
+		"severity": "HIGH",
+		"userId": "3601990c-2b89-4f4b-b4e6-b4e1a3b6a1a2"
+	}`})
+	require.NoError(t, err)
+	assert.Equal(t, models.Schedule("cron(0 12 ? * MON-FRI *)"), result.Schedule)
+	assert.Equal(t, models.DedupColumn("user"), result.DedupColumn)
+	assert.Equal(t, models.DedupPeriodMinutes(defaultDedupPeriodMinutes), result.DedupPeriodMinutes)
+
+	item := scheduledQueryItem(result)
+	assert.Equal(t, typeScheduledQuery, item.Type)
+	assert.Equal(t, result.Schedule, item.Rule().Schedule)
+}
+
+func TestParseUpdateScheduledQueryInvalid(t *testing.T) {
+	const base = `"body": "SELECT 1", "enabled": true, "id": "query.id", "severity": "HIGH",
+		"userId": "3601990c-2b89-4f4b-b4e6-b4e1a3b6a1a2"`
+	bodies := []string{
+		// missing schedule
+		`{` + base + `}`,
+		// invalid schedule
+		`{` + base + `, "schedule": "every 5 minutes"}`,
+		`{` + base + `, "schedule": "cron(0 12 * * * *)"}`,
+		// unit tests are not supported
+		`{` + base + `, "schedule": "rate(5 minutes)", "tests": [{"name": "test", "expectedResult": true, "resource": "{}"}]}`,
+	}
+	for _, body := range bodies {
+		_, err := parseUpdateScheduledQuery(&events.APIGatewayProxyRequest{Body: body})
+		assert.Error(t, err, body)
+	}
+}
+
+func TestWriteScheduledQueryDoesNotQueuePolicy(t *testing.T) {
+	mockDynamo, mockS3, mockSqs := &testutils.DynamoDBMock{}, &testutils.S3Mock{}, &testutils.SqsMock{}
+	dynamoClient, s3Client, sqsClient = mockDynamo, mockS3, mockSqs
+	mockDynamo.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{}, nil).Once()
+	mockDynamo.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil).Once()
+	mockS3.On("PutObject", mock.Anything).Return(&s3.PutObjectOutput{VersionId: aws.String("v1")}, nil).Once()
+
+	item := &tableItem{
+		Body:     "SELECT user FROM panther_views.all_logs",
+		Enabled:  true,
+		ID:       "query.id",
+		Schedule: "cron(0 12 ? * MON-FRI *)",
+		Severity: models.SeverityHIGH,
+		Type:     typeScheduledQuery,
+	}
+	changeType, err := writeItem(item, "3601990c-2b89-4f4b-b4e6-b4e1a3b6a1a2", aws.Bool(false))
+
+	require.NoError(t, err)
+	assert.Equal(t, newItem, changeType)
+	mockDynamo.AssertExpectations(t)
+	mockS3.AssertExpectations(t)
+	// The query is not sent to the resource processor as if it were a policy
+	mockSqs.AssertNotCalled(t, "SendMessage", mock.Anything)
+}
@@ -29,7 +29,15 @@ import (
 //
 // This ensures policy changes are reflected almost immediately (instead of waiting for daily scan).
 func queuePolicy(policy *tableItem) error {
-	body, err := jsoniter.MarshalToString(policy.Policy(""))
+	// Exceptions are stored separately, but the resource processor needs them to mark suppressed resources
+	result := policy.Policy("")
+	exceptions, err := activeExceptions(policy.ID)
+	if err != nil {
+		return err
+	}
+	result.Exceptions = exceptions
+
+	body, err := jsoniter.MarshalToString(result)
 	if err != nil {
 		zap.L().Error("failed to marshal policy", zap.Error(err))
 		return err
@@ -124,6 +124,21 @@ func setEquality(first, second []string) bool {
 	return true
 }
 
+// Returns true if the two report mappings have the same frameworks with the same set of controls
+func reportsEquality(first, second models.Reports) bool {
+	if len(first) != len(second) {
+		return false
+	}
+
+	for framework, controls := range first {
+		if otherControls, ok := second[framework]; !ok || !setEquality(controls, otherControls) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Rewrite test resource json in alphabetical order.
 func standardizeTests(p *models.Policy) error {
 	json := jsoniter.ConfigCompatibleWithStandardLibrary
@@ -143,8 +158,17 @@ func standardizeTests(p *models.Policy) error {
 	return nil
 }
 
-// Returns true if the two policies are logically equivalent.
+// Returns true if the two items are logically equivalent.
 func policiesEqual(first, second *tableItem) (bool, error) {
+	// Fields of rules, scheduled queries and correlation rules which the Policy model leaves out
+	if first.Type != second.Type || first.DedupColumn != second.DedupColumn ||
+		first.DedupPeriodMinutes != second.DedupPeriodMinutes || first.Threshold != second.Threshold ||
+		first.Schedule != second.Schedule || !reflect.DeepEqual(first.Correlation, second.Correlation) ||
+		(len(first.Reports) > 0 || len(second.Reports) > 0) && !reflect.DeepEqual(first.Reports, second.Reports) {
+
+		return false, nil
+	}
+
 	p1, p2 := first.Policy(""), second.Policy("")
 	p1.CreatedAt = p2.CreatedAt
 	p1.CreatedBy = p2.CreatedBy
@@ -181,17 +205,52 @@ func policiesEqual(first, second *tableItem) (bool, error) {
 // The first return value indicates what kind of change took place (none, new item, updated item).
 func writeItem(item *tableItem, userID models.UserID, mustExist *bool) (int, error) {
 	oldItem, err := dynamoGet(item.ID, true)
-	changeType := noChange
 	if err != nil {
+		return noChange, err
+	}
+
+	changeType, write, err := prepareItem(oldItem, item, userID, mustExist)
+	if err != nil || !write {
+		return changeType, err
+	}
+
+	// Write to S3 first so we can get the versionID
+	if err := s3Upload(item); err != nil {
+		return changeType, err
+	}
+
+	// Write to Dynamo (with version ID)
+	if err := dynamoPut(item); err != nil {
 		return changeType, err
 	}
 
+	// Only policies have a compliance status. Rules, globals, scheduled queries and correlation
+	// rules are not evaluated against resources.
+	if item.Type != typePolicy {
+		return changeType, nil
+	}
+
+	// Updated policies may require changes to the compliance status.
+	if err := updateComplianceStatus(oldItem, item); err != nil {
+		zap.L().Error("item update successful but failed to update compliance status", zap.Error(err))
+		// A failure here means we couldn't update the compliance status right now, but it will
+		// still be updated on the next daily scan / resource change, so we don't need to mark the
+		// entire API call as a failure.
+	}
+	return changeType, nil
+}
+
+// prepareItem checks an item against its current version and sets the created/modified fields.
+//
+// The second return value is false if the item is identical and does not need to be written.
+func prepareItem(oldItem, item *tableItem, userID models.UserID, mustExist *bool) (int, bool, error) {
+	changeType := noChange
 	if mustExist != nil {
 		if *mustExist && oldItem == nil {
-			return changeType, errNotExists // item should exist but does not (update)
+			return changeType, false, errNotExists // item should exist but does not (update)
 		}
 		if !*mustExist && oldItem != nil {
-			return changeType, errExists // item exists but should not (create)
+			return changeType, false, errExists // item exists but should not (create)
 		}
 	}
 
@@ -201,13 +260,18 @@ func writeItem(item *tableItem, userID models.UserID, mustExist *bool) (int, err
 		changeType = newItem
 	} else {
 		if oldItem.Type != item.Type {
-			return changeType, errWrongType
+			return changeType, false, errWrongType
+		}
+
+		// Items keep their pack when they are edited directly, so the edit is detected as drift
+		if item.PackID == "" {
+			item.PackID = oldItem.PackID
 		}
 
-		if equal, err := policiesEqual(oldItem, item); equal && err != nil {
+		if equal, err := policiesEqual(oldItem, item); equal && err == nil {
 			zap.L().Info("no changes necessary",
 				zap.String("policyId", string(item.ID)))
-			return changeType, nil
+			return changeType, false, nil
 		}
 		// If there was an error evaluating equality, just assume they are not equal and continue
 		// with the update as normal.
@@ -221,36 +285,7 @@ func writeItem(item *tableItem, userID models.UserID, mustExist *bool) (int, err
 
 	item.LastModified = models.ModifyTime(time.Now())
 	item.LastModifiedBy = userID
-
-	// Write to S3 first so we can get the versionID
-	if err := s3Upload(item); err != nil {
-		return changeType, err
-	}
-
-	// Write to Dynamo (with version ID)
-	if err := dynamoPut(item); err != nil {
-		return changeType, err
-	}
-
-	if item.Type == typeRule {
-		return changeType, nil
-	}
-
-	if item.Type == typeGlobal {
-		// When policies and rules are also managed by globals, this can be moved out of the if statement,
-		// although at that point it may be desirable to move this to the caller function so as to only make the call
-		// once for BulkUpload.
-		return changeType, nil
-	}
-
-	// Updated policies may require changes to the compliance status.
-	if err := updateComplianceStatus(oldItem, item); err != nil {
-		zap.L().Error("item update successful but failed to update compliance status", zap.Error(err))
-		// A failure here means we couldn't update the compliance status right now, but it will
-		// still be updated on the next daily scan / resource change, so we don't need to mark the
-		// entire API call as a failure.
-	}
-	return changeType, nil
+	return changeType, true, nil
 }
 
 // itemUpdated checks if ANY field has been changed between the old and new item. Only used to inform users whether the
@@ -261,12 +296,14 @@ func writeItem(item *tableItem, userID models.UserID, mustExist *bool) (int, err
 func itemUpdated(oldItem, newItem *tableItem) bool {
 	itemsEqual := oldItem.AutoRemediationID == newItem.AutoRemediationID && oldItem.Body == newItem.Body &&
 		oldItem.Description == newItem.Description &&
-		setEquality(oldItem.OutputIds, newItem.OutputIds) &&
+		setEquality(oldItem.OutputIds, newItem.OutputIds) && oldItem.PackID == newItem.PackID &&
 		oldItem.DisplayName == newItem.DisplayName &&
 		oldItem.Enabled == newItem.Enabled && oldItem.Reference == newItem.Reference &&
 		oldItem.Runbook == newItem.Runbook && oldItem.Severity == newItem.Severity &&
 		oldItem.DedupPeriodMinutes == newItem.DedupPeriodMinutes &&
 		oldItem.Threshold == newItem.Threshold &&
+		oldItem.Schedule == newItem.Schedule && oldItem.DedupColumn == newItem.DedupColumn &&
+		reflect.DeepEqual(oldItem.Correlation, newItem.Correlation) &&
 		setEquality(oldItem.ResourceTypes, newItem.ResourceTypes) &&
 		setEquality(oldItem.Suppressions, newItem.Suppressions) && setEquality(oldItem.Tags, newItem.Tags) &&
 		len(oldItem.AutoRemediationParameters) == len(newItem.AutoRemediationParameters) &&
@@ -23,6 +23,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/panther-labs/panther/api/gateway/analysis/models"
@@ -56,6 +57,18 @@ func TestSetEquality(t *testing.T) {
 	assert.False(t, setEquality([]string{"panther", "labs"}, []string{"panther", "inc"}))
 }
 
+func TestReportsEquality(t *testing.T) {
+	assert.True(t, reportsEquality(nil, models.Reports{}))
+	assert.True(t, reportsEquality(
+		models.Reports{"CIS": {"1.1", "1.2"}}, models.Reports{"CIS": {"1.2", "1.1"}}))
+	assert.False(t, reportsEquality(
+		models.Reports{"CIS": {"1.1"}}, models.Reports{"CIS": {"1.1"}, "PCI": {"2.1"}}))
+	assert.False(t, reportsEquality(
+		models.Reports{"CIS": {"1.1"}}, models.Reports{"SOC2": {"1.1"}}))
+	assert.False(t, reportsEquality(
+		models.Reports{"CIS": {"1.1"}}, models.Reports{"CIS": {"1.2"}}))
+}
+
 func TestPoliciesEqual(t *testing.T) {
 	first := &tableItem{
 		Body:          "def policy(resource): return True",
@@ -100,6 +113,49 @@ func TestPoliciesEqual(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestPrepareItem(t *testing.T) {
+	oldItem := &tableItem{
+		Body:      "def policy(resource): return True",
+		CreatedAt: models.ModifyTime(time.Now()),
+		CreatedBy: "austin",
+		ID:        "My.Policy",
+		Type:      typePolicy,
+		VersionID: "version-the-first",
+	}
+
+	// Identical items are not written again
+	item := &tableItem{Body: oldItem.Body, ID: oldItem.ID, Type: typePolicy}
+	changeType, write, err := prepareItem(oldItem, item, "user", nil)
+	assert.NoError(t, err)
+	assert.False(t, write)
+	assert.Equal(t, noChange, changeType)
+
+	item = &tableItem{Body: "def policy(resource): return False", ID: oldItem.ID, Type: typePolicy}
+	changeType, write, err = prepareItem(oldItem, item, "user", nil)
+	assert.NoError(t, err)
+	assert.True(t, write)
+	assert.Equal(t, updatedItem, changeType)
+	assert.Equal(t, oldItem.CreatedBy, item.CreatedBy)
+	assert.Equal(t, models.UserID("user"), item.LastModifiedBy)
+
+	// Changes to fields the Policy model leaves out are written too
+	oldRule := &tableItem{Body: "def rule(event): return True", ID: "My.Rule", Type: typeRule, DedupPeriodMinutes: 60}
+	for _, item := range []*tableItem{
+		{Body: oldRule.Body, ID: oldRule.ID, Type: typeRule, DedupPeriodMinutes: 15},
+		{Body: oldRule.Body, ID: oldRule.ID, Type: typeRule, DedupPeriodMinutes: 60, Threshold: 10},
+	} {
+		changeType, write, err = prepareItem(oldRule, item, "user", nil)
+		assert.NoError(t, err)
+		assert.True(t, write)
+		assert.Equal(t, updatedItem, changeType)
+	}
+
+	_, _, err = prepareItem(oldItem, item, "user", aws.Bool(false))
+	assert.Equal(t, errExists, err)
+	_, _, err = prepareItem(nil, item, "user", aws.Bool(true))
+	assert.Equal(t, errNotExists, err)
+}
+
 func TestSortCaseInsensitive(t *testing.T) {
 	input := []string{"AWS.EC2.VPC", "AWS.EC2.Volume"}
 	sortCaseInsensitive(input)
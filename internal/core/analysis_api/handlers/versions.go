@@ -0,0 +1,379 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pmezard/go-difflib/difflib"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+)
+
+const (
+	defaultVersionPageSize = 25
+	maxVersionPageSize     = 50
+)
+
+// Fields which change with every version and are therefore left out of a version diff.
+// The body is compared separately as a unified diff.
+var ignoredDiffFields = map[string]bool{
+	"body":             true,
+	"createdAt":        true,
+	"createdBy":        true,
+	"lastModified":     true,
+	"lastModifiedBy":   true,
+	"lowerDisplayName": true,
+	"lowerId":          true,
+	"lowerTags":        true,
+	"versionId":        true,
+}
+
+type listVersionsParams struct {
+	ID              models.ID
+	PageSize        int
+	VersionIDMarker models.VersionID
+}
+
+type versionDiffParams struct {
+	ID            models.ID
+	FromVersionID models.VersionID
+	ToVersionID   models.VersionID
+}
+
+// ListVersions pages through the version history of a policy, rule or global.
+func ListVersions(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	input, err := parseListVersions(request)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	current, err := dynamoGet(input.ID, false)
+	if err != nil {
+		return failedRequest(fmt.Sprintf("Internal error finding %s", input.ID), http.StatusInternalServerError)
+	}
+	if current == nil {
+		return failedRequest(fmt.Sprintf("Cannot find %s", input.ID), http.StatusNotFound)
+	}
+
+	versions, nextMarker, err := s3ListVersions(input.ID, input.VersionIDMarker, input.PageSize)
+	if err != nil {
+		return failedRequest(fmt.Sprintf("Internal error listing versions of %s", input.ID), http.StatusInternalServerError)
+	}
+
+	result := &models.VersionList{
+		NextVersionIDMarker: string(nextMarker),
+		Versions:            make([]*models.VersionSummary, 0, len(versions)),
+	}
+	for _, version := range versions {
+		// Only the listing is used here: reading every version would be one S3 request each,
+		// so the author is left to GetVersionDiff.
+		result.Versions = append(result.Versions, &models.VersionSummary{
+			IsLatest:     version.IsLatest,
+			LastModified: models.ModifyTime(aws.TimeValue(version.LastModified)),
+			VersionID:    models.VersionID(aws.StringValue(version.VersionId)),
+		})
+	}
+
+	return gatewayapi.MarshalResponse(result, http.StatusOK)
+}
+
+// GetVersionDiff compares two versions of a policy, rule or global.
+func GetVersionDiff(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	input, err := parseVersionDiff(request)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	current, err := dynamoGet(input.ID, false)
+	if err != nil {
+		return failedRequest(fmt.Sprintf("Internal error finding %s", input.ID), http.StatusInternalServerError)
+	}
+	if current == nil {
+		return failedRequest(fmt.Sprintf("Cannot find %s", input.ID), http.StatusNotFound)
+	}
+	if input.ToVersionID == "" {
+		input.ToVersionID = current.VersionID
+	}
+
+	from, err := s3Get(input.ID, input.FromVersionID)
+	if err != nil {
+		return versionGetFailed(input.ID, input.FromVersionID, err)
+	}
+	to, err := s3Get(input.ID, input.ToVersionID)
+	if err != nil {
+		return versionGetFailed(input.ID, input.ToVersionID, err)
+	}
+
+	bodyDiff, err := diffBody(from, to, string(from.VersionID), string(to.VersionID))
+	if err != nil {
+		return failedRequest(fmt.Sprintf("Internal error comparing versions of %s", input.ID), http.StatusInternalServerError)
+	}
+
+	changes, err := diffFields(from, to)
+	if err != nil {
+		return failedRequest(fmt.Sprintf("Internal error comparing versions of %s", input.ID), http.StatusInternalServerError)
+	}
+
+	return gatewayapi.MarshalResponse(&models.VersionDiff{
+		BodyDiff: &bodyDiff,
+		Changes:  changes,
+		From:     versionSummary(from, from.VersionID == current.VersionID),
+		ID:       input.ID,
+		To:       versionSummary(to, to.VersionID == current.VersionID),
+	}, http.StatusOK)
+}
+
+// RevertToVersion restores a previous version of a policy, rule or global.
+//
+// The old version is written as a brand new version, so compliance status and the global layer
+// are updated exactly as they would be for a normal modification.
+func RevertToVersion(request *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+	input, err := parseRevertToVersion(request)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	item, err := s3Get(input.AnalysisID, input.VersionID)
+	if err != nil {
+		return versionGetFailed(input.AnalysisID, input.VersionID, err)
+	}
+
+	if _, err := writeItem(item, input.UserID, aws.Bool(true)); err != nil {
+		if err == errNotExists || err == errWrongType {
+			// The item has since been deleted (or replaced by an item of a different type)
+			return failedRequest(fmt.Sprintf("Cannot find %s", input.AnalysisID), http.StatusNotFound)
+		}
+		return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+	}
+
+	if item.Type == typeGlobal {
+		if err = updateLayer(); err != nil {
+			return &events.APIGatewayProxyResponse{StatusCode: http.StatusInternalServerError}
+		}
+	}
+
+	return gatewayapi.MarshalResponse(versionSummary(item, true), http.StatusOK)
+}
+
+func parseListVersions(request *events.APIGatewayProxyRequest) (*listVersionsParams, error) {
+	id, err := parseAnalysisID(request)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &listVersionsParams{
+		ID:              id,
+		PageSize:        defaultVersionPageSize,
+		VersionIDMarker: models.VersionID(request.QueryStringParameters["versionIdMarker"]),
+	}
+
+	if raw := request.QueryStringParameters["pageSize"]; raw != "" {
+		result.PageSize, err = strconv.Atoi(raw)
+		if err != nil {
+			return nil, errors.New("invalid pageSize: " + err.Error())
+		}
+		if result.PageSize < 1 || result.PageSize > maxVersionPageSize {
+			return nil, fmt.Errorf("invalid pageSize: must be between 1 and %d", maxVersionPageSize)
+		}
+	}
+
+	if result.VersionIDMarker != "" {
+		if err := result.VersionIDMarker.Validate(nil); err != nil {
+			return nil, errors.New("invalid versionIdMarker: " + err.Error())
+		}
+	}
+
+	return result, nil
+}
+
+func parseVersionDiff(request *events.APIGatewayProxyRequest) (*versionDiffParams, error) {
+	id, err := parseAnalysisID(request)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &versionDiffParams{
+		ID:            id,
+		FromVersionID: models.VersionID(request.QueryStringParameters["fromVersionId"]),
+		ToVersionID:   models.VersionID(request.QueryStringParameters["toVersionId"]),
+	}
+
+	if result.FromVersionID == "" {
+		return nil, errors.New("invalid fromVersionId: required")
+	}
+	if err := result.FromVersionID.Validate(nil); err != nil {
+		return nil, errors.New("invalid fromVersionId: " + err.Error())
+	}
+
+	if result.ToVersionID != "" {
+		if err := result.ToVersionID.Validate(nil); err != nil {
+			return nil, errors.New("invalid toVersionId: " + err.Error())
+		}
+	}
+
+	return result, nil
+}
+
+func parseRevertToVersion(request *events.APIGatewayProxyRequest) (*models.RevertToVersion, error) {
+	var result models.RevertToVersion
+	if err := jsoniter.UnmarshalFromString(request.Body, &result); err != nil {
+		return nil, err
+	}
+
+	if err := result.Validate(nil); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func parseAnalysisID(request *events.APIGatewayProxyRequest) (models.ID, error) {
+	raw, err := url.QueryUnescape(request.QueryStringParameters["analysisId"])
+	if err != nil {
+		return "", errors.New("invalid analysisId: " + err.Error())
+	}
+
+	id := models.ID(raw)
+	if err := id.Validate(nil); err != nil {
+		return "", errors.New("invalid analysisId: " + err.Error())
+	}
+	return id, nil
+}
+
+// Convert an s3Get error into a 404 (for a missing version) or a 500 proxy response.
+func versionGetFailed(id models.ID, versionID models.VersionID, err error) *events.APIGatewayProxyResponse {
+	if s3VersionNotFound(err) {
+		return failedRequest(fmt.Sprintf("Cannot find version %s of %s", versionID, id), http.StatusNotFound)
+	}
+	return failedRequest(fmt.Sprintf("Internal error finding version %s of %s", versionID, id), http.StatusInternalServerError)
+}
+
+func versionSummary(item *tableItem, isLatest bool) *models.VersionSummary {
+	return &models.VersionSummary{
+		IsLatest:       aws.Bool(isLatest),
+		LastModified:   item.LastModified,
+		LastModifiedBy: item.LastModifiedBy,
+		VersionID:      item.VersionID,
+	}
+}
+
+// diffBody returns a unified diff of the source code of two versions.
+func diffBody(from, to *tableItem, fromName, toName string) (string, error) {
+	result, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(string(from.Body)),
+		B:        splitLines(string(to.Body)),
+		FromFile: fromName,
+		ToFile:   toName,
+		Context:  3,
+	})
+	if err != nil {
+		zap.L().Error("failed to diff policy body", zap.Error(err))
+		return "", err
+	}
+	return result, nil
+}
+
+// Split source code into lines, keeping the line endings the unified diff expects
+func splitLines(body string) []string {
+	lines := strings.SplitAfter(body, "\n")
+	if lines[len(lines)-1] == "" {
+		// The body ends with a newline - don't add a phantom empty line
+		return lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffFields returns the JSON-encoded before/after values of every field which differs between two versions.
+func diffFields(from, to *tableItem) ([]*models.FieldChange, error) {
+	// String sets are stored unordered - sort them so ordering differences don't show up as changes
+	from.normalize()
+	to.normalize()
+
+	fromFields, err := itemFields(from)
+	if err != nil {
+		return nil, err
+	}
+	toFields, err := itemFields(to)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(fromFields)+len(toFields))
+	for name := range fromFields {
+		names = append(names, name)
+	}
+	for name := range toFields {
+		if _, ok := fromFields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	changes := make([]*models.FieldChange, 0)
+	for _, name := range names {
+		if ignoredDiffFields[name] || reflect.DeepEqual(fromFields[name], toFields[name]) {
+			continue
+		}
+
+		fromValue, err := jsoniter.MarshalToString(fromFields[name])
+		if err != nil {
+			return nil, err
+		}
+		toValue, err := jsoniter.MarshalToString(toFields[name])
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, &models.FieldChange{
+			Field: aws.String(name),
+			From:  &fromValue,
+			To:    &toValue,
+		})
+	}
+	return changes, nil
+}
+
+// Convert an item to its generic JSON representation
+func itemFields(item *tableItem) (map[string]interface{}, error) {
+	raw, err := jsoniter.Marshal(item)
+	if err != nil {
+		zap.L().Error("policy marshal failed", zap.Error(err))
+		return nil, err
+	}
+
+	var fields map[string]interface{}
+	if err = jsoniter.Unmarshal(raw, &fields); err != nil {
+		zap.L().Error("policy unmarshal failed", zap.Error(err))
+		return nil, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,173 @@
+package handlers
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/s3"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+const testVersionID = "TsKejJ6GGi_KdH65g2iu9bcww8JxkkwI"
+
+func TestDiffFields(t *testing.T) {
+	from := &tableItem{
+		Body:           "def policy(resource): return True",
+		Enabled:        true,
+		ID:             "AWS.S3.BucketEncryption",
+		LastModifiedBy: "5f54cf4a-ec56-44c2-83bc-8b742600f307",
+		Severity:       "LOW",
+		Tags:           []string{"S3", "aws"},
+		VersionID:      "version-the-first",
+	}
+	to := &tableItem{
+		Body:           "def policy(resource): return False",
+		Description:    "Buckets must be encrypted",
+		Enabled:        true,
+		ID:             "AWS.S3.BucketEncryption",
+		LastModifiedBy: "b7a2cf4a-ec56-44c2-83bc-8b742600f307",
+		Severity:       "HIGH",
+		Tags:           []string{"aws", "S3"},
+		VersionID:      "version-the-second",
+	}
+
+	changes, err := diffFields(from, to)
+	require.NoError(t, err)
+	expected := []*models.FieldChange{
+		{Field: aws.String("description"), From: aws.String("null"), To: aws.String(`"Buckets must be encrypted"`)},
+		{Field: aws.String("severity"), From: aws.String(`"LOW"`), To: aws.String(`"HIGH"`)},
+	}
+	assert.Equal(t, expected, changes)
+}
+
+func TestDiffFieldsNoChanges(t *testing.T) {
+	item := &tableItem{Body: "def rule(event): return True", ID: "MyRule", Severity: "INFO"}
+	changes, err := diffFields(item, item)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestListVersions(t *testing.T) {
+	env.Table, env.Bucket = "test-analysis", "test-bucket"
+	mockDynamo, mockS3 := &testutils.DynamoDBMock{}, &testutils.S3Mock{}
+	originalDynamo, originalS3 := dynamoClient, s3Client
+	dynamoClient, s3Client = mockDynamo, mockS3
+	t.Cleanup(func() { dynamoClient, s3Client = originalDynamo, originalS3 })
+
+	modified := time.Date(2019, 8, 27, 0, 0, 0, 0, time.UTC)
+	mockDynamo.On("GetItem", mock.Anything).Return(
+		&dynamodb.GetItemOutput{Item: testPolicyItem(t, "AWS.S3.BucketEncryption")}, nil).Once()
+	mockS3.On("ListObjectVersions", mock.Anything).Return(&s3.ListObjectVersionsOutput{
+		Versions: []*s3.ObjectVersion{
+			{
+				IsLatest:     aws.Bool(true),
+				Key:          aws.String("AWS.S3.BucketEncryption"),
+				LastModified: &modified,
+				VersionId:    aws.String(testVersionID),
+			},
+			{
+				IsLatest:     aws.Bool(false),
+				Key:          aws.String("AWS.S3.BucketEncryption.Other"),
+				LastModified: &modified,
+				VersionId:    aws.String("MZs1xPQyb6nLhKfLkDbM6XQXdEXlBF3v"),
+			},
+		},
+	}, nil).Once()
+
+	// The versions themselves are never read from S3 (GetObject is not mocked)
+	response := ListVersions(&events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{"analysisId": "AWS.S3.BucketEncryption"},
+	})
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	var result models.VersionList
+	require.NoError(t, jsoniter.UnmarshalFromString(response.Body, &result))
+	assert.Equal(t, []*models.VersionSummary{
+		{
+			IsLatest:     aws.Bool(true),
+			LastModified: models.ModifyTime(modified),
+			VersionID:    testVersionID,
+		},
+	}, result.Versions)
+	assert.Empty(t, result.NextVersionIDMarker)
+	mockDynamo.AssertExpectations(t)
+	mockS3.AssertExpectations(t)
+}
+
+func TestParseListVersions(t *testing.T) {
+	result, err := parseListVersions(&events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{"analysisId": "My%20Rule"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &listVersionsParams{ID: "My Rule", PageSize: defaultVersionPageSize}, result)
+
+	result, err = parseListVersions(&events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{
+			"analysisId":      "MyRule",
+			"pageSize":        "5",
+			"versionIdMarker": testVersionID,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &listVersionsParams{ID: "MyRule", PageSize: 5, VersionIDMarker: testVersionID}, result)
+}
+
+func TestParseListVersionsInvalid(t *testing.T) {
+	for _, params := range []map[string]string{
+		{},
+		{"analysisId": "MyRule", "pageSize": "0"},
+		{"analysisId": "MyRule", "pageSize": "51"},
+		{"analysisId": "MyRule", "pageSize": "many"},
+		{"analysisId": "MyRule", "versionIdMarker": "not-a-version"},
+	} {
+		_, err := parseListVersions(&events.APIGatewayProxyRequest{QueryStringParameters: params})
+		assert.Error(t, err, params)
+	}
+}
+
+func TestParseVersionDiff(t *testing.T) {
+	result, err := parseVersionDiff(&events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{"analysisId": "MyRule", "fromVersionId": testVersionID},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &versionDiffParams{ID: "MyRule", FromVersionID: testVersionID}, result)
+
+	_, err = parseVersionDiff(&events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{"analysisId": "MyRule"},
+	})
+	assert.Error(t, err)
+
+	_, err = parseVersionDiff(&events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{
+			"analysisId": "MyRule", "fromVersionId": testVersionID, "toVersionId": "latest"},
+	})
+	assert.Error(t, err)
+}
@@ -19,10 +19,14 @@ package main
  */
 
 import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
 
 	"github.com/panther-labs/panther/internal/core/analysis_api/handlers"
 	"github.com/panther-labs/panther/pkg/gatewayapi"
+	"github.com/panther-labs/panther/pkg/lambdalogger"
 )
 
 var methodHandlers = map[string]gatewayapi.RequestHandler{
@@ -34,11 +38,36 @@ var methodHandlers = map[string]gatewayapi.RequestHandler{
 	"POST /update":   handlers.ModifyPolicy,
 	"POST /upload":   handlers.BulkUpload,
 
+	// Policy exceptions
+	"POST /exception":        handlers.CreateException,
+	"POST /exception/delete": handlers.DeleteExceptions,
+	"GET /exception/list":    handlers.ListExceptions,
+
 	// Rules only
-	"GET /rule":         handlers.GetRule,
-	"POST /rule":        handlers.CreateRule,
-	"GET /rule/list":    handlers.ListRules,
-	"POST /rule/update": handlers.ModifyRule,
+	"GET /rule":           handlers.GetRule,
+	"POST /rule":          handlers.CreateRule,
+	"GET /rule/list":      handlers.ListRules,
+	"POST /rule/update":   handlers.ModifyRule,
+	"GET /rule/backtest":  handlers.GetBacktest,
+	"POST /rule/backtest": handlers.BacktestRule,
+
+	// Scheduled queries only
+	"GET /query":         handlers.GetScheduledQuery,
+	"POST /query":        handlers.CreateScheduledQuery,
+	"GET /query/list":    handlers.ListScheduledQueries,
+	"POST /query/update": handlers.ModifyScheduledQuery,
+
+	// Correlation rules only
+	"GET /correlation":         handlers.GetCorrelationRule,
+	"POST /correlation":        handlers.CreateCorrelationRule,
+	"GET /correlation/list":    handlers.ListCorrelationRules,
+	"POST /correlation/update": handlers.ModifyCorrelationRule,
+
+	// Analysis packs
+	"GET /pack":         handlers.GetPack,
+	"POST /pack":        handlers.InstallPack,
+	"GET /pack/list":    handlers.ListPacks,
+	"POST /pack/update": handlers.ModifyPack,
 
 	// Globals only
 	"GET /global":         handlers.GetGlobal,
@@ -48,12 +77,35 @@ var methodHandlers = map[string]gatewayapi.RequestHandler{
 	"POST /global/delete": handlers.DeleteGlobal,
 
 	// Rules and Policies
-	"POST /delete": handlers.DeletePolicies,
-	"GET /enabled": handlers.GetEnabledAnalyses,
-	"POST /test":   handlers.TestPolicy,
+	"POST /delete":    handlers.DeletePolicies,
+	"GET /enabled":    handlers.GetEnabledAnalyses,
+	"GET /datamodels": handlers.ListDataModels,
+	"GET /export":     handlers.ExportAnalysis,
+	"POST /test":      handlers.TestPolicy,
+
+	// Version history
+	"GET /version/list":    handlers.ListVersions,
+	"GET /version/diff":    handlers.GetVersionDiff,
+	"POST /version/revert": handlers.RevertToVersion,
+}
+
+// The analysis-api serves API Gateway requests, and also runs the backtests it invokes asynchronously.
+type analysisEvent struct {
+	events.APIGatewayProxyRequest
+	handlers.BacktestEvent
+}
+
+var proxy = gatewayapi.LambdaProxy(methodHandlers)
+
+func handle(ctx context.Context, event *analysisEvent) (*events.APIGatewayProxyResponse, error) {
+	if event.BacktestID != "" {
+		lambdalogger.ConfigureGlobal(ctx, map[string]interface{}{"backtestId": event.BacktestID})
+		return nil, handlers.RunBacktest(ctx, &event.BacktestEvent)
+	}
+	return proxy(ctx, &event.APIGatewayProxyRequest)
 }
 
 func main() {
 	handlers.Setup()
-	lambda.Start(gatewayapi.LambdaProxy(methodHandlers))
+	lambda.Start(handle)
 }
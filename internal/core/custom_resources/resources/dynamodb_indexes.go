@@ -0,0 +1,169 @@
+package resources
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-lambda-go/cfn"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"go.uber.org/zap"
+)
+
+const (
+	// How often to check whether a new index has finished backfilling
+	indexPollInterval = 15 * time.Second
+
+	// Stop waiting this long before the Lambda function times out, so we can still report to CloudFormation
+	indexDeadlineMargin = time.Minute
+)
+
+type DynamoDBIndexesProperties struct {
+	TableName string                `validate:"required"`
+	Indexes   []DynamoDBIndexConfig `validate:"required,dive"`
+}
+
+// A global secondary index with string keys which projects every attribute
+type DynamoDBIndexConfig struct {
+	IndexName string `validate:"required"`
+	HashKey   string `validate:"required"`
+	RangeKey  string
+}
+
+func customDynamoDBIndexes(ctx context.Context, event cfn.Event) (string, map[string]interface{}, error) {
+	switch event.RequestType {
+	case cfn.RequestCreate, cfn.RequestUpdate:
+		var props DynamoDBIndexesProperties
+		if err := parseProperties(event.ResourceProperties, &props); err != nil {
+			return "", nil, err
+		}
+		return "custom:dynamodb:indexes:" + props.TableName, nil, createIndexes(ctx, &props)
+
+	default:
+		// Indexes are removed along with the table - indexes which are no longer listed are left
+		// in place, the same as the table itself would be.
+		return event.PhysicalResourceID, nil, nil
+	}
+}
+
+// Create each missing index in turn.
+//
+// Dynamo can only add one global secondary index per table update, and the next index can't be
+// added until the previous one has finished backfilling. CloudFormation would instead try to
+// add them all in a single stack update, which fails.
+func createIndexes(ctx context.Context, props *DynamoDBIndexesProperties) error {
+	for i := range props.Indexes {
+		index := &props.Indexes[i]
+		table, err := waitForIndexes(ctx, props.TableName)
+		if err != nil {
+			return err
+		}
+
+		if hasIndex(table, index.IndexName) {
+			continue
+		}
+
+		zap.L().Info("creating dynamo index",
+			zap.String("tableName", props.TableName), zap.String("indexName", index.IndexName))
+		if _, err := dynamoClient.UpdateTable(indexUpdate(props.TableName, index)); err != nil {
+			return fmt.Errorf("failed to create index %s on %s: %v", index.IndexName, props.TableName, err)
+		}
+	}
+
+	// Make sure the last index is ready before the stack update continues
+	_, err := waitForIndexes(ctx, props.TableName)
+	return err
+}
+
+func indexUpdate(tableName string, index *DynamoDBIndexConfig) *dynamodb.UpdateTableInput {
+	keys := []*dynamodb.KeySchemaElement{
+		{AttributeName: aws.String(index.HashKey), KeyType: aws.String(dynamodb.KeyTypeHash)},
+	}
+	attributes := []*dynamodb.AttributeDefinition{
+		{AttributeName: aws.String(index.HashKey), AttributeType: aws.String(dynamodb.ScalarAttributeTypeS)},
+	}
+	if index.RangeKey != "" {
+		keys = append(keys, &dynamodb.KeySchemaElement{
+			AttributeName: aws.String(index.RangeKey), KeyType: aws.String(dynamodb.KeyTypeRange)})
+		attributes = append(attributes, &dynamodb.AttributeDefinition{
+			AttributeName: aws.String(index.RangeKey), AttributeType: aws.String(dynamodb.ScalarAttributeTypeS)})
+	}
+
+	return &dynamodb.UpdateTableInput{
+		AttributeDefinitions: attributes,
+		GlobalSecondaryIndexUpdates: []*dynamodb.GlobalSecondaryIndexUpdate{
+			{
+				Create: &dynamodb.CreateGlobalSecondaryIndexAction{
+					IndexName:  aws.String(index.IndexName),
+					KeySchema:  keys,
+					Projection: &dynamodb.Projection{ProjectionType: aws.String(dynamodb.ProjectionTypeAll)},
+				},
+			},
+		},
+		TableName: aws.String(tableName),
+	}
+}
+
+func hasIndex(table *dynamodb.TableDescription, indexName string) bool {
+	for _, index := range table.GlobalSecondaryIndexes {
+		if aws.StringValue(index.IndexName) == indexName {
+			return true
+		}
+	}
+	return false
+}
+
+// Wait until the table and all of its indexes are active.
+//
+// If the Lambda function is about to time out, an error is returned instead. The index keeps
+// building in the background, so the deployment can be retried once it has finished.
+func waitForIndexes(ctx context.Context, tableName string) (*dynamodb.TableDescription, error) {
+	deadline, hasDeadline := ctx.Deadline()
+	for {
+		response, err := dynamoClient.DescribeTable(&dynamodb.DescribeTableInput{TableName: &tableName})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe table %s: %v", tableName, err)
+		}
+
+		table := response.Table
+		pending := ""
+		if aws.StringValue(table.TableStatus) != dynamodb.TableStatusActive {
+			pending = tableName
+		}
+		for _, index := range table.GlobalSecondaryIndexes {
+			if aws.StringValue(index.IndexStatus) != dynamodb.IndexStatusActive {
+				pending = aws.StringValue(index.IndexName)
+			}
+		}
+		if pending == "" {
+			return table, nil
+		}
+
+		if hasDeadline && time.Until(deadline) < indexDeadlineMargin+indexPollInterval {
+			return nil, fmt.Errorf("%s on table %s is still being built - retry the deployment when it is active",
+				pending, tableName)
+		}
+
+		zap.L().Info("waiting for dynamo index", zap.String("tableName", tableName), zap.String("pending", pending))
+		time.Sleep(indexPollInterval)
+	}
+}
@@ -73,6 +73,20 @@ var CustomResources = map[string]cfn.CustomResourceFunction{
 	// PhysicalId: custom:alarms:dynamodb:$TABLE_NAME
 	"Custom::DynamoDBAlarms": customDynamoDBAlarms,
 
+	// Global secondary indexes for an existing Dynamo table, which are added one at a time.
+	//
+	// Parameters:
+	//     TableName:     string (required)
+	//     Indexes:
+	//         - IndexName: string (required)
+	//           HashKey:   string (required)
+	//           RangeKey:  string
+	// Outputs: None
+	// PhysicalId: custom:dynamodb:indexes:$TABLE_NAME
+	//
+	// Index keys are strings and every attribute is projected. Indexes are never deleted.
+	"Custom::DynamoDBIndexes": customDynamoDBIndexes,
+
 	// CloudWatch alarms for ELB errors, latency, and health
 	//
 	// Parameters:
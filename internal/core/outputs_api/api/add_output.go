@@ -51,6 +51,10 @@ func (API) AddOutput(input *models.AddOutputInput) (*models.AddOutputOutput, err
 		return nil, &genericapi.InvalidInputError{Message: err.Error()}
 	}
 
+	if err = validateDigestByType(input.DigestConfig, outputType); err != nil {
+		return nil, &genericapi.InvalidInputError{Message: err.Error()}
+	}
+
 	alertOutput := &models.AlertOutput{
 		OutputID:           aws.String(uuid.New().String()),
 		DisplayName:        input.DisplayName,
@@ -61,6 +65,7 @@ func (API) AddOutput(input *models.AddOutputInput) (*models.AddOutputOutput, err
 		OutputType:         outputType,
 		OutputConfig:       input.OutputConfig,
 		DefaultForSeverity: input.DefaultForSeverity,
+		DigestConfig:       input.DigestConfig,
 	}
 
 	alertOutputItem, err := AlertOutputToItem(alertOutput)
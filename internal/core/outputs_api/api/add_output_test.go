@@ -278,3 +278,149 @@ func TestAddOutputAsana(t *testing.T) {
 	_, err = uuid.Parse(*result.OutputID)
 	assert.NoError(t, err)
 }
+
+func TestAddOutputSlackDigest(t *testing.T) {
+	mockEncryptionKey := &mockEncryptionKey{}
+	encryptionKey = mockEncryptionKey
+	mockOutputTable := &mockOutputTable{}
+	outputsTable = mockOutputTable
+
+	digest := &models.DigestConfig{WindowMinutes: 15}
+	mockOutputTable.On("GetOutputByName", aws.String("my-channel")).Return(nil, nil)
+	mockEncryptionKey.On("EncryptConfig", mock.Anything).Return(make([]byte, 1), nil)
+	mockOutputTable.On("PutOutput", mock.MatchedBy(func(item *table.AlertOutputItem) bool {
+		return item.DigestConfig == digest
+	})).Return(nil)
+
+	input := &models.AddOutputInput{
+		UserID:       aws.String("userId"),
+		DisplayName:  aws.String("my-channel"),
+		OutputConfig: &models.OutputConfig{Slack: &models.SlackConfig{WebhookURL: "hooks.slack.com"}},
+		DigestConfig: digest,
+	}
+
+	result, err := (API{}).AddOutput(input)
+	require.NoError(t, err)
+	assert.Equal(t, digest, result.DigestConfig)
+
+	mockOutputTable.AssertExpectations(t)
+	mockEncryptionKey.AssertExpectations(t)
+}
+
+func TestAddOutputDigestUnsupported(t *testing.T) {
+	mockEncryptionKey := &mockEncryptionKey{}
+	encryptionKey = mockEncryptionKey
+	mockOutputTable := &mockOutputTable{}
+	outputsTable = mockOutputTable
+
+	mockOutputTable.On("GetOutputByName", aws.String("my-pagerduty")).Return(nil, nil)
+
+	input := &models.AddOutputInput{
+		UserID:      aws.String("userId"),
+		DisplayName: aws.String("my-pagerduty"),
+		OutputConfig: &models.OutputConfig{
+			PagerDuty: &models.PagerDutyConfig{IntegrationKey: "7a08481fbc0746c19a0b7aeee3bfe31b"},
+		},
+		DigestConfig: &models.DigestConfig{WindowMinutes: 60},
+	}
+
+	result, err := (API{}).AddOutput(input)
+	assert.Nil(t, result)
+	assert.Error(t, err)
+
+	mockOutputTable.AssertExpectations(t)
+	mockEncryptionKey.AssertExpectations(t)
+}
+
+func TestAddOutputEmail(t *testing.T) {
+	mockEncryptionKey := &mockEncryptionKey{}
+	encryptionKey = mockEncryptionKey
+	mockOutputTable := &mockOutputTable{}
+	outputsTable = mockOutputTable
+
+	mockOutputTable.On("GetOutputByName", aws.String("my-email")).Return(nil, nil)
+	mockEncryptionKey.On("EncryptConfig", mock.Anything).Return(make([]byte, 1), nil)
+	mockOutputTable.On("PutOutput", mock.Anything).Return(nil)
+
+	input := &models.AddOutputInput{
+		UserID:      aws.String("userId"),
+		DisplayName: aws.String("my-email"),
+		OutputConfig: &models.OutputConfig{
+			Email: &models.EmailConfig{
+				Host:     "smtp.example.com",
+				Port:     aws.Int(587),
+				UserName: "panther",
+				Password: "secret",
+				From:     "alerts@example.com",
+				To:       []string{"soc@example.com"},
+			},
+		},
+	}
+
+	result, err := (API{}).AddOutput(input)
+	require.NoError(t, err)
+	assert.Equal(t, aws.String("email"), result.OutputType)
+	assert.Equal(t, &models.EmailConfig{
+		Host:     "smtp.example.com",
+		Port:     aws.Int(587),
+		UserName: "panther",
+		Password: "",
+		From:     "alerts@example.com",
+		To:       []string{"soc@example.com"},
+	}, result.OutputConfig.Email)
+}
+
+func TestAddOutputMissingRequiredFields(t *testing.T) {
+	configs := map[string]*models.OutputConfig{
+		"email":      {Email: &models.EmailConfig{Host: "smtp.example.com", From: "alerts@example.com"}},
+		"splunk":     {Splunk: &models.SplunkConfig{HecURL: "https://splunk.example.com:8088"}},
+		"servicenow": {ServiceNow: &models.ServiceNowConfig{InstanceURL: "https://dev.service-now.com", UserName: "admin"}},
+		"googlechat": {GoogleChat: &models.GoogleChatConfig{}},
+	}
+
+	for outputType, config := range configs {
+		mockOutputTable := &mockOutputTable{}
+		outputsTable = mockOutputTable
+		mockOutputTable.On("GetOutputByName", aws.String(outputType)).Return(nil, nil)
+
+		result, err := (API{}).AddOutput(&models.AddOutputInput{
+			UserID:       aws.String("userId"),
+			DisplayName:  aws.String(outputType),
+			OutputConfig: config,
+		})
+		assert.Nil(t, result, outputType)
+		assert.Error(t, err, outputType)
+	}
+}
+
+func TestAddOutputServiceNowRedacted(t *testing.T) {
+	mockEncryptionKey := &mockEncryptionKey{}
+	encryptionKey = mockEncryptionKey
+	mockOutputTable := &mockOutputTable{}
+	outputsTable = mockOutputTable
+
+	mockOutputTable.On("GetOutputByName", aws.String("my-servicenow")).Return(nil, nil)
+	mockEncryptionKey.On("EncryptConfig", mock.MatchedBy(func(config *models.OutputConfig) bool {
+		// secrets are encrypted before they are redacted from the response
+		return config.ServiceNow.Password == "password"
+	})).Return(make([]byte, 1), nil)
+	mockOutputTable.On("PutOutput", mock.Anything).Return(nil)
+
+	input := &models.AddOutputInput{
+		UserID:      aws.String("userId"),
+		DisplayName: aws.String("my-servicenow"),
+		OutputConfig: &models.OutputConfig{
+			ServiceNow: &models.ServiceNowConfig{
+				InstanceURL: "https://dev.service-now.com",
+				UserName:    "admin",
+				Password:    "password",
+			},
+		},
+	}
+
+	result, err := (API{}).AddOutput(input)
+	require.NoError(t, err)
+	assert.Equal(t, aws.String("servicenow"), result.OutputType)
+	assert.Equal(t, "", result.OutputConfig.ServiceNow.Password)
+	mockEncryptionKey.AssertExpectations(t)
+}
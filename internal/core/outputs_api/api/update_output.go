@@ -64,6 +64,17 @@ func (API) UpdateOutput(input *models.UpdateOutputInput) (*models.UpdateOutputOu
 		}
 	}
 
+	// Digests are only supported by some output types, so check against the stored type
+	if input.DigestConfig.Enabled() {
+		storedOutput, err := outputsTable.GetOutput(input.OutputID)
+		if err != nil {
+			return nil, err
+		}
+		if err = validateDigestByType(input.DigestConfig, storedOutput.OutputType); err != nil {
+			return nil, &genericapi.InvalidInputError{Message: err.Error()}
+		}
+	}
+
 	alertOutput := &models.AlertOutput{
 		DisplayName:        input.DisplayName,
 		LastModifiedBy:     input.UserID,
@@ -71,6 +82,7 @@ func (API) UpdateOutput(input *models.UpdateOutputInput) (*models.UpdateOutputOu
 		OutputID:           input.OutputID,
 		OutputConfig:       newConfig,
 		DefaultForSeverity: input.DefaultForSeverity,
+		DigestConfig:       input.DigestConfig,
 	}
 
 	alertOutputItem, err := AlertOutputToItem(alertOutput)
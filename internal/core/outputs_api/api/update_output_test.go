@@ -128,3 +128,85 @@ func TestUpdateSameOutputOutput(t *testing.T) {
 
 	mockOutputsTable.AssertExpectations(t)
 }
+
+func TestUpdateOutputDigestUnsupported(t *testing.T) {
+	mockOutputsTable := &mockOutputTable{}
+	outputsTable = mockOutputsTable
+
+	input := &models.UpdateOutputInput{
+		OutputID:     aws.String("outputId"),
+		DisplayName:  aws.String("displayName"),
+		UserID:       aws.String("userId"),
+		DigestConfig: &models.DigestConfig{WindowMinutes: 60},
+	}
+
+	mockOutputsTable.On("GetOutputByName", aws.String("displayName")).Return(nil, nil)
+	mockOutputsTable.On("GetOutput", aws.String("outputId")).Return(
+		&table.AlertOutputItem{OutputID: aws.String("outputId"), OutputType: aws.String("jira")}, nil)
+
+	result, err := (API{}).UpdateOutput(input)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	mockOutputsTable.AssertExpectations(t)
+}
+
+func TestMergeConfigsKeepsRedactedSecrets(t *testing.T) {
+	oldConfig := &models.OutputConfig{
+		Email: &models.EmailConfig{
+			Host:     "smtp.example.com",
+			Port:     aws.Int(587),
+			UserName: "panther",
+			Password: "secret",
+			From:     "alerts@example.com",
+			To:       []string{"soc@example.com"},
+		},
+	}
+	// The password was redacted when the output was read, so it is empty in the update
+	newConfig := &models.OutputConfig{
+		Email: &models.EmailConfig{
+			Host: "smtp.example.com",
+			Port: aws.Int(465),
+			From: "alerts@example.com",
+			To:   []string{"soc@example.com", "oncall@example.com"},
+		},
+	}
+
+	result, err := mergeConfigs(oldConfig, newConfig)
+	require.NoError(t, err)
+	assert.Equal(t, &models.OutputConfig{
+		Email: &models.EmailConfig{
+			Host:     "smtp.example.com",
+			Port:     aws.Int(465),
+			UserName: "panther",
+			Password: "secret",
+			From:     "alerts@example.com",
+			To:       []string{"soc@example.com", "oncall@example.com"},
+		},
+	}, result)
+}
+
+func TestMergeConfigsKeepsOmittedFields(t *testing.T) {
+	oldConfig := &models.OutputConfig{
+		Email: &models.EmailConfig{
+			Host: "smtp.example.com",
+			Port: aws.Int(465),
+			From: "alerts@example.com",
+			To:   []string{"soc@example.com"},
+		},
+	}
+	// Only the sender is updated, the port and recipients are left out
+	newConfig := &models.OutputConfig{
+		Email: &models.EmailConfig{From: "panther@example.com", To: []string{}},
+	}
+
+	result, err := mergeConfigs(oldConfig, newConfig)
+	require.NoError(t, err)
+	assert.Equal(t, &models.OutputConfig{
+		Email: &models.EmailConfig{
+			Host: "smtp.example.com",
+			Port: aws.Int(465),
+			From: "panther@example.com",
+			To:   []string{"soc@example.com"},
+		},
+	}, result)
+}
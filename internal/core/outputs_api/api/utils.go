@@ -42,6 +42,7 @@ func AlertOutputToItem(input *models.AlertOutput) (*table.AlertOutputItem, error
 		OutputID:           input.OutputID,
 		OutputType:         input.OutputType,
 		DefaultForSeverity: input.DefaultForSeverity,
+		DigestConfig:       input.DigestConfig,
 	}
 
 	if input.OutputConfig != nil {
@@ -66,6 +67,7 @@ func ItemToAlertOutput(input *table.AlertOutputItem) (alertOutput *models.AlertO
 		OutputID:           input.OutputID,
 		OutputType:         input.OutputType,
 		DefaultForSeverity: input.DefaultForSeverity,
+		DigestConfig:       input.DigestConfig,
 	}
 
 	// Decrypt the output before returning to the caller
@@ -103,6 +105,18 @@ func redactOutput(outputConfig *models.OutputConfig) {
 	if outputConfig.CustomWebhook != nil {
 		outputConfig.CustomWebhook.WebhookURL = redacted
 	}
+	if outputConfig.Email != nil {
+		outputConfig.Email.Password = redacted
+	}
+	if outputConfig.Splunk != nil {
+		outputConfig.Splunk.Token = redacted
+	}
+	if outputConfig.ServiceNow != nil {
+		outputConfig.ServiceNow.Password = redacted
+	}
+	if outputConfig.GoogleChat != nil {
+		outputConfig.GoogleChat.WebhookURL = redacted
+	}
 }
 
 func getOutputType(outputConfig *models.OutputConfig) (*string, error) {
@@ -136,13 +150,27 @@ func getOutputType(outputConfig *models.OutputConfig) (*string, error) {
 	if outputConfig.CustomWebhook != nil {
 		return aws.String("customwebhook"), nil
 	}
+	if outputConfig.Email != nil {
+		return aws.String("email"), nil
+	}
+	if outputConfig.Splunk != nil {
+		return aws.String("splunk"), nil
+	}
+	if outputConfig.ServiceNow != nil {
+		return aws.String("servicenow"), nil
+	}
+	if outputConfig.GoogleChat != nil {
+		return aws.String("googlechat"), nil
+	}
 
 	return nil, errors.New("no valid output configuration specified for alert output")
 }
 
 // mergeConfigs combines an old config with a new config based on the following rules:
-// 1. For every value in the new config, use it
+// 1. For every value set in the new config, use it
 // 2. For every value in the old config, keep it if it is not overwritten by the new config
+//
+// Null, empty strings and empty lists are not set: they are what redacted or omitted fields look like.
 func mergeConfigs(oldConfig, newConfig *models.OutputConfig) (*models.OutputConfig, error) {
 	// Convert the old config into bytes so we can merge it with the new config
 	oldBytes, err := jsoniter.Marshal(oldConfig)
@@ -152,7 +180,7 @@ func mergeConfigs(oldConfig, newConfig *models.OutputConfig) (*models.OutputConf
 		}
 	}
 	// Turn the bytes into a map so we can work with it more easily
-	var oldMap map[string]map[string]string
+	var oldMap map[string]map[string]interface{}
 	err = jsoniter.Unmarshal(oldBytes, &oldMap)
 	if err != nil {
 		return nil, &genericapi.InternalError{
@@ -167,7 +195,7 @@ func mergeConfigs(oldConfig, newConfig *models.OutputConfig) (*models.OutputConf
 			Message: "Unable to extract the new configuration",
 		}
 	}
-	var newMap map[string]map[string]string
+	var newMap map[string]map[string]interface{}
 	err = jsoniter.Unmarshal(newBytes, &newMap)
 	if err != nil {
 		return nil, &genericapi.InternalError{
@@ -178,7 +206,7 @@ func mergeConfigs(oldConfig, newConfig *models.OutputConfig) (*models.OutputConf
 	// Overwrite the existing configurations with the new configurations
 	for configType, configMap := range newMap {
 		for configKey, configValue := range configMap {
-			if configValue == "" {
+			if !isConfigValueSet(configValue) {
 				continue
 			}
 			oldMap[configType][configKey] = configValue
@@ -248,7 +276,53 @@ func validateConfigByType(config *models.OutputConfig, outputType *string) error
 		if config.CustomWebhook.WebhookURL != "" {
 			return nil
 		}
+	case "email":
+		// The SMTP credentials are optional, some relays only allow trusted networks
+		if config.Email.Host != "" && config.Email.From != "" && len(config.Email.To) != 0 {
+			return nil
+		}
+	case "splunk":
+		if config.Splunk.HecURL != "" && config.Splunk.Token != "" {
+			return nil
+		}
+	case "servicenow":
+		if config.ServiceNow.InstanceURL != "" && config.ServiceNow.UserName != "" && config.ServiceNow.Password != "" {
+			return nil
+		}
+	case "googlechat":
+		if config.GoogleChat.WebhookURL != "" {
+			return nil
+		}
 	}
 
 	return errors.New("invalid output configuration specified for alert output, missing required fields")
 }
+
+// Output types which know how to deliver a summary of many alerts at once
+var digestOutputTypes = map[string]bool{
+	"slack":         true,
+	"msteams":       true,
+	"sns":           true,
+	"customwebhook": true,
+}
+
+func validateDigestByType(digest *models.DigestConfig, outputType *string) error {
+	if !digest.Enabled() || digestOutputTypes[*outputType] {
+		return nil
+	}
+	return errors.New("alert digests are not supported for output type " + *outputType)
+}
+
+// isConfigValueSet returns false for the JSON values which do not overwrite an existing config value.
+func isConfigValueSet(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case string:
+		return v != ""
+	case []interface{}:
+		return len(v) > 0
+	default:
+		return true
+	}
+}
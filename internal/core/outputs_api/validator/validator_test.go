@@ -87,3 +87,67 @@ func TestAddNonSnsArn(t *testing.T) {
 	require.Error(t, err)
 	assert.Equal(t, expectedMsg("AddOutputInput.OutputConfig.Sns", "TopicArn", "snsArn"), err.Error())
 }
+
+func TestAddEmailInvalidRecipient(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	err = validator.Struct(&models.AddOutputInput{
+		UserID:      aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName: aws.String("myemail"),
+		OutputConfig: &models.OutputConfig{
+			Email: &models.EmailConfig{
+				Host: "smtp.example.com",
+				From: "alerts@example.com",
+				To:   []string{"not-an-email"},
+			},
+		},
+	})
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddOutputInput.OutputConfig.Email", "To[0]", "email"), err.Error())
+}
+
+func TestAddEmailInvalidPort(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	err = validator.Struct(&models.AddOutputInput{
+		UserID:      aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName: aws.String("myemail"),
+		OutputConfig: &models.OutputConfig{
+			Email: &models.EmailConfig{Host: "smtp.example.com", Port: aws.Int(70000)},
+		},
+	})
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddOutputInput.OutputConfig.Email", "Port", "max"), err.Error())
+}
+
+func TestAddSplunkInvalidToken(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	err = validator.Struct(&models.AddOutputInput{
+		UserID:      aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+		DisplayName: aws.String("mysplunk"),
+		OutputConfig: &models.OutputConfig{
+			Splunk: &models.SplunkConfig{HecURL: "https://splunk.example.com:8088", Token: "token"},
+		},
+	})
+	require.Error(t, err)
+	assert.Equal(t, expectedMsg("AddOutputInput.OutputConfig.Splunk", "Token", "uuid"), err.Error())
+}
+
+func TestAddNewOutputsValid(t *testing.T) {
+	validator, err := Validator()
+	require.NoError(t, err)
+	configs := []*models.OutputConfig{
+		{Email: &models.EmailConfig{Host: "10.0.0.1", Port: aws.Int(25), From: "alerts@example.com", To: []string{"soc@example.com"}}},
+		{Splunk: &models.SplunkConfig{HecURL: "https://splunk.example.com:8088", Token: "3a2f5ae1-1b0e-4d3c-9c1a-2e1f6f7c8d9e"}},
+		{ServiceNow: &models.ServiceNowConfig{InstanceURL: "https://dev.service-now.com", UserName: "admin", Password: "pw"}},
+		{GoogleChat: &models.GoogleChatConfig{WebhookURL: "https://chat.googleapis.com/v1/spaces/AAAA/messages"}},
+	}
+	for _, config := range configs {
+		assert.NoError(t, validator.Struct(&models.AddOutputInput{
+			UserID:       aws.String("3601990c-b566-404b-b367-3c6eacd6fe60"),
+			DisplayName:  aws.String("myoutput"),
+			OutputConfig: config,
+		}))
+	}
+}
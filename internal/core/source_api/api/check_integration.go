@@ -26,12 +26,14 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/organizations"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"go.uber.org/zap"
 
 	"github.com/panther-labs/panther/api/lambda/source/models"
+	gcppoller "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/gcp"
 	"github.com/panther-labs/panther/pkg/genericapi"
 )
 
@@ -44,6 +46,7 @@ const (
 
 var (
 	evaluateIntegrationFunc       = evaluateIntegration
+	checkGCPCredentialsFunc       = gcppoller.CheckCredentials
 	checkIntegrationInternalError = &genericapi.InternalError{Message: "Failed to validate source. Please try again later"}
 )
 
@@ -53,10 +56,14 @@ func (api API) CheckIntegration(input *models.CheckIntegrationInput) (*models.So
 	switch input.IntegrationType {
 	case models.IntegrationTypeAWSScan:
 		return checkAwsScanIntegration(input), nil
+	case models.IntegrationTypeAWSOrganization:
+		return checkAwsOrganizationIntegration(input), nil
 	case models.IntegrationTypeAWS3:
 		return checkAwsS3Integration(input), nil
 	case models.IntegrationTypeSqs:
 		return checkSqsQueueHealth(input), nil
+	case models.IntegrationTypeGCPScan:
+		return checkGCPScanIntegration(input), nil
 	default:
 		return nil, checkIntegrationInternalError
 	}
@@ -82,6 +89,45 @@ func checkAwsScanIntegration(input *models.CheckIntegrationInput) *models.Source
 	return out
 }
 
+func checkAwsOrganizationIntegration(input *models.CheckIntegrationInput) *models.SourceIntegrationHealth {
+	// The management account is scanned like any other account, so it needs the same roles
+	out := checkAwsScanIntegration(input)
+	out.IntegrationType = input.IntegrationType
+
+	// The audit role (via the SecurityAudit policy) must be able to enumerate the organization
+	if !out.AuditRoleStatus.Healthy {
+		out.OrganizationStatus = models.SourceIntegrationItemStatus{
+			Healthy:      false,
+			ErrorMessage: "cannot assume audit role",
+		}
+		return out
+	}
+	out.OrganizationStatus = checkOrganization(auditRoleCredentials(input.AWSAccountID), input.AWSAccountID)
+	return out
+}
+
+func checkOrganization(roleCredentials *credentials.Credentials, accountID string) models.SourceIntegrationItemStatus {
+	output, err := newOrganizationsClient(roleCredentials).DescribeOrganization(&organizations.DescribeOrganizationInput{})
+	if err != nil {
+		return models.SourceIntegrationItemStatus{
+			Healthy:      false,
+			ErrorMessage: err.Error(),
+		}
+	}
+
+	// Only the management account can list the accounts in an organization
+	if managementAccountID := aws.StringValue(output.Organization.MasterAccountId); managementAccountID != accountID {
+		return models.SourceIntegrationItemStatus{
+			Healthy:      false,
+			ErrorMessage: fmt.Sprintf("%s is not the management account (%s)", accountID, managementAccountID),
+		}
+	}
+
+	return models.SourceIntegrationItemStatus{
+		Healthy: true,
+	}
+}
+
 func checkAwsS3Integration(input *models.CheckIntegrationInput) *models.SourceIntegrationHealth {
 	out := &models.SourceIntegrationHealth{
 		IntegrationType: input.IntegrationType,
@@ -185,6 +231,23 @@ func evaluateIntegration(api API, integration *models.CheckIntegrationInput) (st
 			return "cannot assume remediation role", false, nil
 		}
 
+		if aws.BoolValue(integration.EnableCWESetup) && !status.CWERoleStatus.Healthy {
+			return "cannot assume cwe role", false, nil
+		}
+		return "", true, nil
+	case models.IntegrationTypeAWSOrganization:
+		if !status.AuditRoleStatus.Healthy {
+			return "cannot assume audit role", false, nil
+		}
+
+		if !status.OrganizationStatus.Healthy {
+			return "cannot list organization accounts: " + status.OrganizationStatus.ErrorMessage, false, nil
+		}
+
+		if aws.BoolValue(integration.EnableRemediation) && !status.RemediationRoleStatus.Healthy {
+			return "cannot assume remediation role", false, nil
+		}
+
 		if aws.BoolValue(integration.EnableCWESetup) && !status.CWERoleStatus.Healthy {
 			return "cannot assume cwe role", false, nil
 		}
@@ -207,12 +270,43 @@ func evaluateIntegration(api API, integration *models.CheckIntegrationInput) (st
 			return status.SqsStatus.ErrorMessage, false, nil
 		}
 		return "", true, nil
+	case models.IntegrationTypeGCPScan:
+		if !status.GCPCredentialsStatus.Healthy {
+			return "cannot read GCP project: " + status.GCPCredentialsStatus.ErrorMessage, false, nil
+		}
+		return "", true, nil
 
 	default:
 		return "", false, errors.New("invalid integration type")
 	}
 }
 
+// Check that the service account key can read the GCP project
+func checkGCPScanIntegration(input *models.CheckIntegrationInput) *models.SourceIntegrationHealth {
+	health := &models.SourceIntegrationHealth{
+		IntegrationType: input.IntegrationType,
+	}
+
+	if input.GCPProjectID == "" || input.GCPServiceAccountKey == "" {
+		health.GCPCredentialsStatus = models.SourceIntegrationItemStatus{
+			Healthy:      false,
+			ErrorMessage: "a GCP project ID and service account key are required",
+		}
+		return health
+	}
+
+	if err := checkGCPCredentialsFunc(input.GCPProjectID, []byte(input.GCPServiceAccountKey)); err != nil {
+		health.GCPCredentialsStatus = models.SourceIntegrationItemStatus{
+			Healthy:      false,
+			ErrorMessage: err.Error(),
+		}
+		return health
+	}
+
+	health.GCPCredentialsStatus.Healthy = true
+	return health
+}
+
 // Check the health of the SQS source
 func checkSqsQueueHealth(input *models.CheckIntegrationInput) *models.SourceIntegrationHealth {
 	health := &models.SourceIntegrationHealth{
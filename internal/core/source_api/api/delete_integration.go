@@ -49,6 +49,39 @@ func (API) DeleteIntegration(input *models.DeleteIntegrationInput) error {
 	}
 
 	switch integrationItem.IntegrationType {
+	case models.IntegrationTypeAWSScan:
+		if integrationItem.ParentIntegrationID != "" {
+			parent, err := dynamoClient.GetItem(integrationItem.ParentIntegrationID)
+			if err != nil {
+				zap.L().Error("failed to get parent integration", zap.Error(err))
+				return deleteIntegrationInternalError
+			}
+			// The next sync would just onboard the account again
+			if parent != nil {
+				return &genericapi.InvalidInputError{
+					Message: "Account is managed by an organization source, exclude its organizational unit instead",
+				}
+			}
+		}
+	case models.IntegrationTypeAWSOrganization:
+		existingIntegrations, err := dynamoClient.ScanIntegrations(aws.String(models.IntegrationTypeAWSScan))
+		if err != nil {
+			zap.L().Error("failed to scan integration", zap.Error(err))
+			return deleteIntegrationInternalError
+		}
+
+		// Offboard every member account along with the organization
+		for _, existingIntegration := range existingIntegrations {
+			if existingIntegration.ParentIntegrationID != integrationItem.IntegrationID {
+				continue
+			}
+			if err = dynamoClient.DeleteItem(existingIntegration.IntegrationID); err != nil {
+				zap.L().Error("failed to delete member account integration",
+					zap.String("integrationId", existingIntegration.IntegrationID),
+					zap.Error(err))
+				return deleteIntegrationInternalError
+			}
+		}
 	case models.IntegrationTypeAWS3:
 		existingIntegrations, err := dynamoClient.ScanIntegrations(aws.String(models.IntegrationTypeAWS3))
 		if err != nil {
@@ -89,6 +122,13 @@ func (API) DeleteIntegration(input *models.DeleteIntegrationInput) error {
 				zap.Error(err))
 			return deleteIntegrationInternalError
 		}
+	case models.IntegrationTypeGCPScan:
+		if err := DeleteGCPServiceAccountKey(input.IntegrationID); err != nil {
+			zap.L().Error("failed to delete GCP service account key",
+				zap.String("integrationId", input.IntegrationID),
+				zap.Error(err))
+			return deleteIntegrationInternalError
+		}
 	}
 
 	err = dynamoClient.DeleteItem(input.IntegrationID)
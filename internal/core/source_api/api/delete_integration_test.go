@@ -26,6 +26,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
@@ -58,6 +59,50 @@ func TestDeleteIntegrationItem(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestDeleteGCPScanIntegration(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	dynamoClient = &ddb.DDB{Client: mockClient, TableName: "test"}
+	mockSecrets := &testutils.SecretsManagerMock{}
+	secretsClient = mockSecrets
+
+	mockClient.On("DeleteItem", mock.Anything).Return(&dynamodb.DeleteItemOutput{}, nil)
+	mockClient.On("GetItem", mock.Anything).
+		Return(generateGetItemOutput(models.IntegrationTypeGCPScan), nil)
+	mockSecrets.On("DeleteSecret", &secretsmanager.DeleteSecretInput{
+		SecretId:                   aws.String("panther-gcp-scan/" + testIntegrationID),
+		ForceDeleteWithoutRecovery: aws.Bool(true),
+	}).Return(&secretsmanager.DeleteSecretOutput{}, nil)
+
+	result := apiTest.DeleteIntegration(&models.DeleteIntegrationInput{
+		IntegrationID: testIntegrationID,
+	})
+
+	assert.NoError(t, result)
+	mockClient.AssertExpectations(t)
+	mockSecrets.AssertExpectations(t)
+}
+
+func TestDeleteGCPScanIntegrationSecretAlreadyDeleted(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	dynamoClient = &ddb.DDB{Client: mockClient, TableName: "test"}
+	mockSecrets := &testutils.SecretsManagerMock{}
+	secretsClient = mockSecrets
+
+	mockClient.On("DeleteItem", mock.Anything).Return(&dynamodb.DeleteItemOutput{}, nil)
+	mockClient.On("GetItem", mock.Anything).
+		Return(generateGetItemOutput(models.IntegrationTypeGCPScan), nil)
+	mockSecrets.On("DeleteSecret", mock.Anything).Return(&secretsmanager.DeleteSecretOutput{},
+		awserr.New(secretsmanager.ErrCodeResourceNotFoundException, "not found", nil))
+
+	result := apiTest.DeleteIntegration(&models.DeleteIntegrationInput{
+		IntegrationID: testIntegrationID,
+	})
+
+	assert.NoError(t, result)
+	mockClient.AssertExpectations(t)
+	mockSecrets.AssertExpectations(t)
+}
+
 func TestDeleteLogIntegration(t *testing.T) {
 	mockClient := &testutils.DynamoDBMock{}
 	dynamoClient = &ddb.DDB{Client: mockClient, TableName: "test"}
@@ -0,0 +1,82 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+)
+
+// Stores the service account key of a new gcp-scan integration
+func CreateGCPServiceAccountKey(integrationID, serviceAccountKey string) error {
+	secretName := models.GCPCredentialsSecretName(integrationID)
+	zap.L().Debug("creating GCP service account key secret", zap.String("name", secretName))
+	_, err := secretsClient.CreateSecret(&secretsmanager.CreateSecretInput{
+		Name:         &secretName,
+		Description:  aws.String("Service account key used by Panther to scan a GCP project"),
+		SecretString: &serviceAccountKey,
+	})
+	return errors.Wrapf(err, "failed to create secret %s", secretName)
+}
+
+// Replaces the service account key of a gcp-scan integration
+func UpdateGCPServiceAccountKey(integrationID, serviceAccountKey string) error {
+	secretName := models.GCPCredentialsSecretName(integrationID)
+	_, err := secretsClient.PutSecretValue(&secretsmanager.PutSecretValueInput{
+		SecretId:     &secretName,
+		SecretString: &serviceAccountKey,
+	})
+	return errors.Wrapf(err, "failed to update secret %s", secretName)
+}
+
+// Returns the service account key of a gcp-scan integration
+func GetGCPServiceAccountKey(integrationID string) (string, error) {
+	secretName := models.GCPCredentialsSecretName(integrationID)
+	output, err := secretsClient.GetSecretValue(&secretsmanager.GetSecretValueInput{
+		SecretId: &secretName,
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get secret %s", secretName)
+	}
+	return aws.StringValue(output.SecretString), nil
+}
+
+// Deletes the service account key of a gcp-scan integration
+func DeleteGCPServiceAccountKey(integrationID string) error {
+	secretName := models.GCPCredentialsSecretName(integrationID)
+	_, err := secretsClient.DeleteSecret(&secretsmanager.DeleteSecretInput{
+		SecretId: &secretName,
+		// The key is useless once the integration is gone and a recovery window would block
+		// onboarding the project again under the same secret name
+		ForceDeleteWithoutRecovery: aws.Bool(true),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == secretsmanager.ErrCodeResourceNotFoundException {
+			zap.L().Warn("GCP service account key secret was already deleted", zap.String("name", secretName))
+			return nil
+		}
+		return errors.Wrapf(err, "failed to delete secret %s", secretName)
+	}
+	return nil
+}
@@ -162,7 +162,7 @@ func getTemplate(integrationType string) (string, error) {
 }
 
 func getStackName(integrationType string, label string) string {
-	if integrationType == models.IntegrationTypeAWSScan {
+	if integrationType == models.IntegrationTypeAWSScan || integrationType == models.IntegrationTypeAWSOrganization {
 		return CloudSecStackName
 	}
 	return fmt.Sprintf(LogAnalysisStackNameTemplate, normalizedLabel(label))
@@ -33,6 +33,8 @@ import (
 	"github.com/panther-labs/panther/api/lambda/source/models"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
 	awspoller "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws"
+	gcppoller "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/gcp"
+	"github.com/panther-labs/panther/internal/core/source_api/ddb"
 	"github.com/panther-labs/panther/pkg/awsbatch/sqsbatch"
 	"github.com/panther-labs/panther/pkg/genericapi"
 )
@@ -75,13 +77,36 @@ func (api API) PutIntegration(input *models.PutIntegrationInput) (newIntegration
 		return nil, err
 	}
 
+	// The service account key is kept out of DynamoDB, only the pollers can read it
+	if input.IntegrationType == models.IntegrationTypeGCPScan {
+		if err = CreateGCPServiceAccountKey(newIntegration.IntegrationID, input.GCPServiceAccountKey); err != nil {
+			return nil, putIntegrationInternalError
+		}
+	}
+
 	// Write to DynamoDB
 	if err = dynamoClient.PutItem(item); err != nil {
 		err = errors.Wrap(err, "Failed to store source integration in DDB")
+		if input.IntegrationType == models.IntegrationTypeGCPScan {
+			// The integration was not created, don't leave its secret behind
+			if deleteErr := DeleteGCPServiceAccountKey(newIntegration.IntegrationID); deleteErr != nil {
+				zap.L().Error("failed to clean up GCP service account key", zap.Error(deleteErr))
+			}
+		}
 		return nil, putIntegrationInternalError
 	}
 
-	if input.IntegrationType == models.IntegrationTypeAWSScan {
+	if input.IntegrationType == models.IntegrationTypeAWSOrganization {
+		// Onboard the member accounts now rather than waiting for the next scheduled sync.
+		// Sync failures are recorded in the scan status of the new integration.
+		if _, err = api.syncOrganizationItems([]*ddb.Integration{item}); err != nil {
+			err = errors.Wrap(err, "failed to sync organization accounts")
+			return nil, putIntegrationInternalError
+		}
+		newIntegration = itemToIntegration(item)
+	}
+
+	if input.IntegrationType == models.IntegrationTypeAWSScan || input.IntegrationType == models.IntegrationTypeGCPScan {
 		err = api.FullScan(&models.FullScanInput{Integrations: []*models.SourceIntegrationMetadata{&newIntegration.SourceIntegrationMetadata}})
 		if err != nil {
 			err = errors.Wrap(err, "failed to trigger scanning of resources")
@@ -125,6 +150,9 @@ func (api API) validateIntegration(input *models.PutIntegrationInput) error {
 		S3Prefix:          input.S3Prefix,
 		KmsKey:            input.KmsKey,
 		SqsConfig:         input.SqsConfig,
+
+		GCPProjectID:         input.GCPProjectID,
+		GCPServiceAccountKey: input.GCPServiceAccountKey,
 	})
 	if err != nil {
 		return putIntegrationInternalError
@@ -161,6 +189,14 @@ func (api API) integrationAlreadyExists(input *models.PutIntegrationInput) error
 					}
 				}
 				return nil
+			case models.IntegrationTypeAWSOrganization:
+				if existingIntegration.AWSAccountID == input.AWSAccountID {
+					// We can only have one organization integration for each management account
+					return &genericapi.InvalidInputError{
+						Message: fmt.Sprintf("Organization with management account %s already onboarded",
+							input.AWSAccountID),
+					}
+				}
 			case models.IntegrationTypeAWS3:
 				if existingIntegration.AWSAccountID == input.AWSAccountID &&
 					existingIntegration.IntegrationLabel == input.IntegrationLabel {
@@ -178,6 +214,13 @@ func (api API) integrationAlreadyExists(input *models.PutIntegrationInput) error
 						Message: fmt.Sprintf("Integration with label %s already exists", input.IntegrationLabel),
 					}
 				}
+			case models.IntegrationTypeGCPScan:
+				if existingIntegration.GCPProjectID == input.GCPProjectID {
+					// Like AWS accounts, each project is scanned by a single integration
+					return &genericapi.InvalidInputError{
+						Message: fmt.Sprintf("GCP project %s already onboarded", input.GCPProjectID),
+					}
+				}
 			}
 		}
 	}
@@ -193,15 +236,18 @@ func (api API) FullScan(input *models.FullScanInput) error {
 
 	// For each integration, add a ScanMsg to the queue per service
 	for _, integration := range input.Integrations {
-		for resourceType := range awspoller.ServicePollers {
+		for _, resourceType := range scanResourceTypes(integration) {
+			entry := &pollermodels.ScanEntry{
+				IntegrationID: &integration.IntegrationID,
+				ResourceType:  aws.String(resourceType),
+			}
+			if integration.IntegrationType == models.IntegrationTypeGCPScan {
+				entry.GCPProjectID = &integration.GCPProjectID
+			} else {
+				entry.AWSAccountID = &integration.AWSAccountID
+			}
 			scanMsg := &pollermodels.ScanMsg{
-				Entries: []*pollermodels.ScanEntry{
-					{
-						AWSAccountID:  &integration.AWSAccountID,
-						IntegrationID: &integration.IntegrationID,
-						ResourceType:  aws.String(resourceType),
-					},
-				},
+				Entries: []*pollermodels.ScanEntry{entry},
 			}
 
 			messageBodyBytes, err := jsoniter.MarshalToString(scanMsg)
@@ -233,6 +279,21 @@ func (api API) FullScan(input *models.FullScanInput) error {
 	return err
 }
 
+// scanResourceTypes returns the resource types polled in a full scan of an integration.
+func scanResourceTypes(integration *models.SourceIntegrationMetadata) []string {
+	var resourceTypes []string
+	if integration.IntegrationType == models.IntegrationTypeGCPScan {
+		for resourceType := range gcppoller.ServicePollers {
+			resourceTypes = append(resourceTypes, resourceType)
+		}
+		return resourceTypes
+	}
+	for resourceType := range awspoller.ServicePollers {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	return resourceTypes
+}
+
 func generateNewIntegration(input *models.PutIntegrationInput) *models.SourceIntegration {
 	metadata := models.SourceIntegrationMetadata{
 		CreatedAtTime:    time.Now(),
@@ -249,6 +310,13 @@ func generateNewIntegration(input *models.PutIntegrationInput) *models.SourceInt
 		metadata.RemediationEnabled = input.RemediationEnabled
 		metadata.ScanIntervalMins = input.ScanIntervalMins
 		metadata.StackName = getStackName(input.IntegrationType, input.IntegrationLabel)
+	case models.IntegrationTypeAWSOrganization:
+		metadata.AWSAccountID = input.AWSAccountID
+		metadata.CWEEnabled = input.CWEEnabled
+		metadata.RemediationEnabled = input.RemediationEnabled
+		metadata.ScanIntervalMins = input.ScanIntervalMins
+		metadata.StackName = getStackName(input.IntegrationType, input.IntegrationLabel)
+		metadata.OrganizationConfig = input.OrganizationConfig
 	case models.IntegrationTypeAWS3:
 		metadata.AWSAccountID = input.AWSAccountID
 		metadata.S3Bucket = input.S3Bucket
@@ -267,6 +335,9 @@ func generateNewIntegration(input *models.PutIntegrationInput) *models.SourceInt
 			LogTypes:             input.SqsConfig.LogTypes,
 			QueueURL:             SourceSqsQueueURL(metadata.IntegrationID),
 		}
+	case models.IntegrationTypeGCPScan:
+		metadata.GCPProjectID = input.GCPProjectID
+		metadata.ScanIntervalMins = input.ScanIntervalMins
 	}
 	return &models.SourceIntegration{
 		SourceIntegrationMetadata: metadata,
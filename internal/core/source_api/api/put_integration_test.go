@@ -29,6 +29,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/glue"
 	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
@@ -38,12 +39,17 @@ import (
 	"github.com/panther-labs/panther/api/lambda/source/models"
 	pollermodels "github.com/panther-labs/panther/internal/compliance/snapshot_poller/models/poller"
 	awspoller "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/aws"
+	gcppoller "github.com/panther-labs/panther/internal/compliance/snapshot_poller/pollers/gcp"
 	"github.com/panther-labs/panther/internal/core/source_api/ddb"
 	"github.com/panther-labs/panther/internal/core/source_api/ddb/modelstest"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
 	"github.com/panther-labs/panther/pkg/testutils"
 )
 
+// Athena views created for the log tables: all_logs, all_rule_matches and one per data model
+var numViews = 2 + len(logtypes.Models)
+
 func generateMockSQSBatchInputOutput(integration models.SourceIntegrationMetadata) (
 	*sqs.SendMessageBatchInput, *sqs.SendMessageBatchOutput, error) {
 
@@ -287,7 +293,7 @@ func TestPutLogIntegrationUpdateSqsQueuePermissions(t *testing.T) {
 	mockGlue.On("GetTable", mock.Anything).Return(&glue.GetTableOutput{}, nil).Times(len(registry.AvailableLogTypes()))
 	mockAthena.On("StartQueryExecution", mock.Anything).Return(&athena.StartQueryExecutionOutput{
 		QueryExecutionId: aws.String("test-query-1234"),
-	}, nil).Twice()
+	}, nil).Times(numViews)
 	mockAthena.On("GetQueryExecution", mock.Anything).Return(&athena.GetQueryExecutionOutput{
 		QueryExecution: &athena.QueryExecution{
 			QueryExecutionId: aws.String("test-query-1234"),
@@ -295,8 +301,8 @@ func TestPutLogIntegrationUpdateSqsQueuePermissions(t *testing.T) {
 				State: aws.String(athena.QueryExecutionStateSucceeded),
 			},
 		},
-	}, nil).Twice()
-	mockAthena.On("GetQueryResults", mock.Anything).Return(&athena.GetQueryResultsOutput{}, nil).Twice()
+	}, nil).Times(numViews)
+	mockAthena.On("GetQueryResults", mock.Anything).Return(&athena.GetQueryResultsOutput{}, nil).Times(numViews)
 
 	out, err := apiTest.PutIntegration(&models.PutIntegrationInput{
 		PutIntegrationSettings: models.PutIntegrationSettings{
@@ -332,7 +338,7 @@ func TestPutLogIntegrationUpdateSqsQueuePermissionsFailure(t *testing.T) {
 	mockGlue.On("GetTable", mock.Anything).Return(&glue.GetTableOutput{}, nil).Times(len(registry.AvailableTables()))
 	mockAthena.On("StartQueryExecution", mock.Anything).Return(&athena.StartQueryExecutionOutput{
 		QueryExecutionId: aws.String("test-query-1234"),
-	}, nil).Twice()
+	}, nil).Times(numViews)
 	mockAthena.On("GetQueryExecution", mock.Anything).Return(&athena.GetQueryExecutionOutput{
 		QueryExecution: &athena.QueryExecution{
 			QueryExecutionId: aws.String("test-query-1234"),
@@ -340,8 +346,8 @@ func TestPutLogIntegrationUpdateSqsQueuePermissionsFailure(t *testing.T) {
 				State: aws.String(athena.QueryExecutionStateSucceeded),
 			},
 		},
-	}, nil).Twice()
-	mockAthena.On("GetQueryResults", mock.Anything).Return(&athena.GetQueryResultsOutput{}, nil).Twice()
+	}, nil).Times(numViews)
+	mockAthena.On("GetQueryResults", mock.Anything).Return(&athena.GetQueryResultsOutput{}, nil).Times(numViews)
 
 	out, err := apiTest.PutIntegration(&models.PutIntegrationInput{
 		PutIntegrationSettings: models.PutIntegrationSettings{
@@ -397,7 +403,7 @@ func TestPutSqsIntegration(t *testing.T) {
 	mockGlue.On("GetTable", mock.Anything).Return(&glue.GetTableOutput{}, nil).Times(len(registry.AvailableLogTypes()))
 	mockAthena.On("StartQueryExecution", mock.Anything).Return(&athena.StartQueryExecutionOutput{
 		QueryExecutionId: aws.String("test-query-1234"),
-	}, nil).Twice()
+	}, nil).Times(numViews)
 	mockAthena.On("GetQueryExecution", mock.Anything).Return(&athena.GetQueryExecutionOutput{
 		QueryExecution: &athena.QueryExecution{
 			QueryExecutionId: aws.String("test-query-1234"),
@@ -405,8 +411,8 @@ func TestPutSqsIntegration(t *testing.T) {
 				State: aws.String(athena.QueryExecutionStateSucceeded),
 			},
 		},
-	}, nil).Twice()
-	mockAthena.On("GetQueryResults", mock.Anything).Return(&athena.GetQueryResultsOutput{}, nil).Twice()
+	}, nil).Times(numViews)
+	mockAthena.On("GetQueryResults", mock.Anything).Return(&athena.GetQueryResultsOutput{}, nil).Times(numViews)
 
 	mockLambda.On("CreateEventSourceMapping", mock.Anything).Return(&lambda.EventSourceMappingConfiguration{}, nil)
 
@@ -448,3 +454,111 @@ func TestPutSqsIntegration(t *testing.T) {
 	mockAthena.AssertExpectations(t)
 	mockLambda.AssertExpectations(t)
 }
+
+func TestPutGCPScanIntegration(t *testing.T) {
+	env.SnapshotPollersQueueURL = "test-url"
+	mockSQS := &testutils.SqsMock{}
+	mockSQS.On("SendMessageBatch", mock.Anything).Return(&sqs.SendMessageBatchOutput{}, nil)
+	sqsClient = mockSQS
+	mockSecrets := &testutils.SecretsManagerMock{}
+	secretsClient = mockSecrets
+	dynamoClient = &ddb.DDB{Client: &modelstest.MockDDBClient{TestErr: false}, TableName: "test"}
+	var checkInput *models.CheckIntegrationInput
+	evaluateIntegrationFunc = func(_ API, input *models.CheckIntegrationInput) (string, bool, error) {
+		checkInput = input
+		return "", true, nil
+	}
+
+	mockSecrets.On("CreateSecret", mock.Anything).Return(&secretsmanager.CreateSecretOutput{}, nil).Once()
+
+	out, err := apiTest.PutIntegration(&models.PutIntegrationInput{
+		PutIntegrationSettings: models.PutIntegrationSettings{
+			IntegrationLabel:     "ProdGCP",
+			IntegrationType:      models.IntegrationTypeGCPScan,
+			ScanIntervalMins:     60,
+			UserID:               testUserID,
+			GCPProjectID:         "example-project",
+			GCPServiceAccountKey: `{"type": "service_account"}`,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "example-project", out.GCPProjectID)
+	assert.Empty(t, out.AWSAccountID)
+	assert.Equal(t, `{"type": "service_account"}`, checkInput.GCPServiceAccountKey)
+
+	createInput := mockSecrets.Calls[0].Arguments.Get(0).(*secretsmanager.CreateSecretInput)
+	assert.Equal(t, "panther-gcp-scan/"+out.IntegrationID, *createInput.Name)
+	assert.Equal(t, `{"type": "service_account"}`, *createInput.SecretString)
+
+	// The initial scan requests one scan per GCP resource type
+	batch := mockSQS.Calls[0].Arguments.Get(0).(*sqs.SendMessageBatchInput)
+	require.Len(t, batch.Entries, len(gcppoller.ServicePollers))
+	scanMsg := &pollermodels.ScanMsg{}
+	require.NoError(t, jsoniter.UnmarshalFromString(*batch.Entries[0].MessageBody, scanMsg))
+	assert.Equal(t, aws.String("example-project"), scanMsg.Entries[0].GCPProjectID)
+	assert.Nil(t, scanMsg.Entries[0].AWSAccountID)
+	mockSQS.AssertExpectations(t)
+	mockSecrets.AssertExpectations(t)
+}
+
+func TestPutGCPScanIntegrationDeletesSecretOnFailure(t *testing.T) {
+	mockSecrets := &testutils.SecretsManagerMock{}
+	secretsClient = mockSecrets
+	mockDDB := &testutils.DynamoDBMock{}
+	dynamoClient = &ddb.DDB{Client: mockDDB, TableName: "test"}
+	evaluateIntegrationFunc = func(_ API, _ *models.CheckIntegrationInput) (string, bool, error) { return "", true, nil }
+
+	mockDDB.On("Scan", mock.Anything).Return(&dynamodb.ScanOutput{}, nil).Once()
+	mockDDB.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, errors.New("throttled")).Once()
+	mockSecrets.On("CreateSecret", mock.Anything).Return(&secretsmanager.CreateSecretOutput{}, nil).Once()
+	mockSecrets.On("DeleteSecret", mock.Anything).Return(&secretsmanager.DeleteSecretOutput{}, nil).Once()
+
+	out, err := apiTest.PutIntegration(&models.PutIntegrationInput{
+		PutIntegrationSettings: models.PutIntegrationSettings{
+			IntegrationLabel:     "ProdGCP",
+			IntegrationType:      models.IntegrationTypeGCPScan,
+			ScanIntervalMins:     60,
+			UserID:               testUserID,
+			GCPProjectID:         "example-project",
+			GCPServiceAccountKey: `{"type": "service_account"}`,
+		},
+	})
+	assert.Error(t, err)
+	assert.Nil(t, out)
+
+	// The secret of the integration which was not stored is deleted
+	createInput := mockSecrets.Calls[0].Arguments.Get(0).(*secretsmanager.CreateSecretInput)
+	deleteInput := mockSecrets.Calls[1].Arguments.Get(0).(*secretsmanager.DeleteSecretInput)
+	assert.Equal(t, *createInput.Name, *deleteInput.SecretId)
+	mockDDB.AssertExpectations(t)
+	mockSecrets.AssertExpectations(t)
+}
+
+func TestPutGCPScanIntegrationExists(t *testing.T) {
+	evaluateIntegrationFunc = func(_ API, _ *models.CheckIntegrationInput) (string, bool, error) { return "", true, nil }
+
+	dynamoClient = &ddb.DDB{
+		Client: &modelstest.MockDDBClient{
+			MockScanAttributes: []map[string]*dynamodb.AttributeValue{
+				{
+					"gcpProjectId":    {S: aws.String("example-project")},
+					"integrationType": {S: aws.String(models.IntegrationTypeGCPScan)},
+				},
+			},
+			TestErr: false,
+		},
+		TableName: "test",
+	}
+
+	out, err := apiTest.PutIntegration(&models.PutIntegrationInput{
+		PutIntegrationSettings: models.PutIntegrationSettings{
+			IntegrationLabel: "ProdGCP",
+			IntegrationType:  models.IntegrationTypeGCPScan,
+			UserID:           testUserID,
+			GCPProjectID:     "example-project",
+		},
+	})
+	require.Error(t, err)
+	require.Empty(t, out)
+	assert.Equal(t, "GCP project example-project already onboarded", err.Error())
+}
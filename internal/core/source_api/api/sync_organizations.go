@@ -0,0 +1,380 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/core/source_api/ddb"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+// Child integration labels are derived from the account name and must pass label validation
+const maxChildLabelLength = 32
+
+var (
+	syncOrganizationsInternalError = &genericapi.InternalError{Message: "Failed to sync organizations"}
+
+	childLabelUnsafe = regexp.MustCompile(`[^0-9a-zA-Z- ]+`)
+
+	// Overridden in unit tests
+	newOrganizationsClient = func(roleCredentials *credentials.Credentials) organizationsiface.OrganizationsAPI {
+		return organizations.New(awsSession, &aws.Config{Credentials: roleCredentials})
+	}
+)
+
+// organizationAccount is an active member account and its position in the organization
+type organizationAccount struct {
+	ID     string
+	Name   string
+	OUID   string // ID of the root or OU directly above the account
+	OUPath string // OU names from the root down, e.g. "Root/Production/Web"
+
+	// IDs of the root and every OU above the account, used for include/exclude filtering
+	ancestors []string
+}
+
+// SyncOrganizations creates and removes the aws-scan integrations managed by organization integrations.
+//
+// Each member account selected by the organization's OU filters gets its own aws-scan integration,
+// which inherits the scan settings of the organization. Accounts which leave the organization
+// (or are no longer selected) have their integration removed.
+func (api API) SyncOrganizations(input *models.SyncOrganizationsInput) ([]*models.OrganizationSyncResult, error) {
+	var organizationItems []*ddb.Integration
+	if input.IntegrationID != "" {
+		item, err := dynamoClient.GetItem(input.IntegrationID)
+		if err != nil {
+			zap.L().Error("failed to get organization integration", zap.Error(err))
+			return nil, syncOrganizationsInternalError
+		}
+		if item == nil || item.IntegrationType != models.IntegrationTypeAWSOrganization {
+			return nil, &genericapi.DoesNotExistError{Message: "Organization integration does not exist"}
+		}
+		organizationItems = append(organizationItems, item)
+	} else {
+		items, err := dynamoClient.ScanIntegrations(aws.String(models.IntegrationTypeAWSOrganization))
+		if err != nil {
+			zap.L().Error("failed to scan organization integrations", zap.Error(err))
+			return nil, syncOrganizationsInternalError
+		}
+		organizationItems = items
+	}
+
+	return api.syncOrganizationItems(organizationItems)
+}
+
+// syncOrganizationItems syncs each organization, recording failures in the organization's scan status.
+func (api API) syncOrganizationItems(organizationItems []*ddb.Integration) ([]*models.OrganizationSyncResult, error) {
+	if len(organizationItems) == 0 {
+		return []*models.OrganizationSyncResult{}, nil
+	}
+
+	scanItems, err := dynamoClient.ScanIntegrations(aws.String(models.IntegrationTypeAWSScan))
+	if err != nil {
+		zap.L().Error("failed to scan aws-scan integrations", zap.Error(err))
+		return nil, syncOrganizationsInternalError
+	}
+
+	results := make([]*models.OrganizationSyncResult, 0, len(organizationItems))
+	for _, item := range organizationItems {
+		result, err := api.syncOrganization(item, scanItems)
+		if err != nil {
+			zap.L().Error("failed to sync organization",
+				zap.String("integrationId", item.IntegrationID),
+				zap.Error(err))
+			result.ErrorMessage = err.Error()
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (api API) syncOrganization(
+	organization *ddb.Integration, scanItems []*ddb.Integration) (*models.OrganizationSyncResult, error) {
+
+	result := &models.OrganizationSyncResult{IntegrationID: organization.IntegrationID}
+	startTime := time.Now()
+	organization.LastScanStartTime = &startTime
+
+	client := newOrganizationsClient(auditRoleCredentials(organization.AWSAccountID))
+	accounts, err := listOrganizationAccounts(client)
+	if err == nil {
+		err = api.reconcileOrganization(organization, accounts, scanItems, result)
+	}
+
+	// The scan status of the organization integration reflects the last sync
+	endTime := time.Now()
+	organization.LastScanEndTime = &endTime
+	organization.ScanStatus = models.StatusOK
+	organization.LastScanErrorMessage = ""
+	if err != nil {
+		organization.ScanStatus = models.StatusError
+		organization.LastScanErrorMessage = err.Error()
+	}
+	if putErr := dynamoClient.PutItem(organization); putErr != nil && err == nil {
+		err = errors.Wrap(putErr, "failed to update organization integration")
+	}
+	return result, err
+}
+
+func (api API) reconcileOrganization(
+	organization *ddb.Integration,
+	accounts []*organizationAccount,
+	scanItems []*ddb.Integration,
+	result *models.OrganizationSyncResult,
+) error {
+
+	// Existing aws-scan integrations, keyed by account ID
+	children := make(map[string]*ddb.Integration)
+	others := make(map[string]*ddb.Integration)
+	for _, item := range scanItems {
+		if item.ParentIntegrationID == organization.IntegrationID {
+			children[item.AWSAccountID] = item
+		} else {
+			others[item.AWSAccountID] = item
+		}
+	}
+
+	var newIntegrations []*models.SourceIntegrationMetadata
+	for _, account := range accounts {
+		if !includeAccount(account, organization.OrganizationConfig) {
+			continue
+		}
+
+		if child, ok := children[account.ID]; ok {
+			delete(children, account.ID)
+			if !updateChildIntegration(child, organization, account) {
+				continue
+			}
+			if err := dynamoClient.PutItem(child); err != nil {
+				return errors.Wrapf(err, "failed to update integration for account %s", account.ID)
+			}
+			result.Updated = append(result.Updated, account.ID)
+			continue
+		}
+
+		// Accounts onboarded individually (or by another organization) are left alone
+		if _, ok := others[account.ID]; ok {
+			result.Skipped = append(result.Skipped, account.ID)
+			continue
+		}
+
+		child := newChildIntegration(organization, account)
+		if err := dynamoClient.PutItem(integrationToItem(child)); err != nil {
+			return errors.Wrapf(err, "failed to create integration for account %s", account.ID)
+		}
+		result.Created = append(result.Created, account.ID)
+		newIntegrations = append(newIntegrations, &child.SourceIntegrationMetadata)
+	}
+
+	// Whatever is left has left the organization or is no longer selected by the OU filters
+	for accountID, child := range children {
+		if err := dynamoClient.DeleteItem(child.IntegrationID); err != nil {
+			return errors.Wrapf(err, "failed to remove integration for account %s", accountID)
+		}
+		result.Removed = append(result.Removed, accountID)
+	}
+	sort.Strings(result.Removed)
+
+	zap.L().Info("synced organization",
+		zap.String("integrationId", organization.IntegrationID),
+		zap.Int("created", len(result.Created)),
+		zap.Int("updated", len(result.Updated)),
+		zap.Int("removed", len(result.Removed)),
+		zap.Int("skipped", len(result.Skipped)))
+
+	if len(newIntegrations) == 0 {
+		return nil
+	}
+	return errors.Wrap(api.FullScan(&models.FullScanInput{Integrations: newIntegrations}),
+		"failed to trigger scanning of new accounts")
+}
+
+// listOrganizationAccounts walks the organization tree from each root to find every active account.
+func listOrganizationAccounts(client organizationsiface.OrganizationsAPI) ([]*organizationAccount, error) {
+	var roots []*organizations.Root
+	err := client.ListRootsPages(&organizations.ListRootsInput{},
+		func(page *organizations.ListRootsOutput, lastPage bool) bool {
+			roots = append(roots, page.Roots...)
+			return true
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list organization roots")
+	}
+
+	var accounts []*organizationAccount
+	for _, root := range roots {
+		rootID := aws.StringValue(root.Id)
+		if err = walkOrganizationalUnit(client, rootID, aws.StringValue(root.Name), []string{rootID}, &accounts); err != nil {
+			return nil, err
+		}
+	}
+	return accounts, nil
+}
+
+func walkOrganizationalUnit(
+	client organizationsiface.OrganizationsAPI,
+	parentID, path string,
+	ancestors []string,
+	accounts *[]*organizationAccount,
+) error {
+
+	err := client.ListAccountsForParentPages(&organizations.ListAccountsForParentInput{ParentId: &parentID},
+		func(page *organizations.ListAccountsForParentOutput, lastPage bool) bool {
+			for _, account := range page.Accounts {
+				// Suspended accounts can't be scanned
+				if aws.StringValue(account.Status) != organizations.AccountStatusActive {
+					continue
+				}
+				*accounts = append(*accounts, &organizationAccount{
+					ID:        aws.StringValue(account.Id),
+					Name:      aws.StringValue(account.Name),
+					OUID:      parentID,
+					OUPath:    path,
+					ancestors: ancestors,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list accounts in %s", parentID)
+	}
+
+	var units []*organizations.OrganizationalUnit
+	err = client.ListOrganizationalUnitsForParentPages(
+		&organizations.ListOrganizationalUnitsForParentInput{ParentId: &parentID},
+		func(page *organizations.ListOrganizationalUnitsForParentOutput, lastPage bool) bool {
+			units = append(units, page.OrganizationalUnits...)
+			return true
+		})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list organizational units in %s", parentID)
+	}
+
+	for _, unit := range units {
+		unitID := aws.StringValue(unit.Id)
+		// Copy the ancestors so siblings don't share a backing array
+		unitAncestors := append(append(make([]string, 0, len(ancestors)+1), ancestors...), unitID)
+		unitPath := path + "/" + aws.StringValue(unit.Name)
+		if err := walkOrganizationalUnit(client, unitID, unitPath, unitAncestors, accounts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// includeAccount applies the include and exclude OU filters of an organization integration.
+func includeAccount(account *organizationAccount, config *ddb.OrganizationConfig) bool {
+	if config == nil {
+		return true
+	}
+
+	included := len(config.IncludeOUs) == 0
+	for _, id := range account.ancestors {
+		if containsString(config.ExcludeOUs, id) {
+			return false
+		}
+		if containsString(config.IncludeOUs, id) {
+			included = true
+		}
+	}
+	return included
+}
+
+func newChildIntegration(organization *ddb.Integration, account *organizationAccount) *models.SourceIntegration {
+	label := childIntegrationLabel(account)
+	return &models.SourceIntegration{
+		SourceIntegrationMetadata: models.SourceIntegrationMetadata{
+			AWSAccountID:           account.ID,
+			CreatedAtTime:          time.Now(),
+			CreatedBy:              organization.CreatedBy,
+			CWEEnabled:             organization.CWEEnabled,
+			IntegrationID:          uuid.New().String(),
+			IntegrationLabel:       label,
+			IntegrationType:        models.IntegrationTypeAWSScan,
+			RemediationEnabled:     organization.RemediationEnabled,
+			ScanIntervalMins:       organization.ScanIntervalMins,
+			StackName:              getStackName(models.IntegrationTypeAWSScan, label),
+			ParentIntegrationID:    organization.IntegrationID,
+			OrganizationalUnitID:   account.OUID,
+			OrganizationalUnitPath: account.OUPath,
+		},
+	}
+}
+
+// updateChildIntegration copies the current OU and organization settings to a child integration.
+//
+// Returns true if anything changed.
+func updateChildIntegration(child, organization *ddb.Integration, account *organizationAccount) bool {
+	if child.OrganizationalUnitID == account.OUID &&
+		child.OrganizationalUnitPath == account.OUPath &&
+		child.ScanIntervalMins == organization.ScanIntervalMins &&
+		aws.BoolValue(child.CWEEnabled) == aws.BoolValue(organization.CWEEnabled) &&
+		aws.BoolValue(child.RemediationEnabled) == aws.BoolValue(organization.RemediationEnabled) {
+
+		return false
+	}
+
+	child.OrganizationalUnitID = account.OUID
+	child.OrganizationalUnitPath = account.OUPath
+	child.ScanIntervalMins = organization.ScanIntervalMins
+	child.CWEEnabled = organization.CWEEnabled
+	child.RemediationEnabled = organization.RemediationEnabled
+	return true
+}
+
+// For example, "Prod-Web-1" for the account named "Prod.Web_1"
+func childIntegrationLabel(account *organizationAccount) string {
+	label := strings.Trim(childLabelUnsafe.ReplaceAllString(account.Name, "-"), " -")
+	if len(label) > maxChildLabelLength {
+		label = strings.Trim(label[:maxChildLabelLength], " -")
+	}
+	if label == "" {
+		return account.ID
+	}
+	return label
+}
+
+// auditRoleCredentials assumes the Panther audit role in the given account.
+func auditRoleCredentials(accountID string) *credentials.Credentials {
+	return stscreds.NewCredentials(awsSession, fmt.Sprintf(auditRoleFormat, accountID, *awsSession.Config.Region))
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,246 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/source/models"
+	"github.com/panther-labs/panther/internal/core/source_api/ddb"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+const testOrganizationID = "d3e6b4a1-0c2f-4e8a-9b7d-5a1c3e2f4b6d"
+
+// mockOrganizations serves a fixed organization tree, one page per request
+type mockOrganizations struct {
+	organizationsiface.OrganizationsAPI
+	roots    []*organizations.Root
+	accounts map[string][]*organizations.Account
+	units    map[string][]*organizations.OrganizationalUnit
+}
+
+func (m *mockOrganizations) ListRootsPages(
+	_ *organizations.ListRootsInput, fn func(*organizations.ListRootsOutput, bool) bool) error {
+
+	fn(&organizations.ListRootsOutput{Roots: m.roots}, true)
+	return nil
+}
+
+func (m *mockOrganizations) ListAccountsForParentPages(
+	input *organizations.ListAccountsForParentInput,
+	fn func(*organizations.ListAccountsForParentOutput, bool) bool) error {
+
+	fn(&organizations.ListAccountsForParentOutput{Accounts: m.accounts[*input.ParentId]}, true)
+	return nil
+}
+
+func (m *mockOrganizations) ListOrganizationalUnitsForParentPages(
+	input *organizations.ListOrganizationalUnitsForParentInput,
+	fn func(*organizations.ListOrganizationalUnitsForParentOutput, bool) bool) error {
+
+	fn(&organizations.ListOrganizationalUnitsForParentOutput{OrganizationalUnits: m.units[*input.ParentId]}, true)
+	return nil
+}
+
+func testAccount(id, name, status string) *organizations.Account {
+	return &organizations.Account{Id: aws.String(id), Name: aws.String(name), Status: aws.String(status)}
+}
+
+// The management account in the root, a Production OU (with a suspended account and a nested Web OU)
+// and a Sandbox OU
+var testOrganization = &mockOrganizations{
+	roots: []*organizations.Root{{Id: aws.String("r-ab12"), Name: aws.String("Root")}},
+	accounts: map[string][]*organizations.Account{
+		"r-ab12": {testAccount("111111111111", "Management", organizations.AccountStatusActive)},
+		"ou-ab12-prod1234": {
+			testAccount("222222222222", "Production", organizations.AccountStatusActive),
+			testAccount("333333333333", "Retired", organizations.AccountStatusSuspended),
+		},
+		"ou-ab12-web12345": {testAccount("444444444444", "Web (prod)", organizations.AccountStatusActive)},
+		"ou-ab12-sand1234": {testAccount("555555555555", "Sandbox", organizations.AccountStatusActive)},
+	},
+	units: map[string][]*organizations.OrganizationalUnit{
+		"r-ab12": {
+			{Id: aws.String("ou-ab12-prod1234"), Name: aws.String("Production")},
+			{Id: aws.String("ou-ab12-sand1234"), Name: aws.String("Sandbox")},
+		},
+		"ou-ab12-prod1234": {{Id: aws.String("ou-ab12-web12345"), Name: aws.String("Web")}},
+	},
+}
+
+func TestListOrganizationAccounts(t *testing.T) {
+	accounts, err := listOrganizationAccounts(testOrganization)
+	require.NoError(t, err)
+
+	expected := []*organizationAccount{
+		{
+			ID: "111111111111", Name: "Management", OUID: "r-ab12", OUPath: "Root",
+			ancestors: []string{"r-ab12"},
+		},
+		{
+			ID: "222222222222", Name: "Production", OUID: "ou-ab12-prod1234", OUPath: "Root/Production",
+			ancestors: []string{"r-ab12", "ou-ab12-prod1234"},
+		},
+		{
+			ID: "444444444444", Name: "Web (prod)", OUID: "ou-ab12-web12345", OUPath: "Root/Production/Web",
+			ancestors: []string{"r-ab12", "ou-ab12-prod1234", "ou-ab12-web12345"},
+		},
+		{
+			ID: "555555555555", Name: "Sandbox", OUID: "ou-ab12-sand1234", OUPath: "Root/Sandbox",
+			ancestors: []string{"r-ab12", "ou-ab12-sand1234"},
+		},
+	}
+	assert.Equal(t, expected, accounts)
+}
+
+func TestIncludeAccount(t *testing.T) {
+	web := &organizationAccount{ID: "444444444444", ancestors: []string{"r-ab12", "ou-ab12-prod1234", "ou-ab12-web12345"}}
+	sandbox := &organizationAccount{ID: "555555555555", ancestors: []string{"r-ab12", "ou-ab12-sand1234"}}
+
+	// Everything is included by default
+	assert.True(t, includeAccount(web, nil))
+	assert.True(t, includeAccount(web, &ddb.OrganizationConfig{}))
+
+	// Included OUs apply to nested OUs
+	config := &ddb.OrganizationConfig{IncludeOUs: []string{"ou-ab12-prod1234"}}
+	assert.True(t, includeAccount(web, config))
+	assert.False(t, includeAccount(sandbox, config))
+
+	// Exclusions win
+	config = &ddb.OrganizationConfig{IncludeOUs: []string{"r-ab12"}, ExcludeOUs: []string{"ou-ab12-web12345"}}
+	assert.False(t, includeAccount(web, config))
+	assert.True(t, includeAccount(sandbox, config))
+}
+
+func TestChildIntegrationLabel(t *testing.T) {
+	assert.Equal(t, "Prod-Web-1", childIntegrationLabel(&organizationAccount{Name: "Prod.Web_1"}))
+	assert.Equal(t, "123456789012", childIntegrationLabel(&organizationAccount{ID: "123456789012", Name: "!!"}))
+	assert.Equal(t, "A very long account name which e",
+		childIntegrationLabel(&organizationAccount{Name: "A very long account name which exceeds the limit"}))
+}
+
+func TestReconcileOrganization(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	dynamoClient = &ddb.DDB{Client: mockClient, TableName: "test"}
+	mockClient.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil)
+	mockClient.On("DeleteItem", mock.Anything).Return(&dynamodb.DeleteItemOutput{}, nil)
+
+	env.SnapshotPollersQueueURL = "test-url"
+	mockSQS := &testutils.SqsMock{}
+	mockSQS.On("SendMessageBatch", mock.Anything).Return(&sqs.SendMessageBatchOutput{}, nil)
+	sqsClient = mockSQS
+
+	organization := &ddb.Integration{
+		IntegrationID:    testOrganizationID,
+		IntegrationType:  models.IntegrationTypeAWSOrganization,
+		AWSAccountID:     "111111111111",
+		ScanIntervalMins: 1440,
+		OrganizationConfig: &ddb.OrganizationConfig{
+			ExcludeOUs: []string{"ou-ab12-sand1234"},
+		},
+	}
+	scanItems := []*ddb.Integration{
+		// Onboarded individually before the organization
+		{IntegrationID: "5f0c1a2b-3c4d-4e5f-8a6b-7c8d9e0f1a2b", AWSAccountID: "111111111111"},
+		// Moved from the Web OU to Production
+		{
+			IntegrationID:          "7b1d2e3f-4a5b-4c6d-9e7f-8a9b0c1d2e3f",
+			AWSAccountID:           "222222222222",
+			ParentIntegrationID:    testOrganizationID,
+			OrganizationalUnitID:   "ou-ab12-web12345",
+			OrganizationalUnitPath: "Root/Production/Web",
+			ScanIntervalMins:       1440,
+		},
+		// Left the organization
+		{
+			IntegrationID:       "9d3f4a5b-6c7d-4e8f-a0b1-c2d3e4f5a6b7",
+			AWSAccountID:        "999999999999",
+			ParentIntegrationID: testOrganizationID,
+		},
+	}
+
+	accounts, err := listOrganizationAccounts(testOrganization)
+	require.NoError(t, err)
+	result := &models.OrganizationSyncResult{IntegrationID: testOrganizationID}
+	require.NoError(t, apiTest.reconcileOrganization(organization, accounts, scanItems, result))
+
+	assert.Equal(t, &models.OrganizationSyncResult{
+		IntegrationID: testOrganizationID,
+		Created:       []string{"444444444444"},
+		Updated:       []string{"222222222222"},
+		Removed:       []string{"999999999999"},
+		Skipped:       []string{"111111111111"},
+	}, result)
+	assert.Equal(t, "ou-ab12-prod1234", scanItems[1].OrganizationalUnitID)
+	assert.Equal(t, "Root/Production", scanItems[1].OrganizationalUnitPath)
+
+	mockClient.AssertNumberOfCalls(t, "PutItem", 2)
+	mockClient.AssertNumberOfCalls(t, "DeleteItem", 1)
+	mockSQS.AssertExpectations(t)
+}
+
+func TestSyncOrganizationsRecordsErrors(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	dynamoClient = &ddb.DDB{Client: mockClient, TableName: "test"}
+	mockClient.On("Scan", mock.Anything).Return(&dynamodb.ScanOutput{}, nil)
+	mockClient.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil)
+
+	awsSession = session.Must(session.NewSession(&aws.Config{Region: aws.String("us-west-2")}))
+	defaultClient := newOrganizationsClient
+	defer func() { newOrganizationsClient = defaultClient }()
+	newOrganizationsClient = func(*credentials.Credentials) organizationsiface.OrganizationsAPI {
+		return &failingOrganizations{}
+	}
+
+	organization := &ddb.Integration{
+		IntegrationID:   testOrganizationID,
+		IntegrationType: models.IntegrationTypeAWSOrganization,
+		AWSAccountID:    "111111111111",
+	}
+	results, err := apiTest.syncOrganizationItems([]*ddb.Integration{organization})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "failed to list organization roots: AccessDeniedException", results[0].ErrorMessage)
+	assert.Equal(t, models.StatusError, organization.ScanStatus)
+	assert.Equal(t, results[0].ErrorMessage, organization.LastScanErrorMessage)
+	mockClient.AssertExpectations(t)
+}
+
+type failingOrganizations struct {
+	organizationsiface.OrganizationsAPI
+}
+
+func (*failingOrganizations) ListRootsPages(
+	*organizations.ListRootsInput, func(*organizations.ListRootsOutput, bool) bool) error {
+
+	return errors.New("AccessDeniedException")
+}
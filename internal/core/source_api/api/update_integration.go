@@ -43,11 +43,21 @@ func (api API) UpdateIntegrationSettings(input *models.UpdateIntegrationSettings
 		return nil, err
 	}
 
+	// The service account key is only sent when it is being replaced
+	gcpServiceAccountKey := input.GCPServiceAccountKey
+	if existingIntegrationItem.IntegrationType == models.IntegrationTypeGCPScan && gcpServiceAccountKey == "" {
+		if gcpServiceAccountKey, err = GetGCPServiceAccountKey(existingIntegrationItem.IntegrationID); err != nil {
+			zap.L().Error("failed to load GCP service account key", zap.Error(err))
+			return nil, updateIntegrationInternalError
+		}
+	}
+
 	// Validate the updated existingIntegrationItem settings
 	reason, passing, err := evaluateIntegrationFunc(api, &models.CheckIntegrationInput{
 		// From existing existingIntegrationItem
 		AWSAccountID:    existingIntegrationItem.AWSAccountID,
 		IntegrationType: existingIntegrationItem.IntegrationType,
+		GCPProjectID:    existingIntegrationItem.GCPProjectID,
 
 		// From update existingIntegrationItem request
 		IntegrationLabel:  input.IntegrationLabel,
@@ -57,6 +67,8 @@ func (api API) UpdateIntegrationSettings(input *models.UpdateIntegrationSettings
 		S3Prefix:          input.S3Prefix,
 		KmsKey:            input.KmsKey,
 		SqsConfig:         input.SqsConfig,
+
+		GCPServiceAccountKey: gcpServiceAccountKey,
 	})
 	if err != nil {
 		return nil, err
@@ -84,6 +96,13 @@ func (api API) UpdateIntegrationSettings(input *models.UpdateIntegrationSettings
 		return nil, updateIntegrationInternalError
 	}
 
+	if existingIntegrationItem.IntegrationType == models.IntegrationTypeAWSOrganization {
+		// Apply the new settings and OU filters to the member accounts
+		if _, err := api.syncOrganizationItems([]*ddb.Integration{existingIntegrationItem}); err != nil {
+			return nil, updateIntegrationInternalError
+		}
+	}
+
 	existingIntegration := itemToIntegration(existingIntegrationItem)
 
 	return existingIntegration, nil
@@ -96,6 +115,17 @@ func normalizeIntegration(item *ddb.Integration, input *models.UpdateIntegration
 		item.ScanIntervalMins = input.ScanIntervalMins
 		item.CWEEnabled = input.CWEEnabled
 		item.RemediationEnabled = input.RemediationEnabled
+	case models.IntegrationTypeAWSOrganization:
+		item.IntegrationLabel = input.IntegrationLabel
+		item.ScanIntervalMins = input.ScanIntervalMins
+		item.CWEEnabled = input.CWEEnabled
+		item.RemediationEnabled = input.RemediationEnabled
+		if input.OrganizationConfig != nil {
+			item.OrganizationConfig = &ddb.OrganizationConfig{
+				IncludeOUs: input.OrganizationConfig.IncludeOUs,
+				ExcludeOUs: input.OrganizationConfig.ExcludeOUs,
+			}
+		}
 	case models.IntegrationTypeAWS3:
 		item.S3Bucket = input.S3Bucket
 		item.S3Prefix = input.S3Prefix
@@ -112,6 +142,15 @@ func normalizeIntegration(item *ddb.Integration, input *models.UpdateIntegration
 		if err := UpdateSourceSqsQueue(item.IntegrationID, newAllowedPrincipals, newAllowedSources); err != nil {
 			return updateIntegrationInternalError
 		}
+	case models.IntegrationTypeGCPScan:
+		item.IntegrationLabel = input.IntegrationLabel
+		item.ScanIntervalMins = input.ScanIntervalMins
+		if input.GCPServiceAccountKey != "" {
+			if err := UpdateGCPServiceAccountKey(item.IntegrationID, input.GCPServiceAccountKey); err != nil {
+				zap.L().Error("failed to rotate GCP service account key", zap.Error(err))
+				return updateIntegrationInternalError
+			}
+		}
 	}
 	return nil
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/athena"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -67,6 +68,58 @@ func TestUpdateIntegrationSettingsAwsScanType(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestUpdateIntegrationSettingsGCPScanType(t *testing.T) {
+	mockClient := &testutils.DynamoDBMock{}
+	dynamoClient = &ddb.DDB{Client: mockClient, TableName: "test"}
+	mockSecrets := &testutils.SecretsManagerMock{}
+	secretsClient = mockSecrets
+	var checkInput *models.CheckIntegrationInput
+	evaluateIntegrationFunc = func(_ API, input *models.CheckIntegrationInput) (string, bool, error) {
+		checkInput = input
+		return "", true, nil
+	}
+
+	getResponse := &dynamodb.GetItemOutput{Item: map[string]*dynamodb.AttributeValue{
+		"integrationId":   {S: aws.String(testIntegrationID)},
+		"integrationType": {S: aws.String(models.IntegrationTypeGCPScan)},
+		"gcpProjectId":    {S: aws.String("example-project")},
+	}}
+	mockClient.On("GetItem", mock.Anything).Return(getResponse, nil)
+	mockClient.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil)
+	// Without a new key, the stored key is checked against the project
+	mockSecrets.On("GetSecretValue", &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String("panther-gcp-scan/" + testIntegrationID),
+	}).Return(&secretsmanager.GetSecretValueOutput{SecretString: aws.String("stored-key")}, nil).Once()
+
+	result, err := apiTest.UpdateIntegrationSettings(&models.UpdateIntegrationSettingsInput{
+		IntegrationID:    testIntegrationID,
+		IntegrationLabel: "new-label",
+		ScanIntervalMins: 1440,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "example-project", result.GCPProjectID)
+	assert.Equal(t, "new-label", result.IntegrationLabel)
+	assert.Equal(t, "stored-key", checkInput.GCPServiceAccountKey)
+	assert.Equal(t, "example-project", checkInput.GCPProjectID)
+
+	// A new key is checked and replaces the stored one
+	mockSecrets.On("PutSecretValue", &secretsmanager.PutSecretValueInput{
+		SecretId:     aws.String("panther-gcp-scan/" + testIntegrationID),
+		SecretString: aws.String("rotated-key"),
+	}).Return(&secretsmanager.PutSecretValueOutput{}, nil).Once()
+
+	_, err = apiTest.UpdateIntegrationSettings(&models.UpdateIntegrationSettingsInput{
+		IntegrationID:        testIntegrationID,
+		IntegrationLabel:     "new-label",
+		ScanIntervalMins:     1440,
+		GCPServiceAccountKey: "rotated-key",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "rotated-key", checkInput.GCPServiceAccountKey)
+	mockClient.AssertExpectations(t)
+	mockSecrets.AssertExpectations(t)
+}
+
 func TestUpdateIntegrationSettingsAwsS3Type(t *testing.T) {
 	mockClient := &testutils.DynamoDBMock{}
 	dynamoClient = &ddb.DDB{Client: mockClient, TableName: "test"}
@@ -88,7 +141,7 @@ func TestUpdateIntegrationSettingsAwsS3Type(t *testing.T) {
 	mockGlue.On("GetTable", mock.Anything).Return(&glue.GetTableOutput{}, nil).Times(len(registry.AvailableLogTypes()))
 	mockAthena.On("StartQueryExecution", mock.Anything).Return(&athena.StartQueryExecutionOutput{
 		QueryExecutionId: aws.String("test-query-1234"),
-	}, nil).Twice()
+	}, nil).Times(numViews)
 	mockAthena.On("GetQueryExecution", mock.Anything).Return(&athena.GetQueryExecutionOutput{
 		QueryExecution: &athena.QueryExecution{
 			QueryExecutionId: aws.String("test-query-1234"),
@@ -96,8 +149,8 @@ func TestUpdateIntegrationSettingsAwsS3Type(t *testing.T) {
 				State: aws.String(athena.QueryExecutionStateSucceeded),
 			},
 		},
-	}, nil).Twice()
-	mockAthena.On("GetQueryResults", mock.Anything).Return(&athena.GetQueryResultsOutput{}, nil).Twice()
+	}, nil).Times(numViews)
+	mockAthena.On("GetQueryResults", mock.Anything).Return(&athena.GetQueryResultsOutput{}, nil).Times(numViews)
 
 	result, err := apiTest.UpdateIntegrationSettings(&models.UpdateIntegrationSettingsInput{
 		S3Bucket: "test-bucket-1",
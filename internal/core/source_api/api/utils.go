@@ -54,6 +54,24 @@ func integrationToItem(input *models.SourceIntegration) *ddb.Integration {
 		item.LastScanStartTime = input.LastScanStartTime
 		item.LastScanEndTime = input.LastScanEndTime
 		item.StackName = input.StackName
+		item.ParentIntegrationID = input.ParentIntegrationID
+		item.OrganizationalUnitID = input.OrganizationalUnitID
+		item.OrganizationalUnitPath = input.OrganizationalUnitPath
+	case models.IntegrationTypeAWSOrganization:
+		item.AWSAccountID = input.AWSAccountID
+		item.CWEEnabled = input.CWEEnabled
+		item.RemediationEnabled = input.RemediationEnabled
+		item.ScanIntervalMins = input.ScanIntervalMins
+		item.ScanStatus = input.ScanStatus
+		item.LastScanErrorMessage = input.LastScanErrorMessage
+		item.LastScanStartTime = input.LastScanStartTime
+		item.LastScanEndTime = input.LastScanEndTime
+		item.StackName = input.StackName
+		item.OrganizationConfig = &ddb.OrganizationConfig{}
+		if input.OrganizationConfig != nil {
+			item.OrganizationConfig.IncludeOUs = input.OrganizationConfig.IncludeOUs
+			item.OrganizationConfig.ExcludeOUs = input.OrganizationConfig.ExcludeOUs
+		}
 	case models.IntegrationTypeSqs:
 		item.SqsConfig = &ddb.SqsConfig{
 			QueueURL:             input.SqsConfig.QueueURL,
@@ -64,6 +82,13 @@ func integrationToItem(input *models.SourceIntegration) *ddb.Integration {
 			AllowedPrincipalArns: input.SqsConfig.AllowedPrincipalArns,
 			AllowedSourceArns:    input.SqsConfig.AllowedSourceArns,
 		}
+	case models.IntegrationTypeGCPScan:
+		item.GCPProjectID = input.GCPProjectID
+		item.ScanIntervalMins = input.ScanIntervalMins
+		item.ScanStatus = input.ScanStatus
+		item.LastScanErrorMessage = input.LastScanErrorMessage
+		item.LastScanStartTime = input.LastScanStartTime
+		item.LastScanEndTime = input.LastScanEndTime
 	}
 	return item
 }
@@ -98,6 +123,24 @@ func itemToIntegration(item *ddb.Integration) *models.SourceIntegration {
 		integration.LastScanEndTime = item.LastScanEndTime
 		integration.LastScanErrorMessage = item.LastScanErrorMessage
 		integration.StackName = item.StackName
+		integration.ParentIntegrationID = item.ParentIntegrationID
+		integration.OrganizationalUnitID = item.OrganizationalUnitID
+		integration.OrganizationalUnitPath = item.OrganizationalUnitPath
+	case models.IntegrationTypeAWSOrganization:
+		integration.AWSAccountID = item.AWSAccountID
+		integration.CWEEnabled = item.CWEEnabled
+		integration.RemediationEnabled = item.RemediationEnabled
+		integration.ScanIntervalMins = item.ScanIntervalMins
+		integration.ScanStatus = item.ScanStatus
+		integration.LastScanStartTime = item.LastScanStartTime
+		integration.LastScanEndTime = item.LastScanEndTime
+		integration.LastScanErrorMessage = item.LastScanErrorMessage
+		integration.StackName = item.StackName
+		integration.OrganizationConfig = &models.OrganizationConfig{}
+		if item.OrganizationConfig != nil {
+			integration.OrganizationConfig.IncludeOUs = item.OrganizationConfig.IncludeOUs
+			integration.OrganizationConfig.ExcludeOUs = item.OrganizationConfig.ExcludeOUs
+		}
 	case models.IntegrationTypeSqs:
 		integration.SqsConfig = &models.SqsConfig{
 			S3Bucket:             item.SqsConfig.S3Bucket,
@@ -108,6 +151,13 @@ func itemToIntegration(item *ddb.Integration) *models.SourceIntegration {
 			AllowedPrincipalArns: item.SqsConfig.AllowedPrincipalArns,
 			AllowedSourceArns:    item.SqsConfig.AllowedSourceArns,
 		}
+	case models.IntegrationTypeGCPScan:
+		integration.GCPProjectID = item.GCPProjectID
+		integration.ScanIntervalMins = item.ScanIntervalMins
+		integration.ScanStatus = item.ScanStatus
+		integration.LastScanStartTime = item.LastScanStartTime
+		integration.LastScanEndTime = item.LastScanEndTime
+		integration.LastScanErrorMessage = item.LastScanErrorMessage
 	}
 	return integration
 }
@@ -32,6 +32,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 	"github.com/kelseyhightower/envconfig"
@@ -54,6 +56,7 @@ var (
 	glueClient       glueiface.GlueAPI
 	athenaClient     athenaiface.AthenaAPI
 	lambdaClient     lambdaiface.LambdaAPI
+	secretsClient    secretsmanageriface.SecretsManagerAPI
 )
 
 type envConfig struct {
@@ -82,6 +85,7 @@ func Setup() {
 	glueClient = glue.New(awsSession)
 	athenaClient = athena.New(awsSession)
 	lambdaClient = lambda.New(awsSession)
+	secretsClient = secretsmanager.New(awsSession)
 }
 
 // API provides receiver methods for each route handler.
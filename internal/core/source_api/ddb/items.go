@@ -46,6 +46,13 @@ type Integration struct {
 	LogProcessingRole string   `json:"logProcessingRole,omitempty"`
 
 	SqsConfig *SqsConfig `json:"sqsConfig,omitempty"`
+
+	OrganizationConfig     *OrganizationConfig `json:"organizationConfig,omitempty"`
+	ParentIntegrationID    string              `json:"parentIntegrationId,omitempty"`
+	OrganizationalUnitID   string              `json:"organizationalUnitId,omitempty"`
+	OrganizationalUnitPath string              `json:"organizationalUnitPath,omitempty"`
+
+	GCPProjectID string `json:"gcpProjectId,omitempty"`
 }
 
 type IntegrationStatus struct {
@@ -63,3 +70,8 @@ type SqsConfig struct {
 	AllowedSourceArns    []string `json:"allowedSourceArns" dynamodbav:",stringset"`
 	QueueURL             string   `json:"queueUrl,omitempty"`
 }
+
+type OrganizationConfig struct {
+	IncludeOUs []string `json:"includeOus" dynamodbav:",stringset"`
+	ExcludeOUs []string `json:"excludeOus" dynamodbav:",stringset"`
+}
@@ -22,23 +22,33 @@ import (
 	"crypto/md5" // nolint(gosec)
 	"encoding/hex"
 	"strconv"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/google/uuid"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/pkg/errors"
+	"go.uber.org/zap"
 
 	ruleModel "github.com/panther-labs/panther/api/gateway/analysis/models"
+	alertApiModel "github.com/panther-labs/panther/api/lambda/alerts/models"
 	alertModel "github.com/panther-labs/panther/internal/core/alert_delivery/models"
 	"github.com/panther-labs/panther/pkg/metrics"
 )
 
-const defaultTimePartition = "defaultPartition"
+const (
+	defaultTimePartition = "defaultPartition"
+
+	// ticketCommentInterval is the minimum time between two event count comments on the tickets of an alert
+	ticketCommentInterval = time.Hour
+)
 
 var (
 	staticLogger = metrics.MustStaticLogger([]metrics.DimensionSet{
@@ -66,6 +76,8 @@ type Handler struct {
 	Cache            *RuleCache
 	DdbClient        dynamodbiface.DynamoDBAPI
 	AlertTable       string
+	ActivityTable    string
+	PendingTable     string
 	AlertingQueueURL string
 }
 
@@ -84,13 +96,18 @@ func (h *Handler) Do(oldAlertDedupEvent, newAlertDedupEvent *AlertDedupEvent) (e
 	}
 
 	if shouldIgnoreChange(newRule, newAlertDedupEvent) {
+		// The dedup group is still below the threshold, keep track of it so users can see how close it is to alerting.
+		// This is informational only and should not fail (and retry) the whole batch.
+		if err = h.storePendingAlert(newRule, newAlertDedupEvent); err != nil {
+			zap.L().Error("failed to store pending alert", zap.String("ruleId", newAlertDedupEvent.RuleID), zap.Error(err))
+		}
 		return nil
 	}
 
 	if needToCreateNewAlert(oldRule, oldAlertDedupEvent, newAlertDedupEvent) {
 		return h.handleNewAlert(newRule, newAlertDedupEvent)
 	}
-	return h.updateExistingAlert(newAlertDedupEvent)
+	return h.updateExistingAlert(newRule, newAlertDedupEvent)
 }
 
 func shouldIgnoreChange(rule *ruleModel.Rule, alertDedupEvent *AlertDedupEvent) bool {
@@ -119,6 +136,13 @@ func (h *Handler) handleNewAlert(rule *ruleModel.Rule, event *AlertDedupEvent) e
 		return errors.Wrap(err, "failed to store new alert in DDB")
 	}
 
+	// If the rule has a threshold, the dedup group was pending until now
+	if rule.Threshold > 1 {
+		if err := h.deletePendingAlert(event); err != nil {
+			zap.L().Error("failed to delete pending alert", zap.String("alertId", generateAlertID(event)), zap.Error(err))
+		}
+	}
+
 	err := h.sendAlertNotification(rule, event)
 	if err == nil {
 		staticLogger.LogSingle(1,
@@ -129,7 +153,7 @@ func (h *Handler) handleNewAlert(rule *ruleModel.Rule, event *AlertDedupEvent) e
 	return err
 }
 
-func (h *Handler) updateExistingAlert(event *AlertDedupEvent) error {
+func (h *Handler) updateExistingAlert(rule *ruleModel.Rule, event *AlertDedupEvent) error {
 	// When updating alert, we need to update only 3 fields
 	// - The number of events included in the alert
 	// - The log types of the events in the alert
@@ -151,15 +175,188 @@ func (h *Handler) updateExistingAlert(event *AlertDedupEvent) error {
 		Key: map[string]*dynamodb.AttributeValue{
 			alertTablePartitionKey: {S: aws.String(generateAlertID(event))},
 		},
+		ReturnValues: aws.String(dynamodb.ReturnValueAllNew),
 	}
 
-	_, err = h.DdbClient.UpdateItem(updateInput)
+	response, err := h.DdbClient.UpdateItem(updateInput)
 	if err != nil {
 		return errors.Wrap(err, "failed to update alert")
 	}
+
+	// The alert is already updated, a missing timeline entry should not fail (and retry) the whole batch
+	if err = h.storeEventCountActivity(event); err != nil {
+		zap.L().Error("failed to store event count activity", zap.String("alertId", generateAlertID(event)), zap.Error(err))
+	}
+
+	// If the alert has tickets, they are updated with the new event count
+	var alertTickets AlertTickets
+	if err = dynamodbattribute.UnmarshalMap(response.Attributes, &alertTickets); err != nil {
+		return errors.Wrap(err, "failed to unmarshal updated alert")
+	}
+	if alertTickets.TicketSync == "" || len(alertTickets.Tickets) == 0 {
+		return nil
+	}
+
+	// Event counts change all the time, comment on the tickets at most once per interval
+	commented, err := h.claimTicketComment(event, alertTickets.TicketCommentTime)
+	if err != nil {
+		return errors.Wrap(err, "failed to claim ticket comment")
+	}
+	if !commented {
+		return nil
+	}
+
+	alertNotification := newAlertNotification(rule, event)
+	alertNotification.Update = &alertModel.AlertUpdate{
+		EventCount: int(event.EventCount),
+		Tickets:    alertTickets.Tickets,
+	}
+	if err = h.sendToQueue(alertNotification); err != nil {
+		// Give the claim back, otherwise the retried update would skip the comment
+		if releaseErr := h.releaseTicketComment(event, alertTickets.TicketCommentTime); releaseErr != nil {
+			zap.L().Error("failed to release ticket comment",
+				zap.String("alertId", generateAlertID(event)), zap.Error(releaseErr))
+		}
+		return err
+	}
+	return nil
+}
+
+// claimTicketComment marks the tickets of an alert as commented on, unless they were commented
+// on less than ticketCommentInterval ago.
+//
+// Returns false if the tickets should not be commented on.
+func (h *Handler) claimTicketComment(event *AlertDedupEvent, lastComment int64) (bool, error) {
+	now := event.UpdateTime.Unix()
+	cutoff := now - int64(ticketCommentInterval/time.Second)
+	if lastComment > cutoff {
+		return false, nil
+	}
+
+	// The condition protects against concurrent updates of the same alert
+	commentTime := expression.Name(alertTableTicketCommentAttribute)
+	condition := expression.AttributeExists(expression.Name(alertTableTicketSyncAttribute)).And(
+		expression.Or(
+			expression.AttributeNotExists(commentTime),
+			expression.LessThanEqual(commentTime, expression.Value(cutoff)),
+		))
+	expr, err := expression.NewBuilder().
+		WithUpdate(expression.Set(commentTime, expression.Value(now))).
+		WithCondition(condition).
+		Build()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to build update expression")
+	}
+
+	_, err = h.DdbClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName:                 &h.AlertTable,
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		Key: map[string]*dynamodb.AttributeValue{
+			alertTablePartitionKey: {S: aws.String(generateAlertID(event))},
+		},
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// releaseTicketComment restores the previous comment time of the tickets of an alert,
+// unless another update has claimed the comment since.
+func (h *Handler) releaseTicketComment(event *AlertDedupEvent, lastComment int64) error {
+	commentTime := expression.Name(alertTableTicketCommentAttribute)
+	update := expression.Remove(commentTime)
+	if lastComment != 0 {
+		update = expression.Set(commentTime, expression.Value(lastComment))
+	}
+	expr, err := expression.NewBuilder().
+		WithUpdate(update).
+		WithCondition(expression.Equal(commentTime, expression.Value(event.UpdateTime.Unix()))).
+		Build()
+	if err != nil {
+		return errors.Wrap(err, "failed to build update expression")
+	}
+
+	_, err = h.DdbClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName:                 &h.AlertTable,
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		Key: map[string]*dynamodb.AttributeValue{
+			alertTablePartitionKey: {S: aws.String(generateAlertID(event))},
+		},
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return nil
+		}
+		return err
+	}
 	return nil
 }
 
+// storeEventCountActivity adds the new event count of an alert to its activity timeline
+func (h *Handler) storeEventCountActivity(event *AlertDedupEvent) error {
+	activity := &alertApiModel.AlertActivity{
+		AlertID:    aws.String(generateAlertID(event)),
+		ActivityID: alertApiModel.NewActivityID(event.UpdateTime, uuid.New().String()),
+		Type:       alertApiModel.EventCountActivity,
+		Time:       event.UpdateTime,
+		EventCount: int(event.EventCount),
+	}
+
+	marshaledActivity, err := dynamodbattribute.MarshalMap(activity)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal activity")
+	}
+	_, err = h.DdbClient.PutItem(&dynamodb.PutItemInput{Item: marshaledActivity, TableName: &h.ActivityTable})
+	return err
+}
+
+// storePendingAlert saves the state of a dedup group which has not reached the rule threshold
+func (h *Handler) storePendingAlert(rule *ruleModel.Rule, event *AlertDedupEvent) error {
+	expiresAt := event.CreationTime.Add(time.Duration(rule.DedupPeriodMinutes) * time.Minute)
+	pendingAlert := &PendingAlert{
+		PendingAlert: alertApiModel.PendingAlert{
+			RuleID:       event.RuleID,
+			DedupString:  event.DeduplicationString,
+			RuleVersion:  event.RuleVersion,
+			EventCount:   event.EventCount,
+			Threshold:    int64(rule.Threshold),
+			CreationTime: event.CreationTime,
+			UpdateTime:   event.UpdateTime,
+			ExpiresAt:    expiresAt,
+		},
+		TTL: expiresAt.Unix(),
+	}
+
+	marshaledPendingAlert, err := dynamodbattribute.MarshalMap(pendingAlert)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal pending alert")
+	}
+	_, err = h.DdbClient.PutItem(&dynamodb.PutItemInput{Item: marshaledPendingAlert, TableName: &h.PendingTable})
+	return err
+}
+
+// deletePendingAlert removes a dedup group which has become an alert
+func (h *Handler) deletePendingAlert(event *AlertDedupEvent) error {
+	_, err := h.DdbClient.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			pendingTablePartitionKey: {S: aws.String(event.RuleID)},
+			pendingTableSortKey:      {S: aws.String(event.DeduplicationString)},
+		},
+		TableName: &h.PendingTable,
+	})
+	return err
+}
+
 func (h *Handler) storeNewAlert(rule *ruleModel.Rule, alertDedup *AlertDedupEvent) error {
 	alert := &Alert{
 		ID:              generateAlertID(alertDedup),
@@ -198,7 +395,11 @@ func (h *Handler) storeNewAlert(rule *ruleModel.Rule, alertDedup *AlertDedupEven
 }
 
 func (h *Handler) sendAlertNotification(rule *ruleModel.Rule, alertDedup *AlertDedupEvent) error {
-	alertNotification := &alertModel.Alert{
+	return h.sendToQueue(newAlertNotification(rule, alertDedup))
+}
+
+func newAlertNotification(rule *ruleModel.Rule, alertDedup *AlertDedupEvent) *alertModel.Alert {
+	return &alertModel.Alert{
 		AlertID:             aws.String(generateAlertID(alertDedup)),
 		AnalysisDescription: aws.String(string(rule.Description)),
 		AnalysisID:          alertDedup.RuleID,
@@ -215,7 +416,9 @@ func (h *Handler) sendAlertNotification(rule *ruleModel.Rule, alertDedup *AlertD
 		Title:        aws.String(getAlertTitle(rule, alertDedup)),
 		Version:      &alertDedup.RuleVersion,
 	}
+}
 
+func (h *Handler) sendToQueue(alertNotification *alertModel.Alert) error {
 	msgBody, err := jsoniter.MarshalToString(alertNotification)
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal alert notification")
@@ -22,11 +22,13 @@ import (
 	"errors"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
@@ -38,6 +40,7 @@ import (
 
 	policiesclient "github.com/panther-labs/panther/api/gateway/analysis/client"
 	ruleModel "github.com/panther-labs/panther/api/gateway/analysis/models"
+	alertApiModel "github.com/panther-labs/panther/api/lambda/alerts/models"
 	alertModel "github.com/panther-labs/panther/internal/core/alert_delivery/models"
 	"github.com/panther-labs/panther/pkg/testutils"
 )
@@ -431,6 +434,7 @@ func TestHandleUpdateAlert(t *testing.T) {
 	policyClient := policiesclient.NewHTTPClientWithConfig(nil, policyConfig)
 	handler := &Handler{
 		AlertTable:       "alertsTable",
+		ActivityTable:    "activityTable",
 		AlertingQueueURL: "queueUrl",
 		Cache:            NewCache(httpClient, policyClient),
 		DdbClient:        ddbMock,
@@ -465,12 +469,296 @@ func TestHandleUpdateAlert(t *testing.T) {
 		UpdateExpression:          expr.Update(),
 		ExpressionAttributeValues: expr.Values(),
 		ExpressionAttributeNames:  expr.Names(),
+		ReturnValues:              aws.String("ALL_NEW"),
 	}
 
 	ddbMock.On("UpdateItem", expectedUpdateItemInput).Return(&dynamodb.UpdateItemOutput{}, nil)
+	ddbMock.On("PutItem", mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+		var activity alertApiModel.AlertActivity
+		require.NoError(t, dynamodbattribute.UnmarshalMap(input.Item, &activity))
+		return *input.TableName == "activityTable" &&
+			*activity.AlertID == "b25dc23fb2a0b362da8428dbec1381a8" &&
+			activity.Type == alertApiModel.EventCountActivity &&
+			activity.EventCount == int(dedupEventWithUpdatedFields.EventCount) &&
+			activity.ActivityID != ""
+	})).Return(&dynamodb.PutItemOutput{}, nil).Once()
 	assert.NoError(t, handler.Do(newAlertDedupEvent, dedupEventWithUpdatedFields))
 
 	ddbMock.AssertExpectations(t)
+	sqsMock.AssertExpectations(t) // no tickets, nothing to send
+}
+
+func TestHandleUpdateAlertWithTickets(t *testing.T) {
+	t.Parallel()
+	ddbMock := &testutils.DynamoDBMock{}
+	sqsMock := &testutils.SqsMock{}
+	mockRoundTripper := &mockRoundTripper{}
+	httpClient := &http.Client{Transport: mockRoundTripper}
+	policyConfig := policiesclient.DefaultTransportConfig().
+		WithHost("host").
+		WithBasePath("path")
+	policyClient := policiesclient.NewHTTPClientWithConfig(nil, policyConfig)
+	handler := &Handler{
+		AlertTable:       "alertsTable",
+		ActivityTable:    "activityTable",
+		AlertingQueueURL: "queueUrl",
+		Cache:            NewCache(httpClient, policyClient),
+		DdbClient:        ddbMock,
+		SqsClient:        sqsMock,
+	}
+	mockRoundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(testRuleResponse, http.StatusOK), nil).Once()
+
+	dedupEventWithUpdatedFields := &AlertDedupEvent{
+		RuleID:              newAlertDedupEvent.RuleID,
+		RuleVersion:         newAlertDedupEvent.RuleVersion,
+		DeduplicationString: newAlertDedupEvent.DeduplicationString,
+		AlertCount:          newAlertDedupEvent.AlertCount,
+		CreationTime:        newAlertDedupEvent.CreationTime,
+		UpdateTime:          newAlertDedupEvent.UpdateTime.Add(1 * time.Minute),
+		EventCount:          newAlertDedupEvent.EventCount + 10,
+		LogTypes:            newAlertDedupEvent.LogTypes,
+		GeneratedTitle:      newAlertDedupEvent.GeneratedTitle,
+	}
+
+	tickets := []*alertApiModel.Ticket{
+		{OutputID: "outputId", OutputType: "jira", Key: "SEC-1", CreatedAt: time.Now().UTC()},
+	}
+	updatedAlert, err := dynamodbattribute.MarshalMap(&AlertTickets{TicketSync: "OPEN", Tickets: tickets})
+	require.NoError(t, err)
+	ddbMock.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{Attributes: updatedAlert}, nil)
+	ddbMock.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil).Once()
+
+	expectedAlertNotification := &alertModel.Alert{
+		CreatedAt:           dedupEventWithUpdatedFields.UpdateTime,
+		AnalysisDescription: aws.String(string(testRuleResponse.Description)),
+		AnalysisID:          dedupEventWithUpdatedFields.RuleID,
+		Version:             aws.String(dedupEventWithUpdatedFields.RuleVersion),
+		AnalysisName:        aws.String(string(testRuleResponse.DisplayName)),
+		Runbook:             aws.String(string(testRuleResponse.Runbook)),
+		Severity:            string(testRuleResponse.Severity),
+		Tags:                []string{"Tag"},
+		Type:                alertModel.RuleType,
+		AlertID:             aws.String("b25dc23fb2a0b362da8428dbec1381a8"),
+		Title:               dedupEventWithUpdatedFields.GeneratedTitle,
+		Update: &alertModel.AlertUpdate{
+			EventCount: int(dedupEventWithUpdatedFields.EventCount),
+			Tickets:    tickets,
+		},
+	}
+	expectedMarshaledAlertNotification, err := jsoniter.MarshalToString(expectedAlertNotification)
+	require.NoError(t, err)
+	expectedSendMessageInput := &sqs.SendMessageInput{
+		MessageBody: &expectedMarshaledAlertNotification,
+		QueueUrl:    aws.String("queueUrl"),
+	}
+	sqsMock.On("SendMessage", expectedSendMessageInput).Return(&sqs.SendMessageOutput{}, nil).Once()
+
+	assert.NoError(t, handler.Do(newAlertDedupEvent, dedupEventWithUpdatedFields))
+	ddbMock.AssertExpectations(t)
+	sqsMock.AssertExpectations(t)
+}
+
+func TestHandleUpdateAlertWithClosedTickets(t *testing.T) {
+	t.Parallel()
+	ddbMock := &testutils.DynamoDBMock{}
+	sqsMock := &testutils.SqsMock{}
+	mockRoundTripper := &mockRoundTripper{}
+	httpClient := &http.Client{Transport: mockRoundTripper}
+	policyConfig := policiesclient.DefaultTransportConfig().
+		WithHost("host").
+		WithBasePath("path")
+	policyClient := policiesclient.NewHTTPClientWithConfig(nil, policyConfig)
+	handler := &Handler{
+		AlertTable:       "alertsTable",
+		ActivityTable:    "activityTable",
+		AlertingQueueURL: "queueUrl",
+		Cache:            NewCache(httpClient, policyClient),
+		DdbClient:        ddbMock,
+		SqsClient:        sqsMock,
+	}
+	mockRoundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(testRuleResponse, http.StatusOK), nil).Once()
+
+	dedupEventWithUpdatedFields := &AlertDedupEvent{
+		RuleID:              newAlertDedupEvent.RuleID,
+		RuleVersion:         newAlertDedupEvent.RuleVersion,
+		DeduplicationString: newAlertDedupEvent.DeduplicationString,
+		AlertCount:          newAlertDedupEvent.AlertCount,
+		UpdateTime:          newAlertDedupEvent.UpdateTime.Add(1 * time.Minute),
+		EventCount:          newAlertDedupEvent.EventCount + 10,
+		LogTypes:            newAlertDedupEvent.LogTypes,
+	}
+
+	// The alert was resolved, so ticketSync was removed
+	updatedAlert, err := dynamodbattribute.MarshalMap(&AlertTickets{
+		Tickets: []*alertApiModel.Ticket{{OutputID: "outputId", OutputType: "github", Key: "1"}},
+	})
+	require.NoError(t, err)
+	delete(updatedAlert, "ticketSync")
+	ddbMock.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{Attributes: updatedAlert}, nil)
+	// Failing to store the activity does not fail the update
+	ddbMock.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, errors.New("error")).Once()
+
+	assert.NoError(t, handler.Do(newAlertDedupEvent, dedupEventWithUpdatedFields))
+	ddbMock.AssertExpectations(t)
+	sqsMock.AssertExpectations(t)
+}
+
+func TestHandleUpdateAlertWithRecentTicketComment(t *testing.T) {
+	t.Parallel()
+	ddbMock := &testutils.DynamoDBMock{}
+	sqsMock := &testutils.SqsMock{}
+	mockRoundTripper := &mockRoundTripper{}
+	httpClient := &http.Client{Transport: mockRoundTripper}
+	policyConfig := policiesclient.DefaultTransportConfig().
+		WithHost("host").
+		WithBasePath("path")
+	policyClient := policiesclient.NewHTTPClientWithConfig(nil, policyConfig)
+	handler := &Handler{
+		AlertTable:       "alertsTable",
+		ActivityTable:    "activityTable",
+		AlertingQueueURL: "queueUrl",
+		Cache:            NewCache(httpClient, policyClient),
+		DdbClient:        ddbMock,
+		SqsClient:        sqsMock,
+	}
+	mockRoundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(testRuleResponse, http.StatusOK), nil).Once()
+
+	dedupEventWithUpdatedFields := &AlertDedupEvent{
+		RuleID:              newAlertDedupEvent.RuleID,
+		RuleVersion:         newAlertDedupEvent.RuleVersion,
+		DeduplicationString: newAlertDedupEvent.DeduplicationString,
+		AlertCount:          newAlertDedupEvent.AlertCount,
+		UpdateTime:          newAlertDedupEvent.UpdateTime.Add(1 * time.Minute),
+		EventCount:          newAlertDedupEvent.EventCount + 10,
+		LogTypes:            newAlertDedupEvent.LogTypes,
+	}
+
+	// The tickets were commented on a few minutes ago, they are not commented on again
+	updatedAlert, err := dynamodbattribute.MarshalMap(&AlertTickets{
+		TicketSync:        "OPEN",
+		Tickets:           []*alertApiModel.Ticket{{OutputID: "outputId", OutputType: "jira", Key: "SEC-1"}},
+		TicketCommentTime: dedupEventWithUpdatedFields.UpdateTime.Add(-5 * time.Minute).Unix(),
+	})
+	require.NoError(t, err)
+	ddbMock.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{Attributes: updatedAlert}, nil).Once()
+	ddbMock.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil).Once()
+
+	assert.NoError(t, handler.Do(newAlertDedupEvent, dedupEventWithUpdatedFields))
+	ddbMock.AssertExpectations(t)
+	sqsMock.AssertExpectations(t) // nothing sent
+}
+
+func TestHandleUpdateAlertTicketCommentConflict(t *testing.T) {
+	t.Parallel()
+	ddbMock := &testutils.DynamoDBMock{}
+	sqsMock := &testutils.SqsMock{}
+	mockRoundTripper := &mockRoundTripper{}
+	httpClient := &http.Client{Transport: mockRoundTripper}
+	policyConfig := policiesclient.DefaultTransportConfig().
+		WithHost("host").
+		WithBasePath("path")
+	policyClient := policiesclient.NewHTTPClientWithConfig(nil, policyConfig)
+	handler := &Handler{
+		AlertTable:       "alertsTable",
+		ActivityTable:    "activityTable",
+		AlertingQueueURL: "queueUrl",
+		Cache:            NewCache(httpClient, policyClient),
+		DdbClient:        ddbMock,
+		SqsClient:        sqsMock,
+	}
+	mockRoundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(testRuleResponse, http.StatusOK), nil).Once()
+
+	dedupEventWithUpdatedFields := &AlertDedupEvent{
+		RuleID:              newAlertDedupEvent.RuleID,
+		RuleVersion:         newAlertDedupEvent.RuleVersion,
+		DeduplicationString: newAlertDedupEvent.DeduplicationString,
+		AlertCount:          newAlertDedupEvent.AlertCount,
+		UpdateTime:          newAlertDedupEvent.UpdateTime.Add(1 * time.Minute),
+		EventCount:          newAlertDedupEvent.EventCount + 10,
+		LogTypes:            newAlertDedupEvent.LogTypes,
+	}
+
+	updatedAlert, err := dynamodbattribute.MarshalMap(&AlertTickets{
+		TicketSync: "OPEN",
+		Tickets:    []*alertApiModel.Ticket{{OutputID: "outputId", OutputType: "jira", Key: "SEC-1"}},
+	})
+	require.NoError(t, err)
+	ddbMock.On("UpdateItem", mock.MatchedBy(func(input *dynamodb.UpdateItemInput) bool {
+		return input.ConditionExpression == nil
+	})).Return(&dynamodb.UpdateItemOutput{Attributes: updatedAlert}, nil).Once()
+	// Another invocation commented on the tickets first
+	ddbMock.On("UpdateItem", mock.MatchedBy(func(input *dynamodb.UpdateItemInput) bool {
+		return input.ConditionExpression != nil
+	})).Return(&dynamodb.UpdateItemOutput{},
+		awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "", nil)).Once()
+	ddbMock.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil).Once()
+
+	assert.NoError(t, handler.Do(newAlertDedupEvent, dedupEventWithUpdatedFields))
+	ddbMock.AssertExpectations(t)
+	sqsMock.AssertExpectations(t) // nothing sent
+}
+
+func TestHandleUpdateAlertTicketCommentSendError(t *testing.T) {
+	t.Parallel()
+	ddbMock := &testutils.DynamoDBMock{}
+	sqsMock := &testutils.SqsMock{}
+	mockRoundTripper := &mockRoundTripper{}
+	httpClient := &http.Client{Transport: mockRoundTripper}
+	policyConfig := policiesclient.DefaultTransportConfig().
+		WithHost("host").
+		WithBasePath("path")
+	policyClient := policiesclient.NewHTTPClientWithConfig(nil, policyConfig)
+	handler := &Handler{
+		AlertTable:       "alertsTable",
+		ActivityTable:    "activityTable",
+		AlertingQueueURL: "queueUrl",
+		Cache:            NewCache(httpClient, policyClient),
+		DdbClient:        ddbMock,
+		SqsClient:        sqsMock,
+	}
+	mockRoundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(testRuleResponse, http.StatusOK), nil).Once()
+
+	dedupEventWithUpdatedFields := &AlertDedupEvent{
+		RuleID:              newAlertDedupEvent.RuleID,
+		RuleVersion:         newAlertDedupEvent.RuleVersion,
+		DeduplicationString: newAlertDedupEvent.DeduplicationString,
+		AlertCount:          newAlertDedupEvent.AlertCount,
+		UpdateTime:          newAlertDedupEvent.UpdateTime.Add(1 * time.Minute),
+		EventCount:          newAlertDedupEvent.EventCount + 10,
+		LogTypes:            newAlertDedupEvent.LogTypes,
+	}
+
+	lastComment := dedupEventWithUpdatedFields.UpdateTime.Add(-time.Hour).Unix()
+	updatedAlert, err := dynamodbattribute.MarshalMap(&AlertTickets{
+		TicketSync:        "OPEN",
+		Tickets:           []*alertApiModel.Ticket{{OutputID: "outputId", OutputType: "jira", Key: "SEC-1"}},
+		TicketCommentTime: lastComment,
+	})
+	require.NoError(t, err)
+	ddbMock.On("UpdateItem", mock.MatchedBy(func(input *dynamodb.UpdateItemInput) bool {
+		return input.ConditionExpression == nil
+	})).Return(&dynamodb.UpdateItemOutput{Attributes: updatedAlert}, nil).Once()
+	ddbMock.On("UpdateItem", mock.MatchedBy(func(input *dynamodb.UpdateItemInput) bool {
+		return input.ConditionExpression != nil && strings.Contains(*input.ConditionExpression, "attribute_exists")
+	})).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+	// The claim is released with the previous comment time when the notification can't be sent
+	ddbMock.On("UpdateItem", mock.MatchedBy(func(input *dynamodb.UpdateItemInput) bool {
+		if input.ConditionExpression == nil || strings.Contains(*input.ConditionExpression, "attribute_exists") {
+			return false
+		}
+		for _, value := range input.ExpressionAttributeValues {
+			if aws.StringValue(value.N) == strconv.FormatInt(lastComment, 10) {
+				return true
+			}
+		}
+		return false
+	})).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+	ddbMock.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil).Once()
+	sqsMock.On("SendMessage", mock.Anything).Return(&sqs.SendMessageOutput{}, errors.New("error")).Once()
+
+	assert.Error(t, handler.Do(newAlertDedupEvent, dedupEventWithUpdatedFields))
+	ddbMock.AssertExpectations(t)
+	sqsMock.AssertExpectations(t)
 }
 
 func TestHandleUpdateAlertDDBError(t *testing.T) {
@@ -520,6 +808,7 @@ func TestHandleShouldNotCreateOrUpdateAlertIfThresholdNotReached(t *testing.T) {
 	policyClient := policiesclient.NewHTTPClientWithConfig(nil, policyConfig)
 	handler := &Handler{
 		AlertTable:       "alertsTable",
+		PendingTable:     "pendingTable",
 		AlertingQueueURL: "queueUrl",
 		Cache:            NewCache(httpClient, policyClient),
 		DdbClient:        ddbMock,
@@ -527,14 +816,39 @@ func TestHandleShouldNotCreateOrUpdateAlertIfThresholdNotReached(t *testing.T) {
 	}
 
 	ruleWithThreshold := &ruleModel.Rule{
-		ID:          "ruleId",
-		Description: "Description",
-		DisplayName: "DisplayName",
-		Severity:    "INFO",
-		Runbook:     "Runbook",
-		Tags:        []string{"Tag"},
-		Threshold:   1000,
+		ID:                 "ruleId",
+		Description:        "Description",
+		DisplayName:        "DisplayName",
+		Severity:           "INFO",
+		Runbook:            "Runbook",
+		Tags:               []string{"Tag"},
+		Threshold:          1000,
+		DedupPeriodMinutes: 60,
+	}
+
+	expectedPendingAlert := &PendingAlert{
+		PendingAlert: alertApiModel.PendingAlert{
+			RuleID:       newAlertDedupEvent.RuleID,
+			DedupString:  newAlertDedupEvent.DeduplicationString,
+			RuleVersion:  newAlertDedupEvent.RuleVersion,
+			EventCount:   newAlertDedupEvent.EventCount,
+			Threshold:    1000,
+			CreationTime: newAlertDedupEvent.CreationTime,
+			UpdateTime:   newAlertDedupEvent.UpdateTime,
+			ExpiresAt:    newAlertDedupEvent.CreationTime.Add(time.Hour),
+		},
+		TTL: newAlertDedupEvent.CreationTime.Add(time.Hour).Unix(),
 	}
+	expectedMarshaledPendingAlert, err := dynamodbattribute.MarshalMap(expectedPendingAlert)
+	require.NoError(t, err)
+	assert.Equal(t, "ruleId", aws.StringValue(expectedMarshaledPendingAlert["ruleId"].S))
+	assert.Equal(t, "dedupString", aws.StringValue(expectedMarshaledPendingAlert["dedup"].S))
+	assert.NotNil(t, expectedMarshaledPendingAlert["ttl"].N)
+
+	ddbMock.On("PutItem", &dynamodb.PutItemInput{
+		Item:      expectedMarshaledPendingAlert,
+		TableName: aws.String("pendingTable"),
+	}).Return(&dynamodb.PutItemOutput{}, nil).Once()
 
 	mockRoundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(ruleWithThreshold, http.StatusOK), nil).Once()
 	assert.NoError(t, handler.Do(oldAlertDedupEvent, newAlertDedupEvent))
@@ -556,6 +870,7 @@ func TestHandleShouldCreateAlertIfThresholdNowReached(t *testing.T) {
 	policyClient := policiesclient.NewHTTPClientWithConfig(nil, policyConfig)
 	handler := &Handler{
 		AlertTable:       "alertsTable",
+		PendingTable:     "pendingTable",
 		AlertingQueueURL: "queueUrl",
 		Cache:            NewCache(httpClient, policyClient),
 		DdbClient:        ddbMock,
@@ -585,6 +900,13 @@ func TestHandleShouldCreateAlertIfThresholdNowReached(t *testing.T) {
 	}
 
 	ddbMock.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil).Once()
+	ddbMock.On("DeleteItem", &dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"ruleId": {S: aws.String(newAlertDedup.RuleID)},
+			"dedup":  {S: aws.String(newAlertDedup.DeduplicationString)},
+		},
+		TableName: aws.String("pendingTable"),
+	}).Return(&dynamodb.DeleteItemOutput{}, nil).Once()
 	sqsMock.On("SendMessage", mock.Anything).Return(&sqs.SendMessageOutput{}, nil).Once()
 
 	mockRoundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(ruleWithThreshold, http.StatusOK), nil).Once()
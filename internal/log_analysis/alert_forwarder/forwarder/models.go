@@ -24,6 +24,8 @@ import (
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/pkg/errors"
+
+	alertApiModel "github.com/panther-labs/panther/api/lambda/alerts/models"
 )
 
 const (
@@ -31,6 +33,12 @@ const (
 	alertTableLogTypesAttribute   = "logTypes"
 	alertTableEventCountAttribute = "eventCount"
 	alertTableUpdateTimeAttribute = "updateTime"
+	alertTableTicketSyncAttribute = "ticketSync"
+	// The time (in unix seconds) the tickets of an alert were last commented on
+	alertTableTicketCommentAttribute = "ticketCommentTime"
+
+	pendingTablePartitionKey = "ruleId"
+	pendingTableSortKey      = "dedup"
 )
 
 // AlertDedupEvent represents the event stored in the alert dedup DDB table by the rules engine
@@ -57,6 +65,23 @@ type Alert struct {
 	AlertDedupEvent
 }
 
+// PendingAlert is a dedup group below the rule threshold, as stored in the pending alerts DDB table
+type PendingAlert struct {
+	alertApiModel.PendingAlert
+	// TTL expires the entry once the dedup window is over
+	TTL int64 `dynamodbav:"ttl"`
+}
+
+// AlertTickets are the ticket attributes of an alert in DDB, added by the alerts-api
+// when an issue is created for the alert in Jira or Github
+type AlertTickets struct {
+	// TicketSync is only set while the alert's tickets are synced (the alert is not closed or resolved)
+	TicketSync string                  `dynamodbav:"ticketSync"`
+	Tickets    []*alertApiModel.Ticket `dynamodbav:"tickets"`
+	// TicketCommentTime is when the tickets were last commented on with the event count, in unix seconds
+	TicketCommentTime int64 `dynamodbav:"ticketCommentTime"`
+}
+
 func FromDynamodDBAttribute(input map[string]events.DynamoDBAttributeValue) (event *AlertDedupEvent, err error) {
 	defer func() {
 		if r := recover(); r != nil {
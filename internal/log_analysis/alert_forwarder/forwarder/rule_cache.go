@@ -74,8 +74,32 @@ func (c *RuleCache) getRule(id, version string) (*models.Rule, error) {
 		HTTPClient: c.httpClient,
 	})
 
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to fetch information for ruleID [%s], version [%s]", id, version)
+	if err == nil {
+		return rule.Payload, nil
+	}
+
+	// Alerts can also be created by scheduled queries and correlation rules, which have their own routes
+	if _, notFound := err.(*policiesoperations.GetRuleNotFound); notFound {
+		var query *policiesoperations.GetScheduledQueryOK
+		query, err = c.policyClient.Operations.GetScheduledQuery(&policiesoperations.GetScheduledQueryParams{
+			QueryID:    id,
+			VersionID:  &version,
+			HTTPClient: c.httpClient,
+		})
+		if err == nil {
+			return query.Payload, nil
+		}
+	}
+	if _, notFound := err.(*policiesoperations.GetScheduledQueryNotFound); notFound {
+		var correlation *policiesoperations.GetCorrelationRuleOK
+		correlation, err = c.policyClient.Operations.GetCorrelationRule(&policiesoperations.GetCorrelationRuleParams{
+			CorrelationID: id,
+			VersionID:     &version,
+			HTTPClient:    c.httpClient,
+		})
+		if err == nil {
+			return correlation.Payload, nil
+		}
 	}
-	return rule.Payload, nil
+	return nil, errors.Wrapf(err, "failed to fetch information for ruleID [%s], version [%s]", id, version)
 }
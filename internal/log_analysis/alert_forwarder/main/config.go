@@ -45,6 +45,8 @@ var (
 
 type envConfig struct {
 	AlertsTable      string `required:"true" split_words:"true"`
+	ActivityTable    string `required:"true" split_words:"true"`
+	PendingTable     string `required:"true" split_words:"true"`
 	AlertingQueueURL string `required:"true" split_words:"true"`
 	AnalysisAPIHost  string `required:"true" split_words:"true"`
 	AnalysisAPIPath  string `required:"true" split_words:"true"`
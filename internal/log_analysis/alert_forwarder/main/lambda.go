@@ -44,6 +44,8 @@ func init() {
 		Cache:            cache,
 		AlertingQueueURL: env.AlertingQueueURL,
 		AlertTable:       env.AlertsTable,
+		ActivityTable:    env.ActivityTable,
+		PendingTable:     env.PendingTable,
 	}
 }
 
@@ -0,0 +1,163 @@
+package alertdedup
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"crypto/md5" // nolint(gosec)
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/pkg/errors"
+)
+
+// Attributes of the alert dedup table, shared with the rules engine
+const (
+	partitionKey      = "partitionKey"
+	ruleIDAttr        = "ruleId"
+	ruleVersionAttr   = "ruleVersion"
+	dedupAttr         = "dedup"
+	alertCreationAttr = "alertCreationTime"
+	alertUpdateAttr   = "alertUpdateTime"
+	alertCountAttr    = "alertCount"
+	eventCountAttr    = "eventCount"
+	logTypesAttr      = "logTypes"
+	titleAttr         = "title"
+)
+
+// Group is a batch of events which matched the same rule with the same dedup string.
+type Group struct {
+	RuleID             string
+	RuleVersion        string
+	Dedup              string
+	DedupPeriodMinutes int64
+	EventCount         int
+	LogTypes           []string
+	Title              string // optional
+}
+
+// AlertInfo identifies the alert a group of events was added to.
+type AlertInfo struct {
+	AlertID      string
+	CreationTime time.Time
+	UpdateTime   time.Time
+}
+
+// Update adds a group of events to the alert dedup table and returns the alert they belong to.
+//
+// This is the Go equivalent of the rules engine alert merger: if the dedup period of the previous
+// alert has expired (or there is no previous alert), a new alert is started. Otherwise the events
+// are added to the existing alert. The alert forwarder picks up the change from the table stream.
+func Update(client dynamodbiface.DynamoDBAPI, table string, group *Group, now time.Time) (*AlertInfo, error) {
+	key := map[string]*dynamodb.AttributeValue{
+		partitionKey: {S: aws.String(Key(group.RuleID, group.Dedup))},
+	}
+	logTypes := &dynamodb.AttributeValue{SS: aws.StringSlice(group.LogTypes)}
+
+	condition := expression.Name(alertCreationAttr).LessThan(expression.Value(now.Unix() - group.DedupPeriodMinutes*60)).
+		Or(expression.AttributeNotExists(expression.Name(partitionKey)))
+	update := expression.
+		Add(expression.Name(alertCountAttr), expression.Value(1)).
+		Set(expression.Name(ruleIDAttr), expression.Value(group.RuleID)).
+		Set(expression.Name(dedupAttr), expression.Value(group.Dedup)).
+		Set(expression.Name(alertCreationAttr), expression.Value(now.Unix())).
+		Set(expression.Name(alertUpdateAttr), expression.Value(now.Unix())).
+		Set(expression.Name(eventCountAttr), expression.Value(group.EventCount)).
+		Set(expression.Name(logTypesAttr), expression.Value(logTypes)).
+		Set(expression.Name(ruleVersionAttr), expression.Value(group.RuleVersion))
+	if group.Title != "" {
+		update = update.Set(expression.Name(titleAttr), expression.Value(group.Title))
+	}
+	expr, err := expression.NewBuilder().WithCondition(condition).WithUpdate(update).Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build new alert expression")
+	}
+
+	output, err := client.UpdateItem(&dynamodb.UpdateItemInput{
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		Key:                       key,
+		ReturnValues:              aws.String(dynamodb.ReturnValueAllNew),
+		TableName:                 &table,
+		UpdateExpression:          expr.Update(),
+	})
+	if err == nil {
+		return alertInfo(group, output.Attributes, now)
+	}
+	if awsErr, ok := err.(awserr.Error); !ok || awsErr.Code() != dynamodb.ErrCodeConditionalCheckFailedException {
+		return nil, errors.Wrap(err, "failed to create alert")
+	}
+
+	// The previous alert is still within its dedup period, merge the events into it
+	update = expression.
+		Set(expression.Name(alertUpdateAttr), expression.Value(now.Unix())).
+		Add(expression.Name(eventCountAttr), expression.Value(group.EventCount)).
+		Add(expression.Name(logTypesAttr), expression.Value(logTypes))
+	if expr, err = expression.NewBuilder().WithUpdate(update).Build(); err != nil {
+		return nil, errors.Wrap(err, "failed to build merge expression")
+	}
+
+	output, err = client.UpdateItem(&dynamodb.UpdateItemInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		Key:                       key,
+		ReturnValues:              aws.String(dynamodb.ReturnValueAllNew),
+		TableName:                 &table,
+		UpdateExpression:          expr.Update(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update alert")
+	}
+	return alertInfo(group, output.Attributes, now)
+}
+
+func alertInfo(group *Group, attributes map[string]*dynamodb.AttributeValue, now time.Time) (*AlertInfo, error) {
+	alertCount := attributes[alertCountAttr]
+	creationTime := attributes[alertCreationAttr]
+	if alertCount == nil || alertCount.N == nil || creationTime == nil || creationTime.N == nil {
+		return nil, errors.New("alert dedup item is missing alertCount or alertCreationTime")
+	}
+	creationSeconds, err := strconv.ParseInt(*creationTime.N, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid alertCreationTime")
+	}
+	return &AlertInfo{
+		AlertID:      AlertID(group.RuleID, group.Dedup, *alertCount.N),
+		CreationTime: time.Unix(creationSeconds, 0).UTC(),
+		UpdateTime:   now,
+	}, nil
+}
+
+// Key is the partition key of the alert dedup table, the same hash the rules engine uses.
+func Key(ruleID, dedup string) string {
+	keyHash := md5.Sum([]byte(ruleID + ":" + dedup)) // nolint(gosec)
+	return hex.EncodeToString(keyHash[:])
+}
+
+// AlertID is the ID of the alert for the given dedup string and alert count, the same hash the rules engine uses.
+func AlertID(ruleID, dedup, alertCount string) string {
+	idHash := md5.Sum([]byte(ruleID + ":" + alertCount + ":" + dedup)) // nolint(gosec)
+	return hex.EncodeToString(idHash[:])
+}
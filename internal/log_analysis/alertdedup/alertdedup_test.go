@@ -0,0 +1,104 @@
+package alertdedup
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+var (
+	testNow   = time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	testGroup = &Group{
+		RuleID:             "rule",
+		RuleVersion:        "version",
+		Dedup:              "dedup",
+		DedupPeriodMinutes: 60,
+		EventCount:         3,
+		LogTypes:           []string{"AWS.CloudTrail"},
+	}
+)
+
+func TestKeys(t *testing.T) {
+	// must match the hashes the rules engine generates
+	assert.Equal(t, "8ba86aeb773ec66d1e029af23c5ba9fa", Key("rule", "dedup"))
+	assert.Equal(t, AlertID("rule", "dedup", "2"), AlertID("rule", "dedup", "2"))
+	assert.NotEqual(t, AlertID("rule", "dedup", "1"), AlertID("rule", "dedup", "2"))
+}
+
+func TestUpdateNewAlert(t *testing.T) {
+	ddbMock := &testutils.DynamoDBMock{}
+	ddbMock.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{
+		Attributes: map[string]*dynamodb.AttributeValue{
+			"alertCount":        {N: aws.String("2")},
+			"alertCreationTime": {N: aws.String("1591012800")},
+		},
+	}, nil).Once()
+
+	result, err := Update(ddbMock, "table", testGroup, testNow)
+	require.NoError(t, err)
+	assert.Equal(t, &AlertInfo{AlertID: AlertID("rule", "dedup", "2"), CreationTime: testNow, UpdateTime: testNow}, result)
+
+	input := ddbMock.Calls[0].Arguments.Get(0).(*dynamodb.UpdateItemInput)
+	assert.Equal(t, "table", *input.TableName)
+	assert.Equal(t, Key("rule", "dedup"), *input.Key["partitionKey"].S)
+	assert.NotNil(t, input.ConditionExpression)
+	ddbMock.AssertExpectations(t)
+}
+
+func TestUpdateMergeAlert(t *testing.T) {
+	ddbMock := &testutils.DynamoDBMock{}
+	ddbMock.On("UpdateItem", mock.MatchedBy(func(input *dynamodb.UpdateItemInput) bool {
+		return input.ConditionExpression != nil
+	})).Return(&dynamodb.UpdateItemOutput{},
+		awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "", nil)).Once()
+	ddbMock.On("UpdateItem", mock.MatchedBy(func(input *dynamodb.UpdateItemInput) bool {
+		return input.ConditionExpression == nil
+	})).Return(&dynamodb.UpdateItemOutput{
+		Attributes: map[string]*dynamodb.AttributeValue{
+			"alertCount":        {N: aws.String("1")},
+			"alertCreationTime": {N: aws.String("1591011000")},
+		},
+	}, nil).Once()
+
+	result, err := Update(ddbMock, "table", testGroup, testNow)
+	require.NoError(t, err)
+	assert.Equal(t, AlertID("rule", "dedup", "1"), result.AlertID)
+	assert.Equal(t, time.Unix(1591011000, 0).UTC(), result.CreationTime)
+	ddbMock.AssertExpectations(t)
+}
+
+func TestUpdateError(t *testing.T) {
+	ddbMock := &testutils.DynamoDBMock{}
+	ddbMock.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{},
+		awserr.New(dynamodb.ErrCodeProvisionedThroughputExceededException, "", nil)).Once()
+
+	_, err := Update(ddbMock, "table", testGroup, testNow)
+	assert.Error(t, err)
+	ddbMock.AssertExpectations(t)
+}
@@ -0,0 +1,118 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+	usermodels "github.com/panther-labs/panther/api/lambda/users/models"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+// AssignAlert sets (or clears) the user responsible for an alert.
+func (API) AssignAlert(input *models.AssignAlertInput) (*models.AssignAlertOutput, error) {
+	if input.AssigneeID != "" {
+		if err := validateAssignee(input.AssigneeID); err != nil {
+			return nil, err
+		}
+	}
+
+	alertItem, err := alertsDB.AssignAlert(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// The assignment is already saved, a missing timeline entry should not fail the request
+	activity := &models.AlertActivity{
+		AlertID:    input.AlertID,
+		Type:       models.AssignmentActivity,
+		UserID:     *input.UserID,
+		AssigneeID: input.AssigneeID,
+	}
+	if err = alertsDB.AddActivity([]*models.AlertActivity{activity}); err != nil {
+		zap.L().Error("failed to record assignment activity", zap.String("alertId", *input.AlertID), zap.Error(err))
+	}
+
+	result := alertItemToAlertSummary(alertItem)
+	gatewayapi.ReplaceMapSliceNils(result)
+	return result, nil
+}
+
+// validateAssignee checks the assignee is an existing Panther user.
+func validateAssignee(userID string) error {
+	input := usermodels.LambdaInput{GetUser: &usermodels.GetUserInput{ID: &userID}}
+	var output usermodels.GetUserOutput
+	err := genericapi.Invoke(lambdaClient, usersAPI, &input, &output)
+	if err == nil {
+		return nil
+	}
+
+	if lambdaErr, ok := err.(*genericapi.LambdaError); ok && aws.StringValue(lambdaErr.ErrorType) == "DoesNotExistError" {
+		return &genericapi.InvalidInputError{Message: "assignee " + userID + " does not exist"}
+	}
+	return err
+}
+
+// AddAlertComment adds a comment to the alert timeline.
+func (API) AddAlertComment(input *models.AddAlertCommentInput) (*models.AddAlertCommentOutput, error) {
+	// Comments on alerts which don't exist would never be shown
+	alertItem, err := alertsDB.GetAlert(input.AlertID)
+	if err != nil {
+		return nil, err
+	}
+	if alertItem == nil {
+		return nil, &genericapi.DoesNotExistError{Message: "alertId=" + *input.AlertID}
+	}
+
+	activity := &models.AlertActivity{
+		AlertID: input.AlertID,
+		Type:    models.CommentActivity,
+		UserID:  *input.UserID,
+		Comment: *input.Comment,
+	}
+	if err = alertsDB.AddActivity([]*models.AlertActivity{activity}); err != nil {
+		return nil, err
+	}
+	return activity, nil
+}
+
+// ListAlertActivity returns a page of the alert timeline, oldest first.
+func (API) ListAlertActivity(input *models.ListAlertActivityInput) (*models.ListAlertActivityOutput, error) {
+	activity, lastEvaluatedKey, err := alertsDB.ListActivity(input)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.ListAlertActivityOutput{Activity: activity, LastEvaluatedKey: lastEvaluatedKey}
+	gatewayapi.ReplaceMapSliceNils(result)
+	return result, nil
+}
+
+// AddAlertActivity records activity done by Panther itself, such as alert deliveries.
+func (API) AddAlertActivity(input *models.AddAlertActivityInput) error {
+	for _, activity := range input.Activity {
+		if activity.Type != models.DeliveryActivity {
+			return &genericapi.InvalidInputError{Message: "only " + models.DeliveryActivity + " activity can be added"}
+		}
+	}
+	return alertsDB.AddActivity(input.Activity)
+}
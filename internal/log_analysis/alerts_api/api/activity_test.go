@@ -0,0 +1,174 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
+	"github.com/panther-labs/panther/pkg/genericapi"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+const (
+	testUserID     = "97c4db4e-61d5-40a7-82de-6dd63b199bd2"
+	testAssigneeID = "8304cc90-750d-4b8f-9a63-b90a4543c707"
+)
+
+func TestAssignAlert(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+	mockLambda := &testutils.LambdaMock{}
+	lambdaClient = mockLambda
+
+	input := &models.AssignAlertInput{
+		AlertID:    aws.String("alertId"),
+		AssigneeID: testAssigneeID,
+		UserID:     aws.String(testUserID),
+	}
+	mockLambda.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{Payload: []byte(`{"id":"` + testAssigneeID + `"}`)}, nil).Once()
+	tableMock.On("AssignAlert", input).Return(&table.AlertItem{AlertID: "alertId", AssigneeID: testAssigneeID}, nil).Once()
+	tableMock.On("AddActivity", []*models.AlertActivity{{
+		AlertID:    input.AlertID,
+		Type:       models.AssignmentActivity,
+		UserID:     testUserID,
+		AssigneeID: testAssigneeID,
+	}}).Return(nil).Once()
+
+	result, err := API{}.AssignAlert(input)
+	require.NoError(t, err)
+	assert.Equal(t, testAssigneeID, result.AssigneeID)
+
+	request := mockLambda.Calls[0].Arguments.Get(0).(*lambda.InvokeInput)
+	assert.Equal(t, usersAPI, *request.FunctionName)
+	tableMock.AssertExpectations(t)
+	mockLambda.AssertExpectations(t)
+}
+
+func TestAssignAlertUnknownAssignee(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+	mockLambda := &testutils.LambdaMock{}
+	lambdaClient = mockLambda
+
+	mockLambda.On("Invoke", mock.Anything).Return(&lambda.InvokeOutput{
+		FunctionError: aws.String("Unhandled"),
+		Payload:       []byte(`{"errorMessage":"does not exist","errorType":"DoesNotExistError"}`),
+	}, nil).Once()
+
+	result, err := API{}.AssignAlert(&models.AssignAlertInput{
+		AlertID:    aws.String("alertId"),
+		AssigneeID: testAssigneeID,
+		UserID:     aws.String(testUserID),
+	})
+	assert.Nil(t, result)
+	assert.IsType(t, &genericapi.InvalidInputError{}, err)
+	tableMock.AssertExpectations(t)
+}
+
+func TestUnassignAlert(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+	mockLambda := &testutils.LambdaMock{}
+	lambdaClient = mockLambda
+
+	input := &models.AssignAlertInput{AlertID: aws.String("alertId"), UserID: aws.String(testUserID)}
+	tableMock.On("AssignAlert", input).Return(&table.AlertItem{AlertID: "alertId"}, nil).Once()
+	// Failing to record the activity does not fail the assignment
+	tableMock.On("AddActivity", mock.Anything).Return(errors.New("test")).Once()
+
+	result, err := API{}.AssignAlert(input)
+	require.NoError(t, err)
+	assert.Empty(t, result.AssigneeID)
+	tableMock.AssertExpectations(t)
+	mockLambda.AssertNotCalled(t, "Invoke", mock.Anything)
+}
+
+func TestAddAlertComment(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	input := &models.AddAlertCommentInput{
+		AlertID: aws.String("alertId"),
+		Comment: aws.String("looks fine"),
+		UserID:  aws.String(testUserID),
+	}
+	tableMock.On("GetAlert", input.AlertID).Return(&table.AlertItem{AlertID: "alertId"}, nil).Once()
+	tableMock.On("AddActivity", mock.Anything).Return(nil).Once()
+
+	result, err := API{}.AddAlertComment(input)
+	require.NoError(t, err)
+	assert.Equal(t, models.CommentActivity, result.Type)
+	assert.Equal(t, "looks fine", result.Comment)
+	assert.Equal(t, testUserID, result.UserID)
+	tableMock.AssertExpectations(t)
+}
+
+func TestAddAlertCommentAlertDoesNotExist(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	input := &models.AddAlertCommentInput{
+		AlertID: aws.String("alertId"),
+		Comment: aws.String("looks fine"),
+		UserID:  aws.String(testUserID),
+	}
+	tableMock.On("GetAlert", input.AlertID).Return(nil, nil).Once()
+
+	result, err := API{}.AddAlertComment(input)
+	assert.Nil(t, result)
+	assert.IsType(t, &genericapi.DoesNotExistError{}, err)
+	tableMock.AssertExpectations(t)
+}
+
+func TestListAlertActivity(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	input := &models.ListAlertActivityInput{AlertID: aws.String("alertId")}
+	tableMock.On("ListActivity", input).Return([]*models.AlertActivity(nil), (*string)(nil), nil).Once()
+
+	result, err := API{}.ListAlertActivity(input)
+	require.NoError(t, err)
+	assert.Equal(t, &models.ListAlertActivityOutput{Activity: []*models.AlertActivity{}}, result)
+	tableMock.AssertExpectations(t)
+}
+
+func TestAddAlertActivityOnlyDeliveries(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	err := API{}.AddAlertActivity(&models.AddAlertActivityInput{
+		Activity: []*models.AlertActivity{{AlertID: aws.String("alertId"), Type: models.CommentActivity}},
+	})
+	assert.IsType(t, &genericapi.InvalidInputError{}, err)
+
+	activity := []*models.AlertActivity{{AlertID: aws.String("alertId"), Type: models.DeliveryActivity, Success: aws.Bool(true)}}
+	tableMock.On("AddActivity", activity).Return(nil).Once()
+	require.NoError(t, API{}.AddAlertActivity(&models.AddAlertActivityInput{Activity: activity}))
+	tableMock.AssertExpectations(t)
+}
@@ -24,6 +24,8 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	jsoniter "github.com/json-iterator/go"
@@ -32,6 +34,8 @@ import (
 	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
 )
 
+const usersAPI = "panther-users-api"
+
 // API has all of the handlers as receiver methods.
 type API struct{}
 
@@ -40,15 +44,21 @@ var (
 	awsSession *session.Session
 	alertsDB   table.API
 	s3Client   s3iface.S3API
+
+	lambdaClient lambdaiface.LambdaAPI
 )
 
 type envConfig struct {
-	AnalysisAPIHost     string `required:"true" split_words:"true"`
-	AnalysisAPIPath     string `required:"true" split_words:"true"`
-	AlertsTableName     string `required:"true" split_words:"true"`
-	RuleIndexName       string `required:"true" split_words:"true"`
-	TimeIndexName       string `required:"true" split_words:"true"`
-	ProcessedDataBucket string `required:"true" split_words:"true"`
+	AnalysisAPIHost        string `required:"true" split_words:"true"`
+	AnalysisAPIPath        string `required:"true" split_words:"true"`
+	AlertsTableName        string `required:"true" split_words:"true"`
+	RuleIndexName          string `required:"true" split_words:"true"`
+	TimeIndexName          string `required:"true" split_words:"true"`
+	TicketSyncIndexName    string `required:"true" split_words:"true"`
+	ActivityTableName      string `required:"true" split_words:"true"`
+	FiltersTableName       string `required:"true" split_words:"true"`
+	PendingAlertsTableName string `required:"true" split_words:"true"`
+	ProcessedDataBucket    string `required:"true" split_words:"true"`
 }
 
 // Setup - parses the environment and builds the AWS and http clients.
@@ -61,8 +71,13 @@ func Setup() {
 		Client:                             dynamodb.New(awsSession),
 		RuleIDCreationTimeIndexName:        env.RuleIndexName,
 		TimePartitionCreationTimeIndexName: env.TimeIndexName,
+		TicketSyncIndexName:                env.TicketSyncIndexName,
+		ActivityTableName:                  env.ActivityTableName,
+		FiltersTableName:                   env.FiltersTableName,
+		PendingAlertsTableName:             env.PendingAlertsTableName,
 	}
 	s3Client = s3.New(awsSession)
+	lambdaClient = lambda.New(awsSession)
 }
 
 // EventPaginationToken - token used for paginating through the events in an alert
@@ -0,0 +1,120 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"go.uber.org/zap"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+const (
+	// maxBulkUpdateAlerts is the most alerts a filter can select in a single bulk update
+	maxBulkUpdateAlerts = 1000
+	// maxListAlertsPageSize is the largest page size allowed by ListAlertsInput
+	maxListAlertsPageSize = 50
+)
+
+// BulkUpdateAlertStatus sets the status of every alert selected by id or by filter.
+func (API) BulkUpdateAlertStatus(input *models.BulkUpdateAlertStatusInput) (*models.BulkUpdateAlertStatusOutput, error) {
+	if (len(input.AlertIDs) == 0) == (input.Filter == nil) {
+		return nil, &genericapi.InvalidInputError{Message: "exactly one of alertIds or filter must be set"}
+	}
+
+	result := &models.BulkUpdateAlertStatusOutput{}
+	alertIDs := input.AlertIDs
+	if input.Filter != nil {
+		var err error
+		if alertIDs, result.Truncated, err = listFilteredAlertIDs(input.Filter); err != nil {
+			return nil, err
+		}
+	}
+
+	updated, failures, err := alertsDB.BulkUpdateAlertStatus(uniqueAlertIDs(alertIDs), *input.Status, *input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	activity := make([]*models.AlertActivity, 0, len(updated))
+	for _, alert := range updated {
+		alertID := alert.AlertID
+		result.UpdatedAlertIDs = append(result.UpdatedAlertIDs, alertID)
+		activity = append(activity, &models.AlertActivity{
+			AlertID: &alertID,
+			Type:    models.StatusChangeActivity,
+			UserID:  *input.UserID,
+			Status:  *input.Status,
+		})
+	}
+	result.Failures = failures
+
+	// The alerts are already updated, a missing timeline entry should not fail the request
+	if err = alertsDB.AddActivity(activity); err != nil {
+		zap.L().Error("failed to record bulk status change activity", zap.Error(err))
+	}
+
+	gatewayapi.ReplaceMapSliceNils(result)
+	return result, nil
+}
+
+// uniqueAlertIDs removes repeated alert ids, keeping the first occurrence of each.
+func uniqueAlertIDs(alertIDs []string) []string {
+	seen := make(map[string]struct{}, len(alertIDs))
+	result := make([]string, 0, len(alertIDs))
+	for _, alertID := range alertIDs {
+		if _, ok := seen[alertID]; ok {
+			continue
+		}
+		seen[alertID] = struct{}{}
+		result = append(result, alertID)
+	}
+	return result
+}
+
+// listFilteredAlertIDs pages through the alerts matching a filter.
+//
+// Returns true if more than maxBulkUpdateAlerts alerts matched.
+func listFilteredAlertIDs(filter *models.ListAlertsInput) ([]string, bool, error) {
+	// Only the filters are used, every page is read from the start in the default order
+	query := *filter
+	query.PageSize = aws.Int(maxListAlertsPageSize)
+	query.ExclusiveStartKey = nil
+	query.SortDir = nil
+
+	var alertIDs []string
+	for {
+		items, lastEvaluatedKey, err := alertsDB.ListAll(&query)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, item := range items {
+			if len(alertIDs) == maxBulkUpdateAlerts {
+				return alertIDs, true, nil
+			}
+			alertIDs = append(alertIDs, item.AlertID)
+		}
+		if lastEvaluatedKey == nil {
+			return alertIDs, false, nil
+		}
+		query.ExclusiveStartKey = lastEvaluatedKey
+	}
+}
@@ -0,0 +1,149 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+func TestBulkUpdateAlertStatusByID(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	// Repeated ids are only updated once
+	input := &models.BulkUpdateAlertStatusInput{
+		AlertIDs: []string{"alert-1", "alert-2", "alert-1"},
+		Status:   aws.String(models.ClosedStatus),
+		UserID:   aws.String(testUserID),
+	}
+	failures := []*models.BulkUpdateFailure{{AlertID: "alert-2", Error: "alert does not exist"}}
+	tableMock.On("BulkUpdateAlertStatus", []string{"alert-1", "alert-2"}, models.ClosedStatus, testUserID).
+		Return([]*table.AlertItem{{AlertID: "alert-1"}}, failures, nil).Once()
+	tableMock.On("AddActivity", []*models.AlertActivity{{
+		AlertID: aws.String("alert-1"),
+		Type:    models.StatusChangeActivity,
+		UserID:  testUserID,
+		Status:  models.ClosedStatus,
+	}}).Return(nil).Once()
+
+	result, err := API{}.BulkUpdateAlertStatus(input)
+	require.NoError(t, err)
+	assert.Equal(t, &models.BulkUpdateAlertStatusOutput{
+		UpdatedAlertIDs: []string{"alert-1"},
+		Failures:        failures,
+	}, result)
+	tableMock.AssertExpectations(t)
+}
+
+func TestBulkUpdateAlertStatusByFilter(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	filter := &models.ListAlertsInput{
+		Severity:          []*string{aws.String("INFO")},
+		Status:            []string{models.OpenStatus},
+		PageSize:          aws.Int(5),
+		ExclusiveStartKey: aws.String("ignored"),
+	}
+	input := &models.BulkUpdateAlertStatusInput{
+		Filter: filter,
+		Status: aws.String(models.ResolvedStatus),
+		UserID: aws.String(testUserID),
+	}
+
+	firstPage := mock.MatchedBy(func(query *models.ListAlertsInput) bool {
+		return query.ExclusiveStartKey == nil && *query.PageSize == maxListAlertsPageSize &&
+			*query.Severity[0] == "INFO"
+	})
+	secondPage := mock.MatchedBy(func(query *models.ListAlertsInput) bool {
+		return query.ExclusiveStartKey != nil && *query.ExclusiveStartKey == "page-2"
+	})
+	tableMock.On("ListAll", firstPage).Return([]*table.AlertItem{{AlertID: "alert-1"}}, aws.String("page-2"), nil).Once()
+	tableMock.On("ListAll", secondPage).Return([]*table.AlertItem{{AlertID: "alert-2"}}, (*string)(nil), nil).Once()
+	tableMock.On("BulkUpdateAlertStatus", []string{"alert-1", "alert-2"}, models.ResolvedStatus, testUserID).
+		Return([]*table.AlertItem{{AlertID: "alert-1"}, {AlertID: "alert-2"}}, []*models.BulkUpdateFailure(nil), nil).Once()
+	tableMock.On("AddActivity", mock.Anything).Return(nil).Once()
+
+	result, err := API{}.BulkUpdateAlertStatus(input)
+	require.NoError(t, err)
+	assert.Equal(t, &models.BulkUpdateAlertStatusOutput{
+		UpdatedAlertIDs: []string{"alert-1", "alert-2"},
+		Failures:        []*models.BulkUpdateFailure{},
+	}, result)
+	// The input filter is not modified
+	assert.Equal(t, "ignored", *filter.ExclusiveStartKey)
+	tableMock.AssertExpectations(t)
+}
+
+func TestBulkUpdateAlertStatusTruncated(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	// One more page than the limit, with distinct alerts
+	for pageNum := 0; pageNum <= maxBulkUpdateAlerts/maxListAlertsPageSize; pageNum++ {
+		page := make([]*table.AlertItem, maxListAlertsPageSize)
+		for i := range page {
+			page[i] = &table.AlertItem{AlertID: strconv.Itoa(pageNum*maxListAlertsPageSize + i)}
+		}
+		tableMock.On("ListAll", mock.Anything).Return(page, aws.String("next"), nil).Once()
+	}
+	tableMock.On("BulkUpdateAlertStatus", mock.MatchedBy(func(alertIDs []string) bool {
+		return len(alertIDs) == maxBulkUpdateAlerts
+	}), models.ClosedStatus, testUserID).Return([]*table.AlertItem(nil), []*models.BulkUpdateFailure(nil), nil).Once()
+	tableMock.On("AddActivity", mock.Anything).Return(nil).Once()
+
+	result, err := API{}.BulkUpdateAlertStatus(&models.BulkUpdateAlertStatusInput{
+		Filter: &models.ListAlertsInput{},
+		Status: aws.String(models.ClosedStatus),
+		UserID: aws.String(testUserID),
+	})
+	require.NoError(t, err)
+	assert.True(t, result.Truncated)
+	tableMock.AssertExpectations(t)
+}
+
+func TestBulkUpdateAlertStatusInvalidInput(t *testing.T) {
+	alertsDB = &tableMock{}
+
+	result, err := API{}.BulkUpdateAlertStatus(&models.BulkUpdateAlertStatusInput{
+		Status: aws.String(models.ClosedStatus),
+		UserID: aws.String(testUserID),
+	})
+	assert.Nil(t, result)
+	assert.IsType(t, &genericapi.InvalidInputError{}, err)
+
+	result, err = API{}.BulkUpdateAlertStatus(&models.BulkUpdateAlertStatusInput{
+		AlertIDs: []string{"alert-1"},
+		Filter:   &models.ListAlertsInput{},
+		Status:   aws.String(models.ClosedStatus),
+		UserID:   aws.String(testUserID),
+	})
+	assert.Nil(t, result)
+	assert.IsType(t, &genericapi.InvalidInputError{}, err)
+}
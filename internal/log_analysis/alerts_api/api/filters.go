@@ -0,0 +1,69 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+)
+
+// SaveAlertFilter creates a new saved filter or replaces an existing one.
+func (API) SaveAlertFilter(input *models.SaveAlertFilterInput) (*models.SaveAlertFilterOutput, error) {
+	filterID := input.FilterID
+	if filterID == "" {
+		filterID = uuid.New().String()
+	}
+
+	// Pagination is not part of a saved filter
+	filter := *input.Filter
+	filter.ExclusiveStartKey = nil
+
+	result := &models.AlertFilter{
+		UserID:    *input.UserID,
+		FilterID:  filterID,
+		Name:      *input.Name,
+		Filter:    &filter,
+		UpdatedAt: time.Now().UTC(),
+	}
+	if err := alertsDB.PutFilter(result, input.FilterID != ""); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListAlertFilters returns the saved filters of a user.
+func (API) ListAlertFilters(input *models.ListAlertFiltersInput) (models.ListAlertFiltersOutput, error) {
+	filters, err := alertsDB.ListFilters(*input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if filters == nil {
+		filters = models.ListAlertFiltersOutput{}
+	}
+	return filters, nil
+}
+
+// DeleteAlertFilter deletes a saved filter.
+func (API) DeleteAlertFilter(input *models.DeleteAlertFilterInput) error {
+	return alertsDB.DeleteFilter(*input.UserID, *input.FilterID)
+}
@@ -0,0 +1,95 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+)
+
+const testFilterID = "7d1c5854-f3ea-491c-8a52-0aa0d58cb456"
+
+func TestSaveAlertFilterCreate(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	tableMock.On("PutFilter", mock.Anything, false).Return(nil).Once()
+
+	result, err := API{}.SaveAlertFilter(&models.SaveAlertFilterInput{
+		UserID: aws.String(testUserID),
+		Name:   aws.String("open"),
+		Filter: &models.ListAlertsInput{Status: []string{models.OpenStatus}, ExclusiveStartKey: aws.String("key")},
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.FilterID)
+	assert.Equal(t, testUserID, result.UserID)
+	assert.Equal(t, []string{models.OpenStatus}, result.Filter.Status)
+	assert.Nil(t, result.Filter.ExclusiveStartKey)
+	assert.False(t, result.UpdatedAt.IsZero())
+	tableMock.AssertExpectations(t)
+}
+
+func TestSaveAlertFilterReplace(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	tableMock.On("PutFilter", mock.Anything, true).Return(nil).Once()
+
+	result, err := API{}.SaveAlertFilter(&models.SaveAlertFilterInput{
+		UserID:   aws.String(testUserID),
+		FilterID: testFilterID,
+		Name:     aws.String("open"),
+		Filter:   &models.ListAlertsInput{},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, testFilterID, result.FilterID)
+	tableMock.AssertExpectations(t)
+}
+
+func TestListAlertFilters(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	tableMock.On("ListFilters", testUserID).Return([]*models.AlertFilter(nil), nil).Once()
+
+	result, err := API{}.ListAlertFilters(&models.ListAlertFiltersInput{UserID: aws.String(testUserID)})
+	require.NoError(t, err)
+	assert.Equal(t, models.ListAlertFiltersOutput{}, result)
+	tableMock.AssertExpectations(t)
+}
+
+func TestDeleteAlertFilter(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	tableMock.On("DeleteFilter", testUserID, testFilterID).Return(nil).Once()
+
+	err := API{}.DeleteAlertFilter(&models.DeleteAlertFilterInput{
+		UserID:   aws.String(testUserID),
+		FilterID: aws.String(testFilterID),
+	})
+	require.NoError(t, err)
+	tableMock.AssertExpectations(t)
+}
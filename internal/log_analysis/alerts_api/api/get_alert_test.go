@@ -92,6 +92,63 @@ func (m *tableMock) UpdateAlertStatus(input *models.UpdateAlertStatusInput) (*ta
 	return args.Get(0).(*table.AlertItem), args.Error(1)
 }
 
+func (m *tableMock) AddAlertTicket(input *models.AddAlertTicketInput) (*table.AlertItem, error) {
+	args := m.Called(input)
+	return args.Get(0).(*table.AlertItem), args.Error(1)
+}
+
+func (m *tableMock) ListTicketedAlerts() ([]*table.AlertItem, error) {
+	args := m.Called()
+	return args.Get(0).([]*table.AlertItem), args.Error(1)
+}
+
+func (m *tableMock) StopTicketSync(alertID string) error {
+	args := m.Called(alertID)
+	return args.Error(0)
+}
+
+func (m *tableMock) AssignAlert(input *models.AssignAlertInput) (*table.AlertItem, error) {
+	args := m.Called(input)
+	return args.Get(0).(*table.AlertItem), args.Error(1)
+}
+
+func (m *tableMock) AddActivity(input []*models.AlertActivity) error {
+	args := m.Called(input)
+	return args.Error(0)
+}
+
+func (m *tableMock) ListActivity(input *models.ListAlertActivityInput) ([]*models.AlertActivity, *string, error) {
+	args := m.Called(input)
+	return args.Get(0).([]*models.AlertActivity), args.Get(1).(*string), args.Error(2)
+}
+
+func (m *tableMock) BulkUpdateAlertStatus(
+	alertIDs []string, status, userID string) ([]*table.AlertItem, []*models.BulkUpdateFailure, error) {
+
+	args := m.Called(alertIDs, status, userID)
+	return args.Get(0).([]*table.AlertItem), args.Get(1).([]*models.BulkUpdateFailure), args.Error(2)
+}
+
+func (m *tableMock) PutFilter(filter *models.AlertFilter, mustExist bool) error {
+	args := m.Called(filter, mustExist)
+	return args.Error(0)
+}
+
+func (m *tableMock) ListFilters(userID string) ([]*models.AlertFilter, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]*models.AlertFilter), args.Error(1)
+}
+
+func (m *tableMock) DeleteFilter(userID, filterID string) error {
+	args := m.Called(userID, filterID)
+	return args.Error(0)
+}
+
+func (m *tableMock) ListPendingAlerts(input *models.ListPendingAlertsInput) ([]*models.PendingAlert, *string, error) {
+	args := m.Called(input)
+	return args.Get(0).([]*models.PendingAlert), args.Get(1).(*string), args.Error(2)
+}
+
 func init() {
 	env = envConfig{
 		ProcessedDataBucket: "bucket",
@@ -191,6 +248,7 @@ func TestGetAlert(t *testing.T) {
 			EventsMatched:     aws.Int(5),
 			LastUpdatedBy:     "userId",
 			LastUpdatedByTime: time.Date(2020, 1, 1, 1, 59, 0, 0, time.UTC),
+			Tickets:           []*models.Ticket{},
 		},
 		Events: aws.StringSlice([]string{"testEvent"}),
 		EventsLastEvaluatedKey:
@@ -244,6 +302,7 @@ func TestGetAlert(t *testing.T) {
 			EventsMatched:     aws.Int(5),
 			LastUpdatedBy:     "userId",
 			LastUpdatedByTime: time.Date(2020, 1, 1, 1, 59, 0, 0, time.UTC),
+			Tickets:           []*models.Ticket{},
 		},
 		Events: aws.StringSlice([]string{}),
 		EventsLastEvaluatedKey:
@@ -322,6 +381,7 @@ func TestGetAlertFilterOutS3KeysOutsideTheTimePeriod(t *testing.T) {
 			DedupString:       aws.String("dedupString"),
 			LastUpdatedBy:     "userId",
 			LastUpdatedByTime: time.Date(2020, 1, 1, 1, 59, 0, 0, time.UTC),
+			Tickets:           []*models.Ticket{},
 		},
 		Events: aws.StringSlice([]string{"testEvent"}),
 		EventsLastEvaluatedKey:
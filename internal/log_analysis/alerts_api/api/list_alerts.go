@@ -85,5 +85,7 @@ func alertItemToAlertSummary(item *table.AlertItem) *models.AlertSummary {
 		LastUpdatedBy:     item.LastUpdatedBy,
 		LastUpdatedByTime: item.LastUpdatedByTime,
 		UpdateTime:        &item.UpdateTime,
+		Tickets:           item.Tickets,
+		AssigneeID:        item.AssigneeID,
 	}
 }
@@ -67,6 +67,7 @@ var (
 			Title:             aws.String("title"),
 			LastUpdatedBy:     "userId",
 			LastUpdatedByTime: timeInTest,
+			Tickets:           []*models.Ticket{},
 		},
 	}
 )
@@ -174,6 +175,7 @@ func TestListAllAlertsWithoutTitle(t *testing.T) {
 			Title:             aws.String("ruleId"),
 			LastUpdatedBy:     "userId",
 			LastUpdatedByTime: timeInTest,
+			Tickets:           []*models.Ticket{},
 		},
 		{
 			RuleID:          aws.String("ruleId"),
@@ -191,6 +193,7 @@ func TestListAllAlertsWithoutTitle(t *testing.T) {
 			Title:             aws.String("ruleDisplayName"),
 			LastUpdatedBy:     "userId",
 			LastUpdatedByTime: timeInTest,
+			Tickets:           []*models.Ticket{},
 		},
 	}
 
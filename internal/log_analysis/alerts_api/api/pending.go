@@ -0,0 +1,55 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"sort"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+)
+
+// ListPendingAlerts lists the dedup groups which have matched events, but have not reached their rule threshold.
+//
+// Within a page, the groups closest to their threshold are returned first.
+func (API) ListPendingAlerts(input *models.ListPendingAlertsInput) (*models.ListPendingAlertsOutput, error) {
+	pendingAlerts, lastEvaluatedKey, err := alertsDB.ListPendingAlerts(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if pendingAlerts == nil {
+		pendingAlerts = []*models.PendingAlert{}
+	}
+	sort.SliceStable(pendingAlerts, func(i, j int) bool {
+		return thresholdProgress(pendingAlerts[i]) > thresholdProgress(pendingAlerts[j])
+	})
+
+	return &models.ListPendingAlertsOutput{
+		PendingAlerts:    pendingAlerts,
+		LastEvaluatedKey: lastEvaluatedKey,
+	}, nil
+}
+
+// The fraction of the threshold reached by a pending alert
+func thresholdProgress(pendingAlert *models.PendingAlert) float64 {
+	if pendingAlert.Threshold <= 0 {
+		return 1
+	}
+	return float64(pendingAlert.EventCount) / float64(pendingAlert.Threshold)
+}
@@ -0,0 +1,64 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+)
+
+func TestListPendingAlerts(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	input := &models.ListPendingAlertsInput{}
+	pendingAlerts := []*models.PendingAlert{
+		{RuleID: "a", EventCount: 1, Threshold: 10},
+		{RuleID: "b", EventCount: 9, Threshold: 10},
+		{RuleID: "c", EventCount: 4, Threshold: 5},
+	}
+	tableMock.On("ListPendingAlerts", input).Return(pendingAlerts, (*string)(nil), nil).Once()
+
+	result, err := API{}.ListPendingAlerts(input)
+	require.NoError(t, err)
+	require.Len(t, result.PendingAlerts, 3)
+	// Closest to the threshold first
+	assert.Equal(t, "b", result.PendingAlerts[0].RuleID)
+	assert.Equal(t, "c", result.PendingAlerts[1].RuleID)
+	assert.Equal(t, "a", result.PendingAlerts[2].RuleID)
+	assert.Nil(t, result.LastEvaluatedKey)
+	tableMock.AssertExpectations(t)
+}
+
+func TestListPendingAlertsEmpty(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	input := &models.ListPendingAlertsInput{}
+	tableMock.On("ListPendingAlerts", input).Return([]*models.PendingAlert(nil), (*string)(nil), nil).Once()
+
+	result, err := API{}.ListPendingAlerts(input)
+	require.NoError(t, err)
+	assert.Equal(t, []*models.PendingAlert{}, result.PendingAlerts)
+	tableMock.AssertExpectations(t)
+}
@@ -0,0 +1,63 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+)
+
+// AddAlertTicket links a ticket to an alert so the ticket status can be synced back.
+func (API) AddAlertTicket(input *models.AddAlertTicketInput) error {
+	_, err := alertsDB.AddAlertTicket(input)
+	return err
+}
+
+// ListAlertTickets returns every alert whose tickets are still synced.
+func (API) ListAlertTickets(_ *models.ListAlertTicketsInput) (models.ListAlertTicketsOutput, error) {
+	alertItems, err := alertsDB.ListTicketedAlerts()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(models.ListAlertTicketsOutput, 0, len(alertItems))
+	for _, item := range alertItems {
+		status := item.Status
+		if status == "" {
+			status = models.OpenStatus
+		}
+		result = append(result, &models.AlertTickets{
+			AlertID: &item.AlertID,
+			Status:  status,
+			Tickets: item.Tickets,
+		})
+	}
+	return result, nil
+}
+
+// SyncAlertTickets sets the status of an alert from its tickets and stops syncing them.
+func (api API) SyncAlertTickets(input *models.SyncAlertTicketsInput) error {
+	if input.Status == nil {
+		return alertsDB.StopTicketSync(*input.AlertID)
+	}
+
+	// Closing or resolving the alert also stops the ticket sync.
+	// There is no user behind the change, so the last user who updated the alert is kept.
+	_, err := api.UpdateAlertStatus(&models.UpdateAlertStatusInput{AlertID: input.AlertID, Status: input.Status})
+	return err
+}
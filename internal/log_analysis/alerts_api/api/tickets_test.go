@@ -0,0 +1,96 @@
+package api
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+	"github.com/panther-labs/panther/internal/log_analysis/alerts_api/table"
+)
+
+func TestAddAlertTicket(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	input := &models.AddAlertTicketInput{
+		AlertID: aws.String("alertId"),
+		Ticket:  &models.Ticket{OutputID: "outputId", OutputType: "jira", Key: "SEC-1"},
+	}
+	tableMock.On("AddAlertTicket", input).Return(&table.AlertItem{AlertID: "alertId"}, nil).Once()
+	require.NoError(t, API{}.AddAlertTicket(input))
+	tableMock.AssertExpectations(t)
+}
+
+func TestAddAlertTicketError(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	input := &models.AddAlertTicketInput{AlertID: aws.String("alertId"), Ticket: &models.Ticket{}}
+	tableMock.On("AddAlertTicket", input).Return((*table.AlertItem)(nil), errors.New("test")).Once()
+	require.Error(t, API{}.AddAlertTicket(input))
+}
+
+func TestListAlertTickets(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	tickets := []*models.Ticket{{OutputID: "outputId", OutputType: "github", Key: "1"}}
+	tableMock.On("ListTicketedAlerts").Return([]*table.AlertItem{
+		{AlertID: "alert-1", Tickets: tickets},
+		{AlertID: "alert-2", Status: models.TriagedStatus, Tickets: tickets},
+	}, nil).Once()
+
+	result, err := API{}.ListAlertTickets(&models.ListAlertTicketsInput{})
+	require.NoError(t, err)
+	assert.Equal(t, models.ListAlertTicketsOutput{
+		{AlertID: aws.String("alert-1"), Status: models.OpenStatus, Tickets: tickets},
+		{AlertID: aws.String("alert-2"), Status: models.TriagedStatus, Tickets: tickets},
+	}, result)
+}
+
+func TestSyncAlertTickets(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	input := &models.SyncAlertTicketsInput{AlertID: aws.String("alertId"), Status: aws.String(models.ResolvedStatus)}
+	tableMock.On("UpdateAlertStatus", &models.UpdateAlertStatusInput{
+		AlertID: input.AlertID,
+		Status:  input.Status,
+	}).Return(&table.AlertItem{AlertID: "alertId", Status: models.ResolvedStatus}, nil).Once()
+	tableMock.On("AddActivity", mock.Anything).Return(nil).Once()
+
+	require.NoError(t, API{}.SyncAlertTickets(input))
+	tableMock.AssertExpectations(t)
+}
+
+func TestSyncAlertTicketsWithoutStatus(t *testing.T) {
+	tableMock := &tableMock{}
+	alertsDB = tableMock
+
+	tableMock.On("StopTicketSync", "alertId").Return(nil).Once()
+	require.NoError(t, API{}.SyncAlertTickets(&models.SyncAlertTicketsInput{AlertID: aws.String("alertId")}))
+	tableMock.AssertExpectations(t)
+}
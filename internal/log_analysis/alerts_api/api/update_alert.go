@@ -19,6 +19,9 @@ package api
  */
 
 import (
+	"github.com/aws/aws-sdk-go/aws"
+	"go.uber.org/zap"
+
 	"github.com/panther-labs/panther/api/lambda/alerts/models"
 	"github.com/panther-labs/panther/pkg/gatewayapi"
 )
@@ -36,6 +39,17 @@ func (API) UpdateAlertStatus(input *models.UpdateAlertStatusInput) (result *mode
 		return &models.UpdateAlertStatusOutput{}, nil
 	}
 
+	// The status change is already saved, a missing timeline entry should not fail the request
+	activity := &models.AlertActivity{
+		AlertID: input.AlertID,
+		Type:    models.StatusChangeActivity,
+		UserID:  aws.StringValue(input.UserID),
+		Status:  *input.Status,
+	}
+	if err = alertsDB.AddActivity([]*models.AlertActivity{activity}); err != nil {
+		zap.L().Error("failed to record status change activity", zap.String("alertId", *input.AlertID), zap.Error(err))
+	}
+
 	// Marshal to an alert summary
 	result = alertItemToAlertSummary(alertItem)
 
@@ -57,6 +57,12 @@ func TestUpdateAlert(t *testing.T) {
 	}
 
 	tableMock.On("UpdateAlertStatus", input).Return(output, nil).Once()
+	tableMock.On("AddActivity", []*models.AlertActivity{{
+		AlertID: alertID,
+		Type:    models.StatusChangeActivity,
+		UserID:  *userID,
+		Status:  *status,
+	}}).Return(nil).Once()
 	result, err := API{}.UpdateAlertStatus(input)
 	require.NoError(t, err)
 
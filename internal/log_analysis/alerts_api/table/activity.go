@@ -0,0 +1,162 @@
+package table
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+	"github.com/panther-labs/panther/pkg/awsbatch/dynamodbbatch"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+const (
+	defaultActivityPageSize = 25
+	maxActivityWriteBackoff = 30 * time.Second
+)
+
+// AssignAlert - sets or removes the assignee of an alert and returns the updated item
+func (table *AlertsTable) AssignAlert(input *models.AssignAlertInput) (*AlertItem, error) {
+	var updateBuilder expression.UpdateBuilder
+	if input.AssigneeID == "" {
+		updateBuilder = expression.Remove(expression.Name(AssigneeIDKey))
+	} else {
+		updateBuilder = expression.Set(expression.Name(AssigneeIDKey), expression.Value(input.AssigneeID))
+	}
+	updateBuilder = updateBuilder.
+		Set(expression.Name(LastUpdatedByKey), expression.Value(input.UserID)).
+		Set(expression.Name(LastUpdatedByTimeKey), expression.Value(aws.Time(time.Now().UTC())))
+
+	// Only update alerts which already exist
+	conditionBuilder := expression.AttributeExists(expression.Name(AlertIDKey))
+
+	expr, err := buildExpression(updateBuilder, conditionBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	updateItem := dynamodb.UpdateItemInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		Key:                       DynamoItem{AlertIDKey: {S: input.AlertID}},
+		ReturnValues:              aws.String("ALL_NEW"),
+		TableName:                 &table.AlertsTableName,
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+	}
+
+	updatedAlert := &AlertItem{}
+	if err = table.update(updateItem, &updatedAlert); err != nil {
+		return nil, err
+	}
+	return updatedAlert, nil
+}
+
+// AddActivity - appends entries to the activity log of their alerts
+//
+// The ActivityID (and Time, if missing) of each entry is generated here.
+func (table *AlertsTable) AddActivity(activity []*models.AlertActivity) error {
+	if len(activity) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	requests := make([]*dynamodb.WriteRequest, 0, len(activity))
+	for _, entry := range activity {
+		if entry.Time.IsZero() {
+			entry.Time = now
+		}
+		entry.ActivityID = models.NewActivityID(entry.Time, uuid.New().String())
+
+		item, err := dynamodbattribute.MarshalMap(entry)
+		if err != nil {
+			return &genericapi.InternalError{Message: "failed to marshal activity: " + err.Error()}
+		}
+		requests = append(requests, &dynamodb.WriteRequest{PutRequest: &dynamodb.PutRequest{Item: item}})
+	}
+
+	input := &dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]*dynamodb.WriteRequest{table.ActivityTableName: requests},
+	}
+	if err := dynamodbbatch.BatchWriteItem(table.Client, maxActivityWriteBackoff, input); err != nil {
+		return &genericapi.AWSError{Method: "dynamodb.BatchWriteItem", Err: err}
+	}
+	return nil
+}
+
+// ListActivity - lists a page of the activity log of an alert, oldest first
+func (table *AlertsTable) ListActivity(
+	input *models.ListAlertActivityInput) ([]*models.AlertActivity, *string, error) {
+
+	keyCondition := expression.Key(ActivityAlertIDKey).Equal(expression.Value(input.AlertID))
+	queryExpression, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to build expression")
+	}
+
+	var exclusiveStartKey DynamoItem
+	if input.ExclusiveStartKey != nil {
+		if err = jsoniter.UnmarshalFromString(*input.ExclusiveStartKey, &exclusiveStartKey); err != nil {
+			return nil, nil, &genericapi.InvalidInputError{Message: "invalid exclusiveStartKey: " + err.Error()}
+		}
+	}
+
+	pageSize := defaultActivityPageSize
+	if input.PageSize != nil {
+		pageSize = *input.PageSize
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		TableName:                 &table.ActivityTableName,
+		ExpressionAttributeNames:  queryExpression.Names(),
+		ExpressionAttributeValues: queryExpression.Values(),
+		KeyConditionExpression:    queryExpression.KeyCondition(),
+		ExclusiveStartKey:         exclusiveStartKey,
+		Limit:                     aws.Int64(int64(pageSize)),
+		ScanIndexForward:          aws.Bool(true),
+	}
+
+	response, err := table.Client.Query(queryInput)
+	if err != nil {
+		return nil, nil, &genericapi.AWSError{Method: "dynamodb.Query", Err: err}
+	}
+
+	var activity []*models.AlertActivity
+	if err = dynamodbattribute.UnmarshalListOfMaps(response.Items, &activity); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to unmarshal activity")
+	}
+
+	var lastEvaluatedKey *string
+	if len(response.LastEvaluatedKey) > 0 {
+		serialized, err := jsoniter.MarshalToString(response.LastEvaluatedKey)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to marshal LastEvaluatedKey")
+		}
+		lastEvaluatedKey = &serialized
+	}
+	return activity, lastEvaluatedKey, nil
+}
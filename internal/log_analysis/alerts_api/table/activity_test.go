@@ -0,0 +1,178 @@
+package table
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+)
+
+func (m *mockDynamoDB) Query(input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*dynamodb.QueryOutput), args.Error(1)
+}
+
+func (m *mockDynamoDB) BatchWriteItem(input *dynamodb.BatchWriteItemInput) (*dynamodb.BatchWriteItemOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*dynamodb.BatchWriteItemOutput), args.Error(1)
+}
+
+func TestAssignAlert(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{AlertsTableName: "alertsTableName", Client: mockDdbClient}
+
+	expectedAlert := &AlertItem{AlertID: "alertId", AssigneeID: "assigneeId"}
+	item, err := dynamodbattribute.MarshalMap(expectedAlert)
+	require.NoError(t, err)
+	mockDdbClient.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{Attributes: item}, nil).Once()
+
+	result, err := table.AssignAlert(&models.AssignAlertInput{
+		AlertID:    aws.String("alertId"),
+		AssigneeID: "assigneeId",
+		UserID:     aws.String("userId"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, expectedAlert, result)
+
+	request := mockDdbClient.Calls[0].Arguments.Get(0).(*dynamodb.UpdateItemInput)
+	assert.Equal(t, "alertId", *request.Key[AlertIDKey].S)
+	assert.Contains(t, *request.UpdateExpression, "SET")
+	assert.NotContains(t, *request.UpdateExpression, "REMOVE")
+	mockDdbClient.AssertExpectations(t)
+}
+
+func TestUnassignAlert(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{AlertsTableName: "alertsTableName", Client: mockDdbClient}
+
+	mockDdbClient.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+
+	_, err := table.AssignAlert(&models.AssignAlertInput{AlertID: aws.String("alertId"), UserID: aws.String("userId")})
+	require.NoError(t, err)
+
+	request := mockDdbClient.Calls[0].Arguments.Get(0).(*dynamodb.UpdateItemInput)
+	assert.Contains(t, *request.UpdateExpression, "REMOVE")
+	mockDdbClient.AssertExpectations(t)
+}
+
+func TestAddActivity(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{ActivityTableName: "activityTableName", Client: mockDdbClient}
+
+	mockDdbClient.On("BatchWriteItem", mock.Anything).Return(&dynamodb.BatchWriteItemOutput{}, nil).Once()
+
+	first := &models.AlertActivity{AlertID: aws.String("alertId"), Type: models.CommentActivity, Comment: "comment"}
+	second := &models.AlertActivity{
+		AlertID: aws.String("alertId"),
+		Type:    models.DeliveryActivity,
+		Time:    time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, table.AddActivity([]*models.AlertActivity{first, second}))
+
+	assert.False(t, first.Time.IsZero())
+	assert.NotEmpty(t, first.ActivityID)
+	assert.Contains(t, second.ActivityID, "2020-01-01T00:00:00.000000000Z#")
+	assert.True(t, second.ActivityID < first.ActivityID)
+
+	request := mockDdbClient.Calls[0].Arguments.Get(0).(*dynamodb.BatchWriteItemInput)
+	require.Len(t, request.RequestItems["activityTableName"], 2)
+	item := request.RequestItems["activityTableName"][0].PutRequest.Item
+	assert.Equal(t, "alertId", *item[ActivityAlertIDKey].S)
+	assert.Equal(t, first.ActivityID, *item[ActivityIDKey].S)
+	mockDdbClient.AssertExpectations(t)
+}
+
+func TestAddActivityError(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{ActivityTableName: "activityTableName", Client: mockDdbClient}
+
+	mockDdbClient.On("BatchWriteItem", mock.Anything).Return(&dynamodb.BatchWriteItemOutput{}, errors.New("test"))
+
+	err := table.AddActivity([]*models.AlertActivity{{AlertID: aws.String("alertId"), Type: models.CommentActivity}})
+	assert.Error(t, err)
+}
+
+func TestListActivity(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{ActivityTableName: "activityTableName", Client: mockDdbClient}
+
+	expected := &models.AlertActivity{
+		AlertID:    aws.String("alertId"),
+		ActivityID: "2020-01-01T00:00:00.000000000Z#id",
+		Type:       models.StatusChangeActivity,
+		Time:       time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		UserID:     "userId",
+		Status:     models.TriagedStatus,
+	}
+	item, err := dynamodbattribute.MarshalMap(expected)
+	require.NoError(t, err)
+	lastKey := DynamoItem{
+		ActivityAlertIDKey: {S: aws.String("alertId")},
+		ActivityIDKey:      {S: aws.String(expected.ActivityID)},
+	}
+	mockDdbClient.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{
+		Items:            []DynamoItem{item},
+		LastEvaluatedKey: lastKey,
+	}, nil).Once()
+
+	activity, lastEvaluatedKey, err := table.ListActivity(&models.ListAlertActivityInput{
+		AlertID:  aws.String("alertId"),
+		PageSize: aws.Int(1),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*models.AlertActivity{expected}, activity)
+	require.NotNil(t, lastEvaluatedKey)
+
+	request := mockDdbClient.Calls[0].Arguments.Get(0).(*dynamodb.QueryInput)
+	assert.Equal(t, int64(1), *request.Limit)
+	assert.True(t, *request.ScanIndexForward)
+
+	// The returned key can be used to get the next page
+	mockDdbClient.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{}, nil).Once()
+	activity, lastEvaluatedKey, err = table.ListActivity(&models.ListAlertActivityInput{
+		AlertID:           aws.String("alertId"),
+		ExclusiveStartKey: lastEvaluatedKey,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, activity)
+	assert.Nil(t, lastEvaluatedKey)
+	request = mockDdbClient.Calls[1].Arguments.Get(0).(*dynamodb.QueryInput)
+	assert.Equal(t, lastKey, request.ExclusiveStartKey)
+	mockDdbClient.AssertExpectations(t)
+}
+
+func TestListActivityInvalidStartKey(t *testing.T) {
+	table := AlertsTable{ActivityTableName: "activityTableName", Client: &mockDynamoDB{}}
+
+	_, _, err := table.ListActivity(&models.ListAlertActivityInput{
+		AlertID:           aws.String("alertId"),
+		ExclusiveStartKey: aws.String("not json"),
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,104 @@
+package table
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+// Alerts are updated in parallel, with this many requests in flight
+const bulkUpdateConcurrency = 10
+
+// BulkUpdateAlertStatus - sets the status of many alerts and returns the updated items
+//
+// Each alert is updated on its own so that only the status attributes are written: the alert
+// forwarder keeps updating the event count of open alerts at the same time.
+// Alerts which do not exist or could not be updated are returned as failures.
+func (table *AlertsTable) BulkUpdateAlertStatus(
+	alertIDs []string, status, userID string) ([]*AlertItem, []*models.BulkUpdateFailure, error) {
+
+	if len(alertIDs) == 0 {
+		return nil, nil, nil
+	}
+
+	// Same changes as UpdateAlertStatus, but only for alerts which exist
+	updateBuilder := createUpdateBuilder(&models.UpdateAlertStatusInput{Status: &status, UserID: &userID})
+	conditionBuilder := expression.AttributeExists(expression.Name(AlertIDKey))
+	expr, err := buildExpression(updateBuilder, conditionBuilder)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	alerts := make([]*AlertItem, len(alertIDs))
+	errs := make([]error, len(alertIDs))
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < bulkUpdateConcurrency && i < len(alertIDs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indices {
+				alerts[index] = &AlertItem{}
+				errs[index] = table.update(dynamodb.UpdateItemInput{
+					ConditionExpression:       expr.Condition(),
+					ExpressionAttributeNames:  expr.Names(),
+					ExpressionAttributeValues: expr.Values(),
+					Key:                       DynamoItem{AlertIDKey: {S: aws.String(alertIDs[index])}},
+					ReturnValues:              aws.String(dynamodb.ReturnValueAllNew),
+					TableName:                 &table.AlertsTableName,
+					UpdateExpression:          expr.Update(),
+				}, alerts[index])
+			}
+		}()
+	}
+	for i := range alertIDs {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	var updated []*AlertItem
+	var failures []*models.BulkUpdateFailure
+	for i, alertID := range alertIDs {
+		if errs[i] != nil {
+			failures = append(failures, &models.BulkUpdateFailure{AlertID: alertID, Error: bulkUpdateError(errs[i])})
+			continue
+		}
+		updated = append(updated, alerts[i])
+	}
+	return updated, failures, nil
+}
+
+// bulkUpdateError - the error message reported for an alert which failed to update
+func bulkUpdateError(err error) string {
+	if apiErr, ok := err.(*genericapi.AWSError); ok {
+		if awsErr, ok := apiErr.Err.(awserr.Error); ok && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return "alert does not exist"
+		}
+	}
+	return err.Error()
+}
@@ -0,0 +1,119 @@
+package table
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+)
+
+// updateAlertKey matches the update of a single alert
+func updateAlertKey(alertID string) interface{} {
+	return mock.MatchedBy(func(input *dynamodb.UpdateItemInput) bool {
+		return *input.Key[AlertIDKey].S == alertID
+	})
+}
+
+func updatedAlertOutput(t *testing.T, alert *AlertItem) *dynamodb.UpdateItemOutput {
+	item, err := dynamodbattribute.MarshalMap(alert)
+	require.NoError(t, err)
+	return &dynamodb.UpdateItemOutput{Attributes: item}
+}
+
+func TestBulkUpdateAlertStatus(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{AlertsTableName: "alertsTableName", Client: mockDdbClient}
+
+	mockDdbClient.On("UpdateItem", updateAlertKey("alert-1")).Return(updatedAlertOutput(t,
+		&AlertItem{AlertID: "alert-1", Status: models.ClosedStatus, EventCount: 5}), nil).Once()
+	mockDdbClient.On("UpdateItem", updateAlertKey("alert-2")).Return(updatedAlertOutput(t,
+		&AlertItem{AlertID: "alert-2", Status: models.ClosedStatus, EventCount: 10}), nil).Once()
+	mockDdbClient.On("UpdateItem", updateAlertKey("alert-3")).Return(&dynamodb.UpdateItemOutput{},
+		awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "", nil)).Once()
+
+	updated, failures, err := table.BulkUpdateAlertStatus(
+		[]string{"alert-1", "alert-2", "alert-3"}, models.ClosedStatus, "userId")
+	require.NoError(t, err)
+
+	require.Len(t, updated, 2)
+	assert.Equal(t, "alert-1", updated[0].AlertID)
+	assert.Equal(t, "alert-2", updated[1].AlertID)
+	assert.Equal(t, 10, updated[1].EventCount)
+	assert.Equal(t, []*models.BulkUpdateFailure{{AlertID: "alert-3", Error: "alert does not exist"}}, failures)
+	mockDdbClient.AssertExpectations(t)
+
+	// Only the status attributes are written, and only to existing alerts
+	for _, call := range mockDdbClient.Calls {
+		request := call.Arguments.Get(0).(*dynamodb.UpdateItemInput)
+		assert.Equal(t, "alertsTableName", *request.TableName)
+		assert.Contains(t, *request.ConditionExpression, "attribute_exists")
+		assert.NotContains(t, request.ExpressionAttributeNames, "eventCount")
+		assert.Contains(t, *request.UpdateExpression, "REMOVE") // ticketSync
+	}
+}
+
+func TestBulkUpdateAlertStatusOpen(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{AlertsTableName: "alertsTableName", Client: mockDdbClient}
+
+	mockDdbClient.On("UpdateItem", updateAlertKey("alert-1")).Return(updatedAlertOutput(t,
+		&AlertItem{AlertID: "alert-1"}), nil).Once()
+
+	updated, failures, err := table.BulkUpdateAlertStatus([]string{"alert-1"}, models.OpenStatus, "userId")
+	require.NoError(t, err)
+	assert.Empty(t, failures)
+	require.Len(t, updated, 1)
+	assert.Empty(t, updated[0].Status)
+
+	request := mockDdbClient.Calls[0].Arguments.Get(0).(*dynamodb.UpdateItemInput)
+	assert.Contains(t, *request.UpdateExpression, "REMOVE #1")
+	assert.Equal(t, StatusKey, *request.ExpressionAttributeNames["#1"])
+}
+
+func TestBulkUpdateAlertStatusWriteFailure(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{AlertsTableName: "alertsTableName", Client: mockDdbClient}
+
+	alertIDs := make([]string, 30)
+	for i := range alertIDs {
+		alertIDs[i] = string(rune('a'+i%26)) + string(rune('0'+i/26))
+		if i == 25 {
+			mockDdbClient.On("UpdateItem", updateAlertKey(alertIDs[i])).Return(
+				&dynamodb.UpdateItemOutput{}, errors.New("test")).Once()
+			continue
+		}
+		mockDdbClient.On("UpdateItem", updateAlertKey(alertIDs[i])).Return(updatedAlertOutput(t,
+			&AlertItem{AlertID: alertIDs[i], Status: models.ResolvedStatus}), nil).Once()
+	}
+
+	updated, failures, err := table.BulkUpdateAlertStatus(alertIDs, models.ResolvedStatus, "userId")
+	require.NoError(t, err)
+	assert.Len(t, updated, 29)
+	assert.Equal(t, []*models.BulkUpdateFailure{{AlertID: alertIDs[25], Error: "test"}}, failures)
+	mockDdbClient.AssertExpectations(t)
+}
@@ -0,0 +1,115 @@
+package table
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+// PutFilter - saves an alert filter, mustExist is set when replacing an existing filter
+func (table *AlertsTable) PutFilter(filter *models.AlertFilter, mustExist bool) error {
+	item, err := dynamodbattribute.MarshalMap(filter)
+	if err != nil {
+		return &genericapi.InternalError{Message: "failed to marshal filter: " + err.Error()}
+	}
+
+	input := &dynamodb.PutItemInput{Item: item, TableName: &table.FiltersTableName}
+	if mustExist {
+		condition, err := expression.NewBuilder().
+			WithCondition(expression.AttributeExists(expression.Name(FilterIDKey))).
+			Build()
+		if err != nil {
+			return &genericapi.InternalError{Message: "failed to build condition: " + err.Error()}
+		}
+		input.ConditionExpression = condition.Condition()
+		input.ExpressionAttributeNames = condition.Names()
+	}
+
+	if _, err = table.Client.PutItem(input); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return &genericapi.DoesNotExistError{Message: "filterId=" + filter.FilterID}
+		}
+		return &genericapi.AWSError{Method: "dynamodb.PutItem", Err: err}
+	}
+	return nil
+}
+
+// ListFilters - lists the saved alert filters of a user, sorted by name
+func (table *AlertsTable) ListFilters(userID string) ([]*models.AlertFilter, error) {
+	keyCondition := expression.Key(FilterUserIDKey).Equal(expression.Value(userID))
+	queryExpression, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build expression")
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		TableName:                 &table.FiltersTableName,
+		ExpressionAttributeNames:  queryExpression.Names(),
+		ExpressionAttributeValues: queryExpression.Values(),
+		KeyConditionExpression:    queryExpression.KeyCondition(),
+	}
+
+	var result []*models.AlertFilter
+	var unmarshalErr error
+	err = table.Client.QueryPages(queryInput, func(page *dynamodb.QueryOutput, isLast bool) bool {
+		var filters []*models.AlertFilter
+		if unmarshalErr = dynamodbattribute.UnmarshalListOfMaps(page.Items, &filters); unmarshalErr != nil {
+			return false
+		}
+		result = append(result, filters...)
+		return true
+	})
+	if err != nil {
+		return nil, &genericapi.AWSError{Method: "dynamodb.QueryPages", Err: err}
+	}
+	if unmarshalErr != nil {
+		return nil, errors.Wrap(unmarshalErr, "failed to unmarshal filters")
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return strings.ToLower(result[i].Name) < strings.ToLower(result[j].Name)
+	})
+	return result, nil
+}
+
+// DeleteFilter - deletes a saved alert filter
+func (table *AlertsTable) DeleteFilter(userID, filterID string) error {
+	_, err := table.Client.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: DynamoItem{
+			FilterUserIDKey: {S: aws.String(userID)},
+			FilterIDKey:     {S: aws.String(filterID)},
+		},
+		TableName: &table.FiltersTableName,
+	})
+	if err != nil {
+		return &genericapi.AWSError{Method: "dynamodb.DeleteItem", Err: err}
+	}
+	return nil
+}
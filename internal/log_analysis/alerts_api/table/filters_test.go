@@ -0,0 +1,119 @@
+package table
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+func (m *mockDynamoDB) PutItem(input *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*dynamodb.PutItemOutput), args.Error(1)
+}
+
+func (m *mockDynamoDB) DeleteItem(input *dynamodb.DeleteItemInput) (*dynamodb.DeleteItemOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*dynamodb.DeleteItemOutput), args.Error(1)
+}
+
+func TestPutFilter(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{FiltersTableName: "filtersTableName", Client: mockDdbClient}
+
+	filter := &models.AlertFilter{
+		UserID:   "userId",
+		FilterID: "filterId",
+		Name:     "open",
+		Filter:   &models.ListAlertsInput{Status: []string{models.OpenStatus}},
+	}
+	mockDdbClient.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{}, nil).Once()
+	require.NoError(t, table.PutFilter(filter, false))
+
+	request := mockDdbClient.Calls[0].Arguments.Get(0).(*dynamodb.PutItemInput)
+	assert.Equal(t, "filtersTableName", *request.TableName)
+	assert.Equal(t, "userId", *request.Item[FilterUserIDKey].S)
+	assert.Equal(t, "filterId", *request.Item[FilterIDKey].S)
+	assert.Nil(t, request.ConditionExpression)
+	mockDdbClient.AssertExpectations(t)
+}
+
+func TestPutFilterDoesNotExist(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{FiltersTableName: "filtersTableName", Client: mockDdbClient}
+
+	mockDdbClient.On("PutItem", mock.Anything).Return(&dynamodb.PutItemOutput{},
+		awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "", nil)).Once()
+
+	err := table.PutFilter(&models.AlertFilter{UserID: "userId", FilterID: "filterId"}, true)
+	assert.IsType(t, &genericapi.DoesNotExistError{}, err)
+
+	request := mockDdbClient.Calls[0].Arguments.Get(0).(*dynamodb.PutItemInput)
+	assert.NotNil(t, request.ConditionExpression)
+	mockDdbClient.AssertExpectations(t)
+}
+
+func TestListFilters(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{FiltersTableName: "filtersTableName", Client: mockDdbClient}
+
+	var items []DynamoItem
+	for _, name := range []string{"b", "C", "a"} {
+		item, err := dynamodbattribute.MarshalMap(&models.AlertFilter{UserID: "userId", FilterID: name, Name: name})
+		require.NoError(t, err)
+		items = append(items, item)
+	}
+	mockDdbClient.On("QueryPages", mock.Anything, mock.Anything).Return(&dynamodb.QueryOutput{Items: items}, nil).Once()
+
+	result, err := table.ListFilters("userId")
+	require.NoError(t, err)
+	require.Len(t, result, 3)
+	assert.Equal(t, []string{"a", "b", "C"}, []string{result[0].Name, result[1].Name, result[2].Name})
+	mockDdbClient.AssertExpectations(t)
+}
+
+func TestDeleteFilter(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{FiltersTableName: "filtersTableName", Client: mockDdbClient}
+
+	expected := &dynamodb.DeleteItemInput{
+		Key: DynamoItem{
+			FilterUserIDKey: {S: aws.String("userId")},
+			FilterIDKey:     {S: aws.String("filterId")},
+		},
+		TableName: aws.String("filtersTableName"),
+	}
+	mockDdbClient.On("DeleteItem", expected).Return(&dynamodb.DeleteItemOutput{}, nil).Once()
+	require.NoError(t, table.DeleteFilter("userId", "filterId"))
+
+	mockDdbClient.On("DeleteItem", mock.Anything).Return(&dynamodb.DeleteItemOutput{}, errors.New("test")).Once()
+	assert.Error(t, table.DeleteFilter("userId", "filterId"))
+	mockDdbClient.AssertExpectations(t)
+}
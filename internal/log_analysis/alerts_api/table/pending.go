@@ -0,0 +1,111 @@
+package table
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+const defaultPendingAlertsPageSize = 25
+
+// ListPendingAlerts - lists a page of the dedup groups which have not reached their rule threshold
+//
+// Entries are expired by Dynamo some time after their dedup window ends, so they are also filtered here.
+// This means a page can have fewer entries than the page size even if there are more pending alerts.
+func (table *AlertsTable) ListPendingAlerts(
+	input *models.ListPendingAlertsInput) ([]*models.PendingAlert, *string, error) {
+
+	builder := expression.NewBuilder().WithFilter(
+		expression.Name(PendingTTLKey).GreaterThan(expression.Value(time.Now().Unix())))
+	if input.RuleID != nil {
+		builder = builder.WithKeyCondition(expression.Key(PendingRuleIDKey).Equal(expression.Value(*input.RuleID)))
+	}
+	expr, err := builder.Build()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to build expression")
+	}
+
+	var exclusiveStartKey DynamoItem
+	if input.ExclusiveStartKey != nil {
+		if err = jsoniter.UnmarshalFromString(*input.ExclusiveStartKey, &exclusiveStartKey); err != nil {
+			return nil, nil, &genericapi.InvalidInputError{Message: "invalid exclusiveStartKey: " + err.Error()}
+		}
+	}
+
+	pageSize := defaultPendingAlertsPageSize
+	if input.PageSize != nil {
+		pageSize = *input.PageSize
+	}
+
+	var items []DynamoItem
+	var lastKey DynamoItem
+	if input.RuleID != nil {
+		response, err := table.Client.Query(&dynamodb.QueryInput{
+			TableName:                 &table.PendingAlertsTableName,
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+			KeyConditionExpression:    expr.KeyCondition(),
+			FilterExpression:          expr.Filter(),
+			ExclusiveStartKey:         exclusiveStartKey,
+			Limit:                     aws.Int64(int64(pageSize)),
+		})
+		if err != nil {
+			return nil, nil, &genericapi.AWSError{Method: "dynamodb.Query", Err: err}
+		}
+		items, lastKey = response.Items, response.LastEvaluatedKey
+	} else {
+		response, err := table.Client.Scan(&dynamodb.ScanInput{
+			TableName:                 &table.PendingAlertsTableName,
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+			FilterExpression:          expr.Filter(),
+			ExclusiveStartKey:         exclusiveStartKey,
+			Limit:                     aws.Int64(int64(pageSize)),
+		})
+		if err != nil {
+			return nil, nil, &genericapi.AWSError{Method: "dynamodb.Scan", Err: err}
+		}
+		items, lastKey = response.Items, response.LastEvaluatedKey
+	}
+
+	var pendingAlerts []*models.PendingAlert
+	if err = dynamodbattribute.UnmarshalListOfMaps(items, &pendingAlerts); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to unmarshal pending alerts")
+	}
+
+	var lastEvaluatedKey *string
+	if len(lastKey) > 0 {
+		serialized, err := jsoniter.MarshalToString(lastKey)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to marshal LastEvaluatedKey")
+		}
+		lastEvaluatedKey = &serialized
+	}
+	return pendingAlerts, lastEvaluatedKey, nil
+}
@@ -0,0 +1,86 @@
+package table
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+)
+
+func (m *mockDynamoDB) Scan(input *dynamodb.ScanInput) (*dynamodb.ScanOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*dynamodb.ScanOutput), args.Error(1)
+}
+
+func TestListPendingAlertsByRule(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{PendingAlertsTableName: "pendingTableName", Client: mockDdbClient}
+
+	mockDdbClient.On("Query", mock.Anything).Return(&dynamodb.QueryOutput{
+		Items: []map[string]*dynamodb.AttributeValue{
+			{
+				"ruleId":     {S: aws.String("My.Rule")},
+				"dedup":      {S: aws.String("dedup")},
+				"eventCount": {N: aws.String("3")},
+				"threshold":  {N: aws.String("10")},
+			},
+		},
+		LastEvaluatedKey: map[string]*dynamodb.AttributeValue{"ruleId": {S: aws.String("My.Rule")}},
+	}, nil).Once()
+
+	result, lastKey, err := table.ListPendingAlerts(&models.ListPendingAlertsInput{RuleID: aws.String("My.Rule")})
+	require.NoError(t, err)
+	assert.Equal(t, []*models.PendingAlert{{RuleID: "My.Rule", DedupString: "dedup", EventCount: 3, Threshold: 10}}, result)
+	assert.NotNil(t, lastKey)
+
+	request := mockDdbClient.Calls[0].Arguments.Get(0).(*dynamodb.QueryInput)
+	assert.Equal(t, "pendingTableName", *request.TableName)
+	assert.Equal(t, int64(defaultPendingAlertsPageSize), *request.Limit)
+	assert.NotNil(t, request.FilterExpression)
+	mockDdbClient.AssertExpectations(t)
+}
+
+func TestListPendingAlertsAllRules(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{PendingAlertsTableName: "pendingTableName", Client: mockDdbClient}
+
+	mockDdbClient.On("Scan", mock.Anything).Return(&dynamodb.ScanOutput{}, nil).Once()
+
+	result, lastKey, err := table.ListPendingAlerts(&models.ListPendingAlertsInput{PageSize: aws.Int(10)})
+	require.NoError(t, err)
+	assert.Empty(t, result)
+	assert.Nil(t, lastKey)
+
+	request := mockDdbClient.Calls[0].Arguments.Get(0).(*dynamodb.ScanInput)
+	assert.Equal(t, int64(10), *request.Limit)
+	mockDdbClient.AssertExpectations(t)
+}
+
+func TestListPendingAlertsInvalidStartKey(t *testing.T) {
+	table := AlertsTable{PendingAlertsTableName: "pendingTableName", Client: &mockDynamoDB{}}
+	_, _, err := table.ListPendingAlerts(&models.ListPendingAlertsInput{ExclusiveStartKey: aws.String("not json")})
+	assert.Error(t, err)
+}
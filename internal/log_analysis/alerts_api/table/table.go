@@ -40,6 +40,23 @@ const (
 	StatusKey            = "status"
 	LastUpdatedByKey     = "lastUpdatedBy"
 	LastUpdatedByTimeKey = "lastUpdatedByTime"
+	TicketsKey           = "tickets"
+	// TicketSyncKey is the hash key of a sparse index holding only alerts with tickets that are still synced
+	TicketSyncKey   = "ticketSync"
+	TicketSyncValue = "OPEN"
+	AssigneeIDKey   = "assigneeId"
+
+	// Keys of the alert activity table
+	ActivityAlertIDKey = "alertId"
+	ActivityIDKey      = "activityId"
+
+	// Keys of the saved alert filters table
+	FilterUserIDKey = "userId"
+	FilterIDKey     = "filterId"
+
+	// Keys of the pending alerts table
+	PendingRuleIDKey = "ruleId"
+	PendingTTLKey    = "ttl"
 )
 
 // API defines the interface for the alerts table which can be used for mocking.
@@ -47,6 +64,17 @@ type API interface {
 	GetAlert(*string) (*AlertItem, error)
 	ListAll(*models.ListAlertsInput) ([]*AlertItem, *string, error)
 	UpdateAlertStatus(*models.UpdateAlertStatusInput) (*AlertItem, error)
+	AddAlertTicket(*models.AddAlertTicketInput) (*AlertItem, error)
+	ListTicketedAlerts() ([]*AlertItem, error)
+	StopTicketSync(alertID string) error
+	AssignAlert(*models.AssignAlertInput) (*AlertItem, error)
+	AddActivity([]*models.AlertActivity) error
+	ListActivity(*models.ListAlertActivityInput) ([]*models.AlertActivity, *string, error)
+	BulkUpdateAlertStatus(alertIDs []string, status, userID string) ([]*AlertItem, []*models.BulkUpdateFailure, error)
+	PutFilter(filter *models.AlertFilter, mustExist bool) error
+	ListFilters(userID string) ([]*models.AlertFilter, error)
+	DeleteFilter(userID, filterID string) error
+	ListPendingAlerts(*models.ListPendingAlertsInput) ([]*models.PendingAlert, *string, error)
 }
 
 // AlertsTable encapsulates a connection to the Dynamo alerts table.
@@ -54,6 +82,10 @@ type AlertsTable struct {
 	AlertsTableName                    string
 	RuleIDCreationTimeIndexName        string
 	TimePartitionCreationTimeIndexName string
+	TicketSyncIndexName                string
+	ActivityTableName                  string
+	FiltersTableName                   string
+	PendingAlertsTableName             string
 	Client                             dynamodbiface.DynamoDBAPI
 }
 
@@ -82,4 +114,8 @@ type AlertItem struct {
 	LastUpdatedBy string `json:"lastUpdatedBy"`
 	// LastUpdatedByTime - stores the timestamp of the last person who modified the Alert
 	LastUpdatedByTime time.Time `json:"lastUpdatedByTime"`
+	// Tickets - issues created for this alert in external ticketing systems
+	Tickets []*models.Ticket `json:"tickets,omitempty"`
+	// AssigneeID - the user responsible for the alert
+	AssigneeID string `json:"assigneeId,omitempty"`
 }
@@ -0,0 +1,129 @@
+package table
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+	"github.com/panther-labs/panther/pkg/genericapi"
+)
+
+// AddAlertTicket - appends a ticket to an alert and marks the alert for ticket sync
+func (table *AlertsTable) AddAlertTicket(input *models.AddAlertTicketInput) (*AlertItem, error) {
+	ticket, err := dynamodbattribute.Marshal(input.Ticket)
+	if err != nil {
+		return nil, &genericapi.InternalError{Message: "failed to marshal ticket: " + err.Error()}
+	}
+
+	tickets := expression.Name(TicketsKey)
+	updateBuilder := expression.
+		Set(tickets, expression.ListAppend(
+			expression.IfNotExists(tickets, expression.Value([]*dynamodb.AttributeValue{})),
+			expression.Value([]*dynamodb.AttributeValue{ticket}),
+		)).
+		Set(expression.Name(TicketSyncKey), expression.Value(TicketSyncValue))
+
+	// Only update alerts which already exist
+	conditionBuilder := expression.AttributeExists(expression.Name(AlertIDKey))
+
+	expr, err := buildExpression(updateBuilder, conditionBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	updateItem := dynamodb.UpdateItemInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		Key:                       DynamoItem{AlertIDKey: {S: input.AlertID}},
+		ReturnValues:              aws.String("ALL_NEW"),
+		TableName:                 &table.AlertsTableName,
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+	}
+
+	updatedAlert := &AlertItem{}
+	if err = table.update(updateItem, &updatedAlert); err != nil {
+		return nil, err
+	}
+	return updatedAlert, nil
+}
+
+// StopTicketSync - removes an alert from the ticket sync index
+func (table *AlertsTable) StopTicketSync(alertID string) error {
+	updateBuilder := expression.Remove(expression.Name(TicketSyncKey))
+	conditionBuilder := expression.AttributeExists(expression.Name(AlertIDKey))
+
+	expr, err := buildExpression(updateBuilder, conditionBuilder)
+	if err != nil {
+		return err
+	}
+
+	_, err = table.Client.UpdateItem(&dynamodb.UpdateItemInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		Key:                       DynamoItem{AlertIDKey: {S: &alertID}},
+		TableName:                 &table.AlertsTableName,
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+	})
+	if err != nil {
+		return &genericapi.AWSError{Method: "dynamodb.UpdateItem", Err: err}
+	}
+	return nil
+}
+
+// ListTicketedAlerts - lists all alerts with tickets which are still synced
+func (table *AlertsTable) ListTicketedAlerts() ([]*AlertItem, error) {
+	keyCondition := expression.Key(TicketSyncKey).Equal(expression.Value(TicketSyncValue))
+	queryExpression, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build expression")
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		TableName:                 &table.AlertsTableName,
+		IndexName:                 &table.TicketSyncIndexName,
+		ExpressionAttributeNames:  queryExpression.Names(),
+		ExpressionAttributeValues: queryExpression.Values(),
+		KeyConditionExpression:    queryExpression.KeyCondition(),
+	}
+
+	var result []*AlertItem
+	var unmarshalErr error
+	err = table.Client.QueryPages(queryInput, func(page *dynamodb.QueryOutput, isLast bool) bool {
+		var items []*AlertItem
+		if unmarshalErr = dynamodbattribute.UnmarshalListOfMaps(page.Items, &items); unmarshalErr != nil {
+			return false
+		}
+		result = append(result, items...)
+		return true
+	})
+	if err != nil {
+		return nil, &genericapi.AWSError{Method: "dynamodb.QueryPages", Err: err}
+	}
+	if unmarshalErr != nil {
+		return nil, errors.Wrap(unmarshalErr, "failed to unmarshal alerts")
+	}
+	return result, nil
+}
@@ -0,0 +1,159 @@
+package table
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/api/lambda/alerts/models"
+)
+
+func (m *mockDynamoDB) UpdateItem(input *dynamodb.UpdateItemInput) (*dynamodb.UpdateItemOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*dynamodb.UpdateItemOutput), args.Error(1)
+}
+
+func (m *mockDynamoDB) QueryPages(input *dynamodb.QueryInput, fn func(*dynamodb.QueryOutput, bool) bool) error {
+	args := m.Called(input, fn)
+	if output := args.Get(0); output != nil {
+		fn(output.(*dynamodb.QueryOutput), true)
+	}
+	return args.Error(1)
+}
+
+func TestAddAlertTicket(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{AlertsTableName: "alertsTableName", Client: mockDdbClient}
+
+	ticket := &models.Ticket{
+		OutputID:   "outputId",
+		OutputType: "jira",
+		Key:        "SEC-1",
+		CreatedAt:  time.Now().UTC(),
+	}
+	expectedAlert := &AlertItem{AlertID: "alertId", Tickets: []*models.Ticket{ticket}}
+	item, err := dynamodbattribute.MarshalMap(expectedAlert)
+	require.NoError(t, err)
+
+	mockDdbClient.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{Attributes: item}, nil).Once()
+
+	result, err := table.AddAlertTicket(&models.AddAlertTicketInput{AlertID: aws.String("alertId"), Ticket: ticket})
+	require.NoError(t, err)
+	assert.Equal(t, expectedAlert, result)
+
+	request := mockDdbClient.Calls[0].Arguments.Get(0).(*dynamodb.UpdateItemInput)
+	assert.Equal(t, "alertId", *request.Key[AlertIDKey].S)
+	assert.Contains(t, *request.UpdateExpression, "list_append")
+	mockDdbClient.AssertExpectations(t)
+}
+
+func TestAddAlertTicketError(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{AlertsTableName: "alertsTableName", Client: mockDdbClient}
+
+	mockDdbClient.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, errors.New("test")).Once()
+
+	_, err := table.AddAlertTicket(&models.AddAlertTicketInput{
+		AlertID: aws.String("alertId"),
+		Ticket:  &models.Ticket{OutputID: "outputId", OutputType: "github", Key: "1"},
+	})
+	require.Error(t, err)
+}
+
+func TestStopTicketSync(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{AlertsTableName: "alertsTableName", Client: mockDdbClient}
+
+	mockDdbClient.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{}, nil).Once()
+	require.NoError(t, table.StopTicketSync("alertId"))
+
+	request := mockDdbClient.Calls[0].Arguments.Get(0).(*dynamodb.UpdateItemInput)
+	assert.Equal(t, "alertId", *request.Key[AlertIDKey].S)
+	assert.Contains(t, *request.UpdateExpression, "REMOVE")
+	mockDdbClient.AssertExpectations(t)
+}
+
+func TestListTicketedAlerts(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{
+		AlertsTableName:     "alertsTableName",
+		TicketSyncIndexName: "ticketSync-index",
+		Client:              mockDdbClient,
+	}
+
+	expectedAlert := &AlertItem{
+		AlertID: "alertId",
+		Tickets: []*models.Ticket{{OutputID: "outputId", OutputType: "github", Key: "1"}},
+	}
+	item, err := dynamodbattribute.MarshalMap(expectedAlert)
+	require.NoError(t, err)
+
+	mockDdbClient.On("QueryPages", mock.Anything, mock.Anything).
+		Return(&dynamodb.QueryOutput{Items: []map[string]*dynamodb.AttributeValue{item}}, nil).Once()
+
+	result, err := table.ListTicketedAlerts()
+	require.NoError(t, err)
+	assert.Equal(t, []*AlertItem{expectedAlert}, result)
+
+	request := mockDdbClient.Calls[0].Arguments.Get(0).(*dynamodb.QueryInput)
+	assert.Equal(t, "ticketSync-index", *request.IndexName)
+}
+
+func TestListTicketedAlertsError(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{AlertsTableName: "alertsTableName", Client: mockDdbClient}
+
+	mockDdbClient.On("QueryPages", mock.Anything, mock.Anything).Return(nil, errors.New("test")).Once()
+
+	_, err := table.ListTicketedAlerts()
+	require.Error(t, err)
+}
+
+func TestUpdateAlertStatusWithoutUser(t *testing.T) {
+	mockDdbClient := &mockDynamoDB{}
+	table := AlertsTable{AlertsTableName: "alertsTableName", Client: mockDdbClient}
+
+	item, err := dynamodbattribute.MarshalMap(&AlertItem{AlertID: "alertId", Status: models.ResolvedStatus})
+	require.NoError(t, err)
+	mockDdbClient.On("UpdateItem", mock.Anything).Return(&dynamodb.UpdateItemOutput{Attributes: item}, nil).Once()
+
+	_, err = table.UpdateAlertStatus(&models.UpdateAlertStatusInput{
+		AlertID: aws.String("alertId"),
+		Status:  aws.String(models.ResolvedStatus),
+	})
+	require.NoError(t, err)
+
+	// A ticket sync must not overwrite the last user who updated the alert
+	request := mockDdbClient.Calls[0].Arguments.Get(0).(*dynamodb.UpdateItemInput)
+	names := make([]string, 0, len(request.ExpressionAttributeNames))
+	for _, name := range request.ExpressionAttributeNames {
+		names = append(names, *name)
+	}
+	assert.ElementsMatch(t, []string{AlertIDKey, StatusKey, TicketSyncKey}, names)
+	mockDdbClient.AssertExpectations(t)
+}
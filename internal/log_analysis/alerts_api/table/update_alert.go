@@ -69,20 +69,28 @@ func (table *AlertsTable) UpdateAlertStatus(input *models.UpdateAlertStatusInput
 
 // createUpdateBuilder - creates an update builder
 func createUpdateBuilder(input *models.UpdateAlertStatusInput) expression.UpdateBuilder {
-	// When settig an "open" status we actually remove the attribute
-	// for uniformity against previous items in the database
-	// which also do not have a status attribute.
+	var update expression.UpdateBuilder
 	if *input.Status == models.OpenStatus {
-		return expression.
-			Remove(expression.Name(StatusKey)).
+		// When settig an "open" status we actually remove the attribute
+		// for uniformity against previous items in the database
+		// which also do not have a status attribute.
+		update = expression.Remove(expression.Name(StatusKey))
+	} else {
+		update = expression.Set(expression.Name(StatusKey), expression.Value(input.Status))
+	}
+
+	// Ticket syncs have no user behind them, the alert keeps the last user who updated it
+	if input.UserID != nil {
+		update = update.
 			Set(expression.Name(LastUpdatedByKey), expression.Value(input.UserID)).
 			Set(expression.Name(LastUpdatedByTimeKey), expression.Value(aws.Time(time.Now().UTC())))
 	}
 
-	return expression.
-		Set(expression.Name(StatusKey), expression.Value(input.Status)).
-		Set(expression.Name(LastUpdatedByKey), expression.Value(input.UserID)).
-		Set(expression.Name(LastUpdatedByTimeKey), expression.Value(aws.Time(time.Now().UTC())))
+	// Tickets of a closed or resolved alert no longer need to be synced
+	if *input.Status == models.ClosedStatus || *input.Status == models.ResolvedStatus {
+		update = update.Remove(expression.Name(TicketSyncKey))
+	}
+	return update
 }
 
 // createConditionBuilder - creates a condition builder
@@ -30,7 +30,9 @@ import (
 	"github.com/panther-labs/panther/api/lambda/core/log_analysis/log_processor/models"
 	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
 	"github.com/panther-labs/panther/internal/log_analysis/gluetables"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/registry"
 	"github.com/panther-labs/panther/pkg/awsathena"
 )
 
@@ -74,6 +76,18 @@ func GenerateLogViews(tables []*awsglue.GlueTableMetadata) (sqlStatements []stri
 		return nil, err
 	}
 	sqlStatements = append(sqlStatements, sqlStatement)
+	dataModels := make(map[string]logtypes.DataModel, len(tables))
+	for _, table := range tables {
+		if entry := registry.Default().Get(table.LogType()); entry != nil {
+			dataModels[table.LogType()] = entry.DataModel()
+		}
+	}
+	for _, model := range logtypes.Models {
+		sqlStatement = generateViewDataModel(model, tables, dataModels)
+		if sqlStatement != "" {
+			sqlStatements = append(sqlStatements, sqlStatement)
+		}
+	}
 	// add future views here
 	return sqlStatements, nil
 }
@@ -95,6 +109,50 @@ func generateViewAllRuleMatches(tables []*awsglue.GlueTableMetadata) (sql string
 	return generateViewAllHelper("all_rule_matches", ruleTables, awsglue.RuleMatchColumns)
 }
 
+// generateViewDataModel creates a view over all log tables that map fields of a data model.
+// Each canonical field becomes a varchar column, NULL for log types that do not map it.
+// Returns an empty string if no table maps the model.
+func generateViewDataModel(model logtypes.Model, tables []*awsglue.GlueTableMetadata, dataModels map[string]logtypes.DataModel) string {
+	var selects []string
+	for _, table := range tables {
+		dataModel := dataModels[table.LogType()]
+		if !dataModel.Covers(model) {
+			continue
+		}
+		selectColumns := []string{"p_event_time", "p_log_type", "p_row_id"}
+		for _, field := range model.Fields {
+			path, ok := dataModel[field]
+			if !ok {
+				selectColumns = append(selectColumns, "CAST(NULL AS varchar) AS "+field)
+				continue
+			}
+			selectColumns = append(selectColumns, fmt.Sprintf("CAST(%s AS varchar) AS %s", columnExpression(path), field))
+		}
+		for _, partitionKey := range table.PartitionKeys() {
+			selectColumns = append(selectColumns, partitionKey.Name)
+		}
+		selects = append(selects, fmt.Sprintf("select %s from %s.%s",
+			strings.Join(selectColumns, ","), table.DatabaseName(), table.TableName()))
+	}
+	if len(selects) == 0 {
+		return ""
+	}
+
+	sqlLines := []string{fmt.Sprintf("create or replace view %s.data_model_%s as", awsglue.ViewsDatabaseName, model.Name)}
+	sqlLines = append(sqlLines, strings.Join(selects, "\n\tunion all\n"))
+	sqlLines = append(sqlLines, ";\n")
+	return strings.Join(sqlLines, "\n")
+}
+
+// columnExpression converts a JSON path to a quoted Athena column reference (struct fields are dereferenced with '.')
+func columnExpression(path string) string {
+	parts := strings.Split(path, ".")
+	for i, part := range parts {
+		parts[i] = `"` + strings.ToLower(awsglue.RewriteFieldName(part)) + `"`
+	}
+	return strings.Join(parts, ".")
+}
+
 func generateViewAllHelper(viewName string, tables []*awsglue.GlueTableMetadata, extraColumns []awsglue.Column) (sql string, err error) {
 	// validate they all have the same partition keys
 	if len(tables) > 1 {
@@ -26,6 +26,7 @@ import (
 
 	"github.com/panther-labs/panther/api/lambda/core/log_analysis/log_processor/models"
 	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/logtypes"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
 	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers/awslogs"
 )
@@ -70,3 +71,30 @@ func TestGenerateLogsViewsFail(t *testing.T) {
 	require.Error(t, err)
 	require.True(t, strings.Contains(err.Error(), "no tables"))
 }
+
+func TestGenerateViewDataModel(t *testing.T) {
+	table1 := awsglue.NewGlueTableMetadata(models.LogData, "table1", "test table1", awsglue.GlueTableHourly, &table1Event{})
+	table2 := awsglue.NewGlueTableMetadata(models.LogData, "table2", "test table2", awsglue.GlueTableHourly, &table2Event{})
+	table3 := awsglue.NewGlueTableMetadata(models.LogData, "table3", "test table3", awsglue.GlueTableHourly, &table1Event{})
+	dataModels := map[string]logtypes.DataModel{
+		"table1": {logtypes.FieldSourceIP: "client.ipAddress", logtypes.FieldActorUser: "user"},
+		"table2": {logtypes.FieldSourceIP: "srcAddr", logtypes.FieldDestinationPort: "dstPort"},
+	}
+	model := logtypes.Model{
+		Name:   "test",
+		Fields: []string{logtypes.FieldSourceIP, logtypes.FieldDestinationPort},
+	}
+	// nolint (lll)
+	expectedSQL := `create or replace view panther_views.data_model_test as
+select p_event_time,p_log_type,p_row_id,CAST("client"."ipaddress" AS varchar) AS source_ip,CAST(NULL AS varchar) AS destination_port,year,month,day,hour from panther_logs.table1
+	union all
+select p_event_time,p_log_type,p_row_id,CAST("srcaddr" AS varchar) AS source_ip,CAST("dstport" AS varchar) AS destination_port,year,month,day,hour from panther_logs.table2
+;
+`
+	sql := generateViewDataModel(model, []*awsglue.GlueTableMetadata{table1, table2, table3}, dataModels)
+	require.Equal(t, expectedSQL, sql)
+
+	// no table maps the model
+	sql = generateViewDataModel(model, []*awsglue.GlueTableMetadata{table3}, dataModels)
+	require.Empty(t, sql)
+}
@@ -0,0 +1,215 @@
+package engine
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"compress/gzip"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	logmodels "github.com/panther-labs/panther/api/lambda/core/log_analysis/log_processor/models"
+	"github.com/panther-labs/panther/internal/log_analysis/alertdedup"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+)
+
+const (
+	// Fields added to the events of a correlation alert, linking each event back to its original match
+	correlatedRuleIDField  = "p_correlated_rule_id"
+	correlatedAlertIDField = "p_correlated_alert_id"
+	correlationStepField   = "p_correlation_step"
+
+	// Overwritten in each event, the same as the rules engine does for its matches
+	ruleTagsField          = "p_rule_tags"
+	ruleReportsField       = "p_rule_reports"
+	alertCreationTimeField = "p_alert_creation_time"
+	alertUpdateTimeField   = "p_alert_update_time"
+
+	// Same format as the rules engine
+	objectKeyTimeLayout = "20060102T150405Z"
+
+	// Used when a rule doesn't set its dedup period
+	defaultDedupPeriodMinutes = 60
+)
+
+// createAlert creates (or updates) the composite alert for a completed sequence.
+//
+// The alert is deduplicated on the join value. Its events are the events of the contributing matches,
+// read back from S3 and written under the correlation rule, so they show up as the events of the alert.
+// Objects which were already read are passed in loaded.
+//
+// Each step is saved in the state of the sequence, so a retry after a failure continues where it stopped.
+func (e *Engine) createAlert(rule *correlationRule, joinValue string, pending *pendingSequence, loaded map[string][]*ruleMatch) error {
+	now := e.now()
+	sequence := pending.Matches
+
+	var logTypes []string
+	seen := make(map[string]bool)
+	for _, match := range sequence {
+		if !seen[match.LogType] {
+			seen[match.LogType] = true
+			logTypes = append(logTypes, match.LogType)
+		}
+	}
+	sort.Strings(logTypes)
+
+	dedupPeriod := rule.dedupPeriod
+	if dedupPeriod == 0 {
+		dedupPeriod = defaultDedupPeriodMinutes
+	}
+	alert := pending.Alert
+	if alert == nil {
+		var err error
+		alert, err = alertdedup.Update(e.DdbClient, e.DedupTable, &alertdedup.Group{
+			RuleID:             rule.id,
+			RuleVersion:        rule.version,
+			Dedup:              joinValue,
+			DedupPeriodMinutes: dedupPeriod,
+			EventCount:         len(sequence),
+			LogTypes:           logTypes,
+		}, now)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create alert for correlation rule %s", rule.id)
+		}
+		if err := e.recordAlert(rule, joinValue, alert); err != nil {
+			return err
+		}
+	}
+
+	events, err := e.readSequenceEvents(sequence, loaded)
+	if err != nil {
+		return err
+	}
+
+	// One object per log type, so the events land in the right rule match table
+	byLogType := make(map[string][]map[string]interface{})
+	for i, match := range sequence {
+		original := events[i]
+		if original == nil {
+			// The original object was deleted, e.g. by a lifecycle policy
+			zap.L().Warn("correlated event not found",
+				zap.String("objectKey", match.ObjectKey), zap.String("rowId", match.RowID))
+			continue
+		}
+
+		// The same match can be part of several correlations, don't modify the original
+		event := make(map[string]interface{}, len(original)+3)
+		for name, value := range original {
+			event[name] = value
+		}
+		event[correlatedRuleIDField] = match.RuleID
+		event[correlatedAlertIDField] = match.AlertID
+		event[correlationStepField] = match.Step
+		event[ruleIDField] = rule.id
+		event[alertIDField] = alert.AlertID
+		event[ruleTagsField] = rule.tags
+		event[ruleReportsField] = rule.reports
+		event[alertCreationTimeField] = alert.CreationTime.Format(awsglue.TimestampLayout)
+		event[alertUpdateTimeField] = alert.UpdateTime.Format(awsglue.TimestampLayout)
+		byLogType[match.LogType] = append(byLogType[match.LogType], event)
+	}
+
+	for logType, logTypeEvents := range byLogType {
+		if err := e.writeEvents(rule.id, logType, logTypeEvents, now); err != nil {
+			return err
+		}
+	}
+	return e.finishSequence(rule, joinValue)
+}
+
+// Read the original events of a sequence, in the same order
+func (e *Engine) readSequenceEvents(sequence []*matchRef, loaded map[string][]*ruleMatch) ([]map[string]interface{}, error) {
+	result := make([]map[string]interface{}, len(sequence))
+	byObject := make(map[string][]int)
+	for i, match := range sequence {
+		byObject[match.ObjectKey] = append(byObject[match.ObjectKey], i)
+	}
+
+	for key, indices := range byObject {
+		matches, ok := loaded[key]
+		if !ok {
+			var err error
+			if matches, err = e.readMatches(key); err != nil {
+				return nil, err
+			}
+		}
+		for _, match := range matches {
+			for _, i := range indices {
+				if match.ref.RowID == sequence[i].RowID && match.ref.RuleID == sequence[i].RuleID {
+					result[i] = match.event
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// Write the events of a correlation alert to S3 and notify the same topic as the rules engine
+func (e *Engine) writeEvents(ruleID, logType string, events []map[string]interface{}, now time.Time) error {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	for _, event := range events {
+		line, err := jsoniter.Marshal(event)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal correlated event")
+		}
+		if _, err := writer.Write(append(line, '\n')); err != nil {
+			return errors.Wrap(err, "failed to compress correlated events")
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return errors.Wrap(err, "failed to compress correlated events")
+	}
+
+	key := awsglue.GetPartitionPrefix(logmodels.RuleData, logType, awsglue.GlueTableHourly, now) +
+		ruleIDPartition + ruleID + "/" + now.Format(objectKeyTimeLayout) + "-" + uuid.New().String() + ".json.gz"
+	size := buffer.Len()
+	_, err := e.S3Client.PutObject(&s3.PutObjectInput{
+		Body:        bytes.NewReader(buffer.Bytes()),
+		Bucket:      &e.Bucket,
+		ContentType: aws.String("gzip"),
+		Key:         &key,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to write s3://%s/%s", e.Bucket, key)
+	}
+
+	notification, err := jsoniter.MarshalToString(logmodels.NewS3ObjectPutNotification(e.Bucket, key, size))
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal notification")
+	}
+	_, err = e.SnsClient.Publish(&sns.PublishInput{
+		Message: &notification,
+		// Subscribers filter on the same attributes the rules engine sends
+		MessageAttributes: map[string]*sns.MessageAttributeValue{
+			"type": {DataType: aws.String("String"), StringValue: aws.String(logmodels.RuleData.String())},
+			"id":   {DataType: aws.String("String"), StringValue: aws.String(ruleID)},
+		},
+		TopicArn: &e.NotificationsTopic,
+	})
+	return errors.Wrap(err, "failed to send notification to topic")
+}
@@ -0,0 +1,347 @@
+package engine
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bufio"
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	analysisclient "github.com/panther-labs/panther/api/gateway/analysis/client"
+	"github.com/panther-labs/panther/api/gateway/analysis/client/operations"
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	logmodels "github.com/panther-labs/panther/api/lambda/core/log_analysis/log_processor/models"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+)
+
+const (
+	// Fields added to every event by the log processor and the rules engine
+	ruleIDField    = "p_rule_id"
+	alertIDField   = "p_alert_id"
+	logTypeField   = "p_log_type"
+	eventTimeField = "p_event_time"
+	rowIDField     = "p_row_id"
+
+	// Rule match objects are stored under rules/{table}/year=.../hour=.../rule_id={ruleId}/
+	ruleIDPartition = "rule_id="
+
+	// Older matches of a step are dropped from the state
+	maxMatchesPerStep = 20
+
+	// Processed log lines can be large, but they are never bigger than this
+	maxLogLineBytes = 10 * 1024 * 1024
+)
+
+// Engine consumes rule matches and fires correlation rules when their steps match in sequence.
+//
+// The rules engine writes the events matching each rule to S3 and sends a notification for every object.
+// For each match of a rule which is a step of a correlation rule, the engine extracts the join key from
+// the event and adds a reference to the match to the windowed state of that join value in Dynamo.
+// When every step has matched within the window, a composite alert is created through the alert dedup
+// table and the contributing events are written to S3 as the events of the new alert.
+// The completed sequence stays in the state until its alert is written, so a redelivered notification
+// retries the same alert instead of firing the correlation again.
+type Engine struct {
+	S3Client       s3iface.S3API
+	SnsClient      snsiface.SNSAPI
+	DdbClient      dynamodbiface.DynamoDBAPI
+	AnalysisClient *analysisclient.PantherAnalysis
+	HTTPClient     *http.Client
+
+	// Bucket with the processed logs and rule matches
+	Bucket string
+	// SNS topic notified of new rule match objects
+	NotificationsTopic string
+	// Dynamo tables
+	DedupTable string
+	StateTable string
+
+	// Overridden in unit tests
+	Now func() time.Time
+}
+
+// A correlation rule, indexed for quick lookup
+type correlationRule struct {
+	id          string
+	version     string
+	dedupPeriod int64
+	joinKey     []string
+	ordered     bool
+	steps       []string
+	window      time.Duration
+	tags        []string
+	reports     map[string][]string
+}
+
+// index maps each rule ID to the correlation rules which have it as a step
+type index map[string][]*correlationRule
+
+// A single event matched by a rule
+type ruleMatch struct {
+	ref   *matchRef
+	event map[string]interface{}
+}
+
+// HandleSQS processes a batch of rule match notifications.
+func (e *Engine) HandleSQS(event events.SQSEvent) error {
+	rules, err := e.loadRules()
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		zap.L().Debug("no enabled correlation rules")
+		return nil
+	}
+
+	for _, record := range event.Records {
+		notification := &logmodels.S3Notification{}
+		if err := jsoniter.UnmarshalFromString(record.Body, notification); err != nil {
+			zap.L().Error("failed to unmarshal record", zap.Error(errors.WithStack(err)))
+			continue
+		}
+
+		for _, eventRecord := range notification.Records {
+			if err := e.processObject(rules, eventRecord.S3.Object.Key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Load the enabled correlation rules from the analysis-api
+func (e *Engine) loadRules() (index, error) {
+	result, err := e.AnalysisClient.Operations.GetEnabledPolicies(&operations.GetEnabledPoliciesParams{
+		HTTPClient: e.HTTPClient,
+		Type:       string(models.AnalysisTypeCORRELATIONRULE),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load correlation rules from analysis-api")
+	}
+
+	rules := make(index)
+	for _, policy := range result.Payload.Policies {
+		if policy.Correlation == nil {
+			continue
+		}
+		rule := &correlationRule{
+			id:          string(policy.ID),
+			version:     string(policy.VersionID),
+			dedupPeriod: int64(policy.DedupPeriodMinutes),
+			joinKey:     strings.Split(aws.StringValue(policy.Correlation.JoinKey), "."),
+			ordered:     policy.Correlation.Ordered,
+			window:      time.Duration(aws.Int64Value(policy.Correlation.WindowMinutes)) * time.Minute,
+			tags:        policy.Tags,
+			reports:     policy.Reports,
+		}
+		for _, step := range policy.Correlation.Steps {
+			rule.steps = append(rule.steps, string(step))
+			rules[string(step)] = append(rules[string(step)], rule)
+		}
+	}
+	return rules, nil
+}
+
+// Add the matches in a single S3 object to the state of every correlation rule which uses them
+func (e *Engine) processObject(rules index, key string) error {
+	ruleID := ruleIDFromKey(key)
+	if len(rules[ruleID]) == 0 {
+		// Most rules are not part of any correlation, skip reading the object
+		return nil
+	}
+
+	matches, err := e.readMatches(key)
+	if err != nil {
+		return err
+	}
+
+	type stateKey struct {
+		rule      *correlationRule
+		joinValue string
+	}
+	added := make(map[stateKey][]*matchRef)
+	for _, match := range matches {
+		for _, rule := range rules[match.ref.RuleID] {
+			step := rule.stepIndex(match.ref.RuleID)
+			for _, joinValue := range joinValues(match.event, rule.joinKey) {
+				ref := *match.ref
+				ref.Step = step
+				id := stateKey{rule: rule, joinValue: joinValue}
+				added[id] = append(added[id], &ref)
+			}
+		}
+	}
+
+	for id, refs := range added {
+		sequence, err := e.updateState(id.rule, id.joinValue, key, refs)
+		if err != nil {
+			return err
+		}
+		if sequence == nil {
+			continue
+		}
+		zap.L().Info("correlation rule matched",
+			zap.String("correlationId", id.rule.id), zap.String("joinValue", id.joinValue))
+		loaded := map[string][]*ruleMatch{key: matches}
+		if err := e.createAlert(id.rule, id.joinValue, sequence, loaded); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Read every event in a rule match object
+func (e *Engine) readMatches(key string) ([]*ruleMatch, error) {
+	var result []*ruleMatch
+	err := e.scanObject(key, func(line string) error {
+		var data map[string]interface{}
+		if err := jsoniter.UnmarshalFromString(line, &data); err != nil {
+			zap.L().Warn("skipping invalid rule match", zap.String("key", key), zap.Error(err))
+			return nil
+		}
+
+		rawTime, _ := data[eventTimeField].(string)
+		eventTime, err := time.Parse(awsglue.TimestampLayout, rawTime)
+		if err != nil {
+			zap.L().Warn("skipping rule match without event time", zap.String("key", key), zap.Error(err))
+			return nil
+		}
+
+		result = append(result, &ruleMatch{
+			ref: &matchRef{
+				RuleID:    stringField(data, ruleIDField),
+				AlertID:   stringField(data, alertIDField),
+				LogType:   stringField(data, logTypeField),
+				EventTime: eventTime,
+				ObjectKey: key,
+				RowID:     stringField(data, rowIDField),
+			},
+			event: data,
+		})
+		return nil
+	})
+	return result, err
+}
+
+// Call handle for each line of a gzipped S3 object in the processed data bucket
+func (e *Engine) scanObject(key string, handle func(line string) error) error {
+	object, err := e.S3Client.GetObject(&s3.GetObjectInput{Bucket: &e.Bucket, Key: &key})
+	if err != nil {
+		return errors.Wrapf(err, "failed to read s3://%s/%s", e.Bucket, key)
+	}
+	defer func() {
+		if err := object.Body.Close(); err != nil {
+			zap.L().Error("error closing S3 object", zap.String("key", key), zap.Error(err))
+		}
+	}()
+
+	reader, err := gzip.NewReader(object.Body)
+	if err != nil {
+		return errors.Wrapf(err, "failed to decompress s3://%s/%s", e.Bucket, key)
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineBytes)
+	for scanner.Scan() {
+		if err := handle(scanner.Text()); err != nil {
+			return err
+		}
+	}
+	return errors.Wrapf(scanner.Err(), "failed to read s3://%s/%s", e.Bucket, key)
+}
+
+func (e *Engine) now() time.Time {
+	if e.Now != nil {
+		return e.Now()
+	}
+	return time.Now().UTC()
+}
+
+func (r *correlationRule) stepIndex(ruleID string) int {
+	for i, step := range r.steps {
+		if step == ruleID {
+			return i
+		}
+	}
+	return -1
+}
+
+// Extract the rule ID from a rule match object key
+func ruleIDFromKey(key string) string {
+	for _, part := range strings.Split(key, "/") {
+		if strings.HasPrefix(part, ruleIDPartition) {
+			return strings.TrimPrefix(part, ruleIDPartition)
+		}
+	}
+	return ""
+}
+
+// joinValues returns the values of a (possibly nested) field in an event.
+//
+// Arrays return each of their elements, so a correlation can join on fields like p_any_ip_addresses.
+func joinValues(event map[string]interface{}, path []string) []string {
+	var value interface{} = event
+	for _, name := range path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = object[name]
+	}
+
+	var values []interface{}
+	if array, ok := value.([]interface{}); ok {
+		values = array
+	} else {
+		values = []interface{}{value}
+	}
+
+	var result []string
+	for _, v := range values {
+		switch v := v.(type) {
+		case string:
+			if v != "" {
+				result = append(result, v)
+			}
+		case float64:
+			result = append(result, strconv.FormatFloat(v, 'f', -1, 64))
+		case bool:
+			result = append(result, strconv.FormatBool(v))
+		}
+	}
+	return result
+}
+
+func stringField(event map[string]interface{}, name string) string {
+	value, _ := event[name].(string)
+	return value
+}
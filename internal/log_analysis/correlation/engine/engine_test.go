@@ -0,0 +1,367 @@
+package engine
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sns"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	analysisclient "github.com/panther-labs/panther/api/gateway/analysis/client"
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	logmodels "github.com/panther-labs/panther/api/lambda/core/log_analysis/log_processor/models"
+	"github.com/panther-labs/panther/internal/log_analysis/alertdedup"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+type mockRoundTripper struct {
+	http.RoundTripper
+	mock.Mock
+}
+
+func (m *mockRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	args := m.Called(request)
+	return args.Get(0).(*http.Response), args.Error(1)
+}
+
+func generateResponse(body interface{}, httpCode int) *http.Response {
+	serializedBody, _ := jsoniter.MarshalToString(body)
+	return &http.Response{StatusCode: httpCode, Body: ioutil.NopCloser(strings.NewReader(serializedBody))}
+}
+
+const (
+	mfaResetKey = "rules/okta_systemlog/year=2020/month=06/day=01/hour=12/rule_id=MFA.Reset/20200601T120000Z-uuid.json.gz"
+	loginKey    = "rules/aws_cloudtrail/year=2020/month=06/day=01/hour=12/rule_id=Console.Login/20200601T121000Z-uuid.json.gz"
+)
+
+var testCorrelation = &models.EnabledPolicies{Policies: []*models.EnabledPolicy{{
+	ID: "MFA.Reset.Then.Login",
+	Correlation: &models.Correlation{
+		JoinKey:       aws.String("user.name"),
+		Ordered:       true,
+		Steps:         []models.ID{"MFA.Reset", "Console.Login"},
+		WindowMinutes: aws.Int64(30),
+	},
+	DedupPeriodMinutes: 60,
+	VersionID:          "v1",
+}}}
+
+func gzipObject(t *testing.T, lines ...string) *s3.GetObjectOutput {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	_, err := writer.Write([]byte(strings.Join(lines, "\n")))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	return &s3.GetObjectOutput{Body: ioutil.NopCloser(&buffer)}
+}
+
+func notification(key string) events.SQSMessage {
+	body, _ := jsoniter.MarshalToString(logmodels.NewS3ObjectPutNotification("bucket", key, 100))
+	return events.SQSMessage{Body: body}
+}
+
+func newTestEngine() (*Engine, *testutils.S3Mock, *testutils.DynamoDBMock, *testutils.SnsMock, *mockRoundTripper) {
+	s3Mock := &testutils.S3Mock{}
+	ddbMock := &testutils.DynamoDBMock{}
+	snsMock := &testutils.SnsMock{}
+	roundTripper := &mockRoundTripper{}
+	analysisConfig := analysisclient.DefaultTransportConfig().WithHost("host").WithBasePath("path")
+	return &Engine{
+		S3Client:           s3Mock,
+		SnsClient:          snsMock,
+		DdbClient:          ddbMock,
+		AnalysisClient:     analysisclient.NewHTTPClientWithConfig(nil, analysisConfig),
+		HTTPClient:         &http.Client{Transport: roundTripper},
+		Bucket:             "bucket",
+		NotificationsTopic: "topic",
+		DedupTable:         "dedup",
+		StateTable:         "state",
+		Now:                func() time.Time { return time.Date(2020, 6, 1, 12, 15, 0, 0, time.UTC) },
+	}, s3Mock, ddbMock, snsMock, roundTripper
+}
+
+var (
+	testMFAResetMatch = &matchRef{
+		Step: 0, RuleID: "MFA.Reset", AlertID: "alert1", LogType: "Okta.SystemLog",
+		EventTime: time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC), ObjectKey: mfaResetKey, RowID: "row1",
+	}
+	testLoginMatch = &matchRef{
+		Step: 1, RuleID: "Console.Login", AlertID: "alert2", LogType: "AWS.CloudTrail",
+		EventTime: time.Date(2020, 6, 1, 12, 10, 0, 0, time.UTC), ObjectKey: loginKey, RowID: "row2",
+	}
+
+	testMFAReset = `{"p_rule_id": "MFA.Reset", "p_alert_id": "alert1", "p_log_type": "Okta.SystemLog", ` +
+		`"p_event_time": "2020-06-01 12:00:00.000000000", "p_row_id": "row1", "user": {"name": "alice"}}`
+	testLogin = `{"p_rule_id": "Console.Login", "p_alert_id": "alert2", "p_log_type": "AWS.CloudTrail", ` +
+		`"p_event_time": "2020-06-01 12:10:00.000000000", "p_row_id": "row2", "user": {"name": "alice"}}`
+
+	testDedupOutput = &dynamodb.UpdateItemOutput{
+		Attributes: map[string]*dynamodb.AttributeValue{
+			"alertCount":        {N: aws.String("1")},
+			"alertCreationTime": {N: aws.String("1591013700")},
+		},
+	}
+)
+
+// Mock a state table with a single item, which is read back after every write.
+// Returns the saved versions of the item.
+func mockStateTable(t *testing.T, ddbMock *testutils.DynamoDBMock, item map[string]*dynamodb.AttributeValue, writes int) *[]*stateItem {
+	stored := &dynamodb.GetItemOutput{Item: item}
+	var saves []*stateItem
+	ddbMock.On("GetItem", mock.Anything).Return(stored, nil).Times(writes)
+	ddbMock.On("PutItem", mock.Anything).Run(func(args mock.Arguments) {
+		stored.Item = args.Get(0).(*dynamodb.PutItemInput).Item
+		var state stateItem
+		require.NoError(t, dynamodbattribute.UnmarshalMap(stored.Item, &state))
+		saves = append(saves, &state)
+	}).Return(&dynamodb.PutItemOutput{}, nil).Times(writes)
+	return &saves
+}
+
+func TestHandleSQSFiresCorrelation(t *testing.T) {
+	engine, s3Mock, ddbMock, snsMock, roundTripper := newTestEngine()
+	roundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(testCorrelation, http.StatusOK), nil).Once()
+
+	// The MFA reset was seen before
+	existing, err := dynamodbattribute.MarshalMap(&stateItem{
+		CorrelationID: "MFA.Reset.Then.Login",
+		JoinValue:     "alice",
+		Matches:       []*matchRef{testMFAResetMatch},
+		Version:       3,
+	})
+	require.NoError(t, err)
+	saves := mockStateTable(t, ddbMock, existing, 3)
+	ddbMock.On("UpdateItem", mock.Anything).Return(testDedupOutput, nil).Once()
+
+	s3Mock.On("GetObject", &s3.GetObjectInput{Bucket: aws.String("bucket"), Key: aws.String(loginKey)}).
+		Return(gzipObject(t, testLogin), nil).Once()
+	s3Mock.On("GetObject", &s3.GetObjectInput{Bucket: aws.String("bucket"), Key: aws.String(mfaResetKey)}).
+		Return(gzipObject(t, testMFAReset), nil).Once()
+
+	var written []*s3.PutObjectInput
+	s3Mock.On("PutObject", mock.Anything).Run(func(args mock.Arguments) {
+		written = append(written, args.Get(0).(*s3.PutObjectInput))
+	}).Return(&s3.PutObjectOutput{}, nil)
+	snsMock.On("Publish", mock.Anything).Return(&sns.PublishOutput{}, nil).Twice()
+
+	require.NoError(t, engine.HandleSQS(events.SQSEvent{Records: []events.SQSMessage{notification(loginKey)}}))
+
+	// The completed sequence is moved out of the matches until its alert is written
+	require.Len(t, *saves, 3)
+	assert.Empty(t, (*saves)[0].Matches)
+	require.NotNil(t, (*saves)[0].Pending)
+	assert.Len(t, (*saves)[0].Pending.Matches, 2)
+	assert.Equal(t, loginKey, (*saves)[0].Pending.ObjectKey)
+	require.NotNil(t, (*saves)[1].Pending.Alert)
+	// and then remembered as fired
+	state := (*saves)[2]
+	assert.Nil(t, state.Pending)
+	assert.Len(t, state.Fired, 2)
+	assert.Equal(t, int64(6), state.Version)
+
+	// One object per log type, each event links back to its original match
+	require.Len(t, written, 2)
+	for _, input := range written {
+		assert.Contains(t, *input.Key, "/rule_id=MFA.Reset.Then.Login/")
+		reader, err := gzip.NewReader(input.Body)
+		require.NoError(t, err)
+		var event map[string]interface{}
+		require.NoError(t, jsoniter.NewDecoder(reader).Decode(&event))
+		assert.Equal(t, "MFA.Reset.Then.Login", event[ruleIDField])
+		assert.Contains(t, []interface{}{"alert1", "alert2"}, event[correlatedAlertIDField])
+		assert.Contains(t, []interface{}{"MFA.Reset", "Console.Login"}, event[correlatedRuleIDField])
+	}
+
+	s3Mock.AssertExpectations(t)
+	ddbMock.AssertExpectations(t)
+	snsMock.AssertExpectations(t)
+	roundTripper.AssertExpectations(t)
+}
+
+func TestHandleSQSSkipsUncorrelatedRules(t *testing.T) {
+	engine, s3Mock, ddbMock, _, roundTripper := newTestEngine()
+	roundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(testCorrelation, http.StatusOK), nil).Once()
+
+	key := "rules/aws_cloudtrail/year=2020/month=06/day=01/hour=12/rule_id=Other.Rule/20200601T121000Z-uuid.json.gz"
+	require.NoError(t, engine.HandleSQS(events.SQSEvent{Records: []events.SQSMessage{notification(key)}}))
+
+	// the object is never read
+	s3Mock.AssertExpectations(t)
+	ddbMock.AssertExpectations(t)
+}
+
+func TestHandleSQSStoresPartialSequence(t *testing.T) {
+	engine, s3Mock, ddbMock, _, roundTripper := newTestEngine()
+	roundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(testCorrelation, http.StatusOK), nil).Once()
+
+	s3Mock.On("GetObject", mock.Anything).Return(gzipObject(t, testMFAReset), nil).Once()
+	ddbMock.On("GetItem", mock.Anything).Return(&dynamodb.GetItemOutput{}, nil).Once()
+
+	var saved *dynamodb.PutItemInput
+	ddbMock.On("PutItem", mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(0).(*dynamodb.PutItemInput)
+	}).Return(&dynamodb.PutItemOutput{}, nil).Once()
+
+	require.NoError(t, engine.HandleSQS(events.SQSEvent{Records: []events.SQSMessage{notification(mfaResetKey)}}))
+
+	var state stateItem
+	require.NoError(t, dynamodbattribute.UnmarshalMap(saved.Item, &state))
+	require.Len(t, state.Matches, 1)
+	assert.Equal(t, "row1", state.Matches[0].RowID)
+	assert.Equal(t, int64(1), state.Version)
+	// new items must not exist yet
+	assert.Contains(t, *saved.ConditionExpression, "attribute_not_exists")
+
+	s3Mock.AssertExpectations(t)
+	ddbMock.AssertExpectations(t)
+}
+
+func TestHandleSQSIgnoresFiredMatches(t *testing.T) {
+	engine, s3Mock, ddbMock, _, roundTripper := newTestEngine()
+	roundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(testCorrelation, http.StatusOK), nil).Once()
+
+	// The login was redelivered after its sequence fired, and another MFA reset was seen since
+	otherReset := *testMFAResetMatch
+	otherReset.RowID = "row3"
+	existing, err := dynamodbattribute.MarshalMap(&stateItem{
+		CorrelationID: "MFA.Reset.Then.Login",
+		JoinValue:     "alice",
+		Matches:       []*matchRef{&otherReset},
+		Fired:         []*matchRef{testMFAResetMatch, testLoginMatch},
+		Version:       6,
+	})
+	require.NoError(t, err)
+	saves := mockStateTable(t, ddbMock, existing, 1)
+	s3Mock.On("GetObject", mock.Anything).Return(gzipObject(t, testLogin), nil).Once()
+
+	require.NoError(t, engine.HandleSQS(events.SQSEvent{Records: []events.SQSMessage{notification(loginKey)}}))
+
+	// No new sequence: the login was already used
+	require.Len(t, *saves, 1)
+	assert.Nil(t, (*saves)[0].Pending)
+	require.Len(t, (*saves)[0].Matches, 1)
+	assert.Equal(t, "row3", (*saves)[0].Matches[0].RowID)
+
+	s3Mock.AssertExpectations(t)
+	ddbMock.AssertExpectations(t)
+}
+
+func TestHandleSQSRetriesPendingSequence(t *testing.T) {
+	engine, s3Mock, ddbMock, snsMock, roundTripper := newTestEngine()
+	roundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(testCorrelation, http.StatusOK), nil).Once()
+
+	// The previous attempt updated the alert dedup table, but failed to write the events
+	existing, err := dynamodbattribute.MarshalMap(&stateItem{
+		CorrelationID: "MFA.Reset.Then.Login",
+		JoinValue:     "alice",
+		Pending: &pendingSequence{
+			Matches:   []*matchRef{testMFAResetMatch, testLoginMatch},
+			ObjectKey: loginKey,
+			ClaimedAt: time.Date(2020, 6, 1, 12, 13, 0, 0, time.UTC),
+			Alert:     &alertdedup.AlertInfo{AlertID: "correlationAlert"},
+		},
+		Version: 5,
+	})
+	require.NoError(t, err)
+	saves := mockStateTable(t, ddbMock, existing, 2)
+
+	s3Mock.On("GetObject", &s3.GetObjectInput{Bucket: aws.String("bucket"), Key: aws.String(loginKey)}).
+		Return(gzipObject(t, testLogin), nil).Once()
+	s3Mock.On("GetObject", &s3.GetObjectInput{Bucket: aws.String("bucket"), Key: aws.String(mfaResetKey)}).
+		Return(gzipObject(t, testMFAReset), nil).Once()
+	s3Mock.On("PutObject", mock.Anything).Return(&s3.PutObjectOutput{}, nil).Twice()
+	snsMock.On("Publish", mock.Anything).Return(&sns.PublishOutput{}, nil).Twice()
+
+	require.NoError(t, engine.HandleSQS(events.SQSEvent{Records: []events.SQSMessage{notification(loginKey)}}))
+
+	// The alert dedup table is not updated again (no UpdateItem) and the sequence is finished
+	require.Len(t, *saves, 2)
+	assert.Nil(t, (*saves)[1].Pending)
+	assert.Len(t, (*saves)[1].Fired, 2)
+
+	s3Mock.AssertExpectations(t)
+	ddbMock.AssertExpectations(t)
+	snsMock.AssertExpectations(t)
+}
+
+func TestHandleSQSSkipsSequenceClaimedByOtherObject(t *testing.T) {
+	engine, s3Mock, ddbMock, _, roundTripper := newTestEngine()
+	roundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(testCorrelation, http.StatusOK), nil).Once()
+
+	// Another invocation is firing the sequence right now
+	existing, err := dynamodbattribute.MarshalMap(&stateItem{
+		CorrelationID: "MFA.Reset.Then.Login",
+		JoinValue:     "alice",
+		Pending: &pendingSequence{
+			Matches:   []*matchRef{testMFAResetMatch, testLoginMatch},
+			ObjectKey: loginKey,
+			ClaimedAt: time.Date(2020, 6, 1, 12, 14, 0, 0, time.UTC),
+		},
+		Version: 4,
+	})
+	require.NoError(t, err)
+	saves := mockStateTable(t, ddbMock, existing, 1)
+
+	otherReset := strings.Replace(testMFAReset, "row1", "row3", 1)
+	s3Mock.On("GetObject", mock.Anything).Return(gzipObject(t, otherReset), nil).Once()
+
+	require.NoError(t, engine.HandleSQS(events.SQSEvent{Records: []events.SQSMessage{notification(mfaResetKey)}}))
+
+	// The new match is stored, the pending sequence is left to the other invocation
+	require.Len(t, *saves, 1)
+	assert.Len(t, (*saves)[0].Matches, 1)
+	assert.Equal(t, loginKey, (*saves)[0].Pending.ObjectKey)
+
+	s3Mock.AssertExpectations(t)
+	ddbMock.AssertExpectations(t)
+}
+
+func TestJoinValues(t *testing.T) {
+	event := map[string]interface{}{
+		"user":               map[string]interface{}{"name": "alice", "id": float64(12345678)},
+		"p_any_ip_addresses": []interface{}{"1.2.3.4", "5.6.7.8"},
+		"empty":              "",
+	}
+	assert.Equal(t, []string{"alice"}, joinValues(event, []string{"user", "name"}))
+	assert.Equal(t, []string{"12345678"}, joinValues(event, []string{"user", "id"}))
+	assert.Equal(t, []string{"1.2.3.4", "5.6.7.8"}, joinValues(event, []string{"p_any_ip_addresses"}))
+	assert.Nil(t, joinValues(event, []string{"empty"}))
+	assert.Nil(t, joinValues(event, []string{"user", "name", "first"}))
+	assert.Nil(t, joinValues(event, []string{"missing"}))
+}
+
+func TestRuleIDFromKey(t *testing.T) {
+	assert.Equal(t, "MFA.Reset", ruleIDFromKey(mfaResetKey))
+	assert.Equal(t, "", ruleIDFromKey("logs/aws_cloudtrail/year=2020/month=06/day=01/hour=12/file.json.gz"))
+}
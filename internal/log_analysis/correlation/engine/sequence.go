@@ -0,0 +1,182 @@
+package engine
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"sort"
+	"time"
+)
+
+// A reference to a single rule match which is part of a correlation sequence.
+//
+// Only the location of the event is stored, the event itself is read back from S3
+// when the correlation fires.
+type matchRef struct {
+	Step      int       `json:"step"`
+	RuleID    string    `json:"ruleId"`
+	AlertID   string    `json:"alertId"`
+	LogType   string    `json:"logType"`
+	EventTime time.Time `json:"eventTime"`
+	ObjectKey string    `json:"objectKey"`
+	RowID     string    `json:"rowId"`
+}
+
+// findSequence returns one match for each step if the matches complete the correlation, otherwise nil.
+//
+// All of the returned matches are within window of each other. If ordered is set, their event times
+// must also follow the order of the steps.
+func findSequence(matches []*matchRef, steps int, ordered bool, window time.Duration) []*matchRef {
+	sorted := make([]*matchRef, len(matches))
+	copy(sorted, matches)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].EventTime.Before(sorted[j].EventTime) })
+
+	if ordered {
+		return findOrdered(sorted, steps, window)
+	}
+	return findUnordered(sorted, steps, window)
+}
+
+// Greedily extend a sequence from each match of the first step, picking the earliest match of every next step.
+func findOrdered(sorted []*matchRef, steps int, window time.Duration) []*matchRef {
+	for i, start := range sorted {
+		if start.Step != 0 {
+			continue
+		}
+		windowEnd := start.EventTime.Add(window)
+		result := []*matchRef{start}
+		for _, match := range sorted[i+1:] {
+			if match.EventTime.After(windowEnd) {
+				break
+			}
+			if match.Step == len(result) {
+				result = append(result, match)
+				if len(result) == steps {
+					return result
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Find the earliest window which contains a match for every step.
+func findUnordered(sorted []*matchRef, steps int, window time.Duration) []*matchRef {
+	for i, start := range sorted {
+		windowEnd := start.EventTime.Add(window)
+		result := make([]*matchRef, steps)
+		found := 0
+		for _, match := range sorted[i:] {
+			if match.EventTime.After(windowEnd) {
+				break
+			}
+			if result[match.Step] == nil {
+				result[match.Step] = match
+				if found++; found == steps {
+					return result
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// mergeMatches adds new matches to the existing ones and drops the matches which can no longer be
+// part of a sequence, because they are more than window older than the newest match.
+//
+// The same match can be delivered more than once (e.g. when a message is retried), duplicates are ignored.
+// At most maxMatchesPerStep of the newest matches are kept for each step.
+func mergeMatches(existing, added []*matchRef, window time.Duration) []*matchRef {
+	seen := make(map[matchKey]bool, len(existing)+len(added))
+	var all []*matchRef
+	var newest time.Time
+	for _, match := range append(existing, added...) {
+		key := match.key()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		all = append(all, match)
+		if match.EventTime.After(newest) {
+			newest = match.EventTime
+		}
+	}
+
+	// newest first, so the oldest matches are dropped when a step has too many
+	sort.SliceStable(all, func(i, j int) bool { return all[i].EventTime.After(all[j].EventTime) })
+	perStep := make(map[int]int)
+	result := make([]*matchRef, 0, len(all))
+	for _, match := range all {
+		if newest.Sub(match.EventTime) > window || perStep[match.Step] >= maxMatchesPerStep {
+			continue
+		}
+		perStep[match.Step]++
+		result = append(result, match)
+	}
+
+	// back to oldest first
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	return result
+}
+
+type matchKey struct {
+	step   int
+	ruleID string
+	rowID  string
+}
+
+func (m *matchRef) key() matchKey {
+	return matchKey{step: m.Step, ruleID: m.RuleID, rowID: m.RowID}
+}
+
+// unfiredMatches returns the added matches which are not already part of a pending or fired sequence
+func unfiredMatches(item *stateItem, added []*matchRef) []*matchRef {
+	used := make(map[matchKey]bool, len(item.Fired))
+	for _, match := range item.Fired {
+		used[match.key()] = true
+	}
+	if item.Pending != nil {
+		for _, match := range item.Pending.Matches {
+			used[match.key()] = true
+		}
+	}
+	result := make([]*matchRef, 0, len(added))
+	for _, match := range added {
+		if !used[match.key()] {
+			result = append(result, match)
+		}
+	}
+	return result
+}
+
+// removeMatches returns the matches which are not part of the given sequence
+func removeMatches(matches, sequence []*matchRef) []*matchRef {
+	used := make(map[*matchRef]bool, len(sequence))
+	for _, match := range sequence {
+		used[match] = true
+	}
+	result := make([]*matchRef, 0, len(matches))
+	for _, match := range matches {
+		if !used[match] {
+			result = append(result, match)
+		}
+	}
+	return result
+}
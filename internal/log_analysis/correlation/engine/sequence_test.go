@@ -0,0 +1,96 @@
+package engine
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testStart = time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func testMatch(step, minute int) *matchRef {
+	return &matchRef{
+		Step:      step,
+		RuleID:    "rule" + string(rune('A'+step)),
+		EventTime: testStart.Add(time.Duration(minute) * time.Minute),
+		RowID:     string(rune('a'+step)) + time.Duration(minute).String(),
+	}
+}
+
+func TestFindSequenceOrdered(t *testing.T) {
+	window := 30 * time.Minute
+
+	// B before A does not count, the later A-B pair does
+	matches := []*matchRef{testMatch(1, 0), testMatch(0, 5), testMatch(1, 20)}
+	result := findSequence(matches, 2, true, window)
+	require.Len(t, result, 2)
+	assert.Equal(t, matches[1], result[0])
+	assert.Equal(t, matches[2], result[1])
+
+	// out of order only
+	assert.Nil(t, findSequence([]*matchRef{testMatch(1, 0), testMatch(0, 5)}, 2, true, window))
+	// outside the window
+	assert.Nil(t, findSequence([]*matchRef{testMatch(0, 0), testMatch(1, 31)}, 2, true, window))
+	// a later start still fits in the window
+	result = findSequence([]*matchRef{testMatch(0, 0), testMatch(0, 10), testMatch(1, 35)}, 2, true, window)
+	require.Len(t, result, 2)
+	assert.Equal(t, testStart.Add(10*time.Minute), result[0].EventTime)
+	// every step is required
+	assert.Nil(t, findSequence([]*matchRef{testMatch(0, 0), testMatch(2, 5)}, 3, true, window))
+}
+
+func TestFindSequenceUnordered(t *testing.T) {
+	window := 30 * time.Minute
+
+	result := findSequence([]*matchRef{testMatch(1, 0), testMatch(0, 5)}, 2, false, window)
+	require.Len(t, result, 2)
+	assert.Equal(t, 0, result[0].Step)
+	assert.Equal(t, 1, result[1].Step)
+
+	assert.Nil(t, findSequence([]*matchRef{testMatch(1, 0), testMatch(0, 31)}, 2, false, window))
+	assert.Nil(t, findSequence([]*matchRef{testMatch(0, 0), testMatch(0, 1)}, 2, false, window))
+}
+
+func TestMergeMatches(t *testing.T) {
+	window := 30 * time.Minute
+	existing := []*matchRef{testMatch(0, 0), testMatch(1, 10)}
+
+	// duplicates are ignored, matches which left the window are dropped
+	result := mergeMatches(existing, []*matchRef{testMatch(1, 10), testMatch(0, 35)}, window)
+	assert.Equal(t, []*matchRef{existing[1], testMatch(0, 35)}, result)
+
+	// only the newest matches of each step are kept
+	var many []*matchRef
+	for i := 0; i < maxMatchesPerStep+5; i++ {
+		many = append(many, &matchRef{Step: 0, RowID: string(rune('a' + i)), EventTime: testStart.Add(time.Duration(i) * time.Second)})
+	}
+	result = mergeMatches(nil, many, window)
+	require.Len(t, result, maxMatchesPerStep)
+	assert.Equal(t, many[5], result[0])
+	assert.Equal(t, many[len(many)-1], result[len(result)-1])
+}
+
+func TestRemoveMatches(t *testing.T) {
+	matches := []*matchRef{testMatch(0, 0), testMatch(1, 1), testMatch(0, 2)}
+	assert.Equal(t, []*matchRef{matches[2]}, removeMatches(matches, matches[:2]))
+}
@@ -0,0 +1,221 @@
+package engine
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/pkg/errors"
+
+	"github.com/panther-labs/panther/internal/log_analysis/alertdedup"
+)
+
+const (
+	stateHashKey    = "correlationId"
+	stateRangeKey   = "joinValue"
+	stateVersionKey = "version"
+
+	// Concurrent updates of the same state item are retried this many times
+	maxStateRetries = 5
+
+	// A pending sequence which was not fired within this time is taken over by the next invocation.
+	// Longer than the Lambda timeout, so a running invocation is never overtaken.
+	pendingSequenceTimeout = 5 * time.Minute
+)
+
+// The matches seen so far for one correlation rule and one value of its join key.
+type stateItem struct {
+	CorrelationID string      `json:"correlationId"`
+	JoinValue     string      `json:"joinValue"`
+	Matches       []*matchRef `json:"matches"`
+
+	// A completed sequence whose alert has not been written yet
+	Pending *pendingSequence `json:"pending,omitempty"`
+
+	// Matches which were already part of a fired sequence, so a redelivered copy is ignored
+	Fired []*matchRef `json:"fired,omitempty"`
+
+	// Incremented on every write, for optimistic locking
+	Version int64 `json:"version"`
+
+	// Dynamo TTL: the state is useless once all matches have left the window
+	ExpiresAt int64 `json:"expiresAt"`
+}
+
+// A completed sequence, stored in the state until its alert is written.
+type pendingSequence struct {
+	Matches []*matchRef `json:"matches"`
+
+	// The rule match object which completed the sequence, a redelivery of it fires the sequence again
+	ObjectKey string    `json:"objectKey"`
+	ClaimedAt time.Time `json:"claimedAt"`
+
+	// Set once the alert dedup table was updated, so firing again doesn't count the events twice
+	Alert *alertdedup.AlertInfo `json:"alert,omitempty"`
+}
+
+// updateState adds matches to the windowed state and returns the sequence to fire, if any.
+//
+// The matches of a completed sequence are moved from the state into a pending sequence, so the next
+// sequence starts fresh. The pending sequence is only cleared by finishSequence once the alert is written:
+// if firing fails, the redelivered rule match object (with key objectKey) returns the same sequence
+// instead of starting a new one, so the correlation never fires twice.
+func (e *Engine) updateState(rule *correlationRule, joinValue, objectKey string, added []*matchRef) (*pendingSequence, error) {
+	var result *pendingSequence
+	err := e.modifyState(rule, joinValue, func(item *stateItem) {
+		result = nil
+		item.Matches = mergeMatches(item.Matches, unfiredMatches(item, added), rule.window)
+
+		now := e.now()
+		if item.Pending != nil {
+			if item.Pending.ObjectKey == objectKey || now.Sub(item.Pending.ClaimedAt) > pendingSequenceTimeout {
+				// The previous attempt to fire the sequence failed
+				item.Pending.ObjectKey = objectKey
+				item.Pending.ClaimedAt = now
+				result = item.Pending
+			}
+			return
+		}
+
+		if sequence := findSequence(item.Matches, len(rule.steps), rule.ordered, rule.window); sequence != nil {
+			item.Matches = removeMatches(item.Matches, sequence)
+			item.Pending = &pendingSequence{Matches: sequence, ObjectKey: objectKey, ClaimedAt: now}
+			result = item.Pending
+		}
+	})
+	return result, err
+}
+
+// recordAlert saves the alert of the pending sequence, once the alert dedup table was updated.
+func (e *Engine) recordAlert(rule *correlationRule, joinValue string, alert *alertdedup.AlertInfo) error {
+	return e.modifyState(rule, joinValue, func(item *stateItem) {
+		if item.Pending != nil {
+			item.Pending.Alert = alert
+		}
+	})
+}
+
+// finishSequence clears the pending sequence once its alert is written.
+func (e *Engine) finishSequence(rule *correlationRule, joinValue string) error {
+	return e.modifyState(rule, joinValue, func(item *stateItem) {
+		if item.Pending != nil {
+			item.Fired = mergeMatches(item.Fired, item.Pending.Matches, rule.window)
+			item.Pending = nil
+		}
+	})
+}
+
+// modifyState applies a change to the state of a join value.
+//
+// Concurrent invocations can update the same item, so every write is conditional on the version
+// which was read and the change is applied again to the new version if it changed.
+func (e *Engine) modifyState(rule *correlationRule, joinValue string, modify func(item *stateItem)) error {
+	for attempt := 0; ; attempt++ {
+		item, err := e.getState(rule.id, joinValue)
+		if err != nil {
+			return err
+		}
+
+		oldVersion := item.Version
+		modify(item)
+
+		err = e.putState(item, oldVersion, rule.window)
+		if err == nil {
+			return nil
+		}
+		if awsErr, ok := err.(awserr.Error); !ok || awsErr.Code() != dynamodb.ErrCodeConditionalCheckFailedException {
+			return errors.Wrapf(err, "failed to save correlation state for %s", rule.id)
+		}
+		if attempt == maxStateRetries {
+			return errors.Errorf("correlation state for %s is changing too quickly", rule.id)
+		}
+	}
+}
+
+func (e *Engine) getState(correlationID, joinValue string) (*stateItem, error) {
+	output, err := e.DdbClient.GetItem(&dynamodb.GetItemInput{
+		ConsistentRead: aws.Bool(true),
+		Key:            stateKey(correlationID, joinValue),
+		TableName:      &e.StateTable,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load correlation state for %s", correlationID)
+	}
+
+	item := &stateItem{CorrelationID: correlationID, JoinValue: joinValue}
+	if len(output.Item) == 0 {
+		return item, nil
+	}
+	if err := dynamodbattribute.UnmarshalMap(output.Item, item); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal correlation state for %s", correlationID)
+	}
+	return item, nil
+}
+
+func (e *Engine) putState(item *stateItem, oldVersion int64, window time.Duration) error {
+	var condition expression.ConditionBuilder
+	if oldVersion == 0 {
+		condition = expression.AttributeNotExists(expression.Name(stateHashKey))
+	} else {
+		condition = expression.Name(stateVersionKey).Equal(expression.Value(oldVersion))
+	}
+	expr, err := expression.NewBuilder().WithCondition(condition).Build()
+	if err != nil {
+		return errors.Wrap(err, "failed to build correlation state condition")
+	}
+
+	item.Version = oldVersion + 1
+	item.ExpiresAt = e.now().Add(window).Unix()
+	matches := append(append([]*matchRef{}, item.Matches...), item.Fired...)
+	if item.Pending != nil {
+		matches = append(matches, item.Pending.Matches...)
+	}
+	for _, match := range matches {
+		// keep the state until the newest match leaves the window
+		if expiresAt := match.EventTime.Add(window).Unix(); expiresAt > item.ExpiresAt {
+			item.ExpiresAt = expiresAt
+		}
+	}
+
+	marshaled, err := dynamodbattribute.MarshalMap(item)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal correlation state")
+	}
+
+	_, err = e.DdbClient.PutItem(&dynamodb.PutItemInput{
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		Item:                      marshaled,
+		TableName:                 &e.StateTable,
+	})
+	return err
+}
+
+func stateKey(correlationID, joinValue string) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		stateHashKey:  {S: aws.String(correlationID)},
+		stateRangeKey: {S: aws.String(joinValue)},
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/kelseyhightower/envconfig"
+	"go.uber.org/zap"
+
+	analysisclient "github.com/panther-labs/panther/api/gateway/analysis/client"
+	"github.com/panther-labs/panther/internal/log_analysis/correlation/engine"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+	"github.com/panther-labs/panther/pkg/lambdalogger"
+	"github.com/panther-labs/panther/pkg/oplog"
+)
+
+type envConfig struct {
+	AlertsDedupTable    string `required:"true" split_words:"true"`
+	AnalysisAPIHost     string `required:"true" split_words:"true"`
+	AnalysisAPIPath     string `required:"true" split_words:"true"`
+	CorrelationTable    string `required:"true" split_words:"true"`
+	NotificationsTopic  string `required:"true" split_words:"true"`
+	ProcessedDataBucket string `required:"true" split_words:"true"`
+}
+
+var correlationEngine *engine.Engine
+
+func init() {
+	// Required only once per Lambda container
+	var env envConfig
+	envconfig.MustProcess("", &env)
+
+	awsSession := session.Must(session.NewSession())
+	analysisConfig := analysisclient.DefaultTransportConfig().
+		WithHost(env.AnalysisAPIHost).
+		WithBasePath(env.AnalysisAPIPath)
+	correlationEngine = &engine.Engine{
+		S3Client:           s3.New(awsSession),
+		SnsClient:          sns.New(awsSession),
+		DdbClient:          dynamodb.New(awsSession),
+		AnalysisClient:     analysisclient.NewHTTPClientWithConfig(nil, analysisConfig),
+		HTTPClient:         gatewayapi.GatewayClient(awsSession),
+		Bucket:             env.ProcessedDataBucket,
+		NotificationsTopic: env.NotificationsTopic,
+		DedupTable:         env.AlertsDedupTable,
+		StateTable:         env.CorrelationTable,
+	}
+}
+
+func lambdaHandler(ctx context.Context, event events.SQSEvent) (err error) {
+	lc, _ := lambdalogger.ConfigureGlobal(ctx, nil)
+	operation := oplog.NewManager("log_analysis", "correlation").
+		Start(lc.InvokedFunctionArn).WithMemUsed(lambdacontext.MemoryLimitInMB)
+	defer func() {
+		operation.Stop().Log(err, zap.Int("sqsMessageCount", len(event.Records)))
+	}()
+
+	return correlationEngine.HandleSQS(event)
+}
+
+func main() {
+	lambda.Start(lambdaHandler)
+}
@@ -0,0 +1,144 @@
+package logtypes
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Canonical field names that log types can map in their data model
+const (
+	FieldSourceIP        = "source_ip"
+	FieldSourcePort      = "source_port"
+	FieldDestinationIP   = "destination_ip"
+	FieldDestinationPort = "destination_port"
+	FieldActorUser       = "actor_user"
+	FieldUserAgent       = "user_agent"
+)
+
+// DataModel maps canonical field names to dot separated JSON paths in the events of a log type.
+//
+// It allows a single rule or query to target the same concept (ie the source IP of a request)
+// across log types that name their fields differently.
+type DataModel map[string]string
+
+// Model is a named group of canonical fields that are queried together.
+type Model struct {
+	Name   string
+	Fields []string
+}
+
+// Models are the available data models.
+// A view is generated for each model over all log types that map at least one of its fields.
+var Models = []Model{
+	{
+		Name:   "network",
+		Fields: []string{FieldSourceIP, FieldSourcePort, FieldDestinationIP, FieldDestinationPort},
+	},
+	{
+		Name:   "identity",
+		Fields: []string{FieldActorUser, FieldSourceIP, FieldUserAgent},
+	},
+}
+
+// IsField checks if a name is a known canonical field
+func IsField(name string) bool {
+	for _, model := range Models {
+		for _, field := range model.Fields {
+			if field == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Fields returns the mapped canonical field names in sorted order
+func (m DataModel) Fields() []string {
+	fields := make([]string, 0, len(m))
+	for field := range m {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Covers checks if a data model maps at least one field of a model
+func (m DataModel) Covers(model Model) bool {
+	for _, field := range model.Fields {
+		if _, ok := m[field]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate verifies that all fields are known and all paths exist in the log type schema
+func (m DataModel) Validate(schema interface{}) error {
+	for _, field := range m.Fields() {
+		if !IsField(field) {
+			return errors.Errorf("unknown data model field %q", field)
+		}
+		path := m[field]
+		if path == "" {
+			return errors.Errorf("empty path for data model field %q", field)
+		}
+		if !hasJSONPath(reflect.TypeOf(schema), strings.Split(path, ".")) {
+			return errors.Errorf("path %q for data model field %q does not exist in schema", path, field)
+		}
+	}
+	return nil
+}
+
+// hasJSONPath resolves a path of JSON field names through a struct type, following embedded structs
+func hasJSONPath(typ reflect.Type, path []string) bool {
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if len(path) == 0 {
+		return true
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			if hasJSONPath(field.Type, path) {
+				return true
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if name == path[0] {
+			return hasJSONPath(field.Type, path[1:])
+		}
+	}
+	return false
+}
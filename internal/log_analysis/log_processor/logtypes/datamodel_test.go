@@ -0,0 +1,89 @@
+package logtypes
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/panther-labs/panther/internal/log_analysis/log_processor/parsers"
+)
+
+type dataModelTestClient struct {
+	IP   *string `json:"ipAddress,omitempty" description:"client ip"`
+	Port *int    `json:"port,omitempty" description:"client port"`
+}
+
+type dataModelTestEvent struct {
+	parsers.PantherLog
+	Client *dataModelTestClient `json:"client,omitempty" description:"client"`
+	User   string               `json:"user" description:"user"`
+	Hidden string               `json:"-"`
+}
+
+func TestDataModelValidate(t *testing.T) {
+	schema := dataModelTestEvent{}
+	require.NoError(t, DataModel{}.Validate(schema))
+	require.NoError(t, DataModel(nil).Validate(schema))
+	require.NoError(t, DataModel{
+		FieldSourceIP:   "client.ipAddress",
+		FieldSourcePort: "client.port",
+		FieldActorUser:  "user",
+	}.Validate(schema))
+	// Embedded struct fields are resolved
+	require.NoError(t, DataModel{FieldSourceIP: "p_log_type"}.Validate(&schema))
+
+	require.Error(t, DataModel{"source_address": "client.ipAddress"}.Validate(schema))
+	require.Error(t, DataModel{FieldSourceIP: ""}.Validate(schema))
+	require.Error(t, DataModel{FieldSourceIP: "client.ip"}.Validate(schema))
+	require.Error(t, DataModel{FieldSourceIP: "user.name"}.Validate(schema))
+	require.Error(t, DataModel{FieldActorUser: "Hidden"}.Validate(schema))
+}
+
+func TestDataModelCovers(t *testing.T) {
+	dm := DataModel{FieldUserAgent: "userAgent", FieldActorUser: "user"}
+	require.Equal(t, []string{FieldActorUser, FieldUserAgent}, dm.Fields())
+	require.False(t, dm.Covers(Models[0]))
+	require.True(t, dm.Covers(Models[1]))
+	require.True(t, IsField(FieldDestinationPort))
+	require.False(t, IsField("p_log_type"))
+}
+
+func TestRegistryDataModel(t *testing.T) {
+	r := Registry{}
+	config := Config{
+		Name:         "Foo.Bar",
+		Description:  "Foo.Bar logs",
+		ReferenceURL: "-",
+		Schema:       dataModelTestEvent{},
+		NewParser: parsers.FactoryFunc(func(params interface{}) (parsers.Interface, error) {
+			return nil, nil
+		}),
+		DataModel: DataModel{FieldSourceIP: "client.ipAddress"},
+	}
+	entry, err := r.Register(config)
+	require.NoError(t, err)
+	require.Equal(t, DataModel{FieldSourceIP: "client.ipAddress"}, entry.DataModel())
+
+	config.Name = "Foo.Baz"
+	config.DataModel = DataModel{FieldSourceIP: "client.missing"}
+	_, err = r.Register(config)
+	require.Error(t, err)
+}
@@ -121,6 +121,7 @@ func (r *Registry) Register(config Config) (Entry, error) {
 		return nil, err
 	}
 	newEntry := newEntry(config.Describe(), config.Schema, config.NewParser)
+	newEntry.dataModel = config.DataModel
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	if r.entries == nil {
@@ -149,6 +150,7 @@ type Entry interface {
 	NewParser(params interface{}) (parsers.Interface, error)
 	Schema() interface{}
 	GlueTableMeta() *awsglue.GlueTableMetadata
+	DataModel() DataModel
 	String() string
 }
 
@@ -161,6 +163,8 @@ type Config struct {
 	ReferenceURL string
 	Schema       interface{}
 	NewParser    parsers.Factory
+	// DataModel maps canonical field names to the JSON paths of the log type (optional)
+	DataModel DataModel
 }
 
 func (config *Config) Describe() Desc {
@@ -186,6 +190,9 @@ func (config *Config) Validate() error {
 	if config.NewParser == nil {
 		return errors.New("nil parser factory")
 	}
+	if err := config.DataModel.Validate(config.Schema); err != nil {
+		return errors.Wrapf(err, "invalid data model for log type %q", desc.Name)
+	}
 	return nil
 }
 
@@ -225,6 +232,7 @@ type entry struct {
 	schema        interface{}
 	newParser     parsers.FactoryFunc
 	glueTableMeta *awsglue.GlueTableMetadata
+	dataModel     DataModel
 }
 
 func newEntry(desc Desc, schema interface{}, fac parsers.Factory) *entry {
@@ -253,6 +261,11 @@ func (e *entry) GlueTableMeta() *awsglue.GlueTableMetadata {
 	return e.glueTableMeta
 }
 
+// DataModel returns the canonical field mappings for this entry
+func (e *entry) DataModel() DataModel {
+	return e.dataModel
+}
+
 // Parser returns a new parsers.Interface instance for this log type
 func (e *entry) NewParser(params interface{}) (parsers.Interface, error) {
 	return e.newParser(params)
@@ -45,6 +45,13 @@ func init() {
 			ReferenceURL: `https://docs.aws.amazon.com/elasticloadbalancing/latest/application/load-balancer-access-logs.html`,
 			Schema:       ALB{},
 			NewParser:    parsers.AdapterFactory(&ALBParser{}),
+			DataModel: logtypes.DataModel{
+				logtypes.FieldSourceIP:        `clientIp`,
+				logtypes.FieldSourcePort:      `clientPort`,
+				logtypes.FieldDestinationIP:   `targetIp`,
+				logtypes.FieldDestinationPort: `targetPort`,
+				logtypes.FieldUserAgent:       `userAgent`,
+			},
 		},
 		logtypes.Config{
 			Name:         TypeAuroraMySQLAudit,
@@ -59,6 +66,11 @@ func init() {
 			ReferenceURL: `https://docs.aws.amazon.com/awscloudtrail/latest/userguide/cloudtrail-event-reference.html`,
 			Schema:       CloudTrail{},
 			NewParser:    parsers.AdapterFactory(&CloudTrailParser{}),
+			DataModel: logtypes.DataModel{
+				logtypes.FieldSourceIP:  `sourceIPAddress`,
+				logtypes.FieldActorUser: `userIdentity.arn`,
+				logtypes.FieldUserAgent: `userAgent`,
+			},
 		},
 		logtypes.Config{
 			Name:         TypeCloudTrailDigest,
@@ -94,6 +106,11 @@ func init() {
 			ReferenceURL: `https://docs.aws.amazon.com/AmazonS3/latest/dev/LogFormat.html`,
 			Schema:       S3ServerAccess{},
 			NewParser:    parsers.AdapterFactory(&S3ServerAccessParser{}),
+			DataModel: logtypes.DataModel{
+				logtypes.FieldSourceIP:  `remoteip`,
+				logtypes.FieldActorUser: `requester`,
+				logtypes.FieldUserAgent: `useragent`,
+			},
 		},
 		logtypes.Config{
 			Name:         TypeVPCFlow,
@@ -101,6 +118,12 @@ func init() {
 			ReferenceURL: `https://docs.aws.amazon.com/vpc/latest/userguide/flow-logs-records-examples.html`,
 			Schema:       VPCFlow{},
 			NewParser:    parsers.AdapterFactory(&VPCFlowParser{}),
+			DataModel: logtypes.DataModel{
+				logtypes.FieldSourceIP:        `srcAddr`,
+				logtypes.FieldSourcePort:      `srcPort`,
+				logtypes.FieldDestinationIP:   `dstAddr`,
+				logtypes.FieldDestinationPort: `dstPort`,
+			},
 		},
 	)
 }
@@ -35,5 +35,10 @@ func init() {
 		ReferenceURL: `http://nginx.org/en/docs/http/ngx_http_log_module.html#log_format`,
 		Schema:       Access{},
 		NewParser:    parsers.AdapterFactory(&AccessParser{}),
+		DataModel: logtypes.DataModel{
+			logtypes.FieldSourceIP:  `remoteAddr`,
+			logtypes.FieldActorUser: `remoteUser`,
+			logtypes.FieldUserAgent: `httpUserAgent`,
+		},
 	})
 }
@@ -45,3 +45,13 @@ class AnalysisAPIClient:
         response = requests.get(prepped_request.url, headers=prepped_request.headers)
         response.raise_for_status()
         return response.json()['policies']
+
+    def get_data_models(self) -> Dict[str, Any]:
+        """Gets the data model mappings of all log types."""
+        request = AWSRequest(method='GET', url=self.url + '/datamodels')
+        self.signer.add_auth(request)
+        prepped_request = request.prepare()
+
+        response = requests.get(prepped_request.url, headers=prepped_request.headers)
+        response.raise_for_status()
+        return response.json()
@@ -0,0 +1,41 @@
+# Panther is a Cloud-Native SIEM for the Modern Security Team.
+# Copyright (C) 2020 Panther Labs Inc
+#
+# This program is free software: you can redistribute it and/or modify
+# it under the terms of the GNU Affero General Public License as
+# published by the Free Software Foundation, either version 3 of the
+# License, or (at your option) any later version.
+#
+# This program is distributed in the hope that it will be useful,
+# but WITHOUT ANY WARRANTY; without even the implied warranty of
+# MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+# GNU Affero General Public License for more details.
+#
+# You should have received a copy of the GNU Affero General Public License
+# along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+
+from typing import Any, Dict, Optional
+
+
+class PantherEvent(dict):
+    """Event that resolves canonical data model fields, so one rule can target many log types.
+
+    Rules can access mapped fields with event.udm('source_ip') regardless of how the log type names them.
+    """
+
+    def __init__(self, event: Dict[str, Any], mappings: Optional[Dict[str, str]] = None):
+        super().__init__(event)
+        self._mappings = mappings or {}
+
+    def udm(self, name: str) -> Any:
+        """Returns the value of a data model field, or None if it is not mapped or not present."""
+        path = self._mappings.get(name)
+        if not path:
+            return None
+        value: Any = self
+        for key in path.split('.'):
+            if not isinstance(value, dict):
+                return None
+            value = value.get(key)
+        return value
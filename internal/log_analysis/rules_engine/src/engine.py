@@ -21,6 +21,7 @@ from typing import Any, Dict, List
 
 from . import EventMatch
 from .analysis_api import AnalysisAPIClient
+from .data_model import PantherEvent
 from .logging import get_logger
 from .rule import Rule
 
@@ -34,6 +35,7 @@ class Engine:
         self.logger = get_logger()
         self._last_update = datetime.utcfromtimestamp(0)
         self.log_type_to_rules: Dict[str, List[Rule]] = collections.defaultdict(list)
+        self.log_type_to_mappings: Dict[str, Dict[str, str]] = {}
         self._analysis_client = analysis_api
         self._populate_rules()
 
@@ -44,10 +46,11 @@ class Engine:
             self._populate_rules()
 
         matched: List[EventMatch] = []
+        rule_event = self.wrap_event(log_type, event)
 
         for rule in self.log_type_to_rules[log_type]:
             self.logger.debug('running rule [%s]', rule.rule_id)
-            result = rule.run(event)
+            result = rule.run(rule_event)
             if result.exception:
                 self.logger.error('failed to run rule %s %s %s', rule.rule_id, type(result).__name__, repr(result.exception))
                 continue
@@ -67,6 +70,10 @@ class Engine:
 
         return matched
 
+    def wrap_event(self, log_type: str, event: Dict[str, Any]) -> PantherEvent:
+        """Wraps an event so that rules can access the data model fields of its log type."""
+        return PantherEvent(event, self.log_type_to_mappings.get(log_type))
+
     def _populate_rules(self) -> None:
         """Import all rules."""
         import_count = 0
@@ -93,8 +100,21 @@ class Engine:
 
         end = default_timer()
         self.logger.info('Imported %d rules in %d seconds', import_count, end - start)
+        self._populate_data_models()
         self._last_update = datetime.utcnow()
 
+    def _populate_data_models(self) -> None:
+        """Import the data model mappings of all log types.
+
+        Rules still run without data models, so failures keep the previous mappings.
+        """
+        try:
+            mappings = self._analysis_client.get_data_models()['mappings']
+        except Exception as err:  # pylint: disable=broad-except
+            self.logger.error('Failed to retrieve data models. Error: [%s]', err)
+            return
+        self.log_type_to_mappings = {log_type: dict(fields) for log_type, fields in dict(mappings).items()}
+
     def _get_rules(self) -> List[Dict[str, Any]]:
         """Retrieves all enabled rules.
 
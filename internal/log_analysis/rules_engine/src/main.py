@@ -76,7 +76,7 @@ def direct_analysis(request: Dict[str, Any]) -> Dict[str, Any]:
             # If rule was invalid, no need to try to run it
 
         else:
-            rule_result = test_rule.run(event['data'])
+            rule_result = test_rule.run(_RULES_ENGINE.wrap_event(_event_log_type(raw_rule, event['data']), event['data']))
             if rule_result.exception:
                 result['errored'] = [
                     {
@@ -86,6 +86,7 @@ def direct_analysis(request: Dict[str, Any]) -> Dict[str, Any]:
                 ]
             elif rule_result.matched:
                 result['matched'] = [raw_rule['id']]
+                result['dedup'] = rule_result.dedup_string
             else:
                 result['notMatched'] = [raw_rule['id']]
 
@@ -93,6 +94,14 @@ def direct_analysis(request: Dict[str, Any]) -> Dict[str, Any]:
     return response
 
 
+# Returns the log type of an event for resolving data model fields
+def _event_log_type(raw_rule: Dict[str, Any], data: Dict[str, Any]) -> str:
+    if isinstance(data.get('p_log_type'), str):
+        return data['p_log_type']
+    log_types = raw_rule.get('logTypes') or raw_rule.get('resourceTypes') or []
+    return log_types[0] if len(log_types) == 1 else ''
+
+
 # pylint: disable=too-many-locals
 def log_analysis(event: Dict[str, Any]) -> None:
     """Runs log analysis"""
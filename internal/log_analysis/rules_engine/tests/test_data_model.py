@@ -0,0 +1,40 @@
+# Panther is a Cloud-Native SIEM for the Modern Security Team.
+# Copyright (C) 2020 Panther Labs Inc
+#
+# This program is free software: you can redistribute it and/or modify
+# it under the terms of the GNU Affero General Public License as
+# published by the Free Software Foundation, either version 3 of the
+# License, or (at your option) any later version.
+#
+# This program is distributed in the hope that it will be useful,
+# but WITHOUT ANY WARRANTY; without even the implied warranty of
+# MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+# GNU Affero General Public License for more details.
+#
+# You should have received a copy of the GNU Affero General Public License
+# along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+
+from unittest import TestCase
+
+from ..src.data_model import PantherEvent
+
+
+class TestPantherEvent(TestCase):
+
+    def test_udm(self) -> None:
+        event = PantherEvent({'client': {'ipAddress': '1.2.3.4'}, 'user': 'bob'}, {'source_ip': 'client.ipAddress', 'actor_user': 'user'})
+        self.assertEqual(event.udm('source_ip'), '1.2.3.4')
+        self.assertEqual(event.udm('actor_user'), 'bob')
+        self.assertEqual(event['user'], 'bob')
+
+    def test_udm_missing(self) -> None:
+        event = PantherEvent({'client': 'not-a-dict'}, {'source_ip': 'client.ipAddress', 'destination_ip': 'server.ip'})
+        self.assertIsNone(event.udm('source_ip'))
+        self.assertIsNone(event.udm('destination_ip'))
+        self.assertIsNone(event.udm('user_agent'))
+
+    def test_udm_no_mappings(self) -> None:
+        event = PantherEvent({'sourceIPAddress': '1.2.3.4'})
+        self.assertIsNone(event.udm('source_ip'))
+        self.assertEqual(event, {'sourceIPAddress': '1.2.3.4'})
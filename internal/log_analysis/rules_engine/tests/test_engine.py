@@ -118,3 +118,47 @@ class TestEngine(TestCase):
         ]
 
         self.assertEqual(result, expected_event_matches)
+
+    def test_analyze_data_model(self) -> None:
+        analysis_api = mock.MagicMock()
+        analysis_api.get_enabled_rules.return_value = [
+            {
+                'id': 'rule_id',
+                'resourceTypes': ['log_a', 'log_b'],
+                'body': 'def rule(event):\n\treturn event.udm("source_ip") == "1.2.3.4"',
+                'versionId': 'version'
+            }
+        ]
+        analysis_api.get_data_models.return_value = {
+            'mappings': {
+                'log_a': {
+                    'source_ip': 'sourceIPAddress'
+                },
+                'log_b': {
+                    'source_ip': 'client.ip'
+                },
+            },
+            'models': {},
+        }
+        engine = Engine(analysis_api)
+
+        self.assertEqual(len(engine.analyze('log_a', {'sourceIPAddress': '1.2.3.4'})), 1)
+        self.assertEqual(len(engine.analyze('log_b', {'client': {'ip': '1.2.3.4'}})), 1)
+        self.assertEqual(len(engine.analyze('log_b', {'sourceIPAddress': '1.2.3.4'})), 0)
+        # The original event is returned in the match
+        self.assertEqual(type(engine.analyze('log_a', {'sourceIPAddress': '1.2.3.4'})[0].event), dict)
+
+    def test_data_models_failure(self) -> None:
+        analysis_api = mock.MagicMock()
+        analysis_api.get_enabled_rules.return_value = [
+            {
+                'id': 'rule_id',
+                'resourceTypes': ['log'],
+                'body': 'def rule(event):\n\treturn event.udm("source_ip") is None',
+                'versionId': 'version'
+            }
+        ]
+        analysis_api.get_data_models.side_effect = Exception('failed')
+        engine = Engine(analysis_api)
+        self.assertEqual(len(engine.log_type_to_rules['log']), 1)
+        self.assertEqual(len(engine.analyze('log', {})), 1)
@@ -24,7 +24,7 @@ from botocore.auth import SigV4Auth
 from . import mock_to_return
 
 _RESPONSE_MOCK = mock.MagicMock()
-_RESPONSE_MOCK.json.return_value = {'policies': []}
+_RESPONSE_MOCK.json.return_value = {'policies': [], 'mappings': {}}
 
 _ENV_VARIABLES_MOCK = {
     'ALERTS_DEDUP_TABLE': 'table_name',
@@ -45,7 +45,35 @@ class TestMainDirectAnalysis(TestCase):
     def test_direct_analysis_event_matching(self) -> None:
         rule_body = 'def rule(event):\n\treturn True'
         payload = {'rules': [{'id': 'rule_id', 'body': rule_body}], 'events': [{'id': 'event_id', 'data': 'data'}]}
-        expected_response = {'events': [{'id': 'event_id', 'matched': ['rule_id'], 'notMatched': [], 'errored': []}]}
+        expected_response = {
+            'events':
+                [
+                    {
+                        'id': 'event_id',
+                        'matched': ['rule_id'],
+                        'notMatched': [],
+                        'errored': [],
+                        'dedup': 'defaultDedupString:rule_id'
+                    }
+                ]
+        }
+        self.assertEqual(expected_response, lambda_handler(payload, None))
+
+    def test_direct_analysis_event_matching_dedup(self) -> None:
+        rule_body = 'def rule(event):\n\treturn True\ndef dedup(event):\n\treturn "user"'
+        payload = {'rules': [{'id': 'rule_id', 'body': rule_body}], 'events': [{'id': 'event_id', 'data': 'data'}]}
+        expected_response = {
+            'events':
+                [
+                    {
+                        'id': 'event_id',
+                        'matched': ['rule_id'],
+                        'notMatched': [],
+                        'errored': [],
+                        'dedup': 'user'
+                    }
+                ]
+        }
         self.assertEqual(expected_response, lambda_handler(payload, None))
 
     def test_direct_analysis_event_not_matching(self) -> None:
@@ -0,0 +1,84 @@
+package main
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/athena"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/kelseyhightower/envconfig"
+
+	analysisclient "github.com/panther-labs/panther/api/gateway/analysis/client"
+	"github.com/panther-labs/panther/internal/log_analysis/scheduled_queries/runner"
+	"github.com/panther-labs/panther/pkg/gatewayapi"
+	"github.com/panther-labs/panther/pkg/lambdalogger"
+	"github.com/panther-labs/panther/pkg/oplog"
+)
+
+type envConfig struct {
+	AlertsDedupTable string `required:"true" split_words:"true"`
+	AnalysisAPIHost  string `required:"true" split_words:"true"`
+	AnalysisAPIPath  string `required:"true" split_words:"true"`
+}
+
+var queryRunner *runner.Runner
+
+func init() {
+	// Required only once per Lambda container
+	var env envConfig
+	envconfig.MustProcess("", &env)
+
+	awsSession := session.Must(session.NewSession())
+	analysisConfig := analysisclient.DefaultTransportConfig().
+		WithHost(env.AnalysisAPIHost).
+		WithBasePath(env.AnalysisAPIPath)
+	queryRunner = &runner.Runner{
+		AthenaClient:   athena.New(awsSession),
+		DdbClient:      dynamodb.New(awsSession),
+		AnalysisClient: analysisclient.NewHTTPClientWithConfig(nil, analysisConfig),
+		HTTPClient:     gatewayapi.GatewayClient(awsSession),
+		DedupTable:     env.AlertsDedupTable,
+	}
+}
+
+func lambdaHandler(ctx context.Context, event events.CloudWatchEvent) (err error) {
+	lc, _ := lambdalogger.ConfigureGlobal(ctx, nil)
+	operation := oplog.NewManager("log_analysis", "scheduled_queries").
+		Start(lc.InvokedFunctionArn).WithMemUsed(lambdacontext.MemoryLimitInMB)
+	defer func() {
+		operation.Stop().Log(err)
+	}()
+
+	// Use the scheduled time of the event, so a delayed invocation still runs the right queries
+	now := event.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+	return queryRunner.Run(now)
+}
+
+func main() {
+	lambda.Start(lambdaHandler)
+}
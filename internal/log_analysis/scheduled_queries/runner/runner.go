@@ -0,0 +1,245 @@
+package runner
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/athena"
+	"github.com/aws/aws-sdk-go/service/athena/athenaiface"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	analysisclient "github.com/panther-labs/panther/api/gateway/analysis/client"
+	"github.com/panther-labs/panther/api/gateway/analysis/client/operations"
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	"github.com/panther-labs/panther/internal/log_analysis/alertdedup"
+	"github.com/panther-labs/panther/internal/log_analysis/awsglue"
+	"github.com/panther-labs/panther/internal/log_analysis/scheduled_queries/schedule"
+	"github.com/panther-labs/panther/pkg/awsathena"
+)
+
+const (
+	// Limit the number of Athena queries running at the same time
+	maxConcurrentQueries = 5
+
+	// Result rows beyond this limit are ignored
+	maxResultRows = 10000
+
+	// Used when a query has no dedup column, or the column is missing or null in a row
+	defaultDedupString = "defaultDedupString"
+
+	// Used when a query doesn't declare the log types it reads
+	defaultLogType = "ScheduledQuery"
+
+	// Used when a query doesn't set its dedup period
+	defaultDedupPeriodMinutes = 60
+)
+
+// Runner executes the scheduled queries which are due and turns their results into alerts.
+//
+// Results are written to the alert dedup table in the same way as the rules engine does for streaming rules,
+// so the alert forwarder creates (or updates) the alerts and sends them for delivery.
+type Runner struct {
+	AthenaClient   athenaiface.AthenaAPI
+	DdbClient      dynamodbiface.DynamoDBAPI
+	AnalysisClient *analysisclient.PantherAnalysis
+	HTTPClient     *http.Client
+	DedupTable     string
+}
+
+// Run all of the enabled scheduled queries which are due at the given minute.
+func (r *Runner) Run(now time.Time) error {
+	now = now.UTC().Truncate(time.Minute)
+
+	result, err := r.AnalysisClient.Operations.GetEnabledPolicies(&operations.GetEnabledPoliciesParams{
+		HTTPClient: r.HTTPClient,
+		Type:       string(models.AnalysisTypeSCHEDULEDQUERY),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to load scheduled queries from analysis-api")
+	}
+
+	var due []*models.EnabledPolicy
+	for _, query := range result.Payload.Policies {
+		querySchedule, err := schedule.Parse(string(query.Schedule))
+		if err != nil {
+			// The analysis-api validates schedules, this should never happen
+			zap.L().Error("skipping scheduled query with invalid schedule",
+				zap.String("queryId", string(query.ID)), zap.Error(err))
+			continue
+		}
+		if querySchedule.Due(now) {
+			due = append(due, query)
+		}
+	}
+	zap.L().Info("running scheduled queries",
+		zap.Int("enabled", len(result.Payload.Policies)), zap.Int("due", len(due)))
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed int
+	)
+	semaphore := make(chan struct{}, maxConcurrentQueries)
+	for _, query := range due {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(query *models.EnabledPolicy) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			if err := r.runQuery(query, now); err != nil {
+				zap.L().Error("scheduled query failed", zap.String("queryId", string(query.ID)), zap.Error(err))
+				mu.Lock()
+				failed++
+				mu.Unlock()
+			}
+		}(query)
+	}
+	wg.Wait()
+
+	// A failed query does not stop the others, it runs again at its next scheduled time.
+	// The failure is not returned: a retried invocation would run the queries which succeeded a second time
+	// and count their results twice in the alert dedup table.
+	if failed > 0 {
+		zap.L().Warn("some scheduled queries failed", zap.Int("failed", failed), zap.Int("due", len(due)))
+	}
+	return nil
+}
+
+// Execute a single scheduled query and store its results in the alert dedup table
+func (r *Runner) runQuery(query *models.EnabledPolicy, now time.Time) error {
+	rows, err := r.queryRows(string(query.Body))
+	if err != nil {
+		return errors.Wrapf(err, "failed to run scheduled query %s", query.ID)
+	}
+
+	groups := groupRows(rows, string(query.DedupColumn))
+	for dedup, count := range groups {
+		if err := r.updateDedup(query, dedup, count, now); err != nil {
+			return errors.Wrapf(err, "failed to update alert dedup for scheduled query %s", query.ID)
+		}
+	}
+
+	zap.L().Info("scheduled query finished", zap.String("queryId", string(query.ID)),
+		zap.Int("rows", len(rows)), zap.Int("alerts", len(groups)))
+	return nil
+}
+
+// Run a query against the panther views and return its result rows as column name -> value
+func (r *Runner) queryRows(sql string) ([]map[string]*string, error) {
+	startOutput, err := awsathena.StartQuery(r.AthenaClient, awsglue.ViewsDatabaseName, sql, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := awsathena.WaitForResults(r.AthenaClient, *startOutput.QueryExecutionId)
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []string
+	var rows []map[string]*string
+	for first := true; ; first = false {
+		if first {
+			for _, column := range page.ResultSet.ResultSetMetadata.ColumnInfo {
+				columns = append(columns, aws.StringValue(column.Name))
+			}
+		}
+
+		for i, row := range page.ResultSet.Rows {
+			if first && i == 0 && isHeader(row, columns) {
+				// The first row of a SELECT result holds the column names
+				continue
+			}
+			if len(rows) == maxResultRows {
+				zap.L().Warn("scheduled query result truncated", zap.Int("maxRows", maxResultRows))
+				return rows, nil
+			}
+			values := make(map[string]*string, len(columns))
+			for j, datum := range row.Data {
+				if j < len(columns) {
+					values[columns[j]] = datum.VarCharValue
+				}
+			}
+			rows = append(rows, values)
+		}
+
+		if page.NextToken == nil {
+			return rows, nil
+		}
+		if page, err = awsathena.Results(r.AthenaClient, *startOutput.QueryExecutionId, page.NextToken, nil); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func isHeader(row *athena.Row, columns []string) bool {
+	if len(row.Data) != len(columns) {
+		return false
+	}
+	for i, datum := range row.Data {
+		if aws.StringValue(datum.VarCharValue) != columns[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Count the result rows for each value of the dedup column
+func groupRows(rows []map[string]*string, dedupColumn string) map[string]int {
+	result := make(map[string]int)
+	for _, row := range rows {
+		dedup := defaultDedupString
+		if value := row[dedupColumn]; dedupColumn != "" && value != nil && *value != "" {
+			dedup = *value
+		}
+		result[dedup]++
+	}
+	return result
+}
+
+// Add a group of result rows to the alert dedup table
+func (r *Runner) updateDedup(query *models.EnabledPolicy, dedup string, count int, now time.Time) error {
+	dedupPeriod := int64(query.DedupPeriodMinutes)
+	if dedupPeriod == 0 {
+		dedupPeriod = defaultDedupPeriodMinutes
+	}
+
+	logTypes := []string(query.ResourceTypes)
+	if len(logTypes) == 0 {
+		logTypes = []string{defaultLogType}
+	}
+
+	_, err := alertdedup.Update(r.DdbClient, r.DedupTable, &alertdedup.Group{
+		RuleID:             string(query.ID),
+		RuleVersion:        string(query.VersionID),
+		Dedup:              dedup,
+		DedupPeriodMinutes: dedupPeriod,
+		EventCount:         count,
+		LogTypes:           logTypes,
+	}, now)
+	return err
+}
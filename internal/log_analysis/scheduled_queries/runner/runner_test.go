@@ -0,0 +1,224 @@
+package runner
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/athena"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	analysisclient "github.com/panther-labs/panther/api/gateway/analysis/client"
+	"github.com/panther-labs/panther/api/gateway/analysis/models"
+	"github.com/panther-labs/panther/internal/log_analysis/alertdedup"
+	"github.com/panther-labs/panther/pkg/testutils"
+)
+
+type mockRoundTripper struct {
+	http.RoundTripper
+	mock.Mock
+}
+
+func (m *mockRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	args := m.Called(request)
+	return args.Get(0).(*http.Response), args.Error(1)
+}
+
+func generateResponse(body interface{}, httpCode int) *http.Response {
+	serializedBody, _ := jsoniter.MarshalToString(body)
+	return &http.Response{StatusCode: httpCode, Body: ioutil.NopCloser(strings.NewReader(serializedBody))}
+}
+
+func athenaRow(values ...string) *athena.Row {
+	row := &athena.Row{}
+	for _, value := range values {
+		row.Data = append(row.Data, &athena.Datum{VarCharValue: aws.String(value)})
+	}
+	return row
+}
+
+func newTestRunner() (*Runner, *testutils.AthenaMock, *testutils.DynamoDBMock, *mockRoundTripper) {
+	athenaMock := &testutils.AthenaMock{}
+	ddbMock := &testutils.DynamoDBMock{}
+	roundTripper := &mockRoundTripper{}
+	analysisConfig := analysisclient.DefaultTransportConfig().WithHost("host").WithBasePath("path")
+	return &Runner{
+		AthenaClient:   athenaMock,
+		DdbClient:      ddbMock,
+		AnalysisClient: analysisclient.NewHTTPClientWithConfig(nil, analysisConfig),
+		HTTPClient:     &http.Client{Transport: roundTripper},
+		DedupTable:     "dedupTable",
+	}, athenaMock, ddbMock, roundTripper
+}
+
+func mockQuery(athenaMock *testutils.AthenaMock, pages ...*athena.GetQueryResultsOutput) {
+	athenaMock.On("StartQueryExecution", mock.Anything).Return(
+		&athena.StartQueryExecutionOutput{QueryExecutionId: aws.String("queryId")}, nil).Once()
+	athenaMock.On("GetQueryExecution", mock.Anything).Return(&athena.GetQueryExecutionOutput{
+		QueryExecution: &athena.QueryExecution{
+			QueryExecutionId: aws.String("queryId"),
+			Status:           &athena.QueryExecutionStatus{State: aws.String(athena.QueryExecutionStateSucceeded)},
+		},
+	}, nil).Once()
+	for _, page := range pages {
+		athenaMock.On("GetQueryResults", mock.Anything).Return(page, nil).Once()
+	}
+}
+
+var (
+	testNow = time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	testDedupOutput = &dynamodb.UpdateItemOutput{Attributes: map[string]*dynamodb.AttributeValue{
+		"alertCount":        {N: aws.String("1")},
+		"alertCreationTime": {N: aws.String("1591012800")},
+	}}
+
+	testMetadata = &athena.ResultSetMetadata{
+		ColumnInfo: []*athena.ColumnInfo{{Name: aws.String("user")}, {Name: aws.String("count")}},
+	}
+)
+
+func TestRunDueQuery(t *testing.T) {
+	runner, athenaMock, ddbMock, roundTripper := newTestRunner()
+
+	enabled := &models.EnabledPolicies{Policies: []*models.EnabledPolicy{
+		{
+			ID:          "due",
+			Body:        "SELECT user, count(*) AS count FROM logs GROUP BY user",
+			DedupColumn: "user",
+			Schedule:    "rate(1 hours)",
+			VersionID:   "v1",
+		},
+		{
+			ID:       "notDue",
+			Body:     "SELECT 1",
+			Schedule: "cron(30 * * * ? *)",
+		},
+	}}
+	roundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(enabled, http.StatusOK), nil).Once()
+
+	mockQuery(athenaMock,
+		&athena.GetQueryResultsOutput{
+			NextToken: aws.String("page2"),
+			ResultSet: &athena.ResultSet{
+				ResultSetMetadata: testMetadata,
+				Rows:              []*athena.Row{athenaRow("user", "count"), athenaRow("alice", "3"), athenaRow("bob", "1")},
+			},
+		},
+		&athena.GetQueryResultsOutput{
+			ResultSet: &athena.ResultSet{
+				ResultSetMetadata: testMetadata,
+				Rows:              []*athena.Row{athenaRow("alice", "2")},
+			},
+		},
+	)
+
+	var updates []*dynamodb.UpdateItemInput
+	ddbMock.On("UpdateItem", mock.Anything).Run(func(args mock.Arguments) {
+		updates = append(updates, args.Get(0).(*dynamodb.UpdateItemInput))
+	}).Return(testDedupOutput, nil)
+
+	require.NoError(t, runner.Run(testNow.Add(15*time.Second)))
+
+	// one alert per distinct user, rows from every result page are included
+	require.Len(t, updates, 2)
+	var keys []string
+	for _, update := range updates {
+		assert.Equal(t, "dedupTable", *update.TableName)
+		assert.NotNil(t, update.ConditionExpression)
+		keys = append(keys, *update.Key["partitionKey"].S)
+	}
+	assert.ElementsMatch(t, []string{alertdedup.Key("due", "alice"), alertdedup.Key("due", "bob")}, keys)
+
+	athenaMock.AssertExpectations(t)
+	ddbMock.AssertExpectations(t)
+	roundTripper.AssertExpectations(t)
+}
+
+func TestRunMergesIntoExistingAlert(t *testing.T) {
+	runner, athenaMock, ddbMock, _ := newTestRunner()
+	mockQuery(athenaMock, &athena.GetQueryResultsOutput{
+		ResultSet: &athena.ResultSet{
+			ResultSetMetadata: testMetadata,
+			Rows:              []*athena.Row{athenaRow("user", "count"), athenaRow("alice", "1")},
+		},
+	})
+
+	conditionFailed := awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "", nil)
+	ddbMock.On("UpdateItem", mock.MatchedBy(func(input *dynamodb.UpdateItemInput) bool {
+		return input.ConditionExpression != nil
+	})).Return(&dynamodb.UpdateItemOutput{}, conditionFailed).Once()
+	ddbMock.On("UpdateItem", mock.MatchedBy(func(input *dynamodb.UpdateItemInput) bool {
+		return input.ConditionExpression == nil
+	})).Return(testDedupOutput, nil).Once()
+
+	query := &models.EnabledPolicy{ID: "query", Body: "SELECT 1", Schedule: "rate(5 minutes)"}
+	require.NoError(t, runner.runQuery(query, testNow))
+
+	athenaMock.AssertExpectations(t)
+	ddbMock.AssertExpectations(t)
+}
+
+func TestRunQueryFailure(t *testing.T) {
+	runner, athenaMock, _, _ := newTestRunner()
+	athenaMock.On("StartQueryExecution", mock.Anything).Return(
+		&athena.StartQueryExecutionOutput{}, awserr.New(athena.ErrCodeInvalidRequestException, "bad sql", nil)).Once()
+
+	query := &models.EnabledPolicy{ID: "query", Body: "SELEC 1", Schedule: "rate(5 minutes)"}
+	assert.Error(t, runner.runQuery(query, testNow))
+	athenaMock.AssertExpectations(t)
+}
+
+func TestRunIgnoresQueryFailure(t *testing.T) {
+	runner, athenaMock, ddbMock, roundTripper := newTestRunner()
+	enabled := &models.EnabledPolicies{Policies: []*models.EnabledPolicy{
+		{ID: "broken", Body: "SELEC 1", Schedule: "rate(5 minutes)"},
+	}}
+	roundTripper.On("RoundTrip", mock.Anything).Return(generateResponse(enabled, http.StatusOK), nil).Once()
+	athenaMock.On("StartQueryExecution", mock.Anything).Return(
+		&athena.StartQueryExecutionOutput{}, awserr.New(athena.ErrCodeInvalidRequestException, "bad sql", nil)).Once()
+
+	// The invocation succeeds so it is not retried
+	require.NoError(t, runner.Run(testNow))
+
+	athenaMock.AssertExpectations(t)
+	ddbMock.AssertExpectations(t)
+	roundTripper.AssertExpectations(t)
+}
+
+func TestGroupRows(t *testing.T) {
+	rows := []map[string]*string{
+		{"user": aws.String("alice")},
+		{"user": aws.String("alice")},
+		{"user": nil},
+		{"other": aws.String("x")},
+	}
+	assert.Equal(t, map[string]int{"alice": 2, defaultDedupString: 2}, groupRows(rows, "user"))
+	assert.Equal(t, map[string]int{defaultDedupString: 4}, groupRows(rows, ""))
+}
@@ -0,0 +1,193 @@
+package schedule
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	rateRegex = regexp.MustCompile(`^rate\((\d+) (minutes?|hours?|days?)\)$`)
+	cronRegex = regexp.MustCompile(`^cron\((.+)\)$`)
+
+	monthNames   = map[string]int{"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6, "JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12}
+	weekdayNames = map[string]int{"SUN": 1, "MON": 2, "TUE": 3, "WED": 4, "THU": 5, "FRI": 6, "SAT": 7}
+)
+
+// Schedule decides at which minutes a scheduled query runs.
+//
+// The expressions have the same format as CloudWatch Events schedules, e.g. "rate(15 minutes)" or "cron(0 12 * * ? *)".
+// All times are UTC.
+type Schedule struct {
+	// rate schedules: run every N minutes, aligned to the unix epoch
+	rateMinutes int64
+
+	// cron schedules: run at every minute which matches all fields, nil fields match everything
+	minutes, hours, daysOfMonth, months, daysOfWeek, years map[int]bool
+}
+
+// Parse a rate() or cron() schedule expression.
+func Parse(expression string) (*Schedule, error) {
+	expression = strings.TrimSpace(expression)
+	if match := rateRegex.FindStringSubmatch(expression); match != nil {
+		return parseRate(match[1], match[2])
+	}
+	if match := cronRegex.FindStringSubmatch(expression); match != nil {
+		return parseCron(match[1])
+	}
+	return nil, fmt.Errorf("invalid schedule %q: expected rate(value unit) or cron(fields)", expression)
+}
+
+func parseRate(rawValue, unit string) (*Schedule, error) {
+	value, err := strconv.ParseInt(rawValue, 10, 64)
+	if err != nil || value <= 0 {
+		return nil, fmt.Errorf("invalid rate %q: must be a positive integer", rawValue)
+	}
+
+	switch strings.TrimSuffix(unit, "s") {
+	case "hour":
+		value *= 60
+	case "day":
+		value *= 24 * 60
+	}
+	return &Schedule{rateMinutes: value}, nil
+}
+
+// cron(minutes hours day-of-month month day-of-week year)
+func parseCron(raw string) (*Schedule, error) {
+	fields := strings.Fields(raw)
+	if len(fields) != 6 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 6 fields, found %d", raw, len(fields))
+	}
+	if (fields[2] == "?") == (fields[4] == "?") {
+		return nil, fmt.Errorf("invalid cron expression %q: exactly one of day-of-month and day-of-week must be '?'", raw)
+	}
+
+	var result Schedule
+	var err error
+	if result.minutes, err = parseField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("invalid cron minutes: %s", err)
+	}
+	if result.hours, err = parseField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("invalid cron hours: %s", err)
+	}
+	if result.daysOfMonth, err = parseField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("invalid cron day-of-month: %s", err)
+	}
+	if result.months, err = parseField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("invalid cron month: %s", err)
+	}
+	if result.daysOfWeek, err = parseField(fields[4], 1, 7, weekdayNames); err != nil {
+		return nil, fmt.Errorf("invalid cron day-of-week: %s", err)
+	}
+	if result.years, err = parseField(fields[5], 1970, 2199, nil); err != nil {
+		return nil, fmt.Errorf("invalid cron year: %s", err)
+	}
+	return &result, nil
+}
+
+// Parse a single cron field: "*", "?", or a comma-separated list of values, ranges and steps
+//
+// A nil set is returned for wildcards.
+func parseField(field string, min, max int, names map[string]int) (map[int]bool, error) {
+	if field == "*" || field == "?" {
+		return nil, nil
+	}
+
+	result := make(map[int]bool)
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step in %q", part)
+			}
+			rangePart = part[:i]
+		}
+
+		start, end := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if start, err = parseValue(bounds[0], min, max, names); err != nil {
+				return nil, err
+			}
+			if end, err = parseValue(bounds[1], min, max, names); err != nil {
+				return nil, err
+			}
+			if end < start {
+				return nil, fmt.Errorf("invalid range %q", rangePart)
+			}
+		default:
+			var err error
+			if start, err = parseValue(rangePart, min, max, names); err != nil {
+				return nil, err
+			}
+			if step == 1 {
+				// a single value
+				end = start
+			}
+		}
+
+		for value := start; value <= end; value += step {
+			result[value] = true
+		}
+	}
+	return result, nil
+}
+
+func parseValue(raw string, min, max int, names map[string]int) (int, error) {
+	if value, ok := names[strings.ToUpper(raw)]; ok {
+		return value, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("unsupported value %q", raw)
+	}
+	if value < min || value > max {
+		return 0, fmt.Errorf("value %d is out of range [%d, %d]", value, min, max)
+	}
+	return value, nil
+}
+
+// Due returns true if the schedule runs at the minute of the given time.
+func (s *Schedule) Due(t time.Time) bool {
+	t = t.UTC()
+	if s.rateMinutes > 0 {
+		return (t.Unix()/60)%s.rateMinutes == 0
+	}
+
+	return matches(s.minutes, t.Minute()) &&
+		matches(s.hours, t.Hour()) &&
+		matches(s.daysOfMonth, t.Day()) &&
+		matches(s.months, int(t.Month())) &&
+		// cron days of the week start at 1 (Sunday), Go starts at 0
+		matches(s.daysOfWeek, int(t.Weekday())+1) &&
+		matches(s.years, t.Year())
+}
+
+func matches(set map[int]bool, value int) bool {
+	return set == nil || set[value]
+}
@@ -0,0 +1,91 @@
+package schedule
+
+/**
+ * Panther is a Cloud-Native SIEM for the Modern Security Team.
+ * Copyright (C) 2020 Panther Labs Inc
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A Wednesday
+var testTime = time.Date(2020, 6, 17, 12, 30, 0, 0, time.UTC)
+
+func TestRate(t *testing.T) {
+	schedule, err := Parse("rate(15 minutes)")
+	require.NoError(t, err)
+	assert.True(t, schedule.Due(testTime))
+	assert.True(t, schedule.Due(testTime.Add(15*time.Minute)))
+	assert.False(t, schedule.Due(testTime.Add(5*time.Minute)))
+
+	schedule, err = Parse("rate(1 hour)")
+	require.NoError(t, err)
+	assert.False(t, schedule.Due(testTime))
+	assert.True(t, schedule.Due(testTime.Add(30*time.Minute)))
+
+	schedule, err = Parse("rate(1 day)")
+	require.NoError(t, err)
+	assert.True(t, schedule.Due(time.Date(2020, 6, 17, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, schedule.Due(testTime))
+}
+
+func TestCron(t *testing.T) {
+	schedule, err := Parse("cron(30 12 * * ? *)")
+	require.NoError(t, err)
+	assert.True(t, schedule.Due(testTime))
+	assert.False(t, schedule.Due(testTime.Add(time.Minute)))
+	assert.True(t, schedule.Due(testTime.Add(24*time.Hour)))
+
+	// Every 10 minutes during business hours on weekdays
+	schedule, err = Parse("cron(0/10 9-17 ? * MON-FRI *)")
+	require.NoError(t, err)
+	assert.True(t, schedule.Due(testTime))
+	assert.False(t, schedule.Due(testTime.Add(5*time.Minute)))
+	assert.False(t, schedule.Due(testTime.Add(12*time.Hour)))
+	// Saturday
+	assert.False(t, schedule.Due(testTime.Add(3*24*time.Hour)))
+
+	// First of the month, only in some months
+	schedule, err = Parse("cron(0 0 1 JAN,jul ? 2020)")
+	require.NoError(t, err)
+	assert.True(t, schedule.Due(time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, schedule.Due(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, schedule.Due(time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, expression := range []string{
+		"",
+		"every 5 minutes",
+		"rate(0 minutes)",
+		"rate(5 weeks)",
+		"cron(* * * * *)",
+		"cron(0 12 * * * *)",
+		"cron(0 12 ? * ? *)",
+		"cron(60 12 * * ? *)",
+		"cron(0 12 L * ? *)",
+		"cron(0 12-10 * * ? *)",
+		"cron(0/0 12 * * ? *)",
+	} {
+		_, err := Parse(expression)
+		assert.Error(t, err, expression)
+	}
+}
@@ -24,8 +24,13 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
-// Filepath is the config settings file
-const Filepath = "deployments/panther_config.yml"
+const (
+	// Filepath is the config settings file
+	Filepath = "deployments/panther_config.yml"
+
+	// Used when the setting is missing from a config file which predates it
+	defaultComplianceTrendRetentionDays = 365
+)
 
 type PantherConfig struct {
 	Infra      Infra      `yaml:"Infra"`
@@ -50,16 +55,17 @@ type Monitoring struct {
 }
 
 type Setup struct {
-	Company               Company          `yaml:"Company"`
-	FirstUser             FirstUser        `yaml:"FirstUser"`
-	OnboardSelf           bool             `yaml:"OnboardSelf"`
-	EnableS3AccessLogs    bool             `yaml:"EnableS3AccessLogs"`
-	EnableCloudTrail      bool             `yaml:"EnableCloudTrail"`
-	EnableGuardDuty       bool             `yaml:"EnableGuardDuty"`
-	S3AccessLogsBucket    string           `yaml:"S3AccessLogsBucket"`
-	DataReplicationBucket string           `yaml:"DataReplicationBucket"`
-	InitialAnalysisSets   []string         `yaml:"InitialAnalysisSets"`
-	LogSubscriptions      LogSubscriptions `yaml:"LogSubscriptions"`
+	Company                      Company          `yaml:"Company"`
+	FirstUser                    FirstUser        `yaml:"FirstUser"`
+	OnboardSelf                  bool             `yaml:"OnboardSelf"`
+	EnableS3AccessLogs           bool             `yaml:"EnableS3AccessLogs"`
+	EnableCloudTrail             bool             `yaml:"EnableCloudTrail"`
+	EnableGuardDuty              bool             `yaml:"EnableGuardDuty"`
+	S3AccessLogsBucket           string           `yaml:"S3AccessLogsBucket"`
+	DataReplicationBucket        string           `yaml:"DataReplicationBucket"`
+	InitialAnalysisSets          []string         `yaml:"InitialAnalysisSets"`
+	LogSubscriptions             LogSubscriptions `yaml:"LogSubscriptions"`
+	ComplianceTrendRetentionDays int              `yaml:"ComplianceTrendRetentionDays"`
 }
 
 type Company struct {
@@ -94,5 +100,8 @@ func Settings() (*PantherConfig, error) {
 		return nil, err
 	}
 
+	if settings.Setup.ComplianceTrendRetentionDays == 0 {
+		settings.Setup.ComplianceTrendRetentionDays = defaultComplianceTrendRetentionDays
+	}
 	return &settings, nil
 }
@@ -424,20 +424,21 @@ func deployAppsyncStack(outputs map[string]string) error {
 
 func deployCloudSecurityStack(settings *config.PantherConfig, outputs map[string]string) error {
 	_, err := deployTemplate(cfnstacks.CloudsecTemplate, outputs["SourceBucket"], cfnstacks.Cloudsec, map[string]string{
-		"AlarmTopicArn":              outputs["AlarmTopicArn"],
-		"AnalysisApiId":              outputs["AnalysisApiId"],
-		"CloudWatchLogRetentionDays": strconv.Itoa(settings.Monitoring.CloudWatchLogRetentionDays),
-		"ComplianceApiId":            outputs["ComplianceApiId"],
-		"CustomResourceVersion":      customResourceVersion(),
-		"Debug":                      strconv.FormatBool(settings.Monitoring.Debug),
-		"LayerVersionArns":           settings.Infra.BaseLayerVersionArns,
-		"ProcessedDataBucket":        outputs["ProcessedDataBucket"],
-		"ProcessedDataTopicArn":      outputs["ProcessedDataTopicArn"],
-		"PythonLayerVersionArn":      outputs["PythonLayerVersionArn"],
-		"RemediationApiId":           outputs["RemediationApiId"],
-		"ResourcesApiId":             outputs["ResourcesApiId"],
-		"SqsKeyId":                   outputs["QueueEncryptionKeyId"],
-		"TracingMode":                settings.Monitoring.TracingMode,
+		"AlarmTopicArn":                outputs["AlarmTopicArn"],
+		"AnalysisApiId":                outputs["AnalysisApiId"],
+		"CloudWatchLogRetentionDays":   strconv.Itoa(settings.Monitoring.CloudWatchLogRetentionDays),
+		"ComplianceApiId":              outputs["ComplianceApiId"],
+		"ComplianceTrendRetentionDays": strconv.Itoa(settings.Setup.ComplianceTrendRetentionDays),
+		"CustomResourceVersion":        customResourceVersion(),
+		"Debug":                        strconv.FormatBool(settings.Monitoring.Debug),
+		"LayerVersionArns":             settings.Infra.BaseLayerVersionArns,
+		"ProcessedDataBucket":          outputs["ProcessedDataBucket"],
+		"ProcessedDataTopicArn":        outputs["ProcessedDataTopicArn"],
+		"PythonLayerVersionArn":        outputs["PythonLayerVersionArn"],
+		"RemediationApiId":             outputs["RemediationApiId"],
+		"ResourcesApiId":               outputs["ResourcesApiId"],
+		"SqsKeyId":                     outputs["QueueEncryptionKeyId"],
+		"TracingMode":                  settings.Monitoring.TracingMode,
 	})
 	return err
 }
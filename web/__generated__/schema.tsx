@@ -506,12 +506,14 @@ export type ListResourcesInput = {
   sortDir?: Maybe<SortDirEnum>;
   pageSize?: Maybe<Scalars['Int']>;
   page?: Maybe<Scalars['Int']>;
+  pageToken?: Maybe<Scalars['String']>;
 };
 
 export type ListResourcesResponse = {
   __typename?: 'ListResourcesResponse';
   paging?: Maybe<PagingData>;
   resources?: Maybe<Array<Maybe<ResourceSummary>>>;
+  nextPageToken?: Maybe<Scalars['String']>;
 };
 
 export enum ListResourcesSortFieldsEnum {
@@ -1956,6 +1958,7 @@ export type ListResourcesResponseResolvers<
     ParentType,
     ContextType
   >;
+  nextPageToken?: Resolver<Maybe<ResolversTypes['String']>, ParentType, ContextType>;
   __isTypeOf?: IsTypeOfResolverFn<ParentType>;
 };
 
@@ -780,6 +780,7 @@ export const buildListResourcesInput = (
     sortDir: 'sortDir' in overrides ? overrides.sortDir : SortDirEnum.Descending,
     pageSize: 'pageSize' in overrides ? overrides.pageSize : 228,
     page: 'page' in overrides ? overrides.page : 643,
+    pageToken: 'pageToken' in overrides ? overrides.pageToken : 'Gorgeous',
   };
 };
 
@@ -790,6 +791,7 @@ export const buildListResourcesResponse = (
     __typename: 'ListResourcesResponse',
     paging: 'paging' in overrides ? overrides.paging : buildPagingData(),
     resources: 'resources' in overrides ? overrides.resources : [buildResourceSummary()],
+    nextPageToken: 'nextPageToken' in overrides ? overrides.nextPageToken : 'Baby',
   };
 };
 
@@ -66,7 +66,9 @@ export const DEFAULT_DEDUP_FUNCTION =
 
 export const RESOURCE_TYPES = [
   'AWS.ACM.Certificate',
+  'AWS.APIGateway.Stage',
   'AWS.CloudFormation.Stack',
+  'AWS.CloudFront.Distribution',
   'AWS.CloudTrail',
   'AWS.CloudTrail.Meta',
   'AWS.CloudWatch.LogGroup',
@@ -79,7 +81,11 @@ export const RESOURCE_TYPES = [
   'AWS.EC2.SecurityGroup',
   'AWS.EC2.Volume',
   'AWS.EC2.VPC',
+  'AWS.ECR.Repository',
   'AWS.ECS.Cluster',
+  'AWS.EFS.FileSystem',
+  'AWS.EKS.Cluster',
+  'AWS.ElastiCache.Cluster',
   'AWS.ELBV2.ApplicationLoadBalancer',
   'AWS.GuardDuty.Detector',
   'AWS.IAM.Group',
@@ -89,12 +95,25 @@ export const RESOURCE_TYPES = [
   'AWS.IAM.User',
   'AWS.KMS.Key',
   'AWS.Lambda.Function',
+  'AWS.Organizations.ServiceControlPolicy',
   'AWS.PasswordPolicy',
   'AWS.RDS.Instance',
   'AWS.Redshift.Cluster',
+  'AWS.Route53.HostedZone',
   'AWS.S3.Bucket',
+  'AWS.SecretsManager.Secret',
+  'AWS.SNS.Topic',
+  'AWS.SQS.Queue',
+  'AWS.SSM.Parameter',
   'AWS.WAF.Regional.WebACL',
   'AWS.WAF.WebACL',
+  'GCP.Compute.Firewall',
+  'GCP.Compute.Instance',
+  'GCP.IAM.ServiceAccount',
+  'GCP.KMS.CryptoKey',
+  'GCP.Project',
+  'GCP.SQL.Instance',
+  'GCP.Storage.Bucket',
 ] as const;
 
 export const LOG_TYPES = [
@@ -19,14 +19,16 @@
 import React from 'react';
 import { Alert, Box, Card } from 'pouncejs';
 import { ListResourcesInput, ListResourcesSortFieldsEnum, SortDirEnum } from 'Generated/schema';
-import { TableControlsPagination } from 'Components/utils/TableControls';
+import { DEFAULT_LARGE_PAGE_SIZE } from 'Source/constants';
 import {
   convertObjArrayValuesToCsv,
   encodeParams,
   extendResourceWithIntegrationLabel,
   extractErrorMessage,
 } from 'Helpers/utils';
-import useRequestParamsWithPagination from 'Hooks/useRequestParamsWithPagination';
+import useInfiniteScroll from 'Hooks/useInfiniteScroll';
+import useRequestParamsWithoutPagination from 'Hooks/useRequestParamsWithoutPagination';
+import TablePlaceholder from 'Components/TablePlaceholder';
 import isEmpty from 'lodash/isEmpty';
 import withSEO from 'Hoc/withSEO';
 import ErrorBoundary from 'Components/ErrorBoundary';
@@ -37,18 +39,54 @@ import ListResourcesPageSkeleton from './Skeleton';
 import { useListResources } from './graphql/listResources.generated';
 
 const ListResources = () => {
-  const {
-    requestParams,
-    updateRequestParamsAndResetPaging,
-    updatePagingParams,
-  } = useRequestParamsWithPagination<ListResourcesInput>();
+  const { requestParams, updateRequestParams } = useRequestParamsWithoutPagination<
+    ListResourcesInput
+  >();
 
-  const { loading, data, error } = useListResources({
+  // Resources are always sorted by their id, so that they can be fetched page-by-page with a token.
+  // Any `sortBy` or `page` left over in older URLs is dropped, since it would force offset paging.
+  const { sortBy, page, ...filterParams } = requestParams;
+  const input = {
+    ...encodeParams(convertObjArrayValuesToCsv(filterParams), ['idContains']),
+    pageSize: DEFAULT_LARGE_PAGE_SIZE,
+  };
+
+  const { loading, data, error, fetchMore } = useListResources({
     fetchPolicy: 'cache-and-network',
-    variables: {
-      input: encodeParams(convertObjArrayValuesToCsv(requestParams), ['idContains']),
+    variables: { input },
+  });
+
+  const nextPageToken = data?.resources.nextPageToken || null;
+  const hasNextPage = !!nextPageToken;
+
+  const { sentinelRef } = useInfiniteScroll<HTMLDivElement>({
+    loading,
+    threshold: 500,
+    onLoadMore: () => {
+      fetchMore({
+        variables: {
+          input: { ...input, pageToken: nextPageToken },
+        },
+        updateQuery: (previousResult, { fetchMoreResult }) => {
+          // PreviousResults could contain cached records with the same ids as the incoming ones.
+          // Therefore, we must merge them and not just concatenate.
+          const oldResourceIds = new Set(previousResult.resources.resources.map(({ id }) => id));
+
+          return {
+            ...fetchMoreResult,
+            resources: {
+              ...fetchMoreResult.resources,
+              resources: [
+                ...previousResult.resources.resources,
+                ...fetchMoreResult.resources.resources.filter(({ id }) => !oldResourceIds.has(id)),
+              ],
+            },
+          };
+        },
+      });
     },
   });
+
   if (loading && !data) {
     return <ListResourcesPageSkeleton />;
   }
@@ -70,7 +108,6 @@ const ListResources = () => {
 
   const resourceItems = data.resources.resources;
   const integrationItems = data.listComplianceIntegrations;
-  const pagingData = data.resources.paging;
 
   if (!resourceItems.length && isEmpty(requestParams)) {
     return <ListResourcesPageEmptyDataFallback />;
@@ -88,19 +125,17 @@ const ListResources = () => {
         <Card as="section" px={8} py={4} position="relative">
           <ListResourcesTable
             items={enhancedResourceItems}
-            onSort={updateRequestParamsAndResetPaging}
-            sortBy={requestParams.sortBy || ListResourcesSortFieldsEnum.Id}
+            onSort={updateRequestParams}
+            sortBy={ListResourcesSortFieldsEnum.Id}
             sortDir={requestParams.sortDir || SortDirEnum.Ascending}
           />
+          {hasNextPage && (
+            <Box py={8} ref={sentinelRef}>
+              <TablePlaceholder rowCount={10} />
+            </Box>
+          )}
         </Card>
       </ErrorBoundary>
-      <Box my={6}>
-        <TableControlsPagination
-          page={pagingData.thisPage}
-          totalPages={pagingData.totalPages}
-          onPageChange={updatePagingParams}
-        />
-      </Box>
     </React.Fragment>
   );
 };
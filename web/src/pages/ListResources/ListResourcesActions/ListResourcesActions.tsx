@@ -27,7 +27,7 @@ import FormikCombobox from 'Components/fields/ComboBox';
 import FormikMultiCombobox from 'Components/fields/MultiComboBox';
 import ErrorBoundary from 'Components/ErrorBoundary';
 import pick from 'lodash/pick';
-import useRequestParamsWithPagination from 'Hooks/useRequestParamsWithPagination';
+import useRequestParamsWithoutPagination from 'Hooks/useRequestParamsWithoutPagination';
 import isEmpty from 'lodash/isEmpty';
 import Breadcrumbs from 'Components/Breadcrumbs';
 import { useListAccountIds } from './graphql/listAccountIds.generated';
@@ -92,7 +92,7 @@ interface ListResourcesFiltersProps {
 
 const ListResourcesActions: React.FC = () => {
   const [areFiltersVisible, setFiltersVisibility] = React.useState(false);
-  const { requestParams, updateRequestParamsAndResetPaging } = useRequestParamsWithPagination<
+  const { requestParams, updateRequestParams } = useRequestParamsWithoutPagination<
     ListResourcesInput
   >();
 
@@ -108,7 +108,7 @@ const ListResourcesActions: React.FC = () => {
   // get the value it should expect
   const handleFiltersSubmit = React.useCallback(
     ({ integrationId: integrationObj, ...values }: MutatedListResourcesFiltersValues) => {
-      updateRequestParamsAndResetPaging({
+      updateRequestParams({
         ...values,
         integrationId: integrationObj ? integrationObj.integrationId : null,
       });
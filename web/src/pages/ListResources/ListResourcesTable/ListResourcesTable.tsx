@@ -46,11 +46,10 @@ const ListResourcesTable: React.FC<ListResourcesTableProps> = ({
   const handleSort = (selectedKey: ListResourcesSortFieldsEnum) => {
     if (sortBy === selectedKey) {
       onSort({
-        sortBy,
         sortDir: sortDir === SortDirEnum.Ascending ? SortDirEnum.Descending : SortDirEnum.Ascending,
       });
     } else {
-      onSort({ sortBy: selectedKey, sortDir: SortDirEnum.Ascending });
+      onSort({ sortDir: SortDirEnum.Ascending });
     }
   };
 
@@ -64,27 +63,10 @@ const ListResourcesTable: React.FC<ListResourcesTableProps> = ({
           >
             Resource
           </Table.SortableHeaderCell>
-          <Table.SortableHeaderCell
-            onClick={() => handleSort(ListResourcesSortFieldsEnum.Type)}
-            sortDir={sortBy === ListResourcesSortFieldsEnum.Type ? sortDir : false}
-          >
-            Type
-          </Table.SortableHeaderCell>
+          <Table.HeaderCell>Type</Table.HeaderCell>
           <Table.HeaderCell>Source</Table.HeaderCell>
-          <Table.SortableHeaderCell
-            align="center"
-            onClick={() => handleSort(ListResourcesSortFieldsEnum.ComplianceStatus)}
-            sortDir={sortBy === ListResourcesSortFieldsEnum.ComplianceStatus ? sortDir : false}
-          >
-            Status
-          </Table.SortableHeaderCell>
-          <Table.SortableHeaderCell
-            onClick={() => handleSort(ListResourcesSortFieldsEnum.LastModified)}
-            sortDir={sortBy === ListResourcesSortFieldsEnum.LastModified ? sortDir : false}
-            align="right"
-          >
-            Last Modified
-          </Table.SortableHeaderCell>
+          <Table.HeaderCell align="center">Status</Table.HeaderCell>
+          <Table.HeaderCell align="right">Last Modified</Table.HeaderCell>
         </Table.Row>
       </Table.Head>
       <Table.Body>
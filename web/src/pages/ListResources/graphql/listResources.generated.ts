@@ -27,19 +27,20 @@ export type ListResourcesVariables = {
 };
 
 export type ListResources = {
-  resources?: Types.Maybe<{
-    resources?: Types.Maybe<
-      Array<
-        Types.Maybe<
-          Pick<
-            Types.ResourceSummary,
-            'lastModified' | 'type' | 'integrationId' | 'complianceStatus' | 'id'
+  resources?: Types.Maybe<
+    Pick<Types.ListResourcesResponse, 'nextPageToken'> & {
+      resources?: Types.Maybe<
+        Array<
+          Types.Maybe<
+            Pick<
+              Types.ResourceSummary,
+              'lastModified' | 'type' | 'integrationId' | 'complianceStatus' | 'id'
+            >
           >
         >
-      >
-    >;
-    paging?: Types.Maybe<Pick<Types.PagingData, 'totalPages' | 'thisPage' | 'totalItems'>>;
-  }>;
+      >;
+    }
+  >;
   listComplianceIntegrations: Array<
     Pick<Types.ComplianceIntegration, 'integrationLabel' | 'integrationId'>
   >;
@@ -55,11 +56,7 @@ export const ListResourcesDocument = gql`
         complianceStatus
         id
       }
-      paging {
-        totalPages
-        thisPage
-        totalItems
-      }
+      nextPageToken
     }
     listComplianceIntegrations {
       integrationLabel